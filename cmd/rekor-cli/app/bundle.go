@@ -0,0 +1,115 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/bundle"
+	genclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// writeBundle fetches the entry with its inclusion proof and the latest checkpoint of the log, and writes
+// them to path as a bundle that can be verified offline
+func writeBundle(ctx context.Context, rekorClient *genclient.Rekor, uuid, path string) error {
+	entryParams := entries.NewGetLogEntryByUUIDParamsWithContext(ctx)
+	entryParams.SetTimeout(viper.GetDuration("timeout"))
+	entryParams.EntryUUID = uuid
+	entryResp, err := rekorClient.Entries.GetLogEntryByUUID(entryParams)
+	if err != nil {
+		return err
+	}
+	entry, ok := entryResp.Payload[uuid]
+	if !ok {
+		return fmt.Errorf("entry %s not returned by the log", uuid)
+	}
+	if entry.Verification == nil || entry.Verification.InclusionProof == nil {
+		return errors.New("entry returned without an inclusion proof")
+	}
+
+	// the checkpoint is fetched after the inclusion proof, so its tree is at least as large
+	infoParams := tlog.NewGetLogInfoParamsWithContext(ctx)
+	infoParams.SetTimeout(viper.GetDuration("timeout"))
+	info, err := rekorClient.Tlog.GetLogInfo(infoParams)
+	if err != nil {
+		return err
+	}
+	proofSize := swag.Int64Value(entry.Verification.InclusionProof.TreeSize)
+	treeSize := swag.Int64Value(info.Payload.TreeSize)
+	var consistency []string
+	if treeSize > proofSize {
+		proofParams := tlog.NewGetLogProofParamsWithContext(ctx)
+		proofParams.SetTimeout(viper.GetDuration("timeout"))
+		proofParams.FirstSize = &proofSize
+		proofParams.LastSize = treeSize
+		proof, err := rekorClient.Tlog.GetLogProof(proofParams)
+		if err != nil {
+			return err
+		}
+		consistency = proof.Payload.Hashes
+	}
+
+	b, err := bundle.New(uuid, entry, swag.StringValue(info.Payload.SignedTreeHead), consistency)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func readBundle(path string) (*bundle.Bundle, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var b bundle.Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing bundle: %w", err)
+	}
+	return &b, nil
+}
+
+// pinnedLogKeys returns the keys of the log given with --rekor-public-key, which may be a bundle of the
+// current and previous keys as returned by /api/v1/log/publicKey?history=true, or else the
+// rekor_server_public_key set in the config file
+func pinnedLogKeys() ([]util.LogKey, error) {
+	var data []byte
+	if path := viper.GetString("rekor-public-key"); path != "" {
+		var err error
+		if data, err = ioutil.ReadFile(filepath.Clean(path)); err != nil {
+			return nil, err
+		}
+	} else if key := viper.GetString("rekor_server_public_key"); key != "" {
+		data = []byte(key)
+	} else {
+		return nil, errors.New("the public key of the log must be pinned with --rekor-public-key to verify offline")
+	}
+	return util.ParseLogKeys(data)
+}
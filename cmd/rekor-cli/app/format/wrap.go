@@ -16,9 +16,11 @@
 package format
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/ghodss/yaml"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -26,30 +28,52 @@ import (
 
 type CobraCmd func(cmd *cobra.Command, args []string)
 
-type formatCmd func(args []string) (interface{}, error)
+type formatCmd func(ctx context.Context, args []string) (interface{}, error)
 
+// WrapCmd prints the object returned by f in the format selected with --format. Commands return structs with
+// explicit JSON tags, which are the field names scripts rely on in the json and yaml output. f is passed the
+// context of cmd, which is canceled when the command is interrupted.
 func WrapCmd(f formatCmd) CobraCmd {
 	return func(cmd *cobra.Command, args []string) {
-		obj, err := f(args)
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		obj, err := f(ctx, args)
 		if err != nil {
 			log.CliLogger.Fatal(err)
 		}
+		Print(obj)
+	}
+}
 
-		// TODO: add flags to control output formatting (JSON, plaintext, etc.)
-		format := viper.GetString("format")
-		switch format {
-		case "default":
-			if s, ok := obj.(fmt.Stringer); ok {
-				fmt.Print(s.String())
-			} else {
-				fmt.Println(toJSON(s))
-			}
-		case "json":
+// Print writes obj to stdout in the format selected with --format, for commands that output more than
+// one object as they run. JSON objects are written one per line and YAML objects as separate documents, so
+// that the output can be parsed as a stream.
+func Print(obj interface{}) {
+	switch viper.GetString("format") {
+	case "json":
+		fmt.Println(toJSON(obj))
+	case "yaml":
+		fmt.Printf("---\n%s", toYAML(obj))
+	default:
+		if s, ok := obj.(fmt.Stringer); ok {
+			fmt.Print(s.String())
+		} else {
 			fmt.Println(toJSON(obj))
 		}
 	}
 }
 
+// IsText returns whether the output is meant to be read by people rather than parsed
+func IsText() bool {
+	switch viper.GetString("format") {
+	case "json", "yaml":
+		return false
+	}
+	return true
+}
+
 func toJSON(i interface{}) string {
 	b, err := json.Marshal(i)
 	if err != nil {
@@ -57,3 +81,12 @@ func toJSON(i interface{}) string {
 	}
 	return string(b)
 }
+
+// toYAML converts i to JSON first, so that the field names are the same in both formats
+func toYAML(i interface{}) string {
+	b, err := yaml.Marshal(i)
+	if err != nil {
+		log.CliLogger.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,77 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+type testOutput struct {
+	UUID  string   `json:"UUID"`
+	Index int64    `json:"Index"`
+	Extra []string `json:"Extra,omitempty"`
+}
+
+func (t *testOutput) String() string {
+	return "Entry " + t.UUID + "\n"
+}
+
+// capture returns what f writes to stdout
+func capture(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	f()
+	os.Stdout = stdout
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestPrint(t *testing.T) {
+	obj := &testOutput{UUID: "1234", Index: 5}
+	tests := []struct {
+		format string
+		obj    interface{}
+		want   string
+	}{
+		{format: "text", obj: obj, want: "Entry 1234\n"},
+		{format: "default", obj: obj, want: "Entry 1234\n"},
+		{format: "json", obj: obj, want: `{"UUID":"1234","Index":5}` + "\n"},
+		{format: "yaml", obj: obj, want: "---\nIndex: 5\nUUID: \"1234\"\n"},
+		{format: "text", obj: map[string]int{"a": 1}, want: `{"a":1}` + "\n"},
+	}
+	for _, tc := range tests {
+		viper.Set("format", tc.format)
+		if got := capture(t, func() { Print(tc.obj) }); got != tc.want {
+			t.Errorf("%s: Print() wrote %q, want %q", tc.format, got, tc.want)
+		}
+	}
+	viper.Reset()
+}
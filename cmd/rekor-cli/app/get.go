@@ -22,7 +22,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/runtime"
@@ -38,13 +40,13 @@ import (
 )
 
 type getCmdOutput struct {
-	Attestation     string
-	AttestationType string
-	Body            interface{}
-	LogIndex        int
-	IntegratedTime  int64
-	UUID            string
-	LogID           string
+	Attestation     string      `json:"Attestation"`
+	AttestationType string      `json:"AttestationType"`
+	Body            interface{} `json:"Body"`
+	LogIndex        int         `json:"LogIndex"`
+	IntegratedTime  int64       `json:"IntegratedTime"`
+	UUID            string      `json:"UUID"`
+	LogID           string      `json:"LogID"`
 }
 
 func (g *getCmdOutput) String() string {
@@ -66,6 +68,16 @@ func (g *getCmdOutput) String() string {
 	return s
 }
 
+type getCmdOutputs []*getCmdOutput
+
+func (g getCmdOutputs) String() string {
+	var s []string
+	for _, o := range g {
+		s = append(s, o.String())
+	}
+	return strings.Join(s, "\n")
+}
+
 // getCmd represents the get command
 var getCmd = &cobra.Command{
 	Use:   "get",
@@ -77,7 +89,7 @@ var getCmd = &cobra.Command{
 			log.CliLogger.Fatal("Error initializing cmd line args: ", err)
 		}
 	},
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
 		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
@@ -85,20 +97,26 @@ var getCmd = &cobra.Command{
 
 		logIndex := viper.GetString("log-index")
 		if logIndex != "" {
-			params := entries.NewGetLogEntryByIndexParams()
+			params := entries.NewGetLogEntryByIndexParamsWithContext(ctx)
 			params.SetTimeout(viper.GetDuration("timeout"))
 			logIndexInt, err := strconv.ParseInt(logIndex, 10, 0)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing --log-index: %w", err)
 			}
 			params.LogIndex = logIndexInt
+			if count := viper.GetInt64("count"); count > 1 {
+				params.SetCount(&count)
+			}
 
 			resp, err := rekorClient.Entries.GetLogEntryByIndex(params)
 			if err != nil {
 				return nil, err
 			}
+			if params.Count != nil {
+				return parseEntries(resp.Payload)
+			}
 			for ix, entry := range resp.Payload {
-				if verified, err := verifyLogEntry(context.Background(), rekorClient, entry); err != nil || !verified {
+				if verified, err := verifyLogEntry(ctx, rekorClient, entry); err != nil || !verified {
 					return nil, fmt.Errorf("unable to verify entry was added to log %w", err)
 				}
 
@@ -108,7 +126,7 @@ var getCmd = &cobra.Command{
 
 		uuid := viper.GetString("uuid")
 		if uuid != "" {
-			params := entries.NewGetLogEntryByUUIDParams()
+			params := entries.NewGetLogEntryByUUIDParamsWithContext(ctx)
 			params.SetTimeout(viper.GetDuration("timeout"))
 			params.EntryUUID = uuid
 
@@ -122,7 +140,7 @@ var getCmd = &cobra.Command{
 					continue
 				}
 
-				if verified, err := verifyLogEntry(context.Background(), rekorClient, entry); err != nil || !verified {
+				if verified, err := verifyLogEntry(ctx, rekorClient, entry); err != nil || !verified {
 					return nil, fmt.Errorf("unable to verify entry was added to log %w", err)
 				}
 
@@ -150,18 +168,35 @@ func parseEntry(uuid string, e models.LogEntryAnon) (interface{}, error) {
 	}
 
 	obj := getCmdOutput{
-		Attestation:     string(e.Attestation.Data),
-		AttestationType: e.Attestation.MediaType,
-		Body:            eimpl,
-		UUID:            uuid,
-		IntegratedTime:  *e.IntegratedTime,
-		LogIndex:        int(*e.LogIndex),
-		LogID:           *e.LogID,
+		Body:           eimpl,
+		UUID:           uuid,
+		IntegratedTime: *e.IntegratedTime,
+		LogIndex:       int(*e.LogIndex),
+		LogID:          *e.LogID,
+	}
+	if e.Attestation != nil {
+		obj.Attestation = string(e.Attestation.Data)
+		obj.AttestationType = e.Attestation.MediaType
 	}
 
 	return &obj, nil
 }
 
+// parseEntries returns the entries of a range in index order. The server returns no inclusion proofs for
+// ranges, so they are not verified.
+func parseEntries(logEntry models.LogEntry) (interface{}, error) {
+	var out getCmdOutputs
+	for uuid, entry := range logEntry {
+		obj, err := parseEntry(uuid, entry)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, obj.(*getCmdOutput))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LogIndex < out[j].LogIndex })
+	return out, nil
+}
+
 func init() {
 	initializePFlagMap()
 	if err := addUUIDPFlags(getCmd, false); err != nil {
@@ -170,6 +205,7 @@ func init() {
 	if err := addLogIndexFlag(getCmd, false); err != nil {
 		log.CliLogger.Fatal("Error parsing cmd line args: ", err)
 	}
+	getCmd.Flags().Int64("count", 1, "the number of consecutive entries to get from --log-index, up to 256; entries in a range are not verified")
 
 	rootCmd.AddCommand(getCmd)
 }
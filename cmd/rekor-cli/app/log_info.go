@@ -17,6 +17,7 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/x509"
 	"encoding/hex"
@@ -40,9 +41,9 @@ import (
 )
 
 type logInfoCmdOutput struct {
-	TreeSize       int64
-	RootHash       string
-	TimestampNanos uint64
+	TreeSize       int64  `json:"TreeSize"`
+	RootHash       string `json:"RootHash"`
+	TimestampNanos uint64 `json:"TimestampNanos"`
 }
 
 func (l *logInfoCmdOutput) String() string {
@@ -60,16 +61,16 @@ var logInfoCmd = &cobra.Command{
 	Use:   "loginfo",
 	Short: "Rekor loginfo command",
 	Long:  `Prints info about the transparency log`,
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
 		serverURL := viper.GetString("rekor_server")
 		rekorClient, err := client.GetRekorClient(serverURL)
 		if err != nil {
 			return nil, err
 		}
 
-		params := tlog.GetLogInfoParams{}
+		params := tlog.NewGetLogInfoParamsWithContext(ctx)
 		params.SetTimeout(viper.GetDuration("timeout"))
-		result, err := rekorClient.Tlog.GetLogInfo(&params)
+		result, err := rekorClient.Tlog.GetLogInfo(params)
 		if err != nil {
 			return nil, err
 		}
@@ -121,7 +122,8 @@ var logInfoCmd = &cobra.Command{
 			persistedSize := oldState.Size
 			if persistedSize < sth.Size {
 				log.CliLogger.Infof("Found previous log state, proving consistency between %d and %d", oldState.Size, sth.Size)
-				params := tlog.NewGetLogProofParams()
+				params := tlog.NewGetLogProofParamsWithContext(ctx)
+				params.SetTimeout(viper.GetDuration("timeout"))
 				firstSize := int64(persistedSize)
 				params.FirstSize = &firstSize
 				params.LastSize = int64(sth.Size)
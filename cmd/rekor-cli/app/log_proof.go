@@ -16,6 +16,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -29,8 +30,8 @@ import (
 )
 
 type logProofOutput struct {
-	RootHash string
-	Hashes   []string
+	RootHash string   `json:"RootHash"`
+	Hashes   []string `json:"Hashes"`
 }
 
 func (l *logProofOutput) String() string {
@@ -71,7 +72,7 @@ var logProofCmd = &cobra.Command{
 		}
 		return nil
 	},
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
 		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
@@ -80,7 +81,7 @@ var logProofCmd = &cobra.Command{
 		firstSize := int64(viper.GetUint64("first-size"))
 		lastSize := int64(viper.GetUint64("last-size"))
 
-		params := tlog.NewGetLogProofParams()
+		params := tlog.NewGetLogProofParamsWithContext(ctx)
 		params.FirstSize = &firstSize
 		params.LastSize = lastSize
 		params.SetTimeout(viper.GetDuration("timeout"))
@@ -0,0 +1,197 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	genclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// manifestEntry is an entry to upload, described by the same fields as the flags of the upload command
+type manifestEntry struct {
+	// Name identifies the entry in the report, and defaults to the path of the artifact or entry
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Artifact     string `json:"artifact"`
+	ArtifactHash string `json:"artifact-hash"`
+	Signature    string `json:"signature"`
+	PublicKey    string `json:"public-key"`
+	PKIFormat    string `json:"pki-format"`
+	Entry        string `json:"entry"`
+}
+
+type manifestEntryResult struct {
+	Name string `json:"Name"`
+	*uploadCmdOutput
+	Error string `json:"Error,omitempty"`
+}
+
+type uploadManifestOutput struct {
+	Entries        []manifestEntryResult `json:"Entries"`
+	Created        int                   `json:"Created"`
+	AlreadyExisted int                   `json:"AlreadyExisted"`
+	Failed         int                   `json:"Failed"`
+}
+
+func (u *uploadManifestOutput) String() string {
+	var s string
+	for _, e := range u.Entries {
+		if e.Error != "" {
+			s += fmt.Sprintf("%v: upload failed: %v\n", e.Name, e.Error)
+		} else {
+			s += fmt.Sprintf("%v: %v", e.Name, e.uploadCmdOutput)
+		}
+	}
+	return s + fmt.Sprintf("Uploaded %d entries: %d created, %d already existed, %d failed\n",
+		len(u.Entries), u.Created, u.AlreadyExisted, u.Failed)
+}
+
+func validateManifestPFlags() error {
+	for _, flag := range []string{"entry", "artifact", "artifact-hash", "signature", "public-key", "bundle"} {
+		if viper.GetString(flag) != "" {
+			return fmt.Errorf("--%s cannot be used with --manifest", flag)
+		}
+	}
+	if viper.GetUint("workers") == 0 {
+		return errors.New("--workers must be at least 1")
+	}
+	return nil
+}
+
+// readManifest parses the manifest at path, resolving the relative paths in it against its directory. Entries
+// without a type or PKI format take those of the --type and --pki-format flags.
+func readManifest(path string) ([]manifestEntry, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var manifest []manifestEntry
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if len(manifest) == 0 {
+		return nil, errors.New("manifest lists no entries")
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(s string) string {
+		if s == "" || isURL(s) || filepath.IsAbs(s) {
+			return s
+		}
+		return filepath.Join(dir, s)
+	}
+	for i := range manifest {
+		e := &manifest[i]
+		if (e.Entry == "") == (e.Artifact == "") {
+			return nil, fmt.Errorf("manifest entry %d: exactly one of 'entry' or 'artifact' must be specified", i)
+		}
+		e.Entry, e.Artifact = resolve(e.Entry), resolve(e.Artifact)
+		e.Signature, e.PublicKey = resolve(e.Signature), resolve(e.PublicKey)
+		if e.Name == "" {
+			e.Name = e.Entry + e.Artifact
+		}
+		if e.Type == "" {
+			e.Type = viper.GetString("type")
+		}
+		if e.PKIFormat == "" {
+			e.PKIFormat = viper.GetString("pki-format")
+		}
+	}
+	return manifest, nil
+}
+
+// proposedEntry creates the entry to upload, as the upload command does from its flags
+func (e *manifestEntry) proposedEntry(ctx context.Context) (models.ProposedEntry, error) {
+	if e.Entry != "" {
+		return loadProposedEntry(e.Entry)
+	}
+	typeStr, versionStr, err := ParseTypeFlag(e.Type)
+	if err != nil {
+		return nil, err
+	}
+	props := types.ArtifactProperties{
+		ArtifactPath:  fileOrURL(e.Artifact),
+		ArtifactHash:  e.ArtifactHash,
+		SignaturePath: fileOrURL(e.Signature),
+		PublicKeyPath: fileOrURL(e.PublicKey),
+		PKIFormat:     e.PKIFormat,
+	}
+	return types.NewProposedEntry(ctx, typeStr, versionStr, props)
+}
+
+// uploadManifest uploads the entries listed in the manifest at path with --workers concurrent uploads. The
+// report is output even if some of the uploads failed, before the command exits with an error.
+func uploadManifest(ctx context.Context, rekorClient *genclient.Rekor, path string) (interface{}, error) {
+	manifest, err := readManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &uploadManifestOutput{Entries: make([]manifestEntryResult, len(manifest))}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := uint(0); w < viper.GetUint("workers"); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				e := manifest[i]
+				result := manifestEntryResult{Name: e.Name}
+				entry, err := e.proposedEntry(ctx)
+				if err == nil {
+					result.uploadCmdOutput, err = uploadEntry(ctx, rekorClient, entry)
+				}
+				if err != nil {
+					result.Error = err.Error()
+				}
+				out.Entries[i] = result
+			}
+		}()
+	}
+	for i := range manifest {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, e := range out.Entries {
+		switch {
+		case e.Error != "":
+			out.Failed++
+		case e.AlreadyExists:
+			out.AlreadyExisted++
+		default:
+			out.Created++
+		}
+	}
+	if out.Failed > 0 {
+		format.Print(out)
+		return nil, fmt.Errorf("%d of %d entries failed to upload", out.Failed, len(out.Entries))
+	}
+	return out, nil
+}
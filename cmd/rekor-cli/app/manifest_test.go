@@ -0,0 +1,115 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestReadManifest(t *testing.T) {
+	viper.Set("type", "rekord")
+	viper.Set("pki-format", "pgp")
+	defer viper.Reset()
+
+	tests := []struct {
+		caseDesc string
+		manifest string
+		want     []manifestEntry
+		wantErr  bool
+	}{
+		{
+			caseDesc: "YAML",
+			manifest: `
+- artifact: dist/release.tar.gz
+  signature: dist/release.tar.gz.sig
+  public-key: /etc/release.pub
+- name: sbom
+  type: intoto
+  entry: https://example.com/sbom.entry.json
+`,
+			want: []manifestEntry{
+				{
+					Name:      "DIR/dist/release.tar.gz",
+					Type:      "rekord",
+					Artifact:  "DIR/dist/release.tar.gz",
+					Signature: "DIR/dist/release.tar.gz.sig",
+					PublicKey: "/etc/release.pub",
+					PKIFormat: "pgp",
+				},
+				{
+					Name:      "sbom",
+					Type:      "intoto",
+					Entry:     "https://example.com/sbom.entry.json",
+					PKIFormat: "pgp",
+				},
+			},
+		},
+		{
+			caseDesc: "JSON",
+			manifest: `[{"artifact": "a.txt", "pki-format": "x509"}]`,
+			want:     []manifestEntry{{Name: "DIR/a.txt", Type: "rekord", Artifact: "DIR/a.txt", PKIFormat: "x509"}},
+		},
+		{
+			caseDesc: "entry and artifact",
+			manifest: `[{"artifact": "a.txt", "entry": "a.json"}]`,
+			wantErr:  true,
+		},
+		{
+			caseDesc: "neither entry nor artifact",
+			manifest: `[{"signature": "a.sig"}]`,
+			wantErr:  true,
+		},
+		{
+			caseDesc: "empty",
+			manifest: `[]`,
+			wantErr:  true,
+		},
+		{
+			caseDesc: "not a list",
+			manifest: `artifact: a.txt`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "manifest.yaml")
+		if err := ioutil.WriteFile(path, []byte(tc.manifest), 0600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readManifest(path)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%v: readManifest() error = %v, wantErr %v", tc.caseDesc, err, tc.wantErr)
+			continue
+		}
+		for i := range tc.want {
+			for _, s := range []*string{&tc.want[i].Name, &tc.want[i].Artifact, &tc.want[i].Signature} {
+				if strings.HasPrefix(*s, "DIR/") {
+					*s = filepath.Join(dir, strings.TrimPrefix(*s, "DIR/"))
+				}
+			}
+		}
+		if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: readManifest() = %+v, want %+v", tc.caseDesc, got, tc.want)
+		}
+	}
+}
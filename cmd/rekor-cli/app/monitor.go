@@ -0,0 +1,430 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/state"
+	"github.com/sigstore/rekor/pkg/client"
+	genclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/identity"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// maxMonitorBatch is the number of entries fetched at once, the most the log returns for a range
+const maxMonitorBatch = 256
+
+type monitorMatch struct {
+	UUID           string `json:"UUID"`
+	LogIndex       int64  `json:"LogIndex"`
+	IntegratedTime int64  `json:"IntegratedTime"`
+	Kind           string `json:"Kind"`
+	// Matched are the --email, --san and --key-fingerprint values that the entry matched
+	Matched []string `json:"Matched,omitempty"`
+}
+
+func (m *monitorMatch) String() string {
+	s := fmt.Sprintf("Entry %d (%s) of kind %s integrated at %s", m.LogIndex, m.UUID, m.Kind,
+		time.Unix(m.IntegratedTime, 0).UTC().Format(time.RFC3339))
+	if len(m.Matched) > 0 {
+		s += fmt.Sprintf(" matches %s", strings.Join(m.Matched, ", "))
+	}
+	return s + "\n"
+}
+
+type monitorCmdOutput struct {
+	TreeSize int64  `json:"TreeSize"`
+	RootHash string `json:"RootHash"`
+	// Entries is the number of entries checked
+	Entries int64 `json:"Entries"`
+	Matches int64 `json:"Matches"`
+}
+
+func (m *monitorCmdOutput) String() string {
+	return fmt.Sprintf("Monitored %d entries up to tree size %d (root hash %s); %d matched\n", m.Entries, m.TreeSize, m.RootHash, m.Matches)
+}
+
+// monitorFilter maps the search index keys of the identities being monitored to the flag values they were given as
+type monitorFilter map[string]string
+
+func newMonitorFilter() monitorFilter {
+	f := monitorFilter{}
+	for _, email := range viper.GetStringSlice("email") {
+		f[identity.Identity{Type: identity.Email, Value: email}.IndexKey()] = email
+	}
+	for _, san := range viper.GetStringSlice("san") {
+		// email SANs are indexed as emails
+		f[identity.Identity{Type: identity.Email, Value: san}.IndexKey()] = san
+		f[identity.Identity{Type: identity.URI, Value: san}.IndexKey()] = san
+	}
+	for _, fp := range viper.GetStringSlice("key-fingerprint") {
+		value := strings.TrimPrefix(strings.ToLower(fp), "sha256:")
+		// the hash of the canonicalized key, as searched for with a public key, or an identity of the key or certificate
+		f[value] = fp
+		f[identity.Identity{Type: identity.Fingerprint, Value: value}.IndexKey()] = fp
+		f[identity.Identity{Type: identity.CertFingerprint, Value: value}.IndexKey()] = fp
+	}
+	return f
+}
+
+// match reports whether an entry with the index keys is monitored, and which identities it matched; every
+// entry is monitored if no identities are
+func (f monitorFilter) match(indexKeys []string) ([]string, bool) {
+	if len(f) == 0 {
+		return nil, true
+	}
+	seen := map[string]bool{}
+	var matched []string
+	for _, key := range indexKeys {
+		if v, ok := f[strings.ToLower(key)]; ok && !seen[v] {
+			seen[v] = true
+			matched = append(matched, v)
+		}
+	}
+	return matched, len(matched) > 0
+}
+
+// monitorCmd represents the monitor command
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Rekor monitor command",
+	Long: `Tails the transparency log, verifying that each checkpoint is consistent with the previous one,
+and prints the new entries, or only those of the identities given with --email, --san and --key-fingerprint.
+
+The last checkpoint processed is stored in ~/.rekor/monitor.json, and monitoring resumes from it. Without a
+stored checkpoint, monitoring starts with the entries added after the current checkpoint, or at --start-index.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			return fmt.Errorf("error initializing cmd line args: %s", err)
+		}
+		return nil
+	},
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
+		serverURL := viper.GetString("rekor_server")
+		rekorClient, err := client.GetRekorClient(serverURL)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := monitorLogKeys(ctx, rekorClient)
+		if err != nil {
+			return nil, err
+		}
+
+		m := &monitor{
+			rekorClient: rekorClient,
+			keys:        keys,
+			filter:      newMonitorFilter(),
+			prev:        state.LoadMonitor(serverURL),
+			out:         &monitorCmdOutput{},
+		}
+		if m.prev == nil {
+			m.start = viper.GetInt64("start-index")
+		}
+		for {
+			if err := m.pass(ctx); err != nil {
+				if ctx.Err() != nil {
+					return m.out, nil
+				}
+				return nil, err
+			}
+			if err := state.DumpMonitor(serverURL, m.prev); err != nil {
+				return nil, fmt.Errorf("storing monitor state: %w", err)
+			}
+			if viper.GetBool("once") {
+				return m.out, nil
+			}
+			select {
+			case <-ctx.Done():
+				return m.out, nil
+			case <-time.After(viper.GetDuration("interval")):
+			}
+		}
+	}),
+}
+
+// monitorLogKeys returns the pinned keys of the log or, if none are pinned, the key the log serves
+func monitorLogKeys(ctx context.Context, rekorClient *genclient.Rekor) ([]util.LogKey, error) {
+	if viper.GetString("rekor-public-key") != "" || viper.GetString("rekor_server_public_key") != "" {
+		return pinnedLogKeys()
+	}
+	log.CliLogger.Infof("No log key pinned with --rekor-public-key, using the key served by the log")
+	pub, err := util.PublicKey(ctx, rekorClient)
+	if err != nil {
+		return nil, err
+	}
+	return []util.LogKey{{PublicKey: pub}}, nil
+}
+
+type monitor struct {
+	rekorClient *genclient.Rekor
+	keys        []util.LogKey
+	filter      monitorFilter
+	// prev is the last checkpoint whose entries have all been checked
+	prev *util.SignedCheckpoint
+	// start is the index of the first entry to check if there is no previous checkpoint; negative to start
+	// at the first checkpoint
+	start int64
+	out   *monitorCmdOutput
+}
+
+// pass fetches the latest checkpoint, verifies that it is consistent with the previous one, and checks the
+// entries added in between
+func (m *monitor) pass(ctx context.Context) error {
+	infoParams := tlog.NewGetLogInfoParamsWithContext(ctx)
+	infoParams.SetTimeout(viper.GetDuration("timeout"))
+	info, err := m.rekorClient.Tlog.GetLogInfo(infoParams)
+	if err != nil {
+		return err
+	}
+	cur := &util.SignedCheckpoint{}
+	if err := cur.UnmarshalText([]byte(swag.StringValue(info.Payload.SignedTreeHead))); err != nil {
+		return err
+	}
+	if !m.signedByLog(cur) {
+		return errors.New("signature on tree head did not verify")
+	}
+
+	start := m.start
+	if m.prev != nil {
+		if err := m.verifyConsistency(ctx, int64(m.prev.Size), m.prev.Hash, int64(cur.Size), cur.Hash); err != nil {
+			return fmt.Errorf("checkpoint of tree size %d is not consistent with the previous checkpoint of tree size %d: %w", cur.Size, m.prev.Size, err)
+		}
+		start = int64(m.prev.Size)
+	} else if start < 0 {
+		start = int64(cur.Size)
+	} else if start > int64(cur.Size) {
+		return fmt.Errorf("--start-index %d is beyond the tree size %d", start, cur.Size)
+	}
+
+	for index := start; index < int64(cur.Size); {
+		count := int64(cur.Size) - index
+		if count > maxMonitorBatch {
+			count = maxMonitorBatch
+		}
+		fetched, err := m.checkEntries(ctx, cur, index, count)
+		if err != nil {
+			return err
+		}
+		index += fetched
+	}
+
+	m.prev = cur
+	m.out.TreeSize = int64(cur.Size)
+	m.out.RootHash = hex.EncodeToString(cur.Hash)
+	return nil
+}
+
+func (m *monitor) signedByLog(sth *util.SignedCheckpoint) bool {
+	for _, k := range m.keys {
+		verifier, err := signature.LoadVerifier(k.PublicKey, crypto.SHA256)
+		if err == nil && sth.Verify(verifier) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEntries checks up to count entries from index, and returns how many were returned by the log
+func (m *monitor) checkEntries(ctx context.Context, cur *util.SignedCheckpoint, index, count int64) (int64, error) {
+	params := entries.NewGetLogEntryByIndexParamsWithContext(ctx)
+	params.SetTimeout(viper.GetDuration("timeout"))
+	params.LogIndex = index
+	params.Count = &count
+	resp, err := m.rekorClient.Entries.GetLogEntryByIndex(params)
+	if err != nil {
+		return 0, err
+	}
+
+	type leaf struct {
+		uuid  string
+		entry models.LogEntryAnon
+	}
+	var leaves []leaf
+	for uuid, e := range resp.Payload {
+		i := swag.Int64Value(e.LogIndex)
+		if i < index || i >= index+count {
+			return 0, fmt.Errorf("log returned entry %d for the range of %d entries from %d", i, count, index)
+		}
+		leaves = append(leaves, leaf{uuid, e})
+	}
+	sort.Slice(leaves, func(i, j int) bool { return *leaves[i].entry.LogIndex < *leaves[j].entry.LogIndex })
+	for i, l := range leaves {
+		if *l.entry.LogIndex != index+int64(i) {
+			return 0, fmt.Errorf("log did not return entry %d", index+int64(i))
+		}
+	}
+
+	for _, l := range leaves {
+		body, ok := l.entry.Body.(string)
+		if !ok {
+			return 0, fmt.Errorf("unexpected body of entry %d", *l.entry.LogIndex)
+		}
+		leafValue, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return 0, fmt.Errorf("decoding entry %d: %w", *l.entry.LogIndex, err)
+		}
+		leafHash := rfc6962.DefaultHasher.HashLeaf(leafValue)
+		if !strings.EqualFold(l.uuid, hex.EncodeToString(leafHash)) {
+			return 0, fmt.Errorf("UUID %s of entry %d is not the hash of its body", l.uuid, *l.entry.LogIndex)
+		}
+
+		m.out.Entries++
+		kind, keys, err := entryIndexKeys(leafValue)
+		if err != nil {
+			log.CliLogger.Warnf("Unable to decode entry %d: %v", *l.entry.LogIndex, err)
+		}
+		matched, ok := m.filter.match(keys)
+		if !ok {
+			continue
+		}
+		if err := m.verifyIncluded(ctx, cur, *l.entry.LogIndex, leafHash); err != nil {
+			return 0, fmt.Errorf("verifying inclusion of entry %d: %w", *l.entry.LogIndex, err)
+		}
+		m.out.Matches++
+		format.Print(&monitorMatch{
+			UUID:           l.uuid,
+			LogIndex:       *l.entry.LogIndex,
+			IntegratedTime: swag.Int64Value(l.entry.IntegratedTime),
+			Kind:           kind,
+			Matched:        matched,
+		})
+	}
+	return int64(len(leaves)), nil
+}
+
+// verifyIncluded verifies that the leaf at index is in a tree consistent with the checkpoint cur; entries
+// fetched in ranges do not come with inclusion proofs
+func (m *monitor) verifyIncluded(ctx context.Context, cur *util.SignedCheckpoint, index int64, leafHash []byte) error {
+	params := entries.NewGetLogEntryByIndexParamsWithContext(ctx)
+	params.SetTimeout(viper.GetDuration("timeout"))
+	params.LogIndex = index
+	resp, err := m.rekorClient.Entries.GetLogEntryByIndex(params)
+	if err != nil {
+		return err
+	}
+	for _, e := range resp.Payload {
+		if e.Verification == nil || e.Verification.InclusionProof == nil {
+			return errors.New("no inclusion proof returned")
+		}
+		proof := e.Verification.InclusionProof
+		rootHash, err := hex.DecodeString(swag.StringValue(proof.RootHash))
+		if err != nil {
+			return err
+		}
+		hashes, err := decodeHexHashes(proof.Hashes)
+		if err != nil {
+			return err
+		}
+		size := swag.Int64Value(proof.TreeSize)
+		v := logverifier.New(rfc6962.DefaultHasher)
+		if err := v.VerifyInclusionProof(index, size, hashes, rootHash, leafHash); err != nil {
+			return err
+		}
+		return m.verifyConsistency(ctx, int64(cur.Size), cur.Hash, size, rootHash)
+	}
+	return errors.New("entry not returned")
+}
+
+// verifyConsistency verifies that the tree of size firstSize is a prefix of the tree of size lastSize
+func (m *monitor) verifyConsistency(ctx context.Context, firstSize int64, firstHash []byte, lastSize int64, lastHash []byte) error {
+	switch {
+	case lastSize < firstSize:
+		return fmt.Errorf("tree size %d is smaller than %d", lastSize, firstSize)
+	case lastSize == firstSize:
+		if !bytes.Equal(firstHash, lastHash) {
+			return errors.New("root hashes of trees of the same size differ")
+		}
+		return nil
+	case firstSize == 0:
+		return nil
+	}
+	params := tlog.NewGetLogProofParamsWithContext(ctx)
+	params.SetTimeout(viper.GetDuration("timeout"))
+	params.FirstSize = &firstSize
+	params.LastSize = lastSize
+	proof, err := m.rekorClient.Tlog.GetLogProof(params)
+	if err != nil {
+		return err
+	}
+	hashes, err := decodeHexHashes(proof.Payload.Hashes)
+	if err != nil {
+		return err
+	}
+	v := logverifier.New(rfc6962.DefaultHasher)
+	return v.VerifyConsistencyProof(firstSize, lastSize, firstHash, lastHash, hashes)
+}
+
+// entryIndexKeys returns the kind of the entry stored in a leaf, and the keys it is searchable by
+func entryIndexKeys(leafValue []byte) (string, []string, error) {
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(leafValue), runtime.JSONConsumer())
+	if err != nil {
+		return "", nil, err
+	}
+	entry, err := types.NewEntry(pe)
+	if err != nil {
+		return pe.Kind(), nil, err
+	}
+	return pe.Kind(), entry.IndexKeys(), nil
+}
+
+func decodeHexHashes(hexHashes []string) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(hexHashes))
+	for _, h := range hexHashes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, b)
+	}
+	return hashes, nil
+}
+
+func init() {
+	initializePFlagMap()
+	monitorCmd.Flags().StringSlice("email", nil, "only print entries with a key or certificate for this email address")
+	monitorCmd.Flags().StringSlice("san", nil, "only print entries with a certificate with this URI or email subject alternative name")
+	monitorCmd.Flags().StringSlice("key-fingerprint", nil, "only print entries with a key or certificate with this hex encoded SHA256 fingerprint")
+	monitorCmd.Flags().Int64("start-index", -1, "index of the first entry to check if no checkpoint has been stored; by default, only entries added after the current checkpoint are checked")
+	monitorCmd.Flags().Duration("interval", time.Minute, "how often to fetch the latest checkpoint")
+	monitorCmd.Flags().Bool("once", false, "check the entries up to the current checkpoint and exit")
+	monitorCmd.Flags().String("rekor-public-key", "", "path to the PEM encoded public key of the log, or the bundle of its keys returned by /api/v1/log/publicKey?history=true, to verify checkpoints against")
+	rootCmd.AddCommand(monitorCmd)
+}
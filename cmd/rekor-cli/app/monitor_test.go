@@ -0,0 +1,94 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMonitorFilter(t *testing.T) {
+	const fp = "a243179906f4de6c776a22fdaf026b306c4ff4b832e07dea2a6f3703b7b05cdd"
+	tests := []struct {
+		caseDesc    string
+		emails      []string
+		sans        []string
+		fingerprint []string
+		indexKeys   []string
+		wantMatch   bool
+		wantMatched []string
+	}{
+		{
+			caseDesc:  "no filter matches every entry",
+			indexKeys: []string{"sha256:1234"},
+			wantMatch: true,
+		},
+		{
+			caseDesc:    "email",
+			emails:      []string{"Alice@example.com"},
+			indexKeys:   []string{"sha256:1234", "alice@example.com"},
+			wantMatch:   true,
+			wantMatched: []string{"Alice@example.com"},
+		},
+		{
+			caseDesc:    "URI SAN",
+			sans:        []string{"https://github.com/sigstore/rekor/.github/workflows/release.yml@refs/heads/main"},
+			indexKeys:   []string{"uri:https://github.com/sigstore/rekor/.github/workflows/release.yml@refs/heads/main"},
+			wantMatch:   true,
+			wantMatched: []string{"https://github.com/sigstore/rekor/.github/workflows/release.yml@refs/heads/main"},
+		},
+		{
+			caseDesc:    "email SAN",
+			sans:        []string{"alice@example.com"},
+			indexKeys:   []string{"alice@example.com"},
+			wantMatch:   true,
+			wantMatched: []string{"alice@example.com"},
+		},
+		{
+			caseDesc:    "key fingerprint",
+			fingerprint: []string{"sha256:" + fp},
+			indexKeys:   []string{"fingerprint:" + fp},
+			wantMatch:   true,
+			wantMatched: []string{"sha256:" + fp},
+		},
+		{
+			caseDesc:    "hash of canonicalized key",
+			fingerprint: []string{fp},
+			indexKeys:   []string{fp},
+			wantMatch:   true,
+			wantMatched: []string{fp},
+		},
+		{
+			caseDesc:    "no match",
+			emails:      []string{"alice@example.com"},
+			fingerprint: []string{fp},
+			indexKeys:   []string{"bob@example.com", "sha256:" + fp},
+		},
+	}
+
+	for _, tc := range tests {
+		viper.Set("email", tc.emails)
+		viper.Set("san", tc.sans)
+		viper.Set("key-fingerprint", tc.fingerprint)
+		matched, ok := newMonitorFilter().match(tc.indexKeys)
+		if ok != tc.wantMatch || !reflect.DeepEqual(matched, tc.wantMatched) {
+			t.Errorf("%v: match() = %v, %v, want %v, %v", tc.caseDesc, matched, ok, tc.wantMatched, tc.wantMatch)
+		}
+	}
+	viper.Reset()
+}
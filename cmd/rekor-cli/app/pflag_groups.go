@@ -120,40 +120,26 @@ func validateArtifactPFlags(uuidValid, indexValid bool) error {
 }
 
 func CreatePropsFromPflags() *types.ArtifactProperties {
-	props := &types.ArtifactProperties{}
-
-	artifactString := viper.GetString("artifact")
-	if artifactString != "" {
-		if isURL(artifactString) {
-			props.ArtifactPath, _ = url.Parse(artifactString)
-		} else {
-			props.ArtifactPath = &url.URL{Path: artifactString}
-		}
-	}
-
-	props.ArtifactHash = viper.GetString("artifact-hash")
-
-	signatureString := viper.GetString("signature")
-	if signatureString != "" {
-		if isURL(signatureString) {
-			props.SignaturePath, _ = url.Parse(signatureString)
-		} else {
-			props.SignaturePath = &url.URL{Path: signatureString}
-		}
+	return &types.ArtifactProperties{
+		ArtifactPath:  fileOrURL(viper.GetString("artifact")),
+		ArtifactHash:  viper.GetString("artifact-hash"),
+		SignaturePath: fileOrURL(viper.GetString("signature")),
+		PublicKeyPath: fileOrURL(viper.GetString("public-key")),
+		PKIFormat:     viper.GetString("pki-format"),
 	}
+}
 
-	publicKeyString := viper.GetString("public-key")
-	if publicKeyString != "" {
-		if isURL(publicKeyString) {
-			props.PublicKeyPath, _ = url.Parse(publicKeyString)
-		} else {
-			props.PublicKeyPath = &url.URL{Path: publicKeyString}
-		}
+// fileOrURL returns the URL of the file or URL given to a flag, or nil if none was given
+func fileOrURL(s string) *url.URL {
+	switch {
+	case s == "":
+		return nil
+	case isURL(s):
+		u, _ := url.Parse(s)
+		return u
+	default:
+		return &url.URL{Path: s}
 	}
-
-	props.PKIFormat = viper.GetString("pki-format")
-
-	return props
 }
 
 //TODO: add tests for this
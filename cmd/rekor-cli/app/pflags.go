@@ -42,10 +42,12 @@ const (
 	typeFlag      FlagType = "type"
 	fileFlag      FlagType = "file"
 	urlFlag       FlagType = "url"
+	uriFlag       FlagType = "uri"
 	fileOrURLFlag FlagType = "fileOrURL"
 	oidFlag       FlagType = "oid"
 	formatFlag    FlagType = "format"
 	timeoutFlag   FlagType = "timeout"
+	timeFlag      FlagType = "time"
 )
 
 type newPFlagValueFunc func() pflag.Value
@@ -87,6 +89,10 @@ func initializePFlagMap() {
 			// this validates that the string is a valid http/https URL
 			return valueFactory(urlFlag, validateString("required,url,startswith=http|startswith=https"), "")
 		},
+		uriFlag: func() pflag.Value {
+			// unlike urlFlag, this accepts any scheme, such as spiffe://
+			return valueFactory(uriFlag, validateString("required,uri"), "")
+		},
 		fileOrURLFlag: func() pflag.Value {
 			// applies logic of fileFlag OR urlFlag validators from above
 			return valueFactory(fileOrURLFlag, validateFileOrURL, "")
@@ -96,13 +102,17 @@ func initializePFlagMap() {
 			return valueFactory(oidFlag, validateOID, "")
 		},
 		formatFlag: func() pflag.Value {
-			// this validates the output format requested
-			return valueFactory(formatFlag, validateString("required,oneof=json default"), "")
+			// this validates the output format requested; default is the former name of text
+			return valueFactory(formatFlag, validateString("required,oneof=text json yaml default"), "")
 		},
 		timeoutFlag: func() pflag.Value {
 			// this validates the timeout is >= 0
 			return valueFactory(formatFlag, validateTimeout, "")
 		},
+		timeFlag: func() pflag.Value {
+			// this validates the value is an RFC 3339 timestamp
+			return valueFactory(timeFlag, validateTime, "")
+		},
 	}
 }
 
@@ -218,6 +228,12 @@ func validateTimeout(v string) error {
 	return useValidator(timeoutFlag, d)
 }
 
+// validateTime ensures that the supplied string is an RFC 3339 timestamp
+func validateTime(v string) error {
+	_, err := time.Parse(time.RFC3339, v)
+	return err
+}
+
 // validateTypeFlag ensures that the string is in the format type(\.version)? and
 // that one of the types requested is implemented
 func validateTypeFlag(v string) error {
@@ -461,6 +461,10 @@ func TestSearchPFlags(t *testing.T) {
 		sha                   string
 		email                 string
 		pkiFormat             string
+		certFingerprint       string
+		uri                   string
+		integratedFrom        string
+		integratedTo          string
 		expectParseSuccess    bool
 		expectValidateSuccess bool
 	}
@@ -567,6 +571,58 @@ func TestSearchPFlags(t *testing.T) {
 			expectParseSuccess:    false,
 			expectValidateSuccess: false,
 		},
+		{
+			caseDesc:              "valid certificate fingerprint",
+			certFingerprint:       "sha256:45c7b11fcbf07dec1694adecd8c5b85770a12a6c8dfdcf2580a2db0c47c31779",
+			expectParseSuccess:    true,
+			expectValidateSuccess: true,
+		},
+		{
+			caseDesc:              "invalid certificate fingerprint",
+			certFingerprint:       "45c7b11fcbf",
+			expectParseSuccess:    false,
+			expectValidateSuccess: false,
+		},
+		{
+			caseDesc:              "valid SPIFFE URI",
+			uri:                   "spiffe://example.com/ns/default/sa/builder",
+			expectParseSuccess:    true,
+			expectValidateSuccess: true,
+		},
+		{
+			caseDesc:              "invalid URI",
+			uri:                   "not a uri",
+			expectParseSuccess:    false,
+			expectValidateSuccess: false,
+		},
+		{
+			caseDesc:              "valid time window",
+			email:                 "cat@foo.com",
+			integratedFrom:        "2021-06-01T00:00:00Z",
+			integratedTo:          "2021-06-02T12:00:00+02:00",
+			expectParseSuccess:    true,
+			expectValidateSuccess: true,
+		},
+		{
+			caseDesc:              "window without an end",
+			email:                 "cat@foo.com",
+			integratedFrom:        "2021-06-01T00:00:00Z",
+			expectParseSuccess:    true,
+			expectValidateSuccess: true,
+		},
+		{
+			caseDesc:              "window without a start",
+			email:                 "cat@foo.com",
+			integratedTo:          "2021-06-01T00:00:00Z",
+			expectParseSuccess:    true,
+			expectValidateSuccess: false,
+		},
+		{
+			caseDesc:              "invalid time",
+			integratedFrom:        "1622505600",
+			expectParseSuccess:    false,
+			expectValidateSuccess: false,
+		},
 		{
 			caseDesc:              "no flags when either artifact, sha, public key, or email are needed",
 			expectParseSuccess:    true,
@@ -598,6 +654,18 @@ func TestSearchPFlags(t *testing.T) {
 		if tc.email != "" {
 			args = append(args, "--email", tc.email)
 		}
+		if tc.certFingerprint != "" {
+			args = append(args, "--cert-fingerprint", tc.certFingerprint)
+		}
+		if tc.uri != "" {
+			args = append(args, "--uri", tc.uri)
+		}
+		if tc.integratedFrom != "" {
+			args = append(args, "--integrated-from", tc.integratedFrom)
+		}
+		if tc.integratedTo != "" {
+			args = append(args, "--integrated-to", tc.integratedTo)
+		}
 
 		if err := blankCmd.ParseFlags(args); (err == nil) != tc.expectParseSuccess {
 			t.Errorf("unexpected result parsing '%v': %v", tc.caseDesc, err)
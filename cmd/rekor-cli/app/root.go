@@ -16,25 +16,45 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"runtime/debug"
 	"strings"
+	"syscall"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
 	"github.com/sigstore/rekor/pkg/log"
 
 	// these imports are to call the packages' init methods
 	_ "github.com/sigstore/rekor/pkg/types/alpine/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/archlinux/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/authenticode/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/cose/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/crate/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/cyclonedx/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/deb/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/git/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/gomod/v0.0.1"
 	_ "github.com/sigstore/rekor/pkg/types/helm/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/helm/v0.0.2"
 	_ "github.com/sigstore/rekor/pkg/types/intoto/v0.0.1"
 	_ "github.com/sigstore/rekor/pkg/types/jar/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/maven/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/npm/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/oci/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/openvex/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/pypi/v0.0.1"
 	_ "github.com/sigstore/rekor/pkg/types/rekord/v0.0.1"
 	_ "github.com/sigstore/rekor/pkg/types/rfc3161/v0.0.1"
 	_ "github.com/sigstore/rekor/pkg/types/rpm/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/spdx/v0.0.1"
 	_ "github.com/sigstore/rekor/pkg/types/tuf/v0.0.1"
 )
 
@@ -47,9 +67,15 @@ var rootCmd = &cobra.Command{
 	},
 }
 
-// Execute runs the base CLI
+// Execute runs the base CLI. Interrupting it cancels the requests in progress and any wait before retrying one,
+// and interrupting it again exits immediately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		log.CliLogger.Fatal(err)
 	}
 }
@@ -60,10 +86,13 @@ func init() {
 	rootCmd.PersistentFlags().Bool("store_tree_state", true, "whether to store tree state in between invocations for additional verification")
 
 	rootCmd.PersistentFlags().Var(NewFlagValue(urlFlag, "https://rekor.sigstore.dev"), "rekor_server", "Server address:port")
-	rootCmd.PersistentFlags().Var(NewFlagValue(formatFlag, "default"), "format", "Command output format")
-	rootCmd.PersistentFlags().Var(NewFlagValue(timeoutFlag, "30s"), "timeout", "HTTP timeout")
+	rootCmd.PersistentFlags().Var(NewFlagValue(formatFlag, "text"), "format", "Command output format: text, json or yaml")
+	rootCmd.PersistentFlags().Var(NewFlagValue(timeoutFlag, "30s"), "timeout", "HTTP timeout, including the retries of a request")
+	rootCmd.PersistentFlags().Uint("retry", 3, "number of times to retry a request that could not be sent, or that the server failed to handle or rate limited")
+	rootCmd.PersistentFlags().Var(NewFlagValue(timeoutFlag, "10s"), "retry-max-wait", "longest time to wait before retrying a request")
 
 	rootCmd.PersistentFlags().String("api-key", "", "API key for rekor.sigstore.dev")
+	rootCmd.PersistentFlags().String("id-token", "", "OIDC ID token sent as a bearer token, for servers that only accept entries from authenticated clients")
 
 	// these are bound here and not in PreRun so that all child commands can use them
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
@@ -124,7 +153,7 @@ func initConfig(cmd *cobra.Command) error {
 		default:
 			return err
 		}
-	} else if viper.GetString("format") == "default" {
+	} else if format.IsText() {
 		log.CliLogger.Infof("Using config file:", viper.ConfigFileUsed())
 	}
 
@@ -16,6 +16,7 @@
 package app
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -26,6 +27,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -37,15 +39,16 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/client/index"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
 )
 
 type searchCmdOutput struct {
-	uuids []string
+	UUIDs []string `json:"UUIDs"`
 }
 
 func (s *searchCmdOutput) String() string {
 	str := "No matching entries were found\n"
-	for i, uuid := range s.uuids {
+	for i, uuid := range s.UUIDs {
 		if i == 0 {
 			str = "Found matching entries (listed by UUID):\n"
 		}
@@ -64,6 +67,14 @@ func addSearchPFlags(cmd *cobra.Command) error {
 	cmd.Flags().Var(NewFlagValue(shaFlag, ""), "sha", "the SHA256 sum of the artifact")
 
 	cmd.Flags().Var(NewFlagValue(emailFlag, ""), "email", "email associated with the public key's subject")
+
+	cmd.Flags().Var(NewFlagValue(shaFlag, ""), "cert-fingerprint", "the SHA256 fingerprint of the signing certificate")
+
+	cmd.Flags().Var(NewFlagValue(uriFlag, ""), "uri", "URI subject alternative name of the signing certificate, such as a SPIFFE ID")
+
+	cmd.Flags().Var(NewFlagValue(timeFlag, ""), "integrated-from", "only find entries integrated at or after this RFC 3339 time")
+
+	cmd.Flags().Var(NewFlagValue(timeFlag, ""), "integrated-to", "only find entries integrated at or before this RFC 3339 time; defaults to now if --integrated-from is set")
 	return nil
 }
 
@@ -73,15 +84,20 @@ func validateSearchPFlags() error {
 	publicKey := viper.GetString("public-key")
 	sha := viper.GetString("sha")
 	email := viper.GetString("email")
+	certFingerprint := viper.GetString("cert-fingerprint")
+	uri := viper.GetString("uri")
 
-	if artifactStr == "" && publicKey == "" && sha == "" && email == "" {
-		return errors.New("either 'sha' or 'artifact' or 'public-key' or 'email' must be specified")
+	if artifactStr == "" && publicKey == "" && sha == "" && email == "" && certFingerprint == "" && uri == "" {
+		return errors.New("either 'sha' or 'artifact' or 'public-key' or 'email' or 'cert-fingerprint' or 'uri' must be specified")
 	}
 	if publicKey != "" {
 		if viper.GetString("pki-format") == "" {
 			return errors.New("pki-format must be specified if searching by public-key")
 		}
 	}
+	if viper.GetString("integrated-to") != "" && viper.GetString("integrated-from") == "" {
+		return errors.New("integrated-from must be specified if integrated-to is")
+	}
 	return nil
 }
 
@@ -89,7 +105,7 @@ func validateSearchPFlags() error {
 var searchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Rekor search command",
-	Long:  `Searches the Rekor index to find entries by sha, artifact,  public key, or e-mail`,
+	Long:  `Searches the Rekor index to find entries by sha, artifact, public key, e-mail, or signing certificate fingerprint or URI`,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		// these are bound here so that they are not overwritten by other commands
 		if err := viper.BindPFlags(cmd.Flags()); err != nil {
@@ -101,14 +117,14 @@ var searchCmd = &cobra.Command{
 			os.Exit(1)
 		}
 	},
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
 		log := log.CliLogger
 		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
 		}
 
-		params := index.NewSearchIndexParams()
+		params := index.NewSearchIndexParamsWithContext(ctx)
 		params.SetTimeout(viper.GetDuration("timeout"))
 		params.Query = &models.SearchIndex{}
 
@@ -156,20 +172,10 @@ var searchCmd = &cobra.Command{
 		if publicKeyStr != "" {
 			params.Query.PublicKey = &models.SearchIndexPublicKey{}
 			pkiFormat := viper.GetString("pki-format")
-			switch pkiFormat {
-			case "pgp":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatPgp)
-			case "minisign":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatMinisign)
-			case "x509":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatX509)
-			case "ssh":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatSSH)
-			case "tuf":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatTUF)
-			default:
+			if !pki.IsSupportedFormat(pki.Format(pkiFormat)) {
 				return nil, fmt.Errorf("unknown pki-format %v", pkiFormat)
 			}
+			params.Query.PublicKey.Format = swag.String(pkiFormat)
 			publicKeyStr := viper.GetString("public-key")
 			if isURL(publicKeyStr) {
 				params.Query.PublicKey.URL = strfmt.URI(publicKeyStr)
@@ -186,25 +192,46 @@ var searchCmd = &cobra.Command{
 		if emailStr != "" {
 			params.Query.Email = strfmt.Email(emailStr)
 		}
-		resp, err := rekorClient.Index.SearchIndex(params)
-		if err != nil {
-			switch t := err.(type) {
-			case *index.SearchIndexDefault:
-				if t.Code() == http.StatusNotImplemented {
-					return nil, fmt.Errorf("search index not enabled on %v", viper.GetString("rekor_server"))
+		params.Query.CertificateFingerprint = viper.GetString("cert-fingerprint")
+		params.Query.URI = strfmt.URI(viper.GetString("uri"))
+		for flag, param := range map[string]**int64{"integrated-from": &params.IntegratedTimeFrom, "integrated-to": &params.IntegratedTimeTo} {
+			if v := viper.GetString(flag); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing --%s: %w", flag, err)
 				}
-				return nil, err
-			default:
-				return nil, err
+				*param = swag.Int64(t.Unix())
+			}
+		}
+
+		// results are returned a page at a time
+		var uuids []string
+		for {
+			resp, err := rekorClient.Index.SearchIndex(params)
+			if err != nil {
+				switch t := err.(type) {
+				case *index.SearchIndexDefault:
+					if t.Code() == http.StatusNotImplemented {
+						return nil, fmt.Errorf("search index not enabled on %v", viper.GetString("rekor_server"))
+					}
+					return nil, err
+				default:
+					return nil, err
+				}
+			}
+			uuids = append(uuids, resp.GetPayload()...)
+			if resp.NextPageToken == "" {
+				break
 			}
+			params.SetPageToken(&resp.NextPageToken)
 		}
 
-		if len(resp.Payload) == 0 {
+		if len(uuids) == 0 {
 			return nil, fmt.Errorf("no matching entries found")
 		}
 
 		return &searchCmdOutput{
-			uuids: resp.GetPayload(),
+			UUIDs: uuids,
 		}, nil
 	}),
 }
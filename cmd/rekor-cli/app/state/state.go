@@ -27,14 +27,30 @@ import (
 
 type persistedState map[string]*util.SignedCheckpoint
 
+const (
+	stateFile = "state.json"
+	// monitorFile holds the checkpoints up to which rekor-cli monitor has processed each log; it is kept apart
+	// from the state of the other commands, which advance it without looking at the entries
+	monitorFile = "monitor.json"
+)
+
 func Dump(url string, sth *util.SignedCheckpoint) error {
+	return dumpFile(stateFile, url, sth)
+}
+
+// DumpMonitor persists the checkpoint up to which the entries of the log at url have been monitored
+func DumpMonitor(url string, sth *util.SignedCheckpoint) error {
+	return dumpFile(monitorFile, url, sth)
+}
+
+func dumpFile(name, url string, sth *util.SignedCheckpoint) error {
 	rekorDir, err := getRekorDir()
 	if err != nil {
 		return err
 	}
-	statePath := filepath.Join(rekorDir, "state.json")
+	statePath := filepath.Join(rekorDir, name)
 
-	state := loadStateFile()
+	state := loadStateFile(name)
 	if state == nil {
 		state = make(persistedState)
 	}
@@ -50,12 +66,12 @@ func Dump(url string, sth *util.SignedCheckpoint) error {
 	return nil
 }
 
-func loadStateFile() persistedState {
+func loadStateFile(name string) persistedState {
 	rekorDir, err := getRekorDir()
 	if err != nil {
 		return nil
 	}
-	fp := filepath.Join(rekorDir, "state.json")
+	fp := filepath.Join(rekorDir, name)
 	b, err := ioutil.ReadFile(filepath.Clean(fp))
 	if err != nil {
 		return nil
@@ -68,7 +84,15 @@ func loadStateFile() persistedState {
 }
 
 func Load(url string) *util.SignedCheckpoint {
-	if state := loadStateFile(); state != nil {
+	if state := loadStateFile(stateFile); state != nil {
+		return state[url]
+	}
+	return nil
+}
+
+// LoadMonitor returns the checkpoint up to which the entries of the log at url have been monitored, if any
+func LoadMonitor(url string) *util.SignedCheckpoint {
+	if state := loadStateFile(monitorFile); state != nil {
 		return state[url]
 	}
 	return nil
@@ -17,6 +17,7 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"encoding/asn1"
 	"encoding/hex"
@@ -113,10 +114,10 @@ func createRequestFromFlags() (*pkcs9.TimeStampReq, error) {
 }
 
 type timestampCmdOutput struct {
-	Timestamp time.Time
-	Location  string
-	UUID      string
-	Index     int64
+	Timestamp time.Time `json:"Timestamp"`
+	Location  string    `json:"Location"`
+	UUID      string    `json:"UUID"`
+	Index     int64     `json:"Index"`
 }
 
 func (t *timestampCmdOutput) String() string {
@@ -138,7 +139,7 @@ var timestampCmd = &cobra.Command{
 		}
 		return nil
 	},
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
 		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
@@ -153,7 +154,7 @@ var timestampCmd = &cobra.Command{
 			return nil, err
 		}
 
-		params := timestamp.NewGetTimestampResponseParams()
+		params := timestamp.NewGetTimestampResponseParamsWithContext(ctx)
 		params.SetTimeout(viper.GetDuration("timeout"))
 		params.Request = ioutil.NopCloser(bytes.NewReader(requestBytes))
 
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
@@ -44,16 +45,26 @@ import (
 )
 
 type uploadCmdOutput struct {
-	AlreadyExists bool
-	Location      string
-	Index         int64
+	AlreadyExists bool   `json:"AlreadyExists"`
+	Location      string `json:"Location"`
+	Index         int64  `json:"Index"`
+	Bundle        string `json:"Bundle,omitempty"`
 }
 
 func (u *uploadCmdOutput) String() string {
-	if u.AlreadyExists {
-		return fmt.Sprintf("Entry already exists; available at: %v%v\n", viper.GetString("rekor_server"), u.Location)
+	var s string
+	switch {
+	case u.AlreadyExists && u.Index >= 0:
+		s = fmt.Sprintf("Entry already exists at index %d; available at: %v%v\n", u.Index, viper.GetString("rekor_server"), u.Location)
+	case u.AlreadyExists:
+		s = fmt.Sprintf("Entry already exists; available at: %v%v\n", viper.GetString("rekor_server"), u.Location)
+	default:
+		s = fmt.Sprintf("Created entry at index %d, available at: %v%v\n", u.Index, viper.GetString("rekor_server"), u.Location)
 	}
-	return fmt.Sprintf("Created entry at index %d, available at: %v%v\n", u.Index, viper.GetString("rekor_server"), u.Location)
+	if u.Bundle != "" {
+		s += fmt.Sprintf("Verification bundle written to %v\n", u.Bundle)
+	}
+	return s
 }
 
 // uploadCmd represents the upload command
@@ -65,43 +76,40 @@ var uploadCmd = &cobra.Command{
 		if err := viper.BindPFlags(cmd.Flags()); err != nil {
 			return err
 		}
+		if viper.GetString("manifest") != "" {
+			return validateManifestPFlags()
+		}
 		if err := validateArtifactPFlags(false, false); err != nil {
 			return err
 		}
 		return nil
 	},
-	Long: `This command takes the public key, signature and URL of the release artifact and uploads it to the rekor server.`,
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
-		ctx := context.Background()
+	Long: `This command takes the public key, signature and URL of the release artifact and uploads it to the rekor server.
+
+With --manifest, the entries listed in a YAML or JSON file are uploaded concurrently, and a report of the entry
+created for each is output. Each entry in the manifest takes the same fields as the flags of this command:
+
+  - type: rekord
+    artifact: dist/release.tar.gz
+    signature: dist/release.tar.gz.sig
+    public-key: release.pub
+  - entry: dist/sbom.entry.json
+
+Relative paths are resolved against the directory of the manifest.`,
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
 		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
 		}
+		if manifestPath := viper.GetString("manifest"); manifestPath != "" {
+			return uploadManifest(ctx, rekorClient, manifestPath)
+		}
+
 		var entry models.ProposedEntry
-		params := entries.NewCreateLogEntryParams()
-		params.SetTimeout(viper.GetDuration("timeout"))
-
-		entryStr := viper.GetString("entry")
-		if entryStr != "" {
-			var entryReader io.Reader
-			entryURL, err := url.Parse(entryStr)
-			if err == nil && entryURL.IsAbs() {
-				/* #nosec G107 */
-				entryResp, err := http.Get(entryStr)
-				if err != nil {
-					return nil, fmt.Errorf("error fetching entry: %w", err)
-				}
-				defer entryResp.Body.Close()
-				entryReader = entryResp.Body
-			} else {
-				entryReader, err = os.Open(filepath.Clean(entryStr))
-				if err != nil {
-					return nil, fmt.Errorf("error processing entry file: %w", err)
-				}
-			}
-			entry, err = models.UnmarshalProposedEntry(entryReader, runtime.JSONConsumer())
+		if entryStr := viper.GetString("entry"); entryStr != "" {
+			entry, err = loadProposedEntry(entryStr)
 			if err != nil {
-				return nil, fmt.Errorf("error parsing entry file: %w", err)
+				return nil, err
 			}
 		} else {
 			typeStr, versionStr, err := ParseTypeFlag(viper.GetString("type"))
@@ -111,30 +119,83 @@ var uploadCmd = &cobra.Command{
 
 			props := CreatePropsFromPflags()
 
-			entry, err = types.NewProposedEntry(context.Background(), typeStr, versionStr, *props)
+			entry, err = types.NewProposedEntry(ctx, typeStr, versionStr, *props)
 			if err != nil {
 				return nil, err
 			}
 		}
-		params.SetProposedEntry(entry)
 
-		resp, err := rekorClient.Entries.CreateLogEntry(params)
+		out, err := uploadEntry(ctx, rekorClient, entry)
 		if err != nil {
-			switch e := err.(type) {
-			case *entries.CreateLogEntryConflict:
-				return &uploadCmdOutput{
-					Location:      e.Location.String(),
-					AlreadyExists: true,
-				}, nil
-			default:
-				return nil, err
+			return nil, err
+		}
+		if bundlePath := viper.GetString("bundle"); bundlePath != "" {
+			// the entry URL ends with its UUID
+			if err := writeBundle(ctx, rekorClient, path.Base(out.Location), bundlePath); err != nil {
+				return nil, errors.Wrap(err, "writing verification bundle")
 			}
+			out.Bundle = bundlePath
+		}
+		return out, nil
+	}),
+}
+
+// loadProposedEntry reads a pre-formatted entry from a file or URL
+func loadProposedEntry(entryStr string) (models.ProposedEntry, error) {
+	var entryReader io.Reader
+	entryURL, err := url.Parse(entryStr)
+	if err == nil && entryURL.IsAbs() {
+		/* #nosec G107 */
+		entryResp, err := http.Get(entryStr)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching entry: %w", err)
+		}
+		defer entryResp.Body.Close()
+		entryReader = entryResp.Body
+	} else {
+		f, err := os.Open(filepath.Clean(entryStr))
+		if err != nil {
+			return nil, fmt.Errorf("error processing entry file: %w", err)
 		}
+		defer f.Close()
+		entryReader = f
+	}
+	entry, err := models.UnmarshalProposedEntry(entryReader, runtime.JSONConsumer())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing entry file: %w", err)
+	}
+	return entry, nil
+}
+
+// uploadEntry adds entry to the log, and verifies the signed entry timestamp of the new entry
+func uploadEntry(ctx context.Context, rekorClient *genclient.Rekor, entry models.ProposedEntry) (*uploadCmdOutput, error) {
+	params := entries.NewCreateLogEntryParamsWithContext(ctx)
+	params.SetTimeout(viper.GetDuration("timeout"))
+	params.SetProposedEntry(entry)
 
-		var newIndex int64
+	resp, err := rekorClient.Entries.CreateLogEntry(params)
+
+	var out *uploadCmdOutput
+	switch {
+	case err != nil:
+		e, ok := err.(*entries.CreateLogEntryConflict)
+		if !ok {
+			return nil, err
+		}
+		out = &uploadCmdOutput{
+			Location:      e.Location.String(),
+			AlreadyExists: true,
+			Index:         -1,
+		}
+		if e.Payload != nil && e.Payload.LogIndex != nil {
+			out.Index = *e.Payload.LogIndex
+		}
+	default:
 		var logEntry models.LogEntryAnon
+		// AlreadyExists is set when the server accepts duplicate entries idempotently
+		out = &uploadCmdOutput{Location: string(resp.Location), AlreadyExists: resp.RekorExistingEntry}
 		for _, entry := range resp.Payload {
-			newIndex = swag.Int64Value(entry.LogIndex)
+			out.Index = swag.Int64Value(entry.LogIndex)
 			logEntry = entry
 		}
 
@@ -142,12 +203,8 @@ var uploadCmd = &cobra.Command{
 		if verified, err := verifyLogEntry(ctx, rekorClient, logEntry); err != nil || !verified {
 			return nil, errors.Wrap(err, "unable to verify entry was added to log")
 		}
-
-		return &uploadCmdOutput{
-			Location: string(resp.Location),
-			Index:    newIndex,
-		}, nil
-	}),
+	}
+	return out, nil
 }
 
 func verifyLogEntry(ctx context.Context, rekorClient *genclient.Rekor, logEntry models.LogEntryAnon) (bool, error) {
@@ -194,6 +251,9 @@ func init() {
 	if err := addArtifactPFlags(uploadCmd); err != nil {
 		log.CliLogger.Fatal("Error parsing cmd line args:", err)
 	}
+	uploadCmd.Flags().String("bundle", "", "path to write a bundle to, with which the entry can be verified offline by 'rekor-cli verify --bundle'")
+	uploadCmd.Flags().Var(NewFlagValue(fileFlag, ""), "manifest", "path to a YAML or JSON file listing the entries to upload")
+	uploadCmd.Flags().Uint("workers", 4, "number of entries of the manifest to upload concurrently")
 
 	rootCmd.AddCommand(uploadCmd)
 }
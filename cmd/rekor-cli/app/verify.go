@@ -16,11 +16,14 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/bits"
 	"strconv"
+	"strings"
 
 	"github.com/google/trillian/merkle/logverifier"
 	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
@@ -36,11 +39,11 @@ import (
 )
 
 type verifyCmdOutput struct {
-	RootHash  string
-	EntryUUID string
-	Index     int64
-	Size      int64
-	Hashes    []string
+	RootHash  string   `json:"RootHash"`
+	EntryUUID string   `json:"EntryUUID"`
+	Index     int64    `json:"Index"`
+	Size      int64    `json:"Size"`
+	Hashes    []string `json:"Hashes"`
 }
 
 func (v *verifyCmdOutput) String() string {
@@ -73,24 +76,34 @@ func (v *verifyCmdOutput) String() string {
 var verifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Rekor verify command",
-	Long:  `Verifies an entry exists in the transparency log through an inclusion proof`,
+	Long: `Verifies an entry exists in the transparency log through an inclusion proof.
+
+With --bundle, the entry is verified offline against the bundle written by 'rekor-cli upload --bundle' and
+the log key pinned with --rekor-public-key; if the artifact is given, the bundle must be for its entry.`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// these are bound here so that they are not overwritten by other commands
 		if err := viper.BindPFlags(cmd.Flags()); err != nil {
 			return fmt.Errorf("error initializing cmd line args: %s", err)
 		}
+		if viper.GetString("bundle") != "" {
+			return nil
+		}
 		if err := validateArtifactPFlags(true, true); err != nil {
 			return err
 		}
 		return nil
 	},
-	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+	Run: format.WrapCmd(func(ctx context.Context, args []string) (interface{}, error) {
+		if bundlePath := viper.GetString("bundle"); bundlePath != "" {
+			return verifyBundle(bundlePath)
+		}
+
 		rekorClient, err := client.GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
 		}
 
-		searchParams := entries.NewSearchLogQueryParams()
+		searchParams := entries.NewSearchLogQueryParamsWithContext(ctx)
 		searchParams.SetTimeout(viper.GetDuration("timeout"))
 		searchLogQuery := models.SearchLogQuery{}
 
@@ -113,7 +126,7 @@ var verifyCmd = &cobra.Command{
 
 			props := CreatePropsFromPflags()
 
-			entry, err := types.NewProposedEntry(context.Background(), typeStr, versionStr, *props)
+			entry, err := types.NewProposedEntry(ctx, typeStr, versionStr, *props)
 			if err != nil {
 				return nil, err
 			}
@@ -163,6 +176,58 @@ var verifyCmd = &cobra.Command{
 	}),
 }
 
+// verifyBundle verifies the entry in a bundle without contacting the log
+func verifyBundle(path string) (*verifyCmdOutput, error) {
+	b, err := readBundle(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := pinnedLogKeys()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Verify(keys); err != nil {
+		return nil, err
+	}
+
+	if uuid := viper.GetString("uuid"); uuid != "" && !strings.EqualFold(uuid, b.UUID) {
+		return nil, fmt.Errorf("bundle is for entry %s, not %s", b.UUID, uuid)
+	}
+	if logIndex := viper.GetString("log-index"); logIndex != "" && logIndex != strconv.FormatInt(b.LogIndex, 10) {
+		return nil, fmt.Errorf("bundle is for the entry at index %d, not %s", b.LogIndex, logIndex)
+	}
+	if viper.GetString("artifact") != "" {
+		typeStr, versionStr, err := ParseTypeFlag(viper.GetString("type"))
+		if err != nil {
+			return nil, err
+		}
+		ctx := context.Background()
+		proposedEntry, err := types.NewProposedEntry(ctx, typeStr, versionStr, *CreatePropsFromPflags())
+		if err != nil {
+			return nil, err
+		}
+		entry, err := types.NewEntry(proposedEntry)
+		if err != nil {
+			return nil, err
+		}
+		leaf, err := entry.Canonicalize(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(leaf, b.Body) {
+			return nil, errors.New("bundle is not for an entry of the artifact")
+		}
+	}
+
+	return &verifyCmdOutput{
+		RootHash:  b.InclusionProof.RootHash,
+		EntryUUID: b.UUID,
+		Index:     b.LogIndex,
+		Size:      b.InclusionProof.TreeSize,
+		Hashes:    b.InclusionProof.Hashes,
+	}, nil
+}
+
 func init() {
 	initializePFlagMap()
 	if err := addArtifactPFlags(verifyCmd); err != nil {
@@ -174,6 +239,8 @@ func init() {
 	if err := addLogIndexFlag(verifyCmd, false); err != nil {
 		log.CliLogger.Fatal("Error parsing cmd line args:", err)
 	}
+	verifyCmd.Flags().String("bundle", "", "path to a bundle written by 'rekor-cli upload --bundle', to verify the entry offline")
+	verifyCmd.Flags().String("rekor-public-key", "", "path to the PEM encoded public key of the log, or the bundle of its keys returned by /api/v1/log/publicKey?history=true, to verify bundles against")
 
 	rootCmd.AddCommand(verifyCmd)
 }
@@ -0,0 +1,183 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-openapi/runtime"
+	"github.com/google/trillian"
+	ttypes "github.com/google/trillian/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/indexstorage"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// backfillBatchSize is the number of leaves read from Trillian at a time
+const backfillBatchSize = 100
+
+// backfillIndexCmd represents the backfill-index command
+var backfillIndexCmd = &cobra.Command{
+	Use:   "backfill-index",
+	Short: "Repopulate the search index from the entries in the log",
+	Long: `Reads the entries between --start_index and --end_index from Trillian, re-derives the keys they are
+indexed under and adds them to the configured search index. Run it after the search index data has been lost.
+
+Keys derived from content that is not kept in the log, such as the payload of in-toto attestations, cannot be
+recovered. Entries already in a Redis index are added again; run it against an empty index.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		// Setup the logger to dev/prod
+		log.ConfigureLogger(viper.GetString("log_type"))
+		if err := log.SetLevels(viper.GetStringSlice("log_level")); err != nil {
+			log.Logger.Fatal(err)
+		}
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
+		_ = flag.CommandLine.Parse([]string{})
+
+		// without a tree ID, rekor-server creates a new tree
+		treeID := viper.GetInt64("trillian_log_server.tlog_id")
+		if treeID == 0 {
+			return errors.New("--trillian_log_server.tlog_id must be set")
+		}
+
+		defer loadExternalTypes()()
+
+		ctx := context.Background()
+		logClient, _, closer, err := api.OpenLog(ctx)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		is, err := indexstorage.NewIndexStorage(ctx, viper.GetString("search_index.storage_provider"))
+		if err != nil {
+			return err
+		}
+
+		start, end := viper.GetInt64("start_index"), viper.GetInt64("end_index")
+		if end < 0 {
+			resp, err := logClient.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: treeID})
+			if err != nil {
+				return errors.Wrap(err, "getting tree size")
+			}
+			var root ttypes.LogRootV1
+			if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+				return err
+			}
+			end = int64(root.TreeSize) - 1
+		}
+		if start < 0 || start > end {
+			return fmt.Errorf("invalid index range [%d, %d]", start, end)
+		}
+
+		return backfillIndex(ctx, logClient, treeID, is, start, end)
+	},
+}
+
+func init() {
+	backfillIndexCmd.Flags().Int64("start_index", 0, "index of the first entry to add to the search index")
+	backfillIndexCmd.Flags().Int64("end_index", -1, "index of the last entry to add to the search index; defaults to the last entry in the log")
+	rootCmd.AddCommand(backfillIndexCmd)
+}
+
+// backfillIndex adds the index keys of the entries in [start, end] to the search index. Entries that cannot be
+// decoded are logged and skipped, so that one bad entry does not stop the rest from being indexed.
+func backfillIndex(ctx context.Context, logClient trillian.TrillianLogClient, treeID int64, is indexstorage.IndexStorage, start, end int64) error {
+	var failed int64
+	for i := start; i <= end; {
+		count := end - i + 1
+		if count > backfillBatchSize {
+			count = backfillBatchSize
+		}
+		resp, err := logClient.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{
+			LogId:      treeID,
+			StartIndex: i,
+			Count:      count,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "getting entries from index %d", i)
+		}
+		if len(resp.Leaves) == 0 {
+			return fmt.Errorf("no entries returned from index %d", i)
+		}
+
+		g, gctx := errgroup.WithContext(ctx)
+		for _, leaf := range resp.Leaves {
+			leaf := leaf // https://golang.org/doc/faq#closures_and_goroutines
+			g.Go(func() error {
+				keys, err := indexKeys(leaf.LeafValue)
+				if err != nil {
+					log.Logger.Warnf("skipping entry %d: %v", leaf.LeafIndex, err)
+					atomic.AddInt64(&failed, 1)
+					return nil
+				}
+				uuid := hex.EncodeToString(leaf.MerkleLeafHash)
+				for _, key := range keys {
+					if err := is.WriteIndex(gctx, key, uuid); err != nil {
+						return errors.Wrapf(err, "indexing entry %d", leaf.LeafIndex)
+					}
+					if err := indexstorage.WriteTimeIndex(gctx, is, key, uuid, api.IntegratedTime(leaf)); err != nil {
+						return errors.Wrapf(err, "indexing entry %d", leaf.LeafIndex)
+					}
+				}
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
+		i += int64(len(resp.Leaves))
+		log.Logger.Infof("indexed entries up to %d of %d", i-1, end)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d entries could not be decoded and were not indexed", failed)
+	}
+	return nil
+}
+
+// indexKeys returns the keys that the entry stored in a leaf is searchable by
+func indexKeys(leafValue []byte) ([]string, error) {
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(leafValue), runtime.JSONConsumer())
+	if err != nil {
+		return nil, err
+	}
+	entry, err := types.NewEntry(pe)
+	if err != nil {
+		return nil, err
+	}
+	return entry.IndexKeys(), nil
+}
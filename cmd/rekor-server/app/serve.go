@@ -16,32 +16,67 @@
 package app
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/go-openapi/loads"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc"
 
 	"github.com/sigstore/rekor/pkg/api"
 	"github.com/sigstore/rekor/pkg/generated/restapi"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/tracing"
 	"github.com/sigstore/rekor/pkg/types/alpine"
 	alpine_v001 "github.com/sigstore/rekor/pkg/types/alpine/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/archlinux"
+	archlinux_v001 "github.com/sigstore/rekor/pkg/types/archlinux/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/authenticode"
+	authenticode_v001 "github.com/sigstore/rekor/pkg/types/authenticode/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/cose"
+	cose_v001 "github.com/sigstore/rekor/pkg/types/cose/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/crate"
+	crate_v001 "github.com/sigstore/rekor/pkg/types/crate/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/cyclonedx"
+	cyclonedx_v001 "github.com/sigstore/rekor/pkg/types/cyclonedx/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/deb"
+	deb_v001 "github.com/sigstore/rekor/pkg/types/deb/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/external"
+	"github.com/sigstore/rekor/pkg/types/git"
+	git_v001 "github.com/sigstore/rekor/pkg/types/git/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/gomod"
+	gomod_v001 "github.com/sigstore/rekor/pkg/types/gomod/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/helm"
 	helm_v001 "github.com/sigstore/rekor/pkg/types/helm/v0.0.1"
+	_ "github.com/sigstore/rekor/pkg/types/helm/v0.0.2"
 	"github.com/sigstore/rekor/pkg/types/intoto"
 	intoto_v001 "github.com/sigstore/rekor/pkg/types/intoto/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/jar"
 	jar_v001 "github.com/sigstore/rekor/pkg/types/jar/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/maven"
+	maven_v001 "github.com/sigstore/rekor/pkg/types/maven/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/npm"
+	npm_v001 "github.com/sigstore/rekor/pkg/types/npm/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/oci"
+	oci_v001 "github.com/sigstore/rekor/pkg/types/oci/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/openvex"
+	openvex_v001 "github.com/sigstore/rekor/pkg/types/openvex/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/pypi"
+	pypi_v001 "github.com/sigstore/rekor/pkg/types/pypi/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rekord"
 	rekord_v001 "github.com/sigstore/rekor/pkg/types/rekord/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rfc3161"
 	rfc3161_v001 "github.com/sigstore/rekor/pkg/types/rfc3161/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rpm"
 	rpm_v001 "github.com/sigstore/rekor/pkg/types/rpm/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/spdx"
+	spdx_v001 "github.com/sigstore/rekor/pkg/types/spdx/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/tuf"
 	tuf_v001 "github.com/sigstore/rekor/pkg/types/tuf/v0.0.1"
 )
@@ -55,6 +90,9 @@ var serveCmd = &cobra.Command{
 
 		// Setup the logger to dev/prod
 		log.ConfigureLogger(viper.GetString("log_type"))
+		if err := log.SetLevels(viper.GetStringSlice("log_level")); err != nil {
+			log.Logger.Fatal(err)
+		}
 
 		// workaround for https://github.com/sigstore/rekor/issues/68
 		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
@@ -80,14 +118,28 @@ var serveCmd = &cobra.Command{
 
 		// these trigger loading of package and therefore init() methods to run
 		pluggableTypeMap := map[string]string{
-			rekord.KIND:  rekord_v001.APIVERSION,
-			rpm.KIND:     rpm_v001.APIVERSION,
-			jar.KIND:     jar_v001.APIVERSION,
-			intoto.KIND:  intoto_v001.APIVERSION,
-			rfc3161.KIND: rfc3161_v001.APIVERSION,
-			alpine.KIND:  alpine_v001.APIVERSION,
-			helm.KIND:    helm_v001.APIVERSION,
-			tuf.KIND:     tuf_v001.APIVERSION,
+			rekord.KIND:       rekord_v001.APIVERSION,
+			rpm.KIND:          rpm_v001.APIVERSION,
+			jar.KIND:          jar_v001.APIVERSION,
+			intoto.KIND:       intoto_v001.APIVERSION,
+			rfc3161.KIND:      rfc3161_v001.APIVERSION,
+			alpine.KIND:       alpine_v001.APIVERSION,
+			helm.KIND:         helm_v001.APIVERSION,
+			tuf.KIND:          tuf_v001.APIVERSION,
+			cose.KIND:         cose_v001.APIVERSION,
+			spdx.KIND:         spdx_v001.APIVERSION,
+			cyclonedx.KIND:    cyclonedx_v001.APIVERSION,
+			deb.KIND:          deb_v001.APIVERSION,
+			archlinux.KIND:    archlinux_v001.APIVERSION,
+			git.KIND:          git_v001.APIVERSION,
+			oci.KIND:          oci_v001.APIVERSION,
+			npm.KIND:          npm_v001.APIVERSION,
+			pypi.KIND:         pypi_v001.APIVERSION,
+			maven.KIND:        maven_v001.APIVERSION,
+			crate.KIND:        crate_v001.APIVERSION,
+			gomod.KIND:        gomod_v001.APIVERSION,
+			openvex.KIND:      openvex_v001.APIVERSION,
+			authenticode.KIND: authenticode_v001.APIVERSION,
 		}
 
 		for k, v := range pluggableTypeMap {
@@ -95,24 +147,137 @@ var serveCmd = &cobra.Command{
 			log.Logger.Infof("Loading version '%v' for pluggable type '%v'", v, k)
 		}
 
+		defer loadExternalTypes()()
+
 		server.Host = viper.GetString("rekor_server.address")
 		server.Port = int(viper.GetUint("port"))
 		server.EnabledListeners = []string{"http"}
+		server.GracefulTimeout = viper.GetDuration("graceful_shutdown_timeout")
+
+		// a mirror only receives entries from the log that it replicates
+		if viper.GetString("mirror_url") != "" {
+			viper.Set("read_only", true)
+		}
+
+		// before the API is configured, so that its connections to Trillian are traced
+		shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+			Endpoint:    viper.GetString("tracing.otlp_endpoint"),
+			Insecure:    viper.GetBool("tracing.otlp_insecure"),
+			SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		})
+		if err != nil {
+			log.Logger.Fatal(err)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				log.Logger.Error(err)
+			}
+		}()
+
+		// an unreadable key file stops the server here, rather than failing every npm upload
+		if viper.GetString("npm_registry_keys") != "" {
+			if err := npm_v001.LoadRegistryKeys(); err != nil {
+				log.Logger.Fatal(err)
+			}
+		}
 
 		api.ConfigureAPI()
 		server.ConfigureAPI()
 
+		mirrorCtx, stopMirror := context.WithCancel(context.Background())
+		mirrorDone := make(chan struct{})
+		if mirrorURL := viper.GetString("mirror_url"); mirrorURL != "" {
+			m, err := api.NewMirror(mirrorCtx, mirrorURL)
+			if err != nil {
+				log.Logger.Fatal(err)
+			}
+			log.Logger.Infof("Mirroring %v", mirrorURL)
+			go func() {
+				defer close(mirrorDone)
+				m.Run(mirrorCtx)
+			}()
+		} else {
+			close(mirrorDone)
+		}
+
+		var grpcServer *grpc.Server
+		if viper.GetBool("enable_grpc_api") {
+			lis, err := net.Listen("tcp", fmt.Sprintf("%v:%v", server.Host, viper.GetUint("grpc_port")))
+			if err != nil {
+				log.Logger.Fatal(err)
+			}
+			grpcServer = api.NewGRPCServer()
+			go func() {
+				log.Logger.Infof("Serving gRPC API at %v", lis.Addr())
+				if err := grpcServer.Serve(lis); err != nil {
+					log.Logger.Error(err)
+				}
+			}()
+		}
+
 		http.Handle("/metrics", promhttp.Handler())
 		go func() {
 			_ = http.ListenAndServe(":2112", nil)
 		}()
 
+		// Serve returns once the HTTP server has been interrupted and has finished serving in-flight requests,
+		// or GracefulTimeout has passed
 		if err := server.Serve(); err != nil {
 			log.Logger.Fatal(err)
 		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("graceful_shutdown_timeout"))
+		defer cancel()
+		stopMirror()
+		if grpcServer != nil {
+			stopGRPC(ctx, grpcServer)
+		}
+		select {
+		case <-mirrorDone:
+		case <-ctx.Done():
+		}
+		if err := api.Shutdown(ctx); err != nil {
+			log.Logger.Error(err)
+		}
+		log.Logger.Info("rekor-server stopped")
 	},
 }
 
+// stopGRPC stops accepting gRPC calls and waits until ctx is done for the calls in flight, then cancels them
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
 }
+
+// loadExternalTypes starts and registers the configured external type handlers; the returned function stops them
+func loadExternalTypes() func() {
+	var handlers []*external.Handler
+	for _, path := range viper.GetStringSlice("external_type_handlers") {
+		h, err := external.Start(context.Background(), path)
+		if err != nil {
+			log.Logger.Fatal(err)
+		}
+		if err := external.Register(h); err != nil {
+			log.Logger.Fatal(err)
+		}
+		handlers = append(handlers, h)
+		log.Logger.Infof("Loading support for external type '%v' from %v", h.Kind(), path)
+	}
+	return func() {
+		for _, h := range handlers {
+			h.Close()
+		}
+	}
+}
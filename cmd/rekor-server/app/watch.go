@@ -58,6 +58,9 @@ var watchCmd = &cobra.Command{
 
 		// Setup the logger to dev/prod
 		log.ConfigureLogger(viper.GetString("log_type"))
+		if err := log.SetLevels(viper.GetStringSlice("log_level")); err != nil {
+			log.Logger.Fatal(err)
+		}
 
 		// workaround for https://github.com/sigstore/rekor/issues/68
 		// from https://github.com/golang/glog/commit/fca8c8854093a154ff1eb580aae10276ad6b1b5f
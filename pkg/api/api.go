@@ -17,43 +17,95 @@ package api
 
 import (
 	"context"
+	"crypto"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/trillian"
 	"github.com/google/trillian/client"
-	radix "github.com/mediocregopher/radix/v4"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	"github.com/sigstore/rekor/pkg/auth"
+	"github.com/sigstore/rekor/pkg/embeddedlog"
+	"github.com/sigstore/rekor/pkg/indexstorage"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/notify"
 	pki "github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/ratelimit"
 	"github.com/sigstore/rekor/pkg/signer"
 	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/tilelog"
+	"github.com/sigstore/rekor/pkg/trillianconn"
+	"github.com/sigstore/rekor/pkg/util"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/options"
 )
 
-func dial(ctx context.Context, rpcServer string) (*grpc.ClientConn, error) {
+// dialTrillian connects to the Trillian log server configured with the trillian_log_server flags
+func dialTrillian(ctx context.Context) (*grpc.ClientConn, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Set up and test connection to rpc server
-	conn, err := grpc.DialContext(ctx, rpcServer, grpc.WithInsecure())
-	if err != nil {
-		log.Logger.Fatalf("Failed to connect to RPC server:", err)
+	logRPCServer := fmt.Sprintf("%s:%d",
+		viper.GetString("trillian_log_server.address"),
+		viper.GetUint("trillian_log_server.port"))
+	return trillianconn.Dial(ctx, logRPCServer, trillianconn.Config{
+		EnableTLS:        viper.GetBool("trillian_log_server.enable_tls"),
+		TLSCACert:        viper.GetString("trillian_log_server.tls_ca_cert"),
+		TLSClientCert:    viper.GetString("trillian_log_server.tls_client_cert"),
+		TLSClientKey:     viper.GetString("trillian_log_server.tls_client_key"),
+		TLSServerName:    viper.GetString("trillian_log_server.tls_server_name"),
+		KeepaliveTime:    viper.GetDuration("trillian_log_server.keepalive_time"),
+		KeepaliveTimeout: viper.GetDuration("trillian_log_server.keepalive_timeout"),
+		MaxRetries:       viper.GetInt("trillian_log_server.max_retries"),
+		RetryBackoff:     viper.GetDuration("trillian_log_server.retry_backoff"),
+		BreakerThreshold: viper.GetInt("trillian_log_server.circuit_breaker_threshold"),
+		BreakerCooldown:  viper.GetDuration("trillian_log_server.circuit_breaker_cooldown"),
+	})
+}
+
+// OpenLog returns clients for the log backend selected with the log_backend flag, and a Closer that releases it
+func OpenLog(ctx context.Context) (trillian.TrillianLogClient, trillian.TrillianAdminClient, io.Closer, error) {
+	switch backend := viper.GetString("log_backend"); backend {
+	case "", "trillian":
+		conn, err := dialTrillian(ctx)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "dial")
+		}
+		return trillian.NewTrillianLogClient(conn), trillian.NewTrillianAdminClient(conn), conn, nil
+	case "embedded":
+		l, err := embeddedlog.Open(viper.GetString("embedded_log.path"))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return l, l, l, nil
+	case "tiles":
+		l, err := tilelog.Open(ctx, viper.GetString("tile_log.bucket"), viper.GetDuration("tile_log.batch_interval"))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return l, l, l, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown log backend %q", backend)
 	}
-	return conn, nil
 }
 
 type API struct {
 	logClient    trillian.TrillianLogClient
+	logCloser    io.Closer
 	logID        int64
 	pubkey       string // PEM encoded public key
 	pubkeyHash   string // SHA256 hash of DER-encoded public key
@@ -65,20 +117,21 @@ type API struct {
 }
 
 func NewAPI() (*API, error) {
-	logRPCServer := fmt.Sprintf("%s:%d",
-		viper.GetString("trillian_log_server.address"),
-		viper.GetUint("trillian_log_server.port"))
 	ctx := context.Background()
-	tConn, err := dial(ctx, logRPCServer)
+	logClient, logAdminClient, logCloser, err := OpenLog(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "dial")
+		return nil, err
+	}
+
+	// a mirror adds the entries of another log at the indices they have there
+	treeType := trillian.TreeType_LOG
+	if viper.GetString("mirror_url") != "" {
+		treeType = trillian.TreeType_PREORDERED_LOG
 	}
-	logAdminClient := trillian.NewTrillianAdminClient(tConn)
-	logClient := trillian.NewTrillianLogClient(tConn)
 
 	tLogID := viper.GetInt64("trillian_log_server.tlog_id")
 	if tLogID == 0 {
-		t, err := createAndInitTree(ctx, logAdminClient, logClient)
+		t, err := createAndInitTree(ctx, logAdminClient, logClient, treeType)
 		if err != nil {
 			return nil, errors.Wrap(err, "create and init tree")
 		}
@@ -91,6 +144,9 @@ func NewAPI() (*API, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "get tree")
 	}
+	if treeType == trillian.TreeType_PREORDERED_LOG && t.TreeType != treeType {
+		return nil, fmt.Errorf("tree %d must be a %v tree to mirror another log", tLogID, treeType)
+	}
 
 	rekorSigner, err := signer.New(ctx, viper.GetString("rekor_server.signer"))
 	if err != nil {
@@ -147,6 +203,7 @@ func NewAPI() (*API, error) {
 
 	return &API{
 		logClient:    logClient,
+		logCloser:    logCloser,
 		logID:        tLogID,
 		pubkey:       string(pubkey),
 		pubkeyHash:   hex.EncodeToString(pubkeyHashBytes[:]),
@@ -160,19 +217,26 @@ func NewAPI() (*API, error) {
 
 var (
 	api           *API
-	redisClient   radix.Client
+	indexStorage  indexstorage.IndexStorage
 	storageClient storage.AttestationStorage
+	notifier      notify.Publisher
+	rateLimiter   *ratelimit.Limiter
+	authenticator *auth.Authenticator
 )
 
 func ConfigureAPI() {
-	cfg := radix.PoolConfig{}
 	var err error
 	api, err = NewAPI()
 	if err != nil {
 		log.Logger.Panic(err)
 	}
+	if tl, ok := api.logClient.(*tilelog.Log); ok {
+		if err := tl.PublishCheckpoints(context.Background(), tileCheckpoint); err != nil {
+			log.Logger.Panic(err)
+		}
+	}
 	if viper.GetBool("enable_retrieve_api") {
-		redisClient, err = cfg.New(context.Background(), "tcp", fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port")))
+		indexStorage, err = indexstorage.NewIndexStorage(context.Background(), viper.GetString("search_index.storage_provider"))
 		if err != nil {
 			log.Logger.Panic(err)
 		}
@@ -184,4 +248,145 @@ func ConfigureAPI() {
 			log.Logger.Panic(err)
 		}
 	}
+
+	if topics := viper.GetStringSlice("notification_topics"); len(topics) > 0 {
+		notifier, err = notify.NewPublishers(context.Background(), topics)
+		if err != nil {
+			log.Logger.Panic(err)
+		}
+	}
+
+	if err := configureWriteAuth(); err != nil {
+		log.Logger.Panic(err)
+	}
+
+	if viper.GetFloat64("rate_limit.write_rate") > 0 || viper.GetFloat64("rate_limit.read_rate") > 0 {
+		rateLimiter, err = ratelimit.New(ratelimit.Config{
+			WriteRate:      viper.GetFloat64("rate_limit.write_rate"),
+			WriteBurst:     viper.GetInt("rate_limit.write_burst"),
+			ReadRate:       viper.GetFloat64("rate_limit.read_rate"),
+			ReadBurst:      viper.GetInt("rate_limit.read_burst"),
+			TrustedProxies: viper.GetStringSlice("rate_limit.trusted_proxies"),
+			Identity:       auth.IdentityFromContext,
+		})
+		if err != nil {
+			log.Logger.Panic(err)
+		}
+	}
+
+	util.SetFetchPolicy(util.FetchPolicy{
+		Disabled:       !viper.GetBool("artifact_fetch.enabled"),
+		AllowedSchemes: viper.GetStringSlice("artifact_fetch.allowed_schemes"),
+		AllowedHosts:   viper.GetStringSlice("artifact_fetch.allowed_hosts"),
+		MaxSize:        viper.GetInt64("artifact_fetch.max_size"),
+		Timeout:        viper.GetDuration("artifact_fetch.timeout"),
+		BlockLinkLocal: true,
+		BlockPrivate:   viper.GetBool("artifact_fetch.block_private_addresses"),
+	})
+
+	if err := configureX509Trust(); err != nil {
+		log.Logger.Panic(err)
+	}
+
+	if err := configureWitnesses(); err != nil {
+		log.Logger.Panic(err)
+	}
+
+	if err := configureKeyHistory(); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+// configureX509Trust restricts uploaded x509 certificates to the configured roots and CT logs
+func configureX509Trust() error {
+	rootsPath := viper.GetString("x509_trusted_roots")
+	if rootsPath == "" {
+		return nil
+	}
+	rootsPEM, err := ioutil.ReadFile(filepath.Clean(rootsPath))
+	if err != nil {
+		return errors.Wrap(err, "reading x509 trusted roots")
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootsPEM) {
+		return errors.New("no certificates found in x509 trusted roots")
+	}
+	opts := []pki.Option{pki.WithTrustedRoots(roots)}
+
+	if logKeysPath := viper.GetString("x509_ctlog_public_keys"); logKeysPath != "" {
+		logKeysPEM, err := ioutil.ReadFile(filepath.Clean(logKeysPath))
+		if err != nil {
+			return errors.Wrap(err, "reading CT log public keys")
+		}
+		var logKeys []crypto.PublicKey
+		for block, rest := pem.Decode(logKeysPEM); block != nil; block, rest = pem.Decode(rest) {
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return errors.Wrap(err, "parsing CT log public key")
+			}
+			logKeys = append(logKeys, key)
+		}
+		if len(logKeys) == 0 {
+			return errors.New("no public keys found in CT log public keys")
+		}
+		opts = append(opts, pki.WithSCTVerification(logKeys...))
+	}
+
+	pki.SetDefaultOptions(opts...)
+	return nil
+}
+
+// configureWriteAuth requires clients adding entries to authenticate, if any API keys or OIDC issuers are configured
+func configureWriteAuth() error {
+	cfg := auth.Config{
+		OIDCIssuers:   viper.GetStringSlice("write_auth.oidc_issuers"),
+		OIDCAudiences: viper.GetStringSlice("write_auth.oidc_audiences"),
+	}
+	if keysPath := viper.GetString("write_auth.api_keys_file"); keysPath != "" {
+		keys, err := ioutil.ReadFile(filepath.Clean(keysPath))
+		if err != nil {
+			return errors.Wrap(err, "reading API keys")
+		}
+		// one key per line; blank lines and comments are ignored
+		for _, line := range strings.Split(string(keys), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				cfg.APIKeys = append(cfg.APIKeys, line)
+			}
+		}
+		if len(cfg.APIKeys) == 0 {
+			return errors.New("no API keys found in API keys file")
+		}
+	}
+	if len(cfg.APIKeys) == 0 && len(cfg.OIDCIssuers) == 0 {
+		return nil
+	}
+	var err error
+	authenticator, err = auth.New(cfg)
+	return err
+}
+
+// Authenticate rejects requests adding entries that don't carry a valid API key or ID token, if authentication
+// is configured
+func Authenticate(handler http.Handler) http.Handler {
+	if authenticator == nil {
+		return handler
+	}
+	return authenticator.Middleware(handler, metricWriteAuthFailures.Inc)
+}
+
+// LimitAuthFailures counts the write requests failing authentication against the write limit of their address, so
+// that clients sending invalid credentials are refused before they are checked
+func LimitAuthFailures(handler http.Handler) http.Handler {
+	if authenticator == nil || rateLimiter == nil {
+		return handler
+	}
+	return rateLimiter.AuthFailureMiddleware(handler, rateLimitedMetric)
+}
+
+// RateLimit rejects requests from clients over the configured rate limits, if any
+func RateLimit(handler http.Handler) http.Handler {
+	if rateLimiter == nil {
+		return handler
+	}
+	return rateLimiter.Middleware(handler, rateLimitedMetric)
 }
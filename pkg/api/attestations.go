@@ -0,0 +1,183 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/attestations"
+	"github.com/sigstore/rekor/pkg/types/intoto"
+)
+
+// maxAttestationsPage bounds the number of entries read from the log for a single page of
+// attestation search results
+const maxAttestationsPage = 100
+
+// envelopeKey is the key a DSSE envelope is stored under in the attestation storage, next to
+// the attestations stored by entry UUID
+func envelopeKey(hash string) string {
+	return "dsse/" + strings.ToLower(hash)
+}
+
+func storeEnvelope(ctx context.Context, envelope []byte) error {
+	h := sha256.Sum256(envelope)
+	return storageClient.StoreAttestation(ctx, envelopeKey(hex.EncodeToString(h[:])), "application/json", envelope)
+}
+
+// fetchEnvelope returns the envelope stored under hash, or nil if there is none
+func fetchEnvelope(ctx context.Context, hash string) (*models.AttestationEnvelope, error) {
+	data, _, err := storageClient.FetchAttestation(ctx, envelopeKey(hash))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	envelope := &models.AttestationEnvelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, fmt.Errorf("decoding stored envelope %s: %w", hash, err)
+	}
+	return envelope, nil
+}
+
+// envelopeHash returns the hash of the DSSE envelope recorded in the body of an entry, or an
+// empty string if the entry is not of a type that records one
+func envelopeHash(body []byte) string {
+	var entry struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Content struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"content"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(body, &entry); err != nil || entry.Kind != intoto.KIND {
+		return ""
+	}
+	if entry.Spec.Content.Hash.Algorithm != models.IntotoV001SchemaContentHashAlgorithmSha256 {
+		return ""
+	}
+	return entry.Spec.Content.Hash.Value
+}
+
+// GetAttestationByHashHandler returns the stored DSSE envelope with the given hash
+func GetAttestationByHashHandler(params attestations.GetAttestationByHashParams) middleware.Responder {
+	hash := strings.TrimPrefix(strings.ToLower(params.AttestationHash), "sha256:")
+	envelope, err := fetchEnvelope(params.HTTPRequest.Context(), hash)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, attestationUnexpectedResult)
+	}
+	if envelope == nil {
+		return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("no attestation stored for %s", hash), "")
+	}
+	return attestations.NewGetAttestationByHashOK().WithPayload(envelope)
+}
+
+// SearchAttestationsHandler returns the stored DSSE envelopes of the entries indexed under a subject digest
+func SearchAttestationsHandler(params attestations.SearchAttestationsParams) middleware.Responder {
+	httpReqCtx := params.HTTPRequest.Context()
+
+	uuids, err := indexStorage.LookupIndices(httpReqCtx, params.Subject)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, indexStorageUnexpectedResult)
+	}
+	pageSize := maxAttestationsPage
+	if size := swag.Int64Value(params.PageSize); size > 0 && size < int64(pageSize) {
+		pageSize = int(size)
+	}
+	page, nextPageToken, err := searchIndexPage(uuids, pageSize, swag.StringValue(params.PageToken))
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, invalidPageToken)
+	}
+
+	tc := NewTrillianClient(httpReqCtx)
+	result := map[string]models.AttestationEnvelope{}
+	var mu sync.Mutex
+	g, _ := errgroup.WithContext(httpReqCtx)
+	for _, uuid := range page {
+		uuid := uuid // https://golang.org/doc/faq#closures_and_goroutines
+		g.Go(func() error {
+			hash, err := hex.DecodeString(uuid)
+			if err != nil {
+				return nil
+			}
+			resp := tc.getLeafAndProofByHash(hash)
+			switch resp.status {
+			case codes.OK:
+			case codes.NotFound:
+				return nil
+			default:
+				return resp.err
+			}
+			var leaf *trillian.LogLeaf
+			if resp.getLeafAndProofResult != nil {
+				leaf = resp.getLeafAndProofResult.Leaf
+			}
+			if leaf == nil {
+				return nil
+			}
+			envelopeHash := envelopeHash(leaf.LeafValue)
+			if envelopeHash == "" {
+				return nil
+			}
+			envelope, err := fetchEnvelope(httpReqCtx, envelopeHash)
+			if err != nil || envelope == nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			result[uuid] = *envelope
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, attestationUnexpectedResult)
+	}
+
+	return attestations.NewSearchAttestationsOK().WithPayload(result).WithNextPageToken(nextPageToken)
+}
+
+func GetAttestationByHashNotImplementedHandler(params attestations.GetAttestationByHashParams) middleware.Responder {
+	err := models.Error{
+		Code:    http.StatusNotImplemented,
+		Message: "Attestation storage is not enabled in this Rekor instance",
+	}
+
+	return attestations.NewGetAttestationByHashDefault(http.StatusNotImplemented).WithPayload(&err)
+}
+
+func SearchAttestationsNotImplementedHandler(params attestations.SearchAttestationsParams) middleware.Responder {
+	err := models.Error{
+		Code:    http.StatusNotImplemented,
+		Message: "Attestation storage and the Search Index API must both be enabled in this Rekor instance",
+	}
+
+	return attestations.NewSearchAttestationsDefault(http.StatusNotImplemented).WithPayload(&err)
+}
@@ -0,0 +1,285 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	"github.com/spf13/viper"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// maxCosignedCheckpoints is the number of checkpoints for which witness cosignatures are kept
+const maxCosignedCheckpoints = 64
+
+var (
+	witnesses    []*util.Witness
+	cosignatures = &cosignatureStore{}
+)
+
+// configureWitnesses loads the verifier keys of the witnesses whose cosignatures are accepted
+func configureWitnesses() error {
+	path := viper.GetString("witness_keys")
+	if path == "" {
+		return nil
+	}
+	contents, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("reading witness keys: %w", err)
+	}
+	s := bufio.NewScanner(strings.NewReader(string(contents)))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		w, err := util.ParseWitness(line)
+		if err != nil {
+			return fmt.Errorf("parsing witness key %q: %w", line, err)
+		}
+		witnesses = append(witnesses, w)
+	}
+	return s.Err()
+}
+
+// checkpointOrigin identifies this log in the first line of its checkpoints; a mirror uses
+// the origin of the log that it replicates
+func checkpointOrigin() string {
+	if mirroredOrigin != "" {
+		return mirroredOrigin
+	}
+	return fmt.Sprintf("%s - %d", viper.GetString("rekor_server.hostname"), api.logID)
+}
+
+// verifyCosignatures returns the signatures on a checkpoint made by the configured witnesses;
+// signatures from unknown keys (including the log's own) are ignored, as in the signed note format
+func verifyCosignatures(sc util.SignedCheckpoint) ([]note.Signature, error) {
+	var valid []note.Signature
+	for _, sig := range sc.Signatures {
+		for _, w := range witnesses {
+			if w.Name != sig.Name || w.KeyHash != sig.Hash {
+				continue
+			}
+			if !w.Verify(sc.Note, sig) {
+				return nil, fmt.Errorf(invalidCosignature, sig.Name)
+			}
+			valid = append(valid, sig)
+		}
+	}
+	return valid, nil
+}
+
+// cosignatureStore aggregates the witness cosignatures received for recent checkpoints
+type cosignatureStore struct {
+	mu sync.Mutex
+	// checkpoints is ordered from the largest tree size to the smallest
+	checkpoints []*cosignedCheckpoint
+}
+
+type cosignedCheckpoint struct {
+	checkpoint util.Checkpoint
+	// signatures are keyed by witness name
+	signatures map[string]note.Signature
+}
+
+// add records the cosignatures for a checkpoint and returns all of the cosignatures collected for it
+func (s *cosignatureStore) add(c util.Checkpoint, sigs []note.Signature) []note.Signature {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cc *cosignedCheckpoint
+	for _, existing := range s.checkpoints {
+		if existing.checkpoint.String() == c.String() {
+			cc = existing
+			break
+		}
+	}
+	if cc == nil {
+		cc = &cosignedCheckpoint{checkpoint: c, signatures: map[string]note.Signature{}}
+		s.checkpoints = append(s.checkpoints, cc)
+		sort.SliceStable(s.checkpoints, func(i, j int) bool {
+			return s.checkpoints[i].checkpoint.Size > s.checkpoints[j].checkpoint.Size
+		})
+		if len(s.checkpoints) > maxCosignedCheckpoints {
+			s.checkpoints = s.checkpoints[:maxCosignedCheckpoints]
+		}
+	}
+	for _, sig := range sigs {
+		cc.signatures[sig.Name] = sig
+	}
+	return cc.sorted()
+}
+
+// get returns the cosignatures collected for a checkpoint
+func (s *cosignatureStore) get(c util.Checkpoint) []note.Signature {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cc := range s.checkpoints {
+		if cc.checkpoint.String() == c.String() {
+			return cc.sorted()
+		}
+	}
+	return nil
+}
+
+// withQuorum returns the largest checkpoint cosigned by at least n witnesses
+func (s *cosignatureStore) withQuorum(n int) (util.Checkpoint, []note.Signature, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cc := range s.checkpoints {
+		if len(cc.signatures) >= n {
+			return cc.checkpoint, cc.sorted(), true
+		}
+	}
+	return util.Checkpoint{}, nil, false
+}
+
+func (cc *cosignedCheckpoint) sorted() []note.Signature {
+	sigs := make([]note.Signature, 0, len(cc.signatures))
+	for _, sig := range cc.signatures {
+		sigs = append(sigs, sig)
+	}
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i].Name < sigs[j].Name })
+	return sigs
+}
+
+// signCheckpoint returns the checkpoint signed by the log, followed by the given cosignatures
+func signCheckpoint(ctx context.Context, c util.Checkpoint, cosigs []note.Signature) (string, error) {
+	sc, err := util.CreateSignedCheckpoint(c)
+	if err != nil {
+		return "", err
+	}
+	if _, err := sc.Sign(viper.GetString("rekor_server.hostname"), api.signer, options.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	sc.Signatures = append(sc.Signatures, cosigs...)
+	return sc.SignedNote.String(), nil
+}
+
+// tileCheckpoint signs the checkpoint that the tiles log backend publishes with its tiles
+func tileCheckpoint(ctx context.Context, root *types.LogRootV1) ([]byte, error) {
+	signed, err := signCheckpoint(ctx, util.Checkpoint{
+		Ecosystem: checkpointOrigin(),
+		Size:      root.TreeSize,
+		Hash:      root.RootHash,
+	}, nil)
+	return []byte(signed), err
+}
+
+// GetCheckpointHandler returns the latest checkpoint, or the latest one cosigned by the requested number of witnesses
+func GetCheckpointHandler(params tlog.GetCheckpointParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+
+	var c util.Checkpoint
+	var cosigs []note.Signature
+	if params.Witnesses != nil && *params.Witnesses > 0 {
+		var ok bool
+		c, cosigs, ok = cosignatures.withQuorum(int(*params.Witnesses))
+		if !ok {
+			return handleRekorAPIError(params, http.StatusNotFound, nil, fmt.Sprintf(noCheckpointWithQuorum, *params.Witnesses))
+		}
+	} else {
+		tc := NewTrillianClient(ctx)
+		root, err := tc.root()
+		if err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianCommunicationError)
+		}
+		c = util.Checkpoint{
+			Ecosystem: checkpointOrigin(),
+			Size:      root.TreeSize,
+			Hash:      root.RootHash,
+		}
+		cosigs = cosignatures.get(c)
+	}
+
+	signed, err := signCheckpoint(ctx, c, cosigs)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("signing error: %w", err), signingError)
+	}
+	return tlog.NewGetCheckpointOK().WithPayload(signed)
+}
+
+// AddCheckpointCosignaturesHandler verifies the witness cosignatures on a checkpoint of this log and
+// adds them to the ones served with it
+func AddCheckpointCosignaturesHandler(params tlog.AddCheckpointCosignaturesParams) middleware.Responder {
+	if len(witnesses) == 0 {
+		return handleRekorAPIError(params, http.StatusNotImplemented, errors.New("no witness keys configured"), "")
+	}
+	ctx := params.HTTPRequest.Context()
+
+	sc := util.SignedCheckpoint{}
+	if err := sc.UnmarshalText([]byte(params.Checkpoint)); err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, malformedCheckpoint)
+	}
+	c := sc.Checkpoint
+	if c.Ecosystem != checkpointOrigin() || c.Size == 0 || len(c.OtherContent) != 0 || sc.Note != c.String() {
+		return handleRekorAPIError(params, http.StatusBadRequest, nil, fmt.Sprintf(checkpointNotFromLog, checkpointOrigin()))
+	}
+
+	// the checkpoint must be consistent with the current state of the log
+	tc := NewTrillianClient(ctx)
+	root, err := tc.root()
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianCommunicationError)
+	}
+	if c.Size > root.TreeSize {
+		return handleRekorAPIError(params, http.StatusBadRequest, nil, fmt.Sprintf(lastSizeGreaterThanKnown, c.Size, root.TreeSize))
+	}
+	var proof [][]byte
+	if c.Size < root.TreeSize {
+		resp := tc.getConsistencyProof(int64(c.Size), int64(root.TreeSize))
+		if resp.status != codes.OK {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError)
+		}
+		proof = resp.getConsistencyProofResult.GetProof().GetHashes()
+	}
+	v := logverifier.New(rfc6962.DefaultHasher)
+	if err := v.VerifyConsistencyProof(int64(c.Size), int64(root.TreeSize), c.Hash, root.RootHash, proof); err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, fmt.Sprintf(checkpointNotFromLog, checkpointOrigin()))
+	}
+
+	valid, err := verifyCosignatures(sc)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, err.Error())
+	}
+	if len(valid) == 0 {
+		return handleRekorAPIError(params, http.StatusBadRequest, nil, noKnownCosignatures)
+	}
+
+	signed, err := signCheckpoint(ctx, c, cosignatures.add(c, valid))
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("signing error: %w", err), signingError)
+	}
+	return tlog.NewAddCheckpointCosignaturesOK().WithPayload(signed)
+}
@@ -19,10 +19,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
 	"github.com/go-openapi/runtime/middleware"
@@ -39,7 +41,10 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/notify"
+	"github.com/sigstore/rekor/pkg/tracing"
 	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/options"
 )
@@ -60,6 +65,17 @@ func signEntry(ctx context.Context, signer signature.Signer, entry models.LogEnt
 	return signature, nil
 }
 
+// IntegratedTime returns when a leaf was integrated into the log; entries copied from another
+// log by a mirror keep the time recorded by that log in their extra data
+func IntegratedTime(leaf *trillian.LogLeaf) int64 {
+	if len(leaf.ExtraData) > 0 {
+		if t, err := strconv.ParseInt(string(leaf.ExtraData), 10, 64); err == nil {
+			return t
+		}
+	}
+	return leaf.IntegrateTimestamp.AsTime().Unix()
+}
+
 // logEntryFromLeaf creates a signed LogEntry struct from trillian structs
 func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianClient, leaf *trillian.LogLeaf,
 	signedLogRoot *trillian.SignedLogRoot, proof *trillian.Proof) (models.LogEntry, error) {
@@ -77,7 +93,7 @@ func logEntryFromLeaf(ctx context.Context, signer signature.Signer, tc TrillianC
 		LogID:          swag.String(api.pubkeyHash),
 		LogIndex:       &leaf.LeafIndex,
 		Body:           leaf.LeafValue,
-		IntegratedTime: swag.Int64(leaf.IntegrateTimestamp.AsTime().Unix()),
+		IntegratedTime: swag.Int64(IntegratedTime(leaf)),
 	}
 
 	signature, err := signEntry(ctx, signer, logEntryAnon)
@@ -119,6 +135,10 @@ func GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middlewa
 	ctx := params.HTTPRequest.Context()
 	tc := NewTrillianClient(ctx)
 
+	if count := swag.Int64Value(params.Count); count > 1 {
+		return getLogEntryRange(params, tc, count)
+	}
+
 	resp := tc.getLeafAndProofByIndex(params.LogIndex)
 	switch resp.status {
 	case codes.OK:
@@ -142,26 +162,128 @@ func GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middlewa
 	return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry)
 }
 
-func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middleware.Responder) {
-	ctx := params.HTTPRequest.Context()
-	entry, err := types.NewEntry(params.ProposedEntry)
+// getLogEntryRange returns up to count consecutive entries from params.LogIndex. They are returned without
+// inclusion proofs or signed entry timestamps, which would cost a call to Trillian and a signature each.
+func getLogEntryRange(params entries.GetLogEntryByIndexParams, tc TrillianClient, count int64) middleware.Responder {
+	logEntry := models.LogEntry{}
+	end := params.LogIndex + count
+	for start := params.LogIndex; start < end; {
+		resp := tc.getLeavesByRange(start, end-start)
+		switch resp.status {
+		case codes.OK:
+		case codes.NotFound, codes.OutOfRange, codes.InvalidArgument:
+			// the log ends before the range does
+			if len(logEntry) > 0 {
+				return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry)
+			}
+			return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), "")
+		default:
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc err: %w", resp.err), trillianCommunicationError)
+		}
+
+		leaves := resp.getLeavesByRangeResult.GetLeaves()
+		if len(leaves) == 0 {
+			break
+		}
+		for _, leaf := range leaves {
+			logEntry[hex.EncodeToString(leaf.MerkleLeafHash)] = models.LogEntryAnon{
+				LogID:          swag.String(api.pubkeyHash),
+				LogIndex:       swag.Int64(leaf.LeafIndex),
+				Body:           leaf.LeafValue,
+				IntegratedTime: swag.Int64(IntegratedTime(leaf)),
+			}
+		}
+		start += int64(len(leaves))
+	}
+	if len(logEntry) == 0 {
+		return handleRekorAPIError(params, http.StatusNotFound, errors.New("grpc returned 0 leaves with success code"), "")
+	}
+	return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry)
+}
+
+// entryError describes why a single proposed entry could not be added to the log
+type entryError struct {
+	code    int
+	err     error
+	message string
+	// existingUUID is set when an equivalent entry is already in the log
+	existingUUID string
+	// existing is the equivalent entry, if it has been integrated into the log
+	existing models.LogEntry
+}
+
+// duplicateEntry reports that an entry equivalent to leaf is already in the log
+func duplicateEntry(leaf []byte, existing models.LogEntry, err error) *entryError {
+	uuid := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(leaf))
+	message := fmt.Sprintf(entryAlreadyExists, uuid)
+	if e, ok := existing[uuid]; ok {
+		message = fmt.Sprintf(entryAlreadyExistsAtIndex, uuid, swag.Int64Value(e.LogIndex))
+	}
+	return &entryError{code: http.StatusConflict, err: err, message: message, existingUUID: uuid, existing: existing}
+}
+
+// findExistingEntry looks up a canonicalized entry by its leaf hash, which the log indexes, and returns the
+// equivalent entry if one has been integrated into the log
+func findExistingEntry(ctx context.Context, tc TrillianClient, leaf []byte) (models.LogEntry, error) {
+	resp := tc.getLeafAndProofByHash(rfc6962.DefaultHasher.HashLeaf(leaf))
+	switch resp.status {
+	case codes.OK:
+	case codes.NotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("grpc error: %w", resp.err)
+	}
+	result := resp.getLeafAndProofResult
+	if result.Leaf == nil {
+		return nil, nil
+	}
+	return logEntryFromLeaf(ctx, api.signer, tc, result.Leaf, result.SignedLogRoot, result.Proof)
+}
+
+// checkDuplicate returns a conflict if an entry equivalent to leaf is already in the log. Lookup failures
+// are only logged, as the log rejects duplicate leaves itself when they are queued.
+func checkDuplicate(ctx context.Context, tc TrillianClient, httpReq *http.Request, leaf []byte) *entryError {
+	existing, err := findExistingEntry(ctx, tc, leaf)
 	if err != nil {
-		return nil, handleRekorAPIError(params, http.StatusBadRequest, err, fmt.Sprintf(validationError, err))
+		log.RequestIDLogger(httpReq).Warnf("error looking up existing entry: %v", err)
+		return nil
+	}
+	if existing == nil {
+		return nil
+	}
+	return duplicateEntry(leaf, existing, errors.New("entry already in the log"))
+}
+
+// canonicalizeEntry validates a proposed entry and returns the leaf that should be added to the log
+func canonicalizeEntry(ctx context.Context, proposedEntry models.ProposedEntry) (types.EntryImpl, []byte, *entryError) {
+	entry, err := types.NewEntry(proposedEntry)
+	if err != nil {
+		return nil, nil, &entryError{code: http.StatusBadRequest, err: err, message: fmt.Sprintf(validationError, err)}
 	}
 	leaf, err := entry.Canonicalize(ctx)
 	if err != nil {
+		if errors.Is(err, util.ErrArtifactTooLarge) {
+			return nil, nil, &entryError{code: http.StatusRequestEntityTooLarge, err: err, message: fmt.Sprintf(artifactTooLarge, viper.GetInt64("artifact_fetch.max_size"))}
+		}
 		if _, ok := (err).(types.ValidationError); ok {
-			return nil, handleRekorAPIError(params, http.StatusBadRequest, err, fmt.Sprintf(validationError, err))
+			var format string
+			if f, ok := entry.(types.PKIFormatEntry); ok {
+				format = f.PKIFormat()
+			}
+			metricVerificationFailures.With(map[string]string{"kind": proposedEntry.Kind(), "api_version": entry.APIVersion(), "pki_format": format}).Inc()
+			return nil, nil, &entryError{code: http.StatusBadRequest, err: err, message: fmt.Sprintf(validationError, err)}
 		}
-		return nil, handleRekorAPIError(params, http.StatusInternalServerError, err, failedToGenerateCanonicalEntry)
+		return nil, nil, &entryError{code: http.StatusInternalServerError, err: err, message: failedToGenerateCanonicalEntry}
 	}
+	return entry, leaf, nil
+}
 
-	tc := NewTrillianClient(ctx)
-
-	resp := tc.addLeaf(leaf)
+// logEntryFromAddResult checks the result of adding a leaf to the log and, on success, indexes
+// the entry and returns it with a signed entry timestamp
+func logEntryFromAddResult(ctx context.Context, tc TrillianClient, httpReq *http.Request, entry types.EntryImpl, leaf []byte, resp *Response) (models.LogEntry, *entryError) {
 	// this represents overall GRPC response state (not the results of insertion into the log)
 	if resp.status != codes.OK {
-		return nil, handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult)
+		return nil, &entryError{code: http.StatusInternalServerError, err: fmt.Errorf("grpc error: %w", resp.err), message: trillianUnexpectedResult}
 	}
 
 	// this represents the results of inserting the proposed leaf into the log; status is nil in success path
@@ -170,20 +292,29 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 		switch insertionStatus.Code {
 		case int32(code.Code_OK):
 		case int32(code.Code_ALREADY_EXISTS), int32(code.Code_FAILED_PRECONDITION):
-			existingUUID := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(leaf))
-			err := fmt.Errorf("grpc error: %v", insertionStatus.String())
-			return nil, handleRekorAPIError(params, http.StatusConflict, err, fmt.Sprintf(entryAlreadyExists, existingUUID), "entryURL", getEntryURL(*params.HTTPRequest.URL, existingUUID))
+			// the equivalent entry was queued concurrently, and may not be integrated yet
+			existing, err := findExistingEntry(ctx, tc, leaf)
+			if err != nil {
+				log.RequestIDLogger(httpReq).Warnf("error looking up existing entry: %v", err)
+			}
+			return nil, duplicateEntry(leaf, existing, fmt.Errorf("grpc error: %v", insertionStatus.String()))
 		default:
 			err := fmt.Errorf("grpc error: %v", insertionStatus.String())
-			return nil, handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+			return nil, &entryError{code: http.StatusInternalServerError, err: err, message: trillianUnexpectedResult}
 		}
 	}
 
 	// We made it this far, that means the entry was successfully added.
 	metricNewEntries.Inc()
+	header, err := parseLeafHeader(leaf)
+	if err == nil {
+		metricNewEntriesByType.With(map[string]string{"kind": header.Kind, "api_version": header.APIVersion}).Inc()
+	}
 
 	queuedLeaf := resp.getAddResult.QueuedLeaf.Leaf
 	uuid := hex.EncodeToString(queuedLeaf.GetMerkleLeafHash())
+	log.RequestIDLogger(httpReq).Infow("created entry", "uuid", uuid, "logIndex", queuedLeaf.LeafIndex,
+		"kind", header.Kind, "apiVersion", header.APIVersion)
 
 	logEntryAnon := models.LogEntryAnon{
 		LogID:          swag.String(api.pubkeyHash),
@@ -192,33 +323,53 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 		IntegratedTime: swag.Int64(queuedLeaf.IntegrateTimestamp.AsTime().Unix()),
 	}
 
+	// the index, notification and attestation writes outlive the request, but are traced as part of it
+	asyncCtx := tracing.Detach(ctx)
 	if viper.GetBool("enable_retrieve_api") {
-		go func() {
+		goBackground(func() {
 			for _, key := range entry.IndexKeys() {
-				if err := addToIndex(context.Background(), key, uuid); err != nil {
-					log.RequestIDLogger(params.HTTPRequest).Error(err)
+				if err := addToIndex(asyncCtx, key, uuid); err != nil {
+					log.RequestIDLogger(httpReq).Error(err)
+				}
+				if err := addToTimeIndex(asyncCtx, key, uuid, *logEntryAnon.IntegratedTime); err != nil {
+					log.RequestIDLogger(httpReq).Error(err)
 				}
 			}
-		}()
+		})
+	}
+
+	if notifier != nil {
+		goBackground(func() {
+			if err := publishNotification(asyncCtx, entry, leaf, uuid, queuedLeaf); err != nil {
+				log.RequestIDLogger(httpReq).Errorf("error publishing notification for %s: %s", uuid, err)
+			}
+		})
 	}
 
 	if viper.GetBool("enable_attestation_storage") {
 
-		go func() {
+		goBackground(func() {
+			if e, ok := entry.(types.EnvelopeEntry); ok {
+				if envelope := e.Envelope(); envelope != nil {
+					if err := storeEnvelope(asyncCtx, envelope); err != nil {
+						log.RequestIDLogger(httpReq).Errorf("error storing envelope: %s", err)
+					}
+				}
+			}
 			typ, attestation := entry.Attestation()
 			if typ == "" {
-				log.RequestIDLogger(params.HTTPRequest).Infof("no attestation for %s", uuid)
+				log.RequestIDLogger(httpReq).Infof("no attestation for %s", uuid)
 				return
 			}
-			if err := storeAttestation(context.Background(), uuid, typ, attestation); err != nil {
-				log.RequestIDLogger(params.HTTPRequest).Errorf("error storing attestation: %s", err)
+			if err := storeAttestation(asyncCtx, uuid, typ, attestation); err != nil {
+				log.RequestIDLogger(httpReq).Errorf("error storing attestation: %s", err)
 			}
-		}()
+		})
 	}
 
 	signature, err := signEntry(ctx, api.signer, logEntryAnon)
 	if err != nil {
-		return nil, handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("signing entry error: %v", err), signingError)
+		return nil, &entryError{code: http.StatusInternalServerError, err: fmt.Errorf("signing entry error: %v", err), message: signingError}
 	}
 
 	logEntryAnon.Verification = &models.LogEntryAnonVerification{
@@ -231,11 +382,75 @@ func createLogEntry(params entries.CreateLogEntryParams) (models.LogEntry, middl
 	return logEntry, nil
 }
 
+// leafHeader is the type of a canonicalized entry
+type leafHeader struct {
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion"`
+}
+
+func parseLeafHeader(leaf []byte) (leafHeader, error) {
+	var header leafHeader
+	err := json.Unmarshal(leaf, &header)
+	return header, err
+}
+
+// publishNotification announces a newly integrated entry to the configured notification topics
+func publishNotification(ctx context.Context, entry types.EntryImpl, leaf []byte, uuid string, queuedLeaf *trillian.LogLeaf) error {
+	header, err := parseLeafHeader(leaf)
+	if err != nil {
+		return err
+	}
+	return notifier.Publish(ctx, notify.Notification{
+		Kind:           header.Kind,
+		APIVersion:     header.APIVersion,
+		UUID:           uuid,
+		LogIndex:       queuedLeaf.LeafIndex,
+		IntegratedTime: queuedLeaf.IntegrateTimestamp.AsTime().Unix(),
+		IndexKeys:      entry.IndexKeys(),
+	})
+}
+
+// createLogEntry adds the proposed entry to the log. If an equivalent entry is already in the log and the
+// server accepts duplicates idempotently, that entry is returned with existing set.
+func createLogEntry(params entries.CreateLogEntryParams) (logEntry models.LogEntry, existing bool, responder middleware.Responder) {
+	ctx := params.HTTPRequest.Context()
+	handleError := func(e *entryError) (models.LogEntry, bool, middleware.Responder) {
+		if e.existing != nil && viper.GetBool("idempotent_entries") {
+			return e.existing, true, nil
+		}
+		if e.existingUUID != "" {
+			fields := []interface{}{"entryURL", getEntryURL(*params.HTTPRequest.URL, e.existingUUID), "uuid", e.existingUUID}
+			if existing, ok := e.existing[e.existingUUID]; ok {
+				fields = append(fields, "logIndex", swag.Int64Value(existing.LogIndex))
+			}
+			return nil, false, handleRekorAPIError(params, e.code, e.err, e.message, fields...)
+		}
+		return nil, false, handleRekorAPIError(params, e.code, e.err, e.message)
+	}
+
+	entry, leaf, e := canonicalizeEntry(ctx, params.ProposedEntry)
+	if e != nil {
+		return handleError(e)
+	}
+
+	tc := NewTrillianClient(ctx)
+	if e := checkDuplicate(ctx, tc, params.HTTPRequest, leaf); e != nil {
+		return handleError(e)
+	}
+
+	resp := tc.addLeaf(leaf)
+	logEntry, e = logEntryFromAddResult(ctx, tc, params.HTTPRequest, entry, leaf, resp)
+	if e != nil {
+		return handleError(e)
+	}
+	return logEntry, false, nil
+}
+
 // CreateLogEntryHandler creates new entry into log
 func CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Responder {
 	httpReq := params.HTTPRequest
 
-	logEntry, err := createLogEntry(params)
+	logEntry, existing, err := createLogEntry(params)
 	if err != nil {
 		return err
 	}
@@ -245,7 +460,69 @@ func CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Respo
 		uuid = location
 	}
 
-	return entries.NewCreateLogEntryCreated().WithPayload(logEntry).WithLocation(getEntryURL(*httpReq.URL, uuid)).WithETag(uuid)
+	return entries.NewCreateLogEntryCreated().WithPayload(logEntry).WithLocation(getEntryURL(*httpReq.URL, uuid)).WithETag(uuid).WithRekorExistingEntry(existing)
+}
+
+// CreateLogEntriesHandler creates a new entry in the log for each proposed entry, reporting the outcome of each separately
+func CreateLogEntriesHandler(params entries.CreateLogEntriesParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+
+	if max := viper.GetInt("max_batch_entries"); len(params.ProposedEntries) > max {
+		err := fmt.Errorf("%d entries submitted", len(params.ProposedEntries))
+		return handleRekorAPIError(params, http.StatusBadRequest, err, fmt.Sprintf(tooManyBatchEntries, max))
+	}
+
+	results := make([]*models.BatchEntryResult, len(params.ProposedEntries))
+	failed := func(i int, e *entryError) {
+		if e.existing != nil && viper.GetBool("idempotent_entries") {
+			results[i] = &models.BatchEntryResult{
+				Code:  swag.Int64(http.StatusCreated),
+				Entry: e.existing,
+			}
+			return
+		}
+		if e.code != http.StatusConflict {
+			log.RequestIDLogger(params.HTTPRequest).Errorw("error creating entry in batch", "index", i, "statusCode", e.code, "clientMessage", e.message, "error", e.err)
+		}
+		results[i] = &models.BatchEntryResult{
+			Code:  swag.Int64(int64(e.code)),
+			Error: errorMsg(e.message, e.code),
+		}
+	}
+
+	tc := NewTrillianClient(ctx)
+	impls := make([]types.EntryImpl, len(params.ProposedEntries))
+	leaves := [][]byte{}
+	leafEntries := []int{}
+	for i, proposedEntry := range params.ProposedEntries {
+		entry, leaf, e := canonicalizeEntry(ctx, proposedEntry)
+		if e != nil {
+			failed(i, e)
+			continue
+		}
+		if e := checkDuplicate(ctx, tc, params.HTTPRequest, leaf); e != nil {
+			failed(i, e)
+			continue
+		}
+		impls[i] = entry
+		leaves = append(leaves, leaf)
+		leafEntries = append(leafEntries, i)
+	}
+
+	for j, resp := range tc.addLeaves(leaves) {
+		i := leafEntries[j]
+		logEntry, e := logEntryFromAddResult(ctx, tc, params.HTTPRequest, impls[i], leaves[j], resp)
+		if e != nil {
+			failed(i, e)
+			continue
+		}
+		results[i] = &models.BatchEntryResult{
+			Code:  swag.Int64(http.StatusCreated),
+			Entry: logEntry,
+		}
+	}
+
+	return entries.NewCreateLogEntriesOK().WithPayload(results)
 }
 
 // getEntryURL returns the absolute path to the log entry in a RESTful style
@@ -402,5 +679,18 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 		}
 	}
 
+	if params.IntegratedTimeFrom != nil || params.IntegratedTimeTo != nil {
+		filtered := []models.LogEntry{}
+		for _, logEntry := range resultPayload {
+			for _, e := range logEntry {
+				t := swag.Int64Value(e.IntegratedTime)
+				if (params.IntegratedTimeFrom == nil || t >= *params.IntegratedTimeFrom) && (params.IntegratedTimeTo == nil || t <= *params.IntegratedTimeTo) {
+					filtered = append(filtered, logEntry)
+				}
+			}
+		}
+		resultPayload = filtered
+	}
+
 	return entries.NewSearchLogQueryOK().WithPayload(resultPayload)
 }
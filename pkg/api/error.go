@@ -16,15 +16,20 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/mitchellh/mapstructure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/attestations"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
@@ -36,19 +41,34 @@ import (
 const (
 	trillianCommunicationError        = "Unexpected error communicating with transparency log"
 	trillianUnexpectedResult          = "Unexpected result from transparency log"
+	trillianUnavailable               = "The transparency log is temporarily unavailable, retry later"
 	validationError                   = "Error processing entry: %v"
 	failedToGenerateCanonicalEntry    = "Error generating canonicalized entry"
 	entryAlreadyExists                = "An equivalent entry already exists in the transparency log with UUID %v"
+	entryAlreadyExistsAtIndex         = "An equivalent entry already exists in the transparency log with UUID %v at index %d"
 	firstSizeLessThanLastSize         = "firstSize(%d) must be less than lastSize(%d)"
 	malformedUUID                     = "UUID must be a 64-character hexadecimal string"
 	malformedPublicKey                = "Public key provided could not be parsed"
 	failedToGenerateCanonicalKey      = "Error generating canonicalized public key"
-	redisUnexpectedResult             = "Unexpected result from searching index"
+	indexStorageUnexpectedResult      = "Unexpected result from searching index"
+	attestationUnexpectedResult       = "Unexpected result from attestation storage"
+	invalidPageToken                  = "Page token must be the Next-Page-Token returned with the previous page"
+	invalidTimeWindow                 = "integratedTimeFrom must be set, and be before integratedTimeTo (or the current time if it is not set) by at most 366 days"
 	lastSizeGreaterThanKnown          = "The tree size requested(%d) was greater than what is currently observable(%d)"
 	signingError                      = "Error signing"
 	failedToGenerateTimestampResponse = "Error generating timestamp response"
 	sthGenerateError                  = "Error generating signed tree head"
 	unsupportedPKIFormat              = "The PKI format requested is not supported by this server"
+	tooManyBatchEntries               = "At most %d entries may be submitted in a single request"
+	malformedLastEventID              = "Last-Event-ID must be the index of an entry in the transparency log"
+	malformedCheckpoint               = "Checkpoint could not be parsed"
+	checkpointNotFromLog              = "Checkpoint is not consistent with the transparency log %v"
+	invalidCosignature                = "Invalid cosignature from witness %v"
+	noKnownCosignatures               = "Checkpoint has no cosignatures from witnesses known to this server"
+	noCheckpointWithQuorum            = "No checkpoint has been cosigned by %d witnesses"
+	readOnlyInstance                  = "This Rekor instance is read-only and does not accept new entries"
+	requestTooLarge                   = "Request body is larger than the maximum of %d bytes accepted by this server"
+	artifactTooLarge                  = "Artifact is larger than the maximum of %d bytes this server fetches"
 )
 
 func errorMsg(message string, code int) *models.Error {
@@ -58,7 +78,19 @@ func errorMsg(message string, code int) *models.Error {
 	}
 }
 
+// isUnavailable reports whether err wraps an UNAVAILABLE error from gRPC
+func isUnavailable(err error) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &grpcErr) && grpcErr.GRPCStatus().Code() == codes.Unavailable
+}
+
 func handleRekorAPIError(params interface{}, code int, err error, message string, fields ...interface{}) middleware.Responder {
+	// Trillian being unreachable is not an error of this server, and clients can retry
+	if code == http.StatusInternalServerError && isUnavailable(err) {
+		code = http.StatusServiceUnavailable
+		message = trillianUnavailable
+	}
+
 	if message == "" {
 		message = http.StatusText(code)
 	}
@@ -99,16 +131,17 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 			logMsg(params.HTTPRequest)
 			return entries.NewCreateLogEntryBadRequest().WithPayload(errorMsg(message, code))
 		case http.StatusConflict:
-			resp := entries.NewCreateLogEntryConflict().WithPayload(errorMsg(message, code))
-			locationFound := false
-			for _, field := range fields {
-				if locationFound {
-					existingURL := field.(strfmt.URI)
-					resp.SetLocation(existingURL)
-					break
-				} else if field.(string) == "entryURL" {
-					locationFound = true
-					continue
+			payload := &models.ConflictError{Code: int64(code), Message: message}
+			resp := entries.NewCreateLogEntryConflict().WithPayload(payload)
+			for i := 0; i+1 < len(fields); i += 2 {
+				switch fields[i] {
+				case "entryURL":
+					resp.SetLocation(fields[i+1].(strfmt.URI))
+				case "uuid":
+					payload.UUID = fields[i+1].(string)
+					resp.SetETag(payload.UUID)
+				case "logIndex":
+					payload.LogIndex = swag.Int64(fields[i+1].(int64))
 				}
 			}
 			return resp
@@ -116,6 +149,22 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 			logMsg(params.HTTPRequest)
 			return entries.NewCreateLogEntryDefault(code).WithPayload(errorMsg(message, code))
 		}
+	case entries.CreateLogEntriesParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return entries.NewCreateLogEntriesBadRequest().WithPayload(errorMsg(message, code))
+		default:
+			return entries.NewCreateLogEntriesDefault(code).WithPayload(errorMsg(message, code))
+		}
+	case entries.StreamLogEntriesParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return entries.NewStreamLogEntriesBadRequest().WithPayload(errorMsg(message, code))
+		default:
+			return entries.NewStreamLogEntriesDefault(code).WithPayload(errorMsg(message, code))
+		}
 	case entries.SearchLogQueryParams:
 		logMsg(params.HTTPRequest)
 		switch code {
@@ -135,6 +184,24 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 		default:
 			return tlog.NewGetLogProofDefault(code).WithPayload(errorMsg(message, code))
 		}
+	case tlog.GetCheckpointParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusNotFound:
+			return tlog.NewGetCheckpointNotFound()
+		default:
+			return tlog.NewGetCheckpointDefault(code).WithPayload(errorMsg(message, code))
+		}
+	case tlog.AddCheckpointCosignaturesParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return tlog.NewAddCheckpointCosignaturesBadRequest().WithPayload(errorMsg(message, code))
+		case http.StatusNotImplemented:
+			return tlog.NewAddCheckpointCosignaturesNotImplemented()
+		default:
+			return tlog.NewAddCheckpointCosignaturesDefault(code).WithPayload(errorMsg(message, code))
+		}
 	case pubkey.GetPublicKeyParams:
 		logMsg(params.HTTPRequest)
 		return pubkey.NewGetPublicKeyDefault(code).WithPayload(errorMsg(message, code))
@@ -146,6 +213,22 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 		default:
 			return index.NewSearchIndexDefault(code).WithPayload(errorMsg(message, code))
 		}
+	case attestations.GetAttestationByHashParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusNotFound:
+			return attestations.NewGetAttestationByHashNotFound()
+		default:
+			return attestations.NewGetAttestationByHashDefault(code).WithPayload(errorMsg(message, code))
+		}
+	case attestations.SearchAttestationsParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return attestations.NewSearchAttestationsBadRequest().WithPayload(errorMsg(message, code))
+		default:
+			return attestations.NewSearchAttestationsDefault(code).WithPayload(errorMsg(message, code))
+		}
 	case timestamp.GetTimestampResponseParams:
 		logMsg(params.HTTPRequest)
 		switch code {
@@ -0,0 +1,459 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	rekorpb "github.com/sigstore/rekor/pkg/generated/protobuf"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
+)
+
+// grpcServer implements the gRPC API by calling the same handlers that serve the REST API,
+// so that both APIs behave identically
+type grpcServer struct {
+	rekorpb.UnimplementedRekorServer
+}
+
+// NewGRPCServer returns a server for the gRPC API defined in rekor.proto; ConfigureAPI must have been called first
+func NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	// trace first, so that rejected calls are traced too
+	unary := []grpc.UnaryServerInterceptor{otelgrpc.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{otelgrpc.StreamServerInterceptor()}
+	// then authenticate, so that authenticated calls are rate limited per identity, and failed authentications
+	// per address before credentials are checked
+	if authenticator != nil {
+		if rateLimiter != nil {
+			unary = append(unary, rateLimiter.AuthFailureUnaryServerInterceptor(rateLimitedMetric))
+		}
+		unary = append(unary, authenticator.UnaryServerInterceptor(metricWriteAuthFailures.Inc))
+	}
+	if rateLimiter != nil {
+		unary = append(unary, rateLimiter.UnaryServerInterceptor(rateLimitedMetric))
+		stream = append(stream, rateLimiter.StreamServerInterceptor(rateLimitedMetric))
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	if max := viper.GetInt64("max_request_body_size"); max > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(int(max)))
+	}
+	s := grpc.NewServer(opts...)
+	rekorpb.RegisterRekorServer(s, &grpcServer{})
+	return s
+}
+
+// grpcRequest builds the HTTP request passed to a REST handler; handlers use it for its
+// context, for logging, and to build the URLs of entries
+func grpcRequest(ctx context.Context, method, path string) *http.Request {
+	req, err := http.NewRequestWithContext(ctx, method, path, nil)
+	if err != nil {
+		// method and path are constants in this file
+		panic(err)
+	}
+	return req
+}
+
+// responseBuffer is an http.ResponseWriter that holds the response of a REST handler in memory
+type responseBuffer struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (r *responseBuffer) Header() http.Header {
+	return r.header
+}
+
+func (r *responseBuffer) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseBuffer) WriteHeader(code int) {
+	r.code = code
+}
+
+// callHandler decodes the JSON payload written by a REST handler's responder into out;
+// error responses are returned as gRPC status errors
+func callHandler(responder middleware.Responder, out interface{}) error {
+	resp := &responseBuffer{header: http.Header{}, code: http.StatusOK}
+	responder.WriteResponse(resp, runtime.JSONProducer())
+
+	if resp.code >= 200 && resp.code < 300 {
+		if err := json.Unmarshal(resp.body.Bytes(), out); err != nil {
+			return status.Errorf(codes.Internal, "decoding response: %v", err)
+		}
+		return nil
+	}
+
+	message := http.StatusText(resp.code)
+	var apiErr models.Error
+	if err := json.Unmarshal(resp.body.Bytes(), &apiErr); err == nil && apiErr.Message != "" {
+		message = apiErr.Message
+	}
+	return status.Error(grpcCode(resp.code), message)
+}
+
+// grpcCode maps the HTTP status codes returned by the REST handlers to gRPC codes
+func grpcCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// toProtoLogEntry converts a LogEntry as returned by the REST API into its protobuf form
+func toProtoLogEntry(logEntry models.LogEntry) (*rekorpb.LogEntry, error) {
+	for uuid, e := range logEntry {
+		entry := &rekorpb.LogEntry{
+			Uuid:           uuid,
+			LogId:          swag.StringValue(e.LogID),
+			LogIndex:       swag.Int64Value(e.LogIndex),
+			IntegratedTime: swag.Int64Value(e.IntegratedTime),
+		}
+		// the body is serialized as base64 in JSON
+		if body, ok := e.Body.(string); ok {
+			b, err := base64.StdEncoding.DecodeString(body)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "decoding entry body: %v", err)
+			}
+			entry.Body = b
+		}
+		if e.Verification != nil {
+			entry.SignedEntryTimestamp = e.Verification.SignedEntryTimestamp
+			if p := e.Verification.InclusionProof; p != nil {
+				entry.InclusionProof = &rekorpb.InclusionProof{
+					LogIndex: swag.Int64Value(p.LogIndex),
+					RootHash: swag.StringValue(p.RootHash),
+					TreeSize: swag.Int64Value(p.TreeSize),
+					Hashes:   p.Hashes,
+				}
+			}
+		}
+		return entry, nil
+	}
+	return nil, status.Error(codes.Internal, "empty log entry")
+}
+
+func (s *grpcServer) CreateLogEntry(ctx context.Context, in *rekorpb.CreateLogEntryRequest) (*rekorpb.LogEntry, error) {
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(in.ProposedEntry), runtime.JSONConsumer())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := pe.Validate(strfmt.Default); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	params := entries.NewCreateLogEntryParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodPost, "/api/v1/log/entries")
+	params.ProposedEntry = pe
+
+	handler := CreateLogEntryHandler
+	if viper.GetBool("read_only") {
+		handler = CreateLogEntryReadOnlyHandler
+	}
+	var logEntry models.LogEntry
+	if err := callHandler(handler(params), &logEntry); err != nil {
+		return nil, err
+	}
+	return toProtoLogEntry(logEntry)
+}
+
+func (s *grpcServer) GetLogEntryByUUID(ctx context.Context, in *rekorpb.GetLogEntryByUUIDRequest) (*rekorpb.LogEntry, error) {
+	if err := validate.Pattern("uuid", "body", in.Uuid, `^[0-9a-fA-F]{64}$`); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	params := entries.NewGetLogEntryByUUIDParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodGet, "/api/v1/log/entries/"+in.Uuid)
+	params.EntryUUID = in.Uuid
+
+	var logEntry models.LogEntry
+	if err := callHandler(GetLogEntryByUUIDHandler(params), &logEntry); err != nil {
+		return nil, err
+	}
+	return toProtoLogEntry(logEntry)
+}
+
+func (s *grpcServer) getLogEntryByIndex(ctx context.Context, logIndex int64) (*rekorpb.LogEntry, error) {
+	if err := validate.MinimumInt("logIndex", "body", logIndex, 0, false); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	params := entries.NewGetLogEntryByIndexParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodGet, "/api/v1/log/entries")
+	params.LogIndex = logIndex
+
+	var logEntry models.LogEntry
+	if err := callHandler(GetLogEntryByIndexHandler(params), &logEntry); err != nil {
+		return nil, err
+	}
+	return toProtoLogEntry(logEntry)
+}
+
+func (s *grpcServer) GetLogEntryByIndex(ctx context.Context, in *rekorpb.GetLogEntryByIndexRequest) (*rekorpb.LogEntry, error) {
+	return s.getLogEntryByIndex(ctx, in.LogIndex)
+}
+
+// maxStreamRange is the largest number of entries a single StreamLogEntries call returns, which matches
+// the maximum count of the REST range endpoint
+const maxStreamRange = 256
+
+func (s *grpcServer) StreamLogEntries(in *rekorpb.StreamLogEntriesRequest, stream rekorpb.Rekor_StreamLogEntriesServer) error {
+	ctx := stream.Context()
+
+	if err := validate.MinimumInt("startIndex", "body", in.StartIndex, 0, false); err != nil {
+		return invalidArgument(err)
+	}
+	end := in.EndIndex
+	if end == 0 {
+		logInfo, err := s.GetLogInfo(ctx, &rekorpb.GetLogInfoRequest{})
+		if err != nil {
+			return err
+		}
+		end = logInfo.TreeSize
+		if end > in.StartIndex+maxStreamRange {
+			end = in.StartIndex + maxStreamRange
+		}
+	}
+	if in.StartIndex > end {
+		return invalidArgument(fmt.Errorf("start index %d is after end index %d", in.StartIndex, end))
+	}
+	if end-in.StartIndex > maxStreamRange {
+		return invalidArgument(fmt.Errorf("at most %d entries can be streamed at a time", maxStreamRange))
+	}
+
+	// like the REST range endpoint, entries are returned without inclusion proofs or signed entry
+	// timestamps, which would cost a call to Trillian and a signature each
+	tc := NewTrillianClient(ctx)
+	for start := in.StartIndex; start < end; {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		count := end - start
+		if count > streamBatchSize {
+			count = streamBatchSize
+		}
+		resp := tc.getLeavesByRange(start, count)
+		switch resp.status {
+		case codes.OK:
+		case codes.NotFound, codes.OutOfRange, codes.InvalidArgument:
+			// the log ends before the range does
+			return nil
+		default:
+			return status.Errorf(codes.Internal, "%s: %v", trillianCommunicationError, resp.err)
+		}
+
+		leaves := resp.getLeavesByRangeResult.GetLeaves()
+		if len(leaves) == 0 {
+			return nil
+		}
+		for _, leaf := range leaves {
+			if err := stream.Send(&rekorpb.LogEntry{
+				Uuid:           hex.EncodeToString(leaf.MerkleLeafHash),
+				LogId:          api.pubkeyHash,
+				LogIndex:       leaf.LeafIndex,
+				Body:           leaf.LeafValue,
+				IntegratedTime: IntegratedTime(leaf),
+			}); err != nil {
+				return err
+			}
+		}
+		start += int64(len(leaves))
+	}
+	return nil
+}
+
+func (s *grpcServer) SearchLogQuery(ctx context.Context, in *rekorpb.SearchLogQueryRequest) (*rekorpb.SearchLogQueryResponse, error) {
+	// build the same document a REST client would send, so that it is unmarshalled and validated identically
+	query := struct {
+		EntryUUIDs []string          `json:"entryUUIDs,omitempty"`
+		LogIndexes []int64           `json:"logIndexes,omitempty"`
+		Entries    []json.RawMessage `json:"entries,omitempty"`
+	}{
+		EntryUUIDs: in.EntryUuids,
+		LogIndexes: in.LogIndexes,
+	}
+	for _, e := range in.Entries {
+		query.Entries = append(query.Entries, json.RawMessage(e))
+	}
+	b, err := json.Marshal(query)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	var searchLogQuery models.SearchLogQuery
+	if err := searchLogQuery.UnmarshalJSON(b); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := searchLogQuery.Validate(strfmt.Default); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	params := entries.NewSearchLogQueryParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodPost, "/api/v1/log/entries/retrieve")
+	params.Entry = &searchLogQuery
+	if in.IntegratedTimeFrom != 0 {
+		params.IntegratedTimeFrom = swag.Int64(in.IntegratedTimeFrom)
+	}
+	if in.IntegratedTimeTo != 0 {
+		params.IntegratedTimeTo = swag.Int64(in.IntegratedTimeTo)
+	}
+
+	var logEntries []models.LogEntry
+	if err := callHandler(SearchLogQueryHandler(params), &logEntries); err != nil {
+		return nil, err
+	}
+	resp := &rekorpb.SearchLogQueryResponse{}
+	for _, logEntry := range logEntries {
+		entry, err := toProtoLogEntry(logEntry)
+		if err != nil {
+			return nil, err
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) SearchIndex(ctx context.Context, in *rekorpb.SearchIndexRequest) (*rekorpb.SearchIndexResponse, error) {
+	query := &models.SearchIndex{
+		Email:                  strfmt.Email(in.Email),
+		Hash:                   in.Hash,
+		CertificateFingerprint: in.CertificateFingerprint,
+		URI:                    strfmt.URI(in.Uri),
+	}
+	if pk := in.PublicKey; pk != nil {
+		query.PublicKey = &models.SearchIndexPublicKey{
+			Format:  swag.String(pk.Format),
+			Content: pk.Content,
+			URL:     strfmt.URI(pk.Url),
+		}
+	}
+	if err := query.Validate(strfmt.Default); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	params := index.NewSearchIndexParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodPost, "/api/v1/index/retrieve")
+	params.Query = query
+	if in.PageSize != 0 {
+		params.PageSize = swag.Int64(in.PageSize)
+		if err := validate.MinimumInt("pageSize", "body", in.PageSize, 1, false); err != nil {
+			return nil, invalidArgument(err)
+		}
+	}
+	if in.PageToken != "" {
+		params.PageToken = swag.String(in.PageToken)
+	}
+	if in.IntegratedTimeFrom != 0 {
+		params.IntegratedTimeFrom = swag.Int64(in.IntegratedTimeFrom)
+	}
+	if in.IntegratedTimeTo != 0 {
+		params.IntegratedTimeTo = swag.Int64(in.IntegratedTimeTo)
+	}
+
+	handler := SearchIndexHandler
+	if !viper.GetBool("enable_retrieve_api") {
+		handler = SearchIndexNotImplementedHandler
+	}
+	responder := handler(params)
+	var uuids []string
+	if err := callHandler(responder, &uuids); err != nil {
+		return nil, err
+	}
+	resp := &rekorpb.SearchIndexResponse{Uuids: uuids}
+	// the token is returned in a header, which callHandler does not decode
+	if ok, isOK := responder.(*index.SearchIndexOK); isOK {
+		resp.NextPageToken = ok.NextPageToken
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) GetLogInfo(ctx context.Context, in *rekorpb.GetLogInfoRequest) (*rekorpb.LogInfo, error) {
+	params := tlog.NewGetLogInfoParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodGet, "/api/v1/log")
+
+	var logInfo models.LogInfo
+	if err := callHandler(GetLogInfoHandler(params), &logInfo); err != nil {
+		return nil, err
+	}
+	return &rekorpb.LogInfo{
+		RootHash:       swag.StringValue(logInfo.RootHash),
+		TreeSize:       swag.Int64Value(logInfo.TreeSize),
+		SignedTreeHead: swag.StringValue(logInfo.SignedTreeHead),
+	}, nil
+}
+
+func (s *grpcServer) GetLogProof(ctx context.Context, in *rekorpb.GetLogProofRequest) (*rekorpb.ConsistencyProof, error) {
+	params := tlog.NewGetLogProofParams()
+	params.HTTPRequest = grpcRequest(ctx, http.MethodGet, "/api/v1/log/proof")
+	if in.FirstSize != 0 {
+		params.FirstSize = swag.Int64(in.FirstSize)
+	}
+	params.LastSize = in.LastSize
+	if err := validate.MinimumInt("firstSize", "body", *params.FirstSize, 1, false); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := validate.MinimumInt("lastSize", "body", params.LastSize, 1, false); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	var proof models.ConsistencyProof
+	if err := callHandler(GetLogProofHandler(params), &proof); err != nil {
+		return nil, err
+	}
+	return &rekorpb.ConsistencyProof{
+		RootHash: swag.StringValue(proof.RootHash),
+		Hashes:   proof.Hashes,
+	}, nil
+}
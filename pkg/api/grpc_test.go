@@ -0,0 +1,74 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	rekorpb "github.com/sigstore/rekor/pkg/generated/protobuf"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := []struct {
+		httpCode int
+		want     codes.Code
+	}{
+		{http.StatusBadRequest, codes.InvalidArgument},
+		{http.StatusUnauthorized, codes.Unauthenticated},
+		{http.StatusForbidden, codes.PermissionDenied},
+		{http.StatusNotFound, codes.NotFound},
+		{http.StatusConflict, codes.AlreadyExists},
+		{http.StatusRequestEntityTooLarge, codes.ResourceExhausted},
+		{http.StatusTooManyRequests, codes.ResourceExhausted},
+		{http.StatusInternalServerError, codes.Internal},
+		{http.StatusNotImplemented, codes.Unimplemented},
+		{http.StatusServiceUnavailable, codes.Unavailable},
+	}
+	for _, tt := range tests {
+		if got := grpcCode(tt.httpCode); got != tt.want {
+			t.Errorf("grpcCode(%d) = %v, want %v", tt.httpCode, got, tt.want)
+		}
+	}
+}
+
+// rangeOnlyStream panics on any call other than Context
+type rangeOnlyStream struct {
+	rekorpb.Rekor_StreamLogEntriesServer
+}
+
+func (rangeOnlyStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamLogEntriesRange(t *testing.T) {
+	tests := []*rekorpb.StreamLogEntriesRequest{
+		{StartIndex: -1, EndIndex: 10},
+		{StartIndex: 10, EndIndex: 5},
+		{StartIndex: 0, EndIndex: maxStreamRange + 1},
+	}
+	s := &grpcServer{}
+	for _, in := range tests {
+		// the range is checked before the stream or Trillian are used
+		if err := s.StreamLogEntries(in, rangeOnlyStream{}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("StreamLogEntries(%d, %d): expected InvalidArgument, got %v", in.StartIndex, in.EndIndex, err)
+		}
+	}
+}
@@ -18,29 +18,53 @@ package api
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
-	radix "github.com/mediocregopher/radix/v4"
+	"github.com/spf13/viper"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
+	"github.com/sigstore/rekor/pkg/indexstorage"
 	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	"github.com/sigstore/rekor/pkg/util"
 )
 
 func SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
 	httpReqCtx := params.HTTPRequest.Context()
 
+	lookup := func(key string) ([]string, error) {
+		return indexStorage.LookupIndices(httpReqCtx, key)
+	}
+	if params.IntegratedTimeFrom != nil || params.IntegratedTimeTo != nil {
+		// the window ends now unless a time is given
+		from, to := swag.Int64Value(params.IntegratedTimeFrom), time.Now().Unix()
+		if params.IntegratedTimeTo != nil {
+			to = *params.IntegratedTimeTo
+		}
+		if params.IntegratedTimeFrom == nil || to < from || to-from > int64(indexstorage.MaxTimeWindow/time.Second) {
+			return handleRekorAPIError(params, http.StatusBadRequest, fmt.Errorf("invalid time window [%d, %d]", from, to), invalidTimeWindow)
+		}
+		lookup = func(key string) ([]string, error) {
+			return indexstorage.LookupTimeRange(httpReqCtx, indexStorage, key, from, to)
+		}
+	}
+
 	var result []string
 	if params.Query.Hash != "" {
 		// This must be a valid sha256 hash
-		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(params.Query.Hash), "0", "-1")); err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		resultUUIDs, err := lookup(params.Query.Hash)
+		if err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, indexStorageUnexpectedResult)
 		}
 		result = append(result, resultUUIDs...)
 	}
@@ -65,21 +89,73 @@ func SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
 		}
 
 		keyHash := sha256.Sum256(canonicalKey)
-		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(hex.EncodeToString(keyHash[:])), "0", "-1")); err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		resultUUIDs, err := lookup(hex.EncodeToString(keyHash[:]))
+		if err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, indexStorageUnexpectedResult)
 		}
 		result = append(result, resultUUIDs...)
 	}
 	if params.Query.Email != "" {
-		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(params.Query.Email.String()), "0", "-1")); err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		resultUUIDs, err := lookup(params.Query.Email.String())
+		if err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, indexStorageUnexpectedResult)
 		}
 		result = append(result, resultUUIDs...)
 	}
+	if params.Query.CertificateFingerprint != "" {
+		fp := strings.TrimPrefix(strings.ToLower(params.Query.CertificateFingerprint), "sha256:")
+		resultUUIDs, err := lookup(identity.Identity{Type: identity.CertFingerprint, Value: fp}.IndexKey())
+		if err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, indexStorageUnexpectedResult)
+		}
+		result = append(result, resultUUIDs...)
+	}
+	if params.Query.URI != "" {
+		resultUUIDs, err := lookup(identity.Identity{Type: identity.URI, Value: params.Query.URI.String()}.IndexKey())
+		if err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, indexStorageUnexpectedResult)
+		}
+		result = append(result, resultUUIDs...)
+	}
+
+	pageSize := viper.GetInt("search_index.max_results")
+	if size := swag.Int64Value(params.PageSize); size > 0 && (pageSize <= 0 || size < int64(pageSize)) {
+		pageSize = int(size)
+	}
+	page, nextPageToken, err := searchIndexPage(result, pageSize, swag.StringValue(params.PageToken))
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, invalidPageToken)
+	}
+	return index.NewSearchIndexOK().WithPayload(page).WithNextPageToken(nextPageToken)
+}
+
+// searchIndexPage returns up to pageSize (or, if it is not positive, all) of uuids, in ascending order and without duplicates, that follow
+// the last UUID of the previous page encoded in pageToken. Paging by UUID rather than by offset keeps later
+// pages consistent while entries are added to the index. If there are further UUIDs, the token for the next
+// page is returned too. The index has no cursor, so every page looks up, sorts and scans the whole result
+// set again; paging bounds the size of each response, not the work done to produce it.
+func searchIndexPage(uuids []string, pageSize int, pageToken string) ([]string, string, error) {
+	sort.Strings(uuids)
+	start := 0
+	if pageToken != "" {
+		after, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil || len(after) == 0 {
+			return nil, "", errors.New("malformed page token")
+		}
+		start = sort.Search(len(uuids), func(i int) bool { return uuids[i] > string(after) })
+	}
 
-	return index.NewSearchIndexOK().WithPayload(result)
+	page := []string{}
+	for i := start; i < len(uuids); i++ {
+		if i > 0 && uuids[i] == uuids[i-1] {
+			continue
+		}
+		if pageSize > 0 && len(page) == pageSize {
+			return page, base64.RawURLEncoding.EncodeToString([]byte(page[len(page)-1])), nil
+		}
+		page = append(page, uuids[i])
+	}
+	return page, "", nil
 }
 
 func SearchIndexNotImplementedHandler(params index.SearchIndexParams) middleware.Responder {
@@ -93,7 +169,17 @@ func SearchIndexNotImplementedHandler(params index.SearchIndexParams) middleware
 }
 
 func addToIndex(ctx context.Context, key, value string) error {
-	return redisClient.Do(ctx, radix.Cmd(nil, "LPUSH", key, value))
+	start := time.Now()
+	err := indexStorage.WriteIndex(ctx, key, value)
+	metricIndexWriteLatency.With(map[string]string{"index": "key", "result": resultLabel(err)}).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func addToTimeIndex(ctx context.Context, key, uuid string, integratedTime int64) error {
+	start := time.Now()
+	err := indexstorage.WriteTimeIndex(ctx, indexStorage, key, uuid, integratedTime)
+	metricIndexWriteLatency.With(map[string]string{"index": "time", "result": resultLabel(err)}).Observe(time.Since(start).Seconds())
+	return err
 }
 
 func storeAttestation(ctx context.Context, uuid, attestationType string, attestation []byte) error {
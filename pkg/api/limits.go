@@ -0,0 +1,106 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// limitedBody fails reads once more than remaining bytes have been read from the request body
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, fmt.Errorf(requestTooLarge, viper.GetInt64("max_request_body_size"))
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		b.exceeded = true
+		return n, fmt.Errorf(requestTooLarge, viper.GetInt64("max_request_body_size"))
+	}
+	return n, err
+}
+
+// tooLargeWriter replaces the response to a request whose body turned out to be too large, whatever
+// error the handler reports for the truncated body, with 413 Request Entity Too Large
+type tooLargeWriter struct {
+	http.ResponseWriter
+	body     *limitedBody
+	max      int64
+	replaced bool
+}
+
+func (w *tooLargeWriter) WriteHeader(code int) {
+	if w.body.exceeded && code >= http.StatusBadRequest {
+		w.replaced = true
+		writeRequestTooLarge(w.ResponseWriter, w.max)
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *tooLargeWriter) Write(p []byte) (int, error) {
+	if w.replaced {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func writeRequestTooLarge(w http.ResponseWriter, max int64) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	_ = json.NewEncoder(w).Encode(&models.Error{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: fmt.Sprintf(requestTooLarge, max),
+	})
+}
+
+// LimitRequestBody rejects requests with bodies larger than max_request_body_size bytes with 413 Request Entity
+// Too Large, if a limit is configured
+func LimitRequestBody(handler http.Handler) http.Handler {
+	max := viper.GetInt64("max_request_body_size")
+	if max <= 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > max {
+			writeRequestTooLarge(w, max)
+			return
+		}
+		if r.Body == nil || r.Body == http.NoBody {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		body := &limitedBody{ReadCloser: r.Body, remaining: max}
+		r.Body = body
+		handler.ServeHTTP(&tooLargeWriter{ResponseWriter: w, body: body, max: max}, r)
+	})
+}
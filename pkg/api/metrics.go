@@ -16,8 +16,12 @@
 package api
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sigstore/rekor/pkg/pki/tuf"
 )
 
 var (
@@ -26,8 +30,73 @@ var (
 		Help: "The total number of new log entries",
 	})
 
+	metricNewEntriesByType = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_new_entries_by_type",
+		Help: "The total number of new log entries of each type",
+	}, []string{"kind", "api_version"})
+
+	metricVerificationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_entry_verification_failures",
+		Help: "The total number of proposed entries rejected as invalid, by type and, for types that support several, PKI format",
+	}, []string{"kind", "api_version", "pki_format"})
+
+	metricTrillianLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "rekor_trillian_add_latency_seconds",
+		Help: "Time taken to queue new leaves with the log, and then for them to be integrated",
+	}, []string{"stage", "code"})
+
+	metricIndexWriteLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "rekor_index_write_latency_seconds",
+		Help: "Time taken to write a key to the search index",
+	}, []string{"index", "result"})
+
 	MetricLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name: "rekor_api_latency",
 		Help: "Api Latency on calls",
 	}, []string{"path", "code"})
+
+	metricTufVerifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_tuf_verifications",
+		Help: "The total number of TUF manifest verifications",
+	}, []string{"role", "spec_version", "result"})
+
+	metricRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_rate_limited_requests",
+		Help: "The total number of requests rejected by the rate limits",
+	}, []string{"class"})
+
+	metricWriteAuthFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rekor_write_auth_failures",
+		Help: "The total number of requests to add entries rejected for missing or invalid credentials",
+	})
 )
+
+func init() {
+	tuf.SetMetricsHook(tufMetrics{})
+}
+
+func rateLimitedMetric(write bool) {
+	class := "read"
+	if write {
+		class = "write"
+	}
+	metricRateLimited.With(map[string]string{"class": class}).Inc()
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// tufMetrics exports TUF manifest verification outcomes to prometheus
+type tufMetrics struct{}
+
+func (tufMetrics) ObserveVerification(role, specVersion string, _ time.Duration, err error) {
+	metricTufVerifications.With(map[string]string{
+		"role":         role,
+		"spec_version": specVersion,
+		"result":       resultLabel(err),
+	}).Inc()
+}
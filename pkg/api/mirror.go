@@ -0,0 +1,388 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	ttypes "github.com/google/trillian/types"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/generated/client"
+	entriesclient "github.com/sigstore/rekor/pkg/generated/client/entries"
+	pubkeyclient "github.com/sigstore/rekor/pkg/generated/client/pubkey"
+	tlogclient "github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/timestamp"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// mirrorBatchSize is the number of entries copied from the upstream log at a time
+const mirrorBatchSize = 100
+
+// mirroredOrigin is the checkpoint origin of the log replicated by this instance, if any
+var mirroredOrigin string
+
+// errDiverged means that the mirror no longer holds a prefix of the upstream log
+var errDiverged = errors.New("mirror has diverged from the upstream log")
+
+// Mirror copies the entries of another Rekor instance into the local tree, at the same indices,
+// so that this instance serves the same entries, proofs and checkpoints
+type Mirror struct {
+	upstream *client.Rekor
+	// keys are those of the upstream log, which must have signed its checkpoints
+	keys []util.LogKey
+	// next is the index of the next entry to copy; entries up to it may be sequenced but not yet integrated
+	next uint64
+}
+
+func NewMirror(ctx context.Context, upstreamURL string) (*Mirror, error) {
+	upstream, err := rekorclient.GetRekorClient(upstreamURL)
+	if err != nil {
+		return nil, err
+	}
+	m := &Mirror{upstream: upstream}
+	if m.keys, err = upstreamKeys(ctx, upstream); err != nil {
+		return nil, err
+	}
+	sc, err := m.checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mirroredOrigin = sc.Ecosystem
+	return m, nil
+}
+
+// upstreamKeys returns the keys pinned with --mirror_public_key, or else those served by the upstream log
+func upstreamKeys(ctx context.Context, upstream *client.Rekor) ([]util.LogKey, error) {
+	if path := viper.GetString("mirror_public_key"); path != "" {
+		data, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("reading upstream public key: %w", err)
+		}
+		return util.ParseLogKeys(data)
+	}
+	log.Logger.Warn("--mirror_public_key is not set; trusting the public key served by the upstream log")
+	params := pubkeyclient.NewGetPublicKeyParamsWithContext(ctx)
+	params.History = swag.Bool(true)
+	resp, err := upstream.Pubkey.GetPublicKey(params)
+	if err != nil {
+		return nil, fmt.Errorf("fetching upstream public key: %w", err)
+	}
+	return util.ParseLogKeys([]byte(resp.Payload))
+}
+
+// checkpoint returns the latest checkpoint of the upstream log, once its signature is verified
+func (m *Mirror) checkpoint(ctx context.Context) (*util.SignedCheckpoint, error) {
+	resp, err := m.upstream.Tlog.GetCheckpoint(tlogclient.NewGetCheckpointParamsWithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("fetching upstream checkpoint: %w", err)
+	}
+	sc := &util.SignedCheckpoint{}
+	if err := sc.UnmarshalText([]byte(resp.Payload)); err != nil {
+		return nil, fmt.Errorf("parsing upstream checkpoint: %w", err)
+	}
+	if mirroredOrigin != "" && sc.Ecosystem != mirroredOrigin {
+		return nil, fmt.Errorf("upstream checkpoint is for %q rather than %q", sc.Ecosystem, mirroredOrigin)
+	}
+	for _, k := range m.keys {
+		verifier, err := signature.LoadVerifier(k.PublicKey, crypto.SHA256)
+		if err != nil {
+			continue
+		}
+		if sc.Verify(verifier) {
+			return sc, nil
+		}
+	}
+	return nil, errors.New("upstream checkpoint is not signed by the upstream log key")
+}
+
+// Run copies new upstream entries until ctx is done, or until the logs are found to have diverged
+func (m *Mirror) Run(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration("mirror_poll_interval"))
+	defer ticker.Stop()
+	for {
+		if err := m.sync(ctx); err != nil {
+			log.Logger.Errorf("mirroring upstream log: %v", err)
+			if errors.Is(err, errDiverged) {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Mirror) sync(ctx context.Context) error {
+	tc := NewTrillianClient(ctx)
+	root, err := tc.root()
+	if err != nil {
+		return err
+	}
+	sc, err := m.checkpoint(ctx)
+	if err != nil {
+		return err
+	}
+	upstreamSize := sc.Size
+
+	if err := m.verifyConsistency(ctx, root, sc); err != nil {
+		return err
+	}
+	if root.TreeSize == upstreamSize {
+		return m.replicateCosignatures(root, sc)
+	}
+
+	if m.next < root.TreeSize {
+		m.next = root.TreeSize
+	}
+	for m.next < upstreamSize {
+		end := m.next + mirrorBatchSize
+		if end > upstreamSize {
+			end = upstreamSize
+		}
+		leaves, err := m.fetch(ctx, m.next, end, sc)
+		if err != nil {
+			return err
+		}
+		resp := tc.addSequencedLeaves(leaves)
+		if resp.status != codes.OK {
+			return fmt.Errorf("grpc error: %w", resp.err)
+		}
+		for _, result := range resp.getAddSequencedResult.Results {
+			// the entry may have been added before a restart, without being integrated yet
+			if s := result.GetStatus(); s != nil && s.Code != int32(code.Code_OK) && s.Code != int32(code.Code_ALREADY_EXISTS) {
+				return fmt.Errorf("adding entry %d: %v", result.GetLeaf().GetLeafIndex(), s.String())
+			}
+		}
+		m.next = end
+	}
+	return nil
+}
+
+// verifyConsistency checks that the local tree is a prefix of the tree of the upstream checkpoint
+func (m *Mirror) verifyConsistency(ctx context.Context, root ttypes.LogRootV1, sc *util.SignedCheckpoint) error {
+	if root.TreeSize == 0 {
+		return nil
+	}
+	if root.TreeSize > sc.Size {
+		return fmt.Errorf("%w: local tree size %d is larger than upstream tree size %d", errDiverged, root.TreeSize, sc.Size)
+	}
+	if err := m.verifyPrefix(ctx, root.TreeSize, root.RootHash, sc.Size, sc.Hash); err != nil {
+		return fmt.Errorf("%w: %v", errDiverged, err)
+	}
+	return nil
+}
+
+// verifyPrefix checks with a consistency proof from the upstream log that the tree of size1 with hash1 is a
+// prefix of the tree of size2 with hash2
+func (m *Mirror) verifyPrefix(ctx context.Context, size1 uint64, hash1 []byte, size2 uint64, hash2 []byte) error {
+	var hashes [][]byte
+	if size1 < size2 {
+		params := tlogclient.NewGetLogProofParamsWithContext(ctx)
+		params.FirstSize = swag.Int64(int64(size1))
+		params.LastSize = int64(size2)
+		proof, err := m.upstream.Tlog.GetLogProof(params)
+		if err != nil {
+			return fmt.Errorf("fetching upstream consistency proof: %w", err)
+		}
+		if hashes, err = decodeHashes(proof.Payload.Hashes); err != nil {
+			return fmt.Errorf("decoding upstream consistency proof: %w", err)
+		}
+	}
+	v := logverifier.New(rfc6962.DefaultHasher)
+	return v.VerifyConsistencyProof(int64(size1), int64(size2), hash1, hash2, hashes)
+}
+
+func decodeHashes(hexHashes []string) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(hexHashes))
+	for _, h := range hexHashes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, b)
+	}
+	return hashes, nil
+}
+
+// fetch returns the upstream entries in [start, end) as leaves to be added at the same indices, once they are
+// proven to be in the tree of the upstream checkpoint sc, as a PREORDERED_LOG tree cannot be rolled back
+func (m *Mirror) fetch(ctx context.Context, start, end uint64, sc *util.SignedCheckpoint) ([]*trillian.LogLeaf, error) {
+	leaves := make([]*trillian.LogLeaf, end-start)
+	proofs := make([]*models.InclusionProof, end-start)
+	g, gctx := errgroup.WithContext(ctx)
+	for i := start; i < end; i++ {
+		i := i // https://golang.org/doc/faq#closures_and_goroutines
+		g.Go(func() error {
+			params := entriesclient.NewGetLogEntryByIndexParamsWithContext(gctx)
+			params.LogIndex = int64(i)
+			resp, err := m.upstream.Entries.GetLogEntryByIndex(params)
+			if err != nil {
+				return fmt.Errorf("fetching upstream entry %d: %w", i, err)
+			}
+			leaf, proof, err := leafFromLogEntry(resp.Payload, int64(i))
+			if err != nil {
+				return fmt.Errorf("upstream entry %d: %w", i, err)
+			}
+			leaves[i-start], proofs[i-start] = leaf, proof
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := m.verifyIncluded(ctx, leaves, proofs, sc); err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+// verifyIncluded checks the inclusion proofs of the leaves. The trees they were proven against, which are
+// those of the upstream log when each entry was fetched, must be consistent with the tree of the checkpoint.
+func (m *Mirror) verifyIncluded(ctx context.Context, leaves []*trillian.LogLeaf, proofs []*models.InclusionProof, sc *util.SignedCheckpoint) error {
+	v := logverifier.New(rfc6962.DefaultHasher)
+	consistent := map[string]bool{}
+	for i, leaf := range leaves {
+		proof := proofs[i]
+		size := swag.Int64Value(proof.TreeSize)
+		rootHash, err := hex.DecodeString(swag.StringValue(proof.RootHash))
+		if err != nil {
+			return fmt.Errorf("upstream entry %d: decoding root hash: %w", leaf.LeafIndex, err)
+		}
+		hashes, err := decodeHashes(proof.Hashes)
+		if err != nil {
+			return fmt.Errorf("upstream entry %d: decoding inclusion proof: %w", leaf.LeafIndex, err)
+		}
+		leafHash := rfc6962.DefaultHasher.HashLeaf(leaf.LeafValue)
+		if err := v.VerifyInclusionProof(leaf.LeafIndex, size, hashes, rootHash, leafHash); err != nil {
+			return fmt.Errorf("upstream entry %d: verifying inclusion proof: %w", leaf.LeafIndex, err)
+		}
+
+		tree := fmt.Sprintf("%d/%x", size, rootHash)
+		if consistent[tree] {
+			continue
+		}
+		if uint64(size) < sc.Size {
+			err = m.verifyPrefix(ctx, uint64(size), rootHash, sc.Size, sc.Hash)
+		} else {
+			err = m.verifyPrefix(ctx, sc.Size, sc.Hash, uint64(size), rootHash)
+		}
+		if err != nil {
+			return fmt.Errorf("upstream entry %d: tree of inclusion proof is not consistent with the checkpoint: %w", leaf.LeafIndex, err)
+		}
+		consistent[tree] = true
+	}
+	return nil
+}
+
+// leafFromLogEntry recreates the leaf of an upstream entry, keeping its integration time in the extra data, and
+// returns it with its inclusion proof
+func leafFromLogEntry(entry models.LogEntry, index int64) (*trillian.LogLeaf, *models.InclusionProof, error) {
+	for uuid, e := range entry {
+		b64, ok := e.Body.(string)
+		if !ok {
+			return nil, nil, errors.New("unexpected type for entry body")
+		}
+		body, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, nil, err
+		}
+		if uuid != hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(body)) {
+			return nil, nil, errors.New("entry UUID does not match its body")
+		}
+		if e.LogIndex == nil || *e.LogIndex != index || e.IntegratedTime == nil {
+			return nil, nil, errors.New("unexpected log index or integrated time")
+		}
+		if e.Verification == nil || e.Verification.InclusionProof == nil {
+			return nil, nil, errors.New("entry has no inclusion proof")
+		}
+		return &trillian.LogLeaf{
+			LeafIndex: index,
+			LeafValue: body,
+			ExtraData: []byte(strconv.FormatInt(*e.IntegratedTime, 10)),
+		}, e.Verification.InclusionProof, nil
+	}
+	return nil, nil, errors.New("empty log entry")
+}
+
+// replicateCosignatures copies the cosignatures of the configured witnesses from the upstream checkpoint sc, if
+// it matches the local tree head
+func (m *Mirror) replicateCosignatures(root ttypes.LogRootV1, sc *util.SignedCheckpoint) error {
+	if len(witnesses) == 0 || root.TreeSize == 0 {
+		return nil
+	}
+	if sc.Size != root.TreeSize || !bytes.Equal(sc.Hash, root.RootHash) {
+		return nil
+	}
+	sigs, err := verifyCosignatures(*sc)
+	if err != nil {
+		return fmt.Errorf("upstream checkpoint: %w", err)
+	}
+	if len(sigs) > 0 {
+		cosignatures.add(sc.Checkpoint, sigs)
+	}
+	return nil
+}
+
+func readOnlyError() *models.Error {
+	return &models.Error{
+		Code:    http.StatusNotImplemented,
+		Message: readOnlyInstance,
+	}
+}
+
+func CreateLogEntryReadOnlyHandler(params entries.CreateLogEntryParams) middleware.Responder {
+	return entries.NewCreateLogEntryDefault(http.StatusNotImplemented).WithPayload(readOnlyError())
+}
+
+func CreateLogEntriesReadOnlyHandler(params entries.CreateLogEntriesParams) middleware.Responder {
+	return entries.NewCreateLogEntriesDefault(http.StatusNotImplemented).WithPayload(readOnlyError())
+}
+
+func TimestampResponseReadOnlyHandler(params timestamp.GetTimestampResponseParams) middleware.Responder {
+	return timestamp.NewGetTimestampResponseNotImplemented()
+}
+
+func AddCheckpointCosignaturesReadOnlyHandler(params tlog.AddCheckpointCosignaturesParams) middleware.Responder {
+	return tlog.NewAddCheckpointCosignaturesNotImplemented()
+}
@@ -17,10 +17,70 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/spf13/viper"
+
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
+	"github.com/sigstore/rekor/pkg/util"
 )
 
+// keyHistory is the PEM bundle of every key the log has signed with, current key first
+var keyHistory string
+
+// configureKeyHistory builds the key history from the current key and the keys in previous_log_keys
+func configureKeyHistory() error {
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(api.pubkey))
+	if err != nil {
+		return err
+	}
+	current := util.LogKey{PublicKey: pub, LogID: api.pubkeyHash, TreeID: api.logID}
+
+	var previous []util.LogKey
+	if path := viper.GetString("previous_log_keys"); path != "" {
+		data, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return errors.Wrap(err, "reading previous log keys")
+		}
+		if previous, err = util.ParseLogKeys(data); err != nil {
+			return errors.Wrap(err, "parsing previous log keys")
+		}
+	}
+	for _, k := range previous {
+		if k.ActiveUntil.IsZero() {
+			return fmt.Errorf("previous log key %s has no Active-Until header", k.LogID)
+		}
+		if k.LogID == current.LogID {
+			return fmt.Errorf("previous log key %s is the current key", k.LogID)
+		}
+		if k.ActiveUntil.After(current.ActiveFrom) {
+			current.ActiveFrom = k.ActiveUntil
+		}
+	}
+	sort.SliceStable(previous, func(i, j int) bool { return previous[i].ActiveUntil.After(previous[j].ActiveUntil) })
+
+	var bundle []byte
+	for _, k := range append([]util.LogKey{current}, previous...) {
+		b, err := k.MarshalPEM()
+		if err != nil {
+			return err
+		}
+		bundle = append(bundle, b...)
+	}
+	keyHistory = string(bundle)
+	return nil
+}
+
 func GetPublicKeyHandler(params pubkey.GetPublicKeyParams) middleware.Responder {
+	if swag.BoolValue(params.History) {
+		return pubkey.NewGetPublicKeyOK().WithPayload(keyHistory)
+	}
 	return pubkey.NewGetPublicKeyOK().WithPayload(api.pubkey)
 }
@@ -0,0 +1,75 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// clientIP returns the address of the client that sent r, looking through the trusted proxies configured
+// for rate limiting
+func clientIP(r *http.Request) string {
+	if rateLimiter != nil {
+		return rateLimiter.ClientIP(r)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// LogRequests logs each request to the http subsystem once it has been served, with its request ID,
+// client address, user agent, response status and size, and latency
+func LogRequests(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			fields := []interface{}{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"proto", r.Proto,
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"latency", time.Since(start),
+				"clientIP", clientIP(r),
+				"userAgent", r.UserAgent(),
+			}
+			if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+				fields = append(fields, "traceID", sc.TraceID().String())
+			}
+			logger := log.RequestIDLogger(r).Named(log.SubsystemHTTP)
+			if status >= http.StatusInternalServerError {
+				logger.Errorw("served request", fields...)
+			} else {
+				logger.Infow("served request", fields...)
+			}
+		}()
+		handler.ServeHTTP(ww, r)
+	})
+}
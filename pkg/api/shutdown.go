@@ -0,0 +1,98 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+var (
+	// background tracks the index writes, notifications and attestation writes that complete after the
+	// response to the request adding an entry has been sent
+	background sync.WaitGroup
+
+	drainOnce sync.Once
+	draining  = make(chan struct{})
+)
+
+// goBackground runs f in a goroutine that Shutdown waits for
+func goBackground(f func()) {
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		f()
+	}()
+}
+
+// Drain ends the streams of log entries, which otherwise never complete, so that the HTTP server can
+// finish serving in-flight requests
+func Drain() {
+	drainOnce.Do(func() { close(draining) })
+}
+
+// Shutdown waits until ctx is done for the background work started while adding entries, then closes the
+// connections to the log, search index, attestation storage and notification topics. It must be called once
+// no more requests are served.
+func Shutdown(ctx context.Context) error {
+	Drain()
+
+	done := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(done)
+	}()
+	var errs []string
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Sprintf("index writes, notifications or attestation writes not complete: %v", ctx.Err()))
+	}
+
+	// the log is closed last, as the tiles backend integrates the leaves still queued when closed
+	type component struct {
+		name  string
+		value interface{}
+	}
+	components := []component{
+		{"notification topics", notifier},
+		{"search index", indexStorage},
+		{"attestation storage", storageClient},
+	}
+	if api != nil {
+		components = append(components, component{"log", api.logCloser})
+	}
+	for _, c := range components {
+		closer, ok := c.value.(io.Closer)
+		if !ok {
+			continue
+		}
+		log.Logger.Infof("Closing %s", c.name)
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("closing %s: %v", c.name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+const (
+	// maximum number of leaves requested from trillian at a time
+	streamBatchSize = 100
+	// interval at which a comment is sent to idle clients, so proxies don't close the connection
+	streamKeepAlive = 15 * time.Second
+)
+
+// treeWatcher polls the log and tells subscribers when its size changes; a single
+// watcher is shared by all subscribers so that the number of clients doesn't affect
+// the load on trillian while the log is idle
+type treeWatcher struct {
+	start sync.Once
+
+	mu          sync.Mutex
+	treeSize    int64
+	subscribers map[chan int64]struct{}
+}
+
+var watcher = &treeWatcher{subscribers: map[chan int64]struct{}{}}
+
+// subscribe returns a channel that receives the latest tree size whenever the log grows,
+// and a function to stop receiving updates. Updates are coalesced for slow subscribers.
+func (w *treeWatcher) subscribe() (<-chan int64, func()) {
+	w.start.Do(func() {
+		go w.poll(viper.GetDuration("stream_poll_interval"))
+	})
+
+	updates := make(chan int64, 1)
+	w.mu.Lock()
+	w.subscribers[updates] = struct{}{}
+	w.mu.Unlock()
+
+	return updates, func() {
+		w.mu.Lock()
+		delete(w.subscribers, updates)
+		w.mu.Unlock()
+	}
+}
+
+func (w *treeWatcher) poll(interval time.Duration) {
+	tc := NewTrillianClient(context.Background())
+	for {
+		if size, err := currentTreeSize(tc); err != nil {
+			log.Logger.Errorf("polling tree size: %v", err)
+		} else {
+			w.update(size)
+		}
+		time.Sleep(interval)
+	}
+}
+
+func (w *treeWatcher) update(size int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if size <= w.treeSize {
+		return
+	}
+	w.treeSize = size
+	for updates := range w.subscribers {
+		// replace any update the subscriber hasn't consumed yet
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- size
+	}
+}
+
+func currentTreeSize(tc TrillianClient) (int64, error) {
+	resp := tc.getLatest(0)
+	if resp.status != codes.OK {
+		return 0, fmt.Errorf("grpc error: %w", resp.err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.getLatestResult.SignedLogRoot.LogRoot); err != nil {
+		return 0, err
+	}
+	return int64(root.TreeSize), nil
+}
+
+// leavesInRange fetches the leaves with indexes in [start, end) from the log, in order
+func leavesInRange(tc TrillianClient, start, end int64) ([]*trillian.LogLeaf, error) {
+	var leaves []*trillian.LogLeaf
+	for start < end {
+		count := end - start
+		if count > streamBatchSize {
+			count = streamBatchSize
+		}
+		resp := tc.getLeavesByRange(start, count)
+		if resp.status != codes.OK {
+			return nil, fmt.Errorf("grpc error: %w", resp.err)
+		}
+		// trillian may return fewer leaves than requested
+		result := resp.getLeavesByRangeResult.Leaves
+		if len(result) == 0 {
+			return nil, fmt.Errorf("no leaves returned for index %d", start)
+		}
+		leaves = append(leaves, result...)
+		start += int64(len(result))
+	}
+	return leaves, nil
+}
+
+// writeEntryEvent writes a leaf as a Server-Sent Event whose ID is the log index
+func writeEntryEvent(rw http.ResponseWriter, leaf *trillian.LogLeaf) error {
+	body := strfmt.Base64(leaf.LeafValue)
+	data, err := json.Marshal(&models.StreamedLogEntry{
+		UUID:     swag.String(hex.EncodeToString(leaf.MerkleLeafHash)),
+		LogIndex: swag.Int64(leaf.LeafIndex),
+		Body:     &body,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(rw, "id: %d\nevent: entry\ndata: %s\n\n", leaf.LeafIndex, data)
+	return err
+}
+
+// StreamLogEntriesHandler streams entries to the client as Server-Sent Events as they are integrated into the log
+func StreamLogEntriesHandler(params entries.StreamLogEntriesParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+	tc := NewTrillianClient(ctx)
+
+	next := int64(-1)
+	if params.StartIndex != nil {
+		next = *params.StartIndex
+	}
+	if params.LastEventID != nil {
+		lastIndex, err := strconv.ParseInt(*params.LastEventID, 10, 64)
+		if err != nil || lastIndex < 0 {
+			return handleRekorAPIError(params, http.StatusBadRequest, err, malformedLastEventID)
+		}
+		next = lastIndex + 1
+	}
+
+	// subscribe before reading the tree size so that no growth is missed
+	updates, unsubscribe := watcher.subscribe()
+	size, err := currentTreeSize(tc)
+	if err != nil {
+		unsubscribe()
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianCommunicationError)
+	}
+	if next < 0 {
+		next = size
+	}
+
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		defer unsubscribe()
+
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			log.RequestIDLogger(params.HTTPRequest).Error("response writer does not support streaming")
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		send := func(size int64) error {
+			for next < size {
+				end := next + streamBatchSize
+				if end > size {
+					end = size
+				}
+				leaves, err := leavesInRange(tc, next, end)
+				if err != nil {
+					return err
+				}
+				for _, leaf := range leaves {
+					if err := writeEntryEvent(rw, leaf); err != nil {
+						return err
+					}
+				}
+				flusher.Flush()
+				next = end
+			}
+			return nil
+		}
+
+		keepAlive := time.NewTicker(streamKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			if err := send(size); err != nil {
+				if ctx.Err() == nil {
+					log.RequestIDLogger(params.HTTPRequest).Errorf("streaming entries: %v", err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-draining:
+				// clients reconnect to another replica with Last-Event-ID
+				return
+			case size = <-updates:
+			case <-keepAlive.C:
+				if _, err := fmt.Fprint(rw, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
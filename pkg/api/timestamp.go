@@ -80,7 +80,7 @@ func TimestampResponseHandler(params timestamp.GetTimestampResponseParams) middl
 	}
 
 	// If middleware is returned, this indicates an error.
-	logEntry, middleware := createLogEntry(entryParams)
+	logEntry, _, middleware := createLogEntry(entryParams)
 	if middleware != nil {
 		return middleware
 	}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/trillian/merkle/logverifier"
@@ -58,6 +59,8 @@ type Response struct {
 	getLeafAndProofResult     *trillian.GetEntryAndProofResponse
 	getLatestResult           *trillian.GetLatestSignedLogRootResponse
 	getConsistencyProofResult *trillian.GetConsistencyProofResponse
+	getLeavesByRangeResult    *trillian.GetLeavesByRangeResponse
+	getAddSequencedResult     *trillian.AddSequencedLeavesResponse
 }
 
 func (t *TrillianClient) root() (types.LogRootV1, error) {
@@ -76,24 +79,66 @@ func (t *TrillianClient) root() (types.LogRootV1, error) {
 }
 
 func (t *TrillianClient) addLeaf(byteValue []byte) *Response {
-	leaf := &trillian.LogLeaf{
-		LeafValue: byteValue,
-	}
-	rqst := &trillian.QueueLeafRequest{
-		LogId: t.logID,
-		Leaf:  leaf,
+	return t.addLeaves([][]byte{byteValue})[0]
+}
+
+// addLeaves queues all of the supplied leaves before waiting for any of them to be
+// integrated, so that leaves submitted together are likely to be sequenced together.
+// The responses are returned in the same order as the leaves.
+func (t *TrillianClient) addLeaves(byteValues [][]byte) []*Response {
+	responses := make([]*Response, len(byteValues))
+	queued := make([]*trillian.QueueLeafResponse, len(byteValues))
+
+	var wg sync.WaitGroup
+	for i, byteValue := range byteValues {
+		i, byteValue := i, byteValue // https://golang.org/doc/faq#closures_and_goroutines
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rqst := &trillian.QueueLeafRequest{
+				LogId: t.logID,
+				Leaf: &trillian.LogLeaf{
+					LeafValue: byteValue,
+				},
+			}
+			start := time.Now()
+			resp, err := t.client.QueueLeaf(t.context, rqst)
+			metricTrillianLatency.With(map[string]string{"stage": "queue", "code": status.Code(err).String()}).Observe(time.Since(start).Seconds())
+
+			// check for error
+			if err != nil || (resp.QueuedLeaf.Status != nil && resp.QueuedLeaf.Status.Code != int32(codes.OK)) {
+				responses[i] = &Response{
+					status:       status.Code(err),
+					err:          err,
+					getAddResult: resp,
+				}
+				return
+			}
+			queued[i] = resp
+		}()
 	}
-	resp, err := t.client.QueueLeaf(t.context, rqst)
+	wg.Wait()
 
-	// check for error
-	if err != nil || (resp.QueuedLeaf.Status != nil && resp.QueuedLeaf.Status.Code != int32(codes.OK)) {
-		return &Response{
-			status:       status.Code(err),
-			err:          err,
-			getAddResult: resp,
+	for i, resp := range queued {
+		if resp == nil {
+			continue
 		}
+		i, resp := i, resp // https://golang.org/doc/faq#closures_and_goroutines
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			responses[i] = t.waitForLeaf(resp)
+			metricTrillianLatency.With(map[string]string{"stage": "inclusion", "code": responses[i].status.String()}).Observe(time.Since(start).Seconds())
+		}()
 	}
+	wg.Wait()
+
+	return responses
+}
 
+// waitForLeaf waits for a queued leaf to be integrated into the log, and fills in its index
+func (t *TrillianClient) waitForLeaf(resp *trillian.QueueLeafResponse) *Response {
 	root, err := t.root()
 	if err != nil {
 		return &Response{
@@ -232,6 +277,42 @@ func (t *TrillianClient) getLeafAndProofByIndex(index int64) *Response {
 	}
 }
 
+func (t *TrillianClient) getLeavesByRange(startIndex, count int64) *Response {
+	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
+	defer cancel()
+
+	resp, err := t.client.GetLeavesByRange(ctx,
+		&trillian.GetLeavesByRangeRequest{
+			LogId:      t.logID,
+			StartIndex: startIndex,
+			Count:      count,
+		})
+
+	return &Response{
+		status:                 status.Code(err),
+		err:                    err,
+		getLeavesByRangeResult: resp,
+	}
+}
+
+// addSequencedLeaves adds leaves at the indices they already have, which requires a PREORDERED_LOG tree
+func (t *TrillianClient) addSequencedLeaves(leaves []*trillian.LogLeaf) *Response {
+	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
+	defer cancel()
+
+	resp, err := t.client.AddSequencedLeaves(ctx,
+		&trillian.AddSequencedLeavesRequest{
+			LogId:  t.logID,
+			Leaves: leaves,
+		})
+
+	return &Response{
+		status:                status.Code(err),
+		err:                   err,
+		getAddSequencedResult: resp,
+	}
+}
+
 func (t *TrillianClient) getProofByHash(hashValue []byte) *Response {
 	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
 	defer cancel()
@@ -306,7 +387,7 @@ func (t *TrillianClient) getConsistencyProof(firstSize, lastSize int64) *Respons
 	}
 }
 
-func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient) (*trillian.Tree, error) {
+func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient, treeType trillian.TreeType) (*trillian.Tree, error) {
 	// First look for and use an existing tree
 	trees, err := adminClient.ListTrees(ctx, &trillian.ListTreesRequest{})
 	if err != nil {
@@ -314,7 +395,7 @@ func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminCl
 	}
 
 	for _, t := range trees.Tree {
-		if t.TreeType == trillian.TreeType_LOG {
+		if t.TreeType == treeType {
 			return t, nil
 		}
 	}
@@ -322,7 +403,7 @@ func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminCl
 	// Otherwise create and initialize one
 	t, err := adminClient.CreateTree(ctx, &trillian.CreateTreeRequest{
 		Tree: &trillian.Tree{
-			TreeType:        trillian.TreeType_LOG,
+			TreeType:        treeType,
 			TreeState:       trillian.TreeState_ACTIVE,
 			MaxRootDuration: durationpb.New(time.Hour),
 		},
@@ -0,0 +1,198 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/sync/singleflight"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+var errNoCredentials = errors.New("no credentials")
+
+// Config lists the credentials accepted from clients adding entries; at least one API key or issuer must be set
+type Config struct {
+	APIKeys []string
+	// OIDCIssuers are the URLs of the OpenID Connect providers whose ID tokens are accepted
+	OIDCIssuers []string
+	// OIDCAudiences are the audiences accepted in ID tokens; a token is accepted if it is issued for any of them
+	OIDCAudiences []string
+}
+
+// Authenticator checks the API keys and OIDC ID tokens presented by clients
+type Authenticator struct {
+	// apiKeys holds the SHA-256 digests of the keys, so that lookups don't leak the keys through timing
+	apiKeys   map[[sha256.Size]byte]string
+	issuers   map[string]*issuer
+	audiences []string
+}
+
+var (
+	// discoveryTimeout bounds each request to an OIDC provider, both for discovery and for fetching its keys
+	discoveryTimeout = 10 * time.Second
+	// discoveryRetryDelay is how long a failed discovery is reported to clients before it is tried again
+	discoveryRetryDelay = 10 * time.Second
+)
+
+// issuer discovers the keys of an OIDC provider the first time one of its tokens is seen, so that
+// rekor-server can start while the provider is unreachable
+type issuer struct {
+	url       string
+	discovery singleflight.Group
+
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+	// err is the error of the last discovery, at failedAt
+	err      error
+	failedAt time.Time
+}
+
+// New returns an Authenticator for cfg
+func New(cfg Config) (*Authenticator, error) {
+	if len(cfg.APIKeys) == 0 && len(cfg.OIDCIssuers) == 0 {
+		return nil, errors.New("no API keys or OIDC issuers configured")
+	}
+	if len(cfg.OIDCIssuers) > 0 && len(cfg.OIDCAudiences) == 0 {
+		// without an audience, tokens minted for any other application of the provider would be accepted
+		return nil, errors.New("OIDC issuers require at least one audience")
+	}
+	a := &Authenticator{
+		apiKeys:   map[[sha256.Size]byte]string{},
+		issuers:   map[string]*issuer{},
+		audiences: cfg.OIDCAudiences,
+	}
+	for _, key := range cfg.APIKeys {
+		if key == "" {
+			return nil, errors.New("API keys must not be empty")
+		}
+		digest := sha256.Sum256([]byte(key))
+		// identify clients by a prefix of the digest, so the key itself never ends up in logs
+		a.apiKeys[digest] = "api-key:" + hex.EncodeToString(digest[:4])
+	}
+	for _, url := range cfg.OIDCIssuers {
+		a.issuers[url] = &issuer{url: url}
+	}
+	return a, nil
+}
+
+// Authenticate returns the identity of the client presenting token: the issuer and subject of an ID token,
+// or a digest of an API key
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", errNoCredentials
+	}
+	if id, ok := a.apiKeys[sha256.Sum256([]byte(token))]; ok {
+		return id, nil
+	}
+	if len(a.issuers) == 0 || strings.Count(token, ".") != 2 {
+		return "", errors.New("invalid API key")
+	}
+
+	// the issuer is only used to pick the verifier; the verifier checks it again along with the signature
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return "", fmt.Errorf("parsing ID token: %w", err)
+	}
+	var claims jwt.Claims
+	if err := parsed.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return "", fmt.Errorf("parsing ID token: %w", err)
+	}
+	iss, ok := a.issuers[claims.Issuer]
+	if !ok {
+		return "", fmt.Errorf("ID token issuer %q is not allowed", claims.Issuer)
+	}
+	verifier, err := iss.getVerifier(ctx)
+	if err != nil {
+		return "", err
+	}
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("verifying ID token: %w", err)
+	}
+	for _, aud := range idToken.Audience {
+		for _, allowed := range a.audiences {
+			if aud == allowed {
+				return idToken.Issuer + ":" + idToken.Subject, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("ID token audience %v is not allowed", idToken.Audience)
+}
+
+// getVerifier returns the verifier of the issuer, discovering the provider if needed. Concurrent callers share a
+// single discovery, which each of them stops waiting for once its ctx is done.
+func (i *issuer) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	i.mu.Lock()
+	verifier, err, failedAt := i.verifier, i.err, i.failedAt
+	i.mu.Unlock()
+	if verifier != nil {
+		return verifier, nil
+	}
+	if err != nil && time.Since(failedAt) < discoveryRetryDelay {
+		return nil, err
+	}
+
+	ch := i.discovery.DoChan(i.url, i.discover)
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("discovering OIDC provider %v: %w", i.url, ctx.Err())
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*oidc.IDTokenVerifier), nil
+	}
+}
+
+func (i *issuer) discover() (interface{}, error) {
+	// the provider keeps using this context to refresh its keys, so it must not be tied to a request; the
+	// timeout of the client bounds each of its requests instead
+	ctx := oidc.ClientContext(context.Background(), &http.Client{Timeout: discoveryTimeout})
+	provider, err := oidc.NewProvider(ctx, i.url)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if err != nil {
+		i.err, i.failedAt = fmt.Errorf("discovering OIDC provider %v: %w", i.url, err), time.Now()
+		return nil, i.err
+	}
+	// audiences are checked against the allowlist by Authenticate
+	i.verifier, i.err = provider.Verifier(&oidc.Config{SkipClientIDCheck: true}), nil
+	return i.verifier, nil
+}
+
+type identityKey struct{}
+
+// ContextWithIdentity returns a copy of ctx carrying the identity of the authenticated client
+func ContextWithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the authenticated client, or "" if the request was not authenticated
+func IdentityFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(identityKey{}).(string)
+	return id
+}
@@ -0,0 +1,251 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// fakeIssuer is an OIDC provider that serves its discovery document and signing key
+type fakeIssuer struct {
+	url    string
+	signer jose.Signer
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: priv, KeyID: "1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeIssuer{signer: signer}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                f.url,
+			"jwks_uri":                              f.url + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: priv.Public(), KeyID: "1", Algorithm: "RS256", Use: "sig"}}})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	f.url = srv.URL
+	return f
+}
+
+func (f *fakeIssuer) token(t *testing.T, audience string, expiry time.Time) string {
+	t.Helper()
+	token, err := jwt.Signed(f.signer).Claims(jwt.Claims{
+		Issuer:   f.url,
+		Subject:  "alice",
+		Audience: jwt.Audience{audience},
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(expiry),
+	}).CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+	trusted := newFakeIssuer(t)
+	untrusted := newFakeIssuer(t)
+	a, err := New(Config{
+		APIKeys:       []string{"key1"},
+		OIDCIssuers:   []string{trusted.url},
+		OIDCAudiences: []string{"rekor"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hour := time.Now().Add(time.Hour)
+	keyDigest := sha256.Sum256([]byte("key1"))
+
+	for _, test := range []struct {
+		desc    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{desc: "API key", token: "key1", want: "api-key:" + hex.EncodeToString(keyDigest[:4])},
+		{desc: "wrong API key", token: "key2", wantErr: true},
+		{desc: "no credentials", token: "", wantErr: true},
+		{desc: "ID token", token: trusted.token(t, "rekor", hour), want: trusted.url + ":alice"},
+		{desc: "wrong audience", token: trusted.token(t, "other", hour), wantErr: true},
+		{desc: "expired", token: trusted.token(t, "rekor", time.Now().Add(-time.Hour)), wantErr: true},
+		{desc: "untrusted issuer", token: untrusted.token(t, "rekor", hour), wantErr: true},
+		{desc: "malformed token", token: "a.b.c", wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := a.Authenticate(context.Background(), test.token)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Authenticate error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("Authenticate = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUnresponsiveIssuer(t *testing.T) {
+	oldTimeout, oldDelay := discoveryTimeout, discoveryRetryDelay
+	discoveryTimeout, discoveryRetryDelay = 200*time.Millisecond, time.Hour
+	defer func() { discoveryTimeout, discoveryRetryDelay = oldTimeout, oldDelay }()
+
+	var mu sync.Mutex
+	discoveries := 0
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		discoveries++
+		mu.Unlock()
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	a, err := New(Config{OIDCIssuers: []string{srv.URL}, OIDCAudiences: []string{"rekor"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(newFakeIssuer(t).signer).Claims(jwt.Claims{Issuer: srv.URL, Audience: jwt.Audience{"rekor"}}).CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// callers stop waiting when their requests are done, while sharing a single discovery
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if _, err := a.Authenticate(ctx, token); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Authenticate error = %v, want %v", err, context.DeadlineExceeded)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the discovery times out, and its failure is returned without trying again
+	time.Sleep(2 * discoveryTimeout)
+	if _, err := a.Authenticate(context.Background(), token); err == nil {
+		t.Fatal("Authenticate succeeded with an unresponsive issuer")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if discoveries != 1 {
+		t.Errorf("provider was queried %d times, want 1", discoveries)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		cfg  Config
+	}{
+		{desc: "nothing configured", cfg: Config{}},
+		{desc: "issuer without audience", cfg: Config{OIDCIssuers: []string{"https://oauth2.example.com"}}},
+		{desc: "empty API key", cfg: Config{APIKeys: []string{""}}},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := New(test.cfg); err == nil {
+				t.Errorf("New(%+v) succeeded, want error", test.cfg)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	a, err := New(Config{APIKeys: []string{"key1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var identity string
+	rejected := 0
+	h := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity = IdentityFromContext(r.Context())
+		w.WriteHeader(http.StatusCreated)
+	}), func() { rejected++ })
+
+	for _, test := range []struct {
+		desc     string
+		method   string
+		target   string
+		header   string
+		wantCode int
+		wantID   bool
+	}{
+		{desc: "read", method: http.MethodGet, target: "/api/v1/log/entries/1234", wantCode: http.StatusCreated},
+		{desc: "search", method: http.MethodPost, target: "/api/v1/log/entries/retrieve", wantCode: http.StatusCreated},
+		{desc: "unauthenticated write", method: http.MethodPost, target: "/api/v1/log/entries", wantCode: http.StatusUnauthorized},
+		{desc: "batch write", method: http.MethodPost, target: "/api/v1/log/entries/batch", wantCode: http.StatusUnauthorized},
+		// spellings the router dispatches to the same endpoint
+		{desc: "lower-case method", method: "post", target: "/api/v1/log/entries", wantCode: http.StatusUnauthorized},
+		{desc: "empty path segment", method: http.MethodPost, target: "/api/v1//log/entries", wantCode: http.StatusUnauthorized},
+		{desc: "dot path segment", method: http.MethodPost, target: "/api/v1/log/./entries", wantCode: http.StatusUnauthorized},
+		{desc: "trailing slash", method: http.MethodPost, target: "/api/v1/log/entries/", wantCode: http.StatusUnauthorized},
+		{desc: "wrong key", method: http.MethodPost, target: "/api/v1/log/entries", header: "Bearer key2", wantCode: http.StatusUnauthorized},
+		{desc: "bearer key", method: http.MethodPost, target: "/api/v1/log/entries", header: "bearer key1", wantCode: http.StatusCreated, wantID: true},
+		{desc: "query key", method: http.MethodPost, target: "/api/v1/log/entries?apiKey=key1", wantCode: http.StatusCreated, wantID: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			identity = ""
+			r := httptest.NewRequest(test.method, test.target, nil)
+			if test.header != "" {
+				r.Header.Set("Authorization", test.header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != test.wantCode {
+				t.Fatalf("got %d, want %d", w.Code, test.wantCode)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("WWW-Authenticate = %q", w.Header().Get("WWW-Authenticate"))
+			}
+			if (identity != "") != test.wantID {
+				t.Errorf("identity = %q", identity)
+			}
+		})
+	}
+	if rejected != 7 {
+		t.Errorf("rejected %d requests, want 7", rejected)
+	}
+}
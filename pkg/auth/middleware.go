@@ -0,0 +1,108 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// protectedEndpoints are the POST endpoints that add entries to the log
+var protectedEndpoints = map[string]bool{
+	"/api/v1/log/entries":       true,
+	"/api/v1/log/entries/batch": true,
+}
+
+// protectedMethods are the gRPC methods that add entries to the log
+var protectedMethods = map[string]bool{
+	"/rekor.v1.Rekor/CreateLogEntry": true,
+}
+
+// Middleware requires the requests adding entries to present an API key or ID token, either as a bearer
+// token or, for API keys, in the apiKey query parameter sent by rekor-cli --api-key. Other requests are
+// passed through unauthenticated. rejected is called for each request refused, if set.
+func (a *Authenticator) Middleware(next http.Handler, rejected func()) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if method, path := util.Route(r); method != http.MethodPost || !protectedEndpoints[path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := bearerToken(r.Header.Get("Authorization"))
+		if token == "" {
+			token = r.URL.Query().Get("apiKey")
+		}
+		identity, err := a.Authenticate(r.Context(), token)
+		if err == nil {
+			next.ServeHTTP(w, r.WithContext(ContextWithIdentity(r.Context(), identity)))
+			return
+		}
+		log.RequestIDLogger(r).Infof("rejecting unauthenticated request: %v", err)
+		if rejected != nil {
+			rejected()
+		}
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(&models.Error{
+			Code:    http.StatusUnauthorized,
+			Message: "A valid API key or ID token is required to add entries",
+		})
+	})
+}
+
+// UnaryServerInterceptor requires gRPC calls adding entries to send an API key or ID token as a bearer token
+// in the authorization metadata
+func (a *Authenticator) UnaryServerInterceptor(rejected func()) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !protectedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = bearerToken(values[0])
+			}
+		}
+		identity, err := a.Authenticate(ctx, token)
+		if err != nil {
+			log.Logger.Infof("rejecting unauthenticated gRPC call: %v", err)
+			if rejected != nil {
+				rejected()
+			}
+			return nil, status.Error(codes.Unauthenticated, "a valid API key or ID token is required to add entries")
+		}
+		return handler(ContextWithIdentity(ctx, identity), req)
+	}
+}
+
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
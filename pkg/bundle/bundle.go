@@ -0,0 +1,221 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle holds everything needed to verify that an entry is in a Rekor log without contacting the
+// log: the canonicalized entry, its signed entry timestamp, an inclusion proof and a signed checkpoint
+package bundle
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// Bundle is an entry in a Rekor log with the proofs that it is
+type Bundle struct {
+	// UUID is the hex-encoded leaf hash of the entry
+	UUID           string `json:"uuid"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	// Body is the canonicalized entry
+	Body                 []byte         `json:"body"`
+	SignedEntryTimestamp []byte         `json:"signedEntryTimestamp"`
+	InclusionProof       InclusionProof `json:"inclusionProof"`
+	// Checkpoint is the signed tree head of the log, in signed note format
+	Checkpoint string `json:"checkpoint"`
+	// ConsistencyProof proves that the tree of the inclusion proof is a prefix of the tree of the checkpoint,
+	// if the log grew in between them being fetched
+	ConsistencyProof []string `json:"consistencyProof,omitempty"`
+}
+
+// InclusionProof proves that the entry is in the tree of size TreeSize
+type InclusionProof struct {
+	TreeSize int64    `json:"treeSize"`
+	RootHash string   `json:"rootHash"`
+	Hashes   []string `json:"hashes"`
+}
+
+// New creates a bundle for an entry as returned by the log, with its inclusion proof. checkpoint must be of a
+// tree at least as large as that of the inclusion proof, with consistencyProof proving the latter is a prefix
+// of the former if they differ.
+func New(uuid string, entry models.LogEntryAnon, checkpoint string, consistencyProof []string) (*Bundle, error) {
+	if entry.Verification == nil || entry.Verification.InclusionProof == nil {
+		return nil, errors.New("entry has no inclusion proof")
+	}
+	if len(entry.Verification.SignedEntryTimestamp) == 0 {
+		return nil, errors.New("entry has no signed entry timestamp")
+	}
+	var body []byte
+	switch b := entry.Body.(type) {
+	case []byte:
+		body = b
+	case string:
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(b); err != nil {
+			return nil, fmt.Errorf("decoding entry body: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected entry body of type %T", entry.Body)
+	}
+	proof := entry.Verification.InclusionProof
+	return &Bundle{
+		UUID:                 uuid,
+		LogID:                swag.StringValue(entry.LogID),
+		LogIndex:             swag.Int64Value(entry.LogIndex),
+		IntegratedTime:       swag.Int64Value(entry.IntegratedTime),
+		Body:                 body,
+		SignedEntryTimestamp: entry.Verification.SignedEntryTimestamp,
+		InclusionProof: InclusionProof{
+			TreeSize: swag.Int64Value(proof.TreeSize),
+			RootHash: swag.StringValue(proof.RootHash),
+			Hashes:   proof.Hashes,
+		},
+		Checkpoint:       checkpoint,
+		ConsistencyProof: consistencyProof,
+	}, nil
+}
+
+// Verify checks the signed entry timestamp of the entry against the key of keys with its log ID, which must
+// have been active when the entry was integrated, and that the entry is included in the tree of the
+// checkpoint, which must be signed by one of keys
+func (b *Bundle) Verify(keys []util.LogKey) error {
+	leafHash := rfc6962.DefaultHasher.HashLeaf(b.Body)
+	if !strings.EqualFold(b.UUID, hex.EncodeToString(leafHash)) {
+		return fmt.Errorf("UUID %s is not the leaf hash of the entry", b.UUID)
+	}
+
+	var entryKey *util.LogKey
+	for i := range keys {
+		if strings.EqualFold(keys[i].LogID, b.LogID) {
+			entryKey = &keys[i]
+			break
+		}
+	}
+	if entryKey == nil {
+		return fmt.Errorf("no key for log ID %s", b.LogID)
+	}
+	integrated := time.Unix(b.IntegratedTime, 0)
+	if !entryKey.ActiveAt(integrated) {
+		return fmt.Errorf("key for log ID %s was not active at %v, when the entry was integrated", b.LogID, integrated.UTC())
+	}
+	if err := b.verifySignedEntryTimestamp(entryKey.PublicKey); err != nil {
+		return err
+	}
+
+	rootHash, err := hex.DecodeString(b.InclusionProof.RootHash)
+	if err != nil {
+		return fmt.Errorf("decoding root hash: %w", err)
+	}
+	hashes, err := decodeHashes(b.InclusionProof.Hashes)
+	if err != nil {
+		return fmt.Errorf("decoding inclusion proof: %w", err)
+	}
+	v := logverifier.New(rfc6962.DefaultHasher)
+	if err := v.VerifyInclusionProof(b.LogIndex, b.InclusionProof.TreeSize, hashes, rootHash, leafHash); err != nil {
+		return fmt.Errorf("verifying inclusion proof: %w", err)
+	}
+
+	sth, err := b.verifyCheckpoint(keys)
+	if err != nil {
+		return err
+	}
+	size := int64(sth.Size)
+	switch {
+	case size < b.InclusionProof.TreeSize:
+		return fmt.Errorf("checkpoint of tree size %d is older than the inclusion proof of tree size %d", size, b.InclusionProof.TreeSize)
+	case size == b.InclusionProof.TreeSize:
+		if !bytes.Equal(sth.Hash, rootHash) {
+			return errors.New("root hash of the inclusion proof does not match the checkpoint")
+		}
+	default:
+		consistency, err := decodeHashes(b.ConsistencyProof)
+		if err != nil {
+			return fmt.Errorf("decoding consistency proof: %w", err)
+		}
+		if err := v.VerifyConsistencyProof(b.InclusionProof.TreeSize, size, rootHash, sth.Hash, consistency); err != nil {
+			return fmt.Errorf("verifying consistency of the inclusion proof with the checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) verifySignedEntryTimestamp(pub crypto.PublicKey) error {
+	le := &models.LogEntryAnon{
+		IntegratedTime: swag.Int64(b.IntegratedTime),
+		LogIndex:       swag.Int64(b.LogIndex),
+		Body:           b.Body,
+		LogID:          swag.String(b.LogID),
+	}
+	payload, err := le.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	canonicalized, err := jsoncanonicalizer.Transform(payload)
+	if err != nil {
+		return err
+	}
+	verifier, err := signature.LoadVerifier(pub, crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	if err := verifier.VerifySignature(bytes.NewReader(b.SignedEntryTimestamp), bytes.NewReader(canonicalized)); err != nil {
+		return fmt.Errorf("verifying signed entry timestamp: %w", err)
+	}
+	return nil
+}
+
+func (b *Bundle) verifyCheckpoint(keys []util.LogKey) (*util.SignedCheckpoint, error) {
+	sth := &util.SignedCheckpoint{}
+	if err := sth.UnmarshalText([]byte(b.Checkpoint)); err != nil {
+		return nil, fmt.Errorf("parsing checkpoint: %w", err)
+	}
+	for _, k := range keys {
+		verifier, err := signature.LoadVerifier(k.PublicKey, crypto.SHA256)
+		if err != nil {
+			continue
+		}
+		if sth.Verify(verifier) {
+			return sth, nil
+		}
+	}
+	return nil, errors.New("checkpoint is not signed by any of the log keys")
+}
+
+func decodeHashes(hexHashes []string) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(hexHashes))
+	for _, h := range hexHashes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, b)
+	}
+	return hashes, nil
+}
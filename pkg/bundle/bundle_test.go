@@ -0,0 +1,200 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+type testLog struct {
+	t      *testing.T
+	signer signature.SignerVerifier
+	key    util.LogKey
+}
+
+func newTestLog(t *testing.T) *testLog {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadECDSASignerVerifier(priv, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(der)
+	return &testLog{t: t, signer: signer, key: util.LogKey{PublicKey: &priv.PublicKey, LogID: hex.EncodeToString(h[:])}}
+}
+
+// entry returns the entry at index with body as returned by the API, with an inclusion proof
+func (l *testLog) entry(index int64, body []byte, proof models.InclusionProof) models.LogEntryAnon {
+	l.t.Helper()
+	e := models.LogEntryAnon{
+		LogID:          swag.String(l.key.LogID),
+		LogIndex:       swag.Int64(index),
+		Body:           body,
+		IntegratedTime: swag.Int64(time.Now().Unix()),
+	}
+	payload, err := e.MarshalBinary()
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	canonicalized, err := jsoncanonicalizer.Transform(payload)
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	set, err := l.signer.SignMessage(bytes.NewReader(canonicalized))
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	// the client decodes the body as a base64 string
+	e.Body = base64.StdEncoding.EncodeToString(body)
+	e.Verification = &models.LogEntryAnonVerification{InclusionProof: &proof, SignedEntryTimestamp: strfmt.Base64(set)}
+	return e
+}
+
+func (l *testLog) checkpoint(size uint64, root []byte) string {
+	l.t.Helper()
+	sth, err := util.CreateSignedCheckpoint(util.Checkpoint{Ecosystem: "Rekor", Size: size, Hash: root})
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	if _, err := sth.Sign("rekor.example.com", l.signer, options.WithContext(context.Background())); err != nil {
+		l.t.Fatal(err)
+	}
+	text, err := sth.MarshalText()
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	return string(text)
+}
+
+func TestVerify(t *testing.T) {
+	l := newTestLog(t)
+	bodies := [][]byte{[]byte(`{"kind":"a"}`), []byte(`{"kind":"b"}`), []byte(`{"kind":"c"}`)}
+	hasher := rfc6962.DefaultHasher
+	h0, h1, h2 := hasher.HashLeaf(bodies[0]), hasher.HashLeaf(bodies[1]), hasher.HashLeaf(bodies[2])
+	root2 := hasher.HashChildren(h0, h1)
+	root3 := hasher.HashChildren(root2, h2)
+	uuid := hex.EncodeToString(h1)
+
+	// entry 1 is proven to be in the tree of size 2
+	proof := models.InclusionProof{
+		TreeSize: swag.Int64(2),
+		RootHash: swag.String(hex.EncodeToString(root2)),
+		LogIndex: swag.Int64(1),
+		Hashes:   []string{hex.EncodeToString(h0)},
+	}
+	entry := l.entry(1, bodies[1], proof)
+
+	sameSize, err := New(uuid, entry, l.checkpoint(2, root2), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grown, err := New(uuid, entry, l.checkpoint(3, root3), []string{hex.EncodeToString(h2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []util.LogKey{l.key}
+	for name, b := range map[string]*Bundle{"same size": sameSize, "grown": grown} {
+		// bundles are written to and read from files
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var read Bundle
+		if err := json.Unmarshal(data, &read); err != nil {
+			t.Fatal(err)
+		}
+		if err := read.Verify(keys); err != nil {
+			t.Errorf("%s: Verify() = %v", name, err)
+		}
+	}
+
+	other := newTestLog(t)
+	tests := map[string]struct {
+		modify func(b *Bundle)
+		keys   []util.LogKey
+	}{
+		"modified body":  {modify: func(b *Bundle) { b.Body = bodies[2]; b.UUID = hex.EncodeToString(h2) }},
+		"wrong UUID":     {modify: func(b *Bundle) { b.UUID = hex.EncodeToString(h0) }},
+		"modified index": {modify: func(b *Bundle) { b.LogIndex = 0 }},
+		"bad proof":      {modify: func(b *Bundle) { b.InclusionProof.Hashes = []string{hex.EncodeToString(h2)} }},
+		"no consistency proof": {modify: func(b *Bundle) {
+			b.Checkpoint = l.checkpoint(3, root3)
+		}},
+		"stale checkpoint": {modify: func(b *Bundle) {
+			b.Checkpoint = l.checkpoint(1, h0)
+		}},
+		"checkpoint of other log": {modify: func(b *Bundle) {
+			b.Checkpoint = other.checkpoint(2, root2)
+		}},
+		"unknown key": {keys: []util.LogKey{other.key}},
+		"key not yet active": {keys: []util.LogKey{{
+			PublicKey:  l.key.PublicKey,
+			LogID:      l.key.LogID,
+			ActiveFrom: time.Now().Add(time.Hour),
+		}}},
+	}
+	for name, tt := range tests {
+		b := *sameSize
+		if tt.modify != nil {
+			tt.modify(&b)
+		}
+		k := keys
+		if tt.keys != nil {
+			k = tt.keys
+		}
+		if err := b.Verify(k); err == nil {
+			t.Errorf("%s: Verify() succeeded, want error", name)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	l := newTestLog(t)
+	entry := l.entry(0, []byte(`{}`), models.InclusionProof{TreeSize: swag.Int64(1), RootHash: swag.String(""), LogIndex: swag.Int64(0)})
+	entry.Verification.InclusionProof = nil
+	if _, err := New("", entry, "", nil); err == nil {
+		t.Error("New() accepted an entry without an inclusion proof")
+	}
+}
@@ -38,9 +38,19 @@ func GetRekorClient(rekorServerURL string) (*client.Rekor, error) {
 	rt.Producers["application/yaml"] = YamlProducer()
 	rt.Producers["application/timestamp-query"] = runtime.ByteStreamProducer()
 	rt.Consumers["application/timestamp-reply"] = runtime.ByteStreamConsumer()
+	if retries := viper.GetUint("retry"); retries > 0 {
+		rt.Transport = &retryTransport{next: rt.Transport, retries: retries, maxWait: viper.GetDuration("retry-max-wait")}
+	}
 
+	var auths []runtime.ClientAuthInfoWriter
 	if viper.GetString("api-key") != "" {
-		rt.DefaultAuthentication = httptransport.APIKeyAuth("apiKey", "query", viper.GetString("api-key"))
+		auths = append(auths, httptransport.APIKeyAuth("apiKey", "query", viper.GetString("api-key")))
+	}
+	if viper.GetString("id-token") != "" {
+		auths = append(auths, httptransport.BearerToken(viper.GetString("id-token")))
+	}
+	if len(auths) > 0 {
+		rt.DefaultAuthentication = httptransport.Compose(auths...)
 	}
 
 	registry := strfmt.Default
@@ -58,3 +58,24 @@ func TestAPIKey(t *testing.T) {
 	}
 	_, _ = client.Pubkey.GetPublicKey(nil)
 }
+
+func TestIDToken(t *testing.T) {
+	var got string
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer testServer.Close()
+
+	viper.Set("id-token", "header.payload.signature")
+	defer viper.Set("id-token", "")
+	client, err := GetRekorClient(testServer.URL)
+	if err != nil {
+		t.Error(err)
+	}
+	_, _ = client.Tlog.GetLogInfo(nil)
+	if want := "Bearer header.payload.signature"; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryMinWait is the delay before the first retry, which doubles with each further retry
+const retryMinWait = 500 * time.Millisecond
+
+// retryTransport retries requests that could not be sent, or that the server failed to handle or rate limited,
+// waiting for an exponentially increasing, jittered delay between attempts
+type retryTransport struct {
+	next    http.RoundTripper
+	retries uint
+	maxWait time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the body is read again for each attempt
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := req.Context()
+	for attempt := uint(0); ; attempt++ {
+		r := req.Clone(ctx)
+		if body != nil {
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := t.next.RoundTrip(r)
+		if attempt == t.retries || ctx.Err() != nil || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	// 501 is returned for APIs that are not enabled on the server, which retrying will not change
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// backoff returns how long to wait before retrying after the given attempt: the delay requested by the server
+// with Retry-After, or else a random delay between half and all of retryMinWait doubled for each attempt, at
+// most maxWait
+func (t *retryTransport) backoff(attempt uint, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			if wait := time.Duration(secs) * time.Second; wait < t.maxWait {
+				return wait
+			}
+			return t.maxWait
+		}
+	}
+	wait := t.maxWait
+	if attempt < 32 && retryMinWait<<attempt < t.maxWait {
+		wait = retryMinWait << attempt
+	}
+	/* #nosec G404 */
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		caseDesc     string
+		statuses     []int
+		retries      uint
+		wantStatus   int
+		wantAttempts int
+	}{
+		{caseDesc: "success", statuses: []int{200}, retries: 3, wantStatus: 200, wantAttempts: 1},
+		{caseDesc: "server errors", statuses: []int{500, 503, 201}, retries: 3, wantStatus: 201, wantAttempts: 3},
+		{caseDesc: "rate limited", statuses: []int{429, 200}, retries: 3, wantStatus: 200, wantAttempts: 2},
+		{caseDesc: "out of retries", statuses: []int{502, 502, 502}, retries: 2, wantStatus: 502, wantAttempts: 3},
+		{caseDesc: "no retries", statuses: []int{500, 200}, retries: 0, wantStatus: 500, wantAttempts: 1},
+		{caseDesc: "client error", statuses: []int{400, 200}, retries: 3, wantStatus: 400, wantAttempts: 1},
+		{caseDesc: "not implemented", statuses: []int{501, 200}, retries: 3, wantStatus: 501, wantAttempts: 1},
+	}
+	for _, tc := range tests {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if body, _ := ioutil.ReadAll(r.Body); string(body) != "entry" {
+				t.Errorf("%v: attempt %d sent body %q", tc.caseDesc, attempts, body)
+			}
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(tc.statuses[attempts])
+			attempts++
+		}))
+		c := &http.Client{Transport: &retryTransport{next: http.DefaultTransport, retries: tc.retries, maxWait: time.Second}}
+		resp, err := c.Post(server.URL, "text/plain", strings.NewReader("entry"))
+		server.Close()
+		if err != nil {
+			t.Errorf("%v: %v", tc.caseDesc, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.wantStatus || attempts != tc.wantAttempts {
+			t.Errorf("%v: got status %d after %d attempts, want %d after %d", tc.caseDesc, resp.StatusCode, attempts, tc.wantStatus, tc.wantAttempts)
+		}
+	}
+}
+
+func TestRetryTransportCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: &retryTransport{next: http.DefaultTransport, retries: 3, maxWait: time.Minute}}
+	start := time.Now()
+	if _, err := c.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("request returned after %v instead of being canceled while waiting", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tr := &retryTransport{maxWait: 4 * time.Second}
+	for attempt, want := range []time.Duration{retryMinWait, 2 * retryMinWait, 4 * retryMinWait, 4 * time.Second, 4 * time.Second} {
+		for i := 0; i < 10; i++ {
+			if got := tr.backoff(uint(attempt), nil); got < want/2 || got > want {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, got, want/2, want)
+			}
+		}
+	}
+	if got := tr.backoff(100, nil); got < 2*time.Second || got > 4*time.Second {
+		t.Errorf("backoff(100) = %v, want at most the max wait", got)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if got := tr.backoff(0, resp); got != 2*time.Second {
+		t.Errorf("backoff() with Retry-After = %v, want 2s", got)
+	}
+	resp.Header.Set("Retry-After", "3600")
+	if got := tr.backoff(0, resp); got != tr.maxWait {
+		t.Errorf("backoff() with long Retry-After = %v, want %v", got, tr.maxWait)
+	}
+}
+
+func TestGetRekorClientRetry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	viper.Set("retry", 2)
+	viper.Set("retry-max-wait", time.Millisecond)
+	defer viper.Set("retry", 0)
+	client, err := GetRekorClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = client.Tlog.GetLogInfo(nil)
+	if attempts != 3 {
+		t.Errorf("request attempted %d times, want 3", attempts)
+	}
+}
@@ -0,0 +1,237 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embeddedlog implements the Trillian log and admin APIs in process, keeping the Merkle trees in a
+// bbolt database file, for deployments too small to justify running Trillian and MySQL.
+//
+// Leaves are integrated into the tree as they are added; there is no separate sequencer.
+package embeddedlog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	treesBucket = []byte("trees")
+
+	// each tree has a bucket holding these sub-buckets and keys
+	leavesBucket     = []byte("leaves")     // leaf index -> LogLeaf
+	identitiesBucket = []byte("identities") // leaf identity hash -> leaf index, to reject duplicates
+	leafHashesBucket = []byte("leafhashes") // Merkle leaf hash -> leaf index
+	nodesBucket      = []byte("nodes")      // level and index -> hash of every perfect subtree
+	rootKey          = []byte("root")       // the latest LogRootV1
+	rangeKey         = []byte("range")      // the compact range of the whole tree, to append to
+)
+
+// Log serves the Trillian log and admin APIs from a bbolt database. Every call runs in a single transaction, so
+// proofs are always consistent with the root they are returned with.
+type Log struct {
+	db  *bolt.DB
+	now func() time.Time
+}
+
+var (
+	_ trillian.TrillianLogClient   = (*Log)(nil)
+	_ trillian.TrillianAdminClient = (*Log)(nil)
+)
+
+// Open opens the database at path, creating it if needed. Only one process can open it at a time.
+func Open(path string) (*Log, error) {
+	db, err := bolt.Open(filepath.Clean(path), 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening embedded log %v: %w", path, err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(treesBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Log{db: db, now: time.Now}, nil
+}
+
+// Close closes the database
+func (l *Log) Close() error {
+	return l.db.Close()
+}
+
+func treeKey(treeID int64) []byte {
+	return uint64Key(uint64(treeID))
+}
+
+func uint64Key(i uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, i)
+	return b
+}
+
+func getTree(tx *bolt.Tx, treeID int64) (*trillian.Tree, error) {
+	v := tx.Bucket(treesBucket).Get(treeKey(treeID))
+	if v == nil {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", treeID)
+	}
+	var tree trillian.Tree
+	if err := proto.Unmarshal(v, &tree); err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding tree %d: %v", treeID, err)
+	}
+	if tree.Deleted {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", treeID)
+	}
+	return &tree, nil
+}
+
+// ListTrees returns the trees in the database
+func (l *Log) ListTrees(ctx context.Context, in *trillian.ListTreesRequest, opts ...grpc.CallOption) (*trillian.ListTreesResponse, error) {
+	resp := &trillian.ListTreesResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(treesBucket).ForEach(func(k, v []byte) error {
+			var tree trillian.Tree
+			if err := proto.Unmarshal(v, &tree); err != nil {
+				return status.Errorf(codes.Internal, "decoding tree: %v", err)
+			}
+			if !tree.Deleted || in.ShowDeleted {
+				resp.Tree = append(resp.Tree, &tree)
+			}
+			return nil
+		})
+	})
+	return resp, err
+}
+
+// GetTree returns the tree with the requested ID
+func (l *Log) GetTree(ctx context.Context, in *trillian.GetTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	var tree *trillian.Tree
+	err := l.db.View(func(tx *bolt.Tx) (err error) {
+		tree, err = getTree(tx, in.TreeId)
+		return err
+	})
+	return tree, err
+}
+
+// CreateTree creates an active LOG or PREORDERED_LOG tree; it must be initialized with InitLog before use
+func (l *Log) CreateTree(ctx context.Context, in *trillian.CreateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	if in.Tree == nil {
+		return nil, status.Error(codes.InvalidArgument, "a tree is required")
+	}
+	tree := proto.Clone(in.Tree).(*trillian.Tree)
+	switch tree.TreeType {
+	case trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "tree type %v is not supported", tree.TreeType)
+	}
+	if tree.TreeState != trillian.TreeState_ACTIVE {
+		return nil, status.Errorf(codes.InvalidArgument, "new trees must be %v", trillian.TreeState_ACTIVE)
+	}
+	now := timestamppb.New(l.now())
+	tree.CreateTime, tree.UpdateTime = now, now
+
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		trees := tx.Bucket(treesBucket)
+		for {
+			id, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+			if err != nil {
+				return err
+			}
+			tree.TreeId = id.Int64() + 1
+			if trees.Get(treeKey(tree.TreeId)) == nil {
+				break
+			}
+		}
+		b, err := proto.Marshal(tree)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(treeKey(tree.TreeId)); err != nil {
+			return err
+		}
+		return trees.Put(treeKey(tree.TreeId), b)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// UpdateTree is not supported
+func (l *Log) UpdateTree(ctx context.Context, in *trillian.UpdateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "the embedded log does not support updating trees")
+}
+
+// DeleteTree is not supported
+func (l *Log) DeleteTree(ctx context.Context, in *trillian.DeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "the embedded log does not support deleting trees")
+}
+
+// UndeleteTree is not supported
+func (l *Log) UndeleteTree(ctx context.Context, in *trillian.UndeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "the embedded log does not support deleting trees")
+}
+
+// InitLog stores the empty root of a new tree
+func (l *Log) InitLog(ctx context.Context, in *trillian.InitLogRequest, opts ...grpc.CallOption) (*trillian.InitLogResponse, error) {
+	var slr *trillian.SignedLogRoot
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		if _, err := getTree(tx, in.LogId); err != nil {
+			return err
+		}
+		b := tx.Bucket(treeKey(in.LogId))
+		if b.Get(rootKey) != nil {
+			return status.Errorf(codes.AlreadyExists, "log %d is already initialized", in.LogId)
+		}
+		for _, name := range [][]byte{leavesBucket, identitiesBucket, leafHashesBucket, nodesBucket} {
+			if _, err := b.CreateBucket(name); err != nil {
+				return err
+			}
+		}
+		root := &types.LogRootV1{
+			RootHash:       hasher.EmptyRoot(),
+			TimestampNanos: uint64(l.now().UnixNano()),
+		}
+		var err error
+		slr, err = putRoot(b, root)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.InitLogResponse{Created: slr}, nil
+}
+
+func putRoot(b *bolt.Bucket, root *types.LogRootV1) (*trillian.SignedLogRoot, error) {
+	rootBytes, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Put(rootKey, rootBytes); err != nil {
+		return nil, err
+	}
+	return &trillian.SignedLogRoot{LogRoot: rootBytes}, nil
+}
@@ -0,0 +1,303 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedlog
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/merkle/logverifier"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newLog(t *testing.T, path string, treeType trillian.TreeType) (*Log, *trillian.Tree) {
+	t.Helper()
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	tree, err := l.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: &trillian.Tree{
+		TreeType:  treeType,
+		TreeState: trillian.TreeState_ACTIVE,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.InitLog(ctx, tree, l); err != nil {
+		t.Fatal(err)
+	}
+	return l, tree
+}
+
+func latestRoot(t *testing.T, l *Log, logID int64) *types.LogRootV1 {
+	t.Helper()
+	resp, err := l.GetLatestSignedLogRoot(context.Background(), &trillian.GetLatestSignedLogRootRequest{LogId: logID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatal(err)
+	}
+	return &root
+}
+
+func queue(t *testing.T, l *Log, logID int64, value string) *trillian.QueuedLogLeaf {
+	t.Helper()
+	resp, err := l.QueueLeaf(context.Background(), &trillian.QueueLeafRequest{
+		LogId: logID,
+		Leaf:  &trillian.LogLeaf{LeafValue: []byte(value)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.QueuedLeaf
+}
+
+func TestProofs(t *testing.T) {
+	ctx := context.Background()
+	l, tree := newLog(t, filepath.Join(t.TempDir(), "log.db"), trillian.TreeType_LOG)
+	defer l.Close()
+	v := logverifier.New(hasher)
+
+	const n = 37
+	roots := []*types.LogRootV1{latestRoot(t, l, tree.TreeId)}
+	for i := 0; i < n; i++ {
+		queue(t, l, tree.TreeId, fmt.Sprintf("leaf %d", i))
+		roots = append(roots, latestRoot(t, l, tree.TreeId))
+	}
+
+	for size := int64(1); size <= n; size++ {
+		root := roots[size]
+		if root.TreeSize != uint64(size) {
+			t.Fatalf("tree size %d after %d leaves", root.TreeSize, size)
+		}
+		for index := int64(0); index < size; index++ {
+			resp, err := l.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: index, TreeSize: size})
+			if err != nil {
+				t.Fatalf("GetInclusionProof(%d, %d): %v", index, size, err)
+			}
+			leafHash := hasher.HashLeaf([]byte(fmt.Sprintf("leaf %d", index)))
+			if err := v.VerifyInclusionProof(index, size, resp.Proof.Hashes, root.RootHash, leafHash); err != nil {
+				t.Errorf("inclusion of %d in %d: %v", index, size, err)
+			}
+		}
+		for first := int64(1); first <= size; first++ {
+			resp, err := l.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{LogId: tree.TreeId, FirstTreeSize: first, SecondTreeSize: size})
+			if err != nil {
+				t.Fatalf("GetConsistencyProof(%d, %d): %v", first, size, err)
+			}
+			if err := v.VerifyConsistencyProof(first, size, roots[first].RootHash, root.RootHash, resp.Proof.Hashes); err != nil {
+				t.Errorf("consistency of %d and %d: %v", first, size, err)
+			}
+		}
+	}
+
+	resp, err := l.GetEntryAndProof(ctx, &trillian.GetEntryAndProofRequest{LogId: tree.TreeId, LeafIndex: 5, TreeSize: n + 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Leaf.LeafValue) != "leaf 5" {
+		t.Errorf("GetEntryAndProof returned %q", resp.Leaf.LeafValue)
+	}
+	if err := v.VerifyInclusionProof(5, n, resp.Proof.Hashes, roots[n].RootHash, resp.Leaf.MerkleLeafHash); err != nil {
+		t.Errorf("GetEntryAndProof: %v", err)
+	}
+
+	for _, test := range []struct {
+		desc string
+		err  error
+		want codes.Code
+	}{
+		{
+			desc: "leaf beyond tree size",
+			err:  call(l.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 3, TreeSize: 3})),
+			want: codes.OutOfRange,
+		},
+		{
+			desc: "tree larger than log",
+			err:  call(l.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 3, TreeSize: n + 1})),
+			want: codes.OutOfRange,
+		},
+		{
+			desc: "unknown hash",
+			err: call(l.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{
+				LogId: tree.TreeId, LeafHash: hasher.HashLeaf([]byte("missing")), TreeSize: n,
+			})),
+			want: codes.NotFound,
+		},
+		{
+			desc: "hash not yet in tree",
+			err: call(l.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{
+				LogId: tree.TreeId, LeafHash: hasher.HashLeaf([]byte("leaf 9")), TreeSize: 5,
+			})),
+			want: codes.NotFound,
+		},
+		{
+			desc: "entry beyond log",
+			err:  call(l.GetEntryAndProof(ctx, &trillian.GetEntryAndProofRequest{LogId: tree.TreeId, LeafIndex: n, TreeSize: n})),
+			want: codes.OutOfRange,
+		},
+		{
+			desc: "range beyond log",
+			err:  call(l.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: n, Count: 1})),
+			want: codes.OutOfRange,
+		},
+		{
+			desc: "unknown tree",
+			err:  call(l.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: tree.TreeId + 1})),
+			want: codes.NotFound,
+		},
+	} {
+		if got := status.Code(test.err); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.desc, test.err, test.want)
+		}
+	}
+}
+
+// call discards the response of a call
+func call(_ interface{}, err error) error {
+	return err
+}
+
+func TestClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	l, tree := newLog(t, filepath.Join(t.TempDir(), "log.db"), trillian.TreeType_LOG)
+	defer l.Close()
+
+	c, err := client.NewFromTree(l, tree, types.LogRootV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := c.AddLeaf(ctx, []byte(fmt.Sprintf("leaf %d", i))); err != nil {
+			t.Fatalf("AddLeaf: %v", err)
+		}
+	}
+	// the client verified the consistency of every root it moved to
+	if root := c.GetRoot(); root.TreeSize != 3 {
+		t.Errorf("tree size %d, want 3", root.TreeSize)
+	}
+	leaves, err := c.ListByIndex(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaves) != 2 || string(leaves[0].LeafValue) != "leaf 1" || string(leaves[1].LeafValue) != "leaf 2" {
+		t.Errorf("ListByIndex returned %v", leaves)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	l, tree := newLog(t, filepath.Join(t.TempDir(), "log.db"), trillian.TreeType_LOG)
+	defer l.Close()
+
+	first := queue(t, l, tree.TreeId, "a")
+	if first.Status != nil {
+		t.Fatalf("first add returned %v", first.Status)
+	}
+	queue(t, l, tree.TreeId, "b")
+	dup := queue(t, l, tree.TreeId, "a")
+	if codes.Code(dup.Status.GetCode()) != codes.AlreadyExists {
+		t.Errorf("duplicate add returned %v, want %v", dup.Status, codes.AlreadyExists)
+	}
+	if dup.Leaf.LeafIndex != 0 || !dup.Leaf.IntegrateTimestamp.AsTime().Equal(first.Leaf.IntegrateTimestamp.AsTime()) {
+		t.Errorf("duplicate add returned leaf %v, want %v", dup.Leaf, first.Leaf)
+	}
+	if size := latestRoot(t, l, tree.TreeId).TreeSize; size != 2 {
+		t.Errorf("tree size %d, want 2", size)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.db")
+	l, tree := newLog(t, path, trillian.TreeType_LOG)
+	for i := 0; i < 5; i++ {
+		queue(t, l, tree.TreeId, fmt.Sprintf("leaf %d", i))
+	}
+	before := latestRoot(t, l, tree.TreeId)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	trees, err := l.ListTrees(context.Background(), &trillian.ListTreesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees.Tree) != 1 || trees.Tree[0].TreeId != tree.TreeId {
+		t.Fatalf("ListTrees returned %v", trees.Tree)
+	}
+	for i := 5; i < 8; i++ {
+		queue(t, l, tree.TreeId, fmt.Sprintf("leaf %d", i))
+	}
+	after := latestRoot(t, l, tree.TreeId)
+	resp, err := l.GetConsistencyProof(context.Background(), &trillian.GetConsistencyProofRequest{
+		LogId: tree.TreeId, FirstTreeSize: int64(before.TreeSize), SecondTreeSize: int64(after.TreeSize),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logverifier.New(hasher).VerifyConsistencyProof(int64(before.TreeSize), int64(after.TreeSize), before.RootHash, after.RootHash, resp.Proof.Hashes); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSequencedLeaves(t *testing.T) {
+	ctx := context.Background()
+	l, tree := newLog(t, filepath.Join(t.TempDir(), "log.db"), trillian.TreeType_PREORDERED_LOG)
+	defer l.Close()
+
+	add := func(indexes ...int64) (*trillian.AddSequencedLeavesResponse, error) {
+		req := &trillian.AddSequencedLeavesRequest{LogId: tree.TreeId}
+		for _, i := range indexes {
+			req.Leaves = append(req.Leaves, &trillian.LogLeaf{LeafIndex: i, LeafValue: []byte(fmt.Sprintf("leaf %d", i))})
+		}
+		return l.AddSequencedLeaves(ctx, req)
+	}
+
+	if _, err := add(0, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := add(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codes.Code(resp.Results[0].Status.GetCode()) != codes.AlreadyExists || resp.Results[1].Status != nil {
+		t.Errorf("got results %v", resp.Results)
+	}
+	if _, err := add(5); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("adding with a gap returned %v", err)
+	}
+	if size := latestRoot(t, l, tree.TreeId).TreeSize; size != 4 {
+		t.Errorf("tree size %d, want 4", size)
+	}
+	if _, err := l.QueueLeaf(ctx, &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte("x")}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("QueueLeaf on a preordered log returned %v", err)
+	}
+}
@@ -0,0 +1,500 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddedlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/merkle"
+	"github.com/google/trillian/merkle/compact"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxLeavesPerRange caps the leaves returned by GetLeavesByRange, as Trillian does
+const maxLeavesPerRange = 1000
+
+var (
+	hasher       = rfc6962.DefaultHasher
+	rangeFactory = &compact.RangeFactory{Hash: hasher.HashChildren}
+)
+
+// openLog returns the tree, bucket and latest root of an initialized log
+func openLog(tx *bolt.Tx, logID int64) (*trillian.Tree, *bolt.Bucket, *types.LogRootV1, error) {
+	tree, err := getTree(tx, logID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	b := tx.Bucket(treeKey(logID))
+	v := b.Get(rootKey)
+	if v == nil {
+		return nil, nil, nil, status.Errorf(codes.FailedPrecondition, "log %d is not initialized", logID)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(append([]byte(nil), v...)); err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "decoding root of log %d: %v", logID, err)
+	}
+	return tree, b, &root, nil
+}
+
+func signedRoot(root *types.LogRootV1) (*trillian.SignedLogRoot, error) {
+	b, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.SignedLogRoot{LogRoot: b}, nil
+}
+
+func writable(tree *trillian.Tree, treeType trillian.TreeType) error {
+	if tree.TreeType != treeType {
+		return status.Errorf(codes.FailedPrecondition, "log %d is a %v tree", tree.TreeId, tree.TreeType)
+	}
+	if tree.TreeState != trillian.TreeState_ACTIVE {
+		return status.Errorf(codes.FailedPrecondition, "log %d is %v", tree.TreeId, tree.TreeState)
+	}
+	return nil
+}
+
+func nodeKey(id compact.NodeID) []byte {
+	k := make([]byte, 9)
+	k[0] = byte(id.Level)
+	binary.BigEndian.PutUint64(k[1:], id.Index)
+	return k
+}
+
+func getLeaf(b *bolt.Bucket, index uint64) (*trillian.LogLeaf, error) {
+	v := b.Bucket(leavesBucket).Get(uint64Key(index))
+	if v == nil {
+		return nil, status.Errorf(codes.Internal, "leaf %d is missing", index)
+	}
+	var leaf trillian.LogLeaf
+	if err := proto.Unmarshal(v, &leaf); err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding leaf %d: %v", index, err)
+	}
+	return &leaf, nil
+}
+
+// getIndex looks up a leaf index in one of the hash -> index buckets
+func getIndex(b *bolt.Bucket, bucket, hash []byte) (uint64, bool) {
+	v := b.Bucket(bucket).Get(hash)
+	if v == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(v), true
+}
+
+// buildProof fetches the nodes of an inclusion or consistency proof and combines those on the right border of
+// the tree, which are not perfect subtrees and so are not stored
+func buildProof(b *bolt.Bucket, nodes []merkle.NodeFetch) ([][]byte, error) {
+	hashes := make([][]byte, len(nodes))
+	for i, n := range nodes {
+		v := b.Bucket(nodesBucket).Get(nodeKey(n.ID))
+		if v == nil {
+			return nil, status.Errorf(codes.Internal, "node %+v is missing", n.ID)
+		}
+		hashes[i] = append([]byte(nil), v...)
+	}
+	return merkle.Rehash(hashes, nodes, hasher.HashChildren)
+}
+
+func inclusionProof(b *bolt.Bucket, index, size uint64) (*trillian.Proof, error) {
+	nodes, err := merkle.CalcInclusionProofNodeAddresses(int64(size), int64(index))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hashes, err := buildProof(b, nodes)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.Proof{LeafIndex: int64(index), Hashes: hashes}, nil
+}
+
+func consistencyProof(b *bolt.Bucket, size1, size2 uint64) (*trillian.Proof, error) {
+	nodes, err := merkle.CalcConsistencyProofNodeAddresses(int64(size1), int64(size2))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hashes, err := buildProof(b, nodes)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.Proof{Hashes: hashes}, nil
+}
+
+// integrate appends leaves, which must already have their indexes and hashes set, to the tree and stores the new root
+func (l *Log) integrate(b *bolt.Bucket, root *types.LogRootV1, leaves []*trillian.LogLeaf) error {
+	cr := rangeFactory.NewEmptyRange(0)
+	if v := b.Get(rangeKey); v != nil {
+		var hashes [][]byte
+		for i := 0; i < len(v); i += hasher.Size() {
+			hashes = append(hashes, append([]byte(nil), v[i:i+hasher.Size()]...))
+		}
+		var err error
+		if cr, err = rangeFactory.NewRange(0, root.TreeSize, hashes); err != nil {
+			return status.Errorf(codes.Internal, "decoding compact range: %v", err)
+		}
+	}
+
+	nodes := b.Bucket(nodesBucket)
+	var putErr error
+	store := func(id compact.NodeID, hash []byte) {
+		if err := nodes.Put(nodeKey(id), hash); err != nil && putErr == nil {
+			putErr = err
+		}
+	}
+	for _, leaf := range leaves {
+		v, err := proto.Marshal(leaf)
+		if err != nil {
+			return err
+		}
+		index := uint64Key(uint64(leaf.LeafIndex))
+		if err := b.Bucket(leavesBucket).Put(index, v); err != nil {
+			return err
+		}
+		if _, ok := getIndex(b, identitiesBucket, leaf.LeafIdentityHash); !ok {
+			if err := b.Bucket(identitiesBucket).Put(leaf.LeafIdentityHash, index); err != nil {
+				return err
+			}
+		}
+		// the first leaf with a hash is the one returned when searching by hash
+		if _, ok := getIndex(b, leafHashesBucket, leaf.MerkleLeafHash); !ok {
+			if err := b.Bucket(leafHashesBucket).Put(leaf.MerkleLeafHash, index); err != nil {
+				return err
+			}
+		}
+		store(compact.NewNodeID(0, uint64(leaf.LeafIndex)), leaf.MerkleLeafHash)
+		if err := cr.Append(leaf.MerkleLeafHash, store); err != nil {
+			return status.Errorf(codes.Internal, "appending leaf %d: %v", leaf.LeafIndex, err)
+		}
+	}
+	if putErr != nil {
+		return putErr
+	}
+
+	rootHash, err := cr.GetRootHash(nil)
+	if err != nil {
+		return status.Errorf(codes.Internal, "computing root hash: %v", err)
+	}
+	if err := b.Put(rangeKey, bytes.Join(cr.Hashes(), nil)); err != nil {
+		return err
+	}
+	// clients only accept roots that are newer than the last one they saw
+	ts := uint64(l.now().UnixNano())
+	if ts <= root.TimestampNanos {
+		ts = root.TimestampNanos + 1
+	}
+	_, err = putRoot(b, &types.LogRootV1{
+		TreeSize:       cr.End(),
+		RootHash:       rootHash,
+		TimestampNanos: ts,
+		Revision:       root.Revision + 1,
+	})
+	return err
+}
+
+func (l *Log) newLeaf(in *trillian.LogLeaf, index uint64) *trillian.LogLeaf {
+	leaf := proto.Clone(in).(*trillian.LogLeaf)
+	leaf.LeafIndex = int64(index)
+	leaf.MerkleLeafHash = hasher.HashLeaf(leaf.LeafValue)
+	if len(leaf.LeafIdentityHash) == 0 {
+		leaf.LeafIdentityHash = leaf.MerkleLeafHash
+	}
+	now := timestamppb.New(l.now())
+	leaf.QueueTimestamp, leaf.IntegrateTimestamp = now, now
+	return leaf
+}
+
+// QueueLeaf adds a leaf to a LOG tree, unless a leaf with the same identity hash is already in it
+func (l *Log) QueueLeaf(ctx context.Context, in *trillian.QueueLeafRequest, opts ...grpc.CallOption) (*trillian.QueueLeafResponse, error) {
+	if in.Leaf == nil {
+		return nil, status.Error(codes.InvalidArgument, "a leaf is required")
+	}
+	var queued *trillian.QueuedLogLeaf
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		tree, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		if err := writable(tree, trillian.TreeType_LOG); err != nil {
+			return err
+		}
+		leaf := l.newLeaf(in.Leaf, root.TreeSize)
+		if index, ok := getIndex(b, identitiesBucket, leaf.LeafIdentityHash); ok {
+			existing, err := getLeaf(b, index)
+			if err != nil {
+				return err
+			}
+			queued = &trillian.QueuedLogLeaf{
+				Leaf:   existing,
+				Status: status.New(codes.AlreadyExists, "leaf already exists").Proto(),
+			}
+			return nil
+		}
+		if err := l.integrate(b, root, []*trillian.LogLeaf{leaf}); err != nil {
+			return err
+		}
+		queued = &trillian.QueuedLogLeaf{Leaf: leaf}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.QueueLeafResponse{QueuedLeaf: queued}, nil
+}
+
+// AddSequencedLeaves adds leaves to a PREORDERED_LOG tree at the indexes they are given. Leaves must be added in
+// order; those already in the tree are reported as existing.
+func (l *Log) AddSequencedLeaves(ctx context.Context, in *trillian.AddSequencedLeavesRequest, opts ...grpc.CallOption) (*trillian.AddSequencedLeavesResponse, error) {
+	resp := &trillian.AddSequencedLeavesResponse{}
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		tree, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		if err := writable(tree, trillian.TreeType_PREORDERED_LOG); err != nil {
+			return err
+		}
+		next := root.TreeSize
+		var leaves []*trillian.LogLeaf
+		for _, in := range in.Leaves {
+			switch index := uint64(in.LeafIndex); {
+			case in.LeafIndex < 0:
+				return status.Errorf(codes.InvalidArgument, "invalid leaf index %d", in.LeafIndex)
+			case index < root.TreeSize:
+				existing, err := getLeaf(b, index)
+				if err != nil {
+					return err
+				}
+				resp.Results = append(resp.Results, &trillian.QueuedLogLeaf{
+					Leaf:   existing,
+					Status: status.New(codes.AlreadyExists, "leaf already exists").Proto(),
+				})
+			case index == next:
+				leaf := l.newLeaf(in, index)
+				leaves = append(leaves, leaf)
+				resp.Results = append(resp.Results, &trillian.QueuedLogLeaf{Leaf: leaf})
+				next++
+			default:
+				return status.Errorf(codes.FailedPrecondition, "leaf %d added before leaf %d", in.LeafIndex, next)
+			}
+		}
+		if len(leaves) == 0 {
+			return nil
+		}
+		return l.integrate(b, root, leaves)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLatestSignedLogRoot returns the latest root, with a proof of consistency from FirstTreeSize if it is smaller
+func (l *Log) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	if in.FirstTreeSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid first tree size %d", in.FirstTreeSize)
+	}
+	resp := &trillian.GetLatestSignedLogRootResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		_, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+			return err
+		}
+		if first := uint64(in.FirstTreeSize); first > 0 && first < root.TreeSize {
+			resp.Proof, err = consistencyProof(b, first, root.TreeSize)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// checkTreeSize checks that size is within the current tree
+func checkTreeSize(root *types.LogRootV1, size int64) error {
+	if size <= 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid tree size %d", size)
+	}
+	if uint64(size) > root.TreeSize {
+		return status.Errorf(codes.OutOfRange, "tree size %d is larger than the current tree size %d", size, root.TreeSize)
+	}
+	return nil
+}
+
+// GetInclusionProof returns the proof that the leaf at LeafIndex is in the tree of TreeSize
+func (l *Log) GetInclusionProof(ctx context.Context, in *trillian.GetInclusionProofRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofResponse, error) {
+	resp := &trillian.GetInclusionProofResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		_, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		if err := checkTreeSize(root, in.TreeSize); err != nil {
+			return err
+		}
+		if in.LeafIndex < 0 || in.LeafIndex >= in.TreeSize {
+			return status.Errorf(codes.OutOfRange, "leaf index %d is not in the tree of size %d", in.LeafIndex, in.TreeSize)
+		}
+		if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+			return err
+		}
+		resp.Proof, err = inclusionProof(b, uint64(in.LeafIndex), uint64(in.TreeSize))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetInclusionProofByHash returns the proof that the leaf with LeafHash is in the tree of TreeSize
+func (l *Log) GetInclusionProofByHash(ctx context.Context, in *trillian.GetInclusionProofByHashRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofByHashResponse, error) {
+	resp := &trillian.GetInclusionProofByHashResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		_, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		if err := checkTreeSize(root, in.TreeSize); err != nil {
+			return err
+		}
+		index, ok := getIndex(b, leafHashesBucket, in.LeafHash)
+		if !ok || index >= uint64(in.TreeSize) {
+			return status.Errorf(codes.NotFound, "no leaf with hash %x in the tree of size %d", in.LeafHash, in.TreeSize)
+		}
+		if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+			return err
+		}
+		proof, err := inclusionProof(b, index, uint64(in.TreeSize))
+		if err != nil {
+			return err
+		}
+		resp.Proof = []*trillian.Proof{proof}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetConsistencyProof returns the proof that the tree of FirstTreeSize is a prefix of the tree of SecondTreeSize
+func (l *Log) GetConsistencyProof(ctx context.Context, in *trillian.GetConsistencyProofRequest, opts ...grpc.CallOption) (*trillian.GetConsistencyProofResponse, error) {
+	resp := &trillian.GetConsistencyProofResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		_, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		if err := checkTreeSize(root, in.SecondTreeSize); err != nil {
+			return err
+		}
+		if in.FirstTreeSize <= 0 || in.FirstTreeSize > in.SecondTreeSize {
+			return status.Errorf(codes.InvalidArgument, "invalid first tree size %d", in.FirstTreeSize)
+		}
+		if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+			return err
+		}
+		resp.Proof, err = consistencyProof(b, uint64(in.FirstTreeSize), uint64(in.SecondTreeSize))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetEntryAndProof returns the leaf at LeafIndex and the proof that it is in the tree of TreeSize, or the
+// current tree if that is smaller
+func (l *Log) GetEntryAndProof(ctx context.Context, in *trillian.GetEntryAndProofRequest, opts ...grpc.CallOption) (*trillian.GetEntryAndProofResponse, error) {
+	resp := &trillian.GetEntryAndProofResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		_, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		size := in.TreeSize
+		if size > int64(root.TreeSize) {
+			size = int64(root.TreeSize)
+		}
+		if in.LeafIndex < 0 || in.LeafIndex >= size {
+			return status.Errorf(codes.OutOfRange, "leaf index %d is not in the tree of size %d", in.LeafIndex, size)
+		}
+		if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+			return err
+		}
+		if resp.Leaf, err = getLeaf(b, uint64(in.LeafIndex)); err != nil {
+			return err
+		}
+		resp.Proof, err = inclusionProof(b, uint64(in.LeafIndex), uint64(size))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLeavesByRange returns up to Count leaves from StartIndex; fewer are returned at the end of the tree
+func (l *Log) GetLeavesByRange(ctx context.Context, in *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	if in.StartIndex < 0 || in.Count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid range of %d leaves from %d", in.Count, in.StartIndex)
+	}
+	resp := &trillian.GetLeavesByRangeResponse{}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		_, b, root, err := openLog(tx, in.LogId)
+		if err != nil {
+			return err
+		}
+		start := uint64(in.StartIndex)
+		if start >= root.TreeSize {
+			return status.Errorf(codes.OutOfRange, "start index %d is not in the tree of size %d", start, root.TreeSize)
+		}
+		count := uint64(in.Count)
+		if count > maxLeavesPerRange {
+			count = maxLeavesPerRange
+		}
+		if start+count > root.TreeSize {
+			count = root.TreeSize - start
+		}
+		for i := start; i < start+count; i++ {
+			leaf, err := getLeaf(b, i)
+			if err != nil {
+				return err
+			}
+			resp.Leaves = append(resp.Leaves, leaf)
+		}
+		resp.SignedLogRoot, err = signedRoot(root)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
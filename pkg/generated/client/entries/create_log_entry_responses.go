@@ -28,6 +28,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 )
@@ -77,7 +78,7 @@ func NewCreateLogEntryCreated() *CreateLogEntryCreated {
 
 /* CreateLogEntryCreated describes a response with status code 201, with default header values.
 
-Returns the entry created in the transparency log
+Returns the entry created in the transparency log, or the equivalent entry already in it if the server is configured to accept duplicate entries idempotently
 */
 type CreateLogEntryCreated struct {
 
@@ -91,6 +92,10 @@ type CreateLogEntryCreated struct {
 	*/
 	Location strfmt.URI
 
+	/* true if the entry was already in the transparency log
+	 */
+	RekorExistingEntry bool
+
 	Payload models.LogEntry
 }
 
@@ -121,6 +126,17 @@ func (o *CreateLogEntryCreated) readResponse(response runtime.ClientResponse, co
 		o.Location = *(vallocation.(*strfmt.URI))
 	}
 
+	// hydrates response header Rekor-Existing-Entry
+	hdrRekorExistingEntry := response.GetHeader("Rekor-Existing-Entry")
+
+	if hdrRekorExistingEntry != "" {
+		valrekorExistingEntry, err := swag.ConvertBool(hdrRekorExistingEntry)
+		if err != nil {
+			return errors.InvalidType("Rekor-Existing-Entry", "header", "bool", hdrRekorExistingEntry)
+		}
+		o.RekorExistingEntry = valrekorExistingEntry
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -171,20 +187,31 @@ func NewCreateLogEntryConflict() *CreateLogEntryConflict {
 The request conflicts with the current state of the transparency log
 */
 type CreateLogEntryConflict struct {
+
+	/* UUID of the equivalent log entry
+	 */
+	ETag     string
 	Location strfmt.URI
 
-	Payload *models.Error
+	Payload *models.ConflictError
 }
 
 func (o *CreateLogEntryConflict) Error() string {
 	return fmt.Sprintf("[POST /api/v1/log/entries][%d] createLogEntryConflict  %+v", 409, o.Payload)
 }
-func (o *CreateLogEntryConflict) GetPayload() *models.Error {
+func (o *CreateLogEntryConflict) GetPayload() *models.ConflictError {
 	return o.Payload
 }
 
 func (o *CreateLogEntryConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header ETag
+	hdrETag := response.GetHeader("ETag")
+
+	if hdrETag != "" {
+		o.ETag = hdrETag
+	}
+
 	// hydrates response header Location
 	hdrLocation := response.GetHeader("Location")
 
@@ -196,7 +223,7 @@ func (o *CreateLogEntryConflict) readResponse(response runtime.ClientResponse, c
 		o.Location = *(vallocation.(*strfmt.URI))
 	}
 
-	o.Payload = new(models.Error)
+	o.Payload = new(models.ConflictError)
 
 	// response payload
 	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
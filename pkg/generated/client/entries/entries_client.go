@@ -44,6 +44,8 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	CreateLogEntries(params *CreateLogEntriesParams, opts ...ClientOption) (*CreateLogEntriesOK, error)
+
 	CreateLogEntry(params *CreateLogEntryParams, opts ...ClientOption) (*CreateLogEntryCreated, error)
 
 	GetLogEntryByIndex(params *GetLogEntryByIndexParams, opts ...ClientOption) (*GetLogEntryByIndexOK, error)
@@ -52,9 +54,51 @@ type ClientService interface {
 
 	SearchLogQuery(params *SearchLogQueryParams, opts ...ClientOption) (*SearchLogQueryOK, error)
 
+	StreamLogEntries(params *StreamLogEntriesParams, opts ...ClientOption) (*StreamLogEntriesOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  CreateLogEntries creates multiple entries in the transparency log
+
+  Creates an entry in the transparency log for each of the proposed entries. All entries are queued before the server waits for them to be included, so they are typically sequenced together. The number of entries accepted in one request is limited by the server.
+
+*/
+func (a *Client) CreateLogEntries(params *CreateLogEntriesParams, opts ...ClientOption) (*CreateLogEntriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateLogEntriesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "createLogEntries",
+		Method:             "POST",
+		PathPattern:        "/api/v1/log/entries/batch",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &CreateLogEntriesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateLogEntriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateLogEntriesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   CreateLogEntry creates an entry in the transparency log
 
@@ -208,6 +252,46 @@ func (a *Client) SearchLogQuery(params *SearchLogQueryParams, opts ...ClientOpti
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  StreamLogEntries streams entries as they are integrated into the transparency log
+
+  Returns a stream of Server-Sent Events, one for each entry integrated into the log. The ID of each event is the index of the entry, so clients that reconnect with a Last-Event-ID header resume after the last entry they received. Without a startIndex or Last-Event-ID, the stream starts with the next entry to be integrated.
+
+*/
+func (a *Client) StreamLogEntries(params *StreamLogEntriesParams, opts ...ClientOption) (*StreamLogEntriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewStreamLogEntriesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "streamLogEntries",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/entries/stream",
+		ProducesMediaTypes: []string{"text/event-stream"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &StreamLogEntriesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*StreamLogEntriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*StreamLogEntriesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -76,6 +76,15 @@ func NewGetLogEntryByIndexParamsWithHTTPClient(client *http.Client) *GetLogEntry
 */
 type GetLogEntryByIndexParams struct {
 
+	/* Count.
+
+	   the number of consecutive entries to retrieve, starting at logIndex; fewer are returned at the end of the log. Inclusion proofs and signed entry timestamps are only returned when a single entry is retrieved.
+
+
+	   Default: 1
+	*/
+	Count *int64
+
 	/* LogIndex.
 
 	   specifies the index of the entry in the transparency log to be retrieved
@@ -99,7 +108,18 @@ func (o *GetLogEntryByIndexParams) WithDefaults() *GetLogEntryByIndexParams {
 //
 // All values with no default are reset to their zero value.
 func (o *GetLogEntryByIndexParams) SetDefaults() {
-	// no default values defined for this parameter
+	var (
+		countDefault = int64(1)
+	)
+
+	val := GetLogEntryByIndexParams{
+		Count: &countDefault,
+	}
+
+	val.timeout = o.timeout
+	val.Context = o.Context
+	val.HTTPClient = o.HTTPClient
+	*o = val
 }
 
 // WithTimeout adds the timeout to the get log entry by index params
@@ -135,6 +155,17 @@ func (o *GetLogEntryByIndexParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithCount adds the count to the get log entry by index params
+func (o *GetLogEntryByIndexParams) WithCount(count *int64) *GetLogEntryByIndexParams {
+	o.SetCount(count)
+	return o
+}
+
+// SetCount adds the count to the get log entry by index params
+func (o *GetLogEntryByIndexParams) SetCount(count *int64) {
+	o.Count = count
+}
+
 // WithLogIndex adds the logIndex to the get log entry by index params
 func (o *GetLogEntryByIndexParams) WithLogIndex(logIndex int64) *GetLogEntryByIndexParams {
 	o.SetLogIndex(logIndex)
@@ -154,6 +185,23 @@ func (o *GetLogEntryByIndexParams) WriteToRequest(r runtime.ClientRequest, reg s
 	}
 	var res []error
 
+	if o.Count != nil {
+
+		// query param count
+		var qrCount int64
+
+		if o.Count != nil {
+			qrCount = *o.Count
+		}
+		qCount := swag.FormatInt64(qrCount)
+		if qCount != "" {
+
+			if err := r.SetQueryParam("count", qCount); err != nil {
+				return err
+			}
+		}
+	}
+
 	// query param logIndex
 	qrLogIndex := o.LogIndex
 	qLogIndex := swag.FormatInt64(qrLogIndex)
@@ -70,7 +70,7 @@ func NewGetLogEntryByIndexOK() *GetLogEntryByIndexOK {
 
 /* GetLogEntryByIndexOK describes a response with status code 200, with default header values.
 
-the entry in the transparency log requested along with an inclusion proof
+the entries in the transparency log requested, along with an inclusion proof if a single entry was requested
 */
 type GetLogEntryByIndexOK struct {
 	Payload models.LogEntry
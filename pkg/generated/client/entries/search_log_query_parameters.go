@@ -30,6 +30,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 )
@@ -80,6 +81,22 @@ type SearchLogQueryParams struct {
 	// Entry.
 	Entry *models.SearchLogQuery
 
+	/* IntegratedTimeFrom.
+
+	   only return entries integrated at or after this time, in seconds since the epoch
+
+	   Format: int64
+	*/
+	IntegratedTimeFrom *int64
+
+	/* IntegratedTimeTo.
+
+	   only return entries integrated at or before this time, in seconds since the epoch
+
+	   Format: int64
+	*/
+	IntegratedTimeTo *int64
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -144,6 +161,28 @@ func (o *SearchLogQueryParams) SetEntry(entry *models.SearchLogQuery) {
 	o.Entry = entry
 }
 
+// WithIntegratedTimeFrom adds the integratedTimeFrom to the search log query params
+func (o *SearchLogQueryParams) WithIntegratedTimeFrom(integratedTimeFrom *int64) *SearchLogQueryParams {
+	o.SetIntegratedTimeFrom(integratedTimeFrom)
+	return o
+}
+
+// SetIntegratedTimeFrom adds the integratedTimeFrom to the search log query params
+func (o *SearchLogQueryParams) SetIntegratedTimeFrom(integratedTimeFrom *int64) {
+	o.IntegratedTimeFrom = integratedTimeFrom
+}
+
+// WithIntegratedTimeTo adds the integratedTimeTo to the search log query params
+func (o *SearchLogQueryParams) WithIntegratedTimeTo(integratedTimeTo *int64) *SearchLogQueryParams {
+	o.SetIntegratedTimeTo(integratedTimeTo)
+	return o
+}
+
+// SetIntegratedTimeTo adds the integratedTimeTo to the search log query params
+func (o *SearchLogQueryParams) SetIntegratedTimeTo(integratedTimeTo *int64) {
+	o.IntegratedTimeTo = integratedTimeTo
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *SearchLogQueryParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -157,6 +196,40 @@ func (o *SearchLogQueryParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 		}
 	}
 
+	if o.IntegratedTimeFrom != nil {
+
+		// query param integratedTimeFrom
+		var qrIntegratedTimeFrom int64
+
+		if o.IntegratedTimeFrom != nil {
+			qrIntegratedTimeFrom = *o.IntegratedTimeFrom
+		}
+		qIntegratedTimeFrom := swag.FormatInt64(qrIntegratedTimeFrom)
+		if qIntegratedTimeFrom != "" {
+
+			if err := r.SetQueryParam("integratedTimeFrom", qIntegratedTimeFrom); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.IntegratedTimeTo != nil {
+
+		// query param integratedTimeTo
+		var qrIntegratedTimeTo int64
+
+		if o.IntegratedTimeTo != nil {
+			qrIntegratedTimeTo = *o.IntegratedTimeTo
+		}
+		qIntegratedTimeTo := swag.FormatInt64(qrIntegratedTimeTo)
+		if qIntegratedTimeTo != "" {
+
+			if err := r.SetQueryParam("integratedTimeTo", qIntegratedTimeTo); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -30,6 +30,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 )
@@ -77,6 +78,34 @@ func NewSearchIndexParamsWithHTTPClient(client *http.Client) *SearchIndexParams
 */
 type SearchIndexParams struct {
 
+	/* IntegratedTimeFrom.
+
+	   only return entries integrated at or after this time, in seconds since the epoch; required to search a window of time
+
+	   Format: int64
+	*/
+	IntegratedTimeFrom *int64
+
+	/* IntegratedTimeTo.
+
+	   only return entries integrated at or before this time, in seconds since the epoch; defaults to the current time
+
+	   Format: int64
+	*/
+	IntegratedTimeTo *int64
+
+	/* PageSize.
+
+	   the maximum number of UUIDs to return; the server may return fewer, and caps this at its configured maximum
+	*/
+	PageSize *int64
+
+	/* PageToken.
+
+	   the Next-Page-Token returned with the previous page of results
+	*/
+	PageToken *string
+
 	// Query.
 	Query *models.SearchIndex
 
@@ -133,6 +162,50 @@ func (o *SearchIndexParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIntegratedTimeFrom adds the integratedTimeFrom to the search index params
+func (o *SearchIndexParams) WithIntegratedTimeFrom(integratedTimeFrom *int64) *SearchIndexParams {
+	o.SetIntegratedTimeFrom(integratedTimeFrom)
+	return o
+}
+
+// SetIntegratedTimeFrom adds the integratedTimeFrom to the search index params
+func (o *SearchIndexParams) SetIntegratedTimeFrom(integratedTimeFrom *int64) {
+	o.IntegratedTimeFrom = integratedTimeFrom
+}
+
+// WithIntegratedTimeTo adds the integratedTimeTo to the search index params
+func (o *SearchIndexParams) WithIntegratedTimeTo(integratedTimeTo *int64) *SearchIndexParams {
+	o.SetIntegratedTimeTo(integratedTimeTo)
+	return o
+}
+
+// SetIntegratedTimeTo adds the integratedTimeTo to the search index params
+func (o *SearchIndexParams) SetIntegratedTimeTo(integratedTimeTo *int64) {
+	o.IntegratedTimeTo = integratedTimeTo
+}
+
+// WithPageSize adds the pageSize to the search index params
+func (o *SearchIndexParams) WithPageSize(pageSize *int64) *SearchIndexParams {
+	o.SetPageSize(pageSize)
+	return o
+}
+
+// SetPageSize adds the pageSize to the search index params
+func (o *SearchIndexParams) SetPageSize(pageSize *int64) {
+	o.PageSize = pageSize
+}
+
+// WithPageToken adds the pageToken to the search index params
+func (o *SearchIndexParams) WithPageToken(pageToken *string) *SearchIndexParams {
+	o.SetPageToken(pageToken)
+	return o
+}
+
+// SetPageToken adds the pageToken to the search index params
+func (o *SearchIndexParams) SetPageToken(pageToken *string) {
+	o.PageToken = pageToken
+}
+
 // WithQuery adds the query to the search index params
 func (o *SearchIndexParams) WithQuery(query *models.SearchIndex) *SearchIndexParams {
 	o.SetQuery(query)
@@ -151,6 +224,74 @@ func (o *SearchIndexParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.R
 		return err
 	}
 	var res []error
+
+	if o.IntegratedTimeFrom != nil {
+
+		// query param integratedTimeFrom
+		var qrIntegratedTimeFrom int64
+
+		if o.IntegratedTimeFrom != nil {
+			qrIntegratedTimeFrom = *o.IntegratedTimeFrom
+		}
+		qIntegratedTimeFrom := swag.FormatInt64(qrIntegratedTimeFrom)
+		if qIntegratedTimeFrom != "" {
+
+			if err := r.SetQueryParam("integratedTimeFrom", qIntegratedTimeFrom); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.IntegratedTimeTo != nil {
+
+		// query param integratedTimeTo
+		var qrIntegratedTimeTo int64
+
+		if o.IntegratedTimeTo != nil {
+			qrIntegratedTimeTo = *o.IntegratedTimeTo
+		}
+		qIntegratedTimeTo := swag.FormatInt64(qrIntegratedTimeTo)
+		if qIntegratedTimeTo != "" {
+
+			if err := r.SetQueryParam("integratedTimeTo", qIntegratedTimeTo); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.PageSize != nil {
+
+		// query param pageSize
+		var qrPageSize int64
+
+		if o.PageSize != nil {
+			qrPageSize = *o.PageSize
+		}
+		qPageSize := swag.FormatInt64(qrPageSize)
+		if qPageSize != "" {
+
+			if err := r.SetQueryParam("pageSize", qPageSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.PageToken != nil {
+
+		// query param pageToken
+		var qrPageToken string
+
+		if o.PageToken != nil {
+			qrPageToken = *o.PageToken
+		}
+		qPageToken := qrPageToken
+		if qPageToken != "" {
+
+			if err := r.SetQueryParam("pageToken", qPageToken); err != nil {
+				return err
+			}
+		}
+	}
 	if o.Query != nil {
 		if err := r.SetBodyParam(o.Query); err != nil {
 			return err
@@ -73,6 +73,11 @@ func NewSearchIndexOK() *SearchIndexOK {
 Returns zero or more entry UUIDs from the transparency log based on search query
 */
 type SearchIndexOK struct {
+
+	/* token to retrieve the next page of results, absent on the last page
+	 */
+	NextPageToken string
+
 	Payload []string
 }
 
@@ -85,6 +90,13 @@ func (o *SearchIndexOK) GetPayload() []string {
 
 func (o *SearchIndexOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header Next-Page-Token
+	hdrNextPageToken := response.GetHeader("Next-Page-Token")
+
+	if hdrNextPageToken != "" {
+		o.NextPageToken = hdrNextPageToken
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
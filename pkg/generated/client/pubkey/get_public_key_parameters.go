@@ -30,6 +30,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewGetPublicKeyParams creates a new GetPublicKeyParams object,
@@ -74,6 +75,14 @@ func NewGetPublicKeyParamsWithHTTPClient(client *http.Client) *GetPublicKeyParam
    Typically these are written to a http.Request.
 */
 type GetPublicKeyParams struct {
+
+	/* History.
+
+	   Return every key the log has signed with, current key first, so that entries signed before a key rotation can be verified. Each PEM block has a Log-ID header, the hex-encoded SHA256 digest of the DER-encoded key found in the logID of the entries it signed, a Tree-ID header for the tree it signed, and Active-From and Active-Until headers in RFC 3339 format bounding when it was used.
+
+	*/
+	History *bool
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -91,7 +100,18 @@ func (o *GetPublicKeyParams) WithDefaults() *GetPublicKeyParams {
 //
 // All values with no default are reset to their zero value.
 func (o *GetPublicKeyParams) SetDefaults() {
-	// no default values defined for this parameter
+	var (
+		historyDefault = bool(false)
+	)
+
+	val := GetPublicKeyParams{
+		History: &historyDefault,
+	}
+
+	val.timeout = o.timeout
+	val.Context = o.Context
+	val.HTTPClient = o.HTTPClient
+	*o = val
 }
 
 // WithTimeout adds the timeout to the get public key params
@@ -127,6 +147,17 @@ func (o *GetPublicKeyParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithHistory adds the history to the get public key params
+func (o *GetPublicKeyParams) WithHistory(history *bool) *GetPublicKeyParams {
+	o.SetHistory(history)
+	return o
+}
+
+// SetHistory adds the history to the get public key params
+func (o *GetPublicKeyParams) SetHistory(history *bool) {
+	o.History = history
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetPublicKeyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -135,6 +166,23 @@ func (o *GetPublicKeyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 	}
 	var res []error
 
+	if o.History != nil {
+
+		// query param history
+		var qrHistory bool
+
+		if o.History != nil {
+			qrHistory = *o.History
+		}
+		qHistory := swag.FormatBool(qrHistory)
+		if qHistory != "" {
+
+			if err := r.SetQueryParam("history", qHistory); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
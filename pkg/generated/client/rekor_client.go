@@ -26,6 +26,7 @@ import (
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
+	"github.com/sigstore/rekor/pkg/generated/client/attestations"
 	"github.com/sigstore/rekor/pkg/generated/client/entries"
 	"github.com/sigstore/rekor/pkg/generated/client/index"
 	"github.com/sigstore/rekor/pkg/generated/client/pubkey"
@@ -75,6 +76,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Rekor {
 
 	cli := new(Rekor)
 	cli.Transport = transport
+	cli.Attestations = attestations.New(transport, formats)
 	cli.Entries = entries.New(transport, formats)
 	cli.Index = index.New(transport, formats)
 	cli.Pubkey = pubkey.New(transport, formats)
@@ -124,6 +126,8 @@ func (cfg *TransportConfig) WithSchemes(schemes []string) *TransportConfig {
 
 // Rekor is a client for rekor
 type Rekor struct {
+	Attestations attestations.ClientService
+
 	Entries entries.ClientService
 
 	Index index.ClientService
@@ -140,6 +144,7 @@ type Rekor struct {
 // SetTransport changes the transport on the client and all its subresources
 func (c *Rekor) SetTransport(transport runtime.ClientTransport) {
 	c.Transport = transport
+	c.Attestations.SetTransport(transport)
 	c.Entries.SetTransport(transport)
 	c.Index.SetTransport(transport)
 	c.Pubkey.SetTransport(transport)
@@ -44,6 +44,10 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	AddCheckpointCosignatures(params *AddCheckpointCosignaturesParams, opts ...ClientOption) (*AddCheckpointCosignaturesOK, error)
+
+	GetCheckpoint(params *GetCheckpointParams, opts ...ClientOption) (*GetCheckpointOK, error)
+
 	GetLogInfo(params *GetLogInfoParams, opts ...ClientOption) (*GetLogInfoOK, error)
 
 	GetLogProof(params *GetLogProofParams, opts ...ClientOption) (*GetLogProofOK, error)
@@ -51,6 +55,84 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  AddCheckpointCosignatures submits witness cosignatures for a checkpoint of the transparency log
+
+  Accepts a checkpoint in the C2SP signed note format carrying cosignatures from one or more witnesses configured on the server. The checkpoint must be consistent with the log; valid cosignatures are added to those served with the checkpoint.
+*/
+func (a *Client) AddCheckpointCosignatures(params *AddCheckpointCosignaturesParams, opts ...ClientOption) (*AddCheckpointCosignaturesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewAddCheckpointCosignaturesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "addCheckpointCosignatures",
+		Method:             "POST",
+		PathPattern:        "/api/v1/log/checkpoint",
+		ProducesMediaTypes: []string{"text/plain"},
+		ConsumesMediaTypes: []string{"text/plain"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &AddCheckpointCosignaturesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*AddCheckpointCosignaturesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*AddCheckpointCosignaturesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  GetCheckpoint gets the latest checkpoint of the transparency log
+
+  Returns the latest signed tree head as a checkpoint in the C2SP signed note format (https://c2sp.org/tlog-checkpoint), followed by the cosignatures that witnesses have submitted for it. Clients that require a quorum of witnesses can ask for the most recent checkpoint cosigned by at least that many witnesses.
+*/
+func (a *Client) GetCheckpoint(params *GetCheckpointParams, opts ...ClientOption) (*GetCheckpointOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetCheckpointParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getCheckpoint",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/checkpoint",
+		ProducesMediaTypes: []string{"text/plain"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetCheckpointReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetCheckpointOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetCheckpointDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetLogInfo gets information about the current state of the transparency log
 
@@ -0,0 +1,46 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file is not generated by the swagger tool; it allows entry kinds that are not part of the
+// OpenAPI definition to be unmarshalled by UnmarshalProposedEntry.
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/runtime"
+)
+
+// externalKinds maps kinds registered at runtime to a constructor for their proposed entry model
+var externalKinds sync.Map
+
+// RegisterExternalKind registers a proposed entry model for a kind which is handled outside of the
+// rekor server binary, such as by an external type handler
+func RegisterExternalKind(kind string, factory func() ProposedEntry) error {
+	if _, found := externalKinds.Load(kind); found {
+		return fmt.Errorf("external kind %q is already registered", kind)
+	}
+	// kinds from the OpenAPI definition are matched first, so they cannot be replaced
+	probe := []byte(fmt.Sprintf(`{"kind":%q}`, kind))
+	if _, err := unmarshalProposedEntry(probe, runtime.JSONConsumer()); err == nil || !strings.Contains(err.Error(), "invalid kind value") {
+		return fmt.Errorf("kind %q is defined by the OpenAPI definition", kind)
+	}
+	externalKinds.Store(kind, factory)
+	return nil
+}
@@ -38,6 +38,10 @@ import (
 // swagger:model jarV001Schema
 type JarV001Schema struct {
 
+	// PEM encoded PKCS7 signatures of any further signers of the JAR file, in the order they appear in the archive
+	// Read Only: true
+	AdditionalSignatures []string `json:"additionalSignatures,omitempty"`
+
 	// archive
 	// Required: true
 	Archive *JarV001SchemaArchive `json:"archive"`
@@ -106,6 +110,10 @@ func (m *JarV001Schema) validateSignature(formats strfmt.Registry) error {
 func (m *JarV001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateAdditionalSignatures(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateArchive(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -120,6 +128,15 @@ func (m *JarV001Schema) ContextValidate(ctx context.Context, formats strfmt.Regi
 	return nil
 }
 
+func (m *JarV001Schema) contextValidateAdditionalSignatures(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "additionalSignatures", "body", []string(m.AdditionalSignatures)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *JarV001Schema) contextValidateArchive(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.Archive != nil {
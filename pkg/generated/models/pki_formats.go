@@ -0,0 +1,54 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package models
+
+// This file is not generated by the swagger tool; the format properties below are no longer enums
+// in the OpenAPI definition so that formats registered at runtime validate, but the constants for
+// the built-in formats are kept for existing clients.
+
+const (
+
+	// RekordV001SchemaSignatureFormatPgp captures format value "pgp"
+	RekordV001SchemaSignatureFormatPgp string = "pgp"
+
+	// RekordV001SchemaSignatureFormatMinisign captures format value "minisign"
+	RekordV001SchemaSignatureFormatMinisign string = "minisign"
+
+	// RekordV001SchemaSignatureFormatX509 captures format value "x509"
+	RekordV001SchemaSignatureFormatX509 string = "x509"
+
+	// RekordV001SchemaSignatureFormatSSH captures format value "ssh"
+	RekordV001SchemaSignatureFormatSSH string = "ssh"
+)
+
+const (
+
+	// SearchIndexPublicKeyFormatPgp captures format value "pgp"
+	SearchIndexPublicKeyFormatPgp string = "pgp"
+
+	// SearchIndexPublicKeyFormatX509 captures format value "x509"
+	SearchIndexPublicKeyFormatX509 string = "x509"
+
+	// SearchIndexPublicKeyFormatMinisign captures format value "minisign"
+	SearchIndexPublicKeyFormatMinisign string = "minisign"
+
+	// SearchIndexPublicKeyFormatSSH captures format value "ssh"
+	SearchIndexPublicKeyFormatSSH string = "ssh"
+
+	// SearchIndexPublicKeyFormatTUF captures format value "tuf"
+	SearchIndexPublicKeyFormatTUF string = "tuf"
+)
@@ -121,6 +121,54 @@ func unmarshalProposedEntry(data []byte, consumer runtime.Consumer) (ProposedEnt
 			return nil, err
 		}
 		return &result, nil
+	case "archlinux":
+		var result Archlinux
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "authenticode":
+		var result Authenticode
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "cose":
+		var result Cose
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "crate":
+		var result Crate
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "cyclonedx":
+		var result Cyclonedx
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "deb":
+		var result Deb
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "git":
+		var result Git
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "gomod":
+		var result Gomod
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
 	case "helm":
 		var result Helm
 		if err := consumer.Consume(buf2, &result); err != nil {
@@ -139,6 +187,36 @@ func unmarshalProposedEntry(data []byte, consumer runtime.Consumer) (ProposedEnt
 			return nil, err
 		}
 		return &result, nil
+	case "maven":
+		var result Maven
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "npm":
+		var result Npm
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "oci":
+		var result Oci
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "openvex":
+		var result Openvex
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	case "pypi":
+		var result Pypi
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
 	case "rekord":
 		var result Rekord
 		if err := consumer.Consume(buf2, &result); err != nil {
@@ -157,6 +235,12 @@ func unmarshalProposedEntry(data []byte, consumer runtime.Consumer) (ProposedEnt
 			return nil, err
 		}
 		return &result, nil
+	case "spdx":
+		var result Spdx
+		if err := consumer.Consume(buf2, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
 	case "tuf":
 		var result TUF
 		if err := consumer.Consume(buf2, &result); err != nil {
@@ -164,6 +248,13 @@ func unmarshalProposedEntry(data []byte, consumer runtime.Consumer) (ProposedEnt
 		}
 		return &result, nil
 	}
+	if factory, ok := externalKinds.Load(getType.Kind); ok {
+		result := factory.(func() ProposedEntry)()
+		if err := consumer.Consume(buf2, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
 	return nil, errors.New(422, "invalid kind value: %q", getType.Kind)
 }
 
@@ -40,6 +40,9 @@ type Rfc3161V001Schema struct {
 	// Arbitrary content to be included in the verifiable entry in the transparency log
 	ExtraData interface{} `json:"extraData,omitempty"`
 
+	// tsa certificate
+	TsaCertificate *Rfc3161V001SchemaTsaCertificate `json:"tsaCertificate,omitempty"`
+
 	// tsr
 	// Required: true
 	Tsr *Rfc3161V001SchemaTsr `json:"tsr"`
@@ -49,6 +52,10 @@ type Rfc3161V001Schema struct {
 func (m *Rfc3161V001Schema) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateTsaCertificate(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTsr(formats); err != nil {
 		res = append(res, err)
 	}
@@ -59,6 +66,23 @@ func (m *Rfc3161V001Schema) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rfc3161V001Schema) validateTsaCertificate(formats strfmt.Registry) error {
+	if swag.IsZero(m.TsaCertificate) { // not required
+		return nil
+	}
+
+	if m.TsaCertificate != nil {
+		if err := m.TsaCertificate.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("tsaCertificate")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Rfc3161V001Schema) validateTsr(formats strfmt.Registry) error {
 
 	if err := validate.Required("tsr", "body", m.Tsr); err != nil {
@@ -81,6 +105,10 @@ func (m *Rfc3161V001Schema) validateTsr(formats strfmt.Registry) error {
 func (m *Rfc3161V001Schema) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateTsaCertificate(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateTsr(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -91,6 +119,20 @@ func (m *Rfc3161V001Schema) ContextValidate(ctx context.Context, formats strfmt.
 	return nil
 }
 
+func (m *Rfc3161V001Schema) contextValidateTsaCertificate(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.TsaCertificate != nil {
+		if err := m.TsaCertificate.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("tsaCertificate")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Rfc3161V001Schema) contextValidateTsr(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.Tsr != nil {
@@ -123,6 +165,63 @@ func (m *Rfc3161V001Schema) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// Rfc3161V001SchemaTsaCertificate The X509 certificate of the timestamping authority that signed the tsr, optionally followed by its issuing certificates
+//
+// swagger:model Rfc3161V001SchemaTsaCertificate
+type Rfc3161V001SchemaTsaCertificate struct {
+
+	// Specifies the PEM encoded certificate chain of the timestamping authority
+	// Required: true
+	// Format: byte
+	Content *strfmt.Base64 `json:"content"`
+}
+
+// Validate validates this rfc3161 v001 schema tsa certificate
+func (m *Rfc3161V001SchemaTsaCertificate) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Rfc3161V001SchemaTsaCertificate) validateContent(formats strfmt.Registry) error {
+
+	if err := validate.Required("tsaCertificate"+"."+"content", "body", m.Content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this rfc3161 v001 schema tsa certificate based on context it is used
+func (m *Rfc3161V001SchemaTsaCertificate) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Rfc3161V001SchemaTsaCertificate) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Rfc3161V001SchemaTsaCertificate) UnmarshalBinary(b []byte) error {
+	var res Rfc3161V001SchemaTsaCertificate
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
 // Rfc3161V001SchemaTsr Information about the tsr file associated with the entry
 //
 // swagger:model Rfc3161V001SchemaTsr
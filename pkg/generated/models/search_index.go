@@ -23,7 +23,6 @@ package models
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -36,6 +35,10 @@ import (
 // swagger:model SearchIndex
 type SearchIndex struct {
 
+	// SHA256 digest of a DER-encoded X.509 certificate that signed entries
+	// Pattern: ^(sha256:)?[0-9a-fA-F]{64}$
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty"`
+
 	// email
 	// Format: email
 	Email strfmt.Email `json:"email,omitempty"`
@@ -46,12 +49,20 @@ type SearchIndex struct {
 
 	// public key
 	PublicKey *SearchIndexPublicKey `json:"publicKey,omitempty"`
+
+	// URI subject alternative name of a certificate that signed entries, such as a SPIFFE ID or CI workflow URI
+	// Format: uri
+	URI strfmt.URI `json:"uri,omitempty"`
 }
 
 // Validate validates this search index
 func (m *SearchIndex) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCertificateFingerprint(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateEmail(formats); err != nil {
 		res = append(res, err)
 	}
@@ -64,12 +75,28 @@ func (m *SearchIndex) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateURI(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *SearchIndex) validateCertificateFingerprint(formats strfmt.Registry) error {
+	if swag.IsZero(m.CertificateFingerprint) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("certificateFingerprint", "body", m.CertificateFingerprint, `^(sha256:)?[0-9a-fA-F]{64}$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *SearchIndex) validateEmail(formats strfmt.Registry) error {
 	if swag.IsZero(m.Email) { // not required
 		return nil
@@ -111,6 +138,18 @@ func (m *SearchIndex) validatePublicKey(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SearchIndex) validateURI(formats strfmt.Registry) error {
+	if swag.IsZero(m.URI) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("uri", "body", "uri", m.URI.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validate this search index based on the context it is used
 func (m *SearchIndex) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -166,9 +205,8 @@ type SearchIndexPublicKey struct {
 	// Format: byte
 	Content strfmt.Base64 `json:"content,omitempty"`
 
-	// format
+	// one of the PKI formats supported by the server, e.g. pgp, x509, minisign, ssh or tuf
 	// Required: true
-	// Enum: [pgp x509 minisign ssh tuf]
 	Format *string `json:"format"`
 
 	// url
@@ -194,55 +232,12 @@ func (m *SearchIndexPublicKey) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
-var searchIndexPublicKeyTypeFormatPropEnum []interface{}
-
-func init() {
-	var res []string
-	if err := json.Unmarshal([]byte(`["pgp","x509","minisign","ssh","tuf"]`), &res); err != nil {
-		panic(err)
-	}
-	for _, v := range res {
-		searchIndexPublicKeyTypeFormatPropEnum = append(searchIndexPublicKeyTypeFormatPropEnum, v)
-	}
-}
-
-const (
-
-	// SearchIndexPublicKeyFormatPgp captures enum value "pgp"
-	SearchIndexPublicKeyFormatPgp string = "pgp"
-
-	// SearchIndexPublicKeyFormatX509 captures enum value "x509"
-	SearchIndexPublicKeyFormatX509 string = "x509"
-
-	// SearchIndexPublicKeyFormatMinisign captures enum value "minisign"
-	SearchIndexPublicKeyFormatMinisign string = "minisign"
-
-	// SearchIndexPublicKeyFormatSSH captures enum value "ssh"
-	SearchIndexPublicKeyFormatSSH string = "ssh"
-
-	// SearchIndexPublicKeyFormatTUF captures enum value "tuf"
-	SearchIndexPublicKeyFormatTUF string = "tuf"
-)
-
-// prop value enum
-func (m *SearchIndexPublicKey) validateFormatEnum(path, location string, value string) error {
-	if err := validate.EnumCase(path, location, value, searchIndexPublicKeyTypeFormatPropEnum, true); err != nil {
-		return err
-	}
-	return nil
-}
-
 func (m *SearchIndexPublicKey) validateFormat(formats strfmt.Registry) error {
 
 	if err := validate.Required("publicKey"+"."+"format", "body", m.Format); err != nil {
 		return err
 	}
 
-	// value enum
-	if err := m.validateFormatEnum("publicKey"+"."+"format", "body", *m.Format); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -18,9 +18,14 @@ limitations under the License.
 package restapi
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	// using embed to add the static html page duing build time
@@ -29,19 +34,24 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 	"github.com/mitchellh/mapstructure"
 	"github.com/rs/cors"
 	"github.com/spf13/viper"
 
 	pkgapi "github.com/sigstore/rekor/pkg/api"
 	"github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/attestations"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/timestamp"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/tracing"
+	"github.com/sigstore/rekor/pkg/types/external"
 	"github.com/sigstore/rekor/pkg/util"
 
 	"github.com/urfave/negroni"
@@ -78,16 +88,31 @@ func configureAPI(api *operations.RekorServerAPI) http.Handler {
 	api.ApplicationPemCertificateChainProducer = runtime.TextProducer()
 	api.ApplicationTimestampQueryConsumer = runtime.ByteStreamConsumer()
 	api.ApplicationTimestampReplyProducer = runtime.ByteStreamProducer()
-
-	api.EntriesCreateLogEntryHandler = entries.CreateLogEntryHandlerFunc(pkgapi.CreateLogEntryHandler)
+	api.TextEventStreamProducer = runtime.TextProducer()
+	api.TxtConsumer = runtime.TextConsumer()
+	api.TxtProducer = runtime.TextProducer()
+
+	if viper.GetBool("read_only") {
+		api.EntriesCreateLogEntryHandler = entries.CreateLogEntryHandlerFunc(pkgapi.CreateLogEntryReadOnlyHandler)
+		api.EntriesCreateLogEntriesHandler = entries.CreateLogEntriesHandlerFunc(pkgapi.CreateLogEntriesReadOnlyHandler)
+		api.TimestampGetTimestampResponseHandler = timestamp.GetTimestampResponseHandlerFunc(pkgapi.TimestampResponseReadOnlyHandler)
+		api.TlogAddCheckpointCosignaturesHandler = tlog.AddCheckpointCosignaturesHandlerFunc(pkgapi.AddCheckpointCosignaturesReadOnlyHandler)
+	} else {
+		api.EntriesCreateLogEntryHandler = entries.CreateLogEntryHandlerFunc(pkgapi.CreateLogEntryHandler)
+		api.EntriesCreateLogEntriesHandler = entries.CreateLogEntriesHandlerFunc(pkgapi.CreateLogEntriesHandler)
+		api.TimestampGetTimestampResponseHandler = timestamp.GetTimestampResponseHandlerFunc(pkgapi.TimestampResponseHandler)
+		api.TlogAddCheckpointCosignaturesHandler = tlog.AddCheckpointCosignaturesHandlerFunc(pkgapi.AddCheckpointCosignaturesHandler)
+	}
 	api.EntriesGetLogEntryByIndexHandler = entries.GetLogEntryByIndexHandlerFunc(pkgapi.GetLogEntryByIndexHandler)
 	api.EntriesGetLogEntryByUUIDHandler = entries.GetLogEntryByUUIDHandlerFunc(pkgapi.GetLogEntryByUUIDHandler)
 	api.EntriesSearchLogQueryHandler = entries.SearchLogQueryHandlerFunc(pkgapi.SearchLogQueryHandler)
+	api.EntriesStreamLogEntriesHandler = entries.StreamLogEntriesHandlerFunc(pkgapi.StreamLogEntriesHandler)
 
 	api.PubkeyGetPublicKeyHandler = pubkey.GetPublicKeyHandlerFunc(pkgapi.GetPublicKeyHandler)
 
 	api.TlogGetLogInfoHandler = tlog.GetLogInfoHandlerFunc(pkgapi.GetLogInfoHandler)
 	api.TlogGetLogProofHandler = tlog.GetLogProofHandlerFunc(pkgapi.GetLogProofHandler)
+	api.TlogGetCheckpointHandler = tlog.GetCheckpointHandlerFunc(pkgapi.GetCheckpointHandler)
 
 	if viper.GetBool("enable_retrieve_api") {
 		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexHandler)
@@ -95,25 +120,48 @@ func configureAPI(api *operations.RekorServerAPI) http.Handler {
 		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexNotImplementedHandler)
 	}
 
-	api.TimestampGetTimestampResponseHandler = timestamp.GetTimestampResponseHandlerFunc(pkgapi.TimestampResponseHandler)
+	if viper.GetBool("enable_attestation_storage") {
+		api.AttestationsGetAttestationByHashHandler = attestations.GetAttestationByHashHandlerFunc(pkgapi.GetAttestationByHashHandler)
+	} else {
+		api.AttestationsGetAttestationByHashHandler = attestations.GetAttestationByHashHandlerFunc(pkgapi.GetAttestationByHashNotImplementedHandler)
+	}
+	if viper.GetBool("enable_attestation_storage") && viper.GetBool("enable_retrieve_api") {
+		api.AttestationsSearchAttestationsHandler = attestations.SearchAttestationsHandlerFunc(pkgapi.SearchAttestationsHandler)
+	} else {
+		api.AttestationsSearchAttestationsHandler = attestations.SearchAttestationsHandlerFunc(pkgapi.SearchAttestationsNotImplementedHandler)
+	}
+
 	api.TimestampGetTimestampCertChainHandler = timestamp.GetTimestampCertChainHandlerFunc(pkgapi.GetTimestampCertChainHandler)
 
 	api.RegisterFormat("signedCheckpoint", &util.SignedNote{}, util.SignedCheckpointValidator)
 
-	api.PreServerShutdown = func() {}
+	// end the entry streams, which would otherwise hold up the shutdown of the HTTP server
+	api.PreServerShutdown = pkgapi.Drain
 
 	api.ServerShutdown = func() {}
 
 	// not cacheable
 	api.AddMiddlewareFor("GET", "/api/v1/log", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/log/proof", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/log/checkpoint", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/log/entries", middleware.NoCache)
-	api.AddMiddlewareFor("GET", "/api/v1/log/entries/{entryUUID}", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/log/entries/stream", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/timestamp", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/attestations", middleware.NoCache)
 
-	// cache forever
-	api.AddMiddlewareFor("GET", "/api/v1/log/publicKey", cacheForever)
+	// cache forever, except for the key history which grows when the key is rotated
+	api.AddMiddlewareFor("GET", "/api/v1/log/publicKey", cachePublicKey)
 	api.AddMiddlewareFor("GET", "/api/v1/log/timestamp/certchain", cacheForever)
+	api.AddMiddlewareFor("GET", "/api/v1/attestations/{attestationHash}", cacheForever)
+
+	// cacheable, but revalidated on every use since the inclusion proof grows with the log
+	api.AddMiddlewareFor("GET", "/api/v1/log/entries/{entryUUID}", revalidate)
+
+	// conditional GETs of the immutable resources
+	api.AddMiddlewareFor("GET", "/api/v1/log/publicKey", withETag)
+	api.AddMiddlewareFor("GET", "/api/v1/log/timestamp/certchain", withETag)
+	api.AddMiddlewareFor("GET", "/api/v1/attestations/{attestationHash}", withETag)
+	api.AddMiddlewareFor("GET", "/api/v1/log/entries/{entryUUID}", withEntryETag)
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
@@ -136,32 +184,36 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 	return handler
 }
 
-// We need this type to act as an adapter between zap and the middleware request logger.
-type logAdapter struct {
-}
-
-func (l *logAdapter) Print(v ...interface{}) {
-	log.Logger.Info(v...)
-}
-
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	middleware.DefaultLogger = middleware.RequestLogger(
-		&middleware.DefaultLogFormatter{Logger: &logAdapter{}})
-	returnHandler := middleware.Logger(handler)
-	returnHandler = middleware.Recoverer(returnHandler)
+	returnHandler := pkgapi.LimitRequestBody(handler)
+	returnHandler = pkgapi.RateLimit(returnHandler)
+	// runs before the rate limits, so that writes are limited per authenticated identity
+	returnHandler = pkgapi.Authenticate(returnHandler)
+	// while failed authentications count against the limit of the client address, before credentials are checked
+	returnHandler = pkgapi.LimitAuthFailures(returnHandler)
 	returnHandler = middleware.Heartbeat("/ping")(returnHandler)
 	returnHandler = serveStaticContent(returnHandler)
+	returnHandler = external.SchemaHandler(returnHandler)
 
 	handleCORS := cors.Default().Handler
 	returnHandler = handleCORS(returnHandler)
 
+	// compresses JSON and text responses for clients that accept gzip or deflate; entry streams are not compressed
+	returnHandler = middleware.Compress(5)(returnHandler)
 	returnHandler = wrapMetrics(returnHandler)
+	// wraps every middleware above, so that a panic in any of them is answered with a 500
+	returnHandler = middleware.Recoverer(returnHandler)
+	// logs every request, including those rejected by the middlewares above, with its trace ID
+	returnHandler = pkgapi.LogRequests(returnHandler)
+	returnHandler = tracing.Middleware(returnHandler)
 
 	return middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		r = r.WithContext(log.WithRequestID(ctx, middleware.GetReqID(ctx)))
+		// echoed so that clients can quote it when reporting a failed request
+		w.Header().Set(middleware.RequestIDHeader, middleware.GetReqID(ctx))
 		defer func() {
 			_ = log.RequestIDLogger(r).Sync()
 		}()
@@ -199,6 +251,123 @@ func cacheForever(handler http.Handler) http.Handler {
 	})
 }
 
+// cachePublicKey caches the current public key forever, but revalidates the key history
+func cachePublicKey(handler http.Handler) http.Handler {
+	current, history := cacheForever(handler), revalidate(handler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if withHistory, err := swag.ConvertBool(r.URL.Query().Get("history")); err == nil && withHistory {
+			history.ServeHTTP(w, r)
+			return
+		}
+		current.ServeHTTP(w, r)
+	})
+}
+
+func revalidate(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := negroni.NewResponseWriter(w)
+		ww.Before(func(w negroni.ResponseWriter) {
+			if w.Status() >= 200 && w.Status() <= 299 {
+				w.Header().Set("Cache-Control", "no-cache")
+			}
+		})
+		handler.ServeHTTP(ww, r)
+	})
+}
+
+// bufferedResponse holds the body of a response until its ETag is known
+type bufferedResponse struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	if b.status == 0 {
+		b.status = code
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// withETag tags successful responses with an ETag of their content, and answers requests whose If-None-Match
+// header lists it with 304 Not Modified. The ETag is weak since the same tag is sent whether or not the
+// response is compressed.
+func withETag(handler http.Handler) http.Handler {
+	return etagHandler(handler, func(body []byte) ([]byte, error) {
+		return body, nil
+	})
+}
+
+// withEntryETag is withETag for log entries. Their signed entry timestamp is signed afresh for every
+// response, so the ETag covers the rest of the entry, including its inclusion proof, instead.
+func withEntryETag(handler http.Handler) http.Handler {
+	return etagHandler(handler, stableEntryContent)
+}
+
+// stableEntryContent returns the log entries in body without their signed entry timestamps
+func stableEntryContent(body []byte) ([]byte, error) {
+	var entry models.LogEntry
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return nil, err
+	}
+	for _, e := range entry {
+		if e.Verification != nil {
+			e.Verification.SignedEntryTimestamp = nil
+		}
+	}
+	return json.Marshal(entry)
+}
+
+// etagHandler tags successful responses with an ETag of the part of their body returned by content; if
+// it cannot be determined, the response is sent without one
+func etagHandler(handler http.Handler, content func([]byte) ([]byte, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := &bufferedResponse{ResponseWriter: w}
+		handler.ServeHTTP(b, r)
+		if b.status == 0 {
+			b.status = http.StatusOK
+		}
+		if b.status == http.StatusOK {
+			tagged, err := content(b.body.Bytes())
+			if err != nil {
+				log.RequestIDLogger(r).Warnf("error computing ETag: %v", err)
+				w.WriteHeader(b.status)
+				_, _ = w.Write(b.body.Bytes())
+				return
+			}
+			sum := sha256.Sum256(tagged)
+			etag := fmt.Sprintf(`W/"%x"`, sum)
+			w.Header().Set("ETag", etag)
+			if etagMatch(r.Header.Get("If-None-Match"), etag) {
+				// nothing is left for the compression middleware to encode
+				w.Header().Del("Content-Type")
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.WriteHeader(b.status)
+		_, _ = w.Write(b.body.Bytes())
+	})
+}
+
+// etagMatch reports whether the If-None-Match header ifNoneMatch lists etag, comparing weakly
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func logAndServeError(w http.ResponseWriter, r *http.Request, err error) {
 	log.RequestIDLogger(r).Error(err)
 	requestFields := map[string]interface{}{}
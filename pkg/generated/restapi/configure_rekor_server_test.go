@@ -0,0 +1,189 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restapi
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func get(t *testing.T, handler http.Handler, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWithETag(t *testing.T) {
+	handler := withETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"value"}`))
+	}))
+
+	rec := get(t, handler, nil)
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || rec.Body.String() != `{"key":"value"}` {
+		t.Fatalf("unexpected response %d, ETag %q: %s", rec.Code, etag, rec.Body)
+	}
+
+	rec = get(t, handler, map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected 304 without a body for matching ETag, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("ETag"); got != etag {
+		t.Errorf("304 response has ETag %q, want %q", got, etag)
+	}
+	// the comparison is weak, and any of the listed tags may match
+	rec = get(t, handler, map[string]string{"If-None-Match": `"other", ` + strings.TrimPrefix(etag, "W/")})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for a list containing the ETag, got %d", rec.Code)
+	}
+	rec = get(t, handler, map[string]string{"If-None-Match": `W/"other"`})
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"key":"value"}` {
+		t.Errorf("expected the body for a different ETag, got %d: %s", rec.Code, rec.Body)
+	}
+
+	notFound := withETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	if rec := get(t, notFound, map[string]string{"If-None-Match": "*"}); rec.Code != http.StatusNotFound || rec.Header().Get("ETag") != "" {
+		t.Errorf("expected untagged 404, got %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestWithEntryETag(t *testing.T) {
+	treeSize := int64(2)
+	signed := 0
+	handler := withEntryETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// every response carries a freshly signed entry timestamp
+		signed++
+		entry := models.LogEntry{
+			"abcd": models.LogEntryAnon{
+				Body:           "Ym9keQ==",
+				IntegratedTime: swag.Int64(1),
+				LogID:          swag.String("id"),
+				LogIndex:       swag.Int64(1),
+				Verification: &models.LogEntryAnonVerification{
+					InclusionProof: &models.InclusionProof{
+						Hashes:   []string{"00"},
+						LogIndex: swag.Int64(1),
+						RootHash: swag.String("11"),
+						TreeSize: swag.Int64(treeSize),
+					},
+					SignedEntryTimestamp: strfmt.Base64(fmt.Sprintf("signature %d", signed)),
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entry)
+	}))
+
+	rec := get(t, handler, nil)
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" {
+		t.Fatalf("unexpected response %d, ETag %q", rec.Code, etag)
+	}
+	rec = get(t, handler, map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for an entry re-signed with the same proof, got %d", rec.Code)
+	}
+
+	// the ETag changes with the inclusion proof
+	treeSize = 3
+	rec = get(t, handler, map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("expected a new ETag once the log grew, got %d with ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	invalid := withEntryETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not an entry"))
+	}))
+	if rec := get(t, invalid, nil); rec.Code != http.StatusOK || rec.Header().Get("ETag") != "" || rec.Body.String() != "not an entry" {
+		t.Errorf("expected untagged body for an unparsable entry, got %d with ETag %q: %s", rec.Code, rec.Header().Get("ETag"), rec.Body)
+	}
+}
+
+func TestCompressedETag(t *testing.T) {
+	body := strings.Repeat(`{"key":"value"}`, 100)
+	handler := middleware.Compress(5)(withETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})))
+
+	plain := get(t, handler, nil)
+	if plain.Header().Get("Content-Encoding") != "" || plain.Body.String() != body {
+		t.Fatalf("unexpected uncompressed response, Content-Encoding %q", plain.Header().Get("Content-Encoding"))
+	}
+
+	rec := get(t, handler, map[string]string{"Accept-Encoding": "gzip"})
+	if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decompressed) != body {
+		t.Errorf("decompressed body = %q, want %q", decompressed, body)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" || etag != plain.Header().Get("ETag") {
+		t.Errorf("compressed response has ETag %q, uncompressed %q", etag, plain.Header().Get("ETag"))
+	}
+
+	rec = get(t, handler, map[string]string{"Accept-Encoding": "gzip", "If-None-Match": etag})
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected 304 without a body for a compressed revalidation, got %d: %q", rec.Code, rec.Body)
+	}
+}
+
+func TestCachePublicKey(t *testing.T) {
+	handler := cachePublicKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("key"))
+	}))
+
+	for target, want := range map[string]string{
+		"/api/v1/log/publicKey":               "s-maxage=31536000, max-age=31536000, immutable",
+		"/api/v1/log/publicKey?history=false": "s-maxage=31536000, max-age=31536000, immutable",
+		"/api/v1/log/publicKey?history=true":  "no-cache",
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if got := rec.Header().Get("Cache-Control"); got != want {
+			t.Errorf("%s: unexpected Cache-Control %q, want %q", target, got, want)
+		}
+	}
+}
@@ -27,6 +27,7 @@
 //  Consumes:
 //    - application/timestamp-query
 //    - application/json
+//    - text/plain
 //    - application/yaml
 //
 //  Produces:
@@ -34,6 +35,8 @@
 //    - application/timestamp-reply
 //    - application/x-pem-file
 //    - application/json
+//    - text/event-stream
+//    - text/plain
 //    - application/yaml
 //
 // swagger:meta
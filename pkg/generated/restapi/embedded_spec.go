@@ -53,8 +53,105 @@ func init() {
   },
   "host": "rekor.sigstore.dev",
   "paths": {
+    "/api/v1/attestations": {
+      "get": {
+        "description": "Returns the DSSE envelopes of the stored attestations about a subject, keyed by the UUID of their entries in the transparency log. Entries are paged through in ascending order of UUID as in /api/v1/index/retrieve, and a page may hold fewer envelopes than entries when some of them have no stored attestation.\n",
+        "tags": [
+          "attestations"
+        ],
+        "summary": "Search stored attestations by subject digest",
+        "operationId": "searchAttestations",
+        "parameters": [
+          {
+            "pattern": "^[a-zA-Z0-9]+:[0-9a-fA-F]+$",
+            "type": "string",
+            "description": "the digest of a subject of the attestations, as algorithm:hex",
+            "name": "subject",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "the maximum number of entries to return attestations from; the server caps this at its configured maximum",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the Next-Page-Token returned with the previous page of results",
+            "name": "pageToken",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The DSSE envelopes of the attestations, keyed by entry UUID",
+            "schema": {
+              "type": "object",
+              "additionalProperties": {
+                "$ref": "#/definitions/AttestationEnvelope"
+              }
+            },
+            "headers": {
+              "Next-Page-Token": {
+                "type": "string",
+                "description": "token to retrieve the next page of results, absent on the last page"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "501": {
+            "$ref": "#/responses/NotImplemented"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/attestations/{attestationHash}": {
+      "get": {
+        "description": "Returns the DSSE envelope of an attestation stored by this server, identified by the hash of the envelope recorded in its entry in the transparency log.\n",
+        "tags": [
+          "attestations"
+        ],
+        "summary": "Get a stored attestation by its hash",
+        "operationId": "getAttestationByHash",
+        "parameters": [
+          {
+            "pattern": "^(sha256:)?[0-9a-fA-F]{64}$",
+            "type": "string",
+            "description": "the SHA256 digest of the envelope",
+            "name": "attestationHash",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The DSSE envelope of the attestation",
+            "schema": {
+              "$ref": "#/definitions/AttestationEnvelope"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "501": {
+            "$ref": "#/responses/NotImplemented"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/index/retrieve": {
       "post": {
+        "description": "Matching UUIDs are returned in ascending order, a page at a time. When there are more results, the response carries a Next-Page-Token header to pass as pageToken to retrieve the next page. Searches limited to a window of integrated time only find entries that were indexed with their integration time; the window may span at most 366 days.\n",
         "tags": [
           "index"
         ],
@@ -68,6 +165,33 @@ func init() {
             "schema": {
               "$ref": "#/definitions/SearchIndex"
             }
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "the maximum number of UUIDs to return; the server may return fewer, and caps this at its configured maximum",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the Next-Page-Token returned with the previous page of results",
+            "name": "pageToken",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or after this time, in seconds since the epoch; required to search a window of time",
+            "name": "integratedTimeFrom",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or before this time, in seconds since the epoch; defaults to the current time",
+            "name": "integratedTimeTo",
+            "in": "query"
           }
         ],
         "responses": {
@@ -80,6 +204,12 @@ func init() {
                 "type": "string",
                 "pattern": "^[0-9a-fA-F]{64}$"
               }
+            },
+            "headers": {
+              "Next-Page-Token": {
+                "type": "string",
+                "description": "token to retrieve the next page of results, absent on the last page"
+              }
             }
           },
           "400": {
@@ -112,6 +242,83 @@ func init() {
         }
       }
     },
+    "/api/v1/log/checkpoint": {
+      "get": {
+        "description": "Returns the latest signed tree head as a checkpoint in the C2SP signed note format (https://c2sp.org/tlog-checkpoint), followed by the cosignatures that witnesses have submitted for it. Clients that require a quorum of witnesses can ask for the most recent checkpoint cosigned by at least that many witnesses.\n",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get the latest checkpoint of the transparency log",
+        "operationId": "getCheckpoint",
+        "parameters": [
+          {
+            "type": "integer",
+            "default": 0,
+            "description": "The minimum number of witnesses that must have cosigned the returned checkpoint",
+            "name": "witnesses",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The checkpoint, signed by the log and cosigned by zero or more witnesses",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      },
+      "post": {
+        "description": "Accepts a checkpoint in the C2SP signed note format carrying cosignatures from one or more witnesses configured on the server. The checkpoint must be consistent with the log; valid cosignatures are added to those served with the checkpoint.\n",
+        "consumes": [
+          "text/plain"
+        ],
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Submit witness cosignatures for a checkpoint of the transparency log",
+        "operationId": "addCheckpointCosignatures",
+        "parameters": [
+          {
+            "name": "checkpoint",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The checkpoint with all cosignatures collected for it so far",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "501": {
+            "$ref": "#/responses/NotImplemented"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/log/entries": {
       "get": {
         "tags": [
@@ -126,11 +333,20 @@ func init() {
             "name": "logIndex",
             "in": "query",
             "required": true
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "default": 1,
+            "description": "the number of consecutive entries to retrieve, starting at logIndex; fewer are returned at the end of the log. Inclusion proofs and signed entry timestamps are only returned when a single entry is retrieved.\n",
+            "name": "count",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "the entry in the transparency log requested along with an inclusion proof",
+            "description": "the entries in the transparency log requested, along with an inclusion proof if a single entry was requested",
             "schema": {
               "$ref": "#/definitions/LogEntry"
             }
@@ -162,7 +378,7 @@ func init() {
         ],
         "responses": {
           "201": {
-            "description": "Returns the entry created in the transparency log",
+            "description": "Returns the entry created in the transparency log, or the equivalent entry already in it if the server is configured to accept duplicate entries idempotently\n",
             "schema": {
               "$ref": "#/definitions/LogEntry"
             },
@@ -175,6 +391,10 @@ func init() {
                 "type": "string",
                 "format": "uri",
                 "description": "URI location of log entry"
+              },
+              "Rekor-Existing-Entry": {
+                "type": "boolean",
+                "description": "true if the entry was already in the transparency log"
               }
             }
           },
@@ -190,6 +410,47 @@ func init() {
         }
       }
     },
+    "/api/v1/log/entries/batch": {
+      "post": {
+        "description": "Creates an entry in the transparency log for each of the proposed entries. All entries are queued before the server waits for them to be included, so they are typically sequenced together. The number of entries accepted in one request is limited by the server.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Creates multiple entries in the transparency log",
+        "operationId": "createLogEntries",
+        "parameters": [
+          {
+            "name": "proposedEntries",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "array",
+              "minItems": 1,
+              "items": {
+                "$ref": "#/definitions/ProposedEntry"
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Returns the result for each proposed entry, in the order they were submitted",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BatchEntryResult"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/log/entries/retrieve": {
       "post": {
         "tags": [
@@ -205,6 +466,20 @@ func init() {
             "schema": {
               "$ref": "#/definitions/SearchLogQuery"
             }
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or after this time, in seconds since the epoch",
+            "name": "integratedTimeFrom",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or before this time, in seconds since the epoch",
+            "name": "integratedTimeTo",
+            "in": "query"
           }
         ],
         "responses": {
@@ -226,6 +501,47 @@ func init() {
         }
       }
     },
+    "/api/v1/log/entries/stream": {
+      "get": {
+        "description": "Returns a stream of Server-Sent Events, one for each entry integrated into the log. The ID of each event is the index of the entry, so clients that reconnect with a Last-Event-ID header resume after the last entry they received. Without a startIndex or Last-Event-ID, the stream starts with the next entry to be integrated.\n",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "entries"
+        ],
+        "summary": "Streams entries as they are integrated into the transparency log",
+        "operationId": "streamLogEntries",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "the index of the first entry to send",
+            "name": "startIndex",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the ID of the last event received by the client",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of events of type \"entry\", whose data is a StreamedLogEntry encoded as JSON\n",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/log/entries/{entryUUID}": {
       "get": {
         "description": "Returns the entry, root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log",
@@ -313,9 +629,18 @@ func init() {
         ],
         "summary": "Retrieve the public key that can be used to validate the signed tree head",
         "operationId": "getPublicKey",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Return every key the log has signed with, current key first, so that entries signed before a key rotation can be verified. Each PEM block has a Log-ID header, the hex-encoded SHA256 digest of the DER-encoded key found in the logID of the entries it signed, a Tree-ID header for the tree it signed, and Active-From and Active-Until headers in RFC 3339 format bounding when it was used.\n",
+            "name": "history",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "The public key",
+            "description": "The public key, or all public keys if history is set",
             "schema": {
               "type": "string"
             }
@@ -414,22 +739,96 @@ func init() {
     }
   },
   "definitions": {
-    "ConsistencyProof": {
+    "AttestationEnvelope": {
+      "description": "A DSSE envelope, as defined by https://github.com/secure-systems-lab/dsse",
       "type": "object",
       "required": [
-        "rootHash",
-        "hashes"
+        "payloadType",
+        "payload",
+        "signatures"
       ],
       "properties": {
-        "hashes": {
+        "payload": {
+          "type": "string",
+          "format": "byte"
+        },
+        "payloadType": {
+          "type": "string"
+        },
+        "signatures": {
           "type": "array",
           "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
+            "type": "object",
+            "required": [
+              "sig"
+            ],
+            "properties": {
+              "keyid": {
+                "type": "string"
+              },
+              "sig": {
+                "type": "string",
+                "format": "byte"
+              }
+            }
           }
-        },
-        "rootHash": {
+        }
+      }
+    },
+    "BatchEntryResult": {
+      "type": "object",
+      "required": [
+        "code"
+      ],
+      "properties": {
+        "code": {
+          "description": "The HTTP status code for this entry, e.g. 201 if it was created, or 409 (201 if the server accepts duplicate entries idempotently) if it was already in the log\n",
+          "type": "integer"
+        },
+        "entry": {
+          "$ref": "#/definitions/LogEntry"
+        },
+        "error": {
+          "$ref": "#/definitions/Error"
+        }
+      }
+    },
+    "ConflictError": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "integer"
+        },
+        "logIndex": {
+          "description": "the index of the equivalent entry; omitted if it has not been integrated into the log yet",
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
+        },
+        "uuid": {
+          "description": "the UUID of the equivalent entry already in the transparency log",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "ConsistencyProof": {
+      "type": "object",
+      "required": [
+        "rootHash",
+        "hashes"
+      ],
+      "properties": {
+        "hashes": {
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "rootHash": {
           "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
           "type": "string",
           "pattern": "^[0-9a-fA-F]{64}$"
@@ -575,6 +974,11 @@ func init() {
     "SearchIndex": {
       "type": "object",
       "properties": {
+        "certificateFingerprint": {
+          "description": "SHA256 digest of a DER-encoded X.509 certificate that signed entries",
+          "type": "string",
+          "pattern": "^(sha256:)?[0-9a-fA-F]{64}$"
+        },
         "email": {
           "type": "string",
           "format": "email"
@@ -594,20 +998,19 @@ func init() {
               "format": "byte"
             },
             "format": {
-              "type": "string",
-              "enum": [
-                "pgp",
-                "x509",
-                "minisign",
-                "ssh",
-                "tuf"
-              ]
+              "description": "one of the PKI formats supported by the server, e.g. pgp, x509, minisign, ssh or tuf",
+              "type": "string"
             },
             "url": {
               "type": "string",
               "format": "uri"
             }
           }
+        },
+        "uri": {
+          "description": "URI subject alternative name of a certificate that signed entries, such as a SPIFFE ID or CI workflow URI",
+          "type": "string",
+          "format": "uri"
         }
       }
     },
@@ -638,6 +1041,30 @@ func init() {
         }
       }
     },
+    "StreamedLogEntry": {
+      "type": "object",
+      "required": [
+        "uuid",
+        "logIndex",
+        "body"
+      ],
+      "properties": {
+        "body": {
+          "description": "the canonicalized entry",
+          "type": "string",
+          "format": "byte"
+        },
+        "logIndex": {
+          "description": "the index of the entry in the transparency log",
+          "type": "integer"
+        },
+        "uuid": {
+          "description": "the UUID of the entry in the transparency log",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
     "alpine": {
       "description": "Alpine package",
       "type": "object",
@@ -664,8 +1091,8 @@ func init() {
         }
       ]
     },
-    "helm": {
-      "description": "Helm chart",
+    "archlinux": {
+      "description": "Arch Linux package",
       "type": "object",
       "allOf": [
         {
@@ -683,14 +1110,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/helm/helm_schema.json"
+              "$ref": "pkg/types/archlinux/archlinux_schema.json"
             }
-          }
+          },
+          "additionalProperties": false
         }
       ]
     },
-    "intoto": {
-      "description": "Intoto object",
+    "authenticode": {
+      "description": "Authenticode signed PE/COFF image",
       "type": "object",
       "allOf": [
         {
@@ -708,15 +1136,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/intoto/intoto_schema.json"
+              "$ref": "pkg/types/authenticode/authenticode_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "jar": {
-      "description": "Java Archive (JAR)",
+    "cose": {
+      "description": "COSE Sign1 envelope",
       "type": "object",
       "allOf": [
         {
@@ -734,15 +1162,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/jar/jar_schema.json"
+              "$ref": "pkg/types/cose/cose_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rekord": {
-      "description": "Rekord object",
+    "crate": {
+      "description": "Rust crate with a PGP signature or Sigstore bundle",
       "type": "object",
       "allOf": [
         {
@@ -760,15 +1188,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/rekord/rekord_schema.json"
+              "$ref": "pkg/types/crate/crate_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rfc3161": {
-      "description": "RFC3161 Timestamp",
+    "cyclonedx": {
+      "description": "CycloneDX SBOM",
       "type": "object",
       "allOf": [
         {
@@ -786,15 +1214,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/rfc3161/rfc3161_schema.json"
+              "$ref": "pkg/types/cyclonedx/cyclonedx_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rpm": {
-      "description": "RPM package",
+    "deb": {
+      "description": "Debian package",
       "type": "object",
       "allOf": [
         {
@@ -812,15 +1240,15 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/rpm/rpm_schema.json"
+              "$ref": "pkg/types/deb/deb_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "tuf": {
-      "description": "TUF metadata",
+    "git": {
+      "description": "Git object",
       "type": "object",
       "allOf": [
         {
@@ -838,656 +1266,5091 @@ func init() {
             },
             "spec": {
               "type": "object",
-              "$ref": "pkg/types/tuf/tuf_schema.json"
+              "$ref": "pkg/types/git/git_schema.json"
             }
           },
           "additionalProperties": false
         }
       ]
-    }
-  },
-  "responses": {
-    "BadContent": {
-      "description": "The content supplied to the server was invalid",
-      "schema": {
-        "$ref": "#/definitions/Error"
-      }
-    },
-    "Conflict": {
-      "description": "The request conflicts with the current state of the transparency log",
-      "schema": {
-        "$ref": "#/definitions/Error"
-      },
-      "headers": {
-        "Location": {
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "InternalServerError": {
-      "description": "There was an internal error in the server while processing the request",
-      "schema": {
-        "$ref": "#/definitions/Error"
-      }
-    },
-    "NotFound": {
-      "description": "The content requested could not be found"
     },
-    "NotImplemented": {
-      "description": "The content requested is not implemented"
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json",
-    "application/yaml"
-  ],
-  "produces": [
-    "application/json;q=1",
-    "application/yaml"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "Rekor is a cryptographically secure, immutable transparency log for signed software releases.",
-    "title": "Rekor",
-    "version": "0.0.1"
-  },
-  "host": "rekor.sigstore.dev",
-  "paths": {
-    "/api/v1/index/retrieve": {
-      "post": {
-        "tags": [
-          "index"
-        ],
-        "summary": "Searches index by entry metadata",
-        "operationId": "searchIndex",
-        "parameters": [
-          {
-            "name": "query",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/SearchIndex"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Returns zero or more entry UUIDs from the transparency log based on search query",
-            "schema": {
-              "type": "array",
-              "items": {
-                "description": "Entry UUID in transparency log",
-                "type": "string",
-                "pattern": "^[0-9a-fA-F]{64}$"
-              }
-            }
-          },
-          "400": {
-            "description": "The content supplied to the server was invalid",
-            "schema": {
-              "$ref": "#/definitions/Error"
+    "gomod": {
+      "description": "Go module version with an author signature",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/gomod/gomod_schema.json"
             }
           },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "/api/v1/log": {
-      "get": {
-        "description": "Returns the current root hash and size of the merkle tree used to store the log entries.",
-        "tags": [
-          "tlog"
-        ],
-        "summary": "Get information about the current state of the transparency log",
-        "operationId": "getLogInfo",
-        "responses": {
-          "200": {
-            "description": "A JSON object with the root hash and tree size as properties",
-            "schema": {
-              "$ref": "#/definitions/LogInfo"
-            }
-          },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
+    "helm": {
+      "description": "Helm chart",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/helm/helm_schema.json"
             }
           }
         }
-      }
+      ]
     },
-    "/api/v1/log/entries": {
-      "get": {
-        "tags": [
-          "entries"
-        ],
-        "summary": "Retrieves an entry and inclusion proof from the transparency log (if it exists) by index",
-        "operationId": "getLogEntryByIndex",
-        "parameters": [
-          {
-            "minimum": 0,
-            "type": "integer",
-            "description": "specifies the index of the entry in the transparency log to be retrieved",
-            "name": "logIndex",
-            "in": "query",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "the entry in the transparency log requested along with an inclusion proof",
-            "schema": {
-              "$ref": "#/definitions/LogEntry"
+    "intoto": {
+      "description": "Intoto object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/intoto/intoto_schema.json"
             }
           },
-          "404": {
-            "description": "The content requested could not be found"
-          },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "additionalProperties": false
         }
-      },
-      "post": {
-        "description": "Creates an entry in the transparency log for a detached signature, public key, and content. Items can be included in the request or fetched by the server when URLs are specified.\n",
-        "tags": [
-          "entries"
-        ],
-        "summary": "Creates an entry in the transparency log",
-        "operationId": "createLogEntry",
-        "parameters": [
-          {
-            "name": "proposedEntry",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/ProposedEntry"
-            }
-          }
-        ],
-        "responses": {
-          "201": {
-            "description": "Returns the entry created in the transparency log",
-            "schema": {
-              "$ref": "#/definitions/LogEntry"
+      ]
+    },
+    "jar": {
+      "description": "Java Archive (JAR)",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "headers": {
-              "ETag": {
-                "type": "string",
-                "description": "UUID of log entry"
-              },
-              "Location": {
-                "type": "string",
-                "format": "uri",
-                "description": "URI location of log entry"
-              }
-            }
-          },
-          "400": {
-            "description": "The content supplied to the server was invalid",
-            "schema": {
-              "$ref": "#/definitions/Error"
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/jar/jar_schema.json"
             }
           },
-          "409": {
-            "description": "The request conflicts with the current state of the transparency log",
-            "schema": {
-              "$ref": "#/definitions/Error"
+          "additionalProperties": false
+        }
+      ]
+    },
+    "maven": {
+      "description": "Maven artifact with a detached PGP signature",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "headers": {
-              "Location": {
-                "type": "string",
-                "format": "uri"
-              }
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/maven/maven_schema.json"
             }
           },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "/api/v1/log/entries/retrieve": {
-      "post": {
-        "tags": [
-          "entries"
-        ],
-        "summary": "Searches transparency log for one or more log entries",
-        "operationId": "searchLogQuery",
-        "parameters": [
-          {
-            "name": "entry",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/SearchLogQuery"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Returns zero or more entries from the transparency log, according to how many were included in request query",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/LogEntry"
-              }
-            }
-          },
-          "400": {
-            "description": "The content supplied to the server was invalid",
-            "schema": {
-              "$ref": "#/definitions/Error"
+    "npm": {
+      "description": "npm package",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/npm/npm_schema.json"
             }
           },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "/api/v1/log/entries/{entryUUID}": {
-      "get": {
-        "description": "Returns the entry, root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log",
-        "tags": [
-          "entries"
-        ],
-        "summary": "Get log entry and information required to generate an inclusion proof for the entry in the transparency log",
-        "operationId": "getLogEntryByUUID",
-        "parameters": [
-          {
-            "pattern": "^[0-9a-fA-F]{64}$",
-            "type": "string",
-            "description": "the UUID of the entry for which the inclusion proof information should be returned",
-            "name": "entryUUID",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Information needed for a client to compute the inclusion proof",
-            "schema": {
-              "$ref": "#/definitions/LogEntry"
+    "oci": {
+      "description": "OCI image signature",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/oci/oci_schema.json"
             }
           },
-          "404": {
-            "description": "The content requested could not be found"
-          },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "/api/v1/log/proof": {
+    "openvex": {
+      "description": "Signed OpenVEX documents",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/openvex/openvex_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "pypi": {
+      "description": "Python distribution with a PEP 740 attestation",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/pypi/pypi_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rekord": {
+      "description": "Rekord object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/rekord/rekord_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rfc3161": {
+      "description": "RFC3161 Timestamp",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/rfc3161/rfc3161_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rpm": {
+      "description": "RPM package",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/rpm/rpm_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "spdx": {
+      "description": "SPDX SBOM document",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/spdx/spdx_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "tuf": {
+      "description": "TUF metadata",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/tuf/tuf_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    }
+  },
+  "responses": {
+    "BadContent": {
+      "description": "The content supplied to the server was invalid",
+      "schema": {
+        "$ref": "#/definitions/Error"
+      }
+    },
+    "Conflict": {
+      "description": "The request conflicts with the current state of the transparency log",
+      "schema": {
+        "$ref": "#/definitions/ConflictError"
+      },
+      "headers": {
+        "ETag": {
+          "type": "string",
+          "description": "UUID of the equivalent log entry"
+        },
+        "Location": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "InternalServerError": {
+      "description": "There was an internal error in the server while processing the request",
+      "schema": {
+        "$ref": "#/definitions/Error"
+      }
+    },
+    "NotFound": {
+      "description": "The content requested could not be found"
+    },
+    "NotImplemented": {
+      "description": "The content requested is not implemented"
+    }
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json",
+    "application/yaml"
+  ],
+  "produces": [
+    "application/json;q=1",
+    "application/yaml"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "Rekor is a cryptographically secure, immutable transparency log for signed software releases.",
+    "title": "Rekor",
+    "version": "0.0.1"
+  },
+  "host": "rekor.sigstore.dev",
+  "paths": {
+    "/api/v1/attestations": {
       "get": {
-        "description": "Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log",
+        "description": "Returns the DSSE envelopes of the stored attestations about a subject, keyed by the UUID of their entries in the transparency log. Entries are paged through in ascending order of UUID as in /api/v1/index/retrieve, and a page may hold fewer envelopes than entries when some of them have no stored attestation.\n",
         "tags": [
-          "tlog"
+          "attestations"
         ],
-        "summary": "Get information required to generate a consistency proof for the transparency log",
-        "operationId": "getLogProof",
+        "summary": "Search stored attestations by subject digest",
+        "operationId": "searchAttestations",
         "parameters": [
+          {
+            "pattern": "^[a-zA-Z0-9]+:[0-9a-fA-F]+$",
+            "type": "string",
+            "description": "the digest of a subject of the attestations, as algorithm:hex",
+            "name": "subject",
+            "in": "query",
+            "required": true
+          },
           {
             "minimum": 1,
             "type": "integer",
-            "default": 1,
-            "description": "The size of the tree that you wish to prove consistency from (1 means the beginning of the log) Defaults to 1 if not specified\n",
-            "name": "firstSize",
+            "description": "the maximum number of entries to return attestations from; the server caps this at its configured maximum",
+            "name": "pageSize",
             "in": "query"
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "description": "The size of the tree that you wish to prove consistency to",
-            "name": "lastSize",
-            "in": "query",
-            "required": true
+            "type": "string",
+            "description": "the Next-Page-Token returned with the previous page of results",
+            "name": "pageToken",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "All hashes required to compute the consistency proof",
-            "schema": {
-              "$ref": "#/definitions/ConsistencyProof"
-            }
-          },
-          "400": {
-            "description": "The content supplied to the server was invalid",
+            "description": "The DSSE envelopes of the attestations, keyed by entry UUID",
+            "schema": {
+              "type": "object",
+              "additionalProperties": {
+                "$ref": "#/definitions/AttestationEnvelope"
+              }
+            },
+            "headers": {
+              "Next-Page-Token": {
+                "type": "string",
+                "description": "token to retrieve the next page of results, absent on the last page"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "501": {
+            "description": "The content requested is not implemented"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/attestations/{attestationHash}": {
+      "get": {
+        "description": "Returns the DSSE envelope of an attestation stored by this server, identified by the hash of the envelope recorded in its entry in the transparency log.\n",
+        "tags": [
+          "attestations"
+        ],
+        "summary": "Get a stored attestation by its hash",
+        "operationId": "getAttestationByHash",
+        "parameters": [
+          {
+            "pattern": "^(sha256:)?[0-9a-fA-F]{64}$",
+            "type": "string",
+            "description": "the SHA256 digest of the envelope",
+            "name": "attestationHash",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The DSSE envelope of the attestation",
+            "schema": {
+              "$ref": "#/definitions/AttestationEnvelope"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "501": {
+            "description": "The content requested is not implemented"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/index/retrieve": {
+      "post": {
+        "description": "Matching UUIDs are returned in ascending order, a page at a time. When there are more results, the response carries a Next-Page-Token header to pass as pageToken to retrieve the next page. Searches limited to a window of integrated time only find entries that were indexed with their integration time; the window may span at most 366 days.\n",
+        "tags": [
+          "index"
+        ],
+        "summary": "Searches index by entry metadata",
+        "operationId": "searchIndex",
+        "parameters": [
+          {
+            "name": "query",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SearchIndex"
+            }
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "the maximum number of UUIDs to return; the server may return fewer, and caps this at its configured maximum",
+            "name": "pageSize",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the Next-Page-Token returned with the previous page of results",
+            "name": "pageToken",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or after this time, in seconds since the epoch; required to search a window of time",
+            "name": "integratedTimeFrom",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or before this time, in seconds since the epoch; defaults to the current time",
+            "name": "integratedTimeTo",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Returns zero or more entry UUIDs from the transparency log based on search query",
+            "schema": {
+              "type": "array",
+              "items": {
+                "description": "Entry UUID in transparency log",
+                "type": "string",
+                "pattern": "^[0-9a-fA-F]{64}$"
+              }
+            },
+            "headers": {
+              "Next-Page-Token": {
+                "type": "string",
+                "description": "token to retrieve the next page of results, absent on the last page"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log": {
+      "get": {
+        "description": "Returns the current root hash and size of the merkle tree used to store the log entries.",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get information about the current state of the transparency log",
+        "operationId": "getLogInfo",
+        "responses": {
+          "200": {
+            "description": "A JSON object with the root hash and tree size as properties",
+            "schema": {
+              "$ref": "#/definitions/LogInfo"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/checkpoint": {
+      "get": {
+        "description": "Returns the latest signed tree head as a checkpoint in the C2SP signed note format (https://c2sp.org/tlog-checkpoint), followed by the cosignatures that witnesses have submitted for it. Clients that require a quorum of witnesses can ask for the most recent checkpoint cosigned by at least that many witnesses.\n",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get the latest checkpoint of the transparency log",
+        "operationId": "getCheckpoint",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "default": 0,
+            "description": "The minimum number of witnesses that must have cosigned the returned checkpoint",
+            "name": "witnesses",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The checkpoint, signed by the log and cosigned by zero or more witnesses",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Accepts a checkpoint in the C2SP signed note format carrying cosignatures from one or more witnesses configured on the server. The checkpoint must be consistent with the log; valid cosignatures are added to those served with the checkpoint.\n",
+        "consumes": [
+          "text/plain"
+        ],
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Submit witness cosignatures for a checkpoint of the transparency log",
+        "operationId": "addCheckpointCosignatures",
+        "parameters": [
+          {
+            "name": "checkpoint",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The checkpoint with all cosignatures collected for it so far",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "501": {
+            "description": "The content requested is not implemented"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries": {
+      "get": {
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves an entry and inclusion proof from the transparency log (if it exists) by index",
+        "operationId": "getLogEntryByIndex",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the index of the entry in the transparency log to be retrieved",
+            "name": "logIndex",
+            "in": "query",
+            "required": true
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "default": 1,
+            "description": "the number of consecutive entries to retrieve, starting at logIndex; fewer are returned at the end of the log. Inclusion proofs and signed entry timestamps are only returned when a single entry is retrieved.\n",
+            "name": "count",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entries in the transparency log requested, along with an inclusion proof if a single entry was requested",
+            "schema": {
+              "$ref": "#/definitions/LogEntry"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
             "schema": {
               "$ref": "#/definitions/Error"
             }
-          },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
+          }
+        }
+      },
+      "post": {
+        "description": "Creates an entry in the transparency log for a detached signature, public key, and content. Items can be included in the request or fetched by the server when URLs are specified.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Creates an entry in the transparency log",
+        "operationId": "createLogEntry",
+        "parameters": [
+          {
+            "name": "proposedEntry",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ProposedEntry"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Returns the entry created in the transparency log, or the equivalent entry already in it if the server is configured to accept duplicate entries idempotently\n",
+            "schema": {
+              "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of log entry"
+              },
+              "Location": {
+                "type": "string",
+                "format": "uri",
+                "description": "URI location of log entry"
+              },
+              "Rekor-Existing-Entry": {
+                "type": "boolean",
+                "description": "true if the entry was already in the transparency log"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "The request conflicts with the current state of the transparency log",
+            "schema": {
+              "$ref": "#/definitions/ConflictError"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of the equivalent log entry"
+              },
+              "Location": {
+                "type": "string",
+                "format": "uri"
+              }
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/batch": {
+      "post": {
+        "description": "Creates an entry in the transparency log for each of the proposed entries. All entries are queued before the server waits for them to be included, so they are typically sequenced together. The number of entries accepted in one request is limited by the server.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Creates multiple entries in the transparency log",
+        "operationId": "createLogEntries",
+        "parameters": [
+          {
+            "name": "proposedEntries",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "array",
+              "minItems": 1,
+              "items": {
+                "$ref": "#/definitions/ProposedEntry"
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Returns the result for each proposed entry, in the order they were submitted",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BatchEntryResult"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/retrieve": {
+      "post": {
+        "tags": [
+          "entries"
+        ],
+        "summary": "Searches transparency log for one or more log entries",
+        "operationId": "searchLogQuery",
+        "parameters": [
+          {
+            "name": "entry",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SearchLogQuery"
+            }
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or after this time, in seconds since the epoch",
+            "name": "integratedTimeFrom",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "description": "only return entries integrated at or before this time, in seconds since the epoch",
+            "name": "integratedTimeTo",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Returns zero or more entries from the transparency log, according to how many were included in request query",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/LogEntry"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/stream": {
+      "get": {
+        "description": "Returns a stream of Server-Sent Events, one for each entry integrated into the log. The ID of each event is the index of the entry, so clients that reconnect with a Last-Event-ID header resume after the last entry they received. Without a startIndex or Last-Event-ID, the stream starts with the next entry to be integrated.\n",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "entries"
+        ],
+        "summary": "Streams entries as they are integrated into the transparency log",
+        "operationId": "streamLogEntries",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "the index of the first entry to send",
+            "name": "startIndex",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the ID of the last event received by the client",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of events of type \"entry\", whose data is a StreamedLogEntry encoded as JSON\n",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/{entryUUID}": {
+      "get": {
+        "description": "Returns the entry, root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Get log entry and information required to generate an inclusion proof for the entry in the transparency log",
+        "operationId": "getLogEntryByUUID",
+        "parameters": [
+          {
+            "pattern": "^[0-9a-fA-F]{64}$",
+            "type": "string",
+            "description": "the UUID of the entry for which the inclusion proof information should be returned",
+            "name": "entryUUID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Information needed for a client to compute the inclusion proof",
+            "schema": {
+              "$ref": "#/definitions/LogEntry"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/proof": {
+      "get": {
+        "description": "Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get information required to generate a consistency proof for the transparency log",
+        "operationId": "getLogProof",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "default": 1,
+            "description": "The size of the tree that you wish to prove consistency from (1 means the beginning of the log) Defaults to 1 if not specified\n",
+            "name": "firstSize",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The size of the tree that you wish to prove consistency to",
+            "name": "lastSize",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "All hashes required to compute the consistency proof",
+            "schema": {
+              "$ref": "#/definitions/ConsistencyProof"
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/publicKey": {
+      "get": {
+        "description": "Returns the public key that can be used to validate the signed tree head",
+        "produces": [
+          "application/x-pem-file"
+        ],
+        "tags": [
+          "pubkey"
+        ],
+        "summary": "Retrieve the public key that can be used to validate the signed tree head",
+        "operationId": "getPublicKey",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Return every key the log has signed with, current key first, so that entries signed before a key rotation can be verified. Each PEM block has a Log-ID header, the hex-encoded SHA256 digest of the DER-encoded key found in the logID of the entries it signed, a Tree-ID header for the tree it signed, and Active-From and Active-Until headers in RFC 3339 format bounding when it was used.\n",
+            "name": "history",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The public key, or all public keys if history is set",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/timestamp": {
+      "post": {
+        "consumes": [
+          "application/timestamp-query"
+        ],
+        "produces": [
+          "application/timestamp-reply"
+        ],
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Generates a new timestamp response and creates a new log entry for the timestamp in the transparency log",
+        "operationId": "getTimestampResponse",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Returns a timestamp response and the location of the log entry in the transprency log",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of the log entry made for the timestamp response"
+              },
+              "Index": {
+                "type": "integer",
+                "description": "Log index of the log entry made for the timestamp response"
+              },
+              "Location": {
+                "type": "string",
+                "format": "uri",
+                "description": "URI location of the log entry made for the timestamp response"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "501": {
+            "description": "The content requested is not implemented"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/timestamp/certchain": {
+      "get": {
+        "description": "Returns the certfiicate chain for timestamping that can be used to validate trusted timestamps",
+        "produces": [
+          "application/pem-certificate-chain"
+        ],
+        "tags": [
+          "timestamp"
+        ],
+        "summary": "Retrieve the certfiicate chain for timestamping that can be used to validate trusted timestamps",
+        "operationId": "getTimestampCertChain",
+        "responses": {
+          "200": {
+            "description": "The PEM encoded cert chain",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "AlpineV001SchemaPackage": {
+      "description": "Information about the package associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          }
+        },
+        "pkginfo": {
+          "description": "Values of the .PKGINFO key / value pairs",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "AlpineV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      }
+    },
+    "AlpineV001SchemaPublicKey": {
+      "description": "The public key that can verify the package signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "ArchlinuxV001SchemaPackage": {
+      "description": "Information about the package associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          }
+        },
+        "pkginfo": {
+          "description": "Values of the pkgname, pkgbase, pkgver and arch fields from .PKGINFO",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "ArchlinuxV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      }
+    },
+    "ArchlinuxV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the package signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "ArchlinuxV001SchemaSignature": {
+      "description": "The detached PGP signature over the package",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "AttestationEnvelope": {
+      "description": "A DSSE envelope, as defined by https://github.com/secure-systems-lab/dsse",
+      "type": "object",
+      "required": [
+        "payloadType",
+        "payload",
+        "signatures"
+      ],
+      "properties": {
+        "payload": {
+          "type": "string",
+          "format": "byte"
+        },
+        "payloadType": {
+          "type": "string"
+        },
+        "signatures": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AttestationEnvelopeSignaturesItems0"
+          }
+        }
+      }
+    },
+    "AttestationEnvelopeSignaturesItems0": {
+      "type": "object",
+      "required": [
+        "sig"
+      ],
+      "properties": {
+        "keyid": {
+          "type": "string"
+        },
+        "sig": {
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "AuthenticodeV001SchemaImage": {
+      "description": "Information about the PE/COFF image associated with the entry",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the image inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire signed image",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the image",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the image; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "AuthenticodeV001SchemaImageHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed image",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the image",
+          "type": "string"
+        }
+      }
+    },
+    "AuthenticodeV001SchemaSignature": {
+      "description": "Information about the Authenticode signature embedded in the image",
+      "type": "object",
+      "required": [
+        "content",
+        "publicKey",
+        "digest"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the PKCS7 signature from the certificate table of the image",
+          "type": "string",
+          "format": "byte"
+        },
+        "digest": {
+          "description": "The Authenticode digest of the image that the signature covers",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the digest",
+              "type": "string",
+              "enum": [
+                "sha1",
+                "sha256",
+                "sha384",
+                "sha512"
+              ]
+            },
+            "value": {
+              "description": "The digest of the image, excluding its checksum and certificate table",
+              "type": "string"
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The X509 certificate of the signer",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      },
+      "readOnly": true
+    },
+    "AuthenticodeV001SchemaSignatureDigest": {
+      "description": "The Authenticode digest of the image that the signature covers",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the digest",
+          "type": "string",
+          "enum": [
+            "sha1",
+            "sha256",
+            "sha384",
+            "sha512"
+          ]
+        },
+        "value": {
+          "description": "The digest of the image, excluding its checksum and certificate table",
+          "type": "string"
+        }
+      }
+    },
+    "AuthenticodeV001SchemaSignaturePublicKey": {
+      "description": "The X509 certificate of the signer",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "BatchEntryResult": {
+      "type": "object",
+      "required": [
+        "code"
+      ],
+      "properties": {
+        "code": {
+          "description": "The HTTP status code for this entry, e.g. 201 if it was created, or 409 (201 if the server accepts duplicate entries idempotently) if it was already in the log\n",
+          "type": "integer"
+        },
+        "entry": {
+          "$ref": "#/definitions/LogEntry"
+        },
+        "error": {
+          "$ref": "#/definitions/Error"
+        }
+      }
+    },
+    "ConflictError": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "integer"
+        },
+        "logIndex": {
+          "description": "the index of the equivalent entry; omitted if it has not been integrated into the log yet",
+          "type": "integer",
+          "minimum": 0
+        },
+        "message": {
+          "type": "string"
+        },
+        "uuid": {
+          "description": "the UUID of the equivalent entry already in the transparency log",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "ConsistencyProof": {
+      "type": "object",
+      "required": [
+        "rootHash",
+        "hashes"
+      ],
+      "properties": {
+        "hashes": {
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "CoseV001SchemaData": {
+      "description": "Information about the content signed by the message",
+      "type": "object",
+      "properties": {
+        "envelopeHash": {
+          "description": "Specifies the hash algorithm and value for the canonicalized COSE_Sign1 message",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the envelope",
+              "type": "string"
+            }
+          }
+        },
+        "payloadHash": {
+          "description": "Specifies the hash algorithm and value for the payload",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the payload",
+              "type": "string"
+            }
+          }
+        }
+      },
+      "readOnly": true
+    },
+    "CoseV001SchemaDataEnvelopeHash": {
+      "description": "Specifies the hash algorithm and value for the canonicalized COSE_Sign1 message",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the envelope",
+          "type": "string"
+        }
+      }
+    },
+    "CoseV001SchemaDataPayloadHash": {
+      "description": "Specifies the hash algorithm and value for the payload",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the payload",
+          "type": "string"
+        }
+      }
+    },
+    "CrateV001SchemaPackage": {
+      "description": "Information about the .crate file associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the .crate file inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the .crate file, which is the checksum recorded in the crates.io index",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the .crate file",
+              "type": "string"
+            }
+          }
+        },
+        "name": {
+          "description": "The crate name, as declared in the embedded Cargo.toml",
+          "type": "string",
+          "readOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the .crate file; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        },
+        "version": {
+          "description": "The crate version, as declared in the embedded Cargo.toml",
+          "type": "string",
+          "readOnly": true
+        }
+      }
+    },
+    "CrateV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the .crate file, which is the checksum recorded in the crates.io index",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the .crate file",
+          "type": "string"
+        }
+      }
+    },
+    "CrateV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the signature; for Sigstore bundles this is the signing certificate and is set by the server",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "CrateV001SchemaSignature": {
+      "description": "The signature over the .crate file",
+      "type": "object",
+      "required": [
+        "format"
+      ],
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the type of signature, either a detached PGP signature or a Sigstore bundle with a message signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "sigstore"
+          ]
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "CyclonedxV001SchemaBom": {
+      "description": "Information about the CycloneDX BOM",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the BOM inline within the entry, in JSON or XML format",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the BOM",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the BOM",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "CyclonedxV001SchemaBomHash": {
+      "description": "Specifies the hash algorithm and value for the BOM",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the BOM",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "DebV001SchemaPackage": {
+      "description": "Information about the .deb package or .changes file associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "control": {
+          "description": "Values of the package name, version and architecture control fields",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
+        },
+        "fileChecksums": {
+          "description": "Checksums of the files shipped in the package or listed in the .changes file, formatted as algorithm:value",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "readOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "DebV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      }
+    },
+    "DebV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the package or .changes signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "DebV001SchemaSignature": {
+      "description": "The detached PGP signature over a .changes file; .deb packages carry their signature in the _gpgorigin archive member",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "Error": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "GitV001SchemaObject": {
+      "description": "The signed git object",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "The raw commit or tag object, as printed by git cat-file",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "id": {
+          "description": "The SHA-1 object name of the git object",
+          "type": "string",
+          "readOnly": true
+        },
+        "target": {
+          "description": "The object name of the object referenced by a tag",
+          "type": "string",
+          "readOnly": true
+        },
+        "type": {
+          "description": "The type of the git object",
+          "type": "string",
+          "enum": [
+            "commit",
+            "tag"
+          ],
+          "readOnly": true
+        }
+      }
+    },
+    "GitV001SchemaSignature": {
+      "description": "The signature embedded in the git object",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "The signature, as embedded in the git object",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "The format of the signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "ssh",
+            "x509"
+          ]
+        }
+      },
+      "readOnly": true
+    },
+    "GomodV001SchemaModule": {
+      "description": "The module version and the hashes the checksum database records for it",
+      "type": "object",
+      "required": [
+        "path",
+        "version",
+        "hash"
+      ],
+      "properties": {
+        "goModHash": {
+          "description": "The h1: dirhash of the module's go.mod file",
+          "type": "string"
+        },
+        "hash": {
+          "description": "The h1: dirhash of the module zip",
+          "type": "string"
+        },
+        "path": {
+          "description": "The module path",
+          "type": "string"
+        },
+        "version": {
+          "description": "The canonical semantic version of the module",
+          "type": "string"
+        },
+        "zip": {
+          "description": "The module zip; if specified, the server checks it matches the hash",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        }
+      }
+    },
+    "GomodV001SchemaSignature": {
+      "description": "The author's signature over the module's go.sum lines, i.e. '\u003cpath\u003e \u003cversion\u003e \u003chash\u003e' followed by '\u003cpath\u003e \u003cversion\u003e/go.mod \u003cgoModHash\u003e' if goModHash is set, each terminated by a newline",
+      "type": "object",
+      "required": [
+        "format",
+        "content",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string",
+          "enum": [
+            "pgp",
+            "minisign",
+            "x509",
+            "ssh"
+          ]
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "GomodV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "HelmV001SchemaChart": {
+      "description": "Information about the Helm chart associated with the entry",
+      "type": "object",
+      "required": [
+        "provenance"
+      ],
+      "properties": {
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the chart",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the chart",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "provenance": {
+          "description": "The provenance entry associated with the signed Helm Chart",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the provenance file inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "signature": {
+              "description": "Information about the included signature in the provenance file",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the signature embedded within the provenance file ",
+                  "type": "string",
+                  "format": "byte",
+                  "readOnly": true
+                }
+              },
+              "readOnly": true
+            },
+            "url": {
+              "description": "Specifies the location of the provenance file",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        }
+      }
+    },
+    "HelmV001SchemaChartHash": {
+      "description": "Specifies the hash algorithm and value for the chart",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the chart",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "HelmV001SchemaChartProvenance": {
+      "description": "The provenance entry associated with the signed Helm Chart",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the provenance file inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "signature": {
+          "description": "Information about the included signature in the provenance file",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the signature embedded within the provenance file ",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            }
+          },
+          "readOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the provenance file",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "HelmV001SchemaChartProvenanceSignature": {
+      "description": "Information about the included signature in the provenance file",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the signature embedded within the provenance file ",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        }
+      },
+      "readOnly": true
+    },
+    "HelmV001SchemaPublicKey": {
+      "description": "The public key that can verify the package signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "HelmV002SchemaChart": {
+      "description": "Information about the Helm chart associated with the entry",
+      "type": "object",
+      "required": [
+        "provenance"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the chart archive inline within the document; if set, its hash must match the provenance file",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the chart archive",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the chart",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        },
+        "name": {
+          "description": "The chart name, from the chart metadata in the provenance file",
+          "type": "string",
+          "readOnly": true
+        },
+        "oci": {
+          "description": "The chart as stored in an OCI registry",
+          "type": "object",
+          "required": [
+            "repository",
+            "digest"
+          ],
+          "properties": {
+            "digest": {
+              "description": "The digest of the chart content layer, which must match the provenance file",
+              "type": "string"
+            },
+            "repository": {
+              "description": "The repository holding the chart, e.g. ghcr.io/org/charts/name",
+              "type": "string"
+            }
+          }
+        },
+        "provenance": {
+          "description": "The provenance entry associated with the signed Helm Chart",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the provenance file inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "signature": {
+              "description": "Information about the included signature in the provenance file",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the signature embedded within the provenance file ",
+                  "type": "string",
+                  "format": "byte",
+                  "readOnly": true
+                }
+              },
+              "readOnly": true
+            },
+            "url": {
+              "description": "Specifies the location of the provenance file",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the chart archive; if set, its hash must match the provenance file",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        },
+        "version": {
+          "description": "The chart version, from the chart metadata in the provenance file",
+          "type": "string",
+          "readOnly": true
+        }
+      }
+    },
+    "HelmV002SchemaChartHash": {
+      "description": "Specifies the hash algorithm and value for the chart archive",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the chart",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "HelmV002SchemaChartOci": {
+      "description": "The chart as stored in an OCI registry",
+      "type": "object",
+      "required": [
+        "repository",
+        "digest"
+      ],
+      "properties": {
+        "digest": {
+          "description": "The digest of the chart content layer, which must match the provenance file",
+          "type": "string"
+        },
+        "repository": {
+          "description": "The repository holding the chart, e.g. ghcr.io/org/charts/name",
+          "type": "string"
+        }
+      }
+    },
+    "HelmV002SchemaChartProvenance": {
+      "description": "The provenance entry associated with the signed Helm Chart",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the provenance file inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "signature": {
+          "description": "Information about the included signature in the provenance file",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the signature embedded within the provenance file ",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            }
+          },
+          "readOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the provenance file",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "HelmV002SchemaChartProvenanceSignature": {
+      "description": "Information about the included signature in the provenance file",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the signature embedded within the provenance file ",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        }
+      },
+      "readOnly": true
+    },
+    "HelmV002SchemaPublicKey": {
+      "description": "The public key that can verify the package signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "InclusionProof": {
+      "type": "object",
+      "required": [
+        "logIndex",
+        "rootHash",
+        "treeSize",
+        "hashes"
+      ],
+      "properties": {
+        "hashes": {
+          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "logIndex": {
+          "description": "The index of the entry in the transparency log",
+          "type": "integer",
+          "minimum": 0
+        },
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "treeSize": {
+          "description": "The size of the merkle tree at the time the inclusion proof was generated",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "IntotoV001SchemaContent": {
+      "type": "object",
+      "properties": {
+        "envelope": {
+          "description": "envelope",
+          "type": "string",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the archive",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "IntotoV001SchemaContentHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the archive",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "JarV001SchemaArchive": {
+      "description": "Information about the archive associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the archive inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the archive",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the archive; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "JarV001SchemaArchiveHash": {
+      "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the archive",
+          "type": "string"
+        }
+      }
+    },
+    "JarV001SchemaSignature": {
+      "description": "Information about the included signature in the JAR file",
+      "type": "object",
+      "required": [
+        "publicKey",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the PKCS7 signature embedded within the JAR file ",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        },
+        "publicKey": {
+          "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "type": "string",
+              "format": "byte"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "JarV001SchemaSignaturePublicKey": {
+      "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      },
+      "readOnly": true
+    },
+    "LogEntry": {
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/LogEntryAnon"
+      }
+    },
+    "LogEntryAnon": {
+      "type": "object",
+      "required": [
+        "logID",
+        "logIndex",
+        "body",
+        "integratedTime"
+      ],
+      "properties": {
+        "attestation": {
+          "type": "object",
+          "format": "byte",
+          "properties": {
+            "data": {
+              "format": "byte"
+            },
+            "mediaType": {
+              "format": "string"
+            }
+          }
+        },
+        "body": {
+          "type": "object",
+          "additionalProperties": true
+        },
+        "integratedTime": {
+          "type": "integer"
+        },
+        "logID": {
+          "description": "This is the SHA256 hash of the DER-encoded public key for the log at the time the entry was included in the log",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "logIndex": {
+          "type": "integer",
+          "minimum": 0
+        },
+        "verification": {
+          "type": "object",
+          "properties": {
+            "inclusionProof": {
+              "$ref": "#/definitions/InclusionProof"
+            },
+            "signedEntryTimestamp": {
+              "description": "Signature over the logID, logIndex, body and integratedTime.",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "LogEntryAnonAttestation": {
+      "type": "object",
+      "format": "byte",
+      "properties": {
+        "data": {
+          "format": "byte"
+        },
+        "mediaType": {
+          "format": "string"
+        }
+      }
+    },
+    "LogEntryAnonVerification": {
+      "type": "object",
+      "properties": {
+        "inclusionProof": {
+          "$ref": "#/definitions/InclusionProof"
+        },
+        "signedEntryTimestamp": {
+          "description": "Signature over the logID, logIndex, body and integratedTime.",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "LogInfo": {
+      "type": "object",
+      "required": [
+        "rootHash",
+        "treeSize",
+        "signedTreeHead"
+      ],
+      "properties": {
+        "rootHash": {
+          "description": "The current hash value stored at the root of the merkle tree",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "signedTreeHead": {
+          "description": "The current signed tree head",
+          "type": "string",
+          "format": "signedCheckpoint"
+        },
+        "treeSize": {
+          "description": "The current number of nodes in the merkle tree",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "MavenV001SchemaArtifact": {
+      "description": "Information about the artifact file associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the artifact inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the artifact",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the artifact",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the artifact; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "MavenV001SchemaArtifactHash": {
+      "description": "Specifies the hash algorithm and value for the artifact",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the artifact",
+          "type": "string"
+        }
+      }
+    },
+    "MavenV001SchemaCoordinates": {
+      "description": "The Maven coordinates of the artifact",
+      "type": "object",
+      "required": [
+        "groupId",
+        "artifactId",
+        "version"
+      ],
+      "properties": {
+        "artifactId": {
+          "description": "The artifact identifier, e.g. maven-core",
+          "type": "string"
+        },
+        "groupId": {
+          "description": "The group identifier, e.g. org.apache.maven",
+          "type": "string"
+        },
+        "version": {
+          "description": "The artifact version",
+          "type": "string"
+        }
+      }
+    },
+    "MavenV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "MavenV001SchemaSignature": {
+      "description": "The detached PGP signature over the artifact, as published in its .asc file",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "NpmV001SchemaPackage": {
+      "description": "The npm package version the signature or attestation covers",
+      "type": "object",
+      "required": [
+        "name",
+        "version",
+        "integrity"
+      ],
+      "properties": {
+        "integrity": {
+          "description": "The subresource integrity string of the package tarball, as found in dist.integrity",
+          "type": "string"
+        },
+        "name": {
+          "description": "The package name, including its scope if any",
+          "type": "string"
+        },
+        "version": {
+          "description": "The package version",
+          "type": "string"
+        }
+      }
+    },
+    "NpmV001SchemaProvenance": {
+      "description": "A Sigstore bundle holding the provenance attestation for the package",
+      "type": "object",
+      "properties": {
+        "certificate": {
+          "description": "The certificate that signed the attestation",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        },
+        "content": {
+          "description": "The Sigstore bundle, as served by the npm attestations API",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the bundle",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the bundle",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "NpmV001SchemaProvenanceHash": {
+      "description": "Specifies the hash algorithm and value for the bundle",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the bundle",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "NpmV001SchemaRegistrySignature": {
+      "description": "A signature by the npm registry, as found in dist.signatures",
+      "type": "object",
+      "required": [
+        "keyid",
+        "sig"
+      ],
+      "properties": {
+        "keyid": {
+          "description": "The identifier of the registry key that made the signature",
+          "type": "string"
+        },
+        "sig": {
+          "description": "The signature over name@version:integrity",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "OciV001SchemaPayload": {
+      "description": "The simple signing payload that references the image manifest",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the payload inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the payload",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the payload",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "OciV001SchemaPayloadHash": {
+      "description": "Specifies the hash algorithm and value for the payload",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the payload",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "OciV001SchemaSignature": {
+      "description": "Information about the signature over the payload",
+      "type": "object",
+      "required": [
+        "content",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "publicKey": {
+          "description": "The public key or certificate that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key or certificate inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "OciV001SchemaSignaturePublicKey": {
+      "description": "The public key or certificate that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key or certificate inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "OpenvexV001SchemaDocument": {
+      "description": "Information about the OpenVEX document",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the OpenVEX document inline within the entry; for DSSE signatures this is the envelope carrying the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the submitted document content",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the document",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "OpenvexV001SchemaDocumentHash": {
+      "description": "Specifies the hash algorithm and value for the submitted document content",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the document",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "OpenvexV001SchemaSignature": {
+      "description": "Information about the signature over the document",
+      "type": "object",
+      "required": [
+        "format",
+        "publicKey"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the detached PGP signature over the document; DSSE signatures are carried in the envelope",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string",
+          "enum": [
+            "dsse",
+            "pgp"
+          ]
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key or x509 certificate inline within the document",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "OpenvexV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key or x509 certificate inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "ProposedEntry": {
+      "type": "object",
+      "required": [
+        "kind"
+      ],
+      "properties": {
+        "kind": {
+          "type": "string"
+        }
+      },
+      "discriminator": "kind"
+    },
+    "PypiV001SchemaAttestation": {
+      "description": "The PEP 740 attestation for the distribution",
+      "type": "object",
+      "properties": {
+        "certificate": {
+          "description": "The certificate that signed the attestation",
+          "type": "string",
+          "format": "byte",
+          "readOnly": true
+        },
+        "content": {
+          "description": "The attestation object, as served by the PyPI integrity API",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the attestation",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the attestation",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "PypiV001SchemaAttestationHash": {
+      "description": "Specifies the hash algorithm and value for the attestation",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the attestation",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "PypiV001SchemaDistribution": {
+      "description": "The wheel or source distribution the attestation covers",
+      "type": "object",
+      "required": [
+        "filename",
+        "hash"
+      ],
+      "properties": {
+        "filename": {
+          "description": "The distribution filename, from which the project name and version are taken",
+          "type": "string"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the distribution",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the distribution",
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "PypiV001SchemaDistributionHash": {
+      "description": "Specifies the hash algorithm and value for the distribution",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the distribution",
+          "type": "string"
+        }
+      }
+    },
+    "RekordV001SchemaData": {
+      "description": "Information about the content associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the content",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the content",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the content",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "RekordV001SchemaDataHash": {
+      "description": "Specifies the hash algorithm and value for the content",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the content",
+          "type": "string"
+        }
+      }
+    },
+    "RekordV001SchemaSignature": {
+      "description": "Information about the detached signature associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The signature scheme detected by the server when the signature was verified, for formats that support more than one; only set for schemes other than the default for the key type, e.g. rsa-pss-sha256 or ed25519ph",
+          "type": "string"
+        },
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature; one of the PKI formats supported by the server, e.g. pgp, minisign, x509 or ssh",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "RekordV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "Rfc3161V001SchemaTsaCertificate": {
+      "description": "The X509 certificate of the timestamping authority that signed the tsr, optionally followed by its issuing certificates",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the PEM encoded certificate chain of the timestamping authority",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "Rfc3161V001SchemaTsr": {
+      "description": "Information about the tsr file associated with the entry",
+      "type": "object",
+      "required": [
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the tsr file content inline within the document",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "RpmV001SchemaPackage": {
+      "description": "Information about the package associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          }
+        },
+        "headers": {
+          "description": "Values of the RPM headers",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          },
+          "readOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "RpmV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      }
+    },
+    "RpmV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the RPM signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "SearchIndex": {
+      "type": "object",
+      "properties": {
+        "certificateFingerprint": {
+          "description": "SHA256 digest of a DER-encoded X.509 certificate that signed entries",
+          "type": "string",
+          "pattern": "^(sha256:)?[0-9a-fA-F]{64}$"
+        },
+        "email": {
+          "type": "string",
+          "format": "email"
+        },
+        "hash": {
+          "type": "string",
+          "pattern": "^(sha256:)?[0-9a-fA-F]{64}$"
+        },
+        "publicKey": {
+          "type": "object",
+          "required": [
+            "format"
+          ],
+          "properties": {
+            "content": {
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "one of the PKI formats supported by the server, e.g. pgp, x509, minisign, ssh or tuf",
+              "type": "string"
+            },
+            "url": {
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "uri": {
+          "description": "URI subject alternative name of a certificate that signed entries, such as a SPIFFE ID or CI workflow URI",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "SearchIndexPublicKey": {
+      "type": "object",
+      "required": [
+        "format"
+      ],
+      "properties": {
+        "content": {
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "one of the PKI formats supported by the server, e.g. pgp, x509, minisign, ssh or tuf",
+          "type": "string"
+        },
+        "url": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "SearchLogQuery": {
+      "type": "object",
+      "properties": {
+        "entries": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ProposedEntry"
+          }
+        },
+        "entryUUIDs": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "logIndexes": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "minimum": 0
+          }
+        }
+      }
+    },
+    "SpdxV001SchemaDocument": {
+      "description": "Information about the SPDX document",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the SPDX document inline within the entry, in tag-value or JSON format",
+          "type": "string",
+          "format": "byte",
+          "writeOnly": true
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the document",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the document",
+              "type": "string"
+            }
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "SpdxV001SchemaDocumentHash": {
+      "description": "Specifies the hash algorithm and value for the document",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the document",
+          "type": "string"
+        }
+      },
+      "readOnly": true
+    },
+    "StreamedLogEntry": {
+      "type": "object",
+      "required": [
+        "uuid",
+        "logIndex",
+        "body"
+      ],
+      "properties": {
+        "body": {
+          "description": "the canonicalized entry",
+          "type": "string",
+          "format": "byte"
+        },
+        "logIndex": {
+          "description": "the index of the entry in the transparency log",
+          "type": "integer",
+          "minimum": 0
+        },
+        "uuid": {
+          "description": "the UUID of the entry in the transparency log",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "TUFV001SchemaMetadata": {
+      "description": "TUF metadata",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the archive inline within the document",
+          "type": "object",
+          "additionalProperties": true,
+          "writeOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the archive",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "TUFV001SchemaRoot": {
+      "description": "root metadata containing about the public keys used to sign the manifest",
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "Specifies the archive inline within the document",
+          "type": "object",
+          "additionalProperties": true,
+          "writeOnly": true
+        },
+        "url": {
+          "description": "Specifies the location of the archive",
+          "type": "string",
+          "format": "uri",
+          "writeOnly": true
+        }
+      }
+    },
+    "alpine": {
+      "description": "Alpine package",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/alpineSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "alpineSchema": {
+      "description": "Schema for Alpine package objects",
+      "type": "object",
+      "title": "Alpine Package Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/alpineV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_schema.json"
+    },
+    "alpineV001Schema": {
+      "description": "Schema for Alpine Package entries",
+      "type": "object",
+      "title": "Alpine v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "package"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "package": {
+          "description": "Information about the package associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the package inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the package",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the package",
+                  "type": "string"
+                }
+              }
+            },
+            "pkginfo": {
+              "description": "Values of the .PKGINFO key / value pairs",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "readOnly": true
+            },
+            "url": {
+              "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The public key that can verify the package signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_v0_0_1_schema.json"
+    },
+    "archlinux": {
+      "description": "Arch Linux package",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/archlinuxSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "archlinuxSchema": {
+      "description": "Schema for Arch Linux package objects",
+      "type": "object",
+      "title": "Arch Linux Package Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/archlinuxV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/archlinux/archlinux_schema.json"
+    },
+    "archlinuxV001Schema": {
+      "description": "Schema for Arch Linux package entries",
+      "type": "object",
+      "title": "Arch Linux v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "package",
+        "signature"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "package": {
+          "description": "Information about the package associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the package inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the package",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the package",
+                  "type": "string"
+                }
+              }
+            },
+            "pkginfo": {
+              "description": "Values of the pkgname, pkgbase, pkgver and arch fields from .PKGINFO",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "readOnly": true
+            },
+            "url": {
+              "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The PGP public key that can verify the package signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "signature": {
+          "description": "The detached PGP signature over the package",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
             }
           }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/archlinux/archlinux_v0_0_1_schema.json"
     },
-    "/api/v1/log/publicKey": {
-      "get": {
-        "description": "Returns the public key that can be used to validate the signed tree head",
-        "produces": [
-          "application/x-pem-file"
-        ],
-        "tags": [
-          "pubkey"
-        ],
-        "summary": "Retrieve the public key that can be used to validate the signed tree head",
-        "operationId": "getPublicKey",
-        "responses": {
-          "200": {
-            "description": "The public key",
-            "schema": {
-              "type": "string"
+    "authenticode": {
+      "description": "Authenticode signed PE/COFF image",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/authenticodeSchema"
             }
           },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "/api/v1/timestamp": {
-      "post": {
-        "consumes": [
-          "application/timestamp-query"
-        ],
-        "produces": [
-          "application/timestamp-reply"
-        ],
-        "tags": [
-          "timestamp"
-        ],
-        "summary": "Generates a new timestamp response and creates a new log entry for the timestamp in the transparency log",
-        "operationId": "getTimestampResponse",
-        "parameters": [
-          {
-            "name": "request",
-            "in": "body",
-            "required": true,
-            "schema": {
+    "authenticodeSchema": {
+      "description": "Schema for Authenticode signed PE/COFF images such as UEFI firmware and Windows binaries",
+      "type": "object",
+      "title": "Authenticode Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/authenticodeV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/authenticode/authenticode_schema.json"
+    },
+    "authenticodeV001Schema": {
+      "description": "Schema for Authenticode entries",
+      "type": "object",
+      "title": "Authenticode v0.0.1 Schema",
+      "required": [
+        "image"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "image": {
+          "description": "Information about the PE/COFF image associated with the entry",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the image inline within the document",
               "type": "string",
-              "format": "binary"
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed image",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the image",
+                  "type": "string"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the image; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
             }
           }
-        ],
-        "responses": {
-          "201": {
-            "description": "Returns a timestamp response and the location of the log entry in the transprency log",
-            "schema": {
+        },
+        "signature": {
+          "description": "Information about the Authenticode signature embedded in the image",
+          "type": "object",
+          "required": [
+            "content",
+            "publicKey",
+            "digest"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the PKCS7 signature from the certificate table of the image",
               "type": "string",
-              "format": "binary"
+              "format": "byte"
             },
-            "headers": {
-              "ETag": {
-                "type": "string",
-                "description": "UUID of the log entry made for the timestamp response"
-              },
-              "Index": {
-                "type": "integer",
-                "description": "Log index of the log entry made for the timestamp response"
-              },
-              "Location": {
-                "type": "string",
-                "format": "uri",
-                "description": "URI location of the log entry made for the timestamp response"
+            "digest": {
+              "description": "The Authenticode digest of the image that the signature covers",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the digest",
+                  "type": "string",
+                  "enum": [
+                    "sha1",
+                    "sha256",
+                    "sha384",
+                    "sha512"
+                  ]
+                },
+                "value": {
+                  "description": "The digest of the image, excluding its checksum and certificate table",
+                  "type": "string"
+                }
+              }
+            },
+            "publicKey": {
+              "description": "The X509 certificate of the signer",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+                  "type": "string",
+                  "format": "byte"
+                }
               }
             }
           },
-          "400": {
-            "description": "The content supplied to the server was invalid",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          },
-          "501": {
-            "description": "The content requested is not implemented"
-          },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
+          "readOnly": true
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/authenticode/authenticode_v0_0_1_schema.json"
     },
-    "/api/v1/timestamp/certchain": {
-      "get": {
-        "description": "Returns the certfiicate chain for timestamping that can be used to validate trusted timestamps",
-        "produces": [
-          "application/pem-certificate-chain"
-        ],
-        "tags": [
-          "timestamp"
-        ],
-        "summary": "Retrieve the certfiicate chain for timestamping that can be used to validate trusted timestamps",
-        "operationId": "getTimestampCertChain",
-        "responses": {
-          "200": {
-            "description": "The PEM encoded cert chain",
-            "schema": {
-              "type": "string"
-            }
-          },
-          "404": {
-            "description": "The content requested could not be found"
-          },
-          "default": {
-            "description": "There was an internal error in the server while processing the request",
-            "schema": {
-              "$ref": "#/definitions/Error"
+    "cose": {
+      "description": "COSE Sign1 envelope",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/coseSchema"
             }
-          }
+          },
+          "additionalProperties": false
         }
-      }
-    }
-  },
-  "definitions": {
-    "AlpineV001SchemaPackage": {
-      "description": "Information about the package associated with the entry",
+      ]
+    },
+    "coseSchema": {
+      "description": "COSE for Rekord objects",
       "type": "object",
+      "title": "COSE Schema",
       "oneOf": [
         {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
+          "$ref": "#/definitions/coseV001Schema"
         }
       ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/cose/cose_schema.json"
+    },
+    "coseV001Schema": {
+      "description": "Schema for COSE Sign1 objects",
+      "type": "object",
+      "title": "cose v0.0.1 Schema",
+      "required": [
+        "publicKey"
+      ],
       "properties": {
-        "content": {
-          "description": "Specifies the package inline within the document",
+        "data": {
+          "description": "Information about the content signed by the message",
+          "type": "object",
+          "properties": {
+            "envelopeHash": {
+              "description": "Specifies the hash algorithm and value for the canonicalized COSE_Sign1 message",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the envelope",
+                  "type": "string"
+                }
+              }
+            },
+            "payloadHash": {
+              "description": "Specifies the hash algorithm and value for the payload",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the payload",
+                  "type": "string"
+                }
+              }
+            }
+          },
+          "readOnly": true
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "message": {
+          "description": "The COSE_Sign1 message",
           "type": "string",
           "format": "byte",
           "writeOnly": true
         },
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the package",
-          "type": "object",
+        "publicKey": {
+          "description": "The public key or certificate that can verify the signature",
+          "type": "string",
+          "format": "byte"
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/cose/cose_v0_0_1_schema.json"
+    },
+    "crate": {
+      "description": "Rust crate with a PGP signature or Sigstore bundle",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
           "required": [
-            "algorithm",
-            "value"
+            "apiVersion",
+            "spec"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "apiVersion": {
               "type": "string",
-              "enum": [
-                "sha256"
-              ]
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "value": {
-              "description": "The hash value for the package",
-              "type": "string"
+            "spec": {
+              "$ref": "#/definitions/crateSchema"
             }
-          }
-        },
-        "pkginfo": {
-          "description": "Values of the .PKGINFO key / value pairs",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
           },
-          "readOnly": true
-        },
-        "url": {
-          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "AlpineV001SchemaPackageHash": {
-      "description": "Specifies the hash algorithm and value for the package",
+    "crateSchema": {
+      "description": "Schema for signed Rust crates",
+      "type": "object",
+      "title": "Rust Crate Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/crateV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/crate/crate_schema.json"
+    },
+    "crateV001Schema": {
+      "description": "Schema for .crate files signed with PGP or a Sigstore bundle",
       "type": "object",
+      "title": "Rust Crate v0.0.1 Schema",
       "required": [
-        "algorithm",
-        "value"
+        "package",
+        "signature"
       ],
       "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "value": {
-          "description": "The hash value for the package",
-          "type": "string"
+        "package": {
+          "description": "Information about the .crate file associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the .crate file inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the .crate file, which is the checksum recorded in the crates.io index",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the .crate file",
+                  "type": "string"
+                }
+              }
+            },
+            "name": {
+              "description": "The crate name, as declared in the embedded Cargo.toml",
+              "type": "string",
+              "readOnly": true
+            },
+            "url": {
+              "description": "Specifies the location of the .crate file; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            },
+            "version": {
+              "description": "The crate version, as declared in the embedded Cargo.toml",
+              "type": "string",
+              "readOnly": true
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The PGP public key that can verify the signature; for Sigstore bundles this is the signing certificate and is set by the server",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "signature": {
+          "description": "The signature over the .crate file",
+          "type": "object",
+          "required": [
+            "format"
+          ],
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the type of signature, either a detached PGP signature or a Sigstore bundle with a message signature",
+              "type": "string",
+              "enum": [
+                "pgp",
+                "sigstore"
+              ]
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/crate/crate_v0_0_1_schema.json"
     },
-    "AlpineV001SchemaPublicKey": {
-      "description": "The public key that can verify the package signature",
+    "cyclonedx": {
+      "description": "CycloneDX SBOM",
       "type": "object",
-      "oneOf": [
+      "allOf": [
         {
-          "required": [
-            "url"
-          ]
+          "$ref": "#/definitions/ProposedEntry"
         },
         {
           "required": [
-            "content"
-          ]
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/cyclonedxSchema"
+            }
+          },
+          "additionalProperties": false
         }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "url": {
-          "description": "Specifies the location of the public key",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
+      ]
+    },
+    "cyclonedxSchema": {
+      "description": "CycloneDX for Rekord objects",
+      "type": "object",
+      "title": "CycloneDX Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/cyclonedxV001Schema"
         }
-      }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/cyclonedx/cyclonedx_schema.json"
     },
-    "ConsistencyProof": {
+    "cyclonedxV001Schema": {
+      "description": "Schema for CycloneDX SBOM objects",
       "type": "object",
+      "title": "cyclonedx v0.0.1 Schema",
       "required": [
-        "rootHash",
-        "hashes"
+        "bom"
       ],
       "properties": {
-        "hashes": {
+        "bom": {
+          "description": "Information about the CycloneDX BOM",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the BOM inline within the entry, in JSON or XML format",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the BOM",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the BOM",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            }
+          }
+        },
+        "componentHashes": {
+          "description": "Hashes of the components listed in the BOM, formatted as algorithm:value",
           "type": "array",
           "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
-          }
+            "type": "string"
+          },
+          "readOnly": true
         },
-        "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature enclosed in the BOM; optional if the signature embeds its public key",
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
-        }
-      }
-    },
-    "Error": {
-      "type": "object",
-      "properties": {
-        "code": {
-          "type": "integer"
+          "format": "byte"
         },
-        "message": {
-          "type": "string"
+        "serialNumber": {
+          "description": "The serialNumber of the BOM",
+          "type": "string",
+          "readOnly": true
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/cyclonedx/cyclonedx_v0_0_1_schema.json"
     },
-    "HelmV001SchemaChart": {
-      "description": "Information about the Helm chart associated with the entry",
+    "deb": {
+      "description": "Debian package",
       "type": "object",
-      "required": [
-        "provenance"
-      ],
-      "properties": {
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the chart",
-          "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
           "required": [
-            "algorithm",
-            "value"
+            "apiVersion",
+            "spec"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "apiVersion": {
               "type": "string",
-              "enum": [
-                "sha256"
-              ]
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "value": {
-              "description": "The hash value for the chart",
-              "type": "string"
+            "spec": {
+              "$ref": "#/definitions/debSchema"
             }
           },
-          "readOnly": true
+          "additionalProperties": false
+        }
+      ]
+    },
+    "debSchema": {
+      "description": "Schema for Debian package objects",
+      "type": "object",
+      "title": "Debian Package Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/debV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/deb/deb_schema.json"
+    },
+    "debV001Schema": {
+      "description": "Schema for Debian package and .changes file entries",
+      "type": "object",
+      "title": "Debian v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "package"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "provenance": {
-          "description": "The provenance entry associated with the signed Helm Chart",
+        "package": {
+          "description": "Information about the .deb package or .changes file associated with the entry",
           "type": "object",
           "oneOf": [
             {
@@ -1503,586 +6366,831 @@ func init() {
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the provenance file inline within the document",
+              "description": "Specifies the package inline within the document",
               "type": "string",
               "format": "byte",
               "writeOnly": true
             },
-            "signature": {
-              "description": "Information about the included signature in the provenance file",
+            "control": {
+              "description": "Values of the package name, version and architecture control fields",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "readOnly": true
+            },
+            "fileChecksums": {
+              "description": "Checksums of the files shipped in the package or listed in the .changes file, formatted as algorithm:value",
+              "type": "array",
+              "items": {
+                "type": "string"
+              },
+              "readOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the package",
               "type": "object",
               "required": [
-                "content"
+                "algorithm",
+                "value"
               ],
               "properties": {
-                "content": {
-                  "description": "Specifies the signature embedded within the provenance file ",
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
                   "type": "string",
-                  "format": "byte",
-                  "readOnly": true
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the package",
+                  "type": "string"
                 }
-              },
-              "readOnly": true
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The PGP public key that can verify the package or .changes signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
+        },
+        "signature": {
+          "description": "The detached PGP signature over a .changes file; .deb packages carry their signature in the _gpgorigin archive member",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
             },
             "url": {
-              "description": "Specifies the location of the provenance file",
+              "description": "Specifies the location of the signature",
               "type": "string",
               "format": "uri",
               "writeOnly": true
             }
           }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/deb/deb_v0_0_1_schema.json"
     },
-    "HelmV001SchemaChartHash": {
-      "description": "Specifies the hash algorithm and value for the chart",
+    "git": {
+      "description": "Git object",
       "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
         },
-        "value": {
-          "description": "The hash value for the chart",
-          "type": "string"
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/gitSchema"
+            }
+          },
+          "additionalProperties": false
         }
-      },
-      "readOnly": true
+      ]
     },
-    "HelmV001SchemaChartProvenance": {
-      "description": "The provenance entry associated with the signed Helm Chart",
+    "gitSchema": {
+      "description": "Schema for signed git commit and tag objects",
       "type": "object",
+      "title": "Git Object Schema",
       "oneOf": [
         {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
+          "$ref": "#/definitions/gitV001Schema"
         }
       ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/git/git_schema.json"
+    },
+    "gitV001Schema": {
+      "description": "Schema for signed git commit and tag objects",
+      "type": "object",
+      "title": "git v0.0.1 Schema",
+      "required": [
+        "object"
+      ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the provenance file inline within the document",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "signature": {
-          "description": "Information about the included signature in the provenance file",
+        "object": {
+          "description": "The signed git object",
           "type": "object",
-          "required": [
-            "content"
-          ],
           "properties": {
             "content": {
-              "description": "Specifies the signature embedded within the provenance file ",
+              "description": "The raw commit or tag object, as printed by git cat-file",
               "type": "string",
               "format": "byte",
+              "writeOnly": true
+            },
+            "id": {
+              "description": "The SHA-1 object name of the git object",
+              "type": "string",
+              "readOnly": true
+            },
+            "target": {
+              "description": "The object name of the object referenced by a tag",
+              "type": "string",
+              "readOnly": true
+            },
+            "type": {
+              "description": "The type of the git object",
+              "type": "string",
+              "enum": [
+                "commit",
+                "tag"
+              ],
               "readOnly": true
             }
-          },
-          "readOnly": true
+          }
         },
-        "url": {
-          "description": "Specifies the location of the provenance file",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
-    },
-    "HelmV001SchemaChartProvenanceSignature": {
-      "description": "Information about the included signature in the provenance file",
-      "type": "object",
-      "required": [
-        "content"
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the signature embedded within the provenance file ",
+        "publicKey": {
+          "description": "The PGP or SSH public key that can verify the signature; x509 signatures embed the signing certificate instead",
           "type": "string",
-          "format": "byte",
+          "format": "byte"
+        },
+        "signature": {
+          "description": "The signature embedded in the git object",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "The signature, as embedded in the git object",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "The format of the signature",
+              "type": "string",
+              "enum": [
+                "pgp",
+                "ssh",
+                "x509"
+              ]
+            }
+          },
           "readOnly": true
         }
       },
-      "readOnly": true
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/git/git_v0_0_1_schema.json"
     },
-    "HelmV001SchemaPublicKey": {
-      "description": "The public key that can verify the package signature",
+    "gomod": {
+      "description": "Go module version with an author signature",
       "type": "object",
-      "oneOf": [
+      "allOf": [
         {
-          "required": [
-            "url"
-          ]
+          "$ref": "#/definitions/ProposedEntry"
         },
         {
           "required": [
-            "content"
-          ]
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/gomodSchema"
+            }
+          },
+          "additionalProperties": false
         }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "url": {
-          "description": "Specifies the location of the public key",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
+      ]
+    },
+    "gomodSchema": {
+      "description": "Schema for author signed Go module checksums",
+      "type": "object",
+      "title": "Go Module Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/gomodV001Schema"
         }
-      }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/gomod/gomod_schema.json"
     },
-    "InclusionProof": {
+    "gomodV001Schema": {
+      "description": "Schema for Go module versions whose go.sum lines are signed by the module author",
       "type": "object",
+      "title": "Go Module v0.0.1 Schema",
       "required": [
-        "logIndex",
-        "rootHash",
-        "treeSize",
-        "hashes"
+        "module",
+        "signature"
       ],
       "properties": {
-        "hashes": {
-          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
-          "type": "array",
-          "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
-          }
-        },
-        "logIndex": {
-          "description": "The index of the entry in the transparency log",
-          "type": "integer",
-          "minimum": 0
-        },
-        "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
-          "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "treeSize": {
-          "description": "The size of the merkle tree at the time the inclusion proof was generated",
-          "type": "integer",
-          "minimum": 1
-        }
-      }
-    },
-    "IntotoV001SchemaContent": {
-      "type": "object",
-      "properties": {
-        "envelope": {
-          "description": "envelope",
-          "type": "string",
-          "writeOnly": true
+        "module": {
+          "description": "The module version and the hashes the checksum database records for it",
+          "type": "object",
+          "required": [
+            "path",
+            "version",
+            "hash"
+          ],
+          "properties": {
+            "goModHash": {
+              "description": "The h1: dirhash of the module's go.mod file",
+              "type": "string"
+            },
+            "hash": {
+              "description": "The h1: dirhash of the module zip",
+              "type": "string"
+            },
+            "path": {
+              "description": "The module path",
+              "type": "string"
+            },
+            "version": {
+              "description": "The canonical semantic version of the module",
+              "type": "string"
+            },
+            "zip": {
+              "description": "The module zip; if specified, the server checks it matches the hash",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            }
+          }
         },
-        "hash": {
-          "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+        "signature": {
+          "description": "The author's signature over the module's go.sum lines, i.e. '\u003cpath\u003e \u003cversion\u003e \u003chash\u003e' followed by '\u003cpath\u003e \u003cversion\u003e/go.mod \u003cgoModHash\u003e' if goModHash is set, each terminated by a newline",
           "type": "object",
           "required": [
-            "algorithm",
-            "value"
+            "format",
+            "content",
+            "publicKey"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the format of the signature",
               "type": "string",
               "enum": [
-                "sha256"
+                "pgp",
+                "minisign",
+                "x509",
+                "ssh"
               ]
-            },
-            "value": {
-              "description": "The hash value for the archive",
-              "type": "string"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
             }
-          },
-          "readOnly": true
-        }
-      }
-    },
-    "IntotoV001SchemaContentHash": {
-      "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
-      "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value for the archive",
-          "type": "string"
+          }
         }
       },
-      "readOnly": true
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/gomod/gomod_v0_0_1_schema.json"
     },
-    "JarV001SchemaArchive": {
-      "description": "Information about the archive associated with the entry",
+    "helm": {
+      "description": "Helm chart",
       "type": "object",
-      "oneOf": [
+      "allOf": [
         {
-          "required": [
-            "url"
-          ]
+          "$ref": "#/definitions/ProposedEntry"
         },
         {
           "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the archive inline within the document",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
-        },
-        "hash": {
-          "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
+            "apiVersion",
+            "spec"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "apiVersion": {
               "type": "string",
-              "enum": [
-                "sha256"
-              ]
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "value": {
-              "description": "The hash value for the archive",
-              "type": "string"
+            "spec": {
+              "$ref": "#/definitions/helmSchema"
             }
           }
-        },
-        "url": {
-          "description": "Specifies the location of the archive; if this is specified, a hash value must also be provided",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
         }
-      }
+      ]
     },
-    "JarV001SchemaArchiveHash": {
-      "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+    "helmSchema": {
+      "description": "Schema for Helm objects",
       "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
+      "title": "Helm Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/helmV001Schema"
         },
-        "value": {
-          "description": "The hash value for the archive",
-          "type": "string"
+        {
+          "$ref": "#/definitions/helmV002Schema"
         }
-      }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/helm/helm_schema.json"
     },
-    "JarV001SchemaSignature": {
-      "description": "Information about the included signature in the JAR file",
+    "helmV001Schema": {
+      "description": "Schema for Helm object",
       "type": "object",
+      "title": "Helm v0.0.1 Schema",
       "required": [
         "publicKey",
-        "content"
+        "chart"
       ],
       "properties": {
-        "content": {
-          "description": "Specifies the PKCS7 signature embedded within the JAR file ",
-          "type": "string",
-          "format": "byte",
-          "readOnly": true
+        "chart": {
+          "description": "Information about the Helm chart associated with the entry",
+          "type": "object",
+          "required": [
+            "provenance"
+          ],
+          "properties": {
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the chart",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the chart",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            },
+            "provenance": {
+              "description": "The provenance entry associated with the signed Helm Chart",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the provenance file inline within the document",
+                  "type": "string",
+                  "format": "byte",
+                  "writeOnly": true
+                },
+                "signature": {
+                  "description": "Information about the included signature in the provenance file",
+                  "type": "object",
+                  "required": [
+                    "content"
+                  ],
+                  "properties": {
+                    "content": {
+                      "description": "Specifies the signature embedded within the provenance file ",
+                      "type": "string",
+                      "format": "byte",
+                      "readOnly": true
+                    }
+                  },
+                  "readOnly": true
+                },
+                "url": {
+                  "description": "Specifies the location of the provenance file",
+                  "type": "string",
+                  "format": "uri",
+                  "writeOnly": true
+                }
+              }
+            }
+          }
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
         "publicKey": {
-          "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+          "description": "The public key that can verify the package signature",
           "type": "object",
-          "required": [
-            "content"
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+              "description": "Specifies the content of the public key inline within the document",
               "type": "string",
               "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
             }
-          },
-          "readOnly": true
-        }
-      }
-    },
-    "JarV001SchemaSignaturePublicKey": {
-      "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
-      "type": "object",
-      "required": [
-        "content"
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
-          "type": "string",
-          "format": "byte"
+          }
         }
       },
-      "readOnly": true
-    },
-    "LogEntry": {
-      "type": "object",
-      "additionalProperties": {
-        "$ref": "#/definitions/LogEntryAnon"
-      }
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/helm/helm_v0_0_1_schema.json"
     },
-    "LogEntryAnon": {
+    "helmV002Schema": {
+      "description": "Schema for Helm charts stored as .tgz files or in OCI registries",
       "type": "object",
+      "title": "Helm v0.0.2 Schema",
       "required": [
-        "logID",
-        "logIndex",
-        "body",
-        "integratedTime"
+        "publicKey",
+        "chart"
       ],
       "properties": {
-        "attestation": {
+        "chart": {
+          "description": "Information about the Helm chart associated with the entry",
           "type": "object",
-          "format": "byte",
+          "required": [
+            "provenance"
+          ],
           "properties": {
-            "data": {
-              "format": "byte"
+            "content": {
+              "description": "Specifies the chart archive inline within the document; if set, its hash must match the provenance file",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
             },
-            "mediaType": {
-              "format": "string"
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the chart archive",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the chart",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            },
+            "name": {
+              "description": "The chart name, from the chart metadata in the provenance file",
+              "type": "string",
+              "readOnly": true
+            },
+            "oci": {
+              "description": "The chart as stored in an OCI registry",
+              "type": "object",
+              "required": [
+                "repository",
+                "digest"
+              ],
+              "properties": {
+                "digest": {
+                  "description": "The digest of the chart content layer, which must match the provenance file",
+                  "type": "string"
+                },
+                "repository": {
+                  "description": "The repository holding the chart, e.g. ghcr.io/org/charts/name",
+                  "type": "string"
+                }
+              }
+            },
+            "provenance": {
+              "description": "The provenance entry associated with the signed Helm Chart",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the provenance file inline within the document",
+                  "type": "string",
+                  "format": "byte",
+                  "writeOnly": true
+                },
+                "signature": {
+                  "description": "Information about the included signature in the provenance file",
+                  "type": "object",
+                  "required": [
+                    "content"
+                  ],
+                  "properties": {
+                    "content": {
+                      "description": "Specifies the signature embedded within the provenance file ",
+                      "type": "string",
+                      "format": "byte",
+                      "readOnly": true
+                    }
+                  },
+                  "readOnly": true
+                },
+                "url": {
+                  "description": "Specifies the location of the provenance file",
+                  "type": "string",
+                  "format": "uri",
+                  "writeOnly": true
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the chart archive; if set, its hash must match the provenance file",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            },
+            "version": {
+              "description": "The chart version, from the chart metadata in the provenance file",
+              "type": "string",
+              "readOnly": true
             }
           }
         },
-        "body": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
           "type": "object",
           "additionalProperties": true
         },
-        "integratedTime": {
-          "type": "integer"
-        },
-        "logID": {
-          "description": "This is the SHA256 hash of the DER-encoded public key for the log at the time the entry was included in the log",
-          "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
-        },
-        "logIndex": {
-          "type": "integer",
-          "minimum": 0
-        },
-        "verification": {
+        "publicKey": {
+          "description": "The public key that can verify the package signature",
           "type": "object",
-          "properties": {
-            "inclusionProof": {
-              "$ref": "#/definitions/InclusionProof"
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
             },
-            "signedEntryTimestamp": {
-              "description": "Signature over the logID, logIndex, body and integratedTime.",
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
               "type": "string",
               "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
             }
           }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/helm/helm_v0_0_2_schema.json"
     },
-    "LogEntryAnonAttestation": {
+    "intoto": {
+      "description": "Intoto object",
       "type": "object",
-      "format": "byte",
-      "properties": {
-        "data": {
-          "format": "byte"
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
         },
-        "mediaType": {
-          "format": "string"
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/intotoSchema"
+            }
+          },
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "LogEntryAnonVerification": {
+    "intotoSchema": {
+      "description": "Intoto for Rekord objects",
       "type": "object",
-      "properties": {
-        "inclusionProof": {
-          "$ref": "#/definitions/InclusionProof"
-        },
-        "signedEntryTimestamp": {
-          "description": "Signature over the logID, logIndex, body and integratedTime.",
-          "type": "string",
-          "format": "byte"
+      "title": "Intoto Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/intotoV001Schema"
         }
-      }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_schema.json"
     },
-    "LogInfo": {
+    "intotoV001Schema": {
+      "description": "Schema for intoto object",
       "type": "object",
+      "title": "intoto v0.0.1 Schema",
       "required": [
-        "rootHash",
-        "treeSize",
-        "signedTreeHead"
+        "publicKey",
+        "content"
       ],
       "properties": {
-        "rootHash": {
-          "description": "The current hash value stored at the root of the merkle tree",
-          "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+        "content": {
+          "type": "object",
+          "properties": {
+            "envelope": {
+              "description": "envelope",
+              "type": "string",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the archive",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            }
+          }
         },
-        "signedTreeHead": {
-          "description": "The current signed tree head",
-          "type": "string",
-          "format": "signedCheckpoint"
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "treeSize": {
-          "description": "The current number of nodes in the merkle tree",
-          "type": "integer",
-          "minimum": 1
-        }
-      }
-    },
-    "ProposedEntry": {
-      "type": "object",
-      "required": [
-        "kind"
-      ],
-      "properties": {
-        "kind": {
-          "type": "string"
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "string",
+          "format": "byte"
         }
       },
-      "discriminator": "kind"
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_v0_0_1_schema.json"
     },
-    "RekordV001SchemaData": {
-      "description": "Information about the content associated with the entry",
+    "jar": {
+      "description": "Java Archive (JAR)",
       "type": "object",
-      "oneOf": [
+      "allOf": [
         {
-          "required": [
-            "url"
-          ]
+          "$ref": "#/definitions/ProposedEntry"
         },
         {
           "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content inline within the document",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
-        },
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the content",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
+            "apiVersion",
+            "spec"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "apiVersion": {
               "type": "string",
-              "enum": [
-                "sha256"
-              ]
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "value": {
-              "description": "The hash value for the content",
-              "type": "string"
+            "spec": {
+              "$ref": "#/definitions/jarSchema"
             }
-          }
-        },
-        "url": {
-          "description": "Specifies the location of the content",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
-    },
-    "RekordV001SchemaDataHash": {
-      "description": "Specifies the hash algorithm and value for the content",
-      "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value for the content",
-          "type": "string"
+          },
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "RekordV001SchemaSignature": {
-      "description": "Information about the detached signature associated with the entry",
+    "jarSchema": {
+      "description": "Schema for JAR objects",
       "type": "object",
+      "title": "JAR Schema",
       "oneOf": [
         {
-          "required": [
-            "format",
-            "publicKey",
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "format",
-            "publicKey",
-            "content"
-          ]
+          "$ref": "#/definitions/jarV001Schema"
         }
       ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/jar/jar_schema.json"
+    },
+    "jarV001Schema": {
+      "description": "Schema for JAR entries",
+      "type": "object",
+      "title": "JAR v0.0.1 Schema",
+      "required": [
+        "archive"
+      ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the signature inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "format": {
-          "description": "Specifies the format of the signature",
-          "type": "string",
-          "enum": [
-            "pgp",
-            "minisign",
-            "x509",
-            "ssh"
-          ]
+        "additionalSignatures": {
+          "description": "PEM encoded PKCS7 signatures of any further signers of the JAR file, in the order they appear in the archive",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "readOnly": true
         },
-        "publicKey": {
-          "description": "The public key that can verify the signature",
+        "archive": {
+          "description": "Information about the archive associated with the entry",
           "type": "object",
           "oneOf": [
             {
@@ -2098,307 +7206,271 @@ func init() {
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the public key inline within the document",
+              "description": "Specifies the archive inline within the document",
               "type": "string",
-              "format": "byte"
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the archive",
+                  "type": "string"
+                }
+              }
             },
             "url": {
-              "description": "Specifies the location of the public key",
+              "description": "Specifies the location of the archive; if this is specified, a hash value must also be provided",
               "type": "string",
               "format": "uri",
               "writeOnly": true
             }
           }
         },
-        "url": {
-          "description": "Specifies the location of the signature",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
-    },
-    "RekordV001SchemaSignaturePublicKey": {
-      "description": "The public key that can verify the signature",
-      "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "url"
-          ]
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        {
+        "signature": {
+          "description": "Information about the included signature in the JAR file",
+          "type": "object",
           "required": [
+            "publicKey",
             "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "url": {
-          "description": "Specifies the location of the public key",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
-    },
-    "Rfc3161V001SchemaTsr": {
-      "description": "Information about the tsr file associated with the entry",
-      "type": "object",
-      "required": [
-        "content"
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the tsr file content inline within the document",
-          "type": "string",
-          "format": "byte"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the PKCS7 signature embedded within the JAR file ",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            },
+            "publicKey": {
+              "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+                  "type": "string",
+                  "format": "byte"
+                }
+              },
+              "readOnly": true
+            }
+          }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/jar/jar_v0_0_1_schema.json"
     },
-    "RpmV001SchemaPackage": {
-      "description": "Information about the package associated with the entry",
+    "maven": {
+      "description": "Maven artifact with a detached PGP signature",
       "type": "object",
-      "oneOf": [
+      "allOf": [
         {
-          "required": [
-            "url"
-          ]
+          "$ref": "#/definitions/ProposedEntry"
         },
         {
           "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the package inline within the document",
-          "type": "string",
-          "format": "byte",
-          "writeOnly": true
-        },
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the package",
-          "type": "object",
-          "required": [
-            "algorithm",
-            "value"
+            "apiVersion",
+            "spec"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "apiVersion": {
               "type": "string",
-              "enum": [
-                "sha256"
-              ]
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
-            "value": {
-              "description": "The hash value for the package",
-              "type": "string"
+            "spec": {
+              "$ref": "#/definitions/mavenSchema"
             }
-          }
-        },
-        "headers": {
-          "description": "Values of the RPM headers",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
           },
-          "readOnly": true
-        },
-        "url": {
-          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
-    },
-    "RpmV001SchemaPackageHash": {
-      "description": "Specifies the hash algorithm and value for the package",
-      "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value for the package",
-          "type": "string"
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "RpmV001SchemaPublicKey": {
-      "description": "The PGP public key that can verify the RPM signature",
+    "mavenSchema": {
+      "description": "Schema for signed Maven artifacts",
       "type": "object",
+      "title": "Maven Artifact Schema",
       "oneOf": [
         {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
+          "$ref": "#/definitions/mavenV001Schema"
         }
       ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "url": {
-          "description": "Specifies the location of the public key",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/maven/maven_schema.json"
     },
-    "SearchIndex": {
+    "mavenV001Schema": {
+      "description": "Schema for Maven artifacts with detached PGP signatures",
       "type": "object",
+      "title": "Maven v0.0.1 Schema",
+      "required": [
+        "coordinates",
+        "artifact",
+        "signature",
+        "publicKey"
+      ],
       "properties": {
-        "email": {
-          "type": "string",
-          "format": "email"
-        },
-        "hash": {
-          "type": "string",
-          "pattern": "^(sha256:)?[0-9a-fA-F]{64}$"
-        },
-        "publicKey": {
+        "artifact": {
+          "description": "Information about the artifact file associated with the entry",
           "type": "object",
-          "required": [
-            "format"
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
           ],
           "properties": {
             "content": {
+              "description": "Specifies the artifact inline within the document",
               "type": "string",
-              "format": "byte"
+              "format": "byte",
+              "writeOnly": true
             },
-            "format": {
-              "type": "string",
-              "enum": [
-                "pgp",
-                "x509",
-                "minisign",
-                "ssh",
-                "tuf"
-              ]
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the artifact",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the artifact",
+                  "type": "string"
+                }
+              }
             },
             "url": {
+              "description": "Specifies the location of the artifact; if this is specified, a hash value must also be provided",
               "type": "string",
-              "format": "uri"
+              "format": "uri",
+              "writeOnly": true
             }
           }
-        }
-      }
-    },
-    "SearchIndexPublicKey": {
-      "type": "object",
-      "required": [
-        "format"
-      ],
-      "properties": {
-        "content": {
-          "type": "string",
-          "format": "byte"
-        },
-        "format": {
-          "type": "string",
-          "enum": [
-            "pgp",
-            "x509",
-            "minisign",
-            "ssh",
-            "tuf"
-          ]
         },
-        "url": {
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "SearchLogQuery": {
-      "type": "object",
-      "properties": {
-        "entries": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/ProposedEntry"
+        "coordinates": {
+          "description": "The Maven coordinates of the artifact",
+          "type": "object",
+          "required": [
+            "groupId",
+            "artifactId",
+            "version"
+          ],
+          "properties": {
+            "artifactId": {
+              "description": "The artifact identifier, e.g. maven-core",
+              "type": "string"
+            },
+            "groupId": {
+              "description": "The group identifier, e.g. org.apache.maven",
+              "type": "string"
+            },
+            "version": {
+              "description": "The artifact version",
+              "type": "string"
+            }
           }
         },
-        "entryUUIDs": {
-          "type": "array",
-          "items": {
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
-          }
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "logIndexes": {
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "type": "integer",
-            "minimum": 0
-          }
-        }
-      }
-    },
-    "TUFV001SchemaMetadata": {
-      "description": "TUF metadata",
-      "type": "object",
-      "properties": {
-        "content": {
-          "description": "Specifies the archive inline within the document",
+        "publicKey": {
+          "description": "The PGP public key that can verify the signature",
           "type": "object",
-          "additionalProperties": true,
-          "writeOnly": true
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
         },
-        "url": {
-          "description": "Specifies the location of the archive",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
-        }
-      }
-    },
-    "TUFV001SchemaRoot": {
-      "description": "root metadata containing about the public keys used to sign the manifest",
-      "type": "object",
-      "properties": {
-        "content": {
-          "description": "Specifies the archive inline within the document",
+        "signature": {
+          "description": "The detached PGP signature over the artifact, as published in its .asc file",
           "type": "object",
-          "additionalProperties": true,
-          "writeOnly": true
-        },
-        "url": {
-          "description": "Specifies the location of the archive",
-          "type": "string",
-          "format": "uri",
-          "writeOnly": true
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri",
+              "writeOnly": true
+            }
+          }
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/maven/maven_v0_0_1_schema.json"
     },
-    "alpine": {
-      "description": "Alpine package",
+    "npm": {
+      "description": "npm package",
       "type": "object",
       "allOf": [
         {
@@ -2415,31 +7487,30 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/alpineSchema"
+              "$ref": "#/definitions/npmSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "alpineSchema": {
-      "description": "Schema for Alpine package objects",
+    "npmSchema": {
+      "description": "Schema for npm package signatures and provenance",
       "type": "object",
-      "title": "Alpine Package Schema",
+      "title": "npm Package Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/alpineV001Schema"
+          "$ref": "#/definitions/npmV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/npm/npm_schema.json"
     },
-    "alpineV001Schema": {
-      "description": "Schema for Alpine Package entries",
+    "npmV001Schema": {
+      "description": "Schema for npm package registry signatures and Sigstore provenance attestations",
       "type": "object",
-      "title": "Alpine v0.0.1 Schema",
+      "title": "npm v0.0.1 Schema",
       "required": [
-        "publicKey",
         "package"
       ],
       "properties": {
@@ -2449,29 +7520,46 @@ func init() {
           "additionalProperties": true
         },
         "package": {
-          "description": "Information about the package associated with the entry",
+          "description": "The npm package version the signature or attestation covers",
           "type": "object",
-          "oneOf": [
-            {
-              "required": [
-                "url"
-              ]
+          "required": [
+            "name",
+            "version",
+            "integrity"
+          ],
+          "properties": {
+            "integrity": {
+              "description": "The subresource integrity string of the package tarball, as found in dist.integrity",
+              "type": "string"
             },
-            {
-              "required": [
-                "content"
-              ]
+            "name": {
+              "description": "The package name, including its scope if any",
+              "type": "string"
+            },
+            "version": {
+              "description": "The package version",
+              "type": "string"
             }
-          ],
+          }
+        },
+        "provenance": {
+          "description": "A Sigstore bundle holding the provenance attestation for the package",
+          "type": "object",
           "properties": {
+            "certificate": {
+              "description": "The certificate that signed the attestation",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            },
             "content": {
-              "description": "Specifies the package inline within the document",
+              "description": "The Sigstore bundle, as served by the npm attestations API",
               "type": "string",
               "format": "byte",
               "writeOnly": true
             },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the package",
+              "description": "Specifies the hash algorithm and value for the bundle",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2486,62 +7574,39 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the package",
+                  "description": "The hash value for the bundle",
                   "type": "string"
                 }
-              }
-            },
-            "pkginfo": {
-              "description": "Values of the .PKGINFO key / value pairs",
-              "type": "object",
-              "additionalProperties": {
-                "type": "string"
               },
               "readOnly": true
-            },
-            "url": {
-              "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
-              "type": "string",
-              "format": "uri",
-              "writeOnly": true
             }
           }
         },
-        "publicKey": {
-          "description": "The public key that can verify the package signature",
+        "registrySignature": {
+          "description": "A signature by the npm registry, as found in dist.signatures",
           "type": "object",
-          "oneOf": [
-            {
-              "required": [
-                "url"
-              ]
-            },
-            {
-              "required": [
-                "content"
-              ]
-            }
+          "required": [
+            "keyid",
+            "sig"
           ],
           "properties": {
-            "content": {
-              "description": "Specifies the content of the public key inline within the document",
-              "type": "string",
-              "format": "byte"
+            "keyid": {
+              "description": "The identifier of the registry key that made the signature",
+              "type": "string"
             },
-            "url": {
-              "description": "Specifies the location of the public key",
+            "sig": {
+              "description": "The signature over name@version:integrity",
               "type": "string",
-              "format": "uri",
-              "writeOnly": true
+              "format": "byte"
             }
           }
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/alpine/alpine_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/npm/npm_v0_0_1_schema.json"
     },
-    "helm": {
-      "description": "Helm chart",
+    "oci": {
+      "description": "OCI image signature",
       "type": "object",
       "allOf": [
         {
@@ -2558,42 +7623,61 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/helmSchema"
+              "$ref": "#/definitions/ociSchema"
             }
-          }
+          },
+          "additionalProperties": false
         }
       ]
     },
-    "helmSchema": {
-      "description": "Schema for Helm objects",
+    "ociSchema": {
+      "description": "Schema for signatures over OCI image manifests",
       "type": "object",
-      "title": "Helm Schema",
+      "title": "OCI Image Signature Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/helmV001Schema"
+          "$ref": "#/definitions/ociV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/helm/helm_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/oci/oci_schema.json"
     },
-    "helmV001Schema": {
-      "description": "Schema for Helm object",
+    "ociV001Schema": {
+      "description": "Schema for cosign style signatures over OCI image manifests",
       "type": "object",
-      "title": "Helm v0.0.1 Schema",
+      "title": "OCI v0.0.1 Schema",
       "required": [
-        "publicKey",
-        "chart"
+        "manifestDigest",
+        "payload",
+        "signature"
       ],
       "properties": {
-        "chart": {
-          "description": "Information about the Helm chart associated with the entry",
+        "dockerReference": {
+          "description": "The repository reference the payload was signed for",
+          "type": "string",
+          "readOnly": true
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "manifestDigest": {
+          "description": "The digest of the signed image manifest, formatted as sha256:\u003chex\u003e",
+          "type": "string"
+        },
+        "payload": {
+          "description": "The simple signing payload that references the image manifest",
           "type": "object",
-          "required": [
-            "provenance"
-          ],
           "properties": {
+            "content": {
+              "description": "Specifies the payload inline within the document",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the chart",
+              "description": "Specifies the hash algorithm and value for the payload",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2608,100 +7692,49 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the chart",
+                  "description": "The hash value for the payload",
                   "type": "string"
                 }
               },
               "readOnly": true
-            },
-            "provenance": {
-              "description": "The provenance entry associated with the signed Helm Chart",
-              "type": "object",
-              "oneOf": [
-                {
-                  "required": [
-                    "url"
-                  ]
-                },
-                {
-                  "required": [
-                    "content"
-                  ]
-                }
-              ],
-              "properties": {
-                "content": {
-                  "description": "Specifies the content of the provenance file inline within the document",
-                  "type": "string",
-                  "format": "byte",
-                  "writeOnly": true
-                },
-                "signature": {
-                  "description": "Information about the included signature in the provenance file",
-                  "type": "object",
-                  "required": [
-                    "content"
-                  ],
-                  "properties": {
-                    "content": {
-                      "description": "Specifies the signature embedded within the provenance file ",
-                      "type": "string",
-                      "format": "byte",
-                      "readOnly": true
-                    }
-                  },
-                  "readOnly": true
-                },
-                "url": {
-                  "description": "Specifies the location of the provenance file",
-                  "type": "string",
-                  "format": "uri",
-                  "writeOnly": true
-                }
-              }
             }
           }
         },
-        "extraData": {
-          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
-          "type": "object",
-          "additionalProperties": true
-        },
-        "publicKey": {
-          "description": "The public key that can verify the package signature",
+        "signature": {
+          "description": "Information about the signature over the payload",
           "type": "object",
-          "oneOf": [
-            {
-              "required": [
-                "url"
-              ]
-            },
-            {
-              "required": [
-                "content"
-              ]
-            }
+          "required": [
+            "content",
+            "publicKey"
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the public key inline within the document",
+              "description": "Specifies the content of the signature inline within the document",
               "type": "string",
               "format": "byte"
             },
-            "url": {
-              "description": "Specifies the location of the public key",
-              "type": "string",
-              "format": "uri",
-              "writeOnly": true
+            "publicKey": {
+              "description": "The public key or certificate that can verify the signature",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key or certificate inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
             }
           }
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/helm/helm_v0_0_1_schema.json"
-    },
-    "intoto": {
-      "description": "Intoto object",
+      "$id": "http://rekor.sigstore.dev/types/oci/oci_v0_0_1_schema.json"
+    },
+    "openvex": {
+      "description": "Signed OpenVEX documents",
       "type": "object",
       "allOf": [
         {
@@ -2718,44 +7751,46 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/intotoSchema"
+              "$ref": "#/definitions/openvexSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "intotoSchema": {
-      "description": "Intoto for Rekord objects",
+    "openvexSchema": {
+      "description": "Schema for signed OpenVEX documents",
       "type": "object",
-      "title": "Intoto Schema",
+      "title": "OpenVEX Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/intotoV001Schema"
+          "$ref": "#/definitions/openvexV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/openvex/openvex_schema.json"
     },
-    "intotoV001Schema": {
-      "description": "Schema for intoto object",
+    "openvexV001Schema": {
+      "description": "Schema for signed OpenVEX documents",
       "type": "object",
-      "title": "intoto v0.0.1 Schema",
+      "title": "openvex v0.0.1 Schema",
       "required": [
-        "publicKey",
-        "content"
+        "document",
+        "signature"
       ],
       "properties": {
-        "content": {
+        "document": {
+          "description": "Information about the OpenVEX document",
           "type": "object",
           "properties": {
-            "envelope": {
-              "description": "envelope",
+            "content": {
+              "description": "Specifies the OpenVEX document inline within the entry; for DSSE signatures this is the envelope carrying the document",
               "type": "string",
+              "format": "byte",
               "writeOnly": true
             },
             "hash": {
-              "description": "Specifies the hash algorithm and value encompassing the entire signed envelope",
+              "description": "Specifies the hash algorithm and value for the submitted document content",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2770,7 +7805,7 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the archive",
+                  "description": "The hash value for the document",
                   "type": "string"
                 }
               },
@@ -2783,17 +7818,70 @@ func init() {
           "type": "object",
           "additionalProperties": true
         },
-        "publicKey": {
-          "description": "The public key that can verify the signature",
+        "id": {
+          "description": "The @id of the OpenVEX document",
           "type": "string",
-          "format": "byte"
+          "readOnly": true
+        },
+        "products": {
+          "description": "Identifiers of the products the document makes statements about, such as package URLs, CPEs and hashes",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "readOnly": true
+        },
+        "signature": {
+          "description": "Information about the signature over the document",
+          "type": "object",
+          "required": [
+            "format",
+            "publicKey"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the detached PGP signature over the document; DSSE signatures are carried in the envelope",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the format of the signature",
+              "type": "string",
+              "enum": [
+                "dsse",
+                "pgp"
+              ]
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "required": [
+                "content"
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key or x509 certificate inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                }
+              }
+            }
+          }
+        },
+        "vulnerabilities": {
+          "description": "Names and aliases of the vulnerabilities the document makes statements about",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "readOnly": true
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/intoto/intoto_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/openvex/openvex_v0_0_1_schema.json"
     },
-    "jar": {
-      "description": "Java Archive (JAR)",
+    "pypi": {
+      "description": "Python distribution with a PEP 740 attestation",
       "type": "object",
       "allOf": [
         {
@@ -2810,57 +7898,52 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/jarSchema"
+              "$ref": "#/definitions/pypiSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "jarSchema": {
-      "description": "Schema for JAR objects",
+    "pypiSchema": {
+      "description": "Schema for Python package distributions with PEP 740 attestations",
       "type": "object",
-      "title": "JAR Schema",
+      "title": "PyPI Distribution Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/jarV001Schema"
+          "$ref": "#/definitions/pypiV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/jar/jar_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/pypi/pypi_schema.json"
     },
-    "jarV001Schema": {
-      "description": "Schema for JAR entries",
+    "pypiV001Schema": {
+      "description": "Schema for Python wheels and source distributions with PEP 740 publish attestations",
       "type": "object",
-      "title": "JAR v0.0.1 Schema",
+      "title": "PyPI v0.0.1 Schema",
       "required": [
-        "archive"
+        "distribution",
+        "attestation"
       ],
       "properties": {
-        "archive": {
-          "description": "Information about the archive associated with the entry",
+        "attestation": {
+          "description": "The PEP 740 attestation for the distribution",
           "type": "object",
-          "oneOf": [
-            {
-              "required": [
-                "url"
-              ]
-            },
-            {
-              "required": [
-                "content"
-              ]
-            }
-          ],
           "properties": {
+            "certificate": {
+              "description": "The certificate that signed the attestation",
+              "type": "string",
+              "format": "byte",
+              "readOnly": true
+            },
             "content": {
-              "description": "Specifies the archive inline within the document",
+              "description": "The attestation object, as served by the PyPI integrity API",
               "type": "string",
               "format": "byte",
               "writeOnly": true
             },
             "hash": {
-              "description": "Specifies the hash algorithm and value encompassing the entire signed archive",
+              "description": "Specifies the hash algorithm and value for the attestation",
               "type": "object",
               "required": [
                 "algorithm",
@@ -2875,58 +7958,57 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the archive",
+                  "description": "The hash value for the attestation",
                   "type": "string"
                 }
-              }
-            },
-            "url": {
-              "description": "Specifies the location of the archive; if this is specified, a hash value must also be provided",
-              "type": "string",
-              "format": "uri",
-              "writeOnly": true
+              },
+              "readOnly": true
             }
           }
         },
-        "extraData": {
-          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
-          "type": "object",
-          "additionalProperties": true
-        },
-        "signature": {
-          "description": "Information about the included signature in the JAR file",
+        "distribution": {
+          "description": "The wheel or source distribution the attestation covers",
           "type": "object",
           "required": [
-            "publicKey",
-            "content"
+            "filename",
+            "hash"
           ],
           "properties": {
-            "content": {
-              "description": "Specifies the PKCS7 signature embedded within the JAR file ",
-              "type": "string",
-              "format": "byte",
-              "readOnly": true
+            "filename": {
+              "description": "The distribution filename, from which the project name and version are taken",
+              "type": "string"
             },
-            "publicKey": {
-              "description": "The X509 certificate containing the public key JAR which verifies the signature of the JAR",
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the distribution",
               "type": "object",
               "required": [
-                "content"
+                "algorithm",
+                "value"
               ],
               "properties": {
-                "content": {
-                  "description": "Specifies the content of the X509 certificate containing the public key used to verify the signature",
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
                   "type": "string",
-                  "format": "byte"
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the distribution",
+                  "type": "string"
                 }
-              },
-              "readOnly": true
+              }
             }
           }
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.sigstore.dev/types/jar/jar_v0_0_1_schema.json"
+      "$id": "http://rekor.sigstore.dev/types/pypi/pypi_v0_0_1_schema.json"
     },
     "rekord": {
       "description": "Rekord object",
@@ -3050,20 +8132,18 @@ func init() {
             }
           ],
           "properties": {
+            "algorithm": {
+              "description": "The signature scheme detected by the server when the signature was verified, for formats that support more than one; only set for schemes other than the default for the key type, e.g. rsa-pss-sha256 or ed25519ph",
+              "type": "string"
+            },
             "content": {
               "description": "Specifies the content of the signature inline within the document",
               "type": "string",
               "format": "byte"
             },
             "format": {
-              "description": "Specifies the format of the signature",
-              "type": "string",
-              "enum": [
-                "pgp",
-                "minisign",
-                "x509",
-                "ssh"
-              ]
+              "description": "Specifies the format of the signature; one of the PKI formats supported by the server, e.g. pgp, minisign, x509 or ssh",
+              "type": "string"
             },
             "publicKey": {
               "description": "The public key that can verify the signature",
@@ -3156,6 +8236,20 @@ func init() {
           "type": "object",
           "additionalProperties": true
         },
+        "tsaCertificate": {
+          "description": "The X509 certificate of the timestamping authority that signed the tsr, optionally followed by its issuing certificates",
+          "type": "object",
+          "required": [
+            "content"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the PEM encoded certificate chain of the timestamping authority",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        },
         "tsr": {
           "description": "Information about the tsr file associated with the entry",
           "type": "object",
@@ -3317,6 +8411,107 @@ func init() {
       "$schema": "http://json-schema.org/draft-07/schema",
       "$id": "http://rekor.sigstore.dev/types/rpm/rpm_v0_0_1_schema.json"
     },
+    "spdx": {
+      "description": "SPDX SBOM document",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/spdxSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "spdxSchema": {
+      "description": "SPDX for Rekord objects",
+      "type": "object",
+      "title": "SPDX Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/spdxV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/spdx/spdx_schema.json"
+    },
+    "spdxV001Schema": {
+      "description": "Schema for SPDX SBOM documents",
+      "type": "object",
+      "title": "spdx v0.0.1 Schema",
+      "required": [
+        "document"
+      ],
+      "properties": {
+        "document": {
+          "description": "Information about the SPDX document",
+          "type": "object",
+          "properties": {
+            "content": {
+              "description": "Specifies the SPDX document inline within the entry, in tag-value or JSON format",
+              "type": "string",
+              "format": "byte",
+              "writeOnly": true
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the document",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the document",
+                  "type": "string"
+                }
+              },
+              "readOnly": true
+            }
+          }
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "namespace": {
+          "description": "The DocumentNamespace of the SPDX document",
+          "type": "string",
+          "readOnly": true
+        },
+        "packageChecksums": {
+          "description": "Checksums of the packages described by the SPDX document, formatted as algorithm:value",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "readOnly": true
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.sigstore.dev/types/spdx/spdx_v0_0_1_schema.json"
+    },
     "tuf": {
       "description": "TUF metadata",
       "type": "object",
@@ -3424,9 +8619,13 @@ func init() {
     "Conflict": {
       "description": "The request conflicts with the current state of the transparency log",
       "schema": {
-        "$ref": "#/definitions/Error"
+        "$ref": "#/definitions/ConflictError"
       },
       "headers": {
+        "ETag": {
+          "type": "string",
+          "description": "UUID of the equivalent log entry"
+        },
         "Location": {
           "type": "string",
           "format": "uri"
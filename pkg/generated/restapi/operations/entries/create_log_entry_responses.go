@@ -26,6 +26,7 @@ import (
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 )
@@ -33,7 +34,7 @@ import (
 // CreateLogEntryCreatedCode is the HTTP code returned for type CreateLogEntryCreated
 const CreateLogEntryCreatedCode int = 201
 
-/*CreateLogEntryCreated Returns the entry created in the transparency log
+/*CreateLogEntryCreated Returns the entry created in the transparency log, or the equivalent entry already in it if the server is configured to accept duplicate entries idempotently
 
 swagger:response createLogEntryCreated
 */
@@ -46,6 +47,10 @@ type CreateLogEntryCreated struct {
 
 	 */
 	Location strfmt.URI `json:"Location"`
+	/*true if the entry was already in the transparency log
+
+	 */
+	RekorExistingEntry bool `json:"Rekor-Existing-Entry"`
 
 	/*
 	  In: Body
@@ -81,6 +86,17 @@ func (o *CreateLogEntryCreated) SetLocation(location strfmt.URI) {
 	o.Location = location
 }
 
+// WithRekorExistingEntry adds the rekorExistingEntry to the create log entry created response
+func (o *CreateLogEntryCreated) WithRekorExistingEntry(rekorExistingEntry bool) *CreateLogEntryCreated {
+	o.RekorExistingEntry = rekorExistingEntry
+	return o
+}
+
+// SetRekorExistingEntry sets the rekorExistingEntry to the create log entry created response
+func (o *CreateLogEntryCreated) SetRekorExistingEntry(rekorExistingEntry bool) {
+	o.RekorExistingEntry = rekorExistingEntry
+}
+
 // WithPayload adds the payload to the create log entry created response
 func (o *CreateLogEntryCreated) WithPayload(payload models.LogEntry) *CreateLogEntryCreated {
 	o.Payload = payload
@@ -109,6 +125,13 @@ func (o *CreateLogEntryCreated) WriteResponse(rw http.ResponseWriter, producer r
 		rw.Header().Set("Location", location)
 	}
 
+	// response header Rekor-Existing-Entry
+
+	rekorExistingEntry := swag.FormatBool(o.RekorExistingEntry)
+	if rekorExistingEntry != "" {
+		rw.Header().Set("Rekor-Existing-Entry", rekorExistingEntry)
+	}
+
 	rw.WriteHeader(201)
 	payload := o.Payload
 	if payload == nil {
@@ -173,6 +196,10 @@ const CreateLogEntryConflictCode int = 409
 swagger:response createLogEntryConflict
 */
 type CreateLogEntryConflict struct {
+	/*UUID of the equivalent log entry
+
+	 */
+	ETag string `json:"ETag"`
 	/*
 
 	 */
@@ -181,7 +208,7 @@ type CreateLogEntryConflict struct {
 	/*
 	  In: Body
 	*/
-	Payload *models.Error `json:"body,omitempty"`
+	Payload *models.ConflictError `json:"body,omitempty"`
 }
 
 // NewCreateLogEntryConflict creates CreateLogEntryConflict with default headers values
@@ -190,6 +217,17 @@ func NewCreateLogEntryConflict() *CreateLogEntryConflict {
 	return &CreateLogEntryConflict{}
 }
 
+// WithETag adds the eTag to the create log entry conflict response
+func (o *CreateLogEntryConflict) WithETag(eTag string) *CreateLogEntryConflict {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the create log entry conflict response
+func (o *CreateLogEntryConflict) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithLocation adds the location to the create log entry conflict response
 func (o *CreateLogEntryConflict) WithLocation(location strfmt.URI) *CreateLogEntryConflict {
 	o.Location = location
@@ -202,19 +240,26 @@ func (o *CreateLogEntryConflict) SetLocation(location strfmt.URI) {
 }
 
 // WithPayload adds the payload to the create log entry conflict response
-func (o *CreateLogEntryConflict) WithPayload(payload *models.Error) *CreateLogEntryConflict {
+func (o *CreateLogEntryConflict) WithPayload(payload *models.ConflictError) *CreateLogEntryConflict {
 	o.Payload = payload
 	return o
 }
 
 // SetPayload sets the payload to the create log entry conflict response
-func (o *CreateLogEntryConflict) SetPayload(payload *models.Error) {
+func (o *CreateLogEntryConflict) SetPayload(payload *models.ConflictError) {
 	o.Payload = payload
 }
 
 // WriteResponse to the client
 func (o *CreateLogEntryConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	// response header Location
 
 	location := o.Location.String()
@@ -33,11 +33,18 @@ import (
 )
 
 // NewGetLogEntryByIndexParams creates a new GetLogEntryByIndexParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewGetLogEntryByIndexParams() GetLogEntryByIndexParams {
 
-	return GetLogEntryByIndexParams{}
+	var (
+		// initialize parameters with default values
+
+		countDefault = int64(1)
+	)
+
+	return GetLogEntryByIndexParams{
+		Count: &countDefault,
+	}
 }
 
 // GetLogEntryByIndexParams contains all the bound params for the get log entry by index operation
@@ -49,6 +56,14 @@ type GetLogEntryByIndexParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*the number of consecutive entries to retrieve, starting at logIndex; fewer are returned at the end of the log. Inclusion proofs and signed entry timestamps are only returned when a single entry is retrieved.
+
+	  Maximum: 256
+	  Minimum: 1
+	  In: query
+	  Default: 1
+	*/
+	Count *int64
 	/*specifies the index of the entry in the transparency log to be retrieved
 	  Required: true
 	  Minimum: 0
@@ -68,6 +83,11 @@ func (o *GetLogEntryByIndexParams) BindRequest(r *http.Request, route *middlewar
 
 	qs := runtime.Values(r.URL.Query())
 
+	qCount, qhkCount, _ := qs.GetOK("count")
+	if err := o.bindCount(qCount, qhkCount, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qLogIndex, qhkLogIndex, _ := qs.GetOK("logIndex")
 	if err := o.bindLogIndex(qLogIndex, qhkLogIndex, route.Formats); err != nil {
 		res = append(res, err)
@@ -78,6 +98,48 @@ func (o *GetLogEntryByIndexParams) BindRequest(r *http.Request, route *middlewar
 	return nil
 }
 
+// bindCount binds and validates parameter Count from query.
+func (o *GetLogEntryByIndexParams) bindCount(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetLogEntryByIndexParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("count", "query", "int64", raw)
+	}
+	o.Count = &value
+
+	if err := o.validateCount(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCount carries on validations for parameter Count
+func (o *GetLogEntryByIndexParams) validateCount(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("count", "query", *o.Count, 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("count", "query", *o.Count, 256, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindLogIndex binds and validates parameter LogIndex from query.
 func (o *GetLogEntryByIndexParams) bindLogIndex(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
@@ -32,7 +32,7 @@ import (
 // GetLogEntryByIndexOKCode is the HTTP code returned for type GetLogEntryByIndexOK
 const GetLogEntryByIndexOKCode int = 200
 
-/*GetLogEntryByIndexOK the entry in the transparency log requested along with an inclusion proof
+/*GetLogEntryByIndexOK the entries in the transparency log requested, along with an inclusion proof if a single entry was requested
 
 swagger:response getLogEntryByIndexOK
 */
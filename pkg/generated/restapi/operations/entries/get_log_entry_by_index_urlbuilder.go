@@ -31,6 +31,7 @@ import (
 
 // GetLogEntryByIndexURL generates an URL for the get log entry by index operation
 type GetLogEntryByIndexURL struct {
+	Count    *int64
 	LogIndex int64
 
 	_basePath string
@@ -64,6 +65,14 @@ func (o *GetLogEntryByIndexURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var countQ string
+	if o.Count != nil {
+		countQ = swag.FormatInt64(*o.Count)
+	}
+	if countQ != "" {
+		qs.Set("count", countQ)
+	}
+
 	logIndexQ := swag.FormatInt64(o.LogIndex)
 	if logIndexQ != "" {
 		qs.Set("logIndex", logIndexQ)
@@ -29,6 +29,8 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
@@ -56,6 +58,16 @@ type SearchLogQueryParams struct {
 	  In: body
 	*/
 	Entry *models.SearchLogQuery
+	/*only return entries integrated at or after this time, in seconds since the epoch
+	  Minimum: 0
+	  In: query
+	*/
+	IntegratedTimeFrom *int64
+	/*only return entries integrated at or before this time, in seconds since the epoch
+	  Minimum: 0
+	  In: query
+	*/
+	IntegratedTimeTo *int64
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -67,6 +79,8 @@ func (o *SearchLogQueryParams) BindRequest(r *http.Request, route *middleware.Ma
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.SearchLogQuery
@@ -94,8 +108,92 @@ func (o *SearchLogQueryParams) BindRequest(r *http.Request, route *middleware.Ma
 	} else {
 		res = append(res, errors.Required("entry", "body", ""))
 	}
+
+	qIntegratedTimeFrom, qhkIntegratedTimeFrom, _ := qs.GetOK("integratedTimeFrom")
+	if err := o.bindIntegratedTimeFrom(qIntegratedTimeFrom, qhkIntegratedTimeFrom, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qIntegratedTimeTo, qhkIntegratedTimeTo, _ := qs.GetOK("integratedTimeTo")
+	if err := o.bindIntegratedTimeTo(qIntegratedTimeTo, qhkIntegratedTimeTo, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindIntegratedTimeFrom binds and validates parameter IntegratedTimeFrom from query.
+func (o *SearchLogQueryParams) bindIntegratedTimeFrom(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("integratedTimeFrom", "query", "int64", raw)
+	}
+	o.IntegratedTimeFrom = &value
+
+	if err := o.validateIntegratedTimeFrom(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateIntegratedTimeFrom carries on validations for parameter IntegratedTimeFrom
+func (o *SearchLogQueryParams) validateIntegratedTimeFrom(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("integratedTimeFrom", "query", *o.IntegratedTimeFrom, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindIntegratedTimeTo binds and validates parameter IntegratedTimeTo from query.
+func (o *SearchLogQueryParams) bindIntegratedTimeTo(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("integratedTimeTo", "query", "int64", raw)
+	}
+	o.IntegratedTimeTo = &value
+
+	if err := o.validateIntegratedTimeTo(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateIntegratedTimeTo carries on validations for parameter IntegratedTimeTo
+func (o *SearchLogQueryParams) validateIntegratedTimeTo(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("integratedTimeTo", "query", *o.IntegratedTimeTo, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
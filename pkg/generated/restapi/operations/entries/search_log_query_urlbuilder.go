@@ -25,11 +25,18 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // SearchLogQueryURL generates an URL for the search log query operation
 type SearchLogQueryURL struct {
+	IntegratedTimeFrom *int64
+	IntegratedTimeTo   *int64
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -56,6 +63,26 @@ func (o *SearchLogQueryURL) Build() (*url.URL, error) {
 	_basePath := o._basePath
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var integratedTimeFromQ string
+	if o.IntegratedTimeFrom != nil {
+		integratedTimeFromQ = swag.FormatInt64(*o.IntegratedTimeFrom)
+	}
+	if integratedTimeFromQ != "" {
+		qs.Set("integratedTimeFrom", integratedTimeFromQ)
+	}
+
+	var integratedTimeToQ string
+	if o.IntegratedTimeTo != nil {
+		integratedTimeToQ = swag.FormatInt64(*o.IntegratedTimeTo)
+	}
+	if integratedTimeToQ != "" {
+		qs.Set("integratedTimeTo", integratedTimeToQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -49,6 +49,7 @@ func NewSearchIndex(ctx *middleware.Context, handler SearchIndexHandler) *Search
 
 Searches index by entry metadata
 
+Matching UUIDs are returned in ascending order, a page at a time. When there are more results, the response carries a Next-Page-Token header to pass as pageToken to retrieve the next page. Searches limited to a window of integrated time only find entries that were indexed with their integration time; the window may span at most 366 days.
 */
 type SearchIndex struct {
 	Context *middleware.Context
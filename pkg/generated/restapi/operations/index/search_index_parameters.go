@@ -29,6 +29,8 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
@@ -51,6 +53,25 @@ type SearchIndexParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*only return entries integrated at or after this time, in seconds since the epoch; required to search a window of time
+	  Minimum: 0
+	  In: query
+	*/
+	IntegratedTimeFrom *int64
+	/*only return entries integrated at or before this time, in seconds since the epoch; defaults to the current time
+	  Minimum: 0
+	  In: query
+	*/
+	IntegratedTimeTo *int64
+	/*the maximum number of UUIDs to return; the server may return fewer, and caps this at its configured maximum
+	  Minimum: 1
+	  In: query
+	*/
+	PageSize *int64
+	/*the Next-Page-Token returned with the previous page of results
+	  In: query
+	*/
+	PageToken *string
 	/*
 	  Required: true
 	  In: body
@@ -67,6 +88,28 @@ func (o *SearchIndexParams) BindRequest(r *http.Request, route *middleware.Match
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qIntegratedTimeFrom, qhkIntegratedTimeFrom, _ := qs.GetOK("integratedTimeFrom")
+	if err := o.bindIntegratedTimeFrom(qIntegratedTimeFrom, qhkIntegratedTimeFrom, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qIntegratedTimeTo, qhkIntegratedTimeTo, _ := qs.GetOK("integratedTimeTo")
+	if err := o.bindIntegratedTimeTo(qIntegratedTimeTo, qhkIntegratedTimeTo, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPageSize, qhkPageSize, _ := qs.GetOK("pageSize")
+	if err := o.bindPageSize(qPageSize, qhkPageSize, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPageToken, qhkPageToken, _ := qs.GetOK("pageToken")
+	if err := o.bindPageToken(qPageToken, qhkPageToken, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.SearchIndex
@@ -99,3 +142,132 @@ func (o *SearchIndexParams) BindRequest(r *http.Request, route *middleware.Match
 	}
 	return nil
 }
+
+// bindIntegratedTimeFrom binds and validates parameter IntegratedTimeFrom from query.
+func (o *SearchIndexParams) bindIntegratedTimeFrom(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("integratedTimeFrom", "query", "int64", raw)
+	}
+	o.IntegratedTimeFrom = &value
+
+	if err := o.validateIntegratedTimeFrom(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateIntegratedTimeFrom carries on validations for parameter IntegratedTimeFrom
+func (o *SearchIndexParams) validateIntegratedTimeFrom(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("integratedTimeFrom", "query", *o.IntegratedTimeFrom, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindIntegratedTimeTo binds and validates parameter IntegratedTimeTo from query.
+func (o *SearchIndexParams) bindIntegratedTimeTo(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("integratedTimeTo", "query", "int64", raw)
+	}
+	o.IntegratedTimeTo = &value
+
+	if err := o.validateIntegratedTimeTo(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateIntegratedTimeTo carries on validations for parameter IntegratedTimeTo
+func (o *SearchIndexParams) validateIntegratedTimeTo(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("integratedTimeTo", "query", *o.IntegratedTimeTo, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindPageSize binds and validates parameter PageSize from query.
+func (o *SearchIndexParams) bindPageSize(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("pageSize", "query", "int64", raw)
+	}
+	o.PageSize = &value
+
+	if err := o.validatePageSize(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePageSize carries on validations for parameter PageSize
+func (o *SearchIndexParams) validatePageSize(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("pageSize", "query", *o.PageSize, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindPageToken binds and validates parameter PageToken from query.
+func (o *SearchIndexParams) bindPageToken(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.PageToken = &raw
+
+	return nil
+}
@@ -37,6 +37,10 @@ const SearchIndexOKCode int = 200
 swagger:response searchIndexOK
 */
 type SearchIndexOK struct {
+	/*token to retrieve the next page of results, absent on the last page
+
+	 */
+	NextPageToken string `json:"Next-Page-Token"`
 
 	/*
 	  In: Body
@@ -50,6 +54,17 @@ func NewSearchIndexOK() *SearchIndexOK {
 	return &SearchIndexOK{}
 }
 
+// WithNextPageToken adds the nextPageToken to the search index o k response
+func (o *SearchIndexOK) WithNextPageToken(nextPageToken string) *SearchIndexOK {
+	o.NextPageToken = nextPageToken
+	return o
+}
+
+// SetNextPageToken sets the nextPageToken to the search index o k response
+func (o *SearchIndexOK) SetNextPageToken(nextPageToken string) {
+	o.NextPageToken = nextPageToken
+}
+
 // WithPayload adds the payload to the search index o k response
 func (o *SearchIndexOK) WithPayload(payload []string) *SearchIndexOK {
 	o.Payload = payload
@@ -64,6 +79,13 @@ func (o *SearchIndexOK) SetPayload(payload []string) {
 // WriteResponse to the client
 func (o *SearchIndexOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header Next-Page-Token
+
+	nextPageToken := o.NextPageToken
+	if nextPageToken != "" {
+		rw.Header().Set("Next-Page-Token", nextPageToken)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
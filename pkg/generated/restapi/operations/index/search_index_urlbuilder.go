@@ -25,11 +25,20 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // SearchIndexURL generates an URL for the search index operation
 type SearchIndexURL struct {
+	IntegratedTimeFrom *int64
+	IntegratedTimeTo   *int64
+	PageSize           *int64
+	PageToken          *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -56,6 +65,42 @@ func (o *SearchIndexURL) Build() (*url.URL, error) {
 	_basePath := o._basePath
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var integratedTimeFromQ string
+	if o.IntegratedTimeFrom != nil {
+		integratedTimeFromQ = swag.FormatInt64(*o.IntegratedTimeFrom)
+	}
+	if integratedTimeFromQ != "" {
+		qs.Set("integratedTimeFrom", integratedTimeFromQ)
+	}
+
+	var integratedTimeToQ string
+	if o.IntegratedTimeTo != nil {
+		integratedTimeToQ = swag.FormatInt64(*o.IntegratedTimeTo)
+	}
+	if integratedTimeToQ != "" {
+		qs.Set("integratedTimeTo", integratedTimeToQ)
+	}
+
+	var pageSizeQ string
+	if o.PageSize != nil {
+		pageSizeQ = swag.FormatInt64(*o.PageSize)
+	}
+	if pageSizeQ != "" {
+		qs.Set("pageSize", pageSizeQ)
+	}
+
+	var pageTokenQ string
+	if o.PageToken != nil {
+		pageTokenQ = *o.PageToken
+	}
+	if pageTokenQ != "" {
+		qs.Set("pageToken", pageTokenQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
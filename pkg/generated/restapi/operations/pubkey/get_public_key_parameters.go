@@ -25,15 +25,25 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewGetPublicKeyParams creates a new GetPublicKeyParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewGetPublicKeyParams() GetPublicKeyParams {
 
-	return GetPublicKeyParams{}
+	var (
+		// initialize parameters with default values
+
+		historyDefault = bool(false)
+	)
+
+	return GetPublicKeyParams{
+		History: &historyDefault,
+	}
 }
 
 // GetPublicKeyParams contains all the bound params for the get public key operation
@@ -44,6 +54,13 @@ type GetPublicKeyParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*Return every key the log has signed with, current key first, so that entries signed before a key rotation can be verified. Each PEM block has a Log-ID header, the hex-encoded SHA256 digest of the DER-encoded key found in the logID of the entries it signed, a Tree-ID header for the tree it signed, and Active-From and Active-Until headers in RFC 3339 format bounding when it was used.
+
+	  In: query
+	  Default: false
+	*/
+	History *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -55,8 +72,38 @@ func (o *GetPublicKeyParams) BindRequest(r *http.Request, route *middleware.Matc
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qHistory, qhkHistory, _ := qs.GetOK("history")
+	if err := o.bindHistory(qHistory, qhkHistory, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindHistory binds and validates parameter History from query.
+func (o *GetPublicKeyParams) bindHistory(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetPublicKeyParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("history", "query", "bool", raw)
+	}
+	o.History = &value
+
+	return nil
+}
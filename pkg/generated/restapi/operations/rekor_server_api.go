@@ -37,6 +37,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/attestations"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/pubkey"
@@ -66,6 +67,7 @@ func NewRekorServerAPI(spec *loads.Document) *RekorServerAPI {
 			return errors.NotImplemented("applicationTimestampQuery consumer has not yet been implemented")
 		}),
 		JSONConsumer: runtime.JSONConsumer(),
+		TxtConsumer:  runtime.TextConsumer(),
 		YamlConsumer: yamlpc.YAMLConsumer(),
 
 		ApplicationPemCertificateChainProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
@@ -78,11 +80,27 @@ func NewRekorServerAPI(spec *loads.Document) *RekorServerAPI {
 			return errors.NotImplemented("applicationXPemFile producer has not yet been implemented")
 		}),
 		JSONProducer: runtime.JSONProducer(),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("textEventStream producer has not yet been implemented")
+		}),
+		TxtProducer:  runtime.TextProducer(),
 		YamlProducer: yamlpc.YAMLProducer(),
 
+		TlogAddCheckpointCosignaturesHandler: tlog.AddCheckpointCosignaturesHandlerFunc(func(params tlog.AddCheckpointCosignaturesParams) middleware.Responder {
+			return middleware.NotImplemented("operation tlog.AddCheckpointCosignatures has not yet been implemented")
+		}),
+		EntriesCreateLogEntriesHandler: entries.CreateLogEntriesHandlerFunc(func(params entries.CreateLogEntriesParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.CreateLogEntries has not yet been implemented")
+		}),
 		EntriesCreateLogEntryHandler: entries.CreateLogEntryHandlerFunc(func(params entries.CreateLogEntryParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.CreateLogEntry has not yet been implemented")
 		}),
+		AttestationsGetAttestationByHashHandler: attestations.GetAttestationByHashHandlerFunc(func(params attestations.GetAttestationByHashParams) middleware.Responder {
+			return middleware.NotImplemented("operation attestations.GetAttestationByHash has not yet been implemented")
+		}),
+		TlogGetCheckpointHandler: tlog.GetCheckpointHandlerFunc(func(params tlog.GetCheckpointParams) middleware.Responder {
+			return middleware.NotImplemented("operation tlog.GetCheckpoint has not yet been implemented")
+		}),
 		EntriesGetLogEntryByIndexHandler: entries.GetLogEntryByIndexHandlerFunc(func(params entries.GetLogEntryByIndexParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.GetLogEntryByIndex has not yet been implemented")
 		}),
@@ -104,12 +122,18 @@ func NewRekorServerAPI(spec *loads.Document) *RekorServerAPI {
 		TimestampGetTimestampResponseHandler: timestamp.GetTimestampResponseHandlerFunc(func(params timestamp.GetTimestampResponseParams) middleware.Responder {
 			return middleware.NotImplemented("operation timestamp.GetTimestampResponse has not yet been implemented")
 		}),
+		AttestationsSearchAttestationsHandler: attestations.SearchAttestationsHandlerFunc(func(params attestations.SearchAttestationsParams) middleware.Responder {
+			return middleware.NotImplemented("operation attestations.SearchAttestations has not yet been implemented")
+		}),
 		IndexSearchIndexHandler: index.SearchIndexHandlerFunc(func(params index.SearchIndexParams) middleware.Responder {
 			return middleware.NotImplemented("operation index.SearchIndex has not yet been implemented")
 		}),
 		EntriesSearchLogQueryHandler: entries.SearchLogQueryHandlerFunc(func(params entries.SearchLogQueryParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.SearchLogQuery has not yet been implemented")
 		}),
+		EntriesStreamLogEntriesHandler: entries.StreamLogEntriesHandlerFunc(func(params entries.StreamLogEntriesParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.StreamLogEntries has not yet been implemented")
+		}),
 	}
 }
 
@@ -144,6 +168,9 @@ type RekorServerAPI struct {
 	// JSONConsumer registers a consumer for the following mime types:
 	//   - application/json
 	JSONConsumer runtime.Consumer
+	// TxtConsumer registers a consumer for the following mime types:
+	//   - text/plain
+	TxtConsumer runtime.Consumer
 	// YamlConsumer registers a consumer for the following mime types:
 	//   - application/yaml
 	YamlConsumer runtime.Consumer
@@ -160,12 +187,26 @@ type RekorServerAPI struct {
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
+	// TextEventStreamProducer registers a producer for the following mime types:
+	//   - text/event-stream
+	TextEventStreamProducer runtime.Producer
+	// TxtProducer registers a producer for the following mime types:
+	//   - text/plain
+	TxtProducer runtime.Producer
 	// YamlProducer registers a producer for the following mime types:
 	//   - application/yaml
 	YamlProducer runtime.Producer
 
+	// TlogAddCheckpointCosignaturesHandler sets the operation handler for the add checkpoint cosignatures operation
+	TlogAddCheckpointCosignaturesHandler tlog.AddCheckpointCosignaturesHandler
+	// EntriesCreateLogEntriesHandler sets the operation handler for the create log entries operation
+	EntriesCreateLogEntriesHandler entries.CreateLogEntriesHandler
 	// EntriesCreateLogEntryHandler sets the operation handler for the create log entry operation
 	EntriesCreateLogEntryHandler entries.CreateLogEntryHandler
+	// AttestationsGetAttestationByHashHandler sets the operation handler for the get attestation by hash operation
+	AttestationsGetAttestationByHashHandler attestations.GetAttestationByHashHandler
+	// TlogGetCheckpointHandler sets the operation handler for the get checkpoint operation
+	TlogGetCheckpointHandler tlog.GetCheckpointHandler
 	// EntriesGetLogEntryByIndexHandler sets the operation handler for the get log entry by index operation
 	EntriesGetLogEntryByIndexHandler entries.GetLogEntryByIndexHandler
 	// EntriesGetLogEntryByUUIDHandler sets the operation handler for the get log entry by UUID operation
@@ -180,10 +221,14 @@ type RekorServerAPI struct {
 	TimestampGetTimestampCertChainHandler timestamp.GetTimestampCertChainHandler
 	// TimestampGetTimestampResponseHandler sets the operation handler for the get timestamp response operation
 	TimestampGetTimestampResponseHandler timestamp.GetTimestampResponseHandler
+	// AttestationsSearchAttestationsHandler sets the operation handler for the search attestations operation
+	AttestationsSearchAttestationsHandler attestations.SearchAttestationsHandler
 	// IndexSearchIndexHandler sets the operation handler for the search index operation
 	IndexSearchIndexHandler index.SearchIndexHandler
 	// EntriesSearchLogQueryHandler sets the operation handler for the search log query operation
 	EntriesSearchLogQueryHandler entries.SearchLogQueryHandler
+	// EntriesStreamLogEntriesHandler sets the operation handler for the stream log entries operation
+	EntriesStreamLogEntriesHandler entries.StreamLogEntriesHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -259,6 +304,9 @@ func (o *RekorServerAPI) Validate() error {
 	if o.JSONConsumer == nil {
 		unregistered = append(unregistered, "JSONConsumer")
 	}
+	if o.TxtConsumer == nil {
+		unregistered = append(unregistered, "TxtConsumer")
+	}
 	if o.YamlConsumer == nil {
 		unregistered = append(unregistered, "YamlConsumer")
 	}
@@ -275,13 +323,31 @@ func (o *RekorServerAPI) Validate() error {
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
+	if o.TxtProducer == nil {
+		unregistered = append(unregistered, "TxtProducer")
+	}
 	if o.YamlProducer == nil {
 		unregistered = append(unregistered, "YamlProducer")
 	}
 
+	if o.TlogAddCheckpointCosignaturesHandler == nil {
+		unregistered = append(unregistered, "tlog.AddCheckpointCosignaturesHandler")
+	}
+	if o.EntriesCreateLogEntriesHandler == nil {
+		unregistered = append(unregistered, "entries.CreateLogEntriesHandler")
+	}
 	if o.EntriesCreateLogEntryHandler == nil {
 		unregistered = append(unregistered, "entries.CreateLogEntryHandler")
 	}
+	if o.AttestationsGetAttestationByHashHandler == nil {
+		unregistered = append(unregistered, "attestations.GetAttestationByHashHandler")
+	}
+	if o.TlogGetCheckpointHandler == nil {
+		unregistered = append(unregistered, "tlog.GetCheckpointHandler")
+	}
 	if o.EntriesGetLogEntryByIndexHandler == nil {
 		unregistered = append(unregistered, "entries.GetLogEntryByIndexHandler")
 	}
@@ -303,12 +369,18 @@ func (o *RekorServerAPI) Validate() error {
 	if o.TimestampGetTimestampResponseHandler == nil {
 		unregistered = append(unregistered, "timestamp.GetTimestampResponseHandler")
 	}
+	if o.AttestationsSearchAttestationsHandler == nil {
+		unregistered = append(unregistered, "attestations.SearchAttestationsHandler")
+	}
 	if o.IndexSearchIndexHandler == nil {
 		unregistered = append(unregistered, "index.SearchIndexHandler")
 	}
 	if o.EntriesSearchLogQueryHandler == nil {
 		unregistered = append(unregistered, "entries.SearchLogQueryHandler")
 	}
+	if o.EntriesStreamLogEntriesHandler == nil {
+		unregistered = append(unregistered, "entries.StreamLogEntriesHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -342,6 +414,8 @@ func (o *RekorServerAPI) ConsumersFor(mediaTypes []string) map[string]runtime.Co
 			result["application/timestamp-query"] = o.ApplicationTimestampQueryConsumer
 		case "application/json":
 			result["application/json"] = o.JSONConsumer
+		case "text/plain":
+			result["text/plain"] = o.TxtConsumer
 		case "application/yaml":
 			result["application/yaml"] = o.YamlConsumer
 		}
@@ -367,6 +441,10 @@ func (o *RekorServerAPI) ProducersFor(mediaTypes []string) map[string]runtime.Pr
 			result["application/x-pem-file"] = o.ApplicationXPemFileProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
+		case "text/plain":
+			result["text/plain"] = o.TxtProducer
 		case "application/yaml":
 			result["application/yaml"] = o.YamlProducer
 		}
@@ -409,6 +487,14 @@ func (o *RekorServerAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/log/checkpoint"] = tlog.NewAddCheckpointCosignatures(o.context, o.TlogAddCheckpointCosignaturesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/log/entries/batch"] = entries.NewCreateLogEntries(o.context, o.EntriesCreateLogEntriesHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -416,6 +502,14 @@ func (o *RekorServerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/api/v1/attestations/{attestationHash}"] = attestations.NewGetAttestationByHash(o.context, o.AttestationsGetAttestationByHashHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v1/log/checkpoint"] = tlog.NewGetCheckpoint(o.context, o.TlogGetCheckpointHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/api/v1/log/entries"] = entries.NewGetLogEntryByIndex(o.context, o.EntriesGetLogEntryByIndexHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -441,6 +535,10 @@ func (o *RekorServerAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/api/v1/timestamp"] = timestamp.NewGetTimestampResponse(o.context, o.TimestampGetTimestampResponseHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v1/attestations"] = attestations.NewSearchAttestations(o.context, o.AttestationsSearchAttestationsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -449,6 +547,10 @@ func (o *RekorServerAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/api/v1/log/entries/retrieve"] = entries.NewSearchLogQuery(o.context, o.EntriesSearchLogQueryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v1/log/entries/stream"] = entries.NewStreamLogEntries(o.context, o.EntriesStreamLogEntriesHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
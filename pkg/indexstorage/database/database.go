@@ -0,0 +1,191 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	// Blank imports to register the database drivers
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+// Supported databases, by driver name
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+)
+
+// dialect holds the statements that differ between the supported databases
+type dialect struct {
+	lookup string
+	insert string
+	// migrations are applied in order; the number of the last one applied is kept in schema_migrations
+	migrations []string
+}
+
+var dialects = map[string]dialect{
+	MySQL: {
+		lookup: "SELECT entry_uuid FROM entry_index WHERE index_key = ? ORDER BY id DESC",
+		insert: "INSERT IGNORE INTO entry_index (index_key, entry_uuid) VALUES (?, ?)",
+		migrations: []string{
+			`CREATE TABLE IF NOT EXISTS entry_index (
+				id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+				index_key VARCHAR(512) NOT NULL,
+				entry_uuid VARCHAR(128) NOT NULL,
+				UNIQUE KEY entry_index_key_uuid (index_key, entry_uuid)
+			)`,
+		},
+	},
+	Postgres: {
+		lookup: "SELECT entry_uuid FROM entry_index WHERE index_key = $1 ORDER BY id DESC",
+		insert: "INSERT INTO entry_index (index_key, entry_uuid) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		migrations: []string{
+			`CREATE TABLE IF NOT EXISTS entry_index (
+				id BIGSERIAL PRIMARY KEY,
+				index_key TEXT NOT NULL,
+				entry_uuid TEXT NOT NULL,
+				UNIQUE (index_key, entry_uuid)
+			)`,
+		},
+	},
+}
+
+// IndexStorageProvider keeps the search index in a MySQL or PostgreSQL table, one row per key and entry
+type IndexStorageProvider struct {
+	db      *sql.DB
+	dialect dialect
+}
+
+// NewProvider connects to the database and brings its schema up to date
+func NewProvider(ctx context.Context, driver, dsn string) (*IndexStorageProvider, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("search_index.%s.dsn must be set", driver)
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	isp, err := newProvider(ctx, db, driver)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return isp, nil
+}
+
+func newProvider(ctx context.Context, db *sql.DB, driver string) (*IndexStorageProvider, error) {
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database %v", driver)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", driver, err)
+	}
+	isp := &IndexStorageProvider{db: db, dialect: d}
+	if err := isp.migrate(ctx, driver); err != nil {
+		return nil, fmt.Errorf("migrating %s schema: %w", driver, err)
+	}
+	return isp, nil
+}
+
+// migrate applies the migrations that have not been applied yet; concurrent rekor-server instances
+// are serialized with a database lock so that each migration runs once
+func (isp *IndexStorageProvider) migrate(ctx context.Context, driver string) (err error) {
+	conn, err := isp.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch driver {
+	case MySQL:
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK('rekor_index_migrations', 60)").Scan(&acquired); err != nil {
+			return err
+		}
+		if acquired.Int64 != 1 {
+			return errors.New("timed out waiting for the migration lock")
+		}
+		defer func() {
+			if _, rerr := conn.ExecContext(ctx, "SELECT RELEASE_LOCK('rekor_index_migrations')"); rerr != nil && err == nil {
+				err = rerr
+			}
+		}()
+	case Postgres:
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext('rekor_index_migrations'))"); err != nil {
+			return err
+		}
+		defer func() {
+			if _, rerr := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext('rekor_index_migrations'))"); rerr != nil && err == nil {
+				err = rerr
+			}
+		}()
+	}
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY)"); err != nil {
+		return err
+	}
+	var version sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return err
+	}
+	if int(version.Int64) > len(isp.dialect.migrations) {
+		return fmt.Errorf("database schema version %d is newer than this rekor-server supports (%d)", version.Int64, len(isp.dialect.migrations))
+	}
+	for i := int(version.Int64); i < len(isp.dialect.migrations); i++ {
+		if _, err := conn.ExecContext(ctx, isp.dialect.migrations[i]); err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		// the version is a constant, so the placeholder syntax of the dialect is not needed
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("INSERT INTO schema_migrations (version) VALUES (%d)", i+1)); err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (isp *IndexStorageProvider) LookupIndices(ctx context.Context, key string) ([]string, error) {
+	rows, err := isp.db.QueryContext(ctx, isp.dialect.lookup, strings.ToLower(key))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var uuid string
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		result = append(result, uuid)
+	}
+	return result, rows.Err()
+}
+
+func (isp *IndexStorageProvider) WriteIndex(ctx context.Context, key, uuid string) error {
+	_, err := isp.db.ExecContext(ctx, isp.dialect.insert, key, uuid)
+	return err
+}
+
+// Close closes the connections to the database
+func (isp *IndexStorageProvider) Close() error {
+	return isp.db.Close()
+}
@@ -0,0 +1,139 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func expectMigrations(mock sqlmock.Sqlmock, driver string, from int64) {
+	switch driver {
+	case MySQL:
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK")).WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	case Postgres:
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock")).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+	version := sqlmock.NewRows([]string{"version"})
+	if from == 0 {
+		version.AddRow(nil)
+	} else {
+		version.AddRow(from)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(version) FROM schema_migrations")).WillReturnRows(version)
+	for i := from; i < int64(len(dialects[driver].migrations)); i++ {
+		mock.ExpectExec(regexp.QuoteMeta(dialects[driver].migrations[i])).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	switch driver {
+	case MySQL:
+		mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK")).WillReturnResult(sqlmock.NewResult(0, 0))
+	case Postgres:
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock")).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+}
+
+func TestProvider(t *testing.T) {
+	for _, driver := range []string{MySQL, Postgres} {
+		t.Run(driver, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			ctx := context.Background()
+
+			mock.ExpectPing()
+			expectMigrations(mock, driver, 0)
+			isp, err := newProvider(ctx, db, driver)
+			if err != nil {
+				t.Fatalf("newProvider: %v", err)
+			}
+
+			mock.ExpectExec(regexp.QuoteMeta(dialects[driver].insert)).
+				WithArgs("sha256:abcd", "uuid1").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			if err := isp.WriteIndex(ctx, "sha256:abcd", "uuid1"); err != nil {
+				t.Fatalf("WriteIndex: %v", err)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(dialects[driver].lookup)).
+				WithArgs("user@example.com").
+				WillReturnRows(sqlmock.NewRows([]string{"entry_uuid"}).AddRow("uuid2").AddRow("uuid1"))
+			got, err := isp.LookupIndices(ctx, "User@Example.com")
+			if err != nil {
+				t.Fatalf("LookupIndices: %v", err)
+			}
+			if want := []string{"uuid2", "uuid1"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("LookupIndices = %v, want %v", got, want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestMigrateUpToDate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	expectMigrations(mock, Postgres, int64(len(dialects[Postgres].migrations)))
+	isp := &IndexStorageProvider{db: db, dialect: dialects[Postgres]}
+	if err := isp.migrate(context.Background(), Postgres); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrateNewerSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK")).WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(version) FROM schema_migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(len(dialects[MySQL].migrations) + 1))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	isp := &IndexStorageProvider{db: db, dialect: dialects[MySQL]}
+	if err := isp.migrate(context.Background(), MySQL); err == nil {
+		t.Error("migrate succeeded on a newer schema, want error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewProviderWithoutDSN(t *testing.T) {
+	if _, err := NewProvider(context.Background(), MySQL, ""); err == nil {
+		t.Error("NewProvider succeeded without a DSN, want error")
+	}
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/indexstorage/database"
+	"github.com/sigstore/rekor/pkg/indexstorage/redis"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// IndexStorage maps search keys (artifact hashes, public key hashes and email addresses) to entry UUIDs
+type IndexStorage interface {
+	// LookupIndices returns the UUIDs of the entries indexed under key, most recent first
+	LookupIndices(ctx context.Context, key string) ([]string, error)
+	// WriteIndex adds the entry uuid under key
+	WriteIndex(ctx context.Context, key, uuid string) error
+}
+
+// NewIndexStorage connects to the search index backend selected by search_index.storage_provider
+func NewIndexStorage(ctx context.Context, providerType string) (IndexStorage, error) {
+	backend, err := newBackend(ctx, providerType)
+	if err != nil {
+		return nil, err
+	}
+	return traced{backend: backend, provider: providerType}, nil
+}
+
+func newBackend(ctx context.Context, providerType string) (IndexStorage, error) {
+	switch providerType {
+	case "redis":
+		addresses := viper.GetStringSlice("redis_server.addresses")
+		if len(addresses) == 0 {
+			addresses = []string{fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port"))}
+		}
+		log.Named(log.SubsystemIndex).Infof("Configuring Redis search index at %v", addresses)
+		return redis.NewProvider(ctx, redis.Config{
+			Mode:           viper.GetString("redis_server.mode"),
+			Addresses:      addresses,
+			SentinelMaster: viper.GetString("redis_server.sentinel_master"),
+			Username:       viper.GetString("redis_server.username"),
+			Password:       viper.GetString("redis_server.password"),
+			EnableTLS:      viper.GetBool("redis_server.enable_tls"),
+			TLSCACert:      viper.GetString("redis_server.tls_ca_cert"),
+		})
+	case database.MySQL, database.Postgres:
+		log.Named(log.SubsystemIndex).Infof("Configuring %s search index", providerType)
+		return database.NewProvider(ctx, providerType, viper.GetString(fmt.Sprintf("search_index.%s.dsn", providerType)))
+	default:
+		return nil, fmt.Errorf("invalid index storage provider type: %v", providerType)
+	}
+}
@@ -0,0 +1,140 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	radix "github.com/mediocregopher/radix/v4"
+)
+
+// Supported Redis deployments
+const (
+	Standalone = "standalone"
+	Sentinel   = "sentinel"
+	Cluster    = "cluster"
+)
+
+// Config describes how to reach the Redis deployment holding the search index
+type Config struct {
+	Mode string
+	// Addresses are host:port pairs: the server in standalone mode, the sentinels in sentinel mode
+	// and the seed nodes in cluster mode
+	Addresses []string
+	// SentinelMaster is the name of the monitored primary in sentinel mode
+	SentinelMaster string
+	// Username and Password are sent with AUTH to every server, including the sentinels
+	Username, Password string
+	EnableTLS          bool
+	// TLSCACert is a PEM bundle of CAs trusted for the servers' certificates, instead of the system roots
+	TLSCACert string
+}
+
+// IndexStorageProvider keeps each search key as a Redis list of entry UUIDs
+type IndexStorageProvider struct {
+	client radix.MultiClient
+}
+
+func NewProvider(ctx context.Context, cfg Config) (*IndexStorageProvider, error) {
+	if len(cfg.Addresses) == 0 {
+		return nil, errors.New("no Redis server address configured")
+	}
+	dialer, err := newDialer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	poolCfg := radix.PoolConfig{Dialer: dialer}
+
+	var client radix.MultiClient
+	switch cfg.Mode {
+	case Standalone, "":
+		if len(cfg.Addresses) != 1 {
+			return nil, fmt.Errorf("standalone mode takes a single Redis server address, got %d", len(cfg.Addresses))
+		}
+		var pool radix.Client
+		if pool, err = poolCfg.New(ctx, "tcp", cfg.Addresses[0]); err == nil {
+			client = radix.NewMultiClient(radix.ReplicaSet{Primary: pool})
+		}
+	case Sentinel:
+		if cfg.SentinelMaster == "" {
+			return nil, errors.New("sentinel mode requires the name of the primary")
+		}
+		client, err = radix.SentinelConfig{PoolConfig: poolCfg, SentinelDialer: dialer}.New(ctx, cfg.SentinelMaster, cfg.Addresses)
+	case Cluster:
+		client, err = radix.ClusterConfig{PoolConfig: poolCfg}.New(ctx, cfg.Addresses)
+	default:
+		return nil, fmt.Errorf("invalid Redis mode: %v", cfg.Mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &IndexStorageProvider{client: client}, nil
+}
+
+func newDialer(cfg Config) (radix.Dialer, error) {
+	d := radix.Dialer{
+		AuthUser: cfg.Username,
+		AuthPass: cfg.Password,
+	}
+	if cfg.Username != "" && cfg.Password == "" {
+		return d, errors.New("a Redis username requires a password")
+	}
+	if !cfg.EnableTLS {
+		if cfg.TLSCACert != "" {
+			return d, errors.New("a Redis CA certificate is only used with TLS enabled")
+		}
+		return d, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSCACert != "" {
+		pem, err := ioutil.ReadFile(filepath.Clean(cfg.TLSCACert))
+		if err != nil {
+			return d, fmt.Errorf("reading Redis CA certificate: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return d, errors.New("no certificates found in Redis CA certificate file")
+		}
+		tlsConfig.RootCAs = roots
+	}
+	// the server name is taken from each address, so cluster nodes and sentinel-discovered primaries are verified too
+	d.NetDialer = &tls.Dialer{Config: tlsConfig}
+	return d, nil
+}
+
+func (isp *IndexStorageProvider) LookupIndices(ctx context.Context, key string) ([]string, error) {
+	var result []string
+	if err := isp.client.Do(ctx, radix.Cmd(&result, "LRANGE", strings.ToLower(key), "0", "-1")); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (isp *IndexStorageProvider) WriteIndex(ctx context.Context, key, uuid string) error {
+	return isp.client.Do(ctx, radix.Cmd(nil, "LPUSH", key, uuid))
+}
+
+// Close closes the connections to the Redis servers
+func (isp *IndexStorageProvider) Close() error {
+	return isp.client.Close()
+}
@@ -0,0 +1,240 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis serves LPUSH and LRANGE over TLS to clients that have authenticated with password
+type fakeRedis struct {
+	password string
+	mu       sync.Mutex
+	lists    map[string][]string
+}
+
+func (f *fakeRedis) serve(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authenticated := false
+	for {
+		cmd, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		var reply string
+		switch {
+		case strings.EqualFold(cmd[0], "AUTH"):
+			if cmd[len(cmd)-1] == f.password {
+				authenticated = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid username-password pair\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case strings.EqualFold(cmd[0], "PING"):
+			reply = "+PONG\r\n"
+		case strings.EqualFold(cmd[0], "LPUSH"):
+			f.mu.Lock()
+			f.lists[cmd[1]] = append([]string{cmd[2]}, f.lists[cmd[1]]...)
+			reply = fmt.Sprintf(":%d\r\n", len(f.lists[cmd[1]]))
+			f.mu.Unlock()
+		case strings.EqualFold(cmd[0], "LRANGE"):
+			f.mu.Lock()
+			reply = fmt.Sprintf("*%d\r\n", len(f.lists[cmd[1]]))
+			for _, v := range f.lists[cmd[1]] {
+				reply += fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			}
+			f.mu.Unlock()
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	cmd := make([]string, n)
+	for i := range cmd {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		cmd[i] = string(buf[:size])
+	}
+	return cmd, nil
+}
+
+// startTLSServer returns the address of a fake Redis server and the path to its CA certificate
+func startTLSServer(t *testing.T, password string) (string, string) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: priv}},
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go (&fakeRedis{password: password, lists: map[string][]string{}}).serve(l)
+
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	return net.JoinHostPort("localhost", port), caPath
+}
+
+func TestTLSWithAuth(t *testing.T) {
+	addr, caPath := startTLSServer(t, "secret")
+	ctx := context.Background()
+
+	isp, err := NewProvider(ctx, Config{
+		Addresses: []string{addr},
+		Password:  "secret",
+		EnableTLS: true,
+		TLSCACert: caPath,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	for _, uuid := range []string{"uuid1", "uuid2"} {
+		if err := isp.WriteIndex(ctx, "user@example.com", uuid); err != nil {
+			t.Fatalf("WriteIndex: %v", err)
+		}
+	}
+	got, err := isp.LookupIndices(ctx, "User@Example.com")
+	if err != nil {
+		t.Fatalf("LookupIndices: %v", err)
+	}
+	if want := []string{"uuid2", "uuid1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LookupIndices = %v, want %v", got, want)
+	}
+}
+
+func TestTLSUntrustedServer(t *testing.T) {
+	addr, _ := startTLSServer(t, "secret")
+	if _, err := NewProvider(context.Background(), Config{
+		Addresses: []string{addr},
+		Password:  "secret",
+		EnableTLS: true,
+	}); err == nil {
+		t.Error("NewProvider succeeded with a server certificate not signed by a trusted CA")
+	}
+}
+
+func TestWrongPassword(t *testing.T) {
+	addr, caPath := startTLSServer(t, "secret")
+	if _, err := NewProvider(context.Background(), Config{
+		Addresses: []string{addr},
+		Password:  "wrong",
+		EnableTLS: true,
+		TLSCACert: caPath,
+	}); err == nil {
+		t.Error("NewProvider succeeded with the wrong password")
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		cfg  Config
+	}{
+		{desc: "no address", cfg: Config{}},
+		{desc: "standalone with several addresses", cfg: Config{Addresses: []string{"a:6379", "b:6379"}}},
+		{desc: "sentinel without primary", cfg: Config{Mode: Sentinel, Addresses: []string{"a:26379"}}},
+		{desc: "unknown mode", cfg: Config{Mode: "replica", Addresses: []string{"a:6379"}}},
+		{desc: "username without password", cfg: Config{Username: "rekor", Addresses: []string{"a:6379"}}},
+		{desc: "CA without TLS", cfg: Config{TLSCACert: "ca.pem", Addresses: []string{"a:6379"}}},
+		{desc: "missing CA", cfg: Config{EnableTLS: true, TLSCACert: "/nonexistent/ca.pem", Addresses: []string{"a:6379"}}},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := NewProvider(context.Background(), test.cfg); err == nil {
+				t.Errorf("NewProvider(%+v) succeeded, want error", test.cfg)
+			}
+		})
+	}
+}
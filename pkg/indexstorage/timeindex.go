@@ -0,0 +1,77 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexstorage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entries are also indexed under each of their keys for the UTC day they were integrated, so that a search
+// limited to a time window only reads the days it covers. The values of these keys hold the integrated time
+// as well as the UUID, so that the days at either end of the window can be filtered without reading the log.
+const (
+	timeBucket = 24 * time.Hour
+	// MaxTimeWindow bounds the number of days read by a single search
+	MaxTimeWindow = 366 * timeBucket
+)
+
+func timeBucketKey(key string, t time.Time) string {
+	return fmt.Sprintf("integrated:%s:%s", t.UTC().Format("2006-01-02"), key)
+}
+
+// WriteTimeIndex adds the entry uuid, integrated at integratedTime in seconds since the epoch, under key for
+// the day it was integrated
+func WriteTimeIndex(ctx context.Context, is IndexStorage, key, uuid string, integratedTime int64) error {
+	return is.WriteIndex(ctx, timeBucketKey(key, time.Unix(integratedTime, 0)), fmt.Sprintf("%d:%s", integratedTime, uuid))
+}
+
+// LookupTimeRange returns the UUIDs of the entries indexed under key that were integrated between from and to,
+// inclusive, in seconds since the epoch. Only entries indexed with WriteTimeIndex are found.
+func LookupTimeRange(ctx context.Context, is IndexStorage, key string, from, to int64) ([]string, error) {
+	start, end := time.Unix(from, 0).UTC(), time.Unix(to, 0).UTC()
+	if end.Before(start) {
+		return nil, fmt.Errorf("end of time window %d is before its start %d", to, from)
+	}
+	if end.Sub(start) > MaxTimeWindow {
+		return nil, fmt.Errorf("time window is longer than %d days", MaxTimeWindow/timeBucket)
+	}
+
+	var result []string
+	for day := start.Truncate(timeBucket); !day.After(end); day = day.Add(timeBucket) {
+		values, err := is.LookupIndices(ctx, timeBucketKey(key, day))
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			i := strings.IndexByte(v, ':')
+			if i < 0 {
+				return nil, fmt.Errorf("malformed time index value %q", v)
+			}
+			t, err := strconv.ParseInt(v[:i], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed time index value %q", v)
+			}
+			if t >= from && t <= to {
+				result = append(result, v[i+1:])
+			}
+		}
+	}
+	return result, nil
+}
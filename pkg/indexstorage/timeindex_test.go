@@ -0,0 +1,98 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexstorage
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryIndex struct {
+	keys    map[string][]string
+	lookups int
+}
+
+func (m *memoryIndex) LookupIndices(_ context.Context, key string) ([]string, error) {
+	m.lookups++
+	return m.keys[strings.ToLower(key)], nil
+}
+
+func (m *memoryIndex) WriteIndex(_ context.Context, key, uuid string) error {
+	m.keys[key] = append(m.keys[key], uuid)
+	return nil
+}
+
+func TestLookupTimeRange(t *testing.T) {
+	ctx := context.Background()
+	is := &memoryIndex{keys: map[string][]string{}}
+
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC).Unix()
+	written := map[string]int64{
+		"a": day - 1,
+		"b": day,
+		"c": day + 3600,
+		"d": day + 86400 + 10,
+		"e": day + 3*86400,
+	}
+	for uuid, integratedTime := range written {
+		if err := WriteTimeIndex(ctx, is, "user@example.com", uuid, integratedTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := WriteTimeIndex(ctx, is, "other@example.com", "f", day); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to int64
+		want     []string
+		lookups  int
+	}{
+		{name: "one day", from: day, to: day + 86399, want: []string{"b", "c"}, lookups: 1},
+		{name: "bounds are inclusive", from: day - 1, to: day + 86400 + 10, want: []string{"a", "b", "c", "d"}, lookups: 3},
+		{name: "part of a day", from: day + 1, to: day + 3600, want: []string{"c"}, lookups: 1},
+		{name: "several days", from: day, to: day + 3*86400, want: []string{"b", "c", "d", "e"}, lookups: 4},
+		{name: "empty", from: day + 2*86400, to: day + 2*86400 + 100, lookups: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is.lookups = 0
+			got, err := LookupTimeRange(ctx, is, "user@example.com", tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if is.lookups != tt.lookups {
+				t.Errorf("read %d days, want %d", is.lookups, tt.lookups)
+			}
+		})
+	}
+
+	if _, err := LookupTimeRange(ctx, is, "user@example.com", day, day-1); err == nil {
+		t.Error("expected an error for a window that ends before it starts")
+	}
+	if _, err := LookupTimeRange(ctx, is, "user@example.com", day, day+int64(MaxTimeWindow/time.Second)+1); err == nil {
+		t.Error("expected an error for a window that is too long")
+	}
+}
@@ -0,0 +1,53 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexstorage
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sigstore/rekor/pkg/tracing"
+)
+
+// traced records a span for each call to the search index backend
+type traced struct {
+	backend  IndexStorage
+	provider string
+}
+
+func (t traced) LookupIndices(ctx context.Context, key string) (result []string, err error) {
+	ctx, span := tracing.Start(ctx, "indexstorage.LookupIndices", attribute.String("rekor.index.provider", t.provider))
+	defer func() { tracing.End(span, err) }()
+	result, err = t.backend.LookupIndices(ctx, key)
+	span.SetAttributes(attribute.Int("rekor.index.results", len(result)))
+	return result, err
+}
+
+func (t traced) WriteIndex(ctx context.Context, key, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "indexstorage.WriteIndex", attribute.String("rekor.index.provider", t.provider))
+	defer func() { tracing.End(span, err) }()
+	return t.backend.WriteIndex(ctx, key, uuid)
+}
+
+// Close closes the backend, if it holds connections
+func (t traced) Close() error {
+	if c, ok := t.backend.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
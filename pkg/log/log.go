@@ -17,8 +17,11 @@ package log
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/go-chi/chi/middleware"
 	"go.uber.org/zap"
@@ -42,13 +45,128 @@ func ConfigureLogger(logType string) {
 		cfg = zap.NewDevelopmentConfig()
 		cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
-	logger, err := cfg.Build()
+	levels.reset(cfg.Level.Level())
+	// the levels of the subsystems are applied by levelCore, which may be more verbose than the default
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	logger, err := cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, levels: &levels}
+	}))
 	if err != nil {
 		log.Fatalln("createLogger", err)
 	}
 	Logger = logger.Sugar()
 }
 
+// Subsystems whose log level can be set separately with SetLevels; everything else logs at the default level
+const (
+	SubsystemHTTP     = "http"
+	SubsystemTrillian = "trillian"
+	SubsystemIndex    = "index"
+	SubsystemStorage  = "storage"
+	SubsystemNotify   = "notify"
+	SubsystemTileLog  = "tilelog"
+)
+
+// Named returns the logger of subsystem
+func Named(subsystem string) *zap.SugaredLogger {
+	return Logger.Named(subsystem)
+}
+
+// subsystemLevels is the minimum level logged by each subsystem
+type subsystemLevels struct {
+	mu         sync.RWMutex
+	def        zapcore.Level
+	subsystems map[string]zapcore.Level
+}
+
+var levels subsystemLevels
+
+func (l *subsystemLevels) reset(def zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.def = def
+	l.subsystems = nil
+}
+
+// forLogger returns the level of the subsystem of the logger named name, i.e. the first part of its name
+func (l *subsystemLevels) forLogger(name string) zapcore.Level {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if level, ok := l.subsystems[name]; ok {
+		return level
+	}
+	return l.def
+}
+
+// lowest returns the most verbose level logged by any subsystem
+func (l *subsystemLevels) lowest() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	lowest := l.def
+	for _, level := range l.subsystems {
+		if level < lowest {
+			lowest = level
+		}
+	}
+	return lowest
+}
+
+// SetLevels sets the log levels given as "level", for the default level, or "subsystem=level", e.g.
+// "info", "trillian=debug". Levels not given keep the default of the logger type.
+func SetLevels(specs []string) error {
+	return levels.set(specs)
+}
+
+func (l *subsystemLevels) set(specs []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	def, subsystems := l.def, map[string]zapcore.Level{}
+	for _, spec := range specs {
+		name, text := "", spec
+		if i := strings.IndexByte(spec, '='); i >= 0 {
+			name, text = spec[:i], spec[i+1:]
+			if name == "" {
+				return fmt.Errorf("log level %q has no subsystem", spec)
+			}
+		}
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("log level %q: %w", spec, err)
+		}
+		if name == "" {
+			def = level
+		} else {
+			subsystems[name] = level
+		}
+	}
+	l.def, l.subsystems = def, subsystems
+	return nil
+}
+
+// levelCore drops the entries below the level of the subsystem of the logger they were written to
+type levelCore struct {
+	zapcore.Core
+	levels *subsystemLevels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return level >= c.levels.lowest()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.levels.forLogger(ent.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
 var CliLogger = createCliLogger()
 
 func createCliLogger() *zap.SugaredLogger {
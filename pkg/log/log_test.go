@@ -0,0 +1,79 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSubsystemLevels(t *testing.T) {
+	l := &subsystemLevels{}
+	l.reset(zapcore.InfoLevel)
+	if err := l.set([]string{"warn", "trillian=debug", "http=error"}); err != nil {
+		t.Fatal(err)
+	}
+	observed, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(&levelCore{Core: observed, levels: l}).Sugar()
+
+	logger.Info("default info")
+	logger.Warn("default warn")
+	logger.Named(SubsystemTrillian).Debug("trillian debug")
+	logger.Named(SubsystemTrillian).Named("breaker").Debug("trillian breaker debug")
+	logger.Named(SubsystemHTTP).With("requestID", "1").Warn("http warn")
+	logger.Named(SubsystemHTTP).Error("http error")
+	logger.Named(SubsystemIndex).Info("index info")
+
+	var got []string
+	for _, e := range logs.All() {
+		got = append(got, e.Message)
+	}
+	want := []string{"default warn", "trillian debug", "trillian breaker debug", "http error"}
+	if len(got) != len(want) {
+		t.Fatalf("logged %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("logged %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSetLevelsInvalid(t *testing.T) {
+	for _, spec := range []string{"loud", "http=loud", "=debug"} {
+		l := &subsystemLevels{}
+		if err := l.set([]string{spec}); err == nil {
+			t.Errorf("set(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestSetLevelsKeepsDefault(t *testing.T) {
+	l := &subsystemLevels{}
+	l.reset(zapcore.InfoLevel)
+	if err := l.set([]string{"index=debug"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.forLogger("storage"); got != zapcore.InfoLevel {
+		t.Errorf("default level %v, want info", got)
+	}
+	if got := l.lowest(); got != zapcore.DebugLevel {
+		t.Errorf("lowest level %v, want debug", got)
+	}
+}
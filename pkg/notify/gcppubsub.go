@@ -0,0 +1,92 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"google.golang.org/api/option"
+	gtransport "google.golang.org/api/transport/grpc"
+	pb "google.golang.org/genproto/googleapis/pubsub/v1"
+	"google.golang.org/grpc"
+)
+
+const (
+	gcpPubSubScheme   = "gcppubsub"
+	gcpPubSubEndpoint = "pubsub.googleapis.com:443"
+	gcpPubSubScope    = "https://www.googleapis.com/auth/pubsub"
+)
+
+var gcpTopicRE = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+
+// gcpPublisher publishes messages to a GCP Pub/Sub topic, using application default credentials
+type gcpPublisher struct {
+	conn   *grpc.ClientConn
+	client pb.PublisherClient
+	topic  string
+}
+
+func newGCPPublisher(ctx context.Context, u *url.URL) (*gcpPublisher, error) {
+	// gcppubsub://projects/myproject/topics/mytopic
+	topic := u.Host + u.Path
+	if !gcpTopicRE.MatchString(topic) {
+		return nil, fmt.Errorf("invalid GCP Pub/Sub topic %q, expected projects/<project>/topics/<topic>", topic)
+	}
+	conn, err := gtransport.Dial(ctx, option.WithEndpoint(gcpPubSubEndpoint), option.WithScopes(gcpPubSubScope))
+	if err != nil {
+		return nil, err
+	}
+	return &gcpPublisher{
+		conn:   conn,
+		client: pb.NewPublisherClient(conn),
+		topic:  topic,
+	}, nil
+}
+
+func (p *gcpPublisher) Publish(ctx context.Context, n Notification) error {
+	msg, err := n.marshal()
+	if err != nil {
+		return err
+	}
+	// attributes allow subscriptions to filter messages without parsing them
+	_, err = p.client.Publish(ctx, &pb.PublishRequest{
+		Topic: p.topic,
+		Messages: []*pb.PubsubMessage{{
+			Data: msg,
+			Attributes: map[string]string{
+				"kind":       n.Kind,
+				"apiVersion": n.APIVersion,
+				"uuid":       n.UUID,
+				"logIndex":   strconv.FormatInt(n.LogIndex, 10),
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("publishing to %v: %w", p.topic, err)
+	}
+	return nil
+}
+
+func (p *gcpPublisher) Close() error {
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
@@ -0,0 +1,75 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	pb "google.golang.org/genproto/googleapis/pubsub/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakePublisherServer struct {
+	pb.UnimplementedPublisherServer
+	requests chan *pb.PublishRequest
+}
+
+func (s *fakePublisherServer) Publish(ctx context.Context, req *pb.PublishRequest) (*pb.PublishResponse, error) {
+	s.requests <- req
+	return &pb.PublishResponse{MessageIds: []string{"1"}}, nil
+}
+
+func TestGCPPublish(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	fake := &fakePublisherServer{requests: make(chan *pb.PublishRequest, 1)}
+	s := grpc.NewServer()
+	pb.RegisterPublisherServer(s, fake)
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &gcpPublisher{conn: conn, client: pb.NewPublisherClient(conn), topic: "projects/p/topics/t"}
+	defer p.Close()
+
+	n := Notification{Kind: "hashedrekord", APIVersion: "0.0.1", UUID: "abcd", LogIndex: 42}
+	if err := p.Publish(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	req := <-fake.requests
+	if req.Topic != "projects/p/topics/t" || len(req.Messages) != 1 {
+		t.Fatalf("unexpected request %v", req)
+	}
+	msg := req.Messages[0]
+	if msg.Attributes["kind"] != "hashedrekord" || msg.Attributes["logIndex"] != "42" || msg.Attributes["uuid"] != "abcd" {
+		t.Errorf("unexpected attributes %v", msg.Attributes)
+	}
+	var got Notification
+	if err := json.Unmarshal(msg.Data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.UUID != n.UUID || got.LogIndex != n.LogIndex {
+		t.Errorf("unexpected notification %+v", got)
+	}
+}
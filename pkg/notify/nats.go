@@ -0,0 +1,220 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+const (
+	natsScheme      = "nats"
+	natsDefaultPort = "4222"
+	natsTimeout     = 5 * time.Second
+)
+
+// natsPublisher publishes messages using the core NATS client protocol
+// (https://docs.nats.io/reference/reference-protocols/nats-protocol), reconnecting when needed
+type natsPublisher struct {
+	addr     string
+	user     string
+	password string
+	subject  string
+
+	// mu guards conn; wmu serializes writes to it
+	mu   sync.Mutex
+	wmu  sync.Mutex
+	conn *natsConn
+}
+
+type natsConn struct {
+	net.Conn
+	// pongs receives the reply to each PING sent by the client, or an error sent by the server
+	pongs chan error
+	done  chan struct{}
+}
+
+func newNATSPublisher(u *url.URL) (*natsPublisher, error) {
+	subject := strings.TrimPrefix(u.Path, "/")
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid NATS subject %q", subject)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	p := &natsPublisher{
+		addr:    addr,
+		subject: subject,
+	}
+	if u.User != nil {
+		p.user = u.User.Username()
+		p.password, _ = u.User.Password()
+	}
+	return p, nil
+}
+
+// connect dials the server and completes the handshake; the caller must hold p.mu
+func (p *natsPublisher) connect() error {
+	c, err := net.DialTimeout("tcp", p.addr, natsTimeout)
+	if err != nil {
+		return err
+	}
+	fail := func(err error) error {
+		c.Close()
+		return fmt.Errorf("connecting to NATS server %v: %w", p.addr, err)
+	}
+
+	// the server starts by sending INFO
+	r := bufio.NewReader(c)
+	_ = c.SetReadDeadline(time.Now().Add(natsTimeout))
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return fail(err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fail(fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line)))
+	}
+	_ = c.SetReadDeadline(time.Time{})
+
+	options, err := json.Marshal(map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "rekor-server",
+		"lang":     "go",
+		"protocol": 1,
+		"user":     p.user,
+		"pass":     p.password,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	conn := &natsConn{Conn: c, pongs: make(chan error, 1), done: make(chan struct{})}
+	go p.read(conn, r)
+
+	// the PONG confirms that the server accepted CONNECT
+	if err := p.write(conn, fmt.Sprintf("CONNECT %s\r\nPING\r\n", options)); err != nil {
+		return fail(err)
+	}
+	select {
+	case err := <-conn.pongs:
+		if err != nil {
+			return fail(err)
+		}
+	case <-conn.done:
+		return fail(errors.New("connection closed"))
+	case <-time.After(natsTimeout):
+		return fail(errors.New("timed out waiting for server"))
+	}
+
+	p.conn = conn
+	return nil
+}
+
+// read handles the messages sent by the server until the connection is closed
+func (p *natsPublisher) read(conn *natsConn, r *bufio.Reader) {
+	defer close(conn.done)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PING":
+			if err := p.write(conn, "PONG\r\n"); err != nil {
+				return
+			}
+		case line == "PONG":
+			conn.pongs <- nil
+		case strings.HasPrefix(line, "-ERR"):
+			err := fmt.Errorf("NATS server error: %v", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+			log.Named(log.SubsystemNotify).Error(err)
+			select {
+			case conn.pongs <- err:
+			default:
+			}
+		}
+	}
+}
+
+func (p *natsPublisher) write(conn *natsConn, s string) error {
+	p.wmu.Lock()
+	defer p.wmu.Unlock()
+	_ = conn.SetWriteDeadline(time.Now().Add(natsTimeout))
+	_, err := conn.Write([]byte(s))
+	return err
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, n Notification) error {
+	msg, err := n.marshal()
+	if err != nil {
+		return err
+	}
+	pub := fmt.Sprintf("PUB %s %d\r\n%s\r\n", p.subject, len(msg), msg)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// retry once on a new connection, in case the server closed the old one
+	for attempt := 0; ; attempt++ {
+		if p.conn != nil {
+			select {
+			case <-p.conn.done:
+				// the server has closed the connection
+				p.conn.Close()
+				p.conn = nil
+			default:
+			}
+		}
+		if p.conn == nil {
+			if err := p.connect(); err != nil {
+				return err
+			}
+		}
+		err := p.write(p.conn, pub)
+		if err == nil {
+			return nil
+		}
+		p.conn.Close()
+		p.conn = nil
+		if attempt > 0 {
+			return fmt.Errorf("publishing to NATS server %v: %w", p.addr, err)
+		}
+	}
+}
+
+func (p *natsPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
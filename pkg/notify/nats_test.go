@@ -0,0 +1,213 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// natsServer is a minimal NATS server that records the messages published to it
+type natsServer struct {
+	l        net.Listener
+	connects chan map[string]interface{}
+	messages chan string
+	// closeAfter closes each connection after this many messages, if set
+	closeAfter int
+	// reject makes the server refuse CONNECT
+	reject bool
+}
+
+func newNATSServer(t *testing.T) *natsServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &natsServer{l: l, connects: make(chan map[string]interface{}, 10), messages: make(chan string, 10)}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	t.Cleanup(func() { l.Close() })
+	return s
+}
+
+func (s *natsServer) serve(c net.Conn) {
+	defer c.Close()
+	fmt.Fprint(c, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+	r := bufio.NewReader(c)
+	published := 0
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			options := map[string]interface{}{}
+			_ = json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &options)
+			s.connects <- options
+			if s.reject {
+				fmt.Fprint(c, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+			// make sure the client answers pings from the server
+			fmt.Fprint(c, "PING\r\n")
+		case "PING":
+			fmt.Fprint(c, "PONG\r\n")
+		case "PONG":
+		case "PUB":
+			size, _ := strconv.Atoi(fields[2])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.messages <- fields[1] + " " + string(payload[:size])
+			published++
+			if s.closeAfter > 0 && published == s.closeAfter {
+				return
+			}
+		}
+	}
+}
+
+func (s *natsServer) publisher(t *testing.T, userinfo string) Publisher {
+	u, err := url.Parse(fmt.Sprintf("nats://%s%s/rekor.entries", userinfo, s.l.Addr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := newNATSPublisher(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func receive(t *testing.T, ch chan string) string {
+	t.Helper()
+	select {
+	case m := <-ch:
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return ""
+	}
+}
+
+func TestNATSPublish(t *testing.T) {
+	s := newNATSServer(t)
+	p := s.publisher(t, "alice:secret@")
+
+	n := Notification{Kind: "rekord", APIVersion: "0.0.1", UUID: "abcd", LogIndex: 7, IndexKeys: []string{"a@example.com"}}
+	for i := 0; i < 2; i++ {
+		if err := p.Publish(context.Background(), n); err != nil {
+			t.Fatal(err)
+		}
+		msg := receive(t, s.messages)
+		if !strings.HasPrefix(msg, "rekor.entries ") {
+			t.Fatalf("unexpected subject in %q", msg)
+		}
+		var got Notification
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(msg, "rekor.entries ")), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.UUID != n.UUID || got.LogIndex != n.LogIndex || got.IndexKeys[0] != n.IndexKeys[0] {
+			t.Errorf("unexpected notification %+v", got)
+		}
+	}
+
+	// both messages are sent over one connection
+	options := <-s.connects
+	if options["user"] != "alice" || options["pass"] != "secret" {
+		t.Errorf("unexpected credentials in %v", options)
+	}
+	select {
+	case <-s.connects:
+		t.Error("publisher reconnected unnecessarily")
+	default:
+	}
+}
+
+func TestNATSReconnect(t *testing.T) {
+	s := newNATSServer(t)
+	s.closeAfter = 1
+	p := s.publisher(t, "")
+
+	for i := 0; i < 3; i++ {
+		if err := p.Publish(context.Background(), Notification{LogIndex: int64(i)}); err != nil {
+			t.Fatalf("publish %d: %v", i, err)
+		}
+		receive(t, s.messages)
+		// give the client a chance to notice that the server closed the connection
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(s.connects) != 3 {
+		t.Errorf("expected 3 connections, got %d", len(s.connects))
+	}
+}
+
+func TestNATSRejected(t *testing.T) {
+	s := newNATSServer(t)
+	s.reject = true
+	p := s.publisher(t, "")
+
+	err := p.Publish(context.Background(), Notification{})
+	if err == nil || !strings.Contains(err.Error(), "Authorization Violation") {
+		t.Errorf("expected authorization error, got %v", err)
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "nats://localhost/rekor.entries"},
+		{url: "nats://localhost:4223/rekor.entries"},
+		{url: "nats://localhost", wantErr: true},
+		{url: "gcppubsub://projects/p", wantErr: true},
+		{url: "kafka://localhost:9092/rekor", wantErr: true},
+		{url: "rekor.entries", wantErr: true},
+	}
+	for _, tt := range tests {
+		p, err := NewPublisher(context.Background(), tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewPublisher(%v) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+		if p != nil {
+			p.Close()
+		}
+	}
+}
@@ -0,0 +1,106 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// Notification is the message published for each entry integrated into the log
+type Notification struct {
+	Kind           string `json:"kind"`
+	APIVersion     string `json:"apiVersion"`
+	UUID           string `json:"uuid"`
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	// IndexKeys are the keys under which the entry can be found with the index API,
+	// such as email addresses, public key fingerprints and artifact hashes
+	IndexKeys []string `json:"indexKeys"`
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, n Notification) error
+	Close() error
+}
+
+// NewPublisher returns a publisher for a topic URL; the supported schemes are
+// nats://[user:password@]host:port/subject and gcppubsub://projects/<project>/topics/<topic>
+func NewPublisher(ctx context.Context, topicURL string) (Publisher, error) {
+	u, err := url.Parse(topicURL)
+	if err != nil {
+		return nil, err
+	}
+	var p Publisher
+	switch u.Scheme {
+	case natsScheme:
+		p, err = newNATSPublisher(u)
+	case gcpPubSubScheme:
+		p, err = newGCPPublisher(ctx, u)
+	default:
+		return nil, fmt.Errorf("unsupported notification topic scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewPublishers returns a publisher that publishes each notification to all of the given topics
+func NewPublishers(ctx context.Context, topicURLs []string) (Publisher, error) {
+	var publishers multiPublisher
+	for _, topicURL := range topicURLs {
+		log.Named(log.SubsystemNotify).Infof("Configuring entry notifications to %s", topicURL)
+		p, err := NewPublisher(ctx, topicURL)
+		if err != nil {
+			_ = publishers.Close()
+			return nil, fmt.Errorf("configuring notifications to %s: %w", topicURL, err)
+		}
+		publishers = append(publishers, p)
+	}
+	return publishers, nil
+}
+
+type multiPublisher []Publisher
+
+// Publish publishes to every topic, even if publishing to one of them fails; the first error is returned
+func (m multiPublisher) Publish(ctx context.Context, n Notification) error {
+	var firstErr error
+	for _, p := range m {
+		if err := p.Publish(ctx, n); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (m multiPublisher) Close() error {
+	var firstErr error
+	for _, p := range m {
+		if err := p.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (n Notification) marshal() ([]byte, error) {
+	return json.Marshal(n)
+}
@@ -16,8 +16,12 @@
 package pki
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"sync"
 
 	"github.com/sigstore/rekor/pkg/pki/minisign"
 	"github.com/sigstore/rekor/pkg/pki/pgp"
@@ -25,6 +29,7 @@ import (
 	"github.com/sigstore/rekor/pkg/pki/ssh"
 	"github.com/sigstore/rekor/pkg/pki/tuf"
 	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/spf13/viper"
 )
 
 type Format string
@@ -39,88 +44,150 @@ const (
 )
 
 type ArtifactFactory struct {
-	impl pkiImpl
+	impl FormatFactory
 }
 
 func NewArtifactFactory(format Format) (*ArtifactFactory, error) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
 	if impl, ok := artifactFactoryMap[format]; ok {
 		return &ArtifactFactory{impl: impl}, nil
 	}
 	return nil, fmt.Errorf("%v is not a supported PKI format", format)
 }
 
-type pkiImpl struct {
-	newPubKey    func(io.Reader) (PublicKey, error)
-	newSignature func(io.Reader) (Signature, error)
+// FormatFactory creates the public keys and signatures of a single PKI format
+type FormatFactory struct {
+	NewPublicKey func(io.Reader) (PublicKey, error)
+	NewSignature func(io.Reader) (Signature, error)
 }
 
-var artifactFactoryMap map[Format]pkiImpl
+var (
+	formatsMu          sync.RWMutex
+	artifactFactoryMap = map[Format]FormatFactory{}
+)
+
+// RegisterFormat makes a PKI format available to NewArtifactFactory and SupportedFormats, allowing
+// formats to be added without modifying this package. It is normally called from an init function.
+func RegisterFormat(format Format, factory FormatFactory) error {
+	if format == "" {
+		return errors.New("PKI format name must not be empty")
+	}
+	if factory.NewPublicKey == nil || factory.NewSignature == nil {
+		return fmt.Errorf("PKI format %v must provide both a public key and a signature factory", format)
+	}
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if _, ok := artifactFactoryMap[format]; ok {
+		return fmt.Errorf("PKI format %v is already registered", format)
+	}
+	artifactFactoryMap[format] = factory
+	return nil
+}
 
 func init() {
-	artifactFactoryMap = map[Format]pkiImpl{
+	builtin := map[Format]FormatFactory{
 		PGP: {
-			newPubKey: func(r io.Reader) (PublicKey, error) {
+			NewPublicKey: func(r io.Reader) (PublicKey, error) {
 				return pgp.NewPublicKey(r)
 			},
-			newSignature: func(r io.Reader) (Signature, error) {
+			NewSignature: func(r io.Reader) (Signature, error) {
 				return pgp.NewSignature(r)
 			},
 		},
 		Minisign: {
-			newPubKey: func(r io.Reader) (PublicKey, error) {
+			NewPublicKey: func(r io.Reader) (PublicKey, error) {
 				return minisign.NewPublicKey(r)
 			},
-			newSignature: func(r io.Reader) (Signature, error) {
+			NewSignature: func(r io.Reader) (Signature, error) {
 				return minisign.NewSignature(r)
 			},
 		},
 		SSH: {
-			newPubKey: func(r io.Reader) (PublicKey, error) {
+			NewPublicKey: func(r io.Reader) (PublicKey, error) {
 				return ssh.NewPublicKey(r)
 			},
-			newSignature: func(r io.Reader) (Signature, error) {
+			NewSignature: func(r io.Reader) (Signature, error) {
 				return ssh.NewSignature(r)
 			},
 		},
 		X509: {
-			newPubKey: func(r io.Reader) (PublicKey, error) {
+			NewPublicKey: func(r io.Reader) (PublicKey, error) {
 				return x509.NewPublicKey(r)
 			},
-			newSignature: func(r io.Reader) (Signature, error) {
+			NewSignature: func(r io.Reader) (Signature, error) {
 				return x509.NewSignature(r)
 			},
 		},
 		PKCS7: {
-			newPubKey: func(r io.Reader) (PublicKey, error) {
+			NewPublicKey: func(r io.Reader) (PublicKey, error) {
 				return pkcs7.NewPublicKey(r)
 			},
-			newSignature: func(r io.Reader) (Signature, error) {
+			NewSignature: func(r io.Reader) (Signature, error) {
 				return pkcs7.NewSignature(r)
 			},
 		},
 		Tuf: {
-			newPubKey: func(r io.Reader) (PublicKey, error) {
-				return tuf.NewPublicKey(r)
+			NewPublicKey: func(r io.Reader) (PublicKey, error) {
+				// the same policy as for the roots of tuf entries
+				policy, err := tuf.ParseExpiryPolicy(viper.GetString("tuf_expiry_policy"))
+				if err != nil {
+					return nil, err
+				}
+				return tuf.NewPublicKey(r, tuf.WithExpiryPolicy(policy))
 			},
-			newSignature: func(r io.Reader) (Signature, error) {
+			NewSignature: func(r io.Reader) (Signature, error) {
 				return tuf.NewSignature(r)
 			},
 		},
 	}
+	for format, factory := range builtin {
+		if err := RegisterFormat(format, factory); err != nil {
+			panic(err)
+		}
+	}
 }
 
+// SupportedFormats returns the names of all registered PKI formats, sorted
 func SupportedFormats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
 	var formats []string
 	for f := range artifactFactoryMap {
 		formats = append(formats, string(f))
 	}
+	sort.Strings(formats)
 	return formats
 }
 
+// IsSupportedFormat reports whether format has been registered
+func IsSupportedFormat(format Format) bool {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	_, ok := artifactFactoryMap[format]
+	return ok
+}
+
+// ParsePublicKey parses the content of a public key in the given format, as stored in canonicalized entries
+func ParsePublicKey(format Format, content []byte) (PublicKey, error) {
+	if len(content) == 0 {
+		return nil, errors.New("public key content is empty")
+	}
+	af, err := NewArtifactFactory(format)
+	if err != nil {
+		return nil, err
+	}
+	k, err := af.NewPublicKey(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
 func (a ArtifactFactory) NewPublicKey(r io.Reader) (PublicKey, error) {
-	return a.impl.newPubKey(r)
+	return a.impl.NewPublicKey(r)
 }
 
 func (a ArtifactFactory) NewSignature(r io.Reader) (Signature, error) {
-	return a.impl.newSignature(r)
+	return a.impl.NewSignature(r)
 }
@@ -16,9 +16,16 @@
 package pki
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/sigstore/rekor/pkg/pki/minisign"
+	"github.com/spf13/viper"
+	"github.com/theupdateframework/go-tuf/verify"
 	"go.uber.org/goleak"
 )
 
@@ -119,3 +126,99 @@ func TestFactoryNewKey(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePublicKey(t *testing.T) {
+	content, err := ioutil.ReadFile("pgp/testdata/valid_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, err := ParsePublicKey(PGP, content); err != nil || k == nil {
+		t.Errorf("ParsePublicKey() = %v, %v", k, err)
+	}
+	for _, format := range []Format{X509, "unknown"} {
+		// a typed nil key would be mistaken for a parsed one
+		if k, err := ParsePublicKey(format, content); err == nil || k != nil {
+			t.Errorf("ParsePublicKey(%v) = %v, %v, want error", format, k, err)
+		}
+	}
+	if _, err := ParsePublicKey(PGP, nil); err == nil {
+		t.Error("ParsePublicKey succeeded without content")
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	custom := FormatFactory{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) {
+			return minisign.NewPublicKey(r)
+		},
+		NewSignature: func(r io.Reader) (Signature, error) {
+			return minisign.NewSignature(r)
+		},
+	}
+	if IsSupportedFormat("custom") {
+		t.Fatal("custom format should not be registered yet")
+	}
+	if err := RegisterFormat("custom", custom); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		formatsMu.Lock()
+		delete(artifactFactoryMap, "custom")
+		formatsMu.Unlock()
+	}()
+
+	if err := RegisterFormat("custom", custom); err == nil {
+		t.Error("expected error registering a format twice")
+	}
+	if err := RegisterFormat(PGP, custom); err == nil {
+		t.Error("expected error replacing a builtin format")
+	}
+	if err := RegisterFormat("incomplete", FormatFactory{NewPublicKey: custom.NewPublicKey}); err == nil {
+		t.Error("expected error registering a format without a signature factory")
+	}
+
+	if !IsSupportedFormat("custom") {
+		t.Error("custom format should be registered")
+	}
+	found := false
+	for _, f := range SupportedFormats() {
+		found = found || f == "custom"
+	}
+	if !found {
+		t.Errorf("custom format missing from SupportedFormats() = %v", SupportedFormats())
+	}
+
+	factory, err := NewArtifactFactory("custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, err := os.Open("minisign/testdata/minisign.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keyFile.Close()
+	if _, err := factory.NewPublicKey(keyFile); err != nil {
+		t.Errorf("unexpected error from custom format: %v", err)
+	}
+}
+
+func TestTUFExpiryPolicy(t *testing.T) {
+	factory, err := NewArtifactFactory(Tuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// this root expired in 2021
+	root, err := ioutil.ReadFile("tuf/testdata/1.root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := factory.NewPublicKey(bytes.NewReader(root)); !errors.As(err, &verify.ErrExpired{}) {
+		t.Errorf("expected ErrExpired by default, got %v", err)
+	}
+
+	viper.Set("tuf_expiry_policy", "ignore")
+	t.Cleanup(func() { viper.Set("tuf_expiry_policy", "") })
+	if _, err := factory.NewPublicKey(bytes.NewReader(root)); err != nil {
+		t.Errorf("unexpected error with the ignore policy: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+)
+
+// Type describes what kind of value an Identity carries
+type Type string
+
+const (
+	Email       Type = "email"
+	URI         Type = "uri"
+	Fingerprint Type = "fingerprint"
+	Subject     Type = "subject"
+	// CertFingerprint is the hex-encoded SHA256 digest of a DER-encoded certificate, where Fingerprint
+	// identifies the key it certifies
+	CertFingerprint Type = "certfingerprint"
+)
+
+// Identity is a typed identifier bound to a public key, such as an email address or key fingerprint
+type Identity struct {
+	Type  Type
+	Value string
+}
+
+// IndexKey returns the key under which this identity is stored in the search index; email
+// addresses are stored bare so that existing email searches keep working. Keys are lowercased,
+// as the index storage lowercases the keys it looks up.
+func (i Identity) IndexKey() string {
+	if i.Type == Email {
+		return strings.ToLower(i.Value)
+	}
+	return strings.ToLower(string(i.Type) + ":" + i.Value)
+}
+
+// Emails wraps each address as an Email record
+func Emails(emails []string) []Identity {
+	var ids []Identity
+	for _, e := range emails {
+		ids = append(ids, Identity{Type: Email, Value: e})
+	}
+	return ids
+}
+
+// KeyFingerprint returns the hex-encoded SHA256 digest of the DER-encoded SubjectPublicKeyInfo for pub
+func KeyFingerprint(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// Certificate returns the URI SANs, subject, public key fingerprint and fingerprint of a certificate
+func Certificate(c *x509.Certificate) []Identity {
+	var ids []Identity
+	for _, u := range c.URIs {
+		ids = append(ids, Identity{Type: URI, Value: u.String()})
+	}
+	if subject := c.Subject.String(); subject != "" {
+		ids = append(ids, Identity{Type: Subject, Value: subject})
+	}
+	if fp, err := KeyFingerprint(c.PublicKey); err == nil {
+		ids = append(ids, Identity{Type: Fingerprint, Value: fp})
+	}
+	if len(c.Raw) > 0 {
+		digest := sha256.Sum256(c.Raw)
+		ids = append(ids, Identity{Type: CertFingerprint, Value: hex.EncodeToString(digest[:])})
+	}
+	return ids
+}
@@ -18,14 +18,18 @@ package minisign
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"strings"
 
 	minisign "github.com/jedisct1/go-minisign"
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	sigsig "github.com/sigstore/sigstore/pkg/signature"
 )
 
+const trustedCommentPrefix = "trusted comment: "
+
 // Signature Signature that follows the minisign standard; supports both minisign and signify generated signatures
 type Signature struct {
 	signature *minisign.Signature
@@ -102,7 +106,45 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 	if err != nil {
 		return err
 	}
-	return verifier.VerifySignature(bytes.NewReader(s.signature.Signature[:]), r)
+	if err := verifier.VerifySignature(bytes.NewReader(s.signature.Signature[:]), r); err != nil {
+		return err
+	}
+
+	// minisign signatures carry a trusted comment authenticated by a second, global signature
+	if s.signature.TrustedComment == "" {
+		return nil
+	}
+	_, err = s.TrustedComment(key)
+	return err
+}
+
+// TrustedComment returns the trusted comment of a minisign signature after checking the global
+// signature over it with the given key. Signify signatures and canonicalized signatures carry no
+// trusted comment, in which case an empty string is returned.
+func (s Signature) TrustedComment(key *PublicKey) (string, error) {
+	if s.signature == nil {
+		return "", fmt.Errorf("minisign signature has not been initialized")
+	}
+	if s.signature.TrustedComment == "" {
+		return "", nil
+	}
+	if key == nil || key.key == nil {
+		return "", fmt.Errorf("minisign public key has not been initialized")
+	}
+
+	comment := strings.TrimPrefix(s.signature.TrustedComment, trustedCommentPrefix)
+	if len(comment) == len(s.signature.TrustedComment) {
+		return "", fmt.Errorf("invalid trusted comment: missing %q prefix", trustedCommentPrefix)
+	}
+	verifier, err := sigsig.LoadED25519Verifier(key.key.PublicKey[:])
+	if err != nil {
+		return "", err
+	}
+	global := append(append([]byte{}, s.signature.Signature[:]...), comment...)
+	if err := verifier.VerifySignature(bytes.NewReader(s.signature.GlobalSignature[:]), bytes.NewReader(global)); err != nil {
+		return "", fmt.Errorf("trusted comment does not match global signature: %w", err)
+	}
+	return comment, nil
 }
 
 // PublicKey Public Key that follows the minisign standard; supports signify and minisign public keys
@@ -147,3 +189,13 @@ func (k PublicKey) CanonicalValue() ([]byte, error) {
 func (k PublicKey) EmailAddresses() []string {
 	return nil
 }
+
+// Identities implements the pki.IdentityProvider interface
+func (k PublicKey) Identities() []identity.Identity {
+	if k.key == nil {
+		return nil
+	}
+	// minisign displays key IDs as the little-endian key ID bytes in uppercase hex
+	keyID := fmt.Sprintf("%016X", binary.LittleEndian.Uint64(k.key.KeyId[:]))
+	return []identity.Identity{{Type: identity.Fingerprint, Value: keyID}}
+}
@@ -302,3 +302,61 @@ func TestVerifySignature(t *testing.T) {
 		t.Errorf("expected error when using empty key to verify")
 	}
 }
+
+func TestTrustedComment(t *testing.T) {
+	keyFile, err := os.Open("testdata/minisign.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keyFile.Close()
+	k, err := NewPublicKey(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigBytes, err := os.ReadFile("testdata/hello_world.txt.minisig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("testdata/hello_world.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSignature(bytes.NewReader(sigBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err := s.TrustedComment(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment != "timestamp:1610131681\tfile:hello_world.txt" {
+		t.Errorf("unexpected trusted comment %q", comment)
+	}
+
+	// a tampered trusted comment no longer matches the global signature
+	tampered, err := NewSignature(bytes.NewReader(bytes.Replace(sigBytes, []byte("file:hello_world.txt"), []byte("file:other.txt"), 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tampered.TrustedComment(k); err == nil {
+		t.Error("expected error for tampered trusted comment")
+	}
+	if err := tampered.Verify(bytes.NewReader(data), k); err == nil {
+		t.Error("expected verification to fail for tampered trusted comment")
+	}
+
+	// signify signatures have no trusted comment
+	signifyFile, err := os.Open("testdata/hello_world.txt.signify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signifyFile.Close()
+	signify, err := NewSignature(signifyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment, err := signify.TrustedComment(k); err != nil || comment != "" {
+		t.Errorf("expected no trusted comment for signify signature, got %q (%v)", comment, err)
+	}
+}
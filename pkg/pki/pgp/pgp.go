@@ -19,12 +19,14 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/go-playground/validator"
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	"golang.org/x/crypto/openpgp"
 	"golang.org/x/crypto/openpgp/armor"
 	"golang.org/x/crypto/openpgp/packet"
@@ -294,3 +296,17 @@ func (k PublicKey) EmailAddresses() []string {
 	}
 	return names
 }
+
+// Identities implements the pki.IdentityProvider interface
+func (k PublicKey) Identities() []identity.Identity {
+	ids := identity.Emails(k.EmailAddresses())
+	for _, entity := range k.key {
+		if entity.PrimaryKey != nil {
+			ids = append(ids, identity.Identity{Type: identity.Fingerprint, Value: hex.EncodeToString(entity.PrimaryKey.Fingerprint[:])})
+		}
+		for name := range entity.Identities {
+			ids = append(ids, identity.Identity{Type: identity.Subject, Value: name})
+		}
+	}
+	return ids
+}
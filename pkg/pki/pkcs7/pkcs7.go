@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	"github.com/sassoftware/relic/lib/pkcs7"
+	"github.com/sigstore/rekor/pkg/pki/identity"
 )
 
 // EmailAddressOID defined by https://oidref.com/1.2.840.113549.1.9.1
@@ -125,10 +126,22 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 		}
 	}
 
-	if _, err := s.signedData.Verify(extContent, false); err != nil {
+	key, ok := k.(*PublicKey)
+	if !ok || key.key == nil {
+		return fmt.Errorf("invalid public key type for: %v", k)
+	}
+
+	sig, err := s.signedData.Verify(extContent, false)
+	if err != nil {
 		return err
 	}
 
+	// the signature is verified with the certificate embedded in the bundle, so make sure that is
+	// the key the caller asked us to verify with
+	signer, ok := sig.Certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !signer.Equal(key.key) {
+		return errors.New("PKCS7 signature was not produced by the provided public key")
+	}
 	return nil
 }
 
@@ -166,6 +179,12 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	// prefer the certificate of the signer over any chain certificates in the bundle
+	for _, si := range pkcs7.Content.SignerInfos {
+		if cert, err := si.FindCertificate(certs); err == nil {
+			return &PublicKey{key: cert.PublicKey, certs: certs, rawCert: cert.Raw}, nil
+		}
+	}
 	for _, cert := range certs {
 		return &PublicKey{key: cert.PublicKey, certs: certs, rawCert: cert.Raw}, nil
 	}
@@ -202,9 +221,21 @@ func (k PublicKey) EmailAddresses() []string {
 
 	for _, name := range cert.Subject.Names {
 		if name.Type.Equal(EmailAddressOID) {
-			names = append(names, strings.ToLower(name.Value.(string)))
+			if email, ok := name.Value.(string); ok {
+				names = append(names, strings.ToLower(email))
+			}
 		}
 	}
 
 	return names
 }
+
+// Identities implements the pki.IdentityProvider interface
+func (k PublicKey) Identities() []identity.Identity {
+	ids := identity.Emails(k.EmailAddresses())
+	cert, err := x509.ParseCertificate(k.rawCert)
+	if err != nil {
+		return ids
+	}
+	return append(ids, identity.Certificate(cert)...)
+}
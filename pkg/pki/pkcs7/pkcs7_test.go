@@ -310,3 +310,22 @@ func TestEmailAddresses(t *testing.T) {
 	}
 
 }
+
+func TestSignature_VerifyWrongKey(t *testing.T) {
+	s, err := NewSignature(strings.NewReader(pkcsECDSAPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewPublicKey(strings.NewReader(pkcsPEMEmail))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := base64.StdEncoding.DecodeString(signedContent)
+	if err := s.Verify(bytes.NewReader(data), other); err == nil {
+		t.Error("Signature.Verify() expected error for a key other than the signer's")
+	}
+	if err := s.Verify(bytes.NewReader(data), nil); err == nil {
+		t.Error("Signature.Verify() expected error for a missing key")
+	}
+}
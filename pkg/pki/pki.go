@@ -17,6 +17,8 @@ package pki
 
 import (
 	"io"
+
+	"github.com/sigstore/rekor/pkg/pki/identity"
 )
 
 // PublicKey Generic object representing a public key (regardless of format & algorithm)
@@ -25,6 +27,30 @@ type PublicKey interface {
 	EmailAddresses() []string
 }
 
+// IdentityProvider is implemented by public keys that are bound to identities other than email
+// addresses, such as key fingerprints or certificate subjects
+type IdentityProvider interface {
+	Identities() []identity.Identity
+}
+
+// Identities returns the identities of k, or just its email addresses if k does not implement
+// IdentityProvider
+func Identities(k PublicKey) []identity.Identity {
+	if p, ok := k.(IdentityProvider); ok {
+		return p.Identities()
+	}
+	return identity.Emails(k.EmailAddresses())
+}
+
+// IdentityIndexKeys returns the search index keys for all identities of k
+func IdentityIndexKeys(k PublicKey) []string {
+	var keys []string
+	for _, id := range Identities(k) {
+		keys = append(keys, id.IndexKey())
+	}
+	return keys
+}
+
 // Signature Generic object representing a signature (regardless of format & algorithm)
 type Signature interface {
 	CanonicalValue() ([]byte, error)
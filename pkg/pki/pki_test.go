@@ -0,0 +1,38 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"reflect"
+	"testing"
+)
+
+// emailKey only implements PublicKey, as out-of-tree formats may
+type emailKey struct{}
+
+func (emailKey) CanonicalValue() ([]byte, error) {
+	return []byte("key"), nil
+}
+
+func (emailKey) EmailAddresses() []string {
+	return []string{"Someone@Example.com"}
+}
+
+func TestIdentityIndexKeys(t *testing.T) {
+	if got, want := IdentityIndexKeys(emailKey{}), []string{"someone@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IdentityIndexKeys() = %v, want %v", got, want)
+	}
+}
@@ -29,11 +29,16 @@ const (
 )
 
 func Armor(s *ssh.Signature, p ssh.PublicKey) []byte {
+	return armor(s, p, defaultHashAlgorithm)
+}
+
+// armor encodes a signature over a message hashed with hashAlg
+func armor(s *ssh.Signature, p ssh.PublicKey, hashAlg string) []byte {
 	sig := WrappedSig{
 		Version:       1,
 		PublicKey:     string(p.Marshal()),
 		Namespace:     namespace,
-		HashAlgorithm: defaultHashAlgorithm,
+		HashAlgorithm: hashAlg,
 		Signature:     string(ssh.Marshal(s)),
 	}
 
@@ -47,6 +52,11 @@ func Armor(s *ssh.Signature, p ssh.PublicKey) []byte {
 }
 
 func Decode(b []byte) (*Signature, error) {
+	return decode(b, namespace)
+}
+
+// decode parses an armored signature, which must have been created in namespace ns
+func decode(b []byte, ns string) (*Signature, error) {
 	pemBlock, _ := pem.Decode(b)
 	if pemBlock == nil {
 		return nil, errors.New("unable to decode pem file")
@@ -68,7 +78,7 @@ func Decode(b []byte) (*Signature, error) {
 	if string(sig.MagicHeader[:]) != magicHeader {
 		return nil, fmt.Errorf("invalid magic header: %s", sig.MagicHeader[:])
 	}
-	if sig.Namespace != "file" {
+	if sig.Namespace != ns {
 		return nil, fmt.Errorf("invalid signature namespace: %s", sig.Namespace)
 	}
 	if _, ok := supportedHashAlgorithms[sig.HashAlgorithm]; !ok {
@@ -80,12 +90,16 @@ func Decode(b []byte) (*Signature, error) {
 	if err := ssh.Unmarshal([]byte(sig.Signature), &sshSig); err != nil {
 		return nil, err
 	}
-	// TODO: check the format here (should be rsa-sha512)
 
 	pk, err := ssh.ParsePublicKey([]byte(sig.PublicKey))
 	if err != nil {
 		return nil, err
 	}
+	// SHA-1 based ssh-rsa signatures are not allowed for sshsig:
+	// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig#L71
+	if pk.Type() == ssh.KeyAlgoRSA && sshSig.Format != ssh.SigAlgoRSASHA2256 && sshSig.Format != ssh.SigAlgoRSASHA2512 {
+		return nil, fmt.Errorf("unsupported rsa signature format: %s", sshSig.Format)
+	}
 
 	return &Signature{
 		signature: &sshSig,
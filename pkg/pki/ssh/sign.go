@@ -19,6 +19,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"fmt"
 	"hash"
 	"io"
 
@@ -54,16 +55,20 @@ var supportedHashAlgorithms = map[string]func() hash.Hash{
 	"sha512": sha512.New,
 }
 
-func sign(s ssh.AlgorithmSigner, m io.Reader) (*ssh.Signature, error) {
-	hf := sha512.New()
+func sign(s ssh.AlgorithmSigner, m io.Reader, hashAlg string) (*ssh.Signature, error) {
+	newHash, ok := supportedHashAlgorithms[hashAlg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", hashAlg)
+	}
+	hf := newHash()
 	if _, err := io.Copy(hf, m); err != nil {
 		return nil, err
 	}
 	mh := hf.Sum(nil)
 
 	sp := MessageWrapper{
-		Namespace:     "file",
-		HashAlgorithm: defaultHashAlgorithm,
+		Namespace:     namespace,
+		HashAlgorithm: hashAlg,
 		Hash:          string(mh),
 	}
 
@@ -85,6 +90,10 @@ func sign(s ssh.AlgorithmSigner, m io.Reader) (*ssh.Signature, error) {
 }
 
 func Sign(sshPrivateKey string, data io.Reader) ([]byte, error) {
+	return signArmored(sshPrivateKey, data, defaultHashAlgorithm)
+}
+
+func signArmored(sshPrivateKey string, data io.Reader, hashAlg string) ([]byte, error) {
 	s, err := ssh.ParsePrivateKey([]byte(sshPrivateKey))
 	if err != nil {
 		return nil, err
@@ -95,11 +104,11 @@ func Sign(sshPrivateKey string, data io.Reader) ([]byte, error) {
 		return nil, err
 	}
 
-	sig, err := sign(as, data)
+	sig, err := sign(as, data, hashAlg)
 	if err != nil {
 		return nil, err
 	}
 
-	armored := Armor(sig, s.PublicKey())
+	armored := armor(sig, s.PublicKey(), hashAlg)
 	return armored, nil
 }
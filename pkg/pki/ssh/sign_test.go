@@ -17,11 +17,15 @@ package ssh
 
 import (
 	"bytes"
+	"encoding/pem"
 	"io/ioutil"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/sigstore/rekor/pkg/pki/identity"
+	"golang.org/x/crypto/ssh"
 )
 
 var (
@@ -330,3 +334,84 @@ func runErr(t *testing.T, stdin []byte, args ...string) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestHashAlgorithms(t *testing.T) {
+	data := []byte("my good data to be signed!")
+	for _, hashAlg := range []string{"sha256", "sha512"} {
+		t.Run(hashAlg, func(t *testing.T) {
+			armored, err := signArmored(ed25519PrivateKey, bytes.NewReader(data), hashAlg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := NewSignature(bytes.NewReader(armored))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sig.hashAlg != hashAlg {
+				t.Errorf("expected hash algorithm %s, got %s", hashAlg, sig.hashAlg)
+			}
+			pub, err := NewPublicKey(strings.NewReader(ed25519PublicKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// verification goes through the canonical value, which must keep the hash algorithm
+			if err := sig.Verify(bytes.NewReader(data), pub); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	if _, err := signArmored(ed25519PrivateKey, bytes.NewReader(data), "md5"); err == nil {
+		t.Error("expected error for unsupported hash algorithm")
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	s, err := ssh.ParsePrivateKey([]byte(ed25519PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	armored, err := Sign(ed25519PrivateKey, strings.NewReader("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig, err := Decode(armored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrapped := WrappedSig{
+		Version:       1,
+		PublicKey:     string(s.PublicKey().Marshal()),
+		Namespace:     "git",
+		HashAlgorithm: defaultHashAlgorithm,
+		Signature:     string(ssh.Marshal(orig.signature)),
+	}
+	copy(wrapped.MagicHeader[:], magicHeader)
+	other := pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: ssh.Marshal(wrapped)})
+	if _, err := Decode(other); err == nil {
+		t.Error("expected error for a signature outside the file namespace")
+	}
+	if _, err := decode(other, "git"); err != nil {
+		t.Errorf("unexpected error decoding a signature in the git namespace: %v", err)
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	k, err := NewPublicKey(strings.NewReader(sshPublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := k.Identities()
+	// the test@rekor.dev comment of the key is not an identity
+	if len(ids) != 1 {
+		t.Fatalf("expected only the fingerprint identity, got %v", ids)
+	}
+	if ids[0].Type != identity.Fingerprint || ids[0].Value != ssh.FingerprintSHA256(k.key) {
+		t.Errorf("unexpected fingerprint identity %v", ids[0])
+	}
+	// the index storage only looks up lowercased keys
+	if got := ids[0].IndexKey(); got != strings.ToLower("fingerprint:"+ssh.FingerprintSHA256(k.key)) {
+		t.Errorf("unexpected index key %q", got)
+	}
+}
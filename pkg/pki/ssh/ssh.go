@@ -20,6 +20,7 @@ import (
 	"io"
 	"io/ioutil"
 
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -44,7 +45,10 @@ func NewSignature(r io.Reader) (*Signature, error) {
 
 // CanonicalValue implements the pki.Signature interface
 func (s Signature) CanonicalValue() ([]byte, error) {
-	return []byte(Armor(s.signature, s.pk)), nil
+	if s.signature == nil {
+		return nil, fmt.Errorf("ssh signature has not been initialized")
+	}
+	return armor(s.signature, s.pk, s.hashAlg), nil
 }
 
 // Verify implements the pki.Signature interface
@@ -101,3 +105,12 @@ func (k PublicKey) CanonicalValue() ([]byte, error) {
 func (k PublicKey) EmailAddresses() []string {
 	return nil
 }
+
+// Identities implements the pki.IdentityProvider interface
+func (k PublicKey) Identities() []identity.Identity {
+	if k.key == nil {
+		return nil
+	}
+	// the authorized_keys comment is free-form and chosen by the uploader, so it is not an identity
+	return []identity.Identity{{Type: identity.Fingerprint, Value: ssh.FingerprintSHA256(k.key)}}
+}
@@ -22,7 +22,13 @@ import (
 )
 
 func Verify(message io.Reader, armoredSignature []byte, publicKey []byte) error {
-	decodedSignature, err := Decode(armoredSignature)
+	return VerifyNamespace(message, armoredSignature, publicKey, namespace)
+}
+
+// VerifyNamespace verifies a signature created for an application specific namespace, such
+// as the "git" namespace used for signed commits and tags
+func VerifyNamespace(message io.Reader, armoredSignature []byte, publicKey []byte, ns string) error {
+	decodedSignature, err := decode(armoredSignature, ns)
 	if err != nil {
 		return err
 	}
@@ -40,7 +46,7 @@ func Verify(message io.Reader, armoredSignature []byte, publicKey []byte) error
 	hm := h.Sum(nil)
 
 	toVerify := MessageWrapper{
-		Namespace:     "file",
+		Namespace:     ns,
 		HashAlgorithm: decodedSignature.hashAlg,
 		Hash:          string(hm),
 	}
@@ -0,0 +1,98 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity ranks a SecurityWarning
+type Severity string
+
+const (
+	// SeverityCritical marks a trust setup that defeats the protections TUF is meant to provide
+	SeverityCritical Severity = "critical"
+)
+
+// SecurityWarning reports a dangerous but valid trust configuration found in a root
+type SecurityWarning struct {
+	Severity Severity
+	Message  string
+	// KeyIDs are the keys involved, if any
+	KeyIDs []string
+}
+
+func (w SecurityWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Severity, w.Message)
+}
+
+// CheckSingleKeyControl reports whether any single key could satisfy the threshold of every role
+// defined by the root on its own, and if so which keys. Revoked keys are not considered since they
+// can no longer sign.
+func (k PublicKey) CheckSingleKeyControl() (bool, []string, error) {
+	if k.meta == nil {
+		return false, nil, fmt.Errorf("tuf root has not been initialized")
+	}
+	if len(k.meta.Roles) == 0 {
+		return false, nil, nil
+	}
+
+	// count the roles each key can satisfy alone
+	satisfied := make(map[string]int)
+	for _, role := range k.meta.Roles {
+		if role.Threshold > 1 {
+			return false, nil, nil
+		}
+		seen := make(map[string]struct{}, len(role.KeyIDs))
+		for _, id := range role.KeyIDs {
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seen[id] = struct{}{}
+			if _, revoked := k.revoked[id]; !revoked {
+				satisfied[id]++
+			}
+		}
+	}
+
+	var ids []string
+	for id, n := range satisfied {
+		if n == len(k.meta.Roles) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return len(ids) > 0, ids, nil
+}
+
+// SecurityWarnings audits the root for dangerous trust setups
+func (k PublicKey) SecurityWarnings() ([]SecurityWarning, error) {
+	var warnings []SecurityWarning
+	single, ids, err := k.CheckSingleKeyControl()
+	if err != nil {
+		return nil, err
+	}
+	if single {
+		warnings = append(warnings, SecurityWarning{
+			Severity: SeverityCritical,
+			Message:  fmt.Sprintf("key %s alone satisfies the threshold of every role", strings.Join(ids, ", ")),
+			KeyIDs:   ids,
+		})
+	}
+	return warnings, nil
+}
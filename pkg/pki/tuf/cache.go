@@ -0,0 +1,85 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/theupdateframework/go-tuf/verify"
+)
+
+const rootCacheSize = 64
+
+// rootCache shares verified roots between callers loading identical root.json bytes. Cached keys
+// are never mutated after construction, so they are safe for concurrent use.
+var rootCache = newKeyCache(rootCacheSize)
+
+type keyCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type keyCacheEntry struct {
+	digest string
+	key    *PublicKey
+}
+
+func newKeyCache(size int) *keyCache {
+	return &keyCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached key for the canonical root digest, if any. Roots that have expired since they
+// were cached are evicted, so that loading them again checks their expiry; cached roots carry neither
+// certificates nor a trust deadline, so the expiry of the root is the only one that can pass.
+func (c *keyCache) get(digest string) *PublicKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[digest]
+	if !ok {
+		return nil
+	}
+	if verify.IsExpired(e.Value.(*keyCacheEntry).key.expires) {
+		c.order.Remove(e)
+		delete(c.entries, digest)
+		return nil
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*keyCacheEntry).key
+}
+
+func (c *keyCache) add(digest string, pk *PublicKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[digest]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[digest] = c.order.PushFront(&keyCacheEntry{digest: digest, key: pk})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*keyCacheEntry).digest)
+	}
+}
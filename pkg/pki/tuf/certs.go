@@ -0,0 +1,96 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrCertKeyMismatch is returned when the certificate bound to a signature carries a different
+// public key than the one the root declares for the signature's key ID
+var ErrCertKeyMismatch = errors.New("tuf: certificate public key does not match root key")
+
+// certSignature captures the optional certificate some TUF variants bind to each signature;
+// go-tuf's data.Signature drops unknown fields so the signatures are decoded a second time
+type certSignature struct {
+	KeyID string `json:"keyid"`
+	Cert  string `json:"cert,omitempty"`
+}
+
+// parseSignatureCerts returns the certificates bound to the signatures of a signed envelope, keyed by key ID
+func parseSignatureCerts(raw []byte) (map[string]*x509.Certificate, error) {
+	var envelope struct {
+		Signatures []certSignature `json:"signatures"`
+	}
+	if err := jsonUnmarshal(raw, &envelope); err != nil {
+		return nil, err
+	}
+	var certs map[string]*x509.Certificate
+	for _, sig := range envelope.Signatures {
+		if sig.Cert == "" {
+			continue
+		}
+		block, _ := pem.Decode([]byte(sig.Cert))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("signature by key %s has an invalid PEM certificate", sig.KeyID)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("signature by key %s: %w", sig.KeyID, err)
+		}
+		if certs == nil {
+			certs = make(map[string]*x509.Certificate)
+		}
+		certs[sig.KeyID] = cert
+	}
+	return certs, nil
+}
+
+// checkCert validates a signature's certificate, against the configured pool if there is one, and
+// cross-checks its public key against the key the root declares for the key ID
+func (k *PublicKey) checkCert(cert *x509.Certificate, key *data.Key) error {
+	if k.certPool != nil {
+		if _, err := cert.Verify(x509.VerifyOptions{
+			Roots:     k.certPool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return fmt.Errorf("tuf: signature certificate: %w", err)
+		}
+	}
+
+	var certKey []byte
+	switch pub := cert.PublicKey.(type) {
+	case ed25519.PublicKey:
+		certKey = pub
+	case *ecdsa.PublicKey:
+		certKey = elliptic.Marshal(pub.Curve, pub.X, pub.Y)
+	default:
+		return fmt.Errorf("%w: unsupported certificate key type %T", ErrCertKeyMismatch, cert.PublicKey)
+	}
+	if !bytes.Equal(certKey, key.Value.Public) {
+		return ErrCertKeyMismatch
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrMetaChain identifies the link of the timestamp -> snapshot -> targets chain that is broken
+type ErrMetaChain struct {
+	// Link is either "timestamp->snapshot" or "snapshot->targets"
+	Link   string
+	Reason string
+}
+
+func (e ErrMetaChain) Error() string {
+	return fmt.Sprintf("tuf: broken %s link: %s", e.Link, e.Reason)
+}
+
+var chainHashes = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// metaFile is the subset of timestamp and snapshot meta entries needed to check a link
+type metaFile struct {
+	Length  int64       `json:"length"`
+	Hashes  data.Hashes `json:"hashes"`
+	Version int         `json:"version"`
+}
+
+// VerifyMetaChain checks that the timestamp records the snapshot, and the snapshot records the
+// targets, by comparing the recorded hashes, lengths and versions with the canonical form of each
+// manifest. Signatures are not checked; verify each manifest against the root separately.
+func VerifyMetaChain(timestamp, snapshot, targets *Signature) error {
+	for role, s := range map[string]*Signature{"timestamp": timestamp, "snapshot": snapshot, "targets": targets} {
+		if s == nil || s.signed == nil {
+			return fmt.Errorf("tuf %s manifest has not been initialized", role)
+		}
+		if s.Role != role {
+			return fmt.Errorf("expected %s manifest, got %q", role, s.Role)
+		}
+	}
+	if err := checkMetaLink("timestamp->snapshot", timestamp, "snapshot.json", snapshot); err != nil {
+		return err
+	}
+	return checkMetaLink("snapshot->targets", snapshot, "targets.json", targets)
+}
+
+func checkMetaLink(link string, parent *Signature, name string, child *Signature) error {
+	var body struct {
+		Meta map[string]metaFile `json:"meta"`
+	}
+	if err := jsonUnmarshal(parent.signed.Signed, &body); err != nil {
+		return err
+	}
+	meta, ok := body.Meta[name]
+	if !ok {
+		return ErrMetaChain{Link: link, Reason: fmt.Sprintf("%s does not record %s", parent.Role, name)}
+	}
+
+	if meta.Version != 0 && meta.Version != child.Version {
+		return ErrMetaChain{Link: link, Reason: fmt.Sprintf("recorded version %d, got %d", meta.Version, child.Version)}
+	}
+	if meta.Length == 0 && len(meta.Hashes) == 0 {
+		return nil
+	}
+
+	canonical, err := child.CanonicalValue()
+	if err != nil {
+		return err
+	}
+	if meta.Length != 0 && meta.Length != int64(len(canonical)) {
+		return ErrMetaChain{Link: link, Reason: fmt.Sprintf("recorded length %d, got %d", meta.Length, len(canonical))}
+	}
+	checked := 0
+	for alg, expected := range meta.Hashes {
+		newHash, ok := chainHashes[alg]
+		if !ok {
+			continue
+		}
+		h := newHash()
+		h.Write(canonical)
+		if !bytes.Equal(h.Sum(nil), expected) {
+			return ErrMetaChain{Link: link, Reason: fmt.Sprintf("%s hash mismatch", alg)}
+		}
+		checked++
+	}
+	if len(meta.Hashes) > 0 && checked == 0 {
+		return ErrMetaChain{Link: link, Reason: "no supported hash algorithm recorded"}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import "encoding/json"
+
+// JSONCodec is the JSON implementation used to decode and encode metadata. Replacements must behave
+// exactly like encoding/json (e.g. jsoniter.ConfigCompatibleWithStandardLibrary), since decoded values
+// feed into canonicalization and therefore signature verification.
+type JSONCodec interface {
+	Unmarshal(data []byte, v interface{}) error
+	Marshal(v interface{}) ([]byte, error)
+}
+
+type stdlibCodec struct{}
+
+func (stdlibCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (stdlibCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+var codec JSONCodec = stdlibCodec{}
+
+// SetJSONCodec replaces the JSON implementation used by this package; nil restores encoding/json.
+// It is not safe to call concurrently with loading or verifying metadata, so call it during startup.
+func SetJSONCodec(c JSONCodec) {
+	if c == nil {
+		c = stdlibCodec{}
+	}
+	codec = c
+}
+
+func jsonUnmarshal(data []byte, v interface{}) error {
+	return codec.Unmarshal(data, v)
+}
+
+func jsonMarshal(v interface{}) ([]byte, error) {
+	return codec.Marshal(v)
+}
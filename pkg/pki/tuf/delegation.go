@@ -0,0 +1,213 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/verify"
+)
+
+var (
+	// ErrNoDelegations is returned when targets metadata used as key material delegates no roles
+	ErrNoDelegations = errors.New("tuf: targets metadata does not delegate any roles")
+	// ErrNoDelegatedRole is returned when no delegated role both covers and signed a manifest
+	ErrNoDelegatedRole = errors.New("tuf: manifest is not signed by a delegated role covering its targets")
+)
+
+// delegatedRole is an entry of the delegations.roles list of targets metadata
+type delegatedRole struct {
+	Name             string   `json:"name"`
+	KeyIDs           []string `json:"keyids"`
+	Threshold        int      `json:"threshold"`
+	Paths            []string `json:"paths,omitempty"`
+	PathHashPrefixes []string `json:"path_hash_prefixes,omitempty"`
+	Terminating      bool     `json:"terminating"`
+
+	role *verify.Role
+}
+
+// covers reports whether the role is trusted for every one of the given target names
+func (d delegatedRole) covers(names []string) bool {
+	for _, name := range names {
+		if !d.coversTarget(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d delegatedRole) coversTarget(name string) bool {
+	for _, pattern := range d.Paths {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	if len(d.PathHashPrefixes) > 0 {
+		digest := sha256.Sum256([]byte(name))
+		hexDigest := hex.EncodeToString(digest[:])
+		for _, prefix := range d.PathHashPrefixes {
+			if strings.HasPrefix(hexDigest, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type delegations struct {
+	Keys  map[string]*data.Key `json:"keys"`
+	Roles []delegatedRole      `json:"roles"`
+}
+
+// newDelegatingKey builds a PublicKey from the delegations of targets metadata, so that manifests of
+// the delegated roles can be verified against it. The signatures of the delegating targets metadata
+// itself cannot be checked without its root and are not verified here.
+func newDelegatingKey(s *data.Signed, body json.RawMessage, expires time.Time, o *options) (*PublicKey, error) {
+	var targets struct {
+		Version     int          `json:"version"`
+		Delegations *delegations `json:"delegations"`
+	}
+	if err := jsonUnmarshal(body, &targets); err != nil {
+		return nil, err
+	}
+	if targets.Version < 1 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidVersion, targets.Version)
+	}
+	if targets.Delegations == nil || len(targets.Delegations.Roles) == 0 {
+		return nil, ErrNoDelegations
+	}
+
+	db, revoked, err := newKeyDB(targets.Delegations.Keys, o)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(targets.Delegations.Roles))
+	roles := make([]delegatedRole, 0, len(targets.Delegations.Roles))
+	for _, d := range targets.Delegations.Roles {
+		if d.Name == "" || verify.ValidRole(d.Name) {
+			return nil, fmt.Errorf("invalid delegated role name %q", d.Name)
+		}
+		if _, dup := seen[d.Name]; dup {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateRole, d.Name)
+		}
+		seen[d.Name] = struct{}{}
+		if d.Threshold < 1 {
+			return nil, fmt.Errorf("delegated role %s: %w", d.Name, verify.ErrInvalidThreshold)
+		}
+		d.role = &verify.Role{KeyIDs: make(map[string]struct{}, len(d.KeyIDs)), Threshold: d.Threshold}
+		for _, id := range d.KeyIDs {
+			if len(id) != data.KeyIDLength {
+				return nil, fmt.Errorf("delegated role %s: %w", d.Name, verify.ErrInvalidKeyID)
+			}
+			d.role.KeyIDs[id] = struct{}{}
+		}
+		roles = append(roles, d)
+	}
+
+	pk := newPublicKey(s, db, expires, revoked, o)
+	pk.delegations = roles
+	return pk, nil
+}
+
+// delegation returns the delegated role with the given name, if the key delegates it
+func (k *PublicKey) delegation(name string) *delegatedRole {
+	for i := range k.delegations {
+		if k.delegations[i].Name == name {
+			return &k.delegations[i]
+		}
+	}
+	return nil
+}
+
+// roleFor returns the top-level or delegated role with the given name
+func (k *PublicKey) roleFor(name string) *verify.Role {
+	if d := k.delegation(name); d != nil {
+		return d.role
+	}
+	return k.db.GetRole(name)
+}
+
+// metaTypeFor returns the metadata type expected for manifests of role
+func (k *PublicKey) metaTypeFor(role string) string {
+	if k.delegation(role) != nil {
+		return "targets"
+	}
+	return role
+}
+
+// VerifyDelegated verifies a targets manifest against the roles delegated by the targets metadata k
+// was loaded from, and returns the name of the delegated role that signed it. Roles are tried in
+// delegation order, skipping any not trusted for all of the manifest's targets; a terminating role
+// that covers the targets but did not sign the manifest ends the search, as it would for a client.
+func (s Signature) VerifyDelegated(k *PublicKey) (string, error) {
+	if k == nil || k.db == nil {
+		return "", fmt.Errorf("tuf root has not been initialized")
+	}
+	if s.signed == nil {
+		return "", fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if k.delegations == nil {
+		return "", fmt.Errorf("tuf key material does not delegate any roles")
+	}
+	if s.Role != "targets" {
+		return "", fmt.Errorf("%w: expected targets manifest, got %q", verify.ErrWrongMetaType, s.Role)
+	}
+
+	var body struct {
+		Targets data.TargetFiles `json:"targets"`
+	}
+	if err := jsonUnmarshal(s.signed.Signed, &body); err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(body.Targets))
+	for name := range body.Targets {
+		names = append(names, name)
+	}
+
+	// all delegated roles tried share a single verification budget
+	ctx, cancel := k.verifyContext()
+	defer cancel()
+	var failures []string
+	for _, d := range k.delegations {
+		if !d.covers(names) {
+			continue
+		}
+		err := s.verifySignedWithin(ctx, k, s.signed, d.Name)
+		if err == nil {
+			return d.Name, nil
+		}
+		if errors.Is(err, ErrVerifyTimeout) {
+			return "", err
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", d.Name, err))
+		if d.Terminating {
+			break
+		}
+	}
+	if len(failures) == 0 {
+		return "", fmt.Errorf("%w: no delegated role is trusted for all targets", ErrNoDelegatedRole)
+	}
+	return "", fmt.Errorf("%w: %s", ErrNoDelegatedRole, strings.Join(failures, "; "))
+}
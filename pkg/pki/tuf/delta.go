@@ -0,0 +1,176 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrInvalidDelta is returned for a delta root that is malformed or does not apply to the current root
+var ErrInvalidDelta = errors.New("tuf: invalid delta root")
+
+// rootDeltaType is the _type of a delta root
+const rootDeltaType = "root-delta"
+
+// rootDelta describes the changes from one root version to the next. A delta root is encoded as
+//
+//	{
+//	  "delta": {
+//	    "_type": "root-delta",
+//	    "base_version": 1,             // version of the root the delta applies to
+//	    "version": 2,                  // version of the composed root, base_version + 1
+//	    "expires": "2030-01-01T00:00:00Z",
+//	    "add_keys": {"<keyid>": <key>, ...},
+//	    "remove_keys": ["<keyid>", ...],
+//	    "roles": {"<role>": {"keyids": [...], "threshold": n}, ...}  // replaced role definitions
+//	  },
+//	  "signatures": [...]
+//	}
+//
+// The signatures are not over the delta but over the composed root: the base root's signed body
+// with version and expires replaced, removed keys dropped, added keys inserted and the listed roles
+// replaced wholesale. Values are copied verbatim so signers and verifiers compose identical bodies.
+type rootDelta struct {
+	Type        string                     `json:"_type"`
+	BaseVersion int                        `json:"base_version"`
+	Version     int                        `json:"version"`
+	Expires     json.RawMessage            `json:"expires"`
+	AddKeys     map[string]json.RawMessage `json:"add_keys"`
+	RemoveKeys  []string                   `json:"remove_keys"`
+	Roles       map[string]json.RawMessage `json:"roles"`
+}
+
+type rootDeltaEnvelope struct {
+	Delta      *rootDelta       `json:"delta"`
+	Signatures []data.Signature `json:"signatures"`
+}
+
+// NewPublicKeyFromDelta applies a delta root to the current trusted root and returns the composed
+// successor root. The composed root must pass the same checks as a full root rotation: it is
+// signed by a threshold of the current root keys and of its own root keys, and increments the
+// version by one. Delta roots are not part of the TUF specification; publishers of very large key
+// sets may use them to avoid republishing every key on each rotation.
+func NewPublicKeyFromDelta(current *PublicKey, delta io.Reader, opts ...Option) (*PublicKey, error) {
+	if current == nil || current.meta == nil {
+		return nil, fmt.Errorf("tuf root has not been initialized")
+	}
+	raw, err := ioutil.ReadAll(delta)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, ErrEmptyInput
+	}
+	env := &rootDeltaEnvelope{}
+	if err := jsonUnmarshal(raw, env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+	}
+	if env.Delta == nil {
+		return nil, fmt.Errorf("%w: missing delta", ErrInvalidDelta)
+	}
+
+	composed, err := env.Delta.apply(current)
+	if err != nil {
+		return nil, err
+	}
+	next, err := jsonMarshal(&data.Signed{Signed: composed, Signatures: env.Signatures})
+	if err != nil {
+		return nil, err
+	}
+	return verifyRotation(current, next, opts...)
+}
+
+// apply validates the delta against the current root and returns the composed signed body
+func (d *rootDelta) apply(current *PublicKey) (json.RawMessage, error) {
+	if d.Type != rootDeltaType {
+		return nil, fmt.Errorf("%w: expected _type %q, got %q", ErrInvalidDelta, rootDeltaType, d.Type)
+	}
+	if d.BaseVersion != current.meta.Version {
+		return nil, fmt.Errorf("%w: applies to version %d, current root is version %d", ErrInvalidDelta, d.BaseVersion, current.meta.Version)
+	}
+	var expires string
+	if err := jsonUnmarshal(d.Expires, &expires); err != nil || expires == "" {
+		return nil, fmt.Errorf("%w: missing or invalid expires", ErrInvalidDelta)
+	}
+
+	var body map[string]json.RawMessage
+	if err := jsonUnmarshal(current.root.Signed, &body); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]json.RawMessage)
+	if err := jsonUnmarshal(body["keys"], &keys); err != nil {
+		return nil, err
+	}
+	roles := make(map[string]json.RawMessage)
+	if err := jsonUnmarshal(body["roles"], &roles); err != nil {
+		return nil, err
+	}
+
+	for _, id := range d.RemoveKeys {
+		if _, ok := keys[id]; !ok {
+			return nil, fmt.Errorf("%w: removed key %s is not in the current root", ErrInvalidDelta, id)
+		}
+		delete(keys, id)
+	}
+	for id, rawKey := range d.AddKeys {
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("%w: added key %s is already in the root", ErrInvalidDelta, id)
+		}
+		key := &data.Key{}
+		if err := jsonUnmarshal(rawKey, key); err != nil {
+			return nil, fmt.Errorf("%w: added key %s: %v", ErrInvalidDelta, id, err)
+		}
+		if !key.ContainsID(id) {
+			return nil, fmt.Errorf("%w: added key does not match key id %s", ErrInvalidDelta, id)
+		}
+		keys[id] = rawKey
+	}
+	for name, rawRole := range d.Roles {
+		switch name {
+		case "root", "targets", "snapshot", "timestamp":
+		default:
+			return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidDelta, name)
+		}
+		role := &data.Role{}
+		if err := jsonUnmarshal(rawRole, role); err != nil {
+			return nil, fmt.Errorf("%w: role %s: %v", ErrInvalidDelta, name, err)
+		}
+		if role.Threshold < 1 || len(role.KeyIDs) < role.Threshold {
+			return nil, fmt.Errorf("%w: role %s has threshold %d with %d keys", ErrInvalidDelta, name, role.Threshold, len(role.KeyIDs))
+		}
+		roles[name] = rawRole
+	}
+
+	var err error
+	if body["version"], err = jsonMarshal(d.Version); err != nil {
+		return nil, err
+	}
+	body["expires"] = d.Expires
+	if body["keys"], err = jsonMarshal(keys); err != nil {
+		return nil, err
+	}
+	if body["roles"], err = jsonMarshal(roles); err != nil {
+		return nil, err
+	}
+	return jsonMarshal(body)
+}
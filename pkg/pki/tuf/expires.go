@@ -0,0 +1,138 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/theupdateframework/go-tuf/verify"
+)
+
+// ExpiryPolicy selects how metadata past its expires time is treated during verification
+type ExpiryPolicy int
+
+const (
+	// ExpiryEnforce rejects expired metadata with verify.ErrExpired. This is the default.
+	ExpiryEnforce ExpiryPolicy = iota
+	// ExpiryIgnore only verifies signatures, so expired metadata verifies successfully. This must be
+	// opted into, e.g. to record metadata that expired long before it is uploaded.
+	ExpiryIgnore
+	// ExpiryFlag accepts expired metadata but logs a warning for each expired manifest
+	ExpiryFlag
+)
+
+var expiryPolicyNames = map[ExpiryPolicy]string{
+	ExpiryEnforce: "enforce",
+	ExpiryIgnore:  "ignore",
+	ExpiryFlag:    "flag",
+}
+
+func (p ExpiryPolicy) String() string {
+	if name, ok := expiryPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("ExpiryPolicy(%d)", int(p))
+}
+
+// ParseExpiryPolicy parses the name of an expiry policy: "enforce", "ignore" or "flag". An empty
+// name selects the default, ExpiryEnforce.
+func ParseExpiryPolicy(name string) (ExpiryPolicy, error) {
+	if name == "" {
+		return ExpiryEnforce, nil
+	}
+	for p, n := range expiryPolicyNames {
+		if strings.EqualFold(n, name) {
+			return p, nil
+		}
+	}
+	return ExpiryEnforce, fmt.Errorf("unknown tuf expiry policy %q", name)
+}
+
+// apply handles metadata for role that expired at expires according to the policy
+func (p ExpiryPolicy) apply(role string, expires time.Time) error {
+	switch p {
+	case ExpiryEnforce:
+		return verify.ErrExpired{Expired: expires}
+	case ExpiryFlag:
+		log.Logger.Warnf("accepting expired tuf %s metadata (expired %s)", role, expires.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// near-miss layouts emitted by some TUF tooling; all are interpreted as UTC
+var lenientLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseExpires parses the expires field of a TUF manifest. Strict parsing follows RFC 3339 exactly
+// (as encoding/json does for time.Time); lenient parsing additionally accepts a missing zone
+// designator or a space in place of the 'T' separator.
+func parseExpires(expires string, lenient bool) (time.Time, error) {
+	if expires == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, expires)
+	if err == nil || !lenient {
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid expires %q: %w", expires, err)
+		}
+		return t, nil
+	}
+	for _, layout := range lenientLayouts {
+		if t, lerr := time.ParseInLocation(layout, expires, time.UTC); lerr == nil {
+			log.Logger.Warnf("tuf manifest has non RFC 3339 expires %q; interpreting as %s", expires, t.UTC().Format(time.RFC3339Nano))
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid expires %q: %w", expires, err)
+}
+
+// normalizeExpires returns a copy of the signed body with expires rewritten in RFC 3339 so it can
+// be decoded into go-tuf structures. The returned body must never be used for signature verification.
+func normalizeExpires(signed json.RawMessage, lenient bool) (json.RawMessage, time.Time, error) {
+	var body map[string]json.RawMessage
+	if err := jsonUnmarshal(signed, &body); err != nil {
+		return nil, time.Time{}, err
+	}
+	var raw string
+	if v, ok := body["expires"]; ok {
+		if err := jsonUnmarshal(v, &raw); err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid expires: %w", err)
+		}
+	}
+	expires, err := parseExpires(raw, lenient)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if _, err := time.Parse(time.RFC3339Nano, raw); raw == "" || err == nil {
+		return signed, expires, nil
+	}
+	body["expires"], err = jsonMarshal(expires.Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	normalized, err := jsonMarshal(body)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return normalized, expires, nil
+}
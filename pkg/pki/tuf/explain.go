@@ -0,0 +1,102 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/theupdateframework/go-tuf/verify"
+)
+
+// ExplainFailure re-runs verification of the manifest against the root one signature at a time and
+// renders a human readable explanation of why it does not verify. An empty string is returned if the
+// manifest verifies successfully.
+func (s Signature) ExplainFailure(k *PublicKey) (string, error) {
+	if s.signed == nil {
+		return "", fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if k == nil || k.db == nil {
+		return "", fmt.Errorf("tuf root has not been initialized")
+	}
+
+	subject := fmt.Sprintf("manifest for role '%s' (version %d)", s.Role, s.Version)
+
+	role := k.db.GetRole(s.Role)
+	if role == nil {
+		return fmt.Sprintf("%s cannot be verified; the root does not define role '%s'", subject, s.Role), nil
+	}
+	if len(s.signed.Signatures) == 0 {
+		return fmt.Sprintf("%s has no signatures; %d required", subject, role.Threshold), nil
+	}
+
+	msg, err := canonicalBody(s.signed.Signed)
+	if err != nil {
+		return "", err
+	}
+
+	valid := make(map[string]struct{})
+	var problems []string
+	for _, sig := range s.signed.Signatures {
+		if !role.ValidKey(sig.KeyID) {
+			problems = append(problems, fmt.Sprintf("key %s is not authorized for role '%s'", sig.KeyID, s.Role))
+			continue
+		}
+		key, err := k.keyFor(sig.KeyID)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("key %s could not be resolved: %v", sig.KeyID, err))
+			continue
+		}
+		if key == nil {
+			problems = append(problems, fmt.Sprintf("key %s is not usable in the root", sig.KeyID))
+			continue
+		}
+		if err := verify.Verifiers[key.Type].Verify(key.Value.Public, msg, sig.Signature); err != nil {
+			problems = append(problems, fmt.Sprintf("key %s produced an invalid signature", sig.KeyID))
+			continue
+		}
+		valid[sig.KeyID] = struct{}{}
+	}
+
+	ids := make([]string, 0, len(role.KeyIDs))
+	for id := range role.KeyIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	signed := make(map[string]struct{}, len(s.signed.Signatures))
+	for _, sig := range s.signed.Signatures {
+		signed[sig.KeyID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := signed[id]; !ok {
+			problems = append(problems, fmt.Sprintf("key %s did not sign", id))
+		}
+	}
+
+	if len(valid) < role.Threshold {
+		explanation := fmt.Sprintf("%s has %d of %d required valid signatures", subject, len(valid), role.Threshold)
+		if len(problems) > 0 {
+			explanation += "; " + strings.Join(problems, "; ")
+		}
+		return explanation, nil
+	}
+
+	if err := s.Verify(nil, k); err != nil {
+		return fmt.Sprintf("%s has %d of %d required valid signatures but failed verification: %v", subject, len(valid), role.Threshold, err), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,121 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// DefaultInTotoField is the target custom field read by an InTotoResolver with no Field set
+const DefaultInTotoField = "in-toto"
+
+// InTotoReference points from a TUF target to an in-toto link or layout that the caller is expected
+// to fetch and verify separately
+type InTotoReference struct {
+	// Target is the name of the TUF target carrying the reference
+	Target string
+	// Path locates the in-toto metadata, relative to the repository
+	Path string
+	// Hashes of the in-toto metadata, if the target recorded them
+	Hashes data.Hashes
+}
+
+// inTotoEntry is a single reference in a target's custom field: either a bare path or
+// a {"path", "hashes"} object
+type inTotoEntry struct {
+	Path   string      `json:"path"`
+	Hashes data.Hashes `json:"hashes,omitempty"`
+}
+
+func (e *inTotoEntry) UnmarshalJSON(b []byte) error {
+	var path string
+	if err := json.Unmarshal(b, &path); err == nil {
+		e.Path = path
+		return nil
+	}
+	type plain inTotoEntry
+	return json.Unmarshal(b, (*plain)(e))
+}
+
+// InTotoResolver extracts in-toto references from the custom fields of targets metadata, e.g.
+//
+//	"custom": {"in-toto": ["root.layout", {"path": "build.link", "hashes": {"sha256": "..."}}]}
+//
+// It only reports the references; in-toto verification is left to the caller.
+type InTotoResolver struct {
+	// Field is the key within each target's custom object; DefaultInTotoField if empty
+	Field string
+}
+
+// Resolve returns the in-toto references of every target in s, ordered by target name. Targets
+// without custom data or without the configured field are skipped. The manifest should be
+// verified before the references are trusted.
+func (r InTotoResolver) Resolve(s *Signature) ([]InTotoReference, error) {
+	if s == nil || s.signed == nil {
+		return nil, fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if s.Role != "targets" {
+		return nil, fmt.Errorf("expected targets manifest, got %q", s.Role)
+	}
+	field := r.Field
+	if field == "" {
+		field = DefaultInTotoField
+	}
+
+	var body struct {
+		Targets data.TargetFiles `json:"targets"`
+	}
+	if err := jsonUnmarshal(s.signed.Signed, &body); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(body.Targets))
+	for name := range body.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var refs []InTotoReference
+	for _, name := range names {
+		meta := body.Targets[name]
+		if meta.Custom == nil {
+			continue
+		}
+		var custom map[string]json.RawMessage
+		if err := jsonUnmarshal(*meta.Custom, &custom); err != nil {
+			// custom data is free-form; only the configured field has to follow this format
+			continue
+		}
+		raw, ok := custom[field]
+		if !ok {
+			continue
+		}
+		var entries []inTotoEntry
+		if err := jsonUnmarshal(raw, &entries); err != nil {
+			return nil, fmt.Errorf("target %s: invalid %s reference list: %w", name, field, err)
+		}
+		for _, e := range entries {
+			if e.Path == "" {
+				return nil, fmt.Errorf("target %s: %s reference is missing a path", name, field)
+			}
+			refs = append(refs, InTotoReference{Target: name, Path: e.Path, Hashes: e.Hashes})
+		}
+	}
+	return refs, nil
+}
@@ -0,0 +1,159 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// VerifiedManifest is an immutable snapshot of metadata extracted from the exact bytes that passed
+// verification
+type VerifiedManifest struct {
+	role    string
+	version int
+	expires time.Time
+	targets data.TargetFiles
+}
+
+// Role returns the role of the verified manifest
+func (m *VerifiedManifest) Role() string {
+	return m.role
+}
+
+// Version returns the version of the verified manifest
+func (m *VerifiedManifest) Version() int {
+	return m.version
+}
+
+// Expires returns the expiry of the verified manifest
+func (m *VerifiedManifest) Expires() time.Time {
+	return m.expires
+}
+
+// Targets returns a copy of the target files of a verified targets manifest, or nil for other roles
+func (m *VerifiedManifest) Targets() data.TargetFiles {
+	if m.targets == nil {
+		return nil
+	}
+	targets := make(data.TargetFiles, len(m.targets))
+	for name, meta := range m.targets {
+		targets[name] = meta
+	}
+	return targets
+}
+
+// VerifyAndExtract verifies the manifest against the root and extracts its metadata in a single
+// pass over a private copy of the signed bytes, so the returned data is guaranteed to come from
+// what was verified
+func (s Signature) VerifyAndExtract(k *PublicKey) (*VerifiedManifest, error) {
+	if k == nil || k.db == nil {
+		return nil, fmt.Errorf("tuf root has not been initialized")
+	}
+	if s.signed == nil {
+		return nil, fmt.Errorf("tuf manifest has not been initialized")
+	}
+
+	signed := &data.Signed{
+		Signed:     append(json.RawMessage(nil), s.signed.Signed...),
+		Signatures: append([]data.Signature(nil), s.signed.Signatures...),
+	}
+	if err := s.verifySignedAs(k, signed, s.Role); err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Version int              `json:"version"`
+		Targets data.TargetFiles `json:"targets"`
+	}
+	if err := jsonUnmarshal(signed.Signed, &body); err != nil {
+		return nil, err
+	}
+	m := &VerifiedManifest{
+		role:    s.Role,
+		version: body.Version,
+		expires: s.expires,
+	}
+	if s.Role == "targets" {
+		m.targets = body.Targets
+		if m.targets == nil {
+			m.targets = data.TargetFiles{}
+		}
+	}
+	return m, nil
+}
+
+// standardFields are the top-level fields of each role's signed body defined by the specification
+var standardFields = map[string][]string{
+	"root":      {"keys", "roles", "consistent_snapshot"},
+	"targets":   {"targets", "delegations"},
+	"snapshot":  {"meta"},
+	"timestamp": {"meta"},
+}
+
+func isStandardField(role, name string) bool {
+	switch name {
+	case "_type", "spec_version", "version", "expires":
+		return true
+	}
+	for _, f := range standardFields[role] {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomField returns the raw value of a non-standard top-level field of the signed body, for
+// deployments that annotate their metadata. The manifest should be verified before the value is
+// trusted; ErrFieldNotFound is returned if the field is absent.
+func (s Signature) CustomField(name string) (json.RawMessage, error) {
+	if s.signed == nil {
+		return nil, fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if isStandardField(s.Role, name) {
+		return nil, fmt.Errorf("%q is a standard %s field", name, s.Role)
+	}
+	body, err := s.ParsedBody()
+	if err != nil {
+		return nil, err
+	}
+	v, ok := body[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, name)
+	}
+	return v, nil
+}
+
+// ParsedBody returns every top-level field of the signed body as raw JSON, for callers that need to
+// decode non-standard structures themselves. The returned values are copies and may be modified.
+// The manifest should be verified before the values are trusted.
+func (s Signature) ParsedBody() (map[string]json.RawMessage, error) {
+	if s.signed == nil {
+		return nil, fmt.Errorf("tuf manifest has not been initialized")
+	}
+	var body map[string]json.RawMessage
+	if err := jsonUnmarshal(s.signed.Signed, &body); err != nil {
+		return nil, err
+	}
+	for name, v := range body {
+		body[name] = append(json.RawMessage(nil), v...)
+	}
+	return body, nil
+}
@@ -0,0 +1,48 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricsHook receives the outcome of every manifest verification, so that callers can export
+// metrics without this package depending on a metrics library
+type MetricsHook interface {
+	ObserveVerification(role, specVersion string, duration time.Duration, err error)
+}
+
+var (
+	metricsMu   sync.RWMutex
+	metricsHook MetricsHook
+)
+
+// SetMetricsHook installs the hook that is notified of manifest verifications; nil disables it
+func SetMetricsHook(h MetricsHook) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	metricsHook = h
+}
+
+func observeVerification(role, specVersion string, duration time.Duration, err error) {
+	metricsMu.RLock()
+	h := metricsHook
+	metricsMu.RUnlock()
+	if h != nil {
+		h.ObserveVerification(role, specVersion, duration, err)
+	}
+}
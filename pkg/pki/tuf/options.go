@@ -0,0 +1,267 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// Option configures how TUF metadata is loaded and verified
+type Option func(*options) error
+
+type options struct {
+	revoked     map[string]RevokedKey
+	lenientTime bool
+	resolver    KeyResolver
+	noCache     bool
+	certPool    *x509.CertPool
+	approved    map[string]map[string]struct{}
+	expected    []string
+	exact       bool
+	allowEmpty  bool
+	parent      *PublicKey
+	timeout     time.Duration
+	offline     map[string]struct{}
+	online      KeyClassifier
+	notAfter    time.Time
+
+	expiryPolicy ExpiryPolicy
+}
+
+func applyOptions(opts []Option) (*options, error) {
+	o := &options{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// cacheable reports whether a root loaded with these options may be shared with other callers;
+// any option that changes how the verification database is built must opt out here. Only roots
+// loaded under ExpiryEnforce are shared, as the cache evicts roots once they expire.
+func (o *options) cacheable() bool {
+	return !o.noCache && len(o.revoked) == 0 && o.resolver == nil && !o.lenientTime && o.certPool == nil && o.approved == nil && o.expected == nil && !o.allowEmpty && o.parent == nil && o.timeout == 0 && o.offline == nil && o.notAfter.IsZero() && o.expiryPolicy == ExpiryEnforce
+}
+
+// RevokedKey is an entry in a key revocation list
+type RevokedKey struct {
+	KeyID  string `json:"keyid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// UnmarshalJSON accepts either a bare key ID string or a {"keyid", "reason"} object
+func (r *RevokedKey) UnmarshalJSON(b []byte) error {
+	var id string
+	if err := json.Unmarshal(b, &id); err == nil {
+		r.KeyID = id
+		return nil
+	}
+	type plain RevokedKey
+	return json.Unmarshal(b, (*plain)(r))
+}
+
+// WithRevokedKeyList reads a JSON array of revoked key IDs (with optional reasons); the listed
+// keys are excluded from the verification database so they can never count towards a threshold
+func WithRevokedKeyList(r io.Reader) Option {
+	return func(o *options) error {
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		var list []RevokedKey
+		if err := jsonUnmarshal(b, &list); err != nil {
+			return fmt.Errorf("parsing revocation list: %w", err)
+		}
+		if o.revoked == nil {
+			o.revoked = make(map[string]RevokedKey, len(list))
+		}
+		for _, rk := range list {
+			if rk.KeyID == "" {
+				return fmt.Errorf("revocation list entry is missing a key id")
+			}
+			o.revoked[rk.KeyID] = rk
+		}
+		return nil
+	}
+}
+
+// WithLenientTimeParsing accepts expires values that are near misses of RFC 3339, such as a missing
+// trailing 'Z' or a space separator, interpreting them as UTC. A warning is logged whenever the
+// lenient path is taken so producers can be asked to fix their output.
+func WithLenientTimeParsing() Option {
+	return func(o *options) error {
+		o.lenientTime = true
+		return nil
+	}
+}
+
+// WithKeyResolver consults r for key material when a signature references a key ID that is
+// authorized for the role but whose key is not embedded in the root
+func WithKeyResolver(r KeyResolver) Option {
+	return func(o *options) error {
+		o.resolver = r
+		return nil
+	}
+}
+
+// WithoutCache always builds a fresh PublicKey instead of returning a shared instance for a root
+// that has been loaded before
+func WithoutCache() Option {
+	return func(o *options) error {
+		o.noCache = true
+		return nil
+	}
+}
+
+// WithCertPool requires certificates bound to signatures to chain to one of the roots in pool
+func WithCertPool(pool *x509.CertPool) Option {
+	return func(o *options) error {
+		o.certPool = pool
+		return nil
+	}
+}
+
+// WithApprovedBodyDigests only accepts manifests for role whose canonical signed body has one of the
+// given SHA-256 digests (hex, optionally prefixed with "sha256:"), on top of signature verification
+func WithApprovedBodyDigests(role string, digests []string) Option {
+	return func(o *options) error {
+		if o.approved == nil {
+			o.approved = make(map[string]map[string]struct{})
+		}
+		if o.approved[role] == nil {
+			o.approved[role] = make(map[string]struct{}, len(digests))
+		}
+		for _, d := range digests {
+			d = strings.ToLower(strings.TrimPrefix(d, "sha256:"))
+			if len(d) != 64 {
+				return fmt.Errorf("invalid sha256 digest %q for role %s", d, role)
+			}
+			o.approved[role][d] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithExpectedTargets requires verified targets metadata to list at least the given target names
+func WithExpectedTargets(names []string) Option {
+	return func(o *options) error {
+		o.expected = append(o.expected, names...)
+		return nil
+	}
+}
+
+// WithExactTargets requires verified targets metadata to list exactly the given target names
+func WithExactTargets(names []string) Option {
+	return func(o *options) error {
+		o.expected = append(o.expected, names...)
+		o.exact = true
+		return nil
+	}
+}
+
+// WithAllowEmptyRoles loads roots that define no roles at all, e.g. for inspection tools. Such a
+// root cannot verify its own signatures, or any other metadata.
+func WithAllowEmptyRoles() Option {
+	return func(o *options) error {
+		o.allowEmpty = true
+		return nil
+	}
+}
+
+// WithTrustedParentRoot accepts a root whose own root role is signed by parent's root role, rather
+// than by itself, making it an intermediate signing authority below parent.
+//
+// This is a non-standard extension: TUF roots are always self-signed, and a client following the
+// specification would reject such a root. Only use it for deployments that deliberately layer an
+// intermediate root below a primary one; the intermediate root's self-signature is not checked.
+func WithTrustedParentRoot(parent *PublicKey) Option {
+	return func(o *options) error {
+		if parent == nil || parent.meta == nil {
+			return fmt.Errorf("parent root must be a loaded TUF root")
+		}
+		o.parent = parent
+		return nil
+	}
+}
+
+// WithVerifyTimeout bounds the total time spent verifying a single manifest, including loading a
+// root, so pathological inputs with many signatures cannot tie up the caller. Verification that
+// runs over the budget fails with ErrVerifyTimeout.
+func WithVerifyTimeout(d time.Duration) Option {
+	return func(o *options) error {
+		if d <= 0 {
+			return fmt.Errorf("verification timeout must be positive, got %s", d)
+		}
+		o.timeout = d
+		return nil
+	}
+}
+
+// WithRequiredOfflineRoles fails verification of a manifest for any of the given roles if it was
+// signed by a key that the classifier set with WithOnlineKeys reports as online, enforcing the
+// separation between offline roles (typically root and targets) and online ones
+func WithRequiredOfflineRoles(roles []string) Option {
+	return func(o *options) error {
+		if o.offline == nil {
+			o.offline = make(map[string]struct{}, len(roles))
+		}
+		for _, role := range roles {
+			o.offline[role] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithOnlineKeys classifies key IDs as online or offline for WithRequiredOfflineRoles
+func WithOnlineKeys(c KeyClassifier) Option {
+	return func(o *options) error {
+		o.online = c
+		return nil
+	}
+}
+
+// WithRootMaxAge stops trusting the root once maxAge has passed since issued, even if the root's own
+// expires is further out, so operators can enforce a rotation cadence stricter than the metadata's.
+// Loading or verifying against such a root fails with ErrRootTooOld.
+func WithRootMaxAge(issued time.Time, maxAge time.Duration) Option {
+	return func(o *options) error {
+		if issued.IsZero() || maxAge <= 0 {
+			return fmt.Errorf("root max age needs an issue time and a positive duration")
+		}
+		o.notAfter = issued.Add(maxAge)
+		return nil
+	}
+}
+
+// WithExpiryPolicy sets how expired metadata, including the root itself, is treated; by default
+// expired metadata is rejected
+func WithExpiryPolicy(p ExpiryPolicy) Option {
+	return func(o *options) error {
+		if _, ok := expiryPolicyNames[p]; !ok {
+			return fmt.Errorf("unknown expiry policy %d", p)
+		}
+		o.expiryPolicy = p
+		return nil
+	}
+}
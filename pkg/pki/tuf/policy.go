@@ -0,0 +1,83 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type trustPolicy struct {
+	SpecVersion        string                     `json:"spec_version"`
+	Version            int                        `json:"version"`
+	Expires            time.Time                  `json:"expires"`
+	ConsistentSnapshot bool                       `json:"consistent_snapshot"`
+	Roles              map[string]trustPolicyRole `json:"roles"`
+	RevokedKeys        []RevokedKey               `json:"revoked_keys,omitempty"`
+}
+
+type trustPolicyRole struct {
+	Threshold int              `json:"threshold"`
+	Keys      []trustPolicyKey `json:"keys"`
+}
+
+type trustPolicyKey struct {
+	KeyID   string `json:"keyid"`
+	KeyType string `json:"keytype,omitempty"`
+	Scheme  string `json:"scheme,omitempty"`
+}
+
+// TrustPolicyJSON renders the effective trust configuration of the root as a stable JSON document:
+// every role with its threshold and authorized keys, plus the root's expiry and spec version. Keys
+// excluded by a revocation list are reported separately and not listed under any role.
+func (k PublicKey) TrustPolicyJSON() ([]byte, error) {
+	if k.meta == nil {
+		return nil, fmt.Errorf("tuf root has not been initialized")
+	}
+
+	policy := trustPolicy{
+		SpecVersion:        k.meta.SpecVersion,
+		Version:            k.meta.Version,
+		Expires:            k.expires.UTC(),
+		ConsistentSnapshot: k.meta.ConsistentSnapshot,
+		Roles:              make(map[string]trustPolicyRole, len(k.meta.Roles)),
+	}
+	for name, role := range k.meta.Roles {
+		ids := append([]string(nil), role.KeyIDs...)
+		sort.Strings(ids)
+		pr := trustPolicyRole{Threshold: role.Threshold, Keys: []trustPolicyKey{}}
+		for _, id := range ids {
+			if _, revoked := k.revoked[id]; revoked {
+				continue
+			}
+			pk := trustPolicyKey{KeyID: id}
+			if key, ok := k.meta.Keys[id]; ok {
+				pk.KeyType = key.Type
+				pk.Scheme = key.Scheme
+			}
+			pr.Keys = append(pr.Keys, pk)
+		}
+		policy.Roles[name] = pr
+	}
+	for _, rk := range k.revoked {
+		policy.RevokedKeys = append(policy.RevokedKeys, rk)
+	}
+	sort.Slice(policy.RevokedKeys, func(i, j int) bool { return policy.RevokedKeys[i].KeyID < policy.RevokedKeys[j].KeyID })
+
+	return json.MarshalIndent(policy, "", "  ")
+}
@@ -0,0 +1,49 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"fmt"
+	"time"
+)
+
+// RepositoryExpiry returns the earliest expiry across the four top-level roles of a repository,
+// along with the name of the role that expires first. This is the deadline by which the whole
+// repository must be refreshed; on a tie the role listed first in the arguments is reported.
+// Signatures are not checked; verify each manifest against the root separately.
+func RepositoryExpiry(root, targets, snapshot, timestamp *Signature) (time.Time, string, error) {
+	var earliest time.Time
+	var first string
+	for _, m := range []struct {
+		role string
+		sig  *Signature
+	}{{"root", root}, {"targets", targets}, {"snapshot", snapshot}, {"timestamp", timestamp}} {
+		if m.sig == nil || m.sig.signed == nil {
+			return time.Time{}, "", fmt.Errorf("%w: %s", ErrMissingRole, m.role)
+		}
+		if m.sig.Role != m.role {
+			return time.Time{}, "", fmt.Errorf("expected %s manifest, got %q", m.role, m.sig.Role)
+		}
+		expires := m.sig.Expires()
+		if expires.IsZero() {
+			return time.Time{}, "", fmt.Errorf("tuf %s manifest does not declare an expiry", m.role)
+		}
+		if first == "" || expires.Before(earliest) {
+			earliest, first = expires, m.role
+		}
+	}
+	return earliest, first, nil
+}
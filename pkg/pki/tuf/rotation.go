@@ -0,0 +1,113 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/theupdateframework/go-tuf/data"
+)
+
+// ErrRotationVersion is returned when a successor root does not increment the version by exactly one
+var ErrRotationVersion = errors.New("tuf: successor root must increment the version by one")
+
+// DryRunRotation checks that candidateNext is a valid successor of the current root without
+// trusting it: it must be signed by a threshold of the current root keys, be signed by a threshold
+// of its own root keys, and increment the version by exactly one
+func DryRunRotation(current *PublicKey, candidateNext io.Reader) error {
+	next, err := ioutil.ReadAll(candidateNext)
+	if err != nil {
+		return err
+	}
+	_, err = verifyRotation(current, next)
+	return err
+}
+
+// NewPublicKeyFromChain initializes a PublicKey from a chain of roots, ordered from the initially
+// trusted 1.root.json to the latest N.root.json. Every root after the first must be a valid rotation
+// of its predecessor: signed by a threshold of the previous root's keys and of its own, with the
+// version incremented by one. As in a TUF client update, only the final root is subject to the
+// expiry policy. The canonical value of the returned key covers the whole chain.
+func NewPublicKeyFromChain(roots []io.Reader, opts ...Option) (*PublicKey, error) {
+	raws := make([][]byte, 0, len(roots))
+	for _, r := range roots {
+		raw, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		raws = append(raws, raw)
+	}
+	return newPublicKeyFromChain(raws, opts)
+}
+
+func newPublicKeyFromChain(roots [][]byte, opts []Option) (*PublicKey, error) {
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("%w: empty root chain", ErrEmptyInput)
+	}
+	intermediate := append(append([]Option{}, opts...), WithExpiryPolicy(ExpiryIgnore), WithoutCache())
+	withPolicy := func(i int) []Option {
+		if i == len(roots)-1 {
+			return append(append([]Option{}, opts...), WithoutCache())
+		}
+		return intermediate
+	}
+
+	current, err := NewPublicKey(bytes.NewReader(roots[0]), withPolicy(0)...)
+	if err != nil {
+		return nil, fmt.Errorf("root 1 of chain: %w", err)
+	}
+	chain := []*data.Signed{current.root}
+	for i := 1; i < len(roots); i++ {
+		next, err := verifyRotation(current, roots[i], withPolicy(i)...)
+		if err != nil {
+			return nil, fmt.Errorf("root %d of chain: %w", i+1, err)
+		}
+		chain = append(chain, next.root)
+		current = next
+	}
+	if len(chain) > 1 {
+		current.chain = chain
+	}
+	return current, nil
+}
+
+// verifyRotation verifies a single root rotation step and returns the successor root
+func verifyRotation(current *PublicKey, next []byte, opts ...Option) (*PublicKey, error) {
+	if current == nil || current.meta == nil {
+		return nil, fmt.Errorf("tuf root has not been initialized")
+	}
+
+	sig, err := NewSignature(bytes.NewReader(next), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := sig.VerifyAsRoot(current); err != nil {
+		return nil, fmt.Errorf("successor root is not signed by the current root: %w", err)
+	}
+	if sig.Version != current.meta.Version+1 {
+		return nil, fmt.Errorf("%w: current version %d, successor version %d", ErrRotationVersion, current.meta.Version, sig.Version)
+	}
+
+	successor, err := NewPublicKey(bytes.NewReader(next), append(opts, WithoutCache())...)
+	if err != nil {
+		return nil, fmt.Errorf("successor root is not signed by its own keys: %w", err)
+	}
+	return successor, nil
+}
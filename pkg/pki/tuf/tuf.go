@@ -16,52 +16,116 @@
 package tuf
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
+	"strings"
 	"time"
 
-	cjson "github.com/tent/canonical-json-go"
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	"github.com/theupdateframework/go-tuf/data"
 	"github.com/theupdateframework/go-tuf/verify"
 )
 
+var (
+	// ErrDuplicateRole is returned when a root defines the same role more than once
+	ErrDuplicateRole = errors.New("tuf: duplicate role definition in root")
+	// ErrInvalidVersion is returned for metadata with a version lower than 1
+	ErrInvalidVersion = errors.New("tuf: metadata version must be at least 1")
+	// ErrMissingExpectedTargets is returned when targets metadata lacks a target the caller expected
+	ErrMissingExpectedTargets = errors.New("tuf: targets metadata is missing expected targets")
+	// ErrUnexpectedTargets is returned when targets metadata lists targets beyond the exact expected set
+	ErrUnexpectedTargets = errors.New("tuf: targets metadata lists unexpected targets")
+	// ErrNoRoles is returned for a root that does not define any roles
+	ErrNoRoles = errors.New("tuf: root does not define any roles")
+	// ErrNoMatchingKeys is returned when none of a manifest's signatures reference a key known to the
+	// root, which usually means the manifest is being verified against the wrong root
+	ErrNoMatchingKeys = errors.New("tuf: no signature references a key known to the root")
+	// ErrMissingRole is returned when the manifest for a required role is not supplied
+	ErrMissingRole = errors.New("tuf: missing manifest for role")
+	// ErrVerifyTimeout is returned when verifying a manifest exceeds the budget set with WithVerifyTimeout
+	ErrVerifyTimeout = errors.New("tuf: verification exceeded its time budget")
+	// ErrFieldNotFound is returned when a manifest does not carry the requested custom field
+	ErrFieldNotFound = errors.New("tuf: manifest has no such custom field")
+	// ErrOnlineKey is returned when a role required to be offline was signed with an online key
+	ErrOnlineKey = errors.New("tuf: offline role signed by an online key")
+	// ErrRootTooOld is returned when a root is used beyond the maximum age set with WithRootMaxAge
+	ErrRootTooOld = errors.New("tuf: root is older than its maximum trusted age")
+	// ErrEmptyInput is returned when the supplied metadata is empty or only contains whitespace
+	ErrEmptyInput = errors.New("tuf: empty metadata input")
+	// ErrBodyNotApproved is returned when a manifest's body is not in the approved set for its role
+	ErrBodyNotApproved = errors.New("tuf: manifest body is not approved")
+)
+
 type Signature struct {
 	signed  *data.Signed
 	Role    string
 	Version int
+	expires time.Time
+	// read once from the signed body so verification metrics need no extra parsing
+	specVersion string
+	// certificates bound to individual signatures, keyed by key ID
+	certs map[string]*x509.Certificate
 }
 
 type signedMeta struct {
-	Type        string    `json:"_type"`
-	Expires     time.Time `json:"expires"`
-	Version     int       `json:"version"`
-	SpecVersion string    `json:"spec_version"`
+	Type        string `json:"_type"`
+	Expires     string `json:"expires"`
+	Version     int    `json:"version"`
+	SpecVersion string `json:"spec_version"`
 }
 
 // NewSignature creates and validates a TUF signed manifest
-func NewSignature(r io.Reader) (*Signature, error) {
+func NewSignature(r io.Reader, opts ...Option) (*Signature, error) {
+	o, err := applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, ErrEmptyInput
+	}
 
 	s := &data.Signed{}
-	if err := json.Unmarshal(b, s); err != nil {
+	if err := jsonUnmarshal(b, s); err != nil {
 		return nil, err
 	}
 
 	// extract role
 	sm := &signedMeta{}
-	if err := json.Unmarshal(s.Signed, sm); err != nil {
+	if err := jsonUnmarshal(s.Signed, sm); err != nil {
+		return nil, err
+	}
+	if sm.Version < 1 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidVersion, sm.Version)
+	}
+	certs, err := parseSignatureCerts(b)
+	if err != nil {
+		return nil, err
+	}
+	expires, err := parseExpires(sm.Expires, o.lenientTime)
+	if err != nil {
 		return nil, err
 	}
 
 	return &Signature{
-		signed:  s,
-		Role:    sm.Type,
-		Version: sm.Version,
+		signed:      s,
+		Role:        sm.Type,
+		Version:     sm.Version,
+		expires:     expires,
+		specVersion: sm.SpecVersion,
+		certs:       certs,
 	}, nil
 }
 
@@ -71,23 +135,22 @@ func (s Signature) CanonicalValue() ([]byte, error) {
 		return nil, fmt.Errorf("tuf manifest has not been initialized")
 	}
 
-	var decoded map[string]interface{}
-	if err := json.Unmarshal(s.signed.Signed, &decoded); err != nil {
-		return nil, err
-	}
+	return canonicalSigned(s.signed)
+}
 
-	canonicalSigned, err := cjson.Marshal(decoded)
-	if err != nil {
-		return nil, err
-	}
-	canonical, err := cjson.Marshal(&data.Signed{
-		Signed:     canonicalSigned,
-		Signatures: s.signed.Signatures})
+// Expires returns the expiry declared by the manifest, or the zero time if it declares none
+func (s Signature) Expires() time.Time {
+	return s.expires
+}
+
+// MatchesLogged reports whether the canonical value of this manifest is byte-for-byte identical to
+// the canonical value stored in a rekor entry
+func (s Signature) MatchesLogged(loggedCanonical []byte) (bool, error) {
+	canonical, err := s.CanonicalValue()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-
-	return canonical, nil
+	return bytes.Equal(canonical, loggedCanonical), nil
 }
 
 // Verify implements the pki.Signature interface
@@ -100,56 +163,337 @@ func (s Signature) Verify(_ io.Reader, k interface{}) error {
 	if key.db == nil {
 		return fmt.Errorf("tuf root has not been initialized")
 	}
+	if s.signed == nil {
+		return fmt.Errorf("tuf manifest has not been initialized")
+	}
+
+	if key.delegations != nil {
+		_, err := s.VerifyDelegated(key)
+		return err
+	}
+	return s.verifySignedAs(key, s.signed, s.Role)
+}
+
+// VerifyAsRoot verifies the manifest as root metadata; it fails if the manifest claims any other role
+func (s Signature) VerifyAsRoot(k *PublicKey) error {
+	return s.verifyAs(k, "root")
+}
+
+// VerifyAsTargets verifies the manifest as targets metadata; it fails if the manifest claims any other role
+func (s Signature) VerifyAsTargets(k *PublicKey) error {
+	return s.verifyAs(k, "targets")
+}
+
+// VerifyAsSnapshot verifies the manifest as snapshot metadata; it fails if the manifest claims any other role
+func (s Signature) VerifyAsSnapshot(k *PublicKey) error {
+	return s.verifyAs(k, "snapshot")
+}
+
+// VerifyAsTimestamp verifies the manifest as timestamp metadata; it fails if the manifest claims any other role
+func (s Signature) VerifyAsTimestamp(k *PublicKey) error {
+	return s.verifyAs(k, "timestamp")
+}
+
+// verifyAs verifies the manifest against the keys of the expected role rather than the self-asserted one
+func (s Signature) verifyAs(k *PublicKey, role string) error {
+	if k == nil || k.db == nil {
+		return fmt.Errorf("tuf root has not been initialized")
+	}
+	if s.signed == nil {
+		return fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if !strings.EqualFold(s.Role, role) {
+		return fmt.Errorf("%w: expected %s manifest, got %q", verify.ErrWrongMetaType, role, s.Role)
+	}
+	return s.verifySignedAs(k, s.signed, role)
+}
+
+// verifySignedAs verifies signed, either the manifest's own bytes or a private copy of them, as role
+// and reports the outcome to the metrics hook
+func (s Signature) verifySignedAs(k *PublicKey, signed *data.Signed, role string) error {
+	ctx, cancel := k.verifyContext()
+	defer cancel()
+	return s.verifySignedWithin(ctx, k, signed, role)
+}
 
-	return key.db.Verify(s.signed, s.Role, 0)
+// verifySignedWithin is verifySignedAs sharing the verification budget of ctx with other steps
+func (s Signature) verifySignedWithin(ctx context.Context, k *PublicKey, signed *data.Signed, role string) error {
+	start := time.Now()
+	err := k.verifySigned(ctx, signed, s.certs, s.Role, role, s.expires)
+	observeVerification(role, s.specVersion, time.Since(start), err)
+	return err
+}
+
+// VerifyAgainstAny tries each candidate root in order and returns the index of the first one that
+// verifies the manifest; if none do, -1 is returned along with the error from every candidate
+func VerifyAgainstAny(sig *Signature, roots []*PublicKey) (int, error) {
+	if sig == nil {
+		return -1, fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if len(roots) == 0 {
+		return -1, fmt.Errorf("no candidate tuf roots supplied")
+	}
+	msgs := make([]string, 0, len(roots))
+	for i, root := range roots {
+		if root == nil {
+			msgs = append(msgs, fmt.Sprintf("root %d: tuf root has not been initialized", i))
+			continue
+		}
+		err := sig.Verify(nil, root)
+		if err == nil {
+			return i, nil
+		}
+		msgs = append(msgs, fmt.Sprintf("root %d: %v", i, err))
+	}
+	return -1, fmt.Errorf("manifest did not verify against any candidate root: %s", strings.Join(msgs, "; "))
 }
 
 // PublicKey Public Key database with verification keys
 type PublicKey struct {
 	// we keep the signed root to retrieve the canonical value
-	root *data.Signed
-	db   *verify.DB
+	root    *data.Signed
+	meta    *data.Root
+	db      *verify.DB
+	expires time.Time
+	// keys present in the root but excluded from db by a revocation list
+	revoked map[string]RevokedKey
+	// optional source of key material for key IDs the root authorizes but does not embed
+	resolver KeyResolver
+	// optional roots that certificates bound to signatures must chain to
+	certPool *x509.CertPool
+	// pre-approved canonical body digests, keyed by role
+	approved map[string]map[string]struct{}
+	// target names that verified targets metadata must list
+	expected      []string
+	exactExpected bool
+	// upper bound on the time spent verifying a single manifest, if positive
+	verifyTimeout time.Duration
+	// roles that must not be signed by keys that online classifies as online
+	offlineRoles map[string]struct{}
+	online       KeyClassifier
+	// end of the trust window imposed with WithRootMaxAge, if set
+	notAfter time.Time
+	// roles delegated by targets metadata used as key material, in delegation order
+	delegations []delegatedRole
+	// how expired metadata is treated
+	expiryPolicy ExpiryPolicy
+	// the verified rotation chain ending in root, if the key was loaded from one
+	chain []*data.Signed
 }
 
 // NewPublicKey implements the pki.PublicKey interface
-func NewPublicKey(r io.Reader) (*PublicKey, error) {
+func NewPublicKey(r io.Reader, opts ...Option) (*PublicKey, error) {
+	o, err := applyOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.offline != nil && o.online == nil {
+		return nil, fmt.Errorf("required offline roles need a classification of online keys")
+	}
+
 	rawRoot, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
+	if len(bytes.TrimSpace(rawRoot)) == 0 {
+		return nil, ErrEmptyInput
+	}
+	// a JSON array holds a root rotation chain
+	if trimmed := bytes.TrimSpace(rawRoot); trimmed[0] == '[' {
+		var chain []json.RawMessage
+		if err := jsonUnmarshal(trimmed, &chain); err != nil {
+			return nil, err
+		}
+		roots := make([][]byte, 0, len(chain))
+		for _, root := range chain {
+			roots = append(roots, root)
+		}
+		return newPublicKeyFromChain(roots, opts)
+	}
 
 	// Unmarshal this to verify that this is a valid root.json
 	s := &data.Signed{}
-	if err := json.Unmarshal(rawRoot, s); err != nil {
+	if err := jsonUnmarshal(rawRoot, s); err != nil {
+		return nil, err
+	}
+	// encoding/json silently keeps the last of any duplicate keys, so scan the raw tokens
+	if err := checkDuplicateRoles(s.Signed); err != nil {
+		return nil, err
+	}
+
+	certs, err := parseSignatureCerts(rawRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// bound certificates are not part of the canonical value, so such roots are never shared
+	var digest string
+	if o.cacheable() && len(certs) == 0 {
+		canonical, err := canonicalSigned(s)
+		if err != nil {
+			return nil, err
+		}
+		digest = fmt.Sprintf("%x", sha256.Sum256(canonical))
+		if pk := rootCache.get(digest); pk != nil {
+			return pk, nil
+		}
+	}
+
+	body, expires, err := normalizeExpires(s.Signed, o.lenientTime)
+	if err != nil {
+		return nil, err
+	}
+	sm := &signedMeta{}
+	if err := jsonUnmarshal(body, sm); err != nil {
 		return nil, err
 	}
+	if sm.Type == "targets" {
+		return newDelegatingKey(s, body, expires, o)
+	}
 	root := &data.Root{}
-	if err := json.Unmarshal(s.Signed, root); err != nil {
+	if err := jsonUnmarshal(body, root); err != nil {
+		return nil, err
+	}
+	if root.Version < 1 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidVersion, root.Version)
+	}
+	if len(root.Roles) == 0 && !o.allowEmpty {
+		return nil, ErrNoRoles
+	}
+	if !o.notAfter.IsZero() && verify.IsExpired(o.notAfter) {
+		return nil, fmt.Errorf("%w: trusted until %s", ErrRootTooOld, o.notAfter.UTC().Format(time.RFC3339))
+	}
+
+	// Now create a verification db that trusts all the keys that have not been revoked
+	db, revoked, err := newKeyDB(root.Keys, o)
+	if err != nil {
 		return nil, err
 	}
+	for name, role := range root.Roles {
+		if err := db.AddRole(name, role); err != nil {
+			return nil, err
+		}
+	}
+
+	pk := newPublicKey(s, db, expires, revoked, o)
+	pk.meta = root
+
+	// Verify that this root.json was signed. An intermediate root is signed by its parent instead of
+	// itself. A root without roles has no keys to do so with, and is only loaded when explicitly allowed.
+	ctx, cancel := pk.verifyContext()
+	defer cancel()
+	if o.parent != nil {
+		if err := o.parent.verifySigned(ctx, s, certs, root.Type, "root", expires); err != nil {
+			return nil, fmt.Errorf("verifying intermediate root against parent: %w", err)
+		}
+	} else if len(root.Roles) > 0 {
+		if err := pk.verifySigned(ctx, s, certs, root.Type, "root", expires); err != nil {
+			return nil, err
+		}
+	}
+
+	if digest != "" {
+		rootCache.add(digest, pk)
+	}
+	return pk, nil
+}
 
-	// Now create a verification db that trusts all the keys
+// newKeyDB returns a verification db holding the keys that have not been revoked, and the revoked ones
+func newKeyDB(keys map[string]*data.Key, o *options) (*verify.DB, map[string]RevokedKey, error) {
 	db := verify.NewDB()
-	for id, k := range root.Keys {
+	revoked := make(map[string]RevokedKey)
+	for id, k := range keys {
+		if rk, ok := o.revoked[id]; ok {
+			revoked[id] = rk
+			continue
+		}
 		if err := db.AddKey(id, k); err != nil {
 			// TAP-12: https://github.com/theupdateframework/taps/blob/master/tap12.md
 			if _, ok := err.(verify.ErrWrongID); !ok {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
-	for name, role := range root.Roles {
-		if err := db.AddRole(name, role); err != nil {
-			return nil, err
+	return db, revoked, nil
+}
+
+// newPublicKey returns a PublicKey for the signed key material configured with o
+func newPublicKey(s *data.Signed, db *verify.DB, expires time.Time, revoked map[string]RevokedKey, o *options) *PublicKey {
+	return &PublicKey{root: s, db: db, expires: expires, revoked: revoked, resolver: o.resolver, certPool: o.certPool,
+		approved: o.approved, expected: o.expected, exactExpected: o.exact, verifyTimeout: o.timeout,
+		offlineRoles: o.offline, online: o.online, notAfter: o.notAfter, expiryPolicy: o.expiryPolicy}
+}
+
+// RevokedKeysUsed reports the revoked root keys that signed the given manifest; these
+// signatures were ignored during verification
+func (k PublicKey) RevokedKeysUsed(s *Signature) []RevokedKey {
+	if s == nil || s.signed == nil {
+		return nil
+	}
+	var used []RevokedKey
+	seen := make(map[string]struct{})
+	for _, sig := range s.signed.Signatures {
+		rk, ok := k.revoked[sig.KeyID]
+		if !ok {
+			continue
 		}
+		if _, dup := seen[sig.KeyID]; dup {
+			continue
+		}
+		seen[sig.KeyID] = struct{}{}
+		used = append(used, rk)
 	}
+	return used
+}
 
-	// Verify that this root.json was signed.
-	if err := db.Verify(s, "root", 0); err != nil {
-		return nil, err
+// checkDuplicateRoles walks the raw tokens of a signed root body and returns
+// ErrDuplicateRole if any role name appears more than once in the roles object
+func checkDuplicateRoles(signed json.RawMessage) error {
+	dec := json.NewDecoder(bytes.NewReader(signed))
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t != json.Delim('{') {
+		return fmt.Errorf("tuf: signed root is not a JSON object")
 	}
-
-	return &PublicKey{root: s, db: db}, nil
+	seen := make(map[string]struct{})
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := t.(string); key != "roles" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if t, err := dec.Token(); err != nil {
+			return err
+		} else if t != json.Delim('{') {
+			return fmt.Errorf("tuf: roles is not a JSON object")
+		}
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := t.(string)
+			if _, ok := seen[name]; ok {
+				return fmt.Errorf("%w: %q", ErrDuplicateRole, name)
+			}
+			seen[name] = struct{}{}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // CanonicalValue implements the pki.PublicKey interface
@@ -157,30 +501,30 @@ func (k PublicKey) CanonicalValue() (encoded []byte, err error) {
 	if k.root == nil {
 		return nil, fmt.Errorf("tuf root has not been initialized")
 	}
-
-	var decoded map[string]interface{}
-	if err := json.Unmarshal(k.root.Signed, &decoded); err != nil {
-		return nil, err
+	if k.chain == nil {
+		return canonicalSigned(k.root)
 	}
 
-	canonicalSigned, err := cjson.Marshal(decoded)
-	if err != nil {
-		return nil, err
-	}
-	canonical, err := cjson.Marshal(&data.Signed{
-		Signed:     canonicalSigned,
-		Signatures: k.root.Signatures})
-	if err != nil {
-		return nil, err
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, root := range k.chain {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		canonical, err := canonicalSigned(root)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(canonical)
 	}
-
-	return canonical, nil
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
 }
 
 func (k PublicKey) SpecVersion() (string, error) {
 	// extract role
 	sm := &signedMeta{}
-	if err := json.Unmarshal(k.root.Signed, sm); err != nil {
+	if err := jsonUnmarshal(k.root.Signed, sm); err != nil {
 		return "", err
 	}
 	return sm.SpecVersion, nil
@@ -190,3 +534,20 @@ func (k PublicKey) SpecVersion() (string, error) {
 func (k PublicKey) EmailAddresses() []string {
 	return nil
 }
+
+// Identities implements the pki.IdentityProvider interface; the identities of a root are the IDs of the keys it trusts
+func (k PublicKey) Identities() []identity.Identity {
+	if k.meta == nil {
+		return nil
+	}
+	keyIDs := make([]string, 0, len(k.meta.Keys))
+	for id := range k.meta.Keys {
+		keyIDs = append(keyIDs, id)
+	}
+	sort.Strings(keyIDs)
+	ids := make([]identity.Identity, 0, len(keyIDs))
+	for _, id := range keyIDs {
+		ids = append(ids, identity.Identity{Type: identity.Fingerprint, Value: id})
+	}
+	return ids
+}
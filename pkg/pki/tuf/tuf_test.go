@@ -17,11 +17,93 @@ package tuf
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/sign"
+	"github.com/theupdateframework/go-tuf/verify"
 )
 
+// testKey deterministically derives an ed25519 TUF signing key from seed
+func testKey(seed byte) *sign.PrivateKey {
+	priv := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+	return &sign.PrivateKey{
+		Type:       data.KeyTypeEd25519,
+		Scheme:     data.KeySchemeEd25519,
+		Algorithms: data.KeyAlgorithms,
+		Value: sign.PrivateKeyValue{
+			Public:  data.HexBytes(priv.Public().(ed25519.PublicKey)),
+			Private: data.HexBytes(priv),
+		},
+	}
+}
+
+func keyID(k *sign.PrivateKey) string {
+	return k.PublicData().IDs()[0]
+}
+
+// testRoot returns a root trusting the given keys for each role with a threshold of one
+func testRoot(roles map[string][]*sign.PrivateKey) *data.Root {
+	root := data.NewRoot()
+	root.Version = 1
+	root.Expires = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, keys := range roles {
+		role := &data.Role{Threshold: 1}
+		for _, k := range keys {
+			root.AddKey(k.PublicData())
+			role.AddKeyIDs([]string{keyID(k)})
+		}
+		root.Roles[name] = role
+	}
+	return root
+}
+
+func testTargets() *data.Targets {
+	t := data.NewTargets()
+	t.Version = 1
+	return t
+}
+
+func testSnapshot() *data.Snapshot {
+	s := data.NewSnapshot()
+	s.Version = 1
+	return s
+}
+
+func testTimestamp() *data.Timestamp {
+	t := data.NewTimestamp()
+	t.Version = 1
+	return t
+}
+
+// testSigned marshals and signs v, returning the resulting manifest bytes
+func testSigned(t testing.TB, v interface{}, signers ...*sign.PrivateKey) []byte {
+	t.Helper()
+	var ss []sign.Signer
+	for _, k := range signers {
+		ss = append(ss, k.Signer())
+	}
+	s, err := sign.Marshal(v, ss...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
 func TestReadPublicKey(t *testing.T) {
 	// Tests reading a valid public key (root.json)
 	type test struct {
@@ -43,7 +125,8 @@ func TestReadPublicKey(t *testing.T) {
 			t.Errorf("%v: cannot open %v", tc.caseDesc, tc.inputFile)
 		}
 
-		got, err := NewPublicKey(file)
+		// the testdata root expired in 2021
+		got, err := NewPublicKey(file, WithExpiryPolicy(ExpiryIgnore))
 		if ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
 			t.Errorf("%v: unexpected result testing %v: %v", tc.caseDesc, tc.inputFile, err)
 		}
@@ -113,7 +196,8 @@ func TestCanonicalValue(t *testing.T) {
 			t.Errorf("%v: cannot open %v", tc.caseDesc, tc.input)
 		}
 
-		inputKey, err := NewPublicKey(inputFile)
+		// the testdata root expired in 2021
+		inputKey, err := NewPublicKey(inputFile, WithExpiryPolicy(ExpiryIgnore))
 		if err != nil {
 			t.Errorf("%v: Error reading input for TestCanonicalValuePublicKey: %v", tc.caseDesc, err)
 		}
@@ -128,7 +212,7 @@ func TestCanonicalValue(t *testing.T) {
 			t.Errorf("%v: cannot open %v", tc.caseDesc, tc.output)
 		}
 
-		outputKey, err := NewPublicKey(outputFile)
+		outputKey, err := NewPublicKey(outputFile, WithExpiryPolicy(ExpiryIgnore))
 		if err != nil {
 			t.Errorf("%v: Error reading input for TestCanonicalValuePublicKey: %v", tc.caseDesc, err)
 		}
@@ -164,7 +248,8 @@ func TestVerifySignature(t *testing.T) {
 		if err != nil {
 			t.Errorf("%v: error reading keyfile '%v': %v", tc.caseDesc, tc.keyFile, err)
 		}
-		k, err := NewPublicKey(keyFile)
+		// the testdata manifests expired in 2021
+		k, err := NewPublicKey(keyFile, WithExpiryPolicy(ExpiryIgnore))
 		if err != nil {
 			t.Errorf("%v: error reading keyfile '%v': %v", tc.caseDesc, tc.keyFile, err)
 		}
@@ -197,3 +282,1712 @@ func TestVerifySignature(t *testing.T) {
 		t.Errorf("expected error when using empty key to verify")
 	}
 }
+
+func TestDuplicateRoles(t *testing.T) {
+	type test struct {
+		caseDesc string
+		root     string
+	}
+
+	tests := []test{
+		{caseDesc: "duplicate key in roles object", root: `{"signed":{"_type":"root","roles":{"root":{"keyids":[],"threshold":1},"root":{"keyids":[],"threshold":1}}},"signatures":[]}`},
+		{caseDesc: "role redefined in second roles object", root: `{"signed":{"_type":"root","roles":{"root":{"keyids":[],"threshold":1}},"roles":{"root":{"keyids":[],"threshold":2}}},"signatures":[]}`},
+	}
+
+	for _, tc := range tests {
+		if _, err := NewPublicKey(strings.NewReader(tc.root)); !errors.Is(err, ErrDuplicateRole) {
+			t.Errorf("%v: expected ErrDuplicateRole, got %v", tc.caseDesc, err)
+		}
+	}
+
+	if err := checkDuplicateRoles([]byte(`{"_type":"root","roles":{"root":{},"targets":{}}}`)); err != nil {
+		t.Errorf("unexpected error for distinct roles: %v", err)
+	}
+}
+
+func TestRevokedKeyList(t *testing.T) {
+	rootKey, targetsKey, otherKey := testKey(1), testKey(2), testKey(3)
+	root := testRoot(map[string][]*sign.PrivateKey{
+		"root":    {rootKey},
+		"targets": {targetsKey, otherKey},
+	})
+	rootBytes := testSigned(t, root, rootKey)
+	targets := testSigned(t, testTargets(), targetsKey)
+
+	list := `["` + keyID(otherKey) + `", {"keyid": "` + keyID(targetsKey) + `", "reason": "compromised"}]`
+	k, err := NewPublicKey(bytes.NewReader(rootBytes), WithRevokedKeyList(strings.NewReader(list)))
+	if err != nil {
+		t.Fatalf("unexpected error loading root: %v", err)
+	}
+	s, err := NewSignature(bytes.NewReader(targets))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, k); err == nil {
+		t.Errorf("expected verification with a revoked key to fail")
+	}
+	used := k.RevokedKeysUsed(s)
+	if len(used) != 1 || used[0].KeyID != keyID(targetsKey) || used[0].Reason != "compromised" {
+		t.Errorf("unexpected revoked keys reported: %v", used)
+	}
+
+	// without the revocation list the same manifest verifies
+	k, err = NewPublicKey(bytes.NewReader(rootBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, k); err != nil {
+		t.Errorf("unexpected verification error: %v", err)
+	}
+	if used := k.RevokedKeysUsed(s); len(used) != 0 {
+		t.Errorf("unexpected revoked keys reported: %v", used)
+	}
+
+	// revoking the only root key means the root can no longer verify itself
+	list = `["` + keyID(rootKey) + `"]`
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithRevokedKeyList(strings.NewReader(list))); err == nil {
+		t.Errorf("expected error loading root signed only by a revoked key")
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithRevokedKeyList(strings.NewReader(`{}`))); err == nil {
+		t.Errorf("expected error for malformed revocation list")
+	}
+}
+
+func TestMatchesLogged(t *testing.T) {
+	var empty Signature
+	if _, err := empty.MatchesLogged(nil); err == nil {
+		t.Errorf("expected error for uninitialized signature")
+	}
+
+	k := testKey(1)
+	targets := testTargets()
+	raw := testSigned(t, targets, k)
+	s, err := NewSignature(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged, err := s.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a differently formatted encoding of the same manifest still matches
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, raw, "", "\t"); err != nil {
+		t.Fatal(err)
+	}
+	reformatted, err := NewSignature(&indented)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := reformatted.MatchesLogged(logged); err != nil || !ok {
+		t.Errorf("expected reformatted manifest to match logged value: %v", err)
+	}
+
+	targets.Version = 2
+	other, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := other.MatchesLogged(logged); err != nil || ok {
+		t.Errorf("expected different manifest not to match logged value: %v", err)
+	}
+}
+
+func TestVerifyAgainstAny(t *testing.T) {
+	var roots []*PublicKey
+	for _, seed := range []byte{1, 2, 3} {
+		k := testKey(seed)
+		root, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, root)
+	}
+
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), testKey(2))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, err := VerifyAgainstAny(s, roots); err != nil || i != 1 {
+		t.Errorf("expected match against root 1, got %d: %v", i, err)
+	}
+
+	if i, err := VerifyAgainstAny(s, []*PublicKey{roots[0], nil, roots[2]}); err == nil || i != -1 {
+		t.Errorf("expected no match, got %d", i)
+	}
+	if _, err := VerifyAgainstAny(s, nil); err == nil {
+		t.Errorf("expected error with no candidate roots")
+	}
+}
+
+func TestExplainFailure(t *testing.T) {
+	k1, k2, k3 := testKey(1), testKey(2), testKey(3)
+	root := testRoot(map[string][]*sign.PrivateKey{"root": {k1}, "targets": {k1, k2}})
+	root.Roles["targets"].Threshold = 2
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, root, k1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := testTargets()
+	targets.Version = 5
+
+	type test struct {
+		caseDesc string
+		signers  []*sign.PrivateKey
+		expected string
+	}
+	tests := []test{
+		{caseDesc: "threshold met", signers: []*sign.PrivateKey{k1, k2}, expected: ""},
+		{caseDesc: "one missing signer", signers: []*sign.PrivateKey{k1},
+			expected: "manifest for role 'targets' (version 5) has 1 of 2 required valid signatures; key " + keyID(k2) + " did not sign"},
+		{caseDesc: "unauthorized signer", signers: []*sign.PrivateKey{k1, k3},
+			expected: "manifest for role 'targets' (version 5) has 1 of 2 required valid signatures; key " + keyID(k3) + " is not authorized for role 'targets'; key " + keyID(k2) + " did not sign"},
+	}
+	for _, tc := range tests {
+		s, err := NewSignature(bytes.NewReader(testSigned(t, targets, tc.signers...)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.ExplainFailure(pub)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.caseDesc, err)
+		}
+		if got != tc.expected {
+			t.Errorf("%v: unexpected explanation\nexpected: %v\ngot:      %v", tc.caseDesc, tc.expected, got)
+		}
+	}
+
+	snapshot, err := NewSignature(bytes.NewReader(testSigned(t, testSnapshot(), k1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := snapshot.ExplainFailure(pub); !strings.Contains(got, "does not define role 'snapshot'") {
+		t.Errorf("unexpected explanation for undefined role: %v", got)
+	}
+
+	if _, err := (Signature{}).ExplainFailure(pub); err == nil {
+		t.Errorf("expected error for uninitialized signature")
+	}
+}
+
+func TestLenientTimeParsing(t *testing.T) {
+	rootFile, err := os.Open("testdata/lenient_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rootFile.Close()
+	if _, err := NewPublicKey(rootFile); err == nil {
+		t.Errorf("expected strict parsing to reject root with malformed expires")
+	}
+	if _, err := rootFile.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	root, err := NewPublicKey(rootFile, WithLenientTimeParsing())
+	if err != nil {
+		t.Fatalf("unexpected error loading root with lenient parsing: %v", err)
+	}
+
+	expected := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, f := range []string{
+		"testdata/lenient_targets_no_z.json",
+		"testdata/lenient_targets_space.json",
+		"testdata/lenient_targets_space_no_z.json",
+		"testdata/lenient_targets_fraction.json",
+	} {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewSignature(bytes.NewReader(b)); err == nil {
+			t.Errorf("%v: expected strict parsing to fail", f)
+		}
+		s, err := NewSignature(bytes.NewReader(b), WithLenientTimeParsing())
+		if err != nil {
+			t.Errorf("%v: unexpected error with lenient parsing: %v", f, err)
+			continue
+		}
+		if got := s.expires.Truncate(time.Second); !got.Equal(expected) || s.expires.Location() != time.UTC {
+			t.Errorf("%v: expected expires %v, got %v", f, expected, s.expires)
+		}
+		if err := s.Verify(nil, root); err != nil {
+			t.Errorf("%v: unexpected verification error: %v", f, err)
+		}
+	}
+
+	if _, err := parseExpires("next tuesday", true); err == nil {
+		t.Errorf("expected error for unparseable expires")
+	}
+}
+
+func TestVerifyAsRole(t *testing.T) {
+	rootKey, targetsKey := testKey(1), testKey(2)
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{
+		"root":      {rootKey},
+		"targets":   {targetsKey},
+		"snapshot":  {rootKey},
+		"timestamp": {rootKey},
+	}), rootKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), targetsKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.VerifyAsTargets(pub); err != nil {
+		t.Errorf("unexpected error verifying targets: %v", err)
+	}
+	for name, fn := range map[string]func(*PublicKey) error{
+		"root":      targets.VerifyAsRoot,
+		"snapshot":  targets.VerifyAsSnapshot,
+		"timestamp": targets.VerifyAsTimestamp,
+	} {
+		if err := fn(pub); !errors.Is(err, verify.ErrWrongMetaType) {
+			t.Errorf("expected wrong meta type verifying targets as %v, got %v", name, err)
+		}
+	}
+
+	snapshot, err := NewSignature(bytes.NewReader(testSigned(t, testSnapshot(), rootKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.VerifyAsSnapshot(pub); err != nil {
+		t.Errorf("unexpected error verifying snapshot: %v", err)
+	}
+	timestamp, err := NewSignature(bytes.NewReader(testSigned(t, testTimestamp(), targetsKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := timestamp.VerifyAsTimestamp(pub); err == nil {
+		t.Errorf("expected timestamp signed by the targets key to fail")
+	}
+	if err := (Signature{}).VerifyAsRoot(pub); err == nil {
+		t.Errorf("expected error for uninitialized signature")
+	}
+}
+
+type mapResolver map[string]*data.Key
+
+func (m mapResolver) Resolve(keyID string) (*data.Key, error) {
+	return m[keyID], nil
+}
+
+func TestKeyResolver(t *testing.T) {
+	rootKey, targetsKey, otherKey := testKey(1), testKey(2), testKey(3)
+	root := testRoot(map[string][]*sign.PrivateKey{"root": {rootKey}, "targets": {targetsKey}})
+	// the targets key is authorized by ID only
+	delete(root.Keys, keyID(targetsKey))
+	rootBytes := testSigned(t, root, rootKey)
+
+	targets, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), targetsKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.Verify(nil, pub); err == nil {
+		t.Errorf("expected verification without resolver to fail")
+	}
+
+	pub, err = NewPublicKey(bytes.NewReader(rootBytes), WithKeyResolver(mapResolver{keyID(targetsKey): targetsKey.PublicData()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.Verify(nil, pub); err != nil {
+		t.Errorf("unexpected error verifying with resolved key: %v", err)
+	}
+
+	// a resolver returning the wrong key for the ID must not be trusted
+	pub, err = NewPublicKey(bytes.NewReader(rootBytes), WithKeyResolver(mapResolver{keyID(targetsKey): otherKey.PublicData()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.Verify(nil, pub); !errors.As(err, &verify.ErrWrongID{}) {
+		t.Errorf("expected key id mismatch, got %v", err)
+	}
+
+	// resolved keys must still be authorized for the role
+	other, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), otherKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err = NewPublicKey(bytes.NewReader(rootBytes), WithKeyResolver(mapResolver{keyID(otherKey): otherKey.PublicData()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Verify(nil, pub); err == nil {
+		t.Errorf("expected verification with unauthorized resolved key to fail")
+	}
+
+	// resolved key material must be usable by the verifier for its type, e.g. a truncated ed25519 key
+	short := targetsKey.PublicData()
+	short.Value.Public = short.Value.Public[:16]
+	pub, err = NewPublicKey(bytes.NewReader(rootBytes), WithKeyResolver(mapResolver{keyID(targetsKey): short}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.Verify(nil, pub); !errors.Is(err, verify.ErrInvalidKey) {
+		t.Errorf("expected invalid key, got %v", err)
+	}
+}
+
+// benchmarkRoot returns a root with several keys per role, similar in shape to a production root
+func benchmarkRoot(t testing.TB) []byte {
+	var keys []*sign.PrivateKey
+	for seed := byte(1); seed <= 5; seed++ {
+		keys = append(keys, testKey(seed))
+	}
+	root := testRoot(map[string][]*sign.PrivateKey{"root": keys, "targets": keys, "snapshot": keys[:1], "timestamp": keys[:1]})
+	root.Roles["root"].Threshold = 3
+	root.Roles["targets"].Threshold = 3
+	return testSigned(t, root, keys...)
+}
+
+func TestRootCache(t *testing.T) {
+	rootBytes := benchmarkRoot(t)
+
+	first, err := NewPublicKey(bytes.NewReader(rootBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a reformatted encoding of the same root has the same canonical digest
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, rootBytes, "", "  "); err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewPublicKey(&indented)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("expected identical roots to share a cached key")
+	}
+
+	uncached, err := NewPublicKey(bytes.NewReader(rootBytes), WithoutCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uncached == first {
+		t.Errorf("expected WithoutCache to build a fresh key")
+	}
+
+	revoked, err := NewPublicKey(bytes.NewReader(rootBytes), WithRevokedKeyList(strings.NewReader(`["`+keyID(testKey(5))+`"]`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked == first {
+		t.Errorf("expected root with revoked keys not to be shared")
+	}
+
+	c := newKeyCache(1)
+	c.add("a", first)
+	c.add("b", second)
+	if c.get("a") != nil {
+		t.Errorf("expected oldest entry to be evicted")
+	}
+	if c.get("b") != second {
+		t.Errorf("expected newest entry to be cached")
+	}
+}
+
+func TestRootCacheExpiry(t *testing.T) {
+	rootBytes := benchmarkRoot(t)
+	cached, err := NewPublicKey(bytes.NewReader(rootBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := NewPublicKey(bytes.NewReader(rootBytes)); err != nil || again != cached {
+		t.Fatalf("expected the root to be cached, got %v", err)
+	}
+
+	// move the clock past the expiry of the cached root
+	isExpired := verify.IsExpired
+	now := cached.expires.Add(time.Hour)
+	verify.IsExpired = func(t time.Time) bool { return t.Before(now) }
+	defer func() { verify.IsExpired = isExpired }()
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes)); !errors.As(err, &verify.ErrExpired{}) {
+		t.Errorf("expected ErrExpired loading a cached root after it expired, got %v", err)
+	}
+}
+
+func BenchmarkNewPublicKey(b *testing.B) {
+	rootBytes := benchmarkRoot(b)
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewPublicKey(bytes.NewReader(rootBytes)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithoutCache()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestSignatureMessages(t *testing.T) {
+	k1, k2, k3 := testKey(1), testKey(2), testKey(3)
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k1}, "targets": {k1, k2}}), k1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), k1, k2, k3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := s.SignatureMessages(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected messages for the two authorized keys, got %d", len(messages))
+	}
+	// every message must verify independently of go-tuf
+	keys := map[string]*sign.PrivateKey{keyID(k1): k1, keyID(k2): k2}
+	for _, m := range messages {
+		k, ok := keys[m.KeyID]
+		if !ok {
+			t.Errorf("unexpected key %v", m.KeyID)
+			continue
+		}
+		if !ed25519.Verify(ed25519.PublicKey(k.Value.Public), m.Message, m.Sig) {
+			t.Errorf("signature by %v does not verify over the returned message", m.KeyID)
+		}
+	}
+
+	if _, err := (Signature{}).SignatureMessages(pub); err == nil {
+		t.Errorf("expected error for uninitialized signature")
+	}
+}
+
+func TestInvalidVersion(t *testing.T) {
+	k := testKey(1)
+	for _, version := range []int{0, -1} {
+		targets := testTargets()
+		targets.Version = version
+		if _, err := NewSignature(bytes.NewReader(testSigned(t, targets, k))); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("expected ErrInvalidVersion for manifest version %d, got %v", version, err)
+		}
+
+		root := testRoot(map[string][]*sign.PrivateKey{"root": {k}})
+		root.Version = version
+		if _, err := NewPublicKey(bytes.NewReader(testSigned(t, root, k))); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("expected ErrInvalidVersion for root version %d, got %v", version, err)
+		}
+	}
+}
+
+func TestCertBoundSignatures(t *testing.T) {
+	caPEM, err := os.ReadFile("testdata/cert_ca.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		t.Fatal("failed to load test CA")
+	}
+
+	open := func(name string) *os.File {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+
+	root, err := NewPublicKey(open("testdata/cert_root.json"), WithCertPool(pool))
+	if err != nil {
+		t.Fatalf("unexpected error loading cert bound root: %v", err)
+	}
+	targets, err := NewSignature(open("testdata/cert_targets.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.Verify(nil, root); err != nil {
+		t.Errorf("unexpected error verifying cert bound targets: %v", err)
+	}
+
+	mismatch, err := NewSignature(open("testdata/cert_targets_mismatch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mismatch.Verify(nil, root); !errors.Is(err, ErrCertKeyMismatch) {
+		t.Errorf("expected ErrCertKeyMismatch, got %v", err)
+	}
+
+	// certificates must chain to the configured pool
+	otherPool := x509.NewCertPool()
+	if _, err := NewPublicKey(open("testdata/cert_root.json"), WithCertPool(otherPool)); err == nil {
+		t.Errorf("expected error when certificate does not chain to the pool")
+	}
+	// without a pool only the key binding is checked
+	if _, err := NewPublicKey(open("testdata/cert_root.json")); err != nil {
+		t.Errorf("unexpected error loading cert bound root without pool: %v", err)
+	}
+}
+
+func TestTrustPolicyJSON(t *testing.T) {
+	if _, err := (PublicKey{}).TrustPolicyJSON(); err == nil {
+		t.Errorf("expected error for uninitialized root")
+	}
+
+	k1, k2 := testKey(1), testKey(2)
+	root := testRoot(map[string][]*sign.PrivateKey{"root": {k1}, "targets": {k2, k1}})
+	root.Roles["targets"].Threshold = 2
+	list := `[{"keyid": "` + keyID(k2) + `", "reason": "rotated"}]`
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, root, k1)), WithRevokedKeyList(strings.NewReader(list)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := pub.TrustPolicyJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := pub.TrustPolicyJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("expected trust policy output to be stable")
+	}
+
+	var policy trustPolicy
+	if err := json.Unmarshal(first, &policy); err != nil {
+		t.Fatal(err)
+	}
+	if policy.SpecVersion != "1.0" || policy.Version != 1 || !policy.Expires.Equal(root.Expires) {
+		t.Errorf("unexpected root metadata in policy: %s", first)
+	}
+	targets := policy.Roles["targets"]
+	if targets.Threshold != 2 || len(targets.Keys) != 1 || targets.Keys[0].KeyID != keyID(k1) || targets.Keys[0].KeyType != data.KeyTypeEd25519 {
+		t.Errorf("unexpected targets role in policy: %+v", targets)
+	}
+	if len(policy.RevokedKeys) != 1 || policy.RevokedKeys[0].Reason != "rotated" {
+		t.Errorf("unexpected revoked keys in policy: %+v", policy.RevokedKeys)
+	}
+}
+
+func TestApprovedBodyDigests(t *testing.T) {
+	k := testKey(1)
+	rootBytes := testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)
+
+	approvedTargets := testTargets()
+	s, err := NewSignature(bytes.NewReader(testSigned(t, approvedTargets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := canonicalBody(s.signed.Signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := fmt.Sprintf("sha256:%X", sha256.Sum256(body))
+
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes), WithApprovedBodyDigests("targets", []string{digest}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err != nil {
+		t.Errorf("unexpected error verifying approved body: %v", err)
+	}
+
+	unapproved := testTargets()
+	unapproved.Version = 2
+	other, err := NewSignature(bytes.NewReader(testSigned(t, unapproved, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Verify(nil, pub); !errors.Is(err, ErrBodyNotApproved) {
+		t.Errorf("expected ErrBodyNotApproved, got %v", err)
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithApprovedBodyDigests("targets", []string{"abc"})); err == nil {
+		t.Errorf("expected error for malformed digest")
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	for _, input := range []string{"", " ", "\n\t \r\n"} {
+		if _, err := NewPublicKey(strings.NewReader(input)); !errors.Is(err, ErrEmptyInput) {
+			t.Errorf("expected ErrEmptyInput from NewPublicKey for %q, got %v", input, err)
+		}
+		if _, err := NewSignature(strings.NewReader(input)); !errors.Is(err, ErrEmptyInput) {
+			t.Errorf("expected ErrEmptyInput from NewSignature for %q, got %v", input, err)
+		}
+	}
+}
+
+func TestExpectedTargets(t *testing.T) {
+	k := testKey(1)
+	rootBytes := testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)
+
+	targets := testTargets()
+	for _, name := range []string{"rekor.pub", "ctfe.pub"} {
+		targets.Targets[name] = data.TargetFileMeta{FileMeta: data.FileMeta{Length: 1, Hashes: data.Hashes{"sha256": []byte{1}}}}
+	}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type test struct {
+		caseDesc string
+		opt      Option
+		expected error
+	}
+	tests := []test{
+		{caseDesc: "subset present", opt: WithExpectedTargets([]string{"rekor.pub"})},
+		{caseDesc: "missing target", opt: WithExpectedTargets([]string{"rekor.pub", "fulcio.crt.pem"}), expected: ErrMissingExpectedTargets},
+		{caseDesc: "exact match", opt: WithExactTargets([]string{"ctfe.pub", "rekor.pub"})},
+		{caseDesc: "exact with extra target", opt: WithExactTargets([]string{"rekor.pub"}), expected: ErrUnexpectedTargets},
+	}
+	for _, tc := range tests {
+		pub, err := NewPublicKey(bytes.NewReader(rootBytes), tc.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.VerifyAsTargets(pub); !errors.Is(err, tc.expected) || (tc.expected == nil && err != nil) {
+			t.Errorf("%v: expected %v, got %v", tc.caseDesc, tc.expected, err)
+		}
+	}
+
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes), WithExpectedTargets([]string{"fulcio.crt.pem"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err == nil || !strings.Contains(err.Error(), "fulcio.crt.pem") {
+		t.Errorf("expected missing target to be listed in error, got %v", err)
+	}
+}
+
+func TestVerifyAndExtract(t *testing.T) {
+	k := testKey(1)
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}, "snapshot": {k}}), k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := testTargets()
+	targets.Version = 3
+	targets.Targets["rekor.pub"] = data.TargetFileMeta{FileMeta: data.FileMeta{Length: 42, Hashes: data.Hashes{"sha256": []byte{1, 2}}}}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := s.VerifyAndExtract(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Role() != "targets" || m.Version() != 3 || !m.Expires().Equal(s.expires) {
+		t.Errorf("unexpected manifest metadata: %v %v %v", m.Role(), m.Version(), m.Expires())
+	}
+	got := m.Targets()
+	if len(got) != 1 || got["rekor.pub"].Length != 42 {
+		t.Errorf("unexpected targets: %v", got)
+	}
+	// callers cannot mutate the snapshot
+	delete(got, "rekor.pub")
+	if len(m.Targets()) != 1 {
+		t.Errorf("expected targets snapshot to be immutable")
+	}
+
+	snapshot, err := NewSignature(bytes.NewReader(testSigned(t, testSnapshot(), k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, err := snapshot.VerifyAndExtract(pub); err != nil || m.Targets() != nil {
+		t.Errorf("unexpected result extracting snapshot: %v", err)
+	}
+
+	unsigned, err := NewSignature(bytes.NewReader(testSigned(t, targets, testKey(2))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, err := unsigned.VerifyAndExtract(pub); err == nil || m != nil {
+		t.Errorf("expected no manifest for unverified metadata")
+	}
+}
+
+func TestNoRoles(t *testing.T) {
+	k := testKey(1)
+	root := testRoot(map[string][]*sign.PrivateKey{})
+	root.AddKey(k.PublicData())
+	rootBytes := testSigned(t, root, k)
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes)); !errors.Is(err, ErrNoRoles) {
+		t.Errorf("expected ErrNoRoles, got %v", err)
+	}
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes), WithAllowEmptyRoles())
+	if err != nil {
+		t.Fatalf("unexpected error loading rolesless root with WithAllowEmptyRoles: %v", err)
+	}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err == nil {
+		t.Errorf("expected rolesless root not to verify anything")
+	}
+}
+
+func TestVerifyMetaChain(t *testing.T) {
+	k := testKey(1)
+	load := func(v interface{}) *Signature {
+		s, err := NewSignature(bytes.NewReader(testSigned(t, v, k)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	fileMeta := func(s *Signature) data.FileMeta {
+		canonical, err := s.CanonicalValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest := sha256.Sum256(canonical)
+		return data.FileMeta{Length: int64(len(canonical)), Hashes: data.Hashes{"sha256": digest[:]}}
+	}
+
+	targets := testTargets()
+	targets.Version = 4
+	targetsSig := load(targets)
+
+	snapshot := testSnapshot()
+	snapshot.Meta["targets.json"] = data.SnapshotFileMeta{FileMeta: fileMeta(targetsSig), Version: 4}
+	snapshotSig := load(snapshot)
+
+	timestamp := testTimestamp()
+	timestamp.Meta["snapshot.json"] = data.TimestampFileMeta{FileMeta: fileMeta(snapshotSig), Version: 1}
+	timestampSig := load(timestamp)
+
+	if err := VerifyMetaChain(timestampSig, snapshotSig, targetsSig); err != nil {
+		t.Errorf("unexpected error verifying intact chain: %v", err)
+	}
+
+	// a different targets manifest breaks the snapshot->targets link
+	other := testTargets()
+	other.Version = 4
+	other.Targets["extra"] = data.TargetFileMeta{FileMeta: data.FileMeta{Length: 1}}
+	var chainErr ErrMetaChain
+	if err := VerifyMetaChain(timestampSig, snapshotSig, load(other)); !errors.As(err, &chainErr) || chainErr.Link != "snapshot->targets" {
+		t.Errorf("expected broken snapshot->targets link, got %v", err)
+	}
+
+	// a snapshot not recorded by the timestamp breaks the timestamp->snapshot link
+	otherSnapshot := testSnapshot()
+	otherSnapshot.Meta["targets.json"] = data.SnapshotFileMeta{Version: 4}
+	if err := VerifyMetaChain(timestampSig, load(otherSnapshot), targetsSig); !errors.As(err, &chainErr) || chainErr.Link != "timestamp->snapshot" {
+		t.Errorf("expected broken timestamp->snapshot link, got %v", err)
+	}
+
+	// version only links are checked against the manifest version
+	versionOnly := testSnapshot()
+	versionOnly.Meta["targets.json"] = data.SnapshotFileMeta{Version: 3}
+	if err := checkMetaLink("snapshot->targets", load(versionOnly), "targets.json", targetsSig); !errors.As(err, &chainErr) {
+		t.Errorf("expected version mismatch, got %v", err)
+	}
+
+	if err := VerifyMetaChain(snapshotSig, snapshotSig, targetsSig); err == nil {
+		t.Errorf("expected error when manifests are passed in the wrong order")
+	}
+}
+
+type recordingHook struct {
+	roles, specVersions []string
+	failures            int
+}
+
+func (r *recordingHook) ObserveVerification(role, specVersion string, _ time.Duration, err error) {
+	r.roles = append(r.roles, role)
+	r.specVersions = append(r.specVersions, specVersion)
+	if err != nil {
+		r.failures++
+	}
+}
+
+func TestMetricsHook(t *testing.T) {
+	hook := &recordingHook{}
+	SetMetricsHook(hook)
+	defer SetMetricsHook(nil)
+
+	k := testKey(1)
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := testTargets()
+	targets.SpecVersion = "1.0.19"
+	s, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VerifyAsSnapshot(pub); err == nil {
+		t.Fatal("expected verification as snapshot to fail")
+	}
+	if err := s.VerifyAsTargets(pub); err != nil {
+		t.Fatal(err)
+	}
+
+	// the role mismatch is rejected before any verification work happens
+	if len(hook.roles) != 2 || hook.failures != 0 {
+		t.Errorf("unexpected observations: %+v", hook)
+	}
+	for _, v := range hook.specVersions {
+		if v != "1.0.19" {
+			t.Errorf("expected spec version 1.0.19, got %v", v)
+		}
+	}
+}
+
+func TestDryRunRotation(t *testing.T) {
+	oldKey, newKey := testKey(1), testKey(2)
+	current, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {oldKey}}), oldKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := testRoot(map[string][]*sign.PrivateKey{"root": {newKey}})
+	next.Version = 2
+
+	type test struct {
+		caseDesc string
+		root     *data.Root
+		signers  []*sign.PrivateKey
+		valid    bool
+	}
+	skipped := testRoot(map[string][]*sign.PrivateKey{"root": {newKey}})
+	skipped.Version = 3
+	tests := []test{
+		{caseDesc: "cross signed successor", root: next, signers: []*sign.PrivateKey{oldKey, newKey}, valid: true},
+		{caseDesc: "not signed by current root", root: next, signers: []*sign.PrivateKey{newKey}},
+		{caseDesc: "not self signed", root: next, signers: []*sign.PrivateKey{oldKey}},
+		{caseDesc: "version skipped", root: skipped, signers: []*sign.PrivateKey{oldKey, newKey}},
+	}
+	for _, tc := range tests {
+		err := DryRunRotation(current, bytes.NewReader(testSigned(t, tc.root, tc.signers...)))
+		if (err == nil) != tc.valid {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+	}
+
+	if err := DryRunRotation(current, bytes.NewReader(testSigned(t, skipped, oldKey, newKey))); !errors.Is(err, ErrRotationVersion) {
+		t.Errorf("expected ErrRotationVersion, got %v", err)
+	}
+	if err := DryRunRotation(nil, bytes.NewReader(testSigned(t, next, oldKey, newKey))); err == nil {
+		t.Errorf("expected error with uninitialized current root")
+	}
+}
+
+type countingCodec struct {
+	stdlibCodec
+	unmarshals int
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return c.stdlibCodec.Unmarshal(data, v)
+}
+
+func largeTargets(t testing.TB, n int) []byte {
+	targets := testTargets()
+	for i := 0; i < n; i++ {
+		digest := sha256.Sum256([]byte(fmt.Sprint(i)))
+		targets.Targets[fmt.Sprintf("artifacts/%d.tar.gz", i)] = data.TargetFileMeta{FileMeta: data.FileMeta{Length: int64(i), Hashes: data.Hashes{"sha256": digest[:]}}}
+	}
+	return testSigned(t, targets, testKey(1))
+}
+
+func TestJSONCodec(t *testing.T) {
+	c := &countingCodec{}
+	SetJSONCodec(c)
+	defer SetJSONCodec(nil)
+
+	s, err := NewSignature(bytes.NewReader(largeTargets(t, 10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CanonicalValue(); err != nil {
+		t.Fatal(err)
+	}
+	if c.unmarshals == 0 {
+		t.Errorf("expected the plugged codec to be used")
+	}
+}
+
+func BenchmarkLargeTargets(b *testing.B) {
+	k := testKey(1)
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(b, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	manifest := largeTargets(b, 10000)
+
+	run := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s, err := NewSignature(bytes.NewReader(manifest))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := s.Verify(nil, pub); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.Run("encoding/json", run)
+	b.Run("plugged", func(b *testing.B) {
+		SetJSONCodec(&countingCodec{})
+		defer SetJSONCodec(nil)
+		run(b)
+	})
+}
+
+func TestNoMatchingKeys(t *testing.T) {
+	k := testKey(1)
+	pub, err := NewPublicKey(bytes.NewReader(testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	foreign1, foreign2 := testKey(7), testKey(8)
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), foreign1, foreign2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Verify(nil, pub)
+	if !errors.Is(err, ErrNoMatchingKeys) {
+		t.Fatalf("expected ErrNoMatchingKeys, got %v", err)
+	}
+	for _, id := range []string{keyID(foreign1), keyID(foreign2)} {
+		if !strings.Contains(err.Error(), id) {
+			t.Errorf("expected unmatched key %v to be listed in %v", id, err)
+		}
+	}
+
+	// one known key, even if it made the signature fail, is a regular threshold failure
+	s, err = NewSignature(bytes.NewReader(testSigned(t, testSnapshot(), foreign1, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err == nil || errors.Is(err, ErrNoMatchingKeys) {
+		t.Errorf("expected a non ErrNoMatchingKeys failure, got %v", err)
+	}
+}
+
+func TestTrustedParentRoot(t *testing.T) {
+	primaryFile, err := os.Open("testdata/twolevel_primary_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primaryFile.Close()
+	primary, err := NewPublicKey(primaryFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	intermediateRoot, err := os.ReadFile("testdata/twolevel_intermediate_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the intermediate root is not self-signed, so it is rejected as a standard TUF root
+	if _, err := NewPublicKey(bytes.NewReader(intermediateRoot)); err == nil {
+		t.Fatal("expected intermediate root to be rejected without a parent")
+	}
+	intermediate, err := NewPublicKey(bytes.NewReader(intermediateRoot), WithTrustedParentRoot(primary))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets, err := os.ReadFile("testdata/twolevel_targets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSignature(bytes.NewReader(targets))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, intermediate); err != nil {
+		t.Errorf("unexpected error verifying targets against intermediate root: %v", err)
+	}
+	if err := s.Verify(nil, primary); err == nil {
+		t.Error("expected targets to be rejected by the primary root")
+	}
+
+	// a root signed by anyone other than the parent is not accepted as an intermediate
+	k := testKey(2)
+	selfSigned := testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}}), k)
+	if _, err := NewPublicKey(bytes.NewReader(selfSigned), WithTrustedParentRoot(primary)); err == nil {
+		t.Error("expected root not signed by the parent to be rejected")
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(intermediateRoot), WithTrustedParentRoot(nil)); err == nil {
+		t.Error("expected error for nil parent")
+	}
+}
+
+func TestRepositoryExpiry(t *testing.T) {
+	k := testKey(1)
+	load := func(v interface{}) *Signature {
+		s, err := NewSignature(bytes.NewReader(testSigned(t, v, k)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	at := func(day int) time.Time { return time.Date(2100, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	root := testRoot(map[string][]*sign.PrivateKey{"root": {k}})
+	root.Expires = at(20)
+	targets := testTargets()
+	targets.Expires = at(10)
+	snapshot := testSnapshot()
+	snapshot.Expires = at(5)
+	timestamp := testTimestamp()
+	timestamp.Expires = at(2)
+
+	r, tg, sn, ts := load(root), load(targets), load(snapshot), load(timestamp)
+	if !ts.Expires().Equal(at(2)) {
+		t.Errorf("unexpected timestamp expiry %v", ts.Expires())
+	}
+	expires, role, err := RepositoryExpiry(r, tg, sn, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != "timestamp" || !expires.Equal(at(2)) {
+		t.Errorf("expected timestamp to expire first at %v, got %s at %v", at(2), role, expires)
+	}
+
+	targets.Expires = at(1)
+	if _, role, err := RepositoryExpiry(r, load(targets), sn, ts); err != nil || role != "targets" {
+		t.Errorf("expected targets to expire first, got %q (%v)", role, err)
+	}
+
+	if _, _, err := RepositoryExpiry(r, tg, nil, ts); !errors.Is(err, ErrMissingRole) {
+		t.Errorf("expected ErrMissingRole, got %v", err)
+	}
+	if _, _, err := RepositoryExpiry(r, sn, tg, ts); err == nil {
+		t.Error("expected error for manifests passed in the wrong order")
+	}
+}
+
+// slowVerifier delays every signature check to simulate expensive verification
+type slowVerifier struct {
+	verify.Verifier
+	delay time.Duration
+}
+
+func (v slowVerifier) Verify(key, msg, sig []byte) error {
+	time.Sleep(v.delay)
+	return v.Verifier.Verify(key, msg, sig)
+}
+
+func TestVerifyTimeout(t *testing.T) {
+	var keys []*sign.PrivateKey
+	for seed := byte(1); seed <= 5; seed++ {
+		keys = append(keys, testKey(seed))
+	}
+	root := testRoot(map[string][]*sign.PrivateKey{"root": keys[:1], "targets": keys})
+	root.Roles["targets"].Threshold = len(keys)
+	rootBytes := testSigned(t, root, keys[0])
+
+	bounded, err := NewPublicKey(bytes.NewReader(rootBytes), WithVerifyTimeout(60*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unbounded, err := NewPublicKey(bytes.NewReader(rootBytes), WithoutCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), keys...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := verify.Verifiers[data.KeySchemeEd25519]
+	verify.Verifiers[data.KeySchemeEd25519] = slowVerifier{Verifier: orig, delay: 30 * time.Millisecond}
+	defer func() { verify.Verifiers[data.KeySchemeEd25519] = orig }()
+
+	if err := s.Verify(nil, bounded); !errors.Is(err, ErrVerifyTimeout) {
+		t.Errorf("expected ErrVerifyTimeout, got %v", err)
+	}
+	if err := s.Verify(nil, unbounded); err != nil {
+		t.Errorf("unexpected error without a timeout: %v", err)
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithVerifyTimeout(0)); err == nil {
+		t.Error("expected error for a non-positive timeout")
+	}
+}
+
+func TestCustomField(t *testing.T) {
+	k := testKey(1)
+	signed := testSigned(t, struct {
+		*data.Targets
+		Owner  string            `json:"x-owner"`
+		Labels map[string]string `json:"x-labels"`
+	}{testTargets(), "release-team", map[string]string{"tier": "prod"}}, k)
+
+	s, err := NewSignature(bytes.NewReader(signed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := s.CustomField("x-owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(owner) != `"release-team"` {
+		t.Errorf("unexpected x-owner value %s", owner)
+	}
+	labels, err := s.CustomField("x-labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(labels, &decoded); err != nil || decoded["tier"] != "prod" {
+		t.Errorf("unexpected x-labels value %s (%v)", labels, err)
+	}
+
+	if _, err := s.CustomField("x-missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound, got %v", err)
+	}
+	for _, name := range []string{"targets", "version", "_type"} {
+		if _, err := s.CustomField(name); err == nil || errors.Is(err, ErrFieldNotFound) {
+			t.Errorf("expected standard field %s to be refused, got %v", name, err)
+		}
+	}
+}
+
+func TestInTotoResolver(t *testing.T) {
+	custom := func(s string) *json.RawMessage {
+		raw := json.RawMessage(s)
+		return &raw
+	}
+	targets := testTargets()
+	targets.Targets = data.TargetFiles{
+		"app.tar.gz": {FileMeta: data.FileMeta{Length: 1, Hashes: data.Hashes{"sha256": make([]byte, 32)},
+			Custom: custom(`{"in-toto": ["root.layout", {"path": "build.link", "hashes": {"sha256": "abcd"}}]}`)}},
+		"docs.tar.gz":  {FileMeta: data.FileMeta{Length: 1, Custom: custom(`{"owner": "docs"}`)}},
+		"plain.tar.gz": {FileMeta: data.FileMeta{Length: 1}},
+		"lib.tar.gz":   {FileMeta: data.FileMeta{Length: 1, Custom: custom(`{"links": ["lib.link"]}`)}},
+	}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, targets, testKey(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := InTotoResolver{}.Resolve(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 references, got %+v", refs)
+	}
+	if refs[0].Target != "app.tar.gz" || refs[0].Path != "root.layout" || refs[0].Hashes != nil {
+		t.Errorf("unexpected first reference %+v", refs[0])
+	}
+	if refs[1].Path != "build.link" || refs[1].Hashes["sha256"].String() != "abcd" {
+		t.Errorf("unexpected second reference %+v", refs[1])
+	}
+
+	refs, err = InTotoResolver{Field: "links"}.Resolve(s)
+	if err != nil || len(refs) != 1 || refs[0].Target != "lib.tar.gz" {
+		t.Errorf("unexpected references for custom field: %+v (%v)", refs, err)
+	}
+
+	targets.Targets["bad.tar.gz"] = data.TargetFileMeta{FileMeta: data.FileMeta{Length: 1, Custom: custom(`{"in-toto": "root.layout"}`)}}
+	s, err = NewSignature(bytes.NewReader(testSigned(t, targets, testKey(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (InTotoResolver{}).Resolve(s); err == nil {
+		t.Error("expected error for malformed reference list")
+	}
+
+	snapshot, err := NewSignature(bytes.NewReader(testSigned(t, testSnapshot(), testKey(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (InTotoResolver{}).Resolve(snapshot); err == nil {
+		t.Error("expected error for non-targets manifest")
+	}
+}
+
+type onlineKeys map[string]bool
+
+func (o onlineKeys) IsOnline(keyID string) bool {
+	return o[keyID]
+}
+
+func TestRequiredOfflineRoles(t *testing.T) {
+	rootKey, targetsKey, onlineKey := testKey(1), testKey(2), testKey(3)
+	// the online key is (mistakenly) also authorized for targets
+	rootBytes := testSigned(t, testRoot(map[string][]*sign.PrivateKey{
+		"root": {rootKey}, "targets": {targetsKey, onlineKey}, "timestamp": {onlineKey},
+	}), rootKey)
+	online := onlineKeys{keyID(onlineKey): true}
+
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes), WithRequiredOfflineRoles([]string{"root", "targets"}), WithOnlineKeys(online))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyWith := func(v interface{}, k *sign.PrivateKey) error {
+		s, err := NewSignature(bytes.NewReader(testSigned(t, v, k)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.Verify(nil, pub)
+	}
+	if err := verifyWith(testTargets(), targetsKey); err != nil {
+		t.Errorf("unexpected error for targets signed offline: %v", err)
+	}
+	if err := verifyWith(testTargets(), onlineKey); !errors.Is(err, ErrOnlineKey) {
+		t.Errorf("expected ErrOnlineKey for targets signed online, got %v", err)
+	}
+	if err := verifyWith(testTimestamp(), onlineKey); err != nil {
+		t.Errorf("unexpected error for timestamp signed online: %v", err)
+	}
+
+	// the root's own signature is subject to the policy too
+	online[keyID(rootKey)] = true
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithRequiredOfflineRoles([]string{"root"}), WithOnlineKeys(online)); !errors.Is(err, ErrOnlineKey) {
+		t.Errorf("expected ErrOnlineKey for root signed online, got %v", err)
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithRequiredOfflineRoles([]string{"root"})); err == nil {
+		t.Error("expected error without a key classification")
+	}
+}
+
+func TestNewPublicKeyFromDelta(t *testing.T) {
+	baseFile, err := os.Open("testdata/delta_base_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer baseFile.Close()
+	base, err := NewPublicKey(baseFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delta, err := os.ReadFile("testdata/delta_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := NewPublicKeyFromDelta(base, bytes.NewReader(delta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.meta.Version != 2 {
+		t.Errorf("expected composed root version 2, got %d", next.meta.Version)
+	}
+	if _, ok := next.meta.Keys[keyID(testKey(1))]; ok {
+		t.Error("expected removed key to be absent from the composed root")
+	}
+	// the composed root trusts the rotated in root key and keeps the unchanged targets role
+	for role, signed := range map[string][]byte{
+		"root":    testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {testKey(2)}}), testKey(2)),
+		"targets": testSigned(t, testTargets(), testKey(3)),
+	} {
+		s, err := NewSignature(bytes.NewReader(signed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Verify(nil, next); err != nil {
+			t.Errorf("unexpected error verifying %s against composed root: %v", role, err)
+		}
+	}
+
+	mutate := func(f func(d map[string]interface{})) []byte {
+		var env map[string]interface{}
+		if err := json.Unmarshal(delta, &env); err != nil {
+			t.Fatal(err)
+		}
+		f(env["delta"].(map[string]interface{}))
+		b, err := json.Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	tests := []struct {
+		caseDesc string
+		delta    []byte
+		invalid  bool
+	}{
+		{caseDesc: "wrong type", delta: mutate(func(d map[string]interface{}) { d["_type"] = "root" }), invalid: true},
+		{caseDesc: "wrong base version", delta: mutate(func(d map[string]interface{}) { d["base_version"] = 2 }), invalid: true},
+		{caseDesc: "removes unknown key", delta: mutate(func(d map[string]interface{}) { d["remove_keys"] = []string{"abcd"} }), invalid: true},
+		{caseDesc: "unknown role", delta: mutate(func(d map[string]interface{}) {
+			d["roles"] = map[string]interface{}{"mirror": map[string]interface{}{"keyids": []string{"abcd"}, "threshold": 1}}
+		}), invalid: true},
+		{caseDesc: "missing expires", delta: mutate(func(d map[string]interface{}) { delete(d, "expires") }), invalid: true},
+		{caseDesc: "composed body differs from what was signed", delta: mutate(func(d map[string]interface{}) { d["expires"] = "2099-01-01T00:00:00Z" })},
+		{caseDesc: "skips a version", delta: mutate(func(d map[string]interface{}) { d["version"] = 3 })},
+	}
+	for _, tc := range tests {
+		_, err := NewPublicKeyFromDelta(base, bytes.NewReader(tc.delta))
+		if err == nil {
+			t.Errorf("%v: expected error", tc.caseDesc)
+		} else if errors.Is(err, ErrInvalidDelta) != tc.invalid {
+			t.Errorf("%v: unexpected error %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestCheckSingleKeyControl(t *testing.T) {
+	k1, k2, k3 := testKey(1), testKey(2), testKey(3)
+	tests := []struct {
+		caseDesc  string
+		root      *data.Root
+		revoked   string
+		expectIDs []string
+	}{
+		{
+			caseDesc:  "one key in every role with threshold one",
+			root:      testRoot(map[string][]*sign.PrivateKey{"root": {k1, k2}, "targets": {k1}, "snapshot": {k1, k3}, "timestamp": {k1}}),
+			expectIDs: []string{keyID(k1)},
+		},
+		{
+			caseDesc: "separate keys per role",
+			root:     testRoot(map[string][]*sign.PrivateKey{"root": {k1}, "targets": {k2}, "snapshot": {k3}, "timestamp": {k3}}),
+		},
+		{
+			caseDesc: "shared key behind a higher threshold",
+			root: func() *data.Root {
+				r := testRoot(map[string][]*sign.PrivateKey{"root": {k1, k2}, "targets": {k1}, "snapshot": {k1}, "timestamp": {k1}})
+				r.Roles["root"].Threshold = 2
+				return r
+			}(),
+		},
+		{
+			caseDesc: "shared key revoked",
+			root:     testRoot(map[string][]*sign.PrivateKey{"root": {k1, k2}, "targets": {k1, k2}, "snapshot": {k1}, "timestamp": {k1}}),
+			revoked:  keyID(k1),
+		},
+	}
+	for _, tc := range tests {
+		opts := []Option{WithoutCache()}
+		if tc.revoked != "" {
+			opts = append(opts, WithRevokedKeyList(strings.NewReader(fmt.Sprintf("[%q]", tc.revoked))))
+		}
+		pub, err := NewPublicKey(bytes.NewReader(testSigned(t, tc.root, k1, k2)), opts...)
+		if err != nil {
+			t.Fatalf("%v: %v", tc.caseDesc, err)
+		}
+		single, ids, err := pub.CheckSingleKeyControl()
+		if err != nil {
+			t.Fatalf("%v: %v", tc.caseDesc, err)
+		}
+		if single != (len(tc.expectIDs) > 0) || strings.Join(ids, ",") != strings.Join(tc.expectIDs, ",") {
+			t.Errorf("%v: expected %v, got %v %v", tc.caseDesc, tc.expectIDs, single, ids)
+		}
+		warnings, err := pub.SecurityWarnings()
+		if err != nil {
+			t.Fatalf("%v: %v", tc.caseDesc, err)
+		}
+		if single && (len(warnings) != 1 || warnings[0].Severity != SeverityCritical) {
+			t.Errorf("%v: expected a critical warning, got %v", tc.caseDesc, warnings)
+		} else if !single && len(warnings) != 0 {
+			t.Errorf("%v: unexpected warnings %v", tc.caseDesc, warnings)
+		}
+	}
+}
+
+func TestRootMaxAge(t *testing.T) {
+	k := testKey(1)
+	rootBytes := testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the root itself expires in 2100, but policy only trusts it for 90 days after issue
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithRootMaxAge(time.Now().Add(-91*24*time.Hour), 90*24*time.Hour)); !errors.Is(err, ErrRootTooOld) {
+		t.Errorf("expected ErrRootTooOld loading an old root, got %v", err)
+	}
+
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes), WithRootMaxAge(time.Now().Add(-89*24*time.Hour), 90*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err != nil {
+		t.Errorf("unexpected error within the trust window: %v", err)
+	}
+
+	// a root loaded within its window stops verifying once the window has passed
+	isExpired := verify.IsExpired
+	verify.IsExpired = func(t time.Time) bool { return time.Until(t) <= 2*24*time.Hour }
+	defer func() { verify.IsExpired = isExpired }()
+	if err := s.Verify(nil, pub); !errors.Is(err, ErrRootTooOld) {
+		t.Errorf("expected ErrRootTooOld after the trust window, got %v", err)
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(rootBytes), WithRootMaxAge(time.Time{}, time.Hour)); err == nil {
+		t.Error("expected error for a missing issue time")
+	}
+}
+
+func TestParsedBody(t *testing.T) {
+	s, err := NewSignature(bytes.NewReader(testSigned(t, struct {
+		*data.Targets
+		Layout json.RawMessage `json:"x-layout"`
+	}{testTargets(), json.RawMessage(`{"steps":["build","test"]}`)}, testKey(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := s.ParsedBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"_type", "version", "expires", "targets", "x-layout"} {
+		if _, ok := body[name]; !ok {
+			t.Errorf("expected field %s in parsed body, got %v", name, body)
+		}
+	}
+	var layout struct {
+		Steps []string `json:"steps"`
+	}
+	if err := json.Unmarshal(body["x-layout"], &layout); err != nil || len(layout.Steps) != 2 {
+		t.Errorf("unexpected x-layout %s (%v)", body["x-layout"], err)
+	}
+
+	// callers may modify the result without affecting the manifest
+	body["version"][0] = '9'
+	delete(body, "targets")
+	again, err := s.ParsedBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again["version"]) != "1" || again["targets"] == nil {
+		t.Errorf("manifest was modified through parsed body: %v", again)
+	}
+
+	if _, err := (Signature{}).ParsedBody(); err == nil {
+		t.Error("expected error for uninitialized manifest")
+	}
+}
+
+func TestDelegatedTargets(t *testing.T) {
+	owner, aKey, bKey, cKey, dKey := testKey(1), testKey(2), testKey(3), testKey(4), testKey(5)
+	role := func(name string, k *sign.PrivateKey, terminating bool, paths ...string) delegatedRole {
+		return delegatedRole{Name: name, KeyIDs: []string{keyID(k)}, Threshold: 1, Paths: paths, Terminating: terminating}
+	}
+	dels := delegations{Keys: map[string]*data.Key{}, Roles: []delegatedRole{
+		role("a", aKey, false, "a/*"),
+		role("c", cKey, true, "c/*"),
+		role("d", dKey, false, "c/*"),
+		role("b", bKey, false, "*/*"),
+	}}
+	for _, k := range []*sign.PrivateKey{aKey, bKey, cKey, dKey} {
+		dels.Keys[keyID(k)] = k.PublicData()
+	}
+	delegating := testSigned(t, struct {
+		*data.Targets
+		Delegations delegations `json:"delegations"`
+	}{testTargets(), dels}, owner)
+
+	pub, err := NewPublicKey(bytes.NewReader(delegating))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := func(k *sign.PrivateKey, names ...string) *Signature {
+		targets := testTargets()
+		for _, name := range names {
+			targets.Targets[name] = data.TargetFileMeta{FileMeta: data.FileMeta{Length: 1, Hashes: data.Hashes{"sha256": make([]byte, 32)}}}
+		}
+		s, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		caseDesc string
+		sig      *Signature
+		role     string
+	}{
+		{caseDesc: "signed by the role covering the target", sig: manifest(aKey, "a/x"), role: "a"},
+		{caseDesc: "signed by the only role covering all targets", sig: manifest(bKey, "a/x", "b/y"), role: "b"},
+		{caseDesc: "signed by a role not trusted for the target", sig: manifest(aKey, "b/y")},
+		{caseDesc: "terminating role covering the target did not sign", sig: manifest(dKey, "c/z")},
+		{caseDesc: "signed by the terminating role", sig: manifest(cKey, "c/z"), role: "c"},
+	}
+	for _, tc := range tests {
+		role, err := tc.sig.VerifyDelegated(pub)
+		if tc.role == "" {
+			if !errors.Is(err, ErrNoDelegatedRole) {
+				t.Errorf("%v: expected ErrNoDelegatedRole, got %v (%q)", tc.caseDesc, err, role)
+			}
+			continue
+		}
+		if err != nil || role != tc.role {
+			t.Errorf("%v: expected role %q, got %q (%v)", tc.caseDesc, tc.role, role, err)
+		}
+		if err := tc.sig.Verify(nil, pub); err != nil {
+			t.Errorf("%v: unexpected error from Verify: %v", tc.caseDesc, err)
+		}
+	}
+
+	if _, err := NewPublicKey(bytes.NewReader(testSigned(t, testTargets(), owner))); !errors.Is(err, ErrNoDelegations) {
+		t.Errorf("expected ErrNoDelegations, got %v", err)
+	}
+}
+
+func TestExpiryPolicy(t *testing.T) {
+	k := testKey(1)
+	root := testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}})
+	rootBytes := testSigned(t, root, k)
+	targets := testTargets()
+	targets.Expires = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		policy  ExpiryPolicy
+		expired bool
+	}{
+		{policy: ExpiryIgnore},
+		{policy: ExpiryFlag},
+		{policy: ExpiryEnforce, expired: true},
+	}
+	for _, tc := range tests {
+		pub, err := NewPublicKey(bytes.NewReader(rootBytes), WithExpiryPolicy(tc.policy))
+		if err != nil {
+			t.Fatalf("%v: %v", tc.policy, err)
+		}
+		err = s.Verify(nil, pub)
+		if _, expired := err.(verify.ErrExpired); expired != tc.expired {
+			t.Errorf("%v: unexpected result verifying expired targets: %v", tc.policy, err)
+		} else if !tc.expired && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.policy, err)
+		}
+	}
+
+	// the root itself is subject to the policy
+	root.Expires = targets.Expires
+	expiredRoot := testSigned(t, root, k)
+	if _, err := NewPublicKey(bytes.NewReader(expiredRoot)); !errors.As(err, &verify.ErrExpired{}) {
+		t.Errorf("expected ErrExpired loading expired root by default, got %v", err)
+	}
+	if _, err := NewPublicKey(bytes.NewReader(expiredRoot), WithExpiryPolicy(ExpiryIgnore)); err != nil {
+		t.Errorf("unexpected error loading expired root with ExpiryIgnore: %v", err)
+	}
+	if _, err := NewPublicKey(bytes.NewReader(expiredRoot), WithExpiryPolicy(ExpiryEnforce)); !errors.As(err, &verify.ErrExpired{}) {
+		t.Errorf("expected ErrExpired loading expired root, got %v", err)
+	}
+
+	for _, name := range []string{"ignore", "Enforce", "flag"} {
+		p, err := ParseExpiryPolicy(name)
+		if err != nil || !strings.EqualFold(p.String(), name) {
+			t.Errorf("unexpected policy %v parsing %q (%v)", p, name, err)
+		}
+	}
+	if _, err := ParseExpiryPolicy("strict"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+	if p, err := ParseExpiryPolicy(""); err != nil || p != ExpiryEnforce {
+		t.Errorf("expected ExpiryEnforce for empty policy name, got %v (%v)", p, err)
+	}
+}
+
+func TestVerifyRejectsExpiredByDefault(t *testing.T) {
+	k := testKey(1)
+	rootBytes := testSigned(t, testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}}), k)
+	targets := testTargets()
+	targets.Expires = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, err := NewSignature(bytes.NewReader(testSigned(t, targets, k)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := NewPublicKey(bytes.NewReader(rootBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); !errors.As(err, &verify.ErrExpired{}) {
+		t.Errorf("expected ErrExpired verifying expired targets without options, got %v", err)
+	}
+}
+
+func TestNewPublicKeyFromChain(t *testing.T) {
+	k1, k2, k3 := testKey(1), testKey(2), testKey(3)
+	rootFor := func(version int, k *sign.PrivateKey) *data.Root {
+		r := testRoot(map[string][]*sign.PrivateKey{"root": {k}, "targets": {k}})
+		r.Version = version
+		return r
+	}
+	v1 := testSigned(t, rootFor(1, k1), k1)
+	// intermediate roots may have expired, as long as the final root has not
+	expiredV2 := rootFor(2, k2)
+	expiredV2.Expires = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	v2 := testSigned(t, expiredV2, k1, k2)
+	v3 := testSigned(t, rootFor(3, k3), k2, k3)
+
+	pub, err := NewPublicKeyFromChain([]io.Reader{bytes.NewReader(v1), bytes.NewReader(v2), bytes.NewReader(v3)}, WithExpiryPolicy(ExpiryEnforce))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.meta.Version != 3 {
+		t.Errorf("expected final root version 3, got %d", pub.meta.Version)
+	}
+	s, err := NewSignature(bytes.NewReader(testSigned(t, testTargets(), k3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(nil, pub); err != nil {
+		t.Errorf("unexpected error verifying against the final root: %v", err)
+	}
+
+	// the canonical value holds the whole chain and loads back to an equivalent key
+	canonical, err := pub.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := NewPublicKey(bytes.NewReader(canonical))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := reloaded.CanonicalValue(); err != nil || !bytes.Equal(again, canonical) {
+		t.Errorf("canonical value of reloaded chain differs (%v)", err)
+	}
+
+	tests := []struct {
+		caseDesc string
+		chain    [][]byte
+	}{
+		{caseDesc: "step not signed by the previous root", chain: [][]byte{v1, testSigned(t, rootFor(2, k2), k2), v3}},
+		{caseDesc: "skipped version", chain: [][]byte{v1, v3}},
+		{caseDesc: "final root expired", chain: [][]byte{v1, v2}},
+	}
+	for _, tc := range tests {
+		readers := make([]io.Reader, 0, len(tc.chain))
+		for _, r := range tc.chain {
+			readers = append(readers, bytes.NewReader(r))
+		}
+		if _, err := NewPublicKeyFromChain(readers, WithExpiryPolicy(ExpiryEnforce)); err == nil {
+			t.Errorf("%v: expected error", tc.caseDesc)
+		}
+	}
+	if _, err := NewPublicKeyFromChain(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("expected ErrEmptyInput for an empty chain, got %v", err)
+	}
+}
@@ -0,0 +1,330 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuf
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cjson "github.com/tent/canonical-json-go"
+	"github.com/theupdateframework/go-tuf/data"
+	"github.com/theupdateframework/go-tuf/verify"
+)
+
+// KeyResolver provides key material for key IDs that a root authorizes for a role without
+// embedding the key itself, e.g. when keys are managed in an external store
+type KeyResolver interface {
+	Resolve(keyID string) (*data.Key, error)
+}
+
+// KeyClassifier tells which key IDs belong to online keys, i.e. keys held by automated services
+// rather than kept offline
+type KeyClassifier interface {
+	IsOnline(keyID string) bool
+}
+
+// SignatureMessage is the exact input to a single signature check, allowing the check to be
+// reproduced independently with a third party crypto library
+type SignatureMessage struct {
+	KeyID   string
+	Message []byte
+	Sig     []byte
+}
+
+// SignatureMessages returns, for each signature on the manifest by a key the root authorizes for the
+// manifest's role, the canonical message that was signed and the raw signature bytes
+func (s Signature) SignatureMessages(k *PublicKey) ([]SignatureMessage, error) {
+	if s.signed == nil {
+		return nil, fmt.Errorf("tuf manifest has not been initialized")
+	}
+	if k == nil || k.db == nil {
+		return nil, fmt.Errorf("tuf root has not been initialized")
+	}
+	role := k.db.GetRole(s.Role)
+	if role == nil {
+		return nil, verify.ErrUnknownRole{Role: s.Role}
+	}
+	msg, err := canonicalBody(s.signed.Signed)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []SignatureMessage
+	for _, sig := range s.signed.Signatures {
+		if !role.ValidKey(sig.KeyID) {
+			continue
+		}
+		messages = append(messages, SignatureMessage{
+			KeyID:   sig.KeyID,
+			Message: msg,
+			Sig:     sig.Signature,
+		})
+	}
+	return messages, nil
+}
+
+// verifySigned checks signatures, type and expiry like verify.DB.Verify, but against an expiry parsed
+// by this package so that leniently parsed timestamps are honoured
+func (k *PublicKey) verifySigned(ctx context.Context, s *data.Signed, certs map[string]*x509.Certificate, metaType, role string, expires time.Time) error {
+	if err := k.verifySignatures(ctx, s, certs, role); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx); err != nil {
+		return err
+	}
+	if !strings.EqualFold(metaType, k.metaTypeFor(role)) {
+		return verify.ErrWrongMetaType
+	}
+	if verify.IsExpired(expires) {
+		if err := k.expiryPolicy.apply(role, expires); err != nil {
+			return err
+		}
+	}
+	if !k.notAfter.IsZero() && verify.IsExpired(k.notAfter) {
+		return fmt.Errorf("%w: trusted until %s", ErrRootTooOld, k.notAfter.UTC().Format(time.RFC3339))
+	}
+	if approved, ok := k.approved[role]; ok {
+		body, err := canonicalBody(s.Signed)
+		if err != nil {
+			return err
+		}
+		digest := fmt.Sprintf("%x", sha256.Sum256(body))
+		if _, ok := approved[digest]; !ok {
+			return fmt.Errorf("%w: %s body digest sha256:%s", ErrBodyNotApproved, role, digest)
+		}
+	}
+	if k.metaTypeFor(role) == "targets" && k.expected != nil {
+		return k.checkExpectedTargets(s.Signed)
+	}
+	return nil
+}
+
+// checkExpectedTargets compares the target names listed in a targets body with the expected names
+func (k *PublicKey) checkExpectedTargets(signed json.RawMessage) error {
+	var body struct {
+		Targets map[string]json.RawMessage `json:"targets"`
+	}
+	if err := jsonUnmarshal(signed, &body); err != nil {
+		return err
+	}
+
+	expected := make(map[string]struct{}, len(k.expected))
+	var missing []string
+	for _, name := range k.expected {
+		expected[name] = struct{}{}
+		if _, ok := body.Targets[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: %s", ErrMissingExpectedTargets, strings.Join(missing, ", "))
+	}
+
+	if k.exactExpected {
+		var unexpected []string
+		for name := range body.Targets {
+			if _, ok := expected[name]; !ok {
+				unexpected = append(unexpected, name)
+			}
+		}
+		if len(unexpected) > 0 {
+			sort.Strings(unexpected)
+			return fmt.Errorf("%w: %s", ErrUnexpectedTargets, strings.Join(unexpected, ", "))
+		}
+	}
+	return nil
+}
+
+// verifySignatures mirrors verify.DB.VerifySignatures, additionally resolving keys that are
+// authorized for the role but absent from the root and checking certificates bound to signatures
+func (k *PublicKey) verifySignatures(ctx context.Context, s *data.Signed, certs map[string]*x509.Certificate, roleName string) error {
+	if len(s.Signatures) == 0 {
+		return verify.ErrNoSignatures
+	}
+
+	role := k.roleFor(roleName)
+	if role == nil {
+		return verify.ErrUnknownRole{Role: roleName}
+	}
+
+	if err := k.checkKnownKeys(s.Signatures); err != nil {
+		return err
+	}
+
+	msg, err := canonicalBody(s.Signed)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	valid := 0
+	for _, sig := range s.Signatures {
+		if err := checkDeadline(ctx); err != nil {
+			return err
+		}
+		if !role.ValidKey(sig.KeyID) {
+			continue
+		}
+		key, err := k.keyFor(sig.KeyID)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			continue
+		}
+		if cert, ok := certs[sig.KeyID]; ok {
+			if err := k.checkCert(cert, key); err != nil {
+				return err
+			}
+		}
+
+		if err := verify.Verifiers[key.Type].Verify(key.Value.Public, msg, sig.Signature); err != nil {
+			return err
+		}
+		if _, offline := k.offlineRoles[roleName]; offline && k.online.IsOnline(sig.KeyID) {
+			return fmt.Errorf("%w: %s manifest signed by online key %s", ErrOnlineKey, roleName, sig.KeyID)
+		}
+
+		if _, ok := seen[sig.KeyID]; !ok {
+			for _, id := range key.IDs() {
+				seen[id] = struct{}{}
+			}
+			seen[sig.KeyID] = struct{}{}
+			valid++
+		}
+	}
+	if valid < role.Threshold {
+		return verify.ErrRoleThreshold{Expected: role.Threshold, Actual: valid}
+	}
+
+	return nil
+}
+
+// verifyContext returns the context bounding a single verification, honouring WithVerifyTimeout
+func (k *PublicKey) verifyContext() (context.Context, context.CancelFunc) {
+	if k.verifyTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), k.verifyTimeout)
+}
+
+// checkDeadline returns ErrVerifyTimeout once the verification budget in ctx is exhausted
+func checkDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrVerifyTimeout, err)
+	}
+	return nil
+}
+
+// checkKnownKeys returns ErrNoMatchingKeys, listing the foreign key IDs, if no signature references
+// a key that the key material declares or authorizes for any role
+func (k *PublicKey) checkKnownKeys(sigs []data.Signature) error {
+	if k.meta == nil && k.delegations == nil {
+		return nil
+	}
+	unmatched := make([]string, 0, len(sigs))
+	for _, sig := range sigs {
+		if k.declaresKey(sig.KeyID) {
+			return nil
+		}
+		unmatched = append(unmatched, sig.KeyID)
+	}
+	return fmt.Errorf("%w: %s", ErrNoMatchingKeys, strings.Join(unmatched, ", "))
+}
+
+// declaresKey reports whether id is listed as a key, or authorized for a role, by the key material
+func (k *PublicKey) declaresKey(id string) bool {
+	if k.meta != nil {
+		if _, ok := k.meta.Keys[id]; ok {
+			return true
+		}
+		for _, role := range k.meta.Roles {
+			for _, roleID := range role.KeyIDs {
+				if roleID == id {
+					return true
+				}
+			}
+		}
+	}
+	if k.db.GetKey(id) != nil {
+		return true
+	}
+	if _, ok := k.revoked[id]; ok {
+		return true
+	}
+	for _, d := range k.delegations {
+		if d.role.ValidKey(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyFor returns the verification key for id, or nil if there is no usable key. Keys missing from
+// the root are looked up with the configured resolver; revoked keys are never resolved.
+func (k *PublicKey) keyFor(id string) (*data.Key, error) {
+	if key := k.db.GetKey(id); key != nil {
+		return key, nil
+	}
+	if _, revoked := k.revoked[id]; revoked || k.resolver == nil {
+		return nil, nil
+	}
+	key, err := k.resolver.Resolve(id)
+	if err != nil {
+		return nil, fmt.Errorf("resolving key %s: %w", id, err)
+	}
+	if key == nil {
+		return nil, nil
+	}
+	v, ok := verify.Verifiers[key.Type]
+	if !ok {
+		return nil, nil
+	}
+	if !v.ValidKey(key.Value.Public) {
+		return nil, verify.ErrInvalidKey
+	}
+	if !key.ContainsID(id) {
+		return nil, verify.ErrWrongID{}
+	}
+	return key, nil
+}
+
+// canonicalBody returns the canonical JSON encoding of a signed body as used for signing
+func canonicalBody(signed json.RawMessage) ([]byte, error) {
+	var decoded map[string]interface{}
+	if err := jsonUnmarshal(signed, &decoded); err != nil {
+		return nil, err
+	}
+	return cjson.Marshal(decoded)
+}
+
+// canonicalSigned returns the canonical JSON encoding of a signed envelope with a canonical body
+func canonicalSigned(s *data.Signed) ([]byte, error) {
+	body, err := canonicalBody(s.Signed)
+	if err != nil {
+		return nil, err
+	}
+	return cjson.Marshal(&data.Signed{
+		Signed:     body,
+		Signatures: s.Signatures})
+}
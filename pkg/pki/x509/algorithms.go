@@ -0,0 +1,107 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	sigsig "github.com/sigstore/sigstore/pkg/signature"
+)
+
+// Signature schemes detected by Signature.Verify
+const (
+	AlgorithmECDSASHA256       = "ecdsa-sha256"
+	AlgorithmRSAPKCS1v15SHA256 = "rsa-pkcs1v15-sha256"
+	AlgorithmRSAPSSSHA256      = "rsa-pss-sha256"
+	AlgorithmEd25519           = "ed25519"
+	AlgorithmEd25519ph         = "ed25519ph"
+)
+
+type signatureScheme struct {
+	name     string
+	verifier sigsig.Verifier
+}
+
+// signatureSchemes returns the schemes a signature by pub may use, in the order they are tried
+func signatureSchemes(pub crypto.PublicKey) ([]signatureScheme, error) {
+	switch p := pub.(type) {
+	case *rsa.PublicKey:
+		pkcs1v15, err := sigsig.LoadRSAPKCS1v15Verifier(p, crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		pss, err := sigsig.LoadRSAPSSVerifier(p, crypto.SHA256, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		if err != nil {
+			return nil, err
+		}
+		return []signatureScheme{
+			{name: AlgorithmRSAPKCS1v15SHA256, verifier: pkcs1v15},
+			{name: AlgorithmRSAPSSSHA256, verifier: pss},
+		}, nil
+	case ed25519.PublicKey:
+		pure, err := sigsig.LoadED25519Verifier(p)
+		if err != nil {
+			return nil, err
+		}
+		return []signatureScheme{
+			{name: AlgorithmEd25519, verifier: pure},
+			{name: AlgorithmEd25519ph, verifier: ed25519phVerifier{pure}},
+		}, nil
+	case *ecdsa.PublicKey:
+		v, err := sigsig.LoadECDSAVerifier(p, crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		return []signatureScheme{{name: AlgorithmECDSASHA256, verifier: v}}, nil
+	}
+	v, err := sigsig.LoadVerifier(pub, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return []signatureScheme{{verifier: v}}, nil
+}
+
+// ed25519phVerifier verifies Ed25519ph (RFC 8032 section 5.1) signatures, which sign the SHA-512
+// digest of the message rather than the message itself
+type ed25519phVerifier struct {
+	*sigsig.ED25519Verifier
+}
+
+func (e ed25519phVerifier) VerifySignature(signature, message io.Reader, _ ...sigsig.VerifyOption) error {
+	pub, err := e.PublicKey()
+	if err != nil {
+		return err
+	}
+	sig, err := ioutil.ReadAll(signature)
+	if err != nil {
+		return err
+	}
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return err
+	}
+	if err := ed25519.VerifyWithOptions(pub.(ed25519.PublicKey), h.Sum(nil), sig, &ed25519.Options{Hash: crypto.SHA512}); err != nil {
+		return errors.New("failed to verify ed25519ph signature")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// SCTListOID is the extension carrying embedded SCTs, defined in RFC 6962 section 3.3
+var SCTListOID asn1.ObjectIdentifier = []int{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// TLS hash and signature algorithm identifiers used in SCTs (RFC 5246 section 7.4.1.4.1)
+const (
+	tlsHashSHA256 = 4
+	tlsSigRSA     = 1
+	tlsSigECDSA   = 3
+)
+
+type sct struct {
+	version    uint8
+	logID      []byte
+	timestamp  []byte
+	extensions []byte
+	hashAlg    uint8
+	sigAlg     uint8
+	signature  []byte
+}
+
+// embeddedSCTs returns the SCTs embedded in c, if any
+func embeddedSCTs(c *x509.Certificate) ([]sct, error) {
+	var raw []byte
+	for _, ext := range c.Extensions {
+		if ext.Id.Equal(SCTListOID) {
+			if _, err := asn1.Unmarshal(ext.Value, &raw); err != nil {
+				return nil, fmt.Errorf("invalid SCT list extension: %w", err)
+			}
+		}
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var list, entry cryptobyte.String
+	s := cryptobyte.String(raw)
+	if !s.ReadUint16LengthPrefixed(&list) || !s.Empty() {
+		return nil, errors.New("invalid SCT list encoding")
+	}
+	var scts []sct
+	for !list.Empty() {
+		if !list.ReadUint16LengthPrefixed(&entry) {
+			return nil, errors.New("invalid SCT list encoding")
+		}
+		var v sct
+		var ext, sig cryptobyte.String
+		if !entry.ReadUint8(&v.version) ||
+			!entry.ReadBytes(&v.logID, sha256.Size) ||
+			!entry.ReadBytes(&v.timestamp, 8) ||
+			!entry.ReadUint16LengthPrefixed(&ext) ||
+			!entry.ReadUint8(&v.hashAlg) ||
+			!entry.ReadUint8(&v.sigAlg) ||
+			!entry.ReadUint16LengthPrefixed(&sig) ||
+			!entry.Empty() {
+			return nil, errors.New("invalid SCT encoding")
+		}
+		v.extensions, v.signature = ext, sig
+		scts = append(scts, v)
+	}
+	return scts, nil
+}
+
+// precertTBS reconstructs the TBSCertificate the log signed, which is the leaf's with the SCT list removed
+func precertTBS(c *x509.Certificate) ([]byte, error) {
+	input := cryptobyte.String(c.RawTBSCertificate)
+	var tbs cryptobyte.String
+	if !input.ReadASN1(&tbs, cbasn1.SEQUENCE) {
+		return nil, errors.New("invalid TBSCertificate")
+	}
+	var b cryptobyte.Builder
+	b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		for !tbs.Empty() {
+			var element cryptobyte.String
+			var tag cbasn1.Tag
+			if !tbs.ReadAnyASN1Element(&element, &tag) {
+				b.SetError(errors.New("invalid TBSCertificate"))
+				return
+			}
+			if tag != cbasn1.Tag(3).Constructed().ContextSpecific() {
+				b.AddBytes(element)
+				continue
+			}
+			var extsWrapper, exts cryptobyte.String
+			if !element.ReadASN1(&extsWrapper, tag) || !extsWrapper.ReadASN1(&exts, cbasn1.SEQUENCE) {
+				b.SetError(errors.New("invalid TBSCertificate extensions"))
+				return
+			}
+			b.AddASN1(tag, func(b *cryptobyte.Builder) {
+				b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					for !exts.Empty() {
+						var ext, body cryptobyte.String
+						var oid asn1.ObjectIdentifier
+						if !exts.ReadASN1Element(&ext, cbasn1.SEQUENCE) {
+							b.SetError(errors.New("invalid extension"))
+							return
+						}
+						body = ext
+						if !body.ReadASN1(&body, cbasn1.SEQUENCE) || !body.ReadASN1ObjectIdentifier(&oid) {
+							b.SetError(errors.New("invalid extension"))
+							return
+						}
+						if !oid.Equal(SCTListOID) {
+							b.AddBytes(ext)
+						}
+					}
+				})
+			})
+		}
+	})
+	return b.Bytes()
+}
+
+// verify checks that s is a valid precertificate SCT for leaf, issued by issuer, from the log holding logKey
+func (s sct) verify(leaf, issuer *x509.Certificate, logKey crypto.PublicKey) error {
+	spki, err := x509.MarshalPKIXPublicKey(logKey)
+	if err != nil {
+		return err
+	}
+	if logID := sha256.Sum256(spki); !bytes.Equal(logID[:], s.logID) {
+		return errors.New("SCT was issued by a different log")
+	}
+	if s.version != 0 || s.hashAlg != tlsHashSHA256 {
+		return errors.New("unsupported SCT version or hash algorithm")
+	}
+	tbs, err := precertTBS(leaf)
+	if err != nil {
+		return err
+	}
+
+	// digitally-signed struct from RFC 6962 section 3.2, for a precert_entry
+	var b cryptobyte.Builder
+	b.AddUint8(0) // v1
+	b.AddUint8(0) // certificate_timestamp
+	b.AddBytes(s.timestamp)
+	b.AddUint16(1) // precert_entry
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	b.AddBytes(issuerKeyHash[:])
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(tbs) })
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(s.extensions) })
+	signed, err := b.Bytes()
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(signed)
+
+	switch key := logKey.(type) {
+	case *ecdsa.PublicKey:
+		if s.sigAlg == tlsSigECDSA && ecdsa.VerifyASN1(key, digest[:], s.signature) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if s.sigAlg == tlsSigRSA && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], s.signature) == nil {
+			return nil
+		}
+	}
+	return errors.New("invalid SCT signature")
+}
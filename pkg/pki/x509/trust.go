@@ -0,0 +1,124 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrUntrustedCertificate is returned when a certificate does not chain to any of the trusted roots
+	ErrUntrustedCertificate = errors.New("x509: certificate does not chain to a trusted root")
+	// ErrMissingSCT is returned when SCT verification is enabled and a certificate carries no valid SCT
+	ErrMissingSCT = errors.New("x509: certificate has no valid signed certificate timestamp from a trusted log")
+)
+
+// Option configures how a certificate presented as a public key is validated
+type Option func(*trustOptions)
+
+type trustOptions struct {
+	roots     *x509.CertPool
+	ctLogKeys []crypto.PublicKey
+}
+
+// WithTrustedRoots requires certificates to chain to one of roots, using any intermediates
+// supplied after the leaf in the PEM blob. Bare public keys are not affected.
+func WithTrustedRoots(roots *x509.CertPool) Option {
+	return func(o *trustOptions) {
+		o.roots = roots
+	}
+}
+
+// WithSCTVerification requires certificates to embed at least one SCT signed by one of the
+// given certificate transparency logs. It only takes effect together with WithTrustedRoots,
+// since verifying an embedded SCT needs the issuer of the leaf.
+func WithSCTVerification(logKeys ...crypto.PublicKey) Option {
+	return func(o *trustOptions) {
+		o.ctLogKeys = logKeys
+	}
+}
+
+var (
+	defaultOptionsMu sync.RWMutex
+	defaultOptions   []Option
+)
+
+// SetDefaultOptions sets the options applied by every call to NewPublicKey, ahead of any passed
+// explicitly; the server uses this to apply its configured trust roots to all entry types
+func SetDefaultOptions(opts ...Option) {
+	defaultOptionsMu.Lock()
+	defer defaultOptionsMu.Unlock()
+	defaultOptions = opts
+}
+
+func newOptions(opts []Option) *trustOptions {
+	o := &trustOptions{}
+	defaultOptionsMu.RLock()
+	for _, opt := range defaultOptions {
+		opt(o)
+	}
+	defaultOptionsMu.RUnlock()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// verifyTrust checks the certificate against the configured roots and CT logs
+func (c *cert) verifyTrust(o *trustOptions) error {
+	if o.roots == nil {
+		return nil
+	}
+	intermediates := x509.NewCertPool()
+	for _, i := range c.intermediates {
+		intermediates.AddCert(i)
+	}
+	chains, err := c.c.Verify(x509.VerifyOptions{
+		Roots:         o.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUntrustedCertificate, err)
+	}
+	if len(o.ctLogKeys) == 0 {
+		return nil
+	}
+	return verifyEmbeddedSCTs(chains[0], o.ctLogKeys)
+}
+
+// verifyEmbeddedSCTs succeeds if the leaf of chain embeds an SCT from any of the given logs
+func verifyEmbeddedSCTs(chain []*x509.Certificate, logKeys []crypto.PublicKey) error {
+	if len(chain) < 2 {
+		return fmt.Errorf("%w: issuer of the certificate is unknown", ErrMissingSCT)
+	}
+	scts, err := embeddedSCTs(chain[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMissingSCT, err)
+	}
+	for _, s := range scts {
+		for _, key := range logKeys {
+			if s.verify(chain[0], chain[1], key) == nil {
+				return nil
+			}
+		}
+	}
+	return ErrMissingSCT
+}
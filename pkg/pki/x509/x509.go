@@ -28,8 +28,8 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator"
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
-	sigsig "github.com/sigstore/sigstore/pkg/signature"
 )
 
 // EmailAddressOID defined by https://oidref.com/1.2.840.113549.1.9.1
@@ -37,6 +37,9 @@ var EmailAddressOID asn1.ObjectIdentifier = []int{1, 2, 840, 113549, 1, 9, 1}
 
 type Signature struct {
 	signature []byte
+	algorithm string
+	// fallback is set when the detected scheme is not the first one tried for the key type
+	fallback bool
 }
 
 // NewSignature creates and validates an x509 signature object
@@ -55,8 +58,9 @@ func (s Signature) CanonicalValue() ([]byte, error) {
 	return s.signature, nil
 }
 
-// Verify implements the pki.Signature interface
-func (s Signature) Verify(r io.Reader, k interface{}) error {
+// Verify implements the pki.Signature interface; on success the detected signature scheme is
+// available from Algorithm
+func (s *Signature) Verify(r io.Reader, k interface{}) error {
 	if len(s.signature) == 0 {
 		//lint:ignore ST1005 X509 is proper use of term
 		return fmt.Errorf("X509 signature has not been initialized")
@@ -72,11 +76,53 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 		p = key.cert.c.PublicKey
 	}
 
-	verifier, err := sigsig.LoadVerifier(p, crypto.SHA256)
+	s.algorithm, s.fallback = "", false
+	schemes, err := signatureSchemes(p)
 	if err != nil {
 		return err
 	}
-	return verifier.VerifySignature(bytes.NewReader(s.signature), r)
+	if len(schemes) == 1 {
+		if err := schemes[0].verifier.VerifySignature(bytes.NewReader(s.signature), r); err != nil {
+			return err
+		}
+		s.algorithm = schemes[0].name
+		return nil
+	}
+
+	// every candidate scheme needs to see the whole message
+	message, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for i, scheme := range schemes {
+		err := scheme.verifier.VerifySignature(bytes.NewReader(s.signature), bytes.NewReader(message))
+		if err == nil {
+			s.algorithm, s.fallback = scheme.name, i > 0
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Algorithm returns the signature scheme detected by a successful call to Verify, or the
+// empty string if the signature has not been verified
+func (s Signature) Algorithm() string {
+	return s.algorithm
+}
+
+// NonDefaultAlgorithm returns the signature scheme detected by Verify if it is not the default for
+// the key type (i.e. rsa-pss-sha256 or ed25519ph), or the empty string otherwise. Only these schemes
+// are recorded in entries, so the canonical form of entries signed with the default scheme does not
+// change.
+func (s Signature) NonDefaultAlgorithm() string {
+	if !s.fallback {
+		return ""
+	}
+	return s.algorithm
 }
 
 // PublicKey Public Key that follows the x509 standard
@@ -88,16 +134,18 @@ type PublicKey struct {
 type cert struct {
 	c *x509.Certificate
 	b []byte
+	// intermediates are any further certificates supplied after the leaf
+	intermediates []*x509.Certificate
 }
 
 // NewPublicKey implements the pki.PublicKey interface
-func NewPublicKey(r io.Reader) (*PublicKey, error) {
+func NewPublicKey(r io.Reader, opts ...Option) (*PublicKey, error) {
 	rawPub, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
 
-	block, _ := pem.Decode(rawPub)
+	block, rest := pem.Decode(rawPub)
 	if block == nil {
 		return nil, errors.New("invalid public key: failure decoding PEM")
 	}
@@ -114,11 +162,22 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &PublicKey{
+		var intermediates []*x509.Certificate
+		if rest = bytes.TrimSpace(rest); len(rest) > 0 {
+			if intermediates, err = cryptoutils.UnmarshalCertificatesFromPEM(rest); err != nil {
+				return nil, fmt.Errorf("invalid certificate chain: %w", err)
+			}
+		}
+		k := &PublicKey{
 			cert: &cert{
-				c: c,
-				b: block.Bytes,
-			}}, nil
+				c:             c,
+				b:             block.Bytes,
+				intermediates: intermediates,
+			}}
+		if err := k.cert.verifyTrust(newOptions(opts)); err != nil {
+			return nil, err
+		}
+		return k, nil
 	}
 	return nil, fmt.Errorf("invalid public key: cannot handle type %v", block.Type)
 }
@@ -130,7 +189,7 @@ func (k PublicKey) CanonicalValue() (encoded []byte, err error) {
 	case k.key != nil:
 		encoded, err = cryptoutils.MarshalPublicKeyToPEM(k.key)
 	case k.cert != nil:
-		encoded, err = cryptoutils.MarshalCertificateToPEM(k.cert.c)
+		encoded, err = cryptoutils.MarshalCertificatesToPEM(append([]*x509.Certificate{k.cert.c}, k.cert.intermediates...))
 	default:
 		err = fmt.Errorf("x509 public key has not been initialized")
 	}
@@ -139,6 +198,9 @@ func (k PublicKey) CanonicalValue() (encoded []byte, err error) {
 }
 
 func (k PublicKey) CryptoPubKey() crypto.PublicKey {
+	if k.cert != nil {
+		return k.cert.c.PublicKey
+	}
 	return k.key
 }
 
@@ -157,6 +219,18 @@ func (k PublicKey) EmailAddresses() []string {
 	return names
 }
 
+// Identities implements the pki.IdentityProvider interface
+func (k PublicKey) Identities() []identity.Identity {
+	ids := identity.Emails(k.EmailAddresses())
+	if k.cert != nil {
+		return append(ids, identity.Certificate(k.cert.c)...)
+	}
+	if fp, err := identity.KeyFingerprint(k.key); err == nil {
+		ids = append(ids, identity.Identity{Type: identity.Fingerprint, Value: fp})
+	}
+	return ids
+}
+
 func CertChainToPEM(certChain []*x509.Certificate) ([]byte, error) {
 	var pemBytes bytes.Buffer
 	for _, cert := range certChain {
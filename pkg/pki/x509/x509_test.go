@@ -19,14 +19,31 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/sigstore/rekor/pkg/pki/identity"
 	"github.com/sigstore/rekor/pkg/signer"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/options"
+	"golang.org/x/crypto/cryptobyte"
 )
 
 // Generated with:
@@ -242,3 +259,307 @@ func TestCertChain_Verify(t *testing.T) {
 		}
 	}
 }
+
+func TestIdentities(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spiffe, _ := url.Parse("spiffe://rekor.dev/ns/default/sa/builder")
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "builder"},
+		NotBefore:      time.Now().Add(-time.Minute),
+		NotAfter:       time.Now().Add(time.Hour),
+		EmailAddresses: []string{"Builder@Rekor.dev"},
+		URIs:           []*url.URL{spiffe},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemBytes, err := cryptoutils.MarshalCertificateToPEM(&x509.Certificate{Raw: der})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := identity.KeyFingerprint(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	certDigest := sha256.Sum256(der)
+
+	k, err := NewPublicKey(bytes.NewReader(pemBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []identity.Identity{
+		{Type: identity.Email, Value: "builder@rekor.dev"},
+		{Type: identity.URI, Value: "spiffe://rekor.dev/ns/default/sa/builder"},
+		{Type: identity.Subject, Value: "CN=builder"},
+		{Type: identity.Fingerprint, Value: fingerprint},
+		{Type: identity.CertFingerprint, Value: hex.EncodeToString(certDigest[:])},
+	}
+	if got := k.Identities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Identities() = %v, want %v", got, want)
+	}
+	wantKeys := []string{
+		"builder@rekor.dev",
+		"uri:spiffe://rekor.dev/ns/default/sa/builder",
+		"subject:cn=builder",
+		"fingerprint:" + fingerprint,
+		"certfingerprint:" + hex.EncodeToString(certDigest[:]),
+	}
+	var gotKeys []string
+	for _, id := range k.Identities() {
+		gotKeys = append(gotKeys, id.IndexKey())
+	}
+	if !reflect.DeepEqual(gotKeys, wantKeys) {
+		t.Errorf("index keys = %v, want %v", gotKeys, wantKeys)
+	}
+
+	// a bare public key is only identified by its fingerprint
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err = NewPublicKey(bytes.NewReader(pubPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []identity.Identity{{Type: identity.Fingerprint, Value: fingerprint}}
+	if got := k.Identities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Identities() = %v, want %v", got, want)
+	}
+}
+
+func TestTrustedRoots(t *testing.T) {
+	newCert := func(template, parent *x509.Certificate, pub crypto.PublicKey, priv crypto.Signer) *x509.Certificate {
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+	caTemplate := func(name string, serial int64) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: name},
+			NotBefore:             time.Now().Add(-time.Minute),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+	}
+
+	rootKey, intermediateKey, leafKey := newKey(), newKey(), newKey()
+	rootTemplate := caTemplate("root", 1)
+	root := newCert(rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	intermediate := newCert(caTemplate("intermediate", 2), root, intermediateKey.Public(), rootKey)
+	leaf := newCert(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, intermediate, leafKey.Public(), intermediateKey)
+
+	// a leaf carrying an SCT from logKey, signed over the leaf's TBSCertificate without the SCT list
+	logKey := newKey()
+	sctTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(5),
+		Subject:      pkix.Name{CommonName: "leaf with sct"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	precert := newCert(sctTemplate, intermediate, leafKey.Public(), intermediateKey)
+	sctTemplate.ExtraExtensions = []pkix.Extension{{Id: SCTListOID, Value: testSCTList(t, logKey, intermediate, precert.RawTBSCertificate)}}
+	sctLeaf := newCert(sctTemplate, intermediate, leafKey.Public(), intermediateKey)
+	withSCT, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{sctLeaf, intermediate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey := newKey()
+	otherTemplate := caTemplate("other", 4)
+	other := newCert(otherTemplate, otherTemplate, otherKey.Public(), otherKey)
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(root)
+	untrusted := x509.NewCertPool()
+	untrusted.AddCert(other)
+
+	withChain, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{leaf, intermediate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafOnly, err := cryptoutils.MarshalCertificateToPEM(leaf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		opts    []Option
+		wantErr error
+	}{
+		{name: "no roots configured", pem: leafOnly},
+		{name: "chain to trusted root", pem: withChain, opts: []Option{WithTrustedRoots(trusted)}},
+		{name: "missing intermediate", pem: leafOnly, opts: []Option{WithTrustedRoots(trusted)}, wantErr: ErrUntrustedCertificate},
+		{name: "untrusted root", pem: withChain, opts: []Option{WithTrustedRoots(untrusted)}, wantErr: ErrUntrustedCertificate},
+		{name: "missing SCT", pem: withChain, opts: []Option{WithTrustedRoots(trusted), WithSCTVerification(logKey.Public())}, wantErr: ErrMissingSCT},
+		{name: "valid SCT", pem: withSCT, opts: []Option{WithTrustedRoots(trusted), WithSCTVerification(otherKey.Public(), logKey.Public())}},
+		{name: "SCT from unknown log", pem: withSCT, opts: []Option{WithTrustedRoots(trusted), WithSCTVerification(otherKey.Public())}, wantErr: ErrMissingSCT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewPublicKey(bytes.NewReader(tt.pem), tt.opts...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewPublicKey() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonical, err := k.CanonicalValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(canonical, tt.pem) {
+				t.Errorf("CanonicalValue() did not preserve the supplied chain")
+			}
+		})
+	}
+
+	// bare public keys are not subject to the trusted roots
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(leafKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPublicKey(bytes.NewReader(pubPEM), WithTrustedRoots(trusted)); err != nil {
+		t.Errorf("unexpected error for bare public key: %v", err)
+	}
+}
+
+// testSCTList returns an SCT list extension value holding one precertificate SCT over tbs
+func testSCTList(t *testing.T, logKey *ecdsa.PrivateKey, issuer *x509.Certificate, tbs []byte) []byte {
+	t.Helper()
+	spki, err := x509.MarshalPKIXPublicKey(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(spki)
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	timestamp := []byte{0, 0, 1, 122, 0, 0, 0, 0}
+
+	var signed cryptobyte.Builder
+	signed.AddUint8(0)
+	signed.AddUint8(0)
+	signed.AddBytes(timestamp)
+	signed.AddUint16(1)
+	signed.AddBytes(issuerKeyHash[:])
+	signed.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(tbs) })
+	signed.AddUint16(0)
+	digest := sha256.Sum256(signed.BytesOrPanic())
+	sig, err := ecdsa.SignASN1(rand.Reader, logKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list cryptobyte.Builder
+	list.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint8(0)
+			b.AddBytes(logID[:])
+			b.AddBytes(timestamp)
+			b.AddUint16(0)
+			b.AddUint8(tlsHashSHA256)
+			b.AddUint8(tlsSigECDSA)
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(sig) })
+		})
+	})
+	value, err := asn1.Marshal(list.BytesOrPanic())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestSignature_VerifyAlgorithms(t *testing.T) {
+	data := []byte("hey! this is my test data")
+	rsaKey, err := cryptoutils.UnmarshalPEMToPrivateKey([]byte(pkcs1v15Priv), cryptoutils.SkipPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, err := cryptoutils.UnmarshalPEMToPrivateKey([]byte(ed25519Priv), cryptoutils.SkipPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(data)
+	prehash := sha512.Sum512(data)
+
+	pssSig, err := rsa.SignPSS(rand.Reader, rsaKey.(*rsa.PrivateKey), crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	if err != nil {
+		t.Fatal(err)
+	}
+	phSig, err := edKey.(ed25519.PrivateKey).Sign(rand.Reader, prehash[:], &ed25519.Options{Hash: crypto.SHA512})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		sig        []byte
+		pub        string
+		want       string
+		nonDefault bool
+	}{
+		{name: "rsa pkcs1v15", sig: signData(t, data, pkcs1v15Priv), pub: pkcs1v15Pub, want: AlgorithmRSAPKCS1v15SHA256},
+		{name: "rsa pss", sig: pssSig, pub: pkcs1v15Pub, want: AlgorithmRSAPSSSHA256, nonDefault: true},
+		{name: "ecdsa", sig: signData(t, data, ecdsaPriv), pub: ecdsaPub, want: AlgorithmECDSASHA256},
+		{name: "ed25519", sig: signData(t, data, ed25519Priv), pub: ed25519Pub, want: AlgorithmEd25519},
+		{name: "ed25519ph", sig: phSig, pub: ed25519Pub, want: AlgorithmEd25519ph, nonDefault: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSignature(bytes.NewReader(tt.sig))
+			if err != nil {
+				t.Fatal(err)
+			}
+			pub, err := NewPublicKey(strings.NewReader(tt.pub))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Algorithm() != "" {
+				t.Errorf("Algorithm() before verification = %q", s.Algorithm())
+			}
+			if err := s.Verify(bytes.NewReader(data), pub); err != nil {
+				t.Fatalf("Signature.Verify() error = %v", err)
+			}
+			if got := s.Algorithm(); got != tt.want {
+				t.Errorf("Algorithm() = %q, want %q", got, tt.want)
+			}
+			if got := s.NonDefaultAlgorithm(); (got != "") != tt.nonDefault || (tt.nonDefault && got != tt.want) {
+				t.Errorf("NonDefaultAlgorithm() = %q, want non-default %v", got, tt.nonDefault)
+			}
+			if err := s.Verify(strings.NewReader("tampered"), pub); err == nil {
+				t.Error("Signature.Verify() expected error for tampered data")
+			}
+		})
+	}
+}
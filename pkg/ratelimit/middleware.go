@@ -0,0 +1,201 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// writeEndpoints are the POST endpoints that add to the log or sign something; other POST endpoints are searches
+var writeEndpoints = map[string]bool{
+	"/api/v1/log/entries":       true,
+	"/api/v1/log/entries/batch": true,
+	"/api/v1/log/checkpoint":    true,
+	"/api/v1/timestamp":         true,
+}
+
+// writeMethods are the gRPC methods limited as writes
+var writeMethods = map[string]bool{
+	"/rekor.v1.Rekor/CreateLogEntry": true,
+}
+
+// IsWrite reports whether r is limited as a write request
+func IsWrite(r *http.Request) bool {
+	method, path := util.Route(r)
+	return method == http.MethodPost && writeEndpoints[path]
+}
+
+// Middleware rejects requests from clients that have exceeded their rate with 429 Too Many Requests;
+// rejected is called for each of them, if set
+func (l *Limiter) Middleware(next http.Handler, rejected func(write bool)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		write := IsWrite(r)
+		ok, retryAfter := l.Allow(l.client(r.Context(), l.ClientIP(r)), write)
+		if ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if rejected != nil {
+			rejected(write)
+		}
+		writeLimited(w, retryAfter)
+	})
+}
+
+// AuthFailureMiddleware counts the write requests that next rejects with 401 Unauthorized against the write
+// limit of the client address. It must wrap the middleware authenticating clients, so that once an address is
+// over the limit, its requests are refused before their credentials are checked; requests that authenticate
+// are limited per identity by Middleware instead.
+func (l *Limiter) AuthFailureMiddleware(next http.Handler, rejected func(write bool)) http.Handler {
+	if l.write == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsWrite(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		addr := l.ClientIP(r)
+		if ok, retryAfter := l.write.available(addr, time.Now()); !ok {
+			if rejected != nil {
+				rejected(true)
+			}
+			writeLimited(w, retryAfter)
+			return
+		}
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		if sw.status == http.StatusUnauthorized {
+			l.write.allow(addr, time.Now())
+		}
+	})
+}
+
+// statusWriter records the status of the response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusWriter) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func writeLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(&models.Error{
+		Code:    http.StatusTooManyRequests,
+		Message: "Rate limit exceeded, retry later",
+	})
+}
+
+// UnaryServerInterceptor applies the limits to gRPC calls, attributing them to the peer's address
+func (l *Limiter) UnaryServerInterceptor(rejected func(write bool)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allowGRPC(ctx, info.FullMethod, rejected); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor applies the read limit to the start of gRPC streams
+func (l *Limiter) StreamServerInterceptor(rejected func(write bool)) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allowGRPC(ss.Context(), info.FullMethod, rejected); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// AuthFailureUnaryServerInterceptor counts the write calls that fail with Unauthenticated against the write limit
+// of the peer's address, like AuthFailureMiddleware; it must come before the interceptor authenticating clients
+func (l *Limiter) AuthFailureUnaryServerInterceptor(rejected func(write bool)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if l.write == nil || !writeMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		addr := peerAddress(ctx)
+		if ok, retryAfter := l.write.available(addr, time.Now()); !ok {
+			if rejected != nil {
+				rejected(true)
+			}
+			return nil, grpcLimited(ctx, retryAfter)
+		}
+		resp, err := handler(ctx, req)
+		if status.Code(err) == codes.Unauthenticated {
+			l.write.allow(addr, time.Now())
+		}
+		return resp, err
+	}
+}
+
+func (l *Limiter) allowGRPC(ctx context.Context, method string, rejected func(write bool)) error {
+	write := writeMethods[method]
+	ok, retryAfter := l.Allow(l.client(ctx, peerAddress(ctx)), write)
+	if ok {
+		return nil
+	}
+	if rejected != nil {
+		rejected(write)
+	}
+	return grpcLimited(ctx, retryAfter)
+}
+
+func peerAddress(ctx context.Context) string {
+	client := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		client = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client); err == nil {
+			client = host
+		}
+	}
+	return client
+}
+
+func grpcLimited(ctx context.Context, retryAfter time.Duration) error {
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfterSeconds(retryAfter))))
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded, retry later")
+}
+
+func retryAfterSeconds(d time.Duration) int {
+	s := int(math.Ceil(d.Seconds()))
+	if s < 1 {
+		return 1
+	}
+	return s
+}
@@ -0,0 +1,222 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// idleTimeout is how long the bucket of a client that sent no requests is kept
+	idleTimeout = 3 * time.Minute
+	// sweepInterval is how often idle buckets are removed
+	sweepInterval = time.Minute
+)
+
+// Config sets the sustained rate (requests per second) and burst allowed for each client; a rate of 0
+// leaves that class of endpoints unlimited
+type Config struct {
+	WriteRate  float64
+	WriteBurst int
+	ReadRate   float64
+	ReadBurst  int
+	// TrustedProxies are the CIDRs of reverse proxies whose X-Forwarded-For header is used to find the client
+	TrustedProxies []string
+	// Identity, if set, returns the authenticated identity of the client making a request; requests with an
+	// identity are limited per identity rather than per address
+	Identity func(ctx context.Context) string
+}
+
+// Limiter enforces a token bucket per client, with separate buckets for write and read endpoints
+type Limiter struct {
+	write    *buckets
+	read     *buckets
+	trusted  []*net.IPNet
+	identity func(ctx context.Context) string
+}
+
+// New returns a Limiter for cfg
+func New(cfg Config) (*Limiter, error) {
+	l := &Limiter{identity: cfg.Identity}
+	if cfg.WriteRate < 0 || cfg.ReadRate < 0 {
+		return nil, fmt.Errorf("rate limits must not be negative")
+	}
+	if cfg.WriteRate > 0 {
+		if cfg.WriteBurst < 1 {
+			return nil, fmt.Errorf("write burst must be at least 1")
+		}
+		l.write = newBuckets(rate.Limit(cfg.WriteRate), cfg.WriteBurst)
+	}
+	if cfg.ReadRate > 0 {
+		if cfg.ReadBurst < 1 {
+			return nil, fmt.Errorf("read burst must be at least 1")
+		}
+		l.read = newBuckets(rate.Limit(cfg.ReadRate), cfg.ReadBurst)
+	}
+	for _, cidr := range cfg.TrustedProxies {
+		if !strings.Contains(cidr, "/") {
+			// a single address
+			if strings.Contains(cidr, ":") {
+				cidr += "/128"
+			} else {
+				cidr += "/32"
+			}
+		}
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parsing trusted proxy %q: %w", cidr, err)
+		}
+		l.trusted = append(l.trusted, n)
+	}
+	return l, nil
+}
+
+// Allow takes a token from the bucket of client for the given class of endpoints; if none is left, it
+// returns false and how long the client should wait before retrying
+func (l *Limiter) Allow(client string, write bool) (bool, time.Duration) {
+	b := l.read
+	if write {
+		b = l.write
+	}
+	if b == nil {
+		return true, 0
+	}
+	return b.allow(client, time.Now())
+}
+
+// ClientIP returns the address of the client that sent r. Requests from trusted proxies are attributed to the
+// rightmost address in X-Forwarded-For that is not itself a trusted proxy, since proxies append to the header
+// and anything to the left of the last trusted hop can be forged by the client.
+func (l *Limiter) ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !l.isTrusted(host) {
+		return host
+	}
+	var hops []string
+	for _, h := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(h, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// a malformed entry can't be trusted, nor anything to the left of it
+			return host
+		}
+		host = hop
+		if !l.isTrusted(hop) {
+			break
+		}
+	}
+	return host
+}
+
+// client returns the key of the bucket for a request from addr
+func (l *Limiter) client(ctx context.Context, addr string) string {
+	if l.identity != nil {
+		if id := l.identity(ctx); id != "" {
+			return "identity:" + id
+		}
+	}
+	return addr
+}
+
+func (l *Limiter) isTrusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range l.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+type buckets struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newBuckets(limit rate.Limit, burst int) *buckets {
+	return &buckets{
+		limit:   limit,
+		burst:   burst,
+		clients: map[string]*bucket{},
+	}
+}
+
+// available reports whether client has a token left, or else how long until it has one, without taking it
+func (b *buckets) available(client string, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cb, ok := b.clients[client]
+	if !ok {
+		return true, 0
+	}
+	r := cb.limiter.ReserveN(now, 1)
+	delay := r.DelayFrom(now)
+	r.CancelAt(now)
+	return delay == 0, delay
+}
+
+func (b *buckets) allow(client string, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Sub(b.lastSweep) > sweepInterval {
+		for c, cb := range b.clients {
+			if now.Sub(cb.lastSeen) > idleTimeout {
+				delete(b.clients, c)
+			}
+		}
+		b.lastSweep = now
+	}
+
+	cb, ok := b.clients[client]
+	if !ok {
+		cb = &bucket{limiter: rate.NewLimiter(b.limit, b.burst)}
+		b.clients[client] = cb
+	}
+	cb.lastSeen = now
+
+	r := cb.limiter.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
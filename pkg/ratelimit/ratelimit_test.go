@@ -0,0 +1,294 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuckets(t *testing.T) {
+	b := newBuckets(1, 2)
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if ok, _ := b.allow("a", now); !ok {
+			t.Fatalf("request %d within the burst was rejected", i)
+		}
+	}
+	ok, retryAfter := b.allow("a", now)
+	if ok {
+		t.Fatal("request over the burst was allowed")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("retry after %v, want within a second", retryAfter)
+	}
+	if ok, _ := b.allow("b", now); !ok {
+		t.Error("another client was rejected")
+	}
+	// a rejected request does not consume a token
+	if ok, _ := b.allow("a", now.Add(time.Second)); !ok {
+		t.Error("request after the refill was rejected")
+	}
+
+	b.allow("b", now.Add(idleTimeout+2*sweepInterval))
+	if _, ok := b.clients["a"]; ok {
+		t.Error("idle client was not removed")
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		cfg  Config
+	}{
+		{desc: "negative rate", cfg: Config{WriteRate: -1}},
+		{desc: "no write burst", cfg: Config{WriteRate: 1}},
+		{desc: "no read burst", cfg: Config{ReadRate: 1}},
+		{desc: "bad proxy", cfg: Config{TrustedProxies: []string{"proxy.example.com"}}},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := New(test.cfg); err == nil {
+				t.Errorf("New(%+v) succeeded, want error", test.cfg)
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	l, err := New(Config{TrustedProxies: []string{"10.0.0.0/8", "2001:db8::1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		desc       string
+		remoteAddr string
+		xff        []string
+		want       string
+	}{
+		{desc: "direct", remoteAddr: "192.0.2.1:1234", want: "192.0.2.1"},
+		{desc: "untrusted peer", remoteAddr: "192.0.2.1:1234", xff: []string{"198.51.100.1"}, want: "192.0.2.1"},
+		{desc: "trusted proxy", remoteAddr: "10.0.0.1:1234", xff: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{desc: "trusted ipv6 proxy", remoteAddr: "[2001:db8::1]:1234", xff: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{desc: "forged entries", remoteAddr: "10.0.0.1:1234", xff: []string{"203.0.113.7, 198.51.100.1"}, want: "198.51.100.1"},
+		{desc: "chained proxies", remoteAddr: "10.0.0.1:1234", xff: []string{"198.51.100.1, 10.0.0.2", "10.0.0.3"}, want: "198.51.100.1"},
+		{desc: "only proxies", remoteAddr: "10.0.0.1:1234", xff: []string{"10.0.0.2"}, want: "10.0.0.2"},
+		{desc: "malformed", remoteAddr: "10.0.0.1:1234", xff: []string{"198.51.100.1, garbage"}, want: "10.0.0.1"},
+		{desc: "no header", remoteAddr: "10.0.0.1:1234", want: "10.0.0.1"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/log", nil)
+			r.RemoteAddr = test.remoteAddr
+			for _, h := range test.xff {
+				r.Header.Add("X-Forwarded-For", h)
+			}
+			if got := l.ClientIP(r); got != test.want {
+				t.Errorf("ClientIP = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	l, err := New(Config{WriteRate: 0.1, WriteBurst: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rejected []bool
+	h := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}), func(write bool) { rejected = append(rejected, write) })
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := do(http.MethodPost, "/api/v1/log/entries"); w.Code != http.StatusCreated {
+		t.Fatalf("first write got %d", w.Code)
+	}
+	w := do(http.MethodPost, "/api/v1/log/entries")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second write got %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "10" {
+		t.Errorf("Retry-After = %q, want 10", got)
+	}
+	if len(rejected) != 1 || !rejected[0] {
+		t.Errorf("rejected = %v, want one write", rejected)
+	}
+	// spellings the router dispatches to the same endpoint are writes too
+	for _, method := range []string{"post", http.MethodPost} {
+		for _, path := range []string{"/api/v1/log/entries/", "/api/v1//log/entries", "/api/v1/log/./entries"} {
+			if w := do(method, path); w.Code != http.StatusTooManyRequests {
+				t.Errorf("%s %s got %d, want %d", method, path, w.Code, http.StatusTooManyRequests)
+			}
+		}
+	}
+	// searches and reads are unlimited without a read rate
+	for i := 0; i < 5; i++ {
+		if w := do(http.MethodPost, "/api/v1/log/entries/retrieve"); w.Code != http.StatusCreated {
+			t.Fatalf("search got %d", w.Code)
+		}
+		if w := do(http.MethodGet, "/api/v1/log"); w.Code != http.StatusCreated {
+			t.Fatalf("read got %d", w.Code)
+		}
+	}
+}
+
+type identityKey struct{}
+
+func TestMiddlewarePerIdentity(t *testing.T) {
+	l, err := New(Config{
+		WriteRate:  0.1,
+		WriteBurst: 1,
+		Identity: func(ctx context.Context) string {
+			id, _ := ctx.Value(identityKey{}).(string)
+			return id
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), nil)
+
+	// clients behind the same address get separate buckets once authenticated
+	for _, test := range []struct {
+		identity string
+		want     int
+	}{
+		{identity: "alice", want: http.StatusOK},
+		{identity: "bob", want: http.StatusOK},
+		{identity: "alice", want: http.StatusTooManyRequests},
+		{identity: "", want: http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, test.identity)))
+		if w.Code != test.want {
+			t.Errorf("request from %q got %d, want %d", test.identity, w.Code, test.want)
+		}
+	}
+}
+
+func TestAuthFailureMiddleware(t *testing.T) {
+	l, err := New(Config{WriteRate: 0.1, WriteBurst: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked := 0
+	h := l.AuthFailureMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checked++
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}), nil)
+
+	do := func(addr, token string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", nil)
+		r.RemoteAddr = addr + ":1234"
+		r.Header.Set("Authorization", "Bearer "+token)
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// authenticated writes do not use up the limit of the address
+	for i := 0; i < 5; i++ {
+		if got := do("192.0.2.1", "valid"); got != http.StatusOK {
+			t.Fatalf("authenticated write got %d", got)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if got := do("192.0.2.1", "bogus"); got != http.StatusUnauthorized {
+			t.Fatalf("failed authentication %d got %d", i, got)
+		}
+	}
+	// once over the limit, requests from the address are refused without checking their credentials
+	checked = 0
+	if got := do("192.0.2.1", "bogus"); got != http.StatusTooManyRequests {
+		t.Errorf("failed authentication over the limit got %d, want %d", got, http.StatusTooManyRequests)
+	}
+	if got := do("192.0.2.1", "valid"); got != http.StatusTooManyRequests {
+		t.Errorf("write from an address over the limit got %d, want %d", got, http.StatusTooManyRequests)
+	}
+	for _, target := range []string{"/api/v1//log/entries", "/api/v1/log/./entries"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("post", target, nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("post %s from an address over the limit got %d, want %d", target, w.Code, http.StatusTooManyRequests)
+		}
+	}
+	if checked != 0 {
+		t.Errorf("credentials were checked %d times for an address over the limit", checked)
+	}
+	if got := do("192.0.2.2", "bogus"); got != http.StatusUnauthorized {
+		t.Errorf("failed authentication from another address got %d", got)
+	}
+}
+
+func TestIsWrite(t *testing.T) {
+	for _, test := range []struct {
+		method, target string
+		want           bool
+	}{
+		{http.MethodPost, "/api/v1/log/entries", true},
+		{"post", "/api/v1/log/entries", true},
+		{http.MethodPost, "/api/v1//log/entries", true},
+		{http.MethodPost, "/api/v1/log/./entries", true},
+		{http.MethodPost, "/api/v1/log/entries/", true},
+		{http.MethodPost, "/api/v1/log/entries/retrieve", false},
+		{http.MethodGet, "/api/v1/log/entries", false},
+	} {
+		if got := IsWrite(httptest.NewRequest(test.method, test.target, nil)); got != test.want {
+			t.Errorf("IsWrite(%s %s) = %v, want %v", test.method, test.target, got, test.want)
+		}
+	}
+}
+
+func TestAuthFailureUnaryServerInterceptor(t *testing.T) {
+	l, err := New(Config{WriteRate: 0.1, WriteBurst: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := l.AuthFailureUnaryServerInterceptor(nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/rekor.v1.Rekor/CreateLogEntry"}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+	unauthenticated := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	if _, err := interceptor(ctx, nil, info, unauthenticated); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("first call got %v", err)
+	}
+	if _, err := interceptor(ctx, nil, info, unauthenticated); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("call over the limit got %v, want %v", err, codes.ResourceExhausted)
+	}
+}
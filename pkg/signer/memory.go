@@ -30,7 +30,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sigstore/sigstore/pkg/signature"
-	"github.com/sigstore/sigstore/pkg/signature/kms/gcp"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
 	"github.com/sigstore/sigstore/pkg/signature/options"
 )
 
@@ -52,7 +52,7 @@ func NewTimestampingCertWithChain(ctx context.Context, pub crypto.PublicKey, sig
 
 	// If the signer is not in-memory, retrieve the crypto.Signer
 	var cryptoSigner crypto.Signer
-	if s, ok := signer.(*gcp.SignerVerifier); ok {
+	if s, ok := signer.(kms.SignerVerifier); ok {
 		if cryptoSigner, _, err = s.CryptoSigner(ctx, func(err error) {}); err != nil {
 			return nil, errors.Wrap(err, "getting kms signer")
 		}
@@ -18,17 +18,26 @@ package signer
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"strings"
 
 	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+	"github.com/sigstore/sigstore/pkg/signature/kms/aws"
+	"github.com/sigstore/sigstore/pkg/signature/kms/azure"
 	"github.com/sigstore/sigstore/pkg/signature/kms/gcp"
+	"github.com/sigstore/sigstore/pkg/signature/kms/hashivault"
 )
 
 func New(ctx context.Context, signer string) (signature.Signer, error) {
 	switch {
 	case strings.HasPrefix(signer, gcp.ReferenceScheme):
 		return gcp.LoadSignerVerifier(ctx, signer)
+	case strings.HasPrefix(signer, aws.ReferenceScheme),
+		strings.HasPrefix(signer, azure.ReferenceScheme),
+		strings.HasPrefix(signer, hashivault.ReferenceScheme):
+		return kms.Get(ctx, signer, crypto.SHA256)
 	case signer == MemoryScheme:
 		return NewMemory()
 	default:
@@ -18,18 +18,37 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
 
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/tracing"
+	"github.com/sigstore/rekor/pkg/types"
 
+	gcs "cloud.google.com/go/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 	"gocloud.dev/blob"
 
 	// Blank imports to register storage
+	_ "gocloud.dev/blob/azureblob"
 	_ "gocloud.dev/blob/fileblob"
 	_ "gocloud.dev/blob/gcsblob"
 	_ "gocloud.dev/blob/memblob"
+	_ "gocloud.dev/blob/s3blob"
 )
 
+// encryptingSchemes are the bucket URL schemes that support server-side encryption with a customer managed key
+var encryptingSchemes = map[string]bool{
+	"s3":     true,
+	"gs":     true,
+	"azblob": true,
+}
+
 type AttestationStorage interface {
 	StoreAttestation(ctx context.Context, key string, attestationType string, attestation []byte) error
 	FetchAttestation(ctx context.Context, key string) ([]byte, string, error)
@@ -37,27 +56,59 @@ type AttestationStorage interface {
 
 func NewAttestationStorage() (AttestationStorage, error) {
 	if url := viper.GetString("attestation_storage_bucket"); url != "" {
-		log.Logger.Infof("Configuring attestation storage at %s", url)
-		bucket, err := blob.OpenBucket(context.Background(), url)
+		log.Named(log.SubsystemStorage).Infof("Configuring attestation storage at %s", url)
+		maxSize, err := types.LargestAttestationSize()
 		if err != nil {
 			return nil, err
 		}
-		return &Blob{
-			bucket: bucket,
-		}, nil
+		return OpenBlob(context.Background(), url, maxSize, viper.GetString("attestation_storage_kms_key"))
 	}
 	return nil, errors.New("no storage configured")
 }
 
+// OpenBlob opens the bucket at bucketURL (s3://, gs://, azblob://, file:// or mem://). Attestations larger
+// than maxSize bytes are rejected; if kmsKey is set, attestations are encrypted by the provider with it.
+func OpenBlob(ctx context.Context, bucketURL string, maxSize int, kmsKey string) (*Blob, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return nil, err
+	}
+	if kmsKey != "" && !encryptingSchemes[u.Scheme] {
+		return nil, fmt.Errorf("server-side encryption is not supported for %s:// attestation storage", u.Scheme)
+	}
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Blob{
+		bucket:  bucket,
+		maxSize: maxSize,
+		kmsKey:  kmsKey,
+	}, nil
+}
+
 type Blob struct {
-	bucket *blob.Bucket
+	bucket  *blob.Bucket
+	maxSize int
+	// kmsKey is an AWS KMS key ID, a Cloud KMS key name or an Azure encryption scope, depending on the bucket
+	kmsKey string
 }
 
-func (b *Blob) StoreAttestation(ctx context.Context, key, attestationType string, attestation []byte) error {
-	log.Logger.Infof("storing attestation of type %s at %s", attestationType, key)
-	w, err := b.bucket.NewWriter(ctx, key, &blob.WriterOptions{
+func (b *Blob) StoreAttestation(ctx context.Context, key, attestationType string, attestation []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "storage.StoreAttestation", attribute.String("rekor.attestation.type", attestationType),
+		attribute.Int("rekor.attestation.size", len(attestation)))
+	defer func() { tracing.End(span, err) }()
+	if len(attestation) > b.maxSize {
+		return fmt.Errorf("attestation of %d bytes is larger than the maximum of %d", len(attestation), b.maxSize)
+	}
+	log.Named(log.SubsystemStorage).Infof("storing attestation of type %s at %s", attestationType, key)
+	opts := &blob.WriterOptions{
 		ContentType: attestationType,
-	})
+	}
+	if b.kmsKey != "" {
+		opts.BeforeWrite = encryptWith(b.kmsKey)
+	}
+	w, err := b.bucket.NewWriter(ctx, key, opts)
 	if err != nil {
 		return err
 	}
@@ -67,8 +118,33 @@ func (b *Blob) StoreAttestation(ctx context.Context, key, attestationType string
 	return w.Close()
 }
 
-func (b *Blob) FetchAttestation(ctx context.Context, key string) ([]byte, string, error) {
-	log.Logger.Infof("fetching attestation %s", key)
+// encryptWith sets the provider-specific options for server-side encryption with a customer managed key
+func encryptWith(kmsKey string) func(asFunc func(interface{}) bool) error {
+	return func(asFunc func(interface{}) bool) error {
+		var s3Input *s3manager.UploadInput
+		if asFunc(&s3Input) {
+			s3Input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+			s3Input.SSEKMSKeyId = aws.String(kmsKey)
+			return nil
+		}
+		var gcsWriter *gcs.Writer
+		if asFunc(&gcsWriter) {
+			gcsWriter.KMSKeyName = kmsKey
+			return nil
+		}
+		var azOpts *azblob.UploadStreamToBlockBlobOptions
+		if asFunc(&azOpts) {
+			azOpts.ClientProvidedKeyOptions.EncryptionScope = &kmsKey
+			return nil
+		}
+		return errors.New("server-side encryption is not supported by this attestation storage")
+	}
+}
+
+func (b *Blob) FetchAttestation(ctx context.Context, key string) (_ []byte, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "storage.FetchAttestation")
+	defer func() { tracing.End(span, err) }()
+	log.Named(log.SubsystemStorage).Infof("fetching attestation %s", key)
 	exists, err := b.bucket.Exists(ctx, key)
 	if err != nil {
 		return nil, "", err
@@ -87,3 +163,8 @@ func (b *Blob) FetchAttestation(ctx context.Context, key string) ([]byte, string
 	}
 	return data, att.ContentType, nil
 }
+
+// Close closes the bucket
+func (b *Blob) Close() error {
+	return b.bucket.Close()
+}
@@ -0,0 +1,117 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+func TestBlob(t *testing.T) {
+	ctx := context.Background()
+	for _, url := range []string{"mem://", "file://" + t.TempDir()} {
+		b, err := OpenBlob(ctx, url, 10, "")
+		if err != nil {
+			t.Fatalf("OpenBlob(%v): %v", url, err)
+		}
+		if err := b.StoreAttestation(ctx, "abcd", "text/plain", []byte("hello")); err != nil {
+			t.Fatalf("%v: StoreAttestation: %v", url, err)
+		}
+		data, typ, err := b.FetchAttestation(ctx, "abcd")
+		if err != nil || string(data) != "hello" || typ != "text/plain" {
+			t.Errorf("%v: FetchAttestation = %q, %q, %v", url, data, typ, err)
+		}
+		if data, _, err := b.FetchAttestation(ctx, "missing"); err != nil || data != nil {
+			t.Errorf("%v: FetchAttestation of missing key = %q, %v", url, data, err)
+		}
+		if err := b.StoreAttestation(ctx, "big", "text/plain", []byte("hello world")); err == nil {
+			t.Errorf("%v: expected attestation larger than the maximum to be rejected", url)
+		}
+	}
+}
+
+func TestOpenBlobEncryption(t *testing.T) {
+	if _, err := OpenBlob(context.Background(), "mem://", 10, "key"); err == nil {
+		t.Error("expected server-side encryption to be rejected for mem://")
+	}
+	if _, err := OpenBlob(context.Background(), "ftp://example.com/bucket", 10, ""); err == nil {
+		t.Error("expected unsupported scheme to be rejected")
+	}
+}
+
+func TestEncryptWith(t *testing.T) {
+	s3Input := &s3manager.UploadInput{}
+	gcsWriter := &gcs.Writer{}
+	azOpts := &azblob.UploadStreamToBlockBlobOptions{}
+	for _, test := range []struct {
+		desc   string
+		asFunc func(interface{}) bool
+		check  func() bool
+	}{
+		{
+			desc: "s3",
+			asFunc: func(i interface{}) bool {
+				p, ok := i.(**s3manager.UploadInput)
+				if ok {
+					*p = s3Input
+				}
+				return ok
+			},
+			check: func() bool {
+				return *s3Input.ServerSideEncryption == "aws:kms" && *s3Input.SSEKMSKeyId == "key"
+			},
+		},
+		{
+			desc: "gcs",
+			asFunc: func(i interface{}) bool {
+				p, ok := i.(**gcs.Writer)
+				if ok {
+					*p = gcsWriter
+				}
+				return ok
+			},
+			check: func() bool { return gcsWriter.KMSKeyName == "key" },
+		},
+		{
+			desc: "azure",
+			asFunc: func(i interface{}) bool {
+				p, ok := i.(**azblob.UploadStreamToBlockBlobOptions)
+				if ok {
+					*p = azOpts
+				}
+				return ok
+			},
+			check: func() bool { return *azOpts.ClientProvidedKeyOptions.EncryptionScope == "key" },
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := encryptWith("key")(test.asFunc); err != nil {
+				t.Fatal(err)
+			}
+			if !test.check() {
+				t.Error("encryption options not set")
+			}
+		})
+	}
+
+	if err := encryptWith("key")(func(interface{}) bool { return false }); err == nil {
+		t.Error("expected error for a backend without server-side encryption")
+	}
+}
@@ -0,0 +1,367 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilelog
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/tlog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxLeavesPerRange caps the leaves returned by GetLeavesByRange, as Trillian does
+const maxLeavesPerRange = 1000
+
+func signedRoot(root *types.LogRootV1) (*trillian.SignedLogRoot, error) {
+	b, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.SignedLogRoot{LogRoot: b}, nil
+}
+
+func writable(tree *trillian.Tree, treeType trillian.TreeType) error {
+	if tree.TreeType != treeType {
+		return status.Errorf(codes.FailedPrecondition, "log %d is a %v tree", tree.TreeId, tree.TreeType)
+	}
+	if tree.TreeState != trillian.TreeState_ACTIVE {
+		return status.Errorf(codes.FailedPrecondition, "log %d is %v", tree.TreeId, tree.TreeState)
+	}
+	return nil
+}
+
+// newLeaf checks a submitted leaf and fills in its hashes. Identity hashes other than the Merkle leaf hash are
+// not supported, since only leaf hashes are indexed.
+func (l *Log) newLeaf(in *trillian.LogLeaf) (*trillian.LogLeaf, error) {
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "a leaf is required")
+	}
+	if len(in.LeafValue) > maxEntrySize {
+		return nil, status.Errorf(codes.InvalidArgument, "leaf of %d bytes is larger than the maximum of %d", len(in.LeafValue), maxEntrySize)
+	}
+	leaf := proto.Clone(in).(*trillian.LogLeaf)
+	h := tlog.RecordHash(leaf.LeafValue)
+	leaf.MerkleLeafHash = h[:]
+	if len(leaf.LeafIdentityHash) != 0 && string(leaf.LeafIdentityHash) != string(leaf.MerkleLeafHash) {
+		return nil, status.Error(codes.InvalidArgument, "leaf identity hashes are not supported")
+	}
+	leaf.LeafIdentityHash = leaf.MerkleLeafHash
+	leaf.QueueTimestamp = timestamppb.New(l.now())
+	return leaf, nil
+}
+
+func alreadyExists(leaf *trillian.LogLeaf) *trillian.QueueLeafResponse {
+	return &trillian.QueueLeafResponse{QueuedLeaf: &trillian.QueuedLogLeaf{
+		Leaf:   leaf,
+		Status: status.New(codes.AlreadyExists, "leaf already exists").Proto(),
+	}}
+}
+
+// QueueLeaf queues a leaf to be added to a LOG tree with the next batch, unless a leaf with the same hash is
+// already in the tree or queued
+func (l *Log) QueueLeaf(ctx context.Context, in *trillian.QueueLeafRequest, opts ...grpc.CallOption) (*trillian.QueueLeafResponse, error) {
+	leaf, err := l.newLeaf(in.Leaf)
+	if err != nil {
+		return nil, err
+	}
+	key := string(leaf.MerkleLeafHash)
+	for {
+		tree, root, err := l.state(in.LogId)
+		if err != nil {
+			return nil, err
+		}
+		if err := writable(tree, trillian.TreeType_LOG); err != nil {
+			return nil, err
+		}
+		existing, err := l.leafByHash(ctx, root, leaf.MerkleLeafHash)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return alreadyExists(existing), nil
+		}
+
+		l.mu.Lock()
+		if queued, ok := l.queued[key]; ok {
+			l.mu.Unlock()
+			return alreadyExists(proto.Clone(queued).(*trillian.LogLeaf)), nil
+		}
+		// if a batch was integrated since the lookup, the leaf may be in it
+		if l.root == root {
+			l.pending = append(l.pending, leaf)
+			l.queued[key] = leaf
+			l.mu.Unlock()
+			return &trillian.QueueLeafResponse{QueuedLeaf: &trillian.QueuedLogLeaf{Leaf: proto.Clone(leaf).(*trillian.LogLeaf)}}, nil
+		}
+		l.mu.Unlock()
+	}
+}
+
+// AddSequencedLeaves adds leaves to a PREORDERED_LOG tree at the indexes they are given. Leaves must be added in
+// order; those already in the tree are reported as existing.
+func (l *Log) AddSequencedLeaves(ctx context.Context, in *trillian.AddSequencedLeavesRequest, opts ...grpc.CallOption) (*trillian.AddSequencedLeavesResponse, error) {
+	l.integrating.Lock()
+	defer l.integrating.Unlock()
+	tree, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if err := writable(tree, trillian.TreeType_PREORDERED_LOG); err != nil {
+		return nil, err
+	}
+
+	resp := &trillian.AddSequencedLeavesResponse{}
+	next := root.TreeSize
+	var leaves []*trillian.LogLeaf
+	now := timestamppb.New(l.now())
+	for _, in := range in.Leaves {
+		switch index := uint64(in.LeafIndex); {
+		case in.LeafIndex < 0:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid leaf index %d", in.LeafIndex)
+		case index < root.TreeSize:
+			existing, err := l.readLeaves(ctx, int64(root.TreeSize), index, 1)
+			if err != nil {
+				return nil, err
+			}
+			resp.Results = append(resp.Results, &trillian.QueuedLogLeaf{
+				Leaf:   existing[0],
+				Status: status.New(codes.AlreadyExists, "leaf already exists").Proto(),
+			})
+		case index == next:
+			leaf, err := l.newLeaf(in)
+			if err != nil {
+				return nil, err
+			}
+			leaf.IntegrateTimestamp = now
+			leaves = append(leaves, leaf)
+			resp.Results = append(resp.Results, &trillian.QueuedLogLeaf{Leaf: leaf})
+			next++
+		default:
+			return nil, status.Errorf(codes.FailedPrecondition, "leaf %d added before leaf %d", in.LeafIndex, next)
+		}
+	}
+	if len(leaves) == 0 {
+		return resp, nil
+	}
+
+	newRoot, err := l.integrate(ctx, root, leaves)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	l.root = newRoot
+	signer := l.signer
+	l.mu.Unlock()
+	if signer != nil {
+		if err := l.publishCheckpoint(ctx, signer, newRoot); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// GetLatestSignedLogRoot returns the latest root, with a proof of consistency from FirstTreeSize if it is smaller
+func (l *Log) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	if in.FirstTreeSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid first tree size %d", in.FirstTreeSize)
+	}
+	_, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLatestSignedLogRootResponse{}
+	if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+		return nil, err
+	}
+	if first := uint64(in.FirstTreeSize); first > 0 && first < root.TreeSize {
+		if resp.Proof, err = l.consistencyProof(ctx, root, int64(first), int64(root.TreeSize)); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (l *Log) inclusionProof(ctx context.Context, root *types.LogRootV1, index, size int64) (*trillian.Proof, error) {
+	p, err := tlog.ProveRecord(size, index, l.hashReader(ctx, int64(root.TreeSize), nil))
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.Proof{LeafIndex: index, Hashes: hashes(p)}, nil
+}
+
+func (l *Log) consistencyProof(ctx context.Context, root *types.LogRootV1, size1, size2 int64) (*trillian.Proof, error) {
+	p, err := tlog.ProveTree(size2, size1, l.hashReader(ctx, int64(root.TreeSize), nil))
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.Proof{Hashes: hashes(p)}, nil
+}
+
+func hashes(p []tlog.Hash) [][]byte {
+	b := make([][]byte, len(p))
+	for i := range p {
+		b[i] = p[i][:]
+	}
+	return b
+}
+
+// checkTreeSize checks that size is within the current tree
+func checkTreeSize(root *types.LogRootV1, size int64) error {
+	if size <= 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid tree size %d", size)
+	}
+	if uint64(size) > root.TreeSize {
+		return status.Errorf(codes.OutOfRange, "tree size %d is larger than the current tree size %d", size, root.TreeSize)
+	}
+	return nil
+}
+
+// GetInclusionProof returns the proof that the leaf at LeafIndex is in the tree of TreeSize
+func (l *Log) GetInclusionProof(ctx context.Context, in *trillian.GetInclusionProofRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofResponse, error) {
+	_, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTreeSize(root, in.TreeSize); err != nil {
+		return nil, err
+	}
+	if in.LeafIndex < 0 || in.LeafIndex >= in.TreeSize {
+		return nil, status.Errorf(codes.OutOfRange, "leaf index %d is not in the tree of size %d", in.LeafIndex, in.TreeSize)
+	}
+	resp := &trillian.GetInclusionProofResponse{}
+	if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+		return nil, err
+	}
+	if resp.Proof, err = l.inclusionProof(ctx, root, in.LeafIndex, in.TreeSize); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetInclusionProofByHash returns the proof that the leaf with LeafHash is in the tree of TreeSize
+func (l *Log) GetInclusionProofByHash(ctx context.Context, in *trillian.GetInclusionProofByHashRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofByHashResponse, error) {
+	_, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTreeSize(root, in.TreeSize); err != nil {
+		return nil, err
+	}
+	leaf, err := l.leafByHash(ctx, root, in.LeafHash)
+	if err != nil {
+		return nil, err
+	}
+	if leaf == nil || leaf.LeafIndex >= in.TreeSize {
+		return nil, status.Errorf(codes.NotFound, "no leaf with hash %x in the tree of size %d", in.LeafHash, in.TreeSize)
+	}
+	resp := &trillian.GetInclusionProofByHashResponse{}
+	if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+		return nil, err
+	}
+	proof, err := l.inclusionProof(ctx, root, leaf.LeafIndex, in.TreeSize)
+	if err != nil {
+		return nil, err
+	}
+	resp.Proof = []*trillian.Proof{proof}
+	return resp, nil
+}
+
+// GetConsistencyProof returns the proof that the tree of FirstTreeSize is a prefix of the tree of SecondTreeSize
+func (l *Log) GetConsistencyProof(ctx context.Context, in *trillian.GetConsistencyProofRequest, opts ...grpc.CallOption) (*trillian.GetConsistencyProofResponse, error) {
+	_, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTreeSize(root, in.SecondTreeSize); err != nil {
+		return nil, err
+	}
+	if in.FirstTreeSize <= 0 || in.FirstTreeSize > in.SecondTreeSize {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid first tree size %d", in.FirstTreeSize)
+	}
+	resp := &trillian.GetConsistencyProofResponse{}
+	if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+		return nil, err
+	}
+	if resp.Proof, err = l.consistencyProof(ctx, root, in.FirstTreeSize, in.SecondTreeSize); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetEntryAndProof returns the leaf at LeafIndex and the proof that it is in the tree of TreeSize, or the
+// current tree if that is smaller
+func (l *Log) GetEntryAndProof(ctx context.Context, in *trillian.GetEntryAndProofRequest, opts ...grpc.CallOption) (*trillian.GetEntryAndProofResponse, error) {
+	_, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	size := in.TreeSize
+	if size > int64(root.TreeSize) {
+		size = int64(root.TreeSize)
+	}
+	if in.LeafIndex < 0 || in.LeafIndex >= size {
+		return nil, status.Errorf(codes.OutOfRange, "leaf index %d is not in the tree of size %d", in.LeafIndex, size)
+	}
+	resp := &trillian.GetEntryAndProofResponse{}
+	if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+		return nil, err
+	}
+	leaves, err := l.readLeaves(ctx, int64(root.TreeSize), uint64(in.LeafIndex), 1)
+	if err != nil {
+		return nil, err
+	}
+	resp.Leaf = leaves[0]
+	if resp.Proof, err = l.inclusionProof(ctx, root, in.LeafIndex, size); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLeavesByRange returns up to Count leaves from StartIndex; fewer are returned at the end of the tree
+func (l *Log) GetLeavesByRange(ctx context.Context, in *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	if in.StartIndex < 0 || in.Count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid range of %d leaves from %d", in.Count, in.StartIndex)
+	}
+	_, root, err := l.state(in.LogId)
+	if err != nil {
+		return nil, err
+	}
+	start := uint64(in.StartIndex)
+	if start >= root.TreeSize {
+		return nil, status.Errorf(codes.OutOfRange, "start index %d is not in the tree of size %d", start, root.TreeSize)
+	}
+	count := uint64(in.Count)
+	if count > maxLeavesPerRange {
+		count = maxLeavesPerRange
+	}
+	if start+count > root.TreeSize {
+		count = root.TreeSize - start
+	}
+	resp := &trillian.GetLeavesByRangeResponse{}
+	if resp.Leaves, err = l.readLeaves(ctx, int64(root.TreeSize), start, count); err != nil {
+		return nil, err
+	}
+	if resp.SignedLogRoot, err = signedRoot(root); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,429 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tilelog implements the Trillian log and admin APIs in process, writing the log to an object storage
+// bucket in the C2SP tlog-tiles layout (https://c2sp.org/tlog-tiles). The checkpoint, hash tiles and entry
+// bundles can be served to readers straight from the bucket or a CDN in front of it; rekor-server only
+// needs the bucket to add entries.
+//
+// Submitted leaves are integrated in batches, so that each batch costs a few object writes however many
+// leaves it holds. Besides the public objects, the bucket keeps the tree and its latest root, the
+// timestamps and extra data of leaves, and an index of leaf hashes under state/, which should not be
+// served. Only one process may add leaves to a bucket at a time.
+package tilelog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sigstore/rekor/pkg/log"
+
+	// Blank imports to register storage
+	_ "gocloud.dev/blob/azureblob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/memblob"
+	_ "gocloud.dev/blob/s3blob"
+)
+
+const (
+	checkpointKey = "checkpoint"
+	treeKey       = "state/tree"
+	rootKey       = "state/root"
+	indexPrefix   = "state/index/" // hex Merkle leaf hash -> leaf index
+)
+
+// CheckpointSigner returns the signed checkpoint published for a root
+type CheckpointSigner func(ctx context.Context, root *types.LogRootV1) ([]byte, error)
+
+// Log serves the Trillian log and admin APIs from a bucket holding a single tree
+type Log struct {
+	bucket   *blob.Bucket
+	now      func() time.Time
+	interval time.Duration
+	tiles    *tileCache
+
+	// mu guards the fields below; root is replaced, never modified, when leaves are integrated
+	mu      sync.Mutex
+	tree    *trillian.Tree
+	root    *types.LogRootV1
+	pending []*trillian.LogLeaf
+	queued  map[string]*trillian.LogLeaf // pending leaves by Merkle leaf hash
+	signer  CheckpointSigner
+
+	// integrating is held while leaves are written, so that one batch is written at a time
+	integrating sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var (
+	_ trillian.TrillianLogClient   = (*Log)(nil)
+	_ trillian.TrillianAdminClient = (*Log)(nil)
+)
+
+// Open opens the log in the bucket at bucketURL (s3://, gs://, azblob://, file:// or mem://), integrating the
+// leaves queued on it every batchInterval
+func Open(ctx context.Context, bucketURL string, batchInterval time.Duration) (*Log, error) {
+	if batchInterval <= 0 {
+		return nil, fmt.Errorf("batch interval must be positive")
+	}
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening tile log %v: %w", bucketURL, err)
+	}
+	l := &Log{
+		bucket:   bucket,
+		now:      time.Now,
+		interval: batchInterval,
+		tiles:    newTileCache(),
+		queued:   map[string]*trillian.LogLeaf{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := l.load(ctx); err != nil {
+		bucket.Close()
+		return nil, err
+	}
+	go l.integrator()
+	return l, nil
+}
+
+// load reads the tree and its latest root, if they have been created
+func (l *Log) load(ctx context.Context) error {
+	v, err := l.read(ctx, treeKey)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var tree trillian.Tree
+	if err := proto.Unmarshal(v, &tree); err != nil {
+		return fmt.Errorf("decoding tree: %w", err)
+	}
+	l.tree = &tree
+
+	v, err = l.read(ctx, rootKey)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(v); err != nil {
+		return fmt.Errorf("decoding root: %w", err)
+	}
+	l.root = &root
+	return nil
+}
+
+// Close integrates the leaves still queued and closes the bucket
+func (l *Log) Close() error {
+	close(l.stop)
+	<-l.done
+	return l.bucket.Close()
+}
+
+// PublishCheckpoints writes a checkpoint signed with signer for the current root and every later one
+func (l *Log) PublishCheckpoints(ctx context.Context, signer CheckpointSigner) error {
+	l.integrating.Lock()
+	defer l.integrating.Unlock()
+	l.mu.Lock()
+	l.signer = signer
+	root := l.root
+	l.mu.Unlock()
+	if root == nil {
+		return nil
+	}
+	return l.publishCheckpoint(ctx, signer, root)
+}
+
+func (l *Log) publishCheckpoint(ctx context.Context, signer CheckpointSigner, root *types.LogRootV1) error {
+	checkpoint, err := signer(ctx, root)
+	if err != nil {
+		return fmt.Errorf("signing checkpoint: %w", err)
+	}
+	return l.write(ctx, checkpointKey, checkpoint, "text/plain; charset=utf-8", false)
+}
+
+func (l *Log) integrator() {
+	defer close(l.done)
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			if err := l.flush(context.Background()); err != nil {
+				log.Named(log.SubsystemTileLog).Errorf("integrating queued leaves: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := l.flush(context.Background()); err != nil {
+				log.Named(log.SubsystemTileLog).Errorf("integrating queued leaves: %v", err)
+			}
+		}
+	}
+}
+
+// flush integrates the leaves queued so far; on failure they stay queued and are retried with the next batch
+func (l *Log) flush(ctx context.Context) error {
+	l.integrating.Lock()
+	defer l.integrating.Unlock()
+
+	l.mu.Lock()
+	root := l.root
+	batch := make([]*trillian.LogLeaf, len(l.pending))
+	for i, leaf := range l.pending {
+		batch[i] = proto.Clone(leaf).(*trillian.LogLeaf)
+		batch[i].LeafIndex = int64(root.TreeSize) + int64(i)
+	}
+	l.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	now := timestamppb.New(l.now())
+	for _, leaf := range batch {
+		leaf.IntegrateTimestamp = now
+	}
+	newRoot, err := l.integrate(ctx, root, batch)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.root = newRoot
+	for _, leaf := range l.pending[:len(batch)] {
+		delete(l.queued, string(leaf.MerkleLeafHash))
+	}
+	l.pending = l.pending[len(batch):]
+	signer := l.signer
+	l.mu.Unlock()
+
+	if signer != nil {
+		if err := l.publishCheckpoint(ctx, signer, newRoot); err != nil {
+			log.Named(log.SubsystemTileLog).Errorf("publishing checkpoint for tree size %d: %v", newRoot.TreeSize, err)
+		}
+	}
+	return nil
+}
+
+// read returns the object at key; a missing object is reported as NotFound
+func (l *Log) read(ctx context.Context, key string) ([]byte, error) {
+	v, err := l.bucket.ReadAll(ctx, key)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, status.Errorf(codes.NotFound, "%s not found", key)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "reading %s: %v", key, err)
+	}
+	return v, nil
+}
+
+// write stores an object; immutable objects may be cached indefinitely by readers
+func (l *Log) write(ctx context.Context, key string, v []byte, contentType string, immutable bool) error {
+	opts := &blob.WriterOptions{ContentType: contentType, CacheControl: "no-cache"}
+	if immutable {
+		opts.CacheControl = "public, max-age=31536000, immutable"
+	}
+	if err := l.bucket.WriteAll(ctx, key, v, opts); err != nil {
+		return status.Errorf(codes.Unavailable, "writing %s: %v", key, err)
+	}
+	return nil
+}
+
+func (l *Log) writeRoot(ctx context.Context, root *types.LogRootV1) (*trillian.SignedLogRoot, error) {
+	b, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := l.write(ctx, rootKey, b, "application/octet-stream", false); err != nil {
+		return nil, err
+	}
+	return &trillian.SignedLogRoot{LogRoot: b}, nil
+}
+
+func indexKey(leafHash []byte) string {
+	return indexPrefix + hex.EncodeToString(leafHash)
+}
+
+func (l *Log) writeIndex(ctx context.Context, leaf *trillian.LogLeaf) error {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(leaf.LeafIndex))
+	return l.write(ctx, indexKey(leaf.MerkleLeafHash), v, "application/octet-stream", false)
+}
+
+// leafByHash returns the first leaf in the tree of root with leafHash, or nil if there is none
+func (l *Log) leafByHash(ctx context.Context, root *types.LogRootV1, leafHash []byte) (*trillian.LogLeaf, error) {
+	v, err := l.read(ctx, indexKey(leafHash))
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if len(v) != 8 {
+		return nil, status.Errorf(codes.Internal, "malformed index of leaf %x", leafHash)
+	}
+	// the index may have been written for a batch that failed before its root was, and so point at a
+	// leaf that is not in the tree or was replaced by another one
+	index := binary.BigEndian.Uint64(v)
+	if index >= root.TreeSize {
+		return nil, nil
+	}
+	leaves, err := l.readLeaves(ctx, int64(root.TreeSize), index, 1)
+	if err != nil {
+		return nil, err
+	}
+	if string(leaves[0].MerkleLeafHash) != string(leafHash) {
+		return nil, nil
+	}
+	return leaves[0], nil
+}
+
+// state returns the tree and root of the log with ID logID
+func (l *Log) state(logID int64) (*trillian.Tree, *types.LogRootV1, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tree == nil || l.tree.TreeId != logID {
+		return nil, nil, status.Errorf(codes.NotFound, "tree %d not found", logID)
+	}
+	if l.root == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "log %d is not initialized", logID)
+	}
+	return l.tree, l.root, nil
+}
+
+// ListTrees returns the tree in the bucket
+func (l *Log) ListTrees(ctx context.Context, in *trillian.ListTreesRequest, opts ...grpc.CallOption) (*trillian.ListTreesResponse, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	resp := &trillian.ListTreesResponse{}
+	if l.tree != nil {
+		resp.Tree = append(resp.Tree, proto.Clone(l.tree).(*trillian.Tree))
+	}
+	return resp, nil
+}
+
+// GetTree returns the tree with the requested ID
+func (l *Log) GetTree(ctx context.Context, in *trillian.GetTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tree == nil || l.tree.TreeId != in.TreeId {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", in.TreeId)
+	}
+	return proto.Clone(l.tree).(*trillian.Tree), nil
+}
+
+// CreateTree creates the active LOG or PREORDERED_LOG tree of the bucket; it must be initialized with InitLog
+// before use
+func (l *Log) CreateTree(ctx context.Context, in *trillian.CreateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	if in.Tree == nil {
+		return nil, status.Error(codes.InvalidArgument, "a tree is required")
+	}
+	tree := proto.Clone(in.Tree).(*trillian.Tree)
+	switch tree.TreeType {
+	case trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "tree type %v is not supported", tree.TreeType)
+	}
+	if tree.TreeState != trillian.TreeState_ACTIVE {
+		return nil, status.Errorf(codes.InvalidArgument, "new trees must be %v", trillian.TreeState_ACTIVE)
+	}
+	id, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return nil, err
+	}
+	tree.TreeId = id.Int64() + 1
+	now := timestamppb.New(l.now())
+	tree.CreateTime, tree.UpdateTime = now, now
+
+	l.integrating.Lock()
+	defer l.integrating.Unlock()
+	l.mu.Lock()
+	exists := l.tree != nil
+	l.mu.Unlock()
+	if exists {
+		return nil, status.Error(codes.AlreadyExists, "the bucket already holds a tree")
+	}
+	b, err := proto.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.write(ctx, treeKey, b, "application/octet-stream", false); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	l.tree = tree
+	l.mu.Unlock()
+	return proto.Clone(tree).(*trillian.Tree), nil
+}
+
+// UpdateTree is not supported
+func (l *Log) UpdateTree(ctx context.Context, in *trillian.UpdateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "the tile log does not support updating trees")
+}
+
+// DeleteTree is not supported
+func (l *Log) DeleteTree(ctx context.Context, in *trillian.DeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "the tile log does not support deleting trees")
+}
+
+// UndeleteTree is not supported
+func (l *Log) UndeleteTree(ctx context.Context, in *trillian.UndeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "the tile log does not support deleting trees")
+}
+
+// InitLog stores the empty root of the tree
+func (l *Log) InitLog(ctx context.Context, in *trillian.InitLogRequest, opts ...grpc.CallOption) (*trillian.InitLogResponse, error) {
+	l.integrating.Lock()
+	defer l.integrating.Unlock()
+	l.mu.Lock()
+	tree, initialized := l.tree, l.root != nil
+	l.mu.Unlock()
+	if tree == nil || tree.TreeId != in.LogId {
+		return nil, status.Errorf(codes.NotFound, "tree %d not found", in.LogId)
+	}
+	if initialized {
+		return nil, status.Errorf(codes.AlreadyExists, "log %d is already initialized", in.LogId)
+	}
+	root := &types.LogRootV1{
+		RootHash:       emptyRoot(),
+		TimestampNanos: uint64(l.now().UnixNano()),
+	}
+	slr, err := l.writeRoot(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	l.root = root
+	l.mu.Unlock()
+	return &trillian.InitLogResponse{Created: slr}, nil
+}
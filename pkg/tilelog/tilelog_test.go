@@ -0,0 +1,407 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilelog
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var hasher = rfc6962.DefaultHasher
+
+// newLog opens the bucket at bucketURL and creates a tree in it; leaves are only integrated when the test
+// calls flush
+func newLog(t *testing.T, bucketURL string, treeType trillian.TreeType) (*Log, *trillian.Tree) {
+	t.Helper()
+	l, err := Open(context.Background(), bucketURL, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	tree, err := l.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: &trillian.Tree{
+		TreeType:  treeType,
+		TreeState: trillian.TreeState_ACTIVE,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.InitLog(ctx, tree, l); err != nil {
+		t.Fatal(err)
+	}
+	return l, tree
+}
+
+func fileBucket(t *testing.T) string {
+	return "file://" + filepath.ToSlash(t.TempDir())
+}
+
+func latestRoot(t *testing.T, l *Log, logID int64) *types.LogRootV1 {
+	t.Helper()
+	resp, err := l.GetLatestSignedLogRoot(context.Background(), &trillian.GetLatestSignedLogRootRequest{LogId: logID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatal(err)
+	}
+	return &root
+}
+
+func queue(t *testing.T, l *Log, logID int64, value string) *trillian.QueuedLogLeaf {
+	t.Helper()
+	resp, err := l.QueueLeaf(context.Background(), &trillian.QueueLeafRequest{
+		LogId: logID,
+		Leaf:  &trillian.LogLeaf{LeafValue: []byte(value)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.QueuedLeaf
+}
+
+func flush(t *testing.T, l *Log) {
+	t.Helper()
+	if err := l.flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTilePath(t *testing.T) {
+	for _, test := range []struct {
+		level int
+		n     int64
+		width int
+		want  string
+	}{
+		{level: 0, n: 0, width: 256, want: "tile/0/000"},
+		{level: 1, n: 5, width: 3, want: "tile/1/005.p/3"},
+		{level: 0, n: 1234067, width: 256, want: "tile/0/x001/x234/067"},
+		{level: 2, n: 1000, width: 1, want: "tile/2/x001/000.p/1"},
+	} {
+		if got := hashTilePath(test.level, test.n, test.width); got != test.want {
+			t.Errorf("hashTilePath(%d, %d, %d) = %q, want %q", test.level, test.n, test.width, got, test.want)
+		}
+	}
+	if got := tilePath(entriesDir, 1, 17); got != "tile/entries/001.p/17" {
+		t.Errorf("entry bundle path %q", got)
+	}
+}
+
+func TestProofs(t *testing.T) {
+	ctx := context.Background()
+	l, tree := newLog(t, "mem://", trillian.TreeType_LOG)
+	defer l.Close()
+	v := logverifier.New(hasher)
+
+	// batches of varying sizes, crossing the boundaries of entry bundles and of level 0 and 1 tiles
+	roots := map[int64]*types.LogRootV1{}
+	var size int64
+	for _, batch := range []int{1, 2, 250, 3, 300, 1, 256, 70000 / 256} {
+		for i := 0; i < batch; i++ {
+			queue(t, l, tree.TreeId, fmt.Sprintf("leaf %d", size))
+			size++
+		}
+		flush(t, l)
+		roots[size] = latestRoot(t, l, tree.TreeId)
+	}
+	for size, root := range roots {
+		if root.TreeSize != uint64(size) {
+			t.Fatalf("tree size %d after %d leaves", root.TreeSize, size)
+		}
+		for _, index := range []int64{0, size / 2, size - 1} {
+			resp, err := l.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{
+				LogId: tree.TreeId, LeafHash: hasher.HashLeaf([]byte(fmt.Sprintf("leaf %d", index))), TreeSize: size,
+			})
+			if err != nil {
+				t.Fatalf("GetInclusionProofByHash(%d, %d): %v", index, size, err)
+			}
+			if err := v.VerifyInclusionProof(index, size, resp.Proof[0].Hashes, root.RootHash, hasher.HashLeaf([]byte(fmt.Sprintf("leaf %d", index)))); err != nil {
+				t.Errorf("inclusion of %d in %d: %v", index, size, err)
+			}
+		}
+		for first, firstRoot := range roots {
+			if first > size {
+				continue
+			}
+			resp, err := l.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{LogId: tree.TreeId, FirstTreeSize: first, SecondTreeSize: size})
+			if err != nil {
+				t.Fatalf("GetConsistencyProof(%d, %d): %v", first, size, err)
+			}
+			if err := v.VerifyConsistencyProof(first, size, firstRoot.RootHash, root.RootHash, resp.Proof.Hashes); err != nil {
+				t.Errorf("consistency of %d and %d: %v", first, size, err)
+			}
+		}
+	}
+
+	leaves, err := l.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: 250, Count: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, leaf := range leaves.Leaves {
+		if leaf.LeafIndex != int64(250+i) || string(leaf.LeafValue) != fmt.Sprintf("leaf %d", 250+i) || leaf.IntegrateTimestamp == nil {
+			t.Errorf("GetLeavesByRange returned %v at %d", leaf, i)
+		}
+	}
+
+	for _, test := range []struct {
+		desc string
+		err  error
+		want codes.Code
+	}{
+		{
+			desc: "leaf beyond tree size",
+			err:  call(l.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 3, TreeSize: 3})),
+			want: codes.OutOfRange,
+		},
+		{
+			desc: "unknown hash",
+			err: call(l.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{
+				LogId: tree.TreeId, LeafHash: hasher.HashLeaf([]byte("missing")), TreeSize: size,
+			})),
+			want: codes.NotFound,
+		},
+		{
+			desc: "hash not yet in tree",
+			err: call(l.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{
+				LogId: tree.TreeId, LeafHash: hasher.HashLeaf([]byte("leaf 9")), TreeSize: 5,
+			})),
+			want: codes.NotFound,
+		},
+		{
+			desc: "range beyond log",
+			err:  call(l.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: size, Count: 1})),
+			want: codes.OutOfRange,
+		},
+		{
+			desc: "unknown tree",
+			err:  call(l.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: tree.TreeId + 1})),
+			want: codes.NotFound,
+		},
+	} {
+		if got := status.Code(test.err); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.desc, test.err, test.want)
+		}
+	}
+}
+
+// call discards the response of a call
+func call(_ interface{}, err error) error {
+	return err
+}
+
+func TestClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	l, err := Open(ctx, "mem://", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	tree, err := client.CreateAndInitTree(ctx, &trillian.CreateTreeRequest{Tree: &trillian.Tree{
+		TreeType:  trillian.TreeType_LOG,
+		TreeState: trillian.TreeState_ACTIVE,
+	}}, l, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := client.NewFromTree(l, tree, types.LogRootV1{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := c.AddLeaf(ctx, []byte(fmt.Sprintf("leaf %d", i))); err != nil {
+			t.Fatalf("AddLeaf: %v", err)
+		}
+	}
+	// the client verified the consistency of every root it moved to
+	if root := c.GetRoot(); root.TreeSize != 3 {
+		t.Errorf("tree size %d, want 3", root.TreeSize)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	l, tree := newLog(t, "mem://", trillian.TreeType_LOG)
+	defer l.Close()
+
+	if first := queue(t, l, tree.TreeId, "a"); first.Status != nil {
+		t.Fatalf("first add returned %v", first.Status)
+	}
+	if dup := queue(t, l, tree.TreeId, "a"); codes.Code(dup.Status.GetCode()) != codes.AlreadyExists {
+		t.Errorf("duplicate of a queued leaf returned %v, want %v", dup.Status, codes.AlreadyExists)
+	}
+	queue(t, l, tree.TreeId, "b")
+	flush(t, l)
+
+	dup := queue(t, l, tree.TreeId, "a")
+	if codes.Code(dup.Status.GetCode()) != codes.AlreadyExists {
+		t.Errorf("duplicate of an integrated leaf returned %v, want %v", dup.Status, codes.AlreadyExists)
+	}
+	if dup.Leaf.LeafIndex != 0 || dup.Leaf.IntegrateTimestamp == nil {
+		t.Errorf("duplicate add returned leaf %v", dup.Leaf)
+	}
+	flush(t, l)
+	if size := latestRoot(t, l, tree.TreeId).TreeSize; size != 2 {
+		t.Errorf("tree size %d, want 2", size)
+	}
+
+	if _, err := l.QueueLeaf(context.Background(), &trillian.QueueLeafRequest{
+		LogId: tree.TreeId,
+		Leaf:  &trillian.LogLeaf{LeafValue: make([]byte, maxEntrySize+1)},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("adding an oversized leaf returned %v", err)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	ctx := context.Background()
+	bucket := fileBucket(t)
+	l, tree := newLog(t, bucket, trillian.TreeType_LOG)
+	for i := 0; i < 300; i++ {
+		queue(t, l, tree.TreeId, fmt.Sprintf("leaf %d", i))
+	}
+	flush(t, l)
+	before := latestRoot(t, l, tree.TreeId)
+	// leaves still queued are integrated on close
+	queue(t, l, tree.TreeId, "leaf 300")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Open(ctx, bucket, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	trees, err := l.ListTrees(ctx, &trillian.ListTreesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees.Tree) != 1 || trees.Tree[0].TreeId != tree.TreeId {
+		t.Fatalf("ListTrees returned %v", trees.Tree)
+	}
+	if dup := queue(t, l, tree.TreeId, "leaf 300"); codes.Code(dup.Status.GetCode()) != codes.AlreadyExists {
+		t.Errorf("leaf queued before close was not integrated: %v", dup.Status)
+	}
+	for i := 301; i < 310; i++ {
+		queue(t, l, tree.TreeId, fmt.Sprintf("leaf %d", i))
+	}
+	flush(t, l)
+	after := latestRoot(t, l, tree.TreeId)
+	if after.TreeSize != 310 {
+		t.Fatalf("tree size %d, want 310", after.TreeSize)
+	}
+	resp, err := l.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{
+		LogId: tree.TreeId, FirstTreeSize: int64(before.TreeSize), SecondTreeSize: int64(after.TreeSize),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logverifier.New(hasher).VerifyConsistencyProof(int64(before.TreeSize), int64(after.TreeSize), before.RootHash, after.RootHash, resp.Proof.Hashes); err != nil {
+		t.Error(err)
+	}
+
+	// the public objects follow the tlog-tiles layout
+	entries, err := l.read(ctx, "tile/entries/001.p/54")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([]byte{0, 8}, "leaf 256"...); string(entries[:len(want)]) != string(want) {
+		t.Errorf("entry bundle starts with %q", entries[:len(want)])
+	}
+	if _, err := l.read(ctx, "tile/0/000"); err != nil {
+		t.Error(err)
+	}
+	if _, err := l.read(ctx, "tile/1/000.p/1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	l, tree := newLog(t, "mem://", trillian.TreeType_LOG)
+	defer l.Close()
+	signer := func(ctx context.Context, root *types.LogRootV1) ([]byte, error) {
+		return []byte(fmt.Sprintf("test\n%d\n%x\n", root.TreeSize, root.RootHash)), nil
+	}
+	if err := l.PublishCheckpoints(ctx, signer); err != nil {
+		t.Fatal(err)
+	}
+	queue(t, l, tree.TreeId, "a")
+	flush(t, l)
+
+	checkpoint, err := l.read(ctx, checkpointKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := signer(ctx, latestRoot(t, l, tree.TreeId))
+	if string(checkpoint) != string(want) {
+		t.Errorf("checkpoint %q, want %q", checkpoint, want)
+	}
+}
+
+func TestSequencedLeaves(t *testing.T) {
+	ctx := context.Background()
+	l, tree := newLog(t, "mem://", trillian.TreeType_PREORDERED_LOG)
+	defer l.Close()
+
+	add := func(indexes ...int64) (*trillian.AddSequencedLeavesResponse, error) {
+		req := &trillian.AddSequencedLeavesRequest{LogId: tree.TreeId}
+		for _, i := range indexes {
+			req.Leaves = append(req.Leaves, &trillian.LogLeaf{
+				LeafIndex: i,
+				LeafValue: []byte(fmt.Sprintf("leaf %d", i)),
+				ExtraData: []byte(fmt.Sprint(i)),
+			})
+		}
+		return l.AddSequencedLeaves(ctx, req)
+	}
+
+	if _, err := add(0, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := add(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codes.Code(resp.Results[0].Status.GetCode()) != codes.AlreadyExists || resp.Results[1].Status != nil {
+		t.Errorf("got results %v", resp.Results)
+	}
+	if string(resp.Results[0].Leaf.ExtraData) != "2" {
+		t.Errorf("extra data of existing leaf is %q", resp.Results[0].Leaf.ExtraData)
+	}
+	if _, err := add(5); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("adding with a gap returned %v", err)
+	}
+	if size := latestRoot(t, l, tree.TreeId).TreeSize; size != 4 {
+		t.Errorf("tree size %d, want 4", size)
+	}
+	if _, err := l.QueueLeaf(ctx, &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte("x")}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("QueueLeaf on a preordered log returned %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilelog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/tlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// tileHeight is the number of tree levels in a tile, which holds up to tileWidth hashes or entries
+	tileHeight = 8
+	tileWidth  = 1 << tileHeight
+
+	// entries in a bundle are prefixed with their length as a uint16
+	maxEntrySize = 1<<16 - 1
+
+	entriesDir = "tile/entries"
+	// leavesDir holds a bundle of the rest of each leaf alongside each entry bundle
+	leavesDir = "state/leaves"
+
+	maxCachedTiles = 4096
+)
+
+func emptyRoot() []byte {
+	h := sha256.Sum256(nil)
+	return h[:]
+}
+
+// tilePath returns the path of the tile with index n in dir, which is partial if it is narrower than tileWidth
+func tilePath(dir string, n int64, width int) string {
+	// the index is split into 3-digit elements, all but the last prefixed with x
+	p := fmt.Sprintf("%03d", n%1000)
+	for n >= 1000 {
+		n /= 1000
+		p = fmt.Sprintf("x%03d/%s", n%1000, p)
+	}
+	p = dir + "/" + p
+	if width < tileWidth {
+		p += fmt.Sprintf(".p/%d", width)
+	}
+	return p
+}
+
+func hashTilePath(level int, n int64, width int) string {
+	return tilePath(fmt.Sprintf("tile/%d", level), n, width)
+}
+
+// widthAt returns the width of tile n of level, or of entry bundle n for level 0, in a tree of size
+func widthAt(level int, n, size int64) int {
+	w := (size >> (tileHeight * level)) - n*tileWidth
+	if w > tileWidth {
+		w = tileWidth
+	}
+	return int(w)
+}
+
+// tileCache keeps full hash tiles, which never change
+type tileCache struct {
+	mu    sync.Mutex
+	tiles map[string][]byte
+}
+
+func newTileCache() *tileCache {
+	return &tileCache{tiles: map[string][]byte{}}
+}
+
+func (c *tileCache) get(path string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.tiles[path]
+	return v, ok
+}
+
+func (c *tileCache) put(path string, v []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.tiles) >= maxCachedTiles {
+		for k := range c.tiles {
+			delete(c.tiles, k)
+			break
+		}
+	}
+	c.tiles[path] = v
+}
+
+// hashReader reads the hashes stored for a tree of size from its tiles, and those in pending from memory
+func (l *Log) hashReader(ctx context.Context, size int64, pending map[int64]tlog.Hash) tlog.HashReader {
+	read := map[string][]byte{}
+	return tlog.HashReaderFunc(func(indexes []int64) ([]tlog.Hash, error) {
+		hashes := make([]tlog.Hash, len(indexes))
+		for i, index := range indexes {
+			if h, ok := pending[index]; ok {
+				hashes[i] = h
+				continue
+			}
+			t := tlog.TileForIndex(tileHeight, index)
+			t.W = widthAt(t.L, t.N, size)
+			if t.W <= 0 {
+				return nil, status.Errorf(codes.Internal, "hash %d is not in the tree of size %d", index, size)
+			}
+			path := hashTilePath(t.L, t.N, t.W)
+			data, ok := read[path]
+			if !ok {
+				if data, ok = l.tiles.get(path); !ok {
+					var err error
+					if data, err = l.read(ctx, path); err != nil {
+						return nil, err
+					}
+					if t.W == tileWidth {
+						l.tiles.put(path, data)
+					}
+				}
+				read[path] = data
+			}
+			h, err := tlog.HashFromTile(t, data, index)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "reading hash %d from %s: %v", index, path, err)
+			}
+			hashes[i] = h
+		}
+		return hashes, nil
+	})
+}
+
+// integrate adds leaves, whose indexes follow on from root, to the tree and stores its new root. Leaves are
+// written before the root, so that a failed batch leaves the tree at its previous root.
+func (l *Log) integrate(ctx context.Context, root *types.LogRootV1, leaves []*trillian.LogLeaf) (*types.LogRootV1, error) {
+	oldSize := int64(root.TreeSize)
+	newSize := oldSize + int64(len(leaves))
+
+	pending := map[int64]tlog.Hash{}
+	r := l.hashReader(ctx, oldSize, pending)
+	for i, leaf := range leaves {
+		n := oldSize + int64(i)
+		var h tlog.Hash
+		copy(h[:], leaf.MerkleLeafHash)
+		stored, err := tlog.StoredHashesForRecordHash(n, h, r)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "hashing leaf %d: %v", n, err)
+		}
+		for j, h := range stored {
+			pending[tlog.StoredHashCount(n)+int64(j)] = h
+		}
+	}
+
+	if err := l.writeBundles(ctx, oldSize, leaves); err != nil {
+		return nil, err
+	}
+	for _, t := range tlog.NewTiles(tileHeight, oldSize, newSize) {
+		data, err := tlog.ReadTileData(t, r)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "building tile: %v", err)
+		}
+		if err := l.write(ctx, hashTilePath(t.L, t.N, t.W), data, "application/octet-stream", t.W == tileWidth); err != nil {
+			return nil, err
+		}
+	}
+	for _, leaf := range leaves {
+		if err := l.writeIndex(ctx, leaf); err != nil {
+			return nil, err
+		}
+	}
+
+	rootHash, err := tlog.TreeHash(newSize, r)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "computing root hash: %v", err)
+	}
+	// clients only accept roots that are newer than the last one they saw
+	ts := uint64(l.now().UnixNano())
+	if ts <= root.TimestampNanos {
+		ts = root.TimestampNanos + 1
+	}
+	newRoot := &types.LogRootV1{
+		TreeSize:       uint64(newSize),
+		RootHash:       rootHash[:],
+		TimestampNanos: ts,
+		Revision:       root.Revision + 1,
+	}
+	if _, err := l.writeRoot(ctx, newRoot); err != nil {
+		return nil, err
+	}
+	return newRoot, nil
+}
+
+// writeBundles appends leaves to the entry bundle, and the bundle of the rest of the leaves, at the end of
+// a tree of size
+func (l *Log) writeBundles(ctx context.Context, size int64, leaves []*trillian.LogLeaf) error {
+	var entries, rest []byte
+	if w := widthAt(0, size/tileWidth, size); w > 0 && w < tileWidth {
+		var err error
+		if entries, err = l.read(ctx, tilePath(entriesDir, size/tileWidth, w)); err != nil {
+			return err
+		}
+		if rest, err = l.read(ctx, tilePath(leavesDir, size/tileWidth, w)); err != nil {
+			return err
+		}
+	}
+	for i, leaf := range leaves {
+		entries = append(entries, byte(len(leaf.LeafValue)>>8), byte(len(leaf.LeafValue)))
+		entries = append(entries, leaf.LeafValue...)
+		b, err := proto.Marshal(&trillian.LogLeaf{
+			ExtraData:          leaf.ExtraData,
+			QueueTimestamp:     leaf.QueueTimestamp,
+			IntegrateTimestamp: leaf.IntegrateTimestamp,
+		})
+		if err != nil {
+			return err
+		}
+		var length [binary.MaxVarintLen64]byte
+		rest = append(rest, length[:binary.PutUvarint(length[:], uint64(len(b)))]...)
+		rest = append(rest, b...)
+
+		index := size + int64(i)
+		if w := int(index%tileWidth) + 1; w == tileWidth || i == len(leaves)-1 {
+			n := index / tileWidth
+			if err := l.write(ctx, tilePath(entriesDir, n, w), entries, "application/octet-stream", w == tileWidth); err != nil {
+				return err
+			}
+			if err := l.write(ctx, tilePath(leavesDir, n, w), rest, "application/octet-stream", false); err != nil {
+				return err
+			}
+			entries, rest = nil, nil
+		}
+	}
+	return nil
+}
+
+// readLeaves returns count leaves from start in a tree of size; the range must be within the tree
+func (l *Log) readLeaves(ctx context.Context, size int64, start, count uint64) ([]*trillian.LogLeaf, error) {
+	var leaves []*trillian.LogLeaf
+	end := start + count
+	for n := int64(start / tileWidth); uint64(n)*tileWidth < end; n++ {
+		w := widthAt(0, n, size)
+		entries, err := l.read(ctx, tilePath(entriesDir, n, w))
+		if err != nil {
+			return nil, err
+		}
+		rest, err := l.read(ctx, tilePath(leavesDir, n, w))
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < w; i++ {
+			if len(entries) < 2 || len(entries)-2 < int(entries[0])<<8|int(entries[1]) {
+				return nil, status.Errorf(codes.Internal, "malformed entry bundle %d", n)
+			}
+			value := entries[2 : 2+(int(entries[0])<<8|int(entries[1]))]
+			entries = entries[2+len(value):]
+			length, k := binary.Uvarint(rest)
+			if k <= 0 || uint64(len(rest)-k) < length {
+				return nil, status.Errorf(codes.Internal, "malformed leaf bundle %d", n)
+			}
+			b := rest[k : k+int(length)]
+			rest = rest[k+int(length):]
+
+			index := uint64(n)*tileWidth + uint64(i)
+			if index < start || index >= end {
+				continue
+			}
+			var leaf trillian.LogLeaf
+			if err := proto.Unmarshal(b, &leaf); err != nil {
+				return nil, status.Errorf(codes.Internal, "decoding leaf %d: %v", index, err)
+			}
+			h := tlog.RecordHash(value)
+			leaf.LeafIndex = int64(index)
+			leaf.LeafValue = value
+			leaf.MerkleLeafHash = h[:]
+			leaf.LeafIdentityHash = h[:]
+			leaves = append(leaves, &leaf)
+		}
+	}
+	return leaves, nil
+}
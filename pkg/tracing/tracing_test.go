@@ -0,0 +1,150 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return exporter
+}
+
+func TestMiddleware(t *testing.T) {
+	exporter := recordSpans(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus codes.Code
+	}{
+		{name: "ok", status: http.StatusOK, wantStatus: codes.Unset},
+		{name: "client error", status: http.StatusNotFound, wantStatus: codes.Unset},
+		{name: "server error", status: http.StatusInternalServerError, wantStatus: codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter.Reset()
+			var handlerSpan trace.SpanContext
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerSpan = trace.SpanContextFromContext(r.Context())
+				w.WriteHeader(tt.status)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/log", nil)
+			req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if got := span.SpanContext.TraceID().String(); got != traceID {
+				t.Errorf("trace ID %s, want the propagated %s", got, traceID)
+			}
+			if !span.Parent.IsRemote() {
+				t.Error("span is not a child of the propagated span")
+			}
+			if span.SpanContext.SpanID() != handlerSpan.SpanID() {
+				t.Error("span is not passed to the handler")
+			}
+			if span.SpanKind != trace.SpanKindServer {
+				t.Errorf("span kind %v, want server", span.SpanKind)
+			}
+			if span.StatusCode != tt.wantStatus {
+				t.Errorf("span status %v, want %v", span.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestStartEnd(t *testing.T) {
+	exporter := recordSpans(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("failed"))
+	End(parent, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Name != "child" || spans[0].Parent.SpanID() != spans[1].SpanContext.SpanID() {
+		t.Errorf("child span %q is not a child of %q", spans[0].Name, spans[1].Name)
+	}
+	if spans[0].StatusCode != codes.Error || len(spans[0].MessageEvents) != 1 {
+		t.Errorf("error not recorded on child span: status %v, events %v", spans[0].StatusCode, spans[0].MessageEvents)
+	}
+	if spans[1].StatusCode != codes.Unset {
+		t.Errorf("parent span status %v, want unset", spans[1].StatusCode)
+	}
+}
+
+func TestDetach(t *testing.T) {
+	recordSpans(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx, span := Start(ctx, "request")
+	defer span.End()
+	cancel()
+
+	detached := Detach(ctx)
+	if detached.Err() != nil {
+		t.Errorf("detached context is cancelled: %v", detached.Err())
+	}
+	if _, ok := detached.Deadline(); ok {
+		t.Error("detached context has a deadline")
+	}
+	if trace.SpanContextFromContext(detached).SpanID() != span.SpanContext().SpanID() {
+		t.Error("detached context does not carry the span")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{})
+	if err != nil {
+		t.Fatalf("Setup() without endpoint: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown without endpoint: %v", err)
+	}
+	if _, err := Setup(context.Background(), Config{Endpoint: "localhost:4317", SampleRatio: 2}); err == nil {
+		t.Error("Setup() accepted sample ratio 2")
+	}
+}
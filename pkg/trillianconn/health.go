@@ -0,0 +1,116 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trillianconn
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// maxRetryBackoff caps the wait between retries
+const maxRetryBackoff = 5 * time.Second
+
+// retryInterceptor retries calls that fail with UNAVAILABLE, which is what gRPC returns while the server is
+// restarting or the connection is being re-established. UNAVAILABLE means the server did not process the call;
+// an add that is processed anyway is reported as a duplicate when retried.
+func retryInterceptor(maxRetries int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		wait := backoff
+		for attempt := 0; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt == maxRetries || status.Code(err) != codes.Unavailable {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(wait):
+			}
+			if wait *= 2; wait > maxRetryBackoff {
+				wait = maxRetryBackoff
+			}
+		}
+	}
+}
+
+// breaker fails calls immediately once threshold consecutive calls have found the server unavailable, so
+// that requests don't pile up waiting on it. After cooldown, one call is let through to probe the server;
+// its success closes the breaker again.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (b *breaker) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !b.allow() {
+		return status.Error(codes.Unavailable, "Trillian is unavailable, circuit breaker open")
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	b.done(status.Code(err) == codes.Unavailable)
+	return err
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *breaker) done(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wasOpen := b.failures >= b.threshold
+	if !failed {
+		if wasOpen {
+			log.Named(log.SubsystemTrillian).Info("Trillian is reachable again, closing circuit breaker")
+		}
+		b.failures = 0
+		b.probing = false
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		if !wasOpen {
+			log.Named(log.SubsystemTrillian).Warnf("Trillian unavailable for %d consecutive calls, opening circuit breaker for %v", b.failures, b.cooldown)
+		}
+		b.openUntil = b.now().Add(b.cooldown)
+		b.probing = false
+	}
+}
@@ -0,0 +1,138 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trillianconn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Config describes how to connect to the Trillian log server and how to ride out its restarts
+type Config struct {
+	EnableTLS bool
+	// TLSCACert is a PEM bundle of CAs trusted for the server's certificate, instead of the system roots
+	TLSCACert string
+	// TLSClientCert and TLSClientKey are PEM files presented to the server for mutual TLS
+	TLSClientCert, TLSClientKey string
+	// TLSServerName overrides the name the server's certificate is verified against, which is otherwise
+	// the host of the address dialed
+	TLSServerName string
+
+	// KeepaliveTime is how long a connection is idle before it is pinged; 0 disables pings
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout is how long to wait for a ping to be answered before closing the connection
+	KeepaliveTimeout time.Duration
+
+	// MaxRetries is how many times a call failing with UNAVAILABLE is retried; 0 disables retries
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry; it doubles on each further retry
+	RetryBackoff time.Duration
+
+	// BreakerThreshold is the number of consecutive failed calls after which calls fail immediately
+	// for BreakerCooldown, instead of waiting on an unreachable server; 0 disables the breaker
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// Dial returns a connection to the Trillian server at target ("host:port")
+func Dial(ctx context.Context, target string, cfg Config) (*grpc.ClientConn, error) {
+	opts, err := dialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.DialContext(ctx, target, opts...)
+}
+
+func dialOptions(cfg Config) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}))
+	}
+
+	// the breaker sees a call only once all its retries have failed, and each call is traced as a whole
+	interceptors := []grpc.UnaryClientInterceptor{otelgrpc.UnaryClientInterceptor()}
+	if cfg.BreakerThreshold > 0 {
+		if cfg.BreakerCooldown <= 0 {
+			return nil, errors.New("circuit breaker cooldown must be positive")
+		}
+		interceptors = append(interceptors, newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown).unaryInterceptor)
+	}
+	if cfg.MaxRetries > 0 {
+		interceptors = append(interceptors, retryInterceptor(cfg.MaxRetries, cfg.RetryBackoff))
+	}
+	opts = append(opts, grpc.WithChainUnaryInterceptor(interceptors...), grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()))
+	return opts, nil
+}
+
+func transportCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	if !cfg.EnableTLS {
+		if cfg.TLSCACert != "" || cfg.TLSClientCert != "" || cfg.TLSClientKey != "" || cfg.TLSServerName != "" {
+			return nil, errors.New("Trillian TLS options are only used with TLS enabled")
+		}
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.TLSServerName,
+	}
+	if cfg.TLSCACert != "" {
+		pem, err := ioutil.ReadFile(filepath.Clean(cfg.TLSCACert))
+		if err != nil {
+			return nil, fmt.Errorf("reading Trillian CA certificate: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in Trillian CA certificate file")
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if (cfg.TLSClientCert == "") != (cfg.TLSClientKey == "") {
+		return nil, errors.New("a Trillian client certificate and key must be set together")
+	}
+	if cfg.TLSClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(filepath.Clean(cfg.TLSClientCert), filepath.Clean(cfg.TLSClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("loading Trillian client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
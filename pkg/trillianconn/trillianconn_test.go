@@ -0,0 +1,271 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trillianconn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &testCA{cert: cert, key: key, dir: t.TempDir()}
+	ca.write(t, "ca.pem", "CERTIFICATE", der)
+	return ca
+}
+
+func (ca *testCA) write(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(ca.dir, name)
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// issue returns a certificate for name and the paths of its certificate and key files
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (tls.Certificate, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		ca.write(t, name+".pem", "CERTIFICATE", der),
+		ca.write(t, name+"-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+// startMTLSServer returns the address of a gRPC server that requires clients to present a certificate from ca
+func startMTLSServer(t *testing.T, ca *testCA) string {
+	t.Helper()
+	serverCert, _, _ := ca.issue(t, "trillian.example.com", x509.ExtKeyUsageServerAuth)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    roots,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.Serve(l) }()
+	t.Cleanup(s.Stop)
+	return l.Addr().String()
+}
+
+func check(t *testing.T, addr string, cfg Config) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := Dial(ctx, addr, cfg)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startMTLSServer(t, ca)
+	_, clientCert, clientKey := ca.issue(t, "rekor", x509.ExtKeyUsageClientAuth)
+
+	cfg := Config{
+		EnableTLS:     true,
+		TLSCACert:     filepath.Join(ca.dir, "ca.pem"),
+		TLSClientCert: clientCert,
+		TLSClientKey:  clientKey,
+		TLSServerName: "trillian.example.com",
+	}
+	if err := check(t, addr, cfg); err != nil {
+		t.Errorf("call with client certificate failed: %v", err)
+	}
+
+	noClientCert := cfg
+	noClientCert.TLSClientCert, noClientCert.TLSClientKey = "", ""
+	if err := check(t, addr, noClientCert); err == nil {
+		t.Error("call without client certificate succeeded")
+	}
+
+	untrusted := cfg
+	untrusted.TLSCACert = ""
+	if err := check(t, addr, untrusted); err == nil {
+		t.Error("call to server with untrusted certificate succeeded")
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		cfg  Config
+	}{
+		{desc: "CA without TLS", cfg: Config{TLSCACert: "ca.pem"}},
+		{desc: "client certificate without key", cfg: Config{EnableTLS: true, TLSClientCert: "cert.pem"}},
+		{desc: "missing CA", cfg: Config{EnableTLS: true, TLSCACert: "/nonexistent/ca.pem"}},
+		{desc: "breaker without cooldown", cfg: Config{BreakerThreshold: 3}},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := dialOptions(test.cfg); err == nil {
+				t.Errorf("dialOptions(%+v) succeeded, want error", test.cfg)
+			}
+		})
+	}
+}
+
+// failingInvoker fails the first failures calls with code, and counts the calls it receives
+type failingInvoker struct {
+	failures int
+	code     codes.Code
+	calls    int
+}
+
+func (f *failingInvoker) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return status.Error(f.code, "failed")
+	}
+	return nil
+}
+
+func TestRetry(t *testing.T) {
+	for _, test := range []struct {
+		desc      string
+		failures  int
+		code      codes.Code
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{desc: "success", failures: 0, code: codes.Unavailable, wantCalls: 1, wantCode: codes.OK},
+		{desc: "recovers", failures: 2, code: codes.Unavailable, wantCalls: 3, wantCode: codes.OK},
+		{desc: "gives up", failures: 5, code: codes.Unavailable, wantCalls: 4, wantCode: codes.Unavailable},
+		{desc: "other errors", failures: 1, code: codes.InvalidArgument, wantCalls: 1, wantCode: codes.InvalidArgument},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			f := &failingInvoker{failures: test.failures, code: test.code}
+			err := retryInterceptor(3, time.Millisecond)(context.Background(), "/trillian.TrillianLog/QueueLeaf", nil, nil, nil, f.invoke)
+			if status.Code(err) != test.wantCode {
+				t.Errorf("got %v, want %v", err, test.wantCode)
+			}
+			if f.calls != test.wantCalls {
+				t.Errorf("%d calls, want %d", f.calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+	call := func(f *failingInvoker) error {
+		return b.unaryInterceptor(context.Background(), "/trillian.TrillianLog/GetLatestSignedLogRoot", nil, nil, nil, f.invoke)
+	}
+
+	down := &failingInvoker{failures: 100, code: codes.Unavailable}
+	_ = call(down)
+	_ = call(down)
+	if err := call(down); status.Code(err) != codes.Unavailable || down.calls != 2 {
+		t.Fatalf("open breaker passed the call through: %v, %d calls", err, down.calls)
+	}
+
+	// a failed probe after the cooldown keeps it open
+	now = now.Add(time.Minute)
+	_ = call(down)
+	if down.calls != 3 {
+		t.Fatalf("probe was not let through")
+	}
+	if _ = call(down); down.calls != 3 {
+		t.Fatalf("breaker closed after a failed probe")
+	}
+
+	// a successful probe closes it
+	now = now.Add(time.Minute)
+	up := &failingInvoker{}
+	if err := call(up); err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	if err := call(up); err != nil || up.calls != 2 {
+		t.Errorf("breaker did not close: %v, %d calls", err, up.calls)
+	}
+}
@@ -76,15 +76,25 @@ func (v V001Entry) IndexKeys() []string {
 		}
 	}
 
-	key, err := v.keyObj.CanonicalValue()
-	if err != nil {
-		log.Logger.Error(err)
-	} else {
-		keyHash := sha256.Sum256(key)
-		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	keyObj := v.keyObj
+	if keyObj == nil && v.AlpineModel.PublicKey != nil {
+		// entries read back from the log carry the public key, but have not had their external entities fetched
+		var err error
+		if keyObj, err = pki.ParsePublicKey(pki.X509, v.AlpineModel.PublicKey.Content); err != nil {
+			log.Logger.Error(err)
+		}
 	}
+	if keyObj != nil {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
 
-	result = append(result, v.keyObj.EmailAddresses()...)
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
+	}
 
 	if v.AlpineModel.Package.Hash != nil {
 		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.AlpineModel.Package.Hash.Algorithm, *v.AlpineModel.Package.Hash.Value))
@@ -0,0 +1,135 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archlinux
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/ulikunitz/xz"
+)
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	xzMagic   = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+)
+
+// pkginfoFields are the .PKGINFO fields recorded for a package
+var pkginfoFields = []string{"pkgname", "pkgbase", "pkgver", "arch"}
+
+// pkgnameRegex follows the package name rules enforced by makepkg
+var pkgnameRegex = regexp.MustCompile(`^[a-z0-9@_+][a-z0-9@._+-]*$`)
+
+type Package struct {
+	PkgInfo map[string]string // selected .PKGINFO fields, see pkginfoFields
+}
+
+// Unmarshal reads a zstd (or legacy xz) compressed Arch Linux package and extracts the
+// metadata from its .PKGINFO file
+func (p *Package) Unmarshal(r io.Reader) error {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(xzMagic))
+	if err != nil {
+		return errors.Wrap(err, "reading package header")
+	}
+
+	var tr io.Reader
+	switch {
+	case bytes.HasPrefix(magic, zstdMagic):
+		dec, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return errors.Wrap(err, "creating zstd reader")
+		}
+		defer dec.Close()
+		tr = dec
+	case bytes.HasPrefix(magic, xzMagic):
+		if tr, err = xz.NewReader(br); err != nil {
+			return errors.Wrap(err, "creating xz reader")
+		}
+	default:
+		return errors.New("not an Arch Linux package: expected a zstd or xz compressed archive")
+	}
+
+	pkg := tar.NewReader(tr)
+	for {
+		header, err := pkg.Next()
+		if err == io.EOF {
+			return errors.New(".PKGINFO file was not located")
+		} else if err != nil {
+			return errors.Wrap(err, "getting next entry in tar archive")
+		}
+		if header.Name != ".PKGINFO" {
+			continue
+		}
+		info, err := ParsePkgInfo(pkg)
+		if err != nil {
+			return errors.Wrap(err, "parsing .PKGINFO")
+		}
+		p.PkgInfo = info
+		return nil
+	}
+}
+
+// ParsePkgInfo parses and validates a .PKGINFO file, returning the fields listed in
+// pkginfoFields
+func ParsePkgInfo(r io.Reader) (map[string]string, error) {
+	info := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid .PKGINFO line %q", line)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		for _, f := range pkginfoFields {
+			if key != f {
+				continue
+			}
+			if _, ok := info[key]; ok {
+				return nil, fmt.Errorf("duplicate %s field", key)
+			}
+			info[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, f := range []string{"pkgname", "pkgver", "arch"} {
+		if info[f] == "" {
+			return nil, fmt.Errorf("missing %s field", f)
+		}
+	}
+	if !pkgnameRegex.MatchString(info["pkgname"]) {
+		return nil, fmt.Errorf("invalid pkgname %q", info["pkgname"])
+	}
+	// pkgver is recorded as [epoch:]pkgver-pkgrel
+	if i := strings.LastIndex(info["pkgver"], "-"); i <= 0 || i == len(info["pkgver"])-1 {
+		return nil, fmt.Errorf("invalid pkgver %q", info["pkgver"])
+	}
+	return info, nil
+}
@@ -0,0 +1,95 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archlinux
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArchlinuxPackage(t *testing.T) {
+	inputArchive, err := os.Open("../../../tests/test_archlinux.pkg.tar.zst")
+	if err != nil {
+		t.Fatalf("could not open archive %v", err)
+	}
+	defer inputArchive.Close()
+
+	p := Package{}
+	if err := p.Unmarshal(inputArchive); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	expected := map[string]string{
+		"pkgname": "rekor-hello",
+		"pkgbase": "rekor-hello",
+		"pkgver":  "1.0.0-1",
+		"arch":    "any",
+	}
+	if !reflect.DeepEqual(p.PkgInfo, expected) {
+		t.Errorf("unexpected .PKGINFO fields: %v", p.PkgInfo)
+	}
+
+	if err := p.Unmarshal(strings.NewReader("not a package")); err == nil {
+		t.Error("expected error unmarshalling invalid package")
+	}
+}
+
+func TestParsePkgInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkginfo string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			pkginfo: "pkgname = hello\npkgver = 1:2.10-3\narch = x86_64\n",
+		},
+		{
+			name:    "missing arch",
+			pkginfo: "pkgname = hello\npkgver = 2.10-3\n",
+			wantErr: true,
+		},
+		{
+			name:    "pkgver without pkgrel",
+			pkginfo: "pkgname = hello\npkgver = 2.10\narch = x86_64\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid pkgname",
+			pkginfo: "pkgname = -hello\npkgver = 2.10-3\narch = x86_64\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate pkgname",
+			pkginfo: "pkgname = hello\npkgname = other\npkgver = 2.10-3\narch = x86_64\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed line",
+			pkginfo: "pkgname hello\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePkgInfo(strings.NewReader(tt.pkginfo)); (err != nil) != tt.wantErr {
+				t.Errorf("ParsePkgInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archlinux
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/archlinux"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := archlinux.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	ArchlinuxModel          models.ArchlinuxV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+	pkgInfo                 map[string]string
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.hasExternalEntities() {
+		if err := v.fetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	keyObj := v.keyObj
+	if keyObj == nil && v.ArchlinuxModel.PublicKey != nil {
+		// entries read back from the log carry the public key, but have not had their external entities fetched
+		var err error
+		if keyObj, err = pki.ParsePublicKey(pki.PGP, v.ArchlinuxModel.PublicKey.Content); err != nil {
+			log.Logger.Error(err)
+		}
+	}
+	if keyObj != nil {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
+	}
+
+	if v.ArchlinuxModel.Package.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.ArchlinuxModel.Package.Hash.Algorithm, *v.ArchlinuxModel.Package.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	if name := v.pkgInfo["pkgname"]; name != "" {
+		result = append(result, name, fmt.Sprintf("%s-%s", name, v.pkgInfo["pkgver"]))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	d, ok := pe.(*models.Archlinux)
+	if !ok {
+		return errors.New("cannot unmarshal non Arch Linux v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(d.Spec, &v.ArchlinuxModel); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.ArchlinuxModel.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+func (v V001Entry) hasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.ArchlinuxModel.Package != nil && v.ArchlinuxModel.Package.URL.String() != "" {
+		return true
+	}
+	if v.ArchlinuxModel.PublicKey != nil && v.ArchlinuxModel.PublicKey.URL.String() != "" {
+		return true
+	}
+	if v.ArchlinuxModel.Signature != nil && v.ArchlinuxModel.Signature.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func readAll(ctx context.Context, url string, content []byte) ([]byte, error) {
+	rc, err := util.FileOrURLReadCloser(ctx, url, content)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	artifactFactory, err := pki.NewArtifactFactory(pki.PGP)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	var pkgBytes, sigBytes []byte
+	g.Go(func() error {
+		var err error
+		pkgBytes, err = readAll(ctx, v.ArchlinuxModel.Package.URL.String(), v.ArchlinuxModel.Package.Content)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		sigBytes, err = readAll(ctx, v.ArchlinuxModel.Signature.URL.String(), v.ArchlinuxModel.Signature.Content)
+		return err
+	})
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.ArchlinuxModel.PublicKey.URL.String(),
+			v.ArchlinuxModel.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		v.keyObj, err = artifactFactory.NewPublicKey(keyReadCloser)
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	h := sha256.Sum256(pkgBytes)
+	computedSHA := hex.EncodeToString(h[:])
+	if v.ArchlinuxModel.Package.Hash != nil && v.ArchlinuxModel.Package.Hash.Value != nil {
+		if oldSHA := swag.StringValue(v.ArchlinuxModel.Package.Hash.Value); computedSHA != oldSHA {
+			return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
+		}
+	}
+
+	p := archlinux.Package{}
+	if err := p.Unmarshal(bytes.NewReader(pkgBytes)); err != nil {
+		return types.ValidationError(err)
+	}
+	v.pkgInfo = p.PkgInfo
+
+	sigObj, err := artifactFactory.NewSignature(bytes.NewReader(sigBytes))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	if err := sigObj.Verify(bytes.NewReader(pkgBytes), v.keyObj); err != nil {
+		return types.ValidationError(err)
+	}
+	v.sigObj = sigObj
+
+	// if we get here, all goroutines succeeded without error
+	if v.ArchlinuxModel.Package.Hash == nil {
+		v.ArchlinuxModel.Package.Hash = &models.ArchlinuxV001SchemaPackageHash{}
+		v.ArchlinuxModel.Package.Hash.Algorithm = swag.String(models.ArchlinuxV001SchemaPackageHashAlgorithmSha256)
+		v.ArchlinuxModel.Package.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.ArchlinuxV001Schema{}
+
+	var err error
+	// need to canonicalize key content
+	canonicalEntry.PublicKey = &models.ArchlinuxV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Package = &models.ArchlinuxV001SchemaPackage{}
+	canonicalEntry.Package.Hash = &models.ArchlinuxV001SchemaPackageHash{}
+	canonicalEntry.Package.Hash.Algorithm = v.ArchlinuxModel.Package.Hash.Algorithm
+	canonicalEntry.Package.Hash.Value = v.ArchlinuxModel.Package.Hash.Value
+	// data content is not set deliberately
+
+	canonicalEntry.Package.Pkginfo = v.pkgInfo
+
+	canonicalEntry.Signature = &models.ArchlinuxV001SchemaSignature{}
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.ArchlinuxModel.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	d := models.Archlinux{}
+	d.APIVersion = swag.String(APIVERSION)
+	d.Spec = &canonicalEntry
+
+	return json.Marshal(&d)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	key := v.ArchlinuxModel.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	pkg := v.ArchlinuxModel.Package
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+
+	hash := pkg.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if len(pkg.Content) == 0 && pkg.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for package")
+	}
+
+	sig := v.ArchlinuxModel.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	return nil
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func readProperty(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Archlinux{}
+	re := V001Entry{}
+
+	// we will need artifact, public-key & signature
+	re.ArchlinuxModel = models.ArchlinuxV001Schema{}
+	re.ArchlinuxModel.Package = &models.ArchlinuxV001SchemaPackage{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to package (file or URL) must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			re.ArchlinuxModel.Package.URL = strfmt.URI(props.ArtifactPath.String())
+			if props.ArtifactHash != "" {
+				re.ArchlinuxModel.Package.Hash = &models.ArchlinuxV001SchemaPackageHash{
+					Algorithm: swag.String(models.ArchlinuxV001SchemaPackageHashAlgorithmSha256),
+					Value:     swag.String(props.ArtifactHash),
+				}
+			}
+		} else {
+			if artifactBytes, err = readProperty(props.ArtifactPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.ArchlinuxModel.Package.Content = strfmt.Base64(artifactBytes)
+		}
+	} else {
+		re.ArchlinuxModel.Package.Content = strfmt.Base64(artifactBytes)
+	}
+
+	re.ArchlinuxModel.PublicKey = &models.ArchlinuxV001SchemaPublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify the signature")
+		}
+		if props.PublicKeyPath.IsAbs() {
+			re.ArchlinuxModel.PublicKey.URL = strfmt.URI(props.PublicKeyPath.String())
+		} else {
+			if publicKeyBytes, err = readProperty(props.PublicKeyPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.ArchlinuxModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+		}
+	} else {
+		re.ArchlinuxModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+	}
+
+	switch {
+	case props.SignatureBytes == nil && props.SignaturePath == nil:
+		return nil, errors.New("a detached signature must be provided to verify the package")
+	case props.SignatureBytes != nil:
+		re.ArchlinuxModel.Signature = &models.ArchlinuxV001SchemaSignature{Content: strfmt.Base64(props.SignatureBytes)}
+	case props.SignaturePath != nil && props.SignaturePath.IsAbs():
+		re.ArchlinuxModel.Signature = &models.ArchlinuxV001SchemaSignature{URL: strfmt.URI(props.SignaturePath.String())}
+	case props.SignaturePath != nil:
+		sigBytes, err := readProperty(props.SignaturePath.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+		re.ArchlinuxModel.Signature = &models.ArchlinuxV001SchemaSignature{Content: strfmt.Base64(sigBytes)}
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if re.hasExternalEntities() {
+		if err := re.fetchExternalEntities(ctx); err != nil {
+			return nil, fmt.Errorf("error retrieving external entities: %v", err)
+		}
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.ArchlinuxModel
+
+	return &returnVal, nil
+}
@@ -0,0 +1,449 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archlinux
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_archlinux.pub")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test_archlinux.pkg.tar.zst")
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test_archlinux.pkg.tar.zst.sig")
+	otherKeyBytes, _ := ioutil.ReadFile("../../../../tests/test_deb.pub")
+
+	h := sha256.Sum256(dataBytes)
+	dataSHA := hex.EncodeToString(h[:])
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &keyBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/key":
+				file = &keyBytes
+			case "/data":
+				file = &dataBytes
+			case "/signature":
+				file = &sigBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key without url or content",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key without package",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key with empty package",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without url or content",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "package without a signature",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key with data & url but no hash",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "public key with data & url and hash missing value",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Hash: &models.ArchlinuxV001SchemaPackageHash{
+							Algorithm: swag.String(models.ArchlinuxV001SchemaPackageHashAlgorithmSha256),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key with data & url with 404 error on data",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Hash: &models.ArchlinuxV001SchemaPackageHash{
+							Algorithm: swag.String(models.ArchlinuxV001SchemaPackageHashAlgorithmSha256),
+							Value:     swag.String(dataSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/404"),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "public key with invalid key content & with data with content",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(dataBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "public key with data & url and incorrect hash value",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Hash: &models.ArchlinuxV001SchemaPackageHash{
+							Algorithm: swag.String(models.ArchlinuxV001SchemaPackageHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "public key with key content & with data with url and complete hash value",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Hash: &models.ArchlinuxV001SchemaPackageHash{
+							Algorithm: swag.String(models.ArchlinuxV001SchemaPackageHashAlgorithmSha256),
+							Value:     swag.String(dataSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "public key with key content & with data with content",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "package content that is not an Arch Linux package",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "public key that did not create the signature",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(otherKeyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "valid obj with extradata",
+			entry: V001Entry{
+				ArchlinuxModel: models.ArchlinuxV001Schema{
+					PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Package: &models.ArchlinuxV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+					},
+					Signature: &models.ArchlinuxV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+					ExtraData: []byte("{\"something\": \"here\""),
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Archlinux{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.ArchlinuxModel,
+		}
+
+		unmarshalAndValidate := func() error {
+			if err := v.Unmarshal(&r); err != nil {
+				return err
+			}
+			return v.validate()
+		}
+		if err := unmarshalAndValidate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.hasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		b, err := v.Canonicalize(context.TODO())
+		if (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		} else if err != nil {
+			if _, ok := err.(types.ValidationError); !ok {
+				t.Errorf("canonicalize returned an unexpected error that isn't of type types.ValidationError: %v", err)
+			}
+		}
+		if b != nil {
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Errorf("unexpected err from Unmarshalling canonicalized entry for '%v': %v", tc.caseDesc, err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Errorf("unexpected err from type-specific unmarshalling for '%v': %v", tc.caseDesc, err)
+			}
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_archlinux.pub")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test_archlinux.pkg.tar.zst")
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test_archlinux.pkg.tar.zst.sig")
+
+	v := V001Entry{
+		ArchlinuxModel: models.ArchlinuxV001Schema{
+			PublicKey: &models.ArchlinuxV001SchemaPublicKey{
+				Content: strfmt.Base64(keyBytes),
+			},
+			Package: &models.ArchlinuxV001SchemaPackage{
+				Content: strfmt.Base64(dataBytes),
+			},
+			Signature: &models.ArchlinuxV001SchemaSignature{
+				Content: strfmt.Base64(sigBytes),
+			},
+		},
+	}
+	if err := v.fetchExternalEntities(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h := sha256.Sum256(dataBytes)
+	keys := v.IndexKeys()
+	found := map[string]bool{}
+	for _, k := range keys {
+		found[k] = true
+	}
+	if !found["sha256:"+hex.EncodeToString(h[:])] {
+		t.Errorf("package hash missing from index keys: %v", keys)
+	}
+	for _, k := range []string{"rekor-hello", "rekor-hello-1.0.0-1"} {
+		if !found[k] {
+			t.Errorf("%s missing from index keys: %v", k, keys)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticode
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/authenticode"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := authenticode.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	AuthenticodeModel       models.AuthenticodeV001Schema
+	fetchedExternalEntities bool
+	imageObj                *signedImage
+	keyObj                  pki.PublicKey
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.hasExternalEntities() {
+		if err := v.fetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	// the signer's key is only known once the image has been fetched, which entries read back from the log are not
+	if v.keyObj != nil {
+		key, err := v.keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+
+		// the signer certificate subject is indexed as an identity
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	if v.AuthenticodeModel.Image.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.AuthenticodeModel.Image.Hash.Algorithm, *v.AuthenticodeModel.Image.Hash.Value))
+		result = append(result, hashKey)
+	}
+	if v.imageObj != nil {
+		result = append(result, hashName(v.imageObj.hash)+":"+hex.EncodeToString(v.imageObj.digest))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	a, ok := pe.(*models.Authenticode)
+	if !ok {
+		return errors.New("cannot unmarshal non Authenticode v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(a.Spec, &v.AuthenticodeModel); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.AuthenticodeModel.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+func (v V001Entry) hasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.AuthenticodeModel.Image != nil && v.AuthenticodeModel.Image.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	oldSHA := ""
+	if v.AuthenticodeModel.Image.Hash != nil && v.AuthenticodeModel.Image.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.AuthenticodeModel.Image.Hash.Value)
+	}
+
+	dataReadCloser, err := util.FileOrURLReadCloser(ctx, v.AuthenticodeModel.Image.URL.String(), v.AuthenticodeModel.Image.Content)
+	if err != nil {
+		return err
+	}
+	defer dataReadCloser.Close()
+
+	hasher := sha256.New()
+	b := &bytes.Buffer{}
+
+	if _, err := io.Copy(io.MultiWriter(hasher, b), dataReadCloser); err != nil {
+		return err
+	}
+
+	computedSHA := hex.EncodeToString(hasher.Sum(nil))
+	if oldSHA != "" && computedSHA != oldSHA {
+		return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
+	}
+
+	// this ensures that the image is signed, the signature verifies, and that the signed
+	// digest matches the image
+	imageObj, err := verifyImage(b.Bytes())
+	if err != nil {
+		return types.ValidationError(err)
+	}
+
+	af, err := pki.NewArtifactFactory(pki.PKCS7)
+	if err != nil {
+		return err
+	}
+	v.keyObj, err = af.NewPublicKey(bytes.NewReader(imageObj.signature))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	v.imageObj = imageObj
+
+	// if we get here, the image was read and verified without error
+	if oldSHA == "" {
+		v.AuthenticodeModel.Image.Hash = &models.AuthenticodeV001SchemaImageHash{}
+		v.AuthenticodeModel.Image.Hash.Algorithm = swag.String(models.AuthenticodeV001SchemaImageHashAlgorithmSha256)
+		v.AuthenticodeModel.Image.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.imageObj == nil {
+		return nil, errors.New("image object not initialized before canonicalization")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("public key not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.AuthenticodeV001Schema{}
+
+	keyContent, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	// the signature is kept whole rather than detached, as it embeds the digest it signs
+	sigContent := strfmt.Base64(v.imageObj.signature)
+	canonicalEntry.Signature = &models.AuthenticodeV001SchemaSignature{
+		Content: &sigContent,
+		PublicKey: &models.AuthenticodeV001SchemaSignaturePublicKey{
+			Content: (*strfmt.Base64)(&keyContent),
+		},
+		Digest: &models.AuthenticodeV001SchemaSignatureDigest{
+			Algorithm: swag.String(hashName(v.imageObj.hash)),
+			Value:     swag.String(hex.EncodeToString(v.imageObj.digest)),
+		},
+	}
+
+	canonicalEntry.Image = &models.AuthenticodeV001SchemaImage{}
+	canonicalEntry.Image.Hash = &models.AuthenticodeV001SchemaImageHash{}
+	canonicalEntry.Image.Hash.Algorithm = v.AuthenticodeModel.Image.Hash.Algorithm
+	canonicalEntry.Image.Hash.Value = v.AuthenticodeModel.Image.Hash.Value
+	// image content is not set deliberately
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.AuthenticodeModel.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	a := models.Authenticode{}
+	a.APIVersion = swag.String(APIVERSION)
+	a.Spec = &canonicalEntry
+
+	return json.Marshal(&a)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	image := v.AuthenticodeModel.Image
+	if image == nil {
+		return errors.New("missing image")
+	}
+
+	// if the signature isn't present, then we need content to extract
+	if v.AuthenticodeModel.Signature == nil || v.AuthenticodeModel.Signature.Content == nil {
+		if len(image.Content) == 0 && image.URL.String() == "" {
+			return errors.New("one of 'content' or 'url' must be specified for image")
+		}
+	}
+
+	hash := image.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if image.URL.String() != "" {
+		return errors.New("hash value must be provided if URL is specified")
+	}
+
+	return nil
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Authenticode{}
+	re := V001Entry{}
+
+	// we will need only the artifact; the certificate & signature are embedded in the image
+	re.AuthenticodeModel = models.AuthenticodeV001Schema{}
+	re.AuthenticodeModel.Image = &models.AuthenticodeV001SchemaImage{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to PE/COFF image (file or URL) must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			re.AuthenticodeModel.Image.URL = strfmt.URI(props.ArtifactPath.String())
+			if props.ArtifactHash != "" {
+				re.AuthenticodeModel.Image.Hash = &models.AuthenticodeV001SchemaImageHash{
+					Algorithm: swag.String(models.AuthenticodeV001SchemaImageHashAlgorithmSha256),
+					Value:     swag.String(props.ArtifactHash),
+				}
+			}
+		} else {
+			artifactBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error reading image file: %w", err)
+			}
+			re.AuthenticodeModel.Image.Content = strfmt.Base64(artifactBytes)
+		}
+	} else {
+		re.AuthenticodeModel.Image.Content = strfmt.Base64(artifactBytes)
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if re.hasExternalEntities() {
+		if err := re.fetchExternalEntities(ctx); err != nil {
+			return nil, fmt.Errorf("error retrieving external entities: %v", err)
+		}
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.AuthenticodeModel
+
+	return &returnVal, nil
+}
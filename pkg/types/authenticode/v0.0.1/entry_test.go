@@ -0,0 +1,243 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticode
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	imageBytes, _ := ioutil.ReadFile("../../../../tests/test_authenticode.exe")
+	h := sha256.Sum256(imageBytes)
+	imageSHA := hex.EncodeToString(h[:])
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/image" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(imageBytes)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "empty image",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "image with url but no hash",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{
+						URL: strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+			hasExtEntities:         true,
+		},
+		{
+			caseDesc: "image with url and hash",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{
+						URL: strfmt.URI(testServer.URL + "/image"),
+						Hash: &models.AuthenticodeV001SchemaImageHash{
+							Algorithm: swag.String(models.AuthenticodeV001SchemaImageHashAlgorithmSha256),
+							Value:     swag.String(imageSHA),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "image with url and wrong hash",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{
+						URL: strfmt.URI(testServer.URL + "/image"),
+						Hash: &models.AuthenticodeV001SchemaImageHash{
+							Algorithm: swag.String(models.AuthenticodeV001SchemaImageHashAlgorithmSha256),
+							Value:     swag.String("85e7e4d2ab2c4c1f5bfb8d9b22ff1c8e1a8df3fd5a6e9b5fa565cea4b4c4b8a2"),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signed image content",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{
+						Content: strfmt.Base64(imageBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "tampered image content",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{
+						Content: strfmt.Base64(tamper(imageBytes)),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "content that is not an image",
+			entry: V001Entry{
+				AuthenticodeModel: models.AuthenticodeV001Schema{
+					Image: &models.AuthenticodeV001SchemaImage{
+						Content: strfmt.Base64("not a PE/COFF image"),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		v := &V001Entry{}
+		r := models.Authenticode{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.AuthenticodeModel,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+		// No need to continue here if unmarshal failed
+		if !tc.expectUnmarshalSuccess {
+			continue
+		}
+
+		if v.hasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		b, err := v.Canonicalize(context.TODO())
+		if (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		} else if err != nil {
+			if _, ok := err.(types.ValidationError); !ok {
+				t.Errorf("canonicalize returned an unexpected error that isn't of type types.ValidationError: %v", err)
+			}
+		}
+		if b != nil {
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Errorf("unexpected err from Unmarshalling canonicalized entry for '%v': %v", tc.caseDesc, err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Errorf("unexpected err from type-specific unmarshalling for '%v': %v", tc.caseDesc, err)
+			}
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	imageBytes, _ := ioutil.ReadFile("../../../../tests/test_authenticode.exe")
+	h := sha256.Sum256(imageBytes)
+
+	v := V001Entry{
+		AuthenticodeModel: models.AuthenticodeV001Schema{
+			Image: &models.AuthenticodeV001SchemaImage{
+				Content: strfmt.Base64(imageBytes),
+			},
+		},
+	}
+	if _, err := v.Canonicalize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := v.IndexKeys()
+	for _, want := range []string{
+		"sha256:" + hex.EncodeToString(h[:]),
+		"sha256:" + hex.EncodeToString(v.imageObj.digest),
+		"subject:cn=rekor test firmware signing,o=sigstore",
+	} {
+		found := false
+		for _, k := range keys {
+			found = found || k == want
+		}
+		if !found {
+			t.Errorf("IndexKeys() = %v, missing %v", keys, want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticode
+
+import (
+	"bytes"
+	"crypto"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sassoftware/relic/lib/authenticode"
+)
+
+// winCertTypePKCSSignedData is the WIN_CERTIFICATE type of an Authenticode signature
+const winCertTypePKCSSignedData = 0x0002
+
+// signedImage is a PE/COFF image whose Authenticode signature has been verified
+type signedImage struct {
+	// signature is the PKCS7 SignedData from the certificate table, including the indirect data
+	// that binds it to the image digest
+	signature []byte
+	digest    []byte
+	hash      crypto.Hash
+}
+
+// verifyImage checks the single Authenticode signature embedded in a PE/COFF image and that the
+// digest it signs matches the image. Certificate chains are not verified.
+func verifyImage(b []byte) (*signedImage, error) {
+	sigs, err := authenticode.VerifyPE(bytes.NewReader(b), false)
+	if err != nil {
+		return nil, err
+	}
+	switch len(sigs) {
+	case 0:
+		return nil, errors.New("no signatures detected in image")
+	case 1:
+	default:
+		return nil, errors.New("multiple signatures detected in image; unable to process")
+	}
+
+	signature, err := certificateTable(b)
+	if err != nil {
+		return nil, err
+	}
+	return &signedImage{
+		signature: signature,
+		digest:    sigs[0].Indirect.MessageDigest.Digest,
+		hash:      sigs[0].ImageHashFunc,
+	}, nil
+}
+
+// certificateTable returns the contents of the first WIN_CERTIFICATE in the image
+func certificateTable(b []byte) ([]byte, error) {
+	f, err := pe.NewFile(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	var dir pe.DataDirectory
+	switch h := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if h.NumberOfRvaAndSizes > pe.IMAGE_DIRECTORY_ENTRY_SECURITY {
+			dir = h.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_SECURITY]
+		}
+	case *pe.OptionalHeader64:
+		if h.NumberOfRvaAndSizes > pe.IMAGE_DIRECTORY_ENTRY_SECURITY {
+			dir = h.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_SECURITY]
+		}
+	default:
+		return nil, errors.New("image has no optional header")
+	}
+
+	// unlike other data directories, the certificate table address is a file offset
+	start, size := uint64(dir.VirtualAddress), uint64(dir.Size)
+	if size < 8 || start+size > uint64(len(b)) {
+		return nil, errors.New("invalid certificate table")
+	}
+	table := b[start : start+size]
+	length := uint64(binary.LittleEndian.Uint32(table[0:4]))
+	if length < 8 || length > size {
+		return nil, errors.New("invalid certificate table entry")
+	}
+	if certType := binary.LittleEndian.Uint16(table[6:8]); certType != winCertTypePKCSSignedData {
+		return nil, fmt.Errorf("unsupported certificate type 0x%04x", certType)
+	}
+	return table[8:length], nil
+}
+
+// hashName returns the spelling used for hash algorithms in the schema, e.g. sha256
+func hashName(h crypto.Hash) string {
+	return strings.ToLower(strings.ReplaceAll(h.String(), "-", ""))
+}
@@ -0,0 +1,65 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authenticode
+
+import (
+	"bytes"
+	"crypto"
+	"debug/pe"
+	"io/ioutil"
+	"testing"
+
+	"github.com/sassoftware/relic/lib/pkcs7"
+)
+
+// tamper returns a copy of the image with a byte of its first section flipped
+func tamper(b []byte) []byte {
+	out := append([]byte{}, b...)
+	f, err := pe.NewFile(bytes.NewReader(b))
+	if err != nil || len(f.Sections) == 0 {
+		return out
+	}
+	out[f.Sections[0].Offset] ^= 0xff
+	return out
+}
+
+func TestVerifyImage(t *testing.T) {
+	imageBytes, err := ioutil.ReadFile("../../../../tests/test_authenticode.exe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := verifyImage(imageBytes)
+	if err != nil {
+		t.Fatalf("verifyImage() error = %v", err)
+	}
+	if img.hash != crypto.SHA256 || len(img.digest) != crypto.SHA256.Size() {
+		t.Errorf("verifyImage() digest = %v %x, want a SHA-256 digest", img.hash, img.digest)
+	}
+	if hashName(img.hash) != "sha256" {
+		t.Errorf("hashName() = %v, want sha256", hashName(img.hash))
+	}
+	if _, err := pkcs7.Unmarshal(img.signature); err != nil {
+		t.Errorf("certificate table does not hold a PKCS7 signature: %v", err)
+	}
+
+	if _, err := verifyImage(tamper(imageBytes)); err == nil {
+		t.Error("verifyImage() succeeded on a tampered image")
+	}
+	if _, err := verifyImage(imageBytes[:len(img.signature)]); err == nil {
+		t.Error("verifyImage() succeeded on a truncated image")
+	}
+}
@@ -0,0 +1,208 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cose
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/cose"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := cose.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	CoseObj models.CoseV001Schema
+	keyObj  *x509.PublicKey
+	message *sign1Message
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.keyObj != nil {
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	switch {
+	case v.message != nil:
+		h := sha256.Sum256(v.message.payload)
+		result = append(result, "sha256:"+hex.EncodeToString(h[:]))
+	case v.CoseObj.Data != nil && v.CoseObj.Data.PayloadHash != nil && v.CoseObj.Data.PayloadHash.Value != nil:
+		result = append(result, *v.CoseObj.Data.PayloadHash.Algorithm+":"+*v.CoseObj.Data.PayloadHash.Value)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Cose)
+	if !ok {
+		return errors.New("cannot unmarshal non COSE v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.CoseObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.CoseObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// the verifier may be either a PEM encoded public key or certificate
+	var err error
+	v.keyObj, err = x509.NewPublicKey(bytes.NewReader(*v.CoseObj.PublicKey))
+	if err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	// This also gets called in the CLI, where we won't have this data
+	if len(v.CoseObj.Message) == 0 {
+		return nil
+	}
+
+	m, err := parseSign1(v.CoseObj.Message)
+	if err != nil {
+		return err
+	}
+	if err := m.verify(v.keyObj.CryptoPubKey()); err != nil {
+		return err
+	}
+	v.message = m
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.keyObj == nil {
+		return nil, errors.New("cannot canonicalize empty key")
+	}
+	if v.message == nil {
+		return nil, errors.New("cannot canonicalize entry without a verified COSE_Sign1 message")
+	}
+
+	pk, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	pkb := strfmt.Base64(pk)
+
+	payloadHash := sha256.Sum256(v.message.payload)
+	envelopeHash := sha256.Sum256(v.message.canonical())
+
+	canonicalEntry := models.CoseV001Schema{
+		PublicKey: &pkb,
+		Data: &models.CoseV001SchemaData{
+			PayloadHash: &models.CoseV001SchemaDataPayloadHash{
+				Algorithm: swag.String(models.CoseV001SchemaDataPayloadHashAlgorithmSha256),
+				Value:     swag.String(hex.EncodeToString(payloadHash[:])),
+			},
+			EnvelopeHash: &models.CoseV001SchemaDataEnvelopeHash{
+				Algorithm: swag.String(models.CoseV001SchemaDataEnvelopeHashAlgorithmSha256),
+				Value:     swag.String(hex.EncodeToString(envelopeHash[:])),
+			},
+		},
+		ExtraData: v.CoseObj.ExtraData,
+	}
+
+	coseObj := models.Cose{}
+	coseObj.APIVersion = swag.String(APIVERSION)
+	coseObj.Spec = &canonicalEntry
+
+	return json.Marshal(&coseObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Cose{}
+
+	var err error
+	messageBytes := props.ArtifactBytes
+	if messageBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to COSE_Sign1 message must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("COSE_Sign1 messages cannot be fetched over HTTP(S)")
+		}
+		messageBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify signature")
+		}
+		publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+	}
+	kb := strfmt.Base64(publicKeyBytes)
+
+	re := V001Entry{
+		CoseObj: models.CoseV001Schema{
+			Message:   strfmt.Base64(messageBytes),
+			PublicKey: &kb,
+		},
+	}
+
+	returnVal.Spec = re.CoseObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
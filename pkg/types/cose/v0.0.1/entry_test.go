@@ -0,0 +1,278 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cose
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func p(b []byte) *strfmt.Base64 {
+	b64 := strfmt.Base64(b)
+	return &b64
+}
+
+func pemKey(t *testing.T, pub interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// sign1 builds a tagged COSE_Sign1 message over payload; unprotected is encoded as-is
+func sign1(alg int64, sign func([]byte) []byte, payload []byte, unprotected []byte) []byte {
+	protected := appendHead(nil, majorMap, 1)
+	protected = appendHead(protected, majorUnsigned, headerAlgorithm)
+	protected = appendHead(protected, majorNegative, uint64(-1-alg))
+
+	m := &sign1Message{protected: protected, payload: payload}
+	sig := sign(m.toBeSigned())
+
+	b := appendHead(nil, majorTag, sign1Tag)
+	b = appendHead(b, majorArray, 4)
+	b = appendBytes(b, protected)
+	b = append(b, unprotected...)
+	b = appendBytes(b, payload)
+	return appendBytes(b, sig)
+}
+
+func es256Signer(t *testing.T, k *ecdsa.PrivateKey) func([]byte) []byte {
+	return func(tbs []byte) []byte {
+		digest := sha256.Sum256(tbs)
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	}
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cose signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &ecKey.PublicKey, ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+
+	payload := []byte("hello cose")
+	edSign := func(tbs []byte) []byte { return ed25519.Sign(edPriv, tbs) }
+	es256 := sign1(algES256, es256Signer(t, ecKey), payload, appendHead(nil, majorMap, 0))
+	eddsa := sign1(algEdDSA, edSign, payload, appendHead(nil, majorMap, 0))
+
+	tampered := append([]byte{}, es256...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	detached := appendHead(nil, majorArray, 4)
+	detached = appendBytes(detached, []byte{0xa1, 0x01, 0x26})
+	detached = appendHead(detached, majorMap, 0)
+	detached = append(detached, 0xf6)
+	detached = appendBytes(detached, make([]byte, 64))
+
+	tests := []struct {
+		name    string
+		obj     models.CoseV001Schema
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			obj:     models.CoseV001Schema{},
+			wantErr: true,
+		},
+		{
+			name: "public key without message",
+			obj: models.CoseV001Schema{
+				PublicKey: p(pemKey(t, &ecKey.PublicKey)),
+			},
+		},
+		{
+			name: "ES256 with public key",
+			obj: models.CoseV001Schema{
+				Message:   es256,
+				PublicKey: p(pemKey(t, &ecKey.PublicKey)),
+			},
+		},
+		{
+			name: "ES256 with certificate",
+			obj: models.CoseV001Schema{
+				Message:   es256,
+				PublicKey: p(cert),
+			},
+		},
+		{
+			name: "EdDSA",
+			obj: models.CoseV001Schema{
+				Message:   eddsa,
+				PublicKey: p(pemKey(t, edPub)),
+			},
+		},
+		{
+			name: "wrong key type",
+			obj: models.CoseV001Schema{
+				Message:   eddsa,
+				PublicKey: p(pemKey(t, &ecKey.PublicKey)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered signature",
+			obj: models.CoseV001Schema{
+				Message:   tampered,
+				PublicKey: p(pemKey(t, &ecKey.PublicKey)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "detached payload",
+			obj: models.CoseV001Schema{
+				Message:   detached,
+				PublicKey: p(pemKey(t, &ecKey.PublicKey)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "not cbor",
+			obj: models.CoseV001Schema{
+				Message:   []byte("not a cose message"),
+				PublicKey: p(pemKey(t, &ecKey.PublicKey)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			it := &models.Cose{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       &tt.obj,
+			}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr || len(tt.obj.Message) == 0 {
+				return
+			}
+			if _, err := v.Canonicalize(context.Background()); err != nil {
+				t.Errorf("V001Entry.Canonicalize() error = %v", err)
+			}
+			h := sha256.Sum256(payload)
+			want := "sha256:" + hex.EncodeToString(h[:])
+			found := false
+			for _, k := range v.IndexKeys() {
+				found = found || k == want
+			}
+			if !found {
+				t.Errorf("V001Entry.IndexKeys() = %v, missing %v", v.IndexKeys(), want)
+			}
+		})
+	}
+}
+
+func TestV001Entry_CanonicalizeIgnoresUnprotectedHeaders(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edSign := func(tbs []byte) []byte { return ed25519.Sign(edPriv, tbs) }
+
+	// {4: h'6b6964'} sets a key id in the unprotected header
+	unprotected := appendHead(nil, majorMap, 1)
+	unprotected = appendHead(unprotected, majorUnsigned, 4)
+	unprotected = appendBytes(unprotected, []byte("kid"))
+
+	plain := sign1(algEdDSA, edSign, []byte("payload"), appendHead(nil, majorMap, 0))
+	m, err := parseSign1(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// move the signature onto a message carrying an unprotected header
+	withHeader := appendHead(nil, majorTag, sign1Tag)
+	withHeader = appendHead(withHeader, majorArray, 4)
+	withHeader = appendBytes(withHeader, m.protected)
+	withHeader = append(withHeader, unprotected...)
+	withHeader = appendBytes(withHeader, m.payload)
+	withHeader = appendBytes(withHeader, m.signature)
+
+	canonicalize := func(msg []byte) []byte {
+		v := &V001Entry{}
+		it := &models.Cose{
+			APIVersion: swag.String(APIVERSION),
+			Spec: &models.CoseV001Schema{
+				Message:   msg,
+				PublicKey: p(pemKey(t, edPub)),
+			},
+		}
+		if err := v.Unmarshal(it); err != nil {
+			t.Fatal(err)
+		}
+		b, err := v.Canonicalize(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	if a, b := canonicalize(plain), canonicalize(withHeader); string(a) != string(b) {
+		t.Errorf("canonical entries differ:\n%s\n%s", a, b)
+	}
+}
@@ -0,0 +1,155 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// sign1Tag is the CBOR tag identifying a COSE_Sign1 message
+	sign1Tag = 18
+
+	// COSE header label for the signature algorithm
+	headerAlgorithm = 1
+
+	// COSE algorithm identifiers from the IANA registry
+	algES256 = -7
+	algEdDSA = -8
+)
+
+var (
+	ErrDetachedPayload      = errors.New("COSE_Sign1 messages with detached payloads are not supported")
+	ErrUnsupportedAlgorithm = errors.New("unsupported COSE signature algorithm")
+)
+
+// sign1Message holds the parts of a COSE_Sign1 message (RFC 8152 section 4.2) that are
+// covered by the signature; unprotected headers are discarded
+type sign1Message struct {
+	protected []byte
+	payload   []byte
+	signature []byte
+	alg       int64
+}
+
+func parseSign1(b []byte) (*sign1Message, error) {
+	v, err := decodeCBOR(b)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := v.(cborTag); ok {
+		if t.number != sign1Tag {
+			return nil, fmt.Errorf("unexpected CBOR tag %d for COSE_Sign1 message", t.number)
+		}
+		v = t.content
+	}
+	a, ok := v.([]interface{})
+	if !ok || len(a) != 4 {
+		return nil, errors.New("COSE_Sign1 message must be an array of four items")
+	}
+
+	m := &sign1Message{}
+	if m.protected, ok = a[0].([]byte); !ok {
+		return nil, errors.New("COSE_Sign1 protected header must be a byte string")
+	}
+	if _, ok := a[1].(map[interface{}]interface{}); !ok {
+		return nil, errors.New("COSE_Sign1 unprotected header must be a map")
+	}
+	if a[2] == nil {
+		return nil, ErrDetachedPayload
+	}
+	if m.payload, ok = a[2].([]byte); !ok {
+		return nil, errors.New("COSE_Sign1 payload must be a byte string")
+	}
+	if m.signature, ok = a[3].([]byte); !ok {
+		return nil, errors.New("COSE_Sign1 signature must be a byte string")
+	}
+
+	// the algorithm must be integrity protected, so it is only read from the protected header
+	if len(m.protected) == 0 {
+		return nil, errors.New("COSE_Sign1 protected header does not specify an algorithm")
+	}
+	h, err := decodeCBOR(m.protected)
+	if err != nil {
+		return nil, fmt.Errorf("decoding protected header: %w", err)
+	}
+	hm, ok := h.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("COSE_Sign1 protected header must be a map")
+	}
+	if m.alg, ok = hm[int64(headerAlgorithm)].(int64); !ok {
+		return nil, errors.New("COSE_Sign1 protected header does not specify an algorithm")
+	}
+	return m, nil
+}
+
+// toBeSigned returns the Sig_structure the signature is computed over, with empty external data
+func (m *sign1Message) toBeSigned() []byte {
+	b := appendHead(nil, majorArray, 4)
+	b = appendText(b, "Signature1")
+	b = appendBytes(b, m.protected)
+	b = appendBytes(b, nil)
+	return appendBytes(b, m.payload)
+}
+
+// canonical returns a deterministic encoding of the message: always tagged, with the
+// unprotected header emptied since it is not covered by the signature
+func (m *sign1Message) canonical() []byte {
+	b := appendHead(nil, majorTag, sign1Tag)
+	b = appendHead(b, majorArray, 4)
+	b = appendBytes(b, m.protected)
+	b = appendHead(b, majorMap, 0)
+	b = appendBytes(b, m.payload)
+	return appendBytes(b, m.signature)
+}
+
+func (m *sign1Message) verify(pub crypto.PublicKey) error {
+	tbs := m.toBeSigned()
+	switch m.alg {
+	case algES256:
+		k, ok := pub.(*ecdsa.PublicKey)
+		if !ok || k.Curve != elliptic.P256() {
+			return errors.New("ES256 signatures require a P-256 ECDSA public key")
+		}
+		if len(m.signature) != 64 {
+			return errors.New("invalid ES256 signature length")
+		}
+		r := new(big.Int).SetBytes(m.signature[:32])
+		s := new(big.Int).SetBytes(m.signature[32:])
+		digest := sha256.Sum256(tbs)
+		if !ecdsa.Verify(k, digest[:], r, s) {
+			return errors.New("COSE_Sign1 signature verification failed")
+		}
+	case algEdDSA:
+		k, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return errors.New("EdDSA signatures require an Ed25519 public key")
+		}
+		if !ed25519.Verify(k, tbs, m.signature) {
+			return errors.New("COSE_Sign1 signature verification failed")
+		}
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedAlgorithm, m.alg)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+)
+
+// maxManifestSize bounds the Cargo.toml read from a crate
+const maxManifestSize = 1 << 20
+
+// nameRegex follows the crate name rules enforced by crates.io
+var nameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,63}$`)
+
+// versionRegex matches semantic versions, see https://semver.org/
+var versionRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+type Package struct {
+	Name    string
+	Version string
+}
+
+type manifest struct {
+	Package struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+	} `toml:"package"`
+}
+
+// Unmarshal reads a .crate file, a gzip compressed tarball with every file below a
+// <name>-<version> directory, and extracts the name and version from its Cargo.toml
+func (p *Package) Unmarshal(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "not a crate: expected a gzip compressed archive")
+	}
+	defer gz.Close()
+
+	var root string
+	var m *manifest
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "getting next entry in tar archive")
+		}
+
+		name := path.Clean(header.Name)
+		dir := strings.SplitN(name, "/", 2)[0]
+		if root == "" {
+			root = dir
+		} else if dir != root {
+			return fmt.Errorf("crate contains files outside of %s/", root)
+		}
+		if name != root+"/Cargo.toml" {
+			continue
+		}
+		if m != nil {
+			return errors.New("crate contains more than one Cargo.toml")
+		}
+		b, err := ioutil.ReadAll(io.LimitReader(tr, maxManifestSize+1))
+		if err != nil {
+			return errors.Wrap(err, "reading Cargo.toml")
+		}
+		if len(b) > maxManifestSize {
+			return errors.New("Cargo.toml is too large")
+		}
+		m = &manifest{}
+		if err := toml.Unmarshal(b, m); err != nil {
+			return errors.Wrap(err, "parsing Cargo.toml")
+		}
+	}
+	if m == nil {
+		return errors.New("Cargo.toml file was not located")
+	}
+
+	if !nameRegex.MatchString(m.Package.Name) {
+		return fmt.Errorf("invalid crate name %q", m.Package.Name)
+	}
+	if !versionRegex.MatchString(m.Package.Version) {
+		return fmt.Errorf("invalid crate version %q", m.Package.Version)
+	}
+	if root != m.Package.Name+"-"+m.Package.Version {
+		return fmt.Errorf("crate directory %s does not match Cargo.toml package %s %s", root, m.Package.Name, m.Package.Version)
+	}
+
+	p.Name, p.Version = m.Package.Name, m.Package.Version
+	return nil
+}
@@ -0,0 +1,87 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+)
+
+// tarball builds a gzip compressed tarball of the given files
+func tarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestPackageUnmarshal(t *testing.T) {
+	f, err := os.Open("../../../tests/test.crate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	p := Package{}
+	if err := p.Unmarshal(f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name != "test-crate" || p.Version != "0.1.0" {
+		t.Errorf("unexpected package %s %s", p.Name, p.Version)
+	}
+
+	manifest := "[package]\nname = \"foo\"\nversion = \"1.0.0-rc.1\"\n"
+	tests := []struct {
+		name    string
+		crate   []byte
+		wantErr bool
+	}{
+		{name: "valid", crate: tarball(t, map[string]string{"foo-1.0.0-rc.1/Cargo.toml": manifest})},
+		{name: "directory mismatch", crate: tarball(t, map[string]string{"foo-1.0.0/Cargo.toml": manifest}), wantErr: true},
+		{name: "files outside of the crate directory", crate: tarball(t, map[string]string{"foo-1.0.0-rc.1/Cargo.toml": manifest, "other/lib.rs": ""}), wantErr: true},
+		{name: "no manifest", crate: tarball(t, map[string]string{"foo-1.0.0-rc.1/src/lib.rs": ""}), wantErr: true},
+		{name: "nested manifest only", crate: tarball(t, map[string]string{"foo-1.0.0-rc.1/sub/Cargo.toml": manifest}), wantErr: true},
+		{name: "invalid version", crate: tarball(t, map[string]string{"foo-1.0/Cargo.toml": "[package]\nname = \"foo\"\nversion = \"1.0\"\n"}), wantErr: true},
+		{name: "invalid name", crate: tarball(t, map[string]string{"1foo-1.0.0/Cargo.toml": "[package]\nname = \"1foo\"\nversion = \"1.0.0\"\n"}), wantErr: true},
+		{name: "invalid toml", crate: tarball(t, map[string]string{"foo-1.0.0/Cargo.toml": "[package"}), wantErr: true},
+		{name: "not gzip", crate: []byte("hello"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Package{}
+			if err := p.Unmarshal(bytes.NewReader(tt.crate)); (err != nil) != tt.wantErr {
+				t.Errorf("Package.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/sigstore/rekor/pkg/pki/x509"
+)
+
+// bundle holds the parts of a Sigstore bundle needed to verify a signature over a blob
+type bundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate"`
+	} `json:"verificationMaterial"`
+	MessageSignature *struct {
+		MessageDigest *struct {
+			Algorithm string `json:"algorithm"`
+			Digest    []byte `json:"digest"`
+		} `json:"messageDigest"`
+		Signature []byte `json:"signature"`
+	} `json:"messageSignature"`
+}
+
+// verifyBundle checks the bundle's message signature over data was made by the certificate in
+// the bundle, which is returned
+func verifyBundle(b, data []byte) (*x509.PublicKey, error) {
+	sb := bundle{}
+	if err := json.Unmarshal(b, &sb); err != nil {
+		return nil, fmt.Errorf("invalid Sigstore bundle: %w", err)
+	}
+	if !strings.HasPrefix(sb.MediaType, "application/vnd.dev.sigstore.bundle") {
+		return nil, fmt.Errorf("unsupported bundle media type %q", sb.MediaType)
+	}
+	ms := sb.MessageSignature
+	if ms == nil || len(ms.Signature) == 0 {
+		return nil, errors.New("bundle does not contain a message signature")
+	}
+	if md := ms.MessageDigest; md != nil {
+		h := sha256.Sum256(data)
+		if md.Algorithm != "SHA2_256" || !bytes.Equal(md.Digest, h[:]) {
+			return nil, errors.New("bundle message digest does not match the crate")
+		}
+	}
+
+	var certDER []byte
+	switch vm := sb.VerificationMaterial; {
+	case vm.Certificate != nil:
+		certDER = vm.Certificate.RawBytes
+	case vm.X509CertificateChain != nil && len(vm.X509CertificateChain.Certificates) > 0:
+		certDER = vm.X509CertificateChain.Certificates[0].RawBytes
+	default:
+		return nil, errors.New("bundle does not contain a signing certificate")
+	}
+	// the certificate is subject to the trust roots configured for x509 keys
+	cert, err := x509.NewPublicKey(bytes.NewReader(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})))
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := signature.LoadVerifier(cert.CryptoPubKey(), crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.VerifySignature(bytes.NewReader(ms.Signature), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("verifying bundle signature: %w", err)
+	}
+	return cert, nil
+}
@@ -0,0 +1,420 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/crate"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := crate.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	CrateModel              models.CrateV001Schema
+	fetchedExternalEntities bool
+	// keyObj is the PGP key, or the certificate from a Sigstore bundle
+	keyObj pki.PublicKey
+	// sigContent is the canonical PGP signature or the Sigstore bundle
+	sigContent []byte
+	pkg        crate.Package
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.hasExternalEntities() {
+		if err := v.fetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if v.keyObj != nil {
+		key, err := v.keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	name, version := v.pkg.Name, v.pkg.Version
+	if name == "" {
+		name, version = v.CrateModel.Package.Name, v.CrateModel.Package.Version
+	}
+	if name != "" {
+		result = append(result, name, name+"@"+version)
+	}
+
+	if v.CrateModel.Package.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.CrateModel.Package.Hash.Algorithm, *v.CrateModel.Package.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	c, ok := pe.(*models.Crate)
+	if !ok {
+		return errors.New("cannot unmarshal non crate v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(c.Spec, &v.CrateModel); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.CrateModel.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+func (v V001Entry) hasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.CrateModel.Package != nil && v.CrateModel.Package.URL.String() != "" {
+		return true
+	}
+	if v.CrateModel.PublicKey != nil && v.CrateModel.PublicKey.URL.String() != "" {
+		return true
+	}
+	if v.CrateModel.Signature != nil && v.CrateModel.Signature.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func readAll(ctx context.Context, url string, content []byte) ([]byte, error) {
+	rc, err := util.FileOrURLReadCloser(ctx, url, content)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	isPGP := *v.CrateModel.Signature.Format == models.CrateV001SchemaSignatureFormatPgp
+	if !isPGP && v.CrateModel.PublicKey != nil {
+		// canonicalized entries hold the certificate here, but it is always taken from the bundle
+		return types.ValidationError(errors.New("a public key cannot be supplied with a Sigstore bundle"))
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	var crateBytes, sigBytes, keyBytes []byte
+	g.Go(func() error {
+		var err error
+		crateBytes, err = readAll(ctx, v.CrateModel.Package.URL.String(), v.CrateModel.Package.Content)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		sigBytes, err = readAll(ctx, v.CrateModel.Signature.URL.String(), v.CrateModel.Signature.Content)
+		return err
+	})
+	if isPGP {
+		g.Go(func() error {
+			var err error
+			keyBytes, err = readAll(ctx, v.CrateModel.PublicKey.URL.String(), v.CrateModel.PublicKey.Content)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	h := sha256.Sum256(crateBytes)
+	computedSHA := hex.EncodeToString(h[:])
+	if v.CrateModel.Package.Hash != nil && v.CrateModel.Package.Hash.Value != nil {
+		if oldSHA := swag.StringValue(v.CrateModel.Package.Hash.Value); computedSHA != oldSHA {
+			return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
+		}
+	}
+
+	if err := v.pkg.Unmarshal(bytes.NewReader(crateBytes)); err != nil {
+		return types.ValidationError(err)
+	}
+
+	if isPGP {
+		artifactFactory, err := pki.NewArtifactFactory(pki.PGP)
+		if err != nil {
+			return err
+		}
+		keyObj, err := artifactFactory.NewPublicKey(bytes.NewReader(keyBytes))
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		sigObj, err := artifactFactory.NewSignature(bytes.NewReader(sigBytes))
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		if err := sigObj.Verify(bytes.NewReader(crateBytes), keyObj); err != nil {
+			return types.ValidationError(err)
+		}
+		if v.sigContent, err = sigObj.CanonicalValue(); err != nil {
+			return err
+		}
+		v.keyObj = keyObj
+	} else {
+		cert, err := verifyBundle(sigBytes, crateBytes)
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		v.keyObj, v.sigContent = cert, sigBytes
+	}
+
+	// if we get here, all goroutines succeeded without error
+	if v.CrateModel.Package.Hash == nil {
+		v.CrateModel.Package.Hash = &models.CrateV001SchemaPackageHash{}
+		v.CrateModel.Package.Hash.Algorithm = swag.String(models.CrateV001SchemaPackageHashAlgorithmSha256)
+		v.CrateModel.Package.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.CrateV001Schema{}
+
+	var err error
+	// need to canonicalize key content
+	canonicalEntry.PublicKey = &models.CrateV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Signature = &models.CrateV001SchemaSignature{
+		Format:  v.CrateModel.Signature.Format,
+		Content: v.sigContent,
+	}
+
+	canonicalEntry.Package = &models.CrateV001SchemaPackage{}
+	canonicalEntry.Package.Hash = &models.CrateV001SchemaPackageHash{}
+	canonicalEntry.Package.Hash.Algorithm = v.CrateModel.Package.Hash.Algorithm
+	canonicalEntry.Package.Hash.Value = v.CrateModel.Package.Hash.Value
+	// data content is not set deliberately
+
+	canonicalEntry.Package.Name = v.pkg.Name
+	canonicalEntry.Package.Version = v.pkg.Version
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.CrateModel.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	c := models.Crate{}
+	c.APIVersion = swag.String(APIVERSION)
+	c.Spec = &canonicalEntry
+
+	return json.Marshal(&c)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	sig := v.CrateModel.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := v.CrateModel.PublicKey
+	if swag.StringValue(sig.Format) == models.CrateV001SchemaSignatureFormatPgp {
+		if key == nil {
+			return errors.New("missing public key")
+		}
+		if len(key.Content) == 0 && key.URL.String() == "" {
+			return errors.New("one of 'content' or 'url' must be specified for publicKey")
+		}
+	}
+
+	pkg := v.CrateModel.Package
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+
+	hash := pkg.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if len(pkg.Content) == 0 && pkg.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for package")
+	}
+
+	return nil
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func readProperty(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Crate{}
+	re := V001Entry{}
+
+	// we will need the crate, a signature and, for PGP signatures, a public key
+	re.CrateModel = models.CrateV001Schema{}
+	re.CrateModel.Package = &models.CrateV001SchemaPackage{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to .crate file (file or URL) must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			re.CrateModel.Package.URL = strfmt.URI(props.ArtifactPath.String())
+			if props.ArtifactHash != "" {
+				re.CrateModel.Package.Hash = &models.CrateV001SchemaPackageHash{
+					Algorithm: swag.String(models.CrateV001SchemaPackageHashAlgorithmSha256),
+					Value:     swag.String(props.ArtifactHash),
+				}
+			}
+		} else {
+			if artifactBytes, err = readProperty(props.ArtifactPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.CrateModel.Package.Content = strfmt.Base64(artifactBytes)
+		}
+	} else {
+		re.CrateModel.Package.Content = strfmt.Base64(artifactBytes)
+	}
+
+	// a Sigstore bundle is used unless a PGP key is given
+	format := models.CrateV001SchemaSignatureFormatSigstore
+	if props.PublicKeyBytes != nil || props.PublicKeyPath != nil {
+		format = models.CrateV001SchemaSignatureFormatPgp
+	}
+	re.CrateModel.Signature = &models.CrateV001SchemaSignature{Format: swag.String(format)}
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a signature or Sigstore bundle must be provided")
+		}
+		if props.SignaturePath.IsAbs() {
+			re.CrateModel.Signature.URL = strfmt.URI(props.SignaturePath.String())
+		} else {
+			if sigBytes, err = readProperty(props.SignaturePath.Path); err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.CrateModel.Signature.Content = strfmt.Base64(sigBytes)
+		}
+	} else {
+		re.CrateModel.Signature.Content = strfmt.Base64(sigBytes)
+	}
+
+	if format == models.CrateV001SchemaSignatureFormatPgp {
+		re.CrateModel.PublicKey = &models.CrateV001SchemaPublicKey{}
+		publicKeyBytes := props.PublicKeyBytes
+		switch {
+		case publicKeyBytes != nil:
+			re.CrateModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+		case props.PublicKeyPath.IsAbs():
+			re.CrateModel.PublicKey.URL = strfmt.URI(props.PublicKeyPath.String())
+		default:
+			if publicKeyBytes, err = readProperty(props.PublicKeyPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.CrateModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+		}
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if re.hasExternalEntities() {
+		if err := re.fetchExternalEntities(ctx); err != nil {
+			return nil, fmt.Errorf("error retrieving external entities: %v", err)
+		}
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.CrateModel
+
+	return &returnVal, nil
+}
@@ -0,0 +1,343 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crate
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+// newBundle returns a Sigstore bundle with a message signature over data, signed by a
+// self-signed certificate
+func newBundle(t *testing.T, data []byte) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "sigstore"},
+		EmailAddresses: []string{"ci@example.com"},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(data)
+	sig, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"mediaType": "application/vnd.dev.sigstore.bundle+json;version=0.2",
+		"verificationMaterial": map[string]interface{}{
+			"x509CertificateChain": map[string]interface{}{
+				"certificates": []map[string][]byte{{"rawBytes": der}},
+			},
+		},
+		"messageSignature": map[string]interface{}{
+			"messageDigest": map[string]interface{}{"algorithm": "SHA2_256", "digest": h[:]},
+			"signature":     sig,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_crate.pub")
+	otherKeyBytes, _ := ioutil.ReadFile("../../../../tests/test_maven.pub")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test.crate")
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test.crate.asc")
+	otherDataBytes, _ := ioutil.ReadFile("../../../../tests/test.jar")
+	otherSigBytes, _ := ioutil.ReadFile("../../../../tests/test.jar.asc")
+	bundleBytes := newBundle(t, dataBytes)
+	otherBundleBytes := newBundle(t, otherDataBytes)
+
+	h := sha256.Sum256(dataBytes)
+	dataSHA := hex.EncodeToString(h[:])
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var file []byte
+			switch r.URL.Path {
+			case "/key":
+				file = keyBytes
+			case "/data":
+				file = dataBytes
+			case "/signature":
+				file = sigBytes
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(file)
+		}))
+	defer testServer.Close()
+
+	pgp := swag.String(models.CrateV001SchemaSignatureFormatPgp)
+	sigstore := swag.String(models.CrateV001SchemaSignatureFormatSigstore)
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "pgp signature without public key",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: pgp, Content: strfmt.Base64(sigBytes)},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without url or content",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: sigstore},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "pgp signature from urls",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package: &models.CrateV001SchemaPackage{
+						URL: strfmt.URI(testServer.URL + "/data"),
+						Hash: &models.CrateV001SchemaPackageHash{
+							Algorithm: swag.String(models.CrateV001SchemaPackageHashAlgorithmSha256),
+							Value:     swag.String(dataSHA),
+						},
+					},
+					Signature: &models.CrateV001SchemaSignature{Format: pgp, URL: strfmt.URI(testServer.URL + "/signature")},
+					PublicKey: &models.CrateV001SchemaPublicKey{URL: strfmt.URI(testServer.URL + "/key")},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "pgp signature inline",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: pgp, Content: strfmt.Base64(sigBytes)},
+					PublicKey: &models.CrateV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "pgp signature with wrong key",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: pgp, Content: strfmt.Base64(sigBytes)},
+					PublicKey: &models.CrateV001SchemaPublicKey{Content: strfmt.Base64(otherKeyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signed file is not a crate",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(otherDataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: pgp, Content: strfmt.Base64(otherSigBytes)},
+					PublicKey: &models.CrateV001SchemaPublicKey{Content: strfmt.Base64(otherKeyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "hash mismatch",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package: &models.CrateV001SchemaPackage{
+						Content: strfmt.Base64(dataBytes),
+						Hash: &models.CrateV001SchemaPackageHash{
+							Algorithm: swag.String(models.CrateV001SchemaPackageHashAlgorithmSha256),
+							Value:     swag.String(dataSHA[:60] + "0000"),
+						},
+					},
+					Signature: &models.CrateV001SchemaSignature{Format: sigstore, Content: strfmt.Base64(bundleBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "sigstore bundle",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: sigstore, Content: strfmt.Base64(bundleBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "sigstore bundle over other data",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: sigstore, Content: strfmt.Base64(otherBundleBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "sigstore bundle with public key",
+			entry: V001Entry{
+				CrateModel: models.CrateV001Schema{
+					Package:   &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.CrateV001SchemaSignature{Format: sigstore, Content: strfmt.Base64(bundleBytes)},
+					PublicKey: &models.CrateV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Crate{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.CrateModel,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.hasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		b, err := v.Canonicalize(context.TODO())
+		if (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		} else if err != nil {
+			if _, ok := err.(types.ValidationError); !ok {
+				t.Errorf("canonicalize returned an unexpected error that isn't of type types.ValidationError: %v", err)
+			}
+		}
+		if b != nil {
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Errorf("unexpected err from Unmarshalling canonicalized entry for '%v': %v", tc.caseDesc, err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Errorf("unexpected err from type-specific unmarshalling for '%v': %v", tc.caseDesc, err)
+			}
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test.crate")
+
+	v := V001Entry{
+		CrateModel: models.CrateV001Schema{
+			Package: &models.CrateV001SchemaPackage{Content: strfmt.Base64(dataBytes)},
+			Signature: &models.CrateV001SchemaSignature{
+				Format:  swag.String(models.CrateV001SchemaSignatureFormatSigstore),
+				Content: strfmt.Base64(newBundle(t, dataBytes)),
+			},
+		},
+	}
+	if err := v.fetchExternalEntities(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h := sha256.Sum256(dataBytes)
+	keys := v.IndexKeys()
+	for _, want := range []string{"test-crate", "test-crate@0.1.0", "sha256:" + hex.EncodeToString(h[:]), "ci@example.com"} {
+		found := false
+		for _, k := range keys {
+			found = found || k == want
+		}
+		if !found {
+			t.Errorf("IndexKeys() = %v, missing %v", keys, want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const xmlNamespacePrefix = "http://cyclonedx.org/schema/bom/"
+
+// bom holds the parts of a CycloneDX BOM that rekor records
+type bom struct {
+	serialNumber string
+	// componentHashes are formatted as algorithm:value, see formatHash
+	componentHashes []string
+	// signature is the enclosed JSF signature of a JSON BOM, if any
+	signature *jsfSignature
+	// signedData is the canonical form of the BOM the signature is computed over
+	signedData []byte
+}
+
+// parseBOM parses a CycloneDX BOM in either JSON or XML format
+func parseBOM(b []byte) (*bom, error) {
+	t := bytes.TrimSpace(b)
+	if len(t) > 0 && t[0] == '{' {
+		return parseJSONBOM(t)
+	}
+	return parseXMLBOM(t)
+}
+
+// formatHash normalizes CycloneDX hash algorithm names so that SHA-2 digests are indexed the
+// same way as artifact hashes elsewhere in the log, e.g. SHA-256 becomes sha256
+func formatHash(alg, content string) string {
+	alg = strings.ToLower(strings.TrimSpace(alg))
+	if strings.HasPrefix(alg, "sha-") {
+		alg = "sha" + strings.TrimPrefix(alg, "sha-")
+	}
+	return alg + ":" + strings.ToLower(strings.TrimSpace(content))
+}
+
+func newBOM(serialNumber string, hashes []string) *bom {
+	seen := map[string]bool{}
+	b := &bom{serialNumber: serialNumber}
+	for _, h := range hashes {
+		if !seen[h] {
+			seen[h] = true
+			b.componentHashes = append(b.componentHashes, h)
+		}
+	}
+	sort.Strings(b.componentHashes)
+	return b
+}
+
+type jsonHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type jsonComponent struct {
+	Hashes     []jsonHash      `json:"hashes"`
+	Components []jsonComponent `json:"components"`
+}
+
+type jsonBOM struct {
+	BOMFormat    string `json:"bomFormat"`
+	SpecVersion  string `json:"specVersion"`
+	SerialNumber string `json:"serialNumber"`
+	Metadata     struct {
+		Component *jsonComponent `json:"component"`
+	} `json:"metadata"`
+	Components []jsonComponent  `json:"components"`
+	Signature  *json.RawMessage `json:"signature"`
+}
+
+func collectJSONHashes(components []jsonComponent, hashes []string) []string {
+	for _, c := range components {
+		for _, h := range c.Hashes {
+			hashes = append(hashes, formatHash(h.Alg, h.Content))
+		}
+		hashes = collectJSONHashes(c.Components, hashes)
+	}
+	return hashes
+}
+
+func parseJSONBOM(b []byte) (*bom, error) {
+	var d jsonBOM
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("parsing CycloneDX JSON BOM: %w", err)
+	}
+	if d.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("unexpected bomFormat %q", d.BOMFormat)
+	}
+	if d.SpecVersion == "" {
+		return nil, errors.New("CycloneDX BOM does not specify a specVersion")
+	}
+
+	components := d.Components
+	if d.Metadata.Component != nil {
+		components = append(components, *d.Metadata.Component)
+	}
+	result := newBOM(d.SerialNumber, collectJSONHashes(components, nil))
+
+	if d.Signature != nil {
+		var err error
+		if result.signature, result.signedData, err = parseJSFSignature(b); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type xmlComponent struct {
+	Hashes []struct {
+		Alg     string `xml:"alg,attr"`
+		Content string `xml:",chardata"`
+	} `xml:"hashes>hash"`
+	Components []xmlComponent `xml:"components>component"`
+}
+
+type xmlBOM struct {
+	XMLName      xml.Name
+	SerialNumber string `xml:"serialNumber,attr"`
+	Metadata     struct {
+		Component *xmlComponent `xml:"component"`
+	} `xml:"metadata"`
+	Components []xmlComponent `xml:"components>component"`
+	Signature  *struct{}      `xml:"http://www.w3.org/2000/09/xmldsig# Signature"`
+}
+
+func collectXMLHashes(components []xmlComponent, hashes []string) []string {
+	for _, c := range components {
+		for _, h := range c.Hashes {
+			hashes = append(hashes, formatHash(h.Alg, h.Content))
+		}
+		hashes = collectXMLHashes(c.Components, hashes)
+	}
+	return hashes
+}
+
+func parseXMLBOM(b []byte) (*bom, error) {
+	var d xmlBOM
+	if err := xml.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("parsing CycloneDX XML BOM: %w", err)
+	}
+	if d.XMLName.Local != "bom" || !strings.HasPrefix(d.XMLName.Space, xmlNamespacePrefix) {
+		return nil, fmt.Errorf("unexpected root element %q in namespace %q", d.XMLName.Local, d.XMLName.Space)
+	}
+	// an XML signature that was not checked must not look like it was
+	if d.Signature != nil {
+		return nil, errors.New("verifying XML signatures on CycloneDX BOMs is not supported")
+	}
+
+	components := d.Components
+	if d.Metadata.Component != nil {
+		components = append(components, *d.Metadata.Component)
+	}
+	return newBOM(d.SerialNumber, collectXMLHashes(components, nil)), nil
+}
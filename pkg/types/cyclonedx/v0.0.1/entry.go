@@ -0,0 +1,229 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/cyclonedx"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := cyclonedx.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	CycloneDXObj models.CyclonedxV001Schema
+	bom          *bom
+	// keyObj is the key that verified the enclosed signature, if the BOM is signed
+	keyObj *x509.PublicKey
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if h := v.CycloneDXObj.Bom.Hash; h != nil && h.Algorithm != nil && h.Value != nil {
+		result = append(result, *h.Algorithm+":"+*h.Value)
+	}
+	if v.bom != nil {
+		if v.bom.serialNumber != "" {
+			result = append(result, strings.ToLower(v.bom.serialNumber))
+		}
+		result = append(result, v.bom.componentHashes...)
+	}
+	if v.keyObj != nil {
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Cyclonedx)
+	if !ok {
+		return errors.New("cannot unmarshal non CycloneDX v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.CycloneDXObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.CycloneDXObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	content := v.CycloneDXObj.Bom.Content
+	// This also gets called in the CLI, where we won't have this data
+	if len(content) == 0 {
+		return nil
+	}
+
+	b, err := parseBOM(content)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case b.signature != nil:
+		if err := v.verifySignature(b); err != nil {
+			return err
+		}
+	case len(v.CycloneDXObj.PublicKey) > 0:
+		return errors.New("a public key was provided but the BOM is not signed")
+	}
+	v.bom = b
+
+	h := sha256.Sum256(content)
+	v.CycloneDXObj.Bom.Hash = &models.CyclonedxV001SchemaBomHash{
+		Algorithm: swag.String(models.CyclonedxV001SchemaBomHashAlgorithmSha256),
+		Value:     swag.String(hex.EncodeToString(h[:])),
+	}
+	return nil
+}
+
+// verifySignature checks the enclosed signature against the key supplied with the entry, or
+// the key embedded in the signature when none was supplied
+func (v *V001Entry) verifySignature(b *bom) error {
+	keyBytes := []byte(v.CycloneDXObj.PublicKey)
+	if len(keyBytes) == 0 {
+		if b.signature.publicKey == nil {
+			return errors.New("the BOM signature does not embed a public key and none was provided")
+		}
+		var err error
+		if keyBytes, err = cryptoutils.MarshalPublicKeyToPEM(b.signature.publicKey); err != nil {
+			return err
+		}
+	}
+	keyObj, err := x509.NewPublicKey(bytes.NewReader(keyBytes))
+	if err != nil {
+		return err
+	}
+	if err := b.signature.verify(keyObj.CryptoPubKey(), b.signedData); err != nil {
+		return err
+	}
+	v.keyObj = keyObj
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.bom == nil {
+		return nil, errors.New("CycloneDX BOM must be parsed before canonicalizing")
+	}
+
+	canonicalEntry := models.CyclonedxV001Schema{
+		Bom: &models.CyclonedxV001SchemaBom{
+			Hash: v.CycloneDXObj.Bom.Hash,
+		},
+		SerialNumber:    v.bom.serialNumber,
+		ComponentHashes: v.bom.componentHashes,
+		ExtraData:       v.CycloneDXObj.ExtraData,
+	}
+	if v.keyObj != nil {
+		pk, err := v.keyObj.CanonicalValue()
+		if err != nil {
+			return nil, err
+		}
+		canonicalEntry.PublicKey = strfmt.Base64(pk)
+	}
+
+	cdxObj := models.Cyclonedx{}
+	cdxObj.APIVersion = swag.String(APIVERSION)
+	cdxObj.Spec = &canonicalEntry
+
+	return json.Marshal(&cdxObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Cyclonedx{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to artifact file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("CycloneDX BOMs cannot be fetched over HTTP(S)")
+		}
+		artifactBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading artifact file: %w", err)
+		}
+	}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil && props.PublicKeyPath != nil {
+		publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+	}
+
+	re := V001Entry{
+		CycloneDXObj: models.CyclonedxV001Schema{
+			Bom: &models.CyclonedxV001SchemaBom{
+				Content: strfmt.Base64(artifactBytes),
+			},
+			PublicKey: strfmt.Base64(publicKeyBytes),
+		},
+	}
+
+	returnVal.Spec = re.CycloneDXObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
@@ -0,0 +1,349 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const jsonBOMDocument = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "component": {
+      "type": "application",
+      "name": "example",
+      "hashes": [{"alg": "SHA-256", "content": "AB3D0F5A3C2B1E5B0E2A1F99A2C3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8B9C0D1"}]
+    }
+  },
+  "components": [
+    {
+      "type": "library",
+      "name": "dependency",
+      "hashes": [{"alg": "SHA-1", "content": "85ed0817af83a24ad8da68c2b5094de69833983c"}],
+      "components": [
+        {"type": "library", "name": "nested", "hashes": [{"alg": "SHA-512", "content": "00ff"}]}
+      ]
+    }
+  ]
+}`
+
+const xmlBOMDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" serialNumber="urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" version="1">
+  <components>
+    <component type="library">
+      <name>dependency</name>
+      <hashes>
+        <hash alg="SHA-1">85ed0817af83a24ad8da68c2b5094de69833983c</hash>
+      </hashes>
+    </component>
+  </components>
+</bom>`
+
+func TestParseBOM(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		hashes  []string
+		wantErr bool
+	}{
+		{
+			name: "json",
+			doc:  jsonBOMDocument,
+			hashes: []string{
+				"sha1:85ed0817af83a24ad8da68c2b5094de69833983c",
+				"sha256:ab3d0f5a3c2b1e5b0e2a1f99a2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1",
+				"sha512:00ff",
+			},
+		},
+		{
+			name:   "xml",
+			doc:    xmlBOMDocument,
+			hashes: []string{"sha1:85ed0817af83a24ad8da68c2b5094de69833983c"},
+		},
+		{
+			name:    "wrong bomFormat",
+			doc:     `{"bomFormat": "SPDX", "specVersion": "1.4"}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong namespace",
+			doc:     `<bom xmlns="http://example.com/bom"></bom>`,
+			wantErr: true,
+		},
+		{
+			name:    "xml signature",
+			doc:     `<bom xmlns="http://cyclonedx.org/schema/bom/1.4"><Signature xmlns="http://www.w3.org/2000/09/xmldsig#"/></bom>`,
+			wantErr: true,
+		},
+		{
+			name:    "not a bom",
+			doc:     "hello",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := parseBOM([]byte(tt.doc))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBOM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if b.serialNumber != "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" {
+				t.Errorf("parseBOM() serialNumber = %v", b.serialNumber)
+			}
+			if !reflect.DeepEqual(b.componentHashes, tt.hashes) {
+				t.Errorf("parseBOM() componentHashes = %v, want %v", b.componentHashes, tt.hashes)
+			}
+		})
+	}
+}
+
+func TestFormatES6Number(t *testing.T) {
+	tests := map[float64]string{
+		0:                      "0",
+		1:                      "1",
+		-1.5:                   "-1.5",
+		1e21:                   "1e+21",
+		1e20:                   "100000000000000000000",
+		0.000001:               "0.000001",
+		1e-7:                   "1e-7",
+		123.456:                "123.456",
+		math.MaxFloat64:        "1.7976931348623157e+308",
+		4.5e-324:               "5e-324",
+		333333333.33333329:     "333333333.3333333",
+		1.0000000000000002e-08: "1.0000000000000002e-8",
+	}
+	for f, want := range tests {
+		if got := formatES6Number(f); got != want {
+			t.Errorf("formatES6Number(%v) = %v, want %v", f, got, want)
+		}
+	}
+}
+
+func TestWriteCanonicalJSON(t *testing.T) {
+	in := `{"b": [1.0, "\u000f\u20ac\n"], "a": {"\u20ac": true, "\r": null}, "\ud83d\ude00": 1, "\ufb33": 2}`
+	d := json.NewDecoder(strings.NewReader(in))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := writeCanonicalJSON(&out, v); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"a\":{\"\\r\":null,\"\u20ac\":true},\"b\":[1,\"\\u000f\u20ac\\n\"],\"\U0001F600\":1,\"\ufb33\":2}"
+	if out.String() != want {
+		t.Errorf("writeCanonicalJSON() = %s, want %s", out.String(), want)
+	}
+}
+
+// signBOM adds an ES256 JSF signature to doc, embedding the public key when embed is set
+func signBOM(t *testing.T, doc string, key *ecdsa.PrivateKey, embed bool) []byte {
+	d := json.NewDecoder(strings.NewReader(doc))
+	d.UseNumber()
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	sig := map[string]interface{}{"algorithm": "ES256"}
+	if embed {
+		sig["publicKey"] = map[string]interface{}{
+			"kty": "EC",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		}
+	}
+	m["signature"] = sig
+
+	var data bytes.Buffer
+	if err := writeCanonicalJSON(&data, m); err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(data.Bytes())
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := make([]byte, 64)
+	r.FillBytes(value[:32])
+	s.FillBytes(value[32:])
+	sig["value"] = base64.RawURLEncoding.EncodeToString(value)
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	embedded := signBOM(t, jsonBOMDocument, key, true)
+	detached := signBOM(t, jsonBOMDocument, key, false)
+	tampered := bytes.Replace(embedded, []byte("dependency"), []byte("dependencY"), 1)
+
+	tests := []struct {
+		name    string
+		obj     models.CyclonedxV001Schema
+		signed  bool
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			obj:     models.CyclonedxV001Schema{},
+			wantErr: true,
+		},
+		{
+			name: "unsigned json",
+			obj: models.CyclonedxV001Schema{
+				Bom: &models.CyclonedxV001SchemaBom{Content: []byte(jsonBOMDocument)},
+			},
+		},
+		{
+			name: "unsigned xml",
+			obj: models.CyclonedxV001Schema{
+				Bom: &models.CyclonedxV001SchemaBom{Content: []byte(xmlBOMDocument)},
+			},
+		},
+		{
+			name: "public key for unsigned bom",
+			obj: models.CyclonedxV001Schema{
+				Bom:       &models.CyclonedxV001SchemaBom{Content: []byte(jsonBOMDocument)},
+				PublicKey: pub,
+			},
+			wantErr: true,
+		},
+		{
+			name: "embedded public key",
+			obj: models.CyclonedxV001Schema{
+				Bom: &models.CyclonedxV001SchemaBom{Content: embedded},
+			},
+			signed: true,
+		},
+		{
+			name: "supplied public key",
+			obj: models.CyclonedxV001Schema{
+				Bom:       &models.CyclonedxV001SchemaBom{Content: detached},
+				PublicKey: pub,
+			},
+			signed: true,
+		},
+		{
+			name: "missing public key",
+			obj: models.CyclonedxV001Schema{
+				Bom: &models.CyclonedxV001SchemaBom{Content: detached},
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong public key",
+			obj: models.CyclonedxV001Schema{
+				Bom:       &models.CyclonedxV001SchemaBom{Content: signBOM(t, jsonBOMDocument, other, false)},
+				PublicKey: pub,
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered",
+			obj: models.CyclonedxV001Schema{
+				Bom: &models.CyclonedxV001SchemaBom{Content: tampered},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			it := &models.Cyclonedx{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       &tt.obj,
+			}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+			var canonical struct {
+				Spec models.CyclonedxV001Schema `json:"spec"`
+			}
+			if err := json.Unmarshal(b, &canonical); err != nil {
+				t.Fatal(err)
+			}
+			if (len(canonical.Spec.PublicKey) > 0) != tt.signed {
+				t.Errorf("canonical entry public key = %q, signed %v", canonical.Spec.PublicKey, tt.signed)
+			}
+			keys := strings.Join(v.IndexKeys(), " ")
+			if !strings.Contains(keys, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79") || !strings.Contains(keys, "sha1:85ed0817af83a24ad8da68c2b5094de69833983c") {
+				t.Errorf("V001Entry.IndexKeys() = %v", keys)
+			}
+		})
+	}
+}
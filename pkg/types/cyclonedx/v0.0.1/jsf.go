@@ -0,0 +1,349 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// CycloneDX JSON BOMs carry enclosed signatures in the JSON Signature Format (JSF): the signed
+// data is the BOM canonicalized per RFC 8785 with the signature's value property removed.
+
+// jsfSignature is a single JSF signature; multiple signatures and certificate chains are not
+// supported
+type jsfSignature struct {
+	algorithm string
+	publicKey crypto.PublicKey
+	value     []byte
+}
+
+func parseJSFSignature(b []byte) (*jsfSignature, []byte, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var doc map[string]interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+	s, ok := doc["signature"].(map[string]interface{})
+	if !ok {
+		return nil, nil, errors.New("BOM signature must be an object")
+	}
+	for _, k := range []string{"signers", "chain"} {
+		if _, ok := s[k]; ok {
+			return nil, nil, fmt.Errorf("JSF %s are not supported", k)
+		}
+	}
+
+	sig := &jsfSignature{}
+	if sig.algorithm, ok = s["algorithm"].(string); !ok {
+		return nil, nil, errors.New("BOM signature does not specify an algorithm")
+	}
+	value, ok := s["value"].(string)
+	if !ok {
+		return nil, nil, errors.New("BOM signature does not contain a value")
+	}
+	var err error
+	if sig.value, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "=")); err != nil {
+		return nil, nil, fmt.Errorf("decoding BOM signature value: %w", err)
+	}
+	if k, ok := s["publicKey"]; ok {
+		jwk, ok := k.(map[string]interface{})
+		if !ok {
+			return nil, nil, errors.New("BOM signature publicKey must be a JWK object")
+		}
+		if sig.publicKey, err = parseJWK(jwk); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	delete(s, "value")
+	var signed bytes.Buffer
+	if err := writeCanonicalJSON(&signed, doc); err != nil {
+		return nil, nil, err
+	}
+	return sig, signed.Bytes(), nil
+}
+
+func (s *jsfSignature) verify(pub crypto.PublicKey, data []byte) error {
+	var h crypto.Hash
+	switch s.algorithm {
+	case "ES256", "RS256", "PS256":
+		h = crypto.SHA256
+	case "ES384", "RS384", "PS384":
+		h = crypto.SHA384
+	case "ES512", "RS512", "PS512":
+		h = crypto.SHA512
+	case "Ed25519":
+		k, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return errors.New("Ed25519 signatures require an Ed25519 public key")
+		}
+		if !ed25519.Verify(k, data, s.value) {
+			return errors.New("BOM signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported JSF signature algorithm %q", s.algorithm)
+	}
+
+	hasher := h.New()
+	hasher.Write(data)
+	digest := hasher.Sum(nil)
+
+	switch s.algorithm[0] {
+	case 'E':
+		k, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s signatures require an ECDSA public key", s.algorithm)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(s.value) != 2*size {
+			return fmt.Errorf("invalid %s signature length", s.algorithm)
+		}
+		r := new(big.Int).SetBytes(s.value[:size])
+		ss := new(big.Int).SetBytes(s.value[size:])
+		if !ecdsa.Verify(k, digest, r, ss) {
+			return errors.New("BOM signature verification failed")
+		}
+	case 'R', 'P':
+		k, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s signatures require an RSA public key", s.algorithm)
+		}
+		var err error
+		if s.algorithm[0] == 'R' {
+			err = rsa.VerifyPKCS1v15(k, h, digest, s.value)
+		} else {
+			err = rsa.VerifyPSS(k, h, digest, s.value, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		if err != nil {
+			return fmt.Errorf("BOM signature verification failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func jwkParam(jwk map[string]interface{}, name string) ([]byte, error) {
+	s, ok := jwk[name].(string)
+	if !ok {
+		return nil, fmt.Errorf("JWK is missing the %q parameter", name)
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// parseJWK converts the EC, RSA and OKP (Ed25519) JSON Web Keys allowed by JSF
+func parseJWK(jwk map[string]interface{}) (crypto.PublicKey, error) {
+	kty, _ := jwk["kty"].(string)
+	crv, _ := jwk["crv"].(string)
+	switch kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported JWK curve %q", crv)
+		}
+		x, err := jwkParam(jwk, "x")
+		if err != nil {
+			return nil, err
+		}
+		y, err := jwkParam(jwk, "y")
+		if err != nil {
+			return nil, err
+		}
+		k := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(k.X, k.Y) {
+			return nil, errors.New("JWK point is not on the curve")
+		}
+		return k, nil
+	case "RSA":
+		n, err := jwkParam(jwk, "n")
+		if err != nil {
+			return nil, err
+		}
+		e, err := jwkParam(jwk, "e")
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 || exp.Int64() < 3 {
+			return nil, errors.New("invalid JWK RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "OKP":
+		if crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported JWK curve %q", crv)
+		}
+		x, err := jwkParam(jwk, "x")
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 JWK length")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported JWK key type %q", kty)
+}
+
+// writeCanonicalJSON serializes a value decoded with json.Decoder.UseNumber per RFC 8785
+func writeCanonicalJSON(w *bytes.Buffer, v interface{}) error {
+	switch t := v.(type) {
+	case nil:
+		w.WriteString("null")
+	case bool:
+		w.WriteString(strconv.FormatBool(t))
+	case string:
+		writeCanonicalString(w, t)
+	case json.Number:
+		f, err := strconv.ParseFloat(string(t), 64)
+		if err != nil {
+			return err
+		}
+		w.WriteString(formatES6Number(f))
+	case []interface{}:
+		w.WriteByte('[')
+		for i, e := range t {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(w, e); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		// properties are sorted by their UTF-16 code units
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := utf16.Encode([]rune(keys[i])), utf16.Encode([]rune(keys[j]))
+			for n := 0; n < len(a) && n < len(b); n++ {
+				if a[n] != b[n] {
+					return a[n] < b[n]
+				}
+			}
+			return len(a) < len(b)
+		})
+		w.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			writeCanonicalString(w, k)
+			w.WriteByte(':')
+			if err := writeCanonicalJSON(w, t[k]); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", v)
+	}
+	return nil
+}
+
+func writeCanonicalString(w *bytes.Buffer, s string) {
+	w.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			w.WriteString(`\"`)
+		case '\\':
+			w.WriteString(`\\`)
+		case '\b':
+			w.WriteString(`\b`)
+		case '\f':
+			w.WriteString(`\f`)
+		case '\n':
+			w.WriteString(`\n`)
+		case '\r':
+			w.WriteString(`\r`)
+		case '\t':
+			w.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(w, `\u%04x`, r)
+			} else {
+				w.WriteRune(r)
+			}
+		}
+	}
+	w.WriteByte('"')
+}
+
+// formatES6Number formats f the way ECMAScript's Number.prototype.toString does
+func formatES6Number(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+	// shortest round-trip digits and exponent
+	e := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp := e[:strings.IndexByte(e, 'e')], e[strings.IndexByte(e, 'e')+1:]
+	digits := strings.Replace(mantissa, ".", "", 1)
+	x, _ := strconv.Atoi(exp)
+	n, k := x+1, len(digits)
+
+	switch {
+	case k <= n && n <= 21:
+		return sign + digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return sign + digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits
+	}
+	es := "+"
+	if n-1 < 0 {
+		es = "-"
+	}
+	m := digits[:1]
+	if k > 1 {
+		m += "." + digits[1:]
+	}
+	return sign + m + "e" + es + strconv.Itoa(abs(n-1))
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
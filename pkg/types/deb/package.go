@@ -0,0 +1,290 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/ulikunitz/xz"
+)
+
+const arMagic = "!<arch>\n"
+
+// controlFields are the control fields recorded for packages and .changes files
+var controlFields = []string{"Package", "Source", "Version", "Architecture"}
+
+type Package struct {
+	Control       map[string]string // selected control fields, see controlFields
+	FileChecksums []string          // formatted as algorithm:value
+	Signature     []byte            // the _gpgorigin detached signature, if present
+	SignedData    []byte            // the data covered by Signature
+}
+
+// IsDeb reports whether b starts like a Debian binary package (an ar archive)
+func IsDeb(b []byte) bool {
+	return bytes.HasPrefix(b, []byte(arMagic))
+}
+
+type arMember struct {
+	name string
+	data []byte
+}
+
+func readAr(r io.Reader) ([]arMember, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != arMagic {
+		return nil, errors.New("not a Debian package: missing ar archive header")
+	}
+
+	var members []arMember
+	hdr := make([]byte, 60)
+	for {
+		if _, err := io.ReadFull(br, hdr); err == io.EOF {
+			return members, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "reading ar member header")
+		}
+		if string(hdr[58:60]) != "`\n" {
+			return nil, errors.New("invalid ar member header")
+		}
+		size, err := strconv.ParseInt(strings.TrimSpace(string(hdr[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			return nil, errors.New("invalid ar member size")
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(br, size))
+		if err != nil {
+			return nil, errors.Wrap(err, "reading ar member")
+		}
+		if int64(len(data)) != size {
+			return nil, errors.New("truncated ar member")
+		}
+		// members are aligned to an even offset
+		if size%2 == 1 {
+			if _, err := br.Discard(1); err != nil && err != io.EOF {
+				return nil, errors.Wrap(err, "reading ar padding")
+			}
+		}
+		members = append(members, arMember{
+			name: strings.TrimSuffix(strings.TrimSpace(string(hdr[0:16])), "/"),
+			data: data,
+		})
+	}
+}
+
+func decompress(name string, data []byte) (io.Reader, error) {
+	switch {
+	case strings.HasSuffix(name, ".gz"):
+		return gzip.NewReader(bytes.NewReader(data))
+	case strings.HasSuffix(name, ".xz"):
+		return xz.NewReader(bytes.NewReader(data))
+	case strings.HasSuffix(name, ".tar"):
+		return bytes.NewReader(data), nil
+	}
+	return nil, fmt.Errorf("unsupported compression for %s", name)
+}
+
+// Unmarshal reads a Debian binary package, extracting its control fields and the checksums
+// from md5sums; the debsigs origin signature is kept along with the data it covers
+func (p *Package) Unmarshal(r io.Reader) error {
+	members, err := readAr(r)
+	if err != nil {
+		return err
+	}
+	if len(members) < 3 || members[0].name != "debian-binary" {
+		return errors.New("not a Debian package: debian-binary must be the first member")
+	}
+	if !strings.HasPrefix(string(members[0].data), "2.") {
+		return fmt.Errorf("unsupported Debian package format %q", strings.TrimSpace(string(members[0].data)))
+	}
+
+	pkg := Package{}
+	var control, data *arMember
+	for i := range members {
+		m := &members[i]
+		switch {
+		case strings.HasPrefix(m.name, "control.tar"):
+			control = m
+		case strings.HasPrefix(m.name, "data.tar"):
+			data = m
+		case m.name == "_gpgorigin":
+			pkg.Signature = m.data
+		}
+	}
+	if control == nil || data == nil {
+		return errors.New("Debian package is missing control or data archive")
+	}
+
+	tr, err := decompress(control.name, control.data)
+	if err != nil {
+		return err
+	}
+	ctl := tar.NewReader(tr)
+	foundControl := false
+	for {
+		header, err := ctl.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "getting next entry in tar archive")
+		}
+		switch strings.TrimPrefix(header.Name, "./") {
+		case "control":
+			fields, err := ParseControl(ctl)
+			if err != nil {
+				return errors.Wrap(err, "parsing control file")
+			}
+			pkg.Control = selectFields(fields)
+			foundControl = true
+		case "md5sums":
+			if pkg.FileChecksums, err = parseMD5Sums(ctl); err != nil {
+				return errors.Wrap(err, "parsing md5sums")
+			}
+			sort.Strings(pkg.FileChecksums)
+		}
+	}
+	if !foundControl {
+		return errors.New("control file was not located")
+	}
+	for _, f := range []string{"Package", "Version", "Architecture"} {
+		if pkg.Control[f] == "" {
+			return fmt.Errorf("control file is missing the %s field", f)
+		}
+	}
+
+	// debsigs signs the concatenation of the package's first three members
+	if pkg.Signature != nil {
+		for _, m := range []*arMember{&members[0], control, data} {
+			pkg.SignedData = append(pkg.SignedData, m.data...)
+		}
+	}
+
+	*p = pkg
+	return nil
+}
+
+func selectFields(fields map[string]string) map[string]string {
+	selected := map[string]string{}
+	for _, f := range controlFields {
+		if v, ok := fields[f]; ok {
+			selected[f] = v
+		}
+	}
+	return selected
+}
+
+func parseMD5Sums(r io.Reader) ([]string, error) {
+	var sums []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		f := strings.Fields(scanner.Text())
+		if len(f) == 0 {
+			continue
+		}
+		if len(f[0]) != 32 {
+			return nil, fmt.Errorf("invalid md5sums line %q", scanner.Text())
+		}
+		sums = append(sums, "md5:"+strings.ToLower(f[0]))
+	}
+	return sums, scanner.Err()
+}
+
+// ParseControl parses a Debian control file (deb822) paragraph. Continuation lines of
+// multi-line fields are joined with newlines.
+func ParseControl(r io.Reader) (map[string]string, error) {
+	fields := map[string]string{}
+	var last string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			if len(fields) > 0 {
+				// only the first paragraph is used
+				return fields, nil
+			}
+		case strings.HasPrefix(line, "#"):
+		case line[0] == ' ' || line[0] == '\t':
+			if last == "" {
+				return nil, fmt.Errorf("continuation line without a field: %q", line)
+			}
+			fields[last] += "\n" + strings.TrimSpace(line)
+		default:
+			i := strings.Index(line, ":")
+			if i <= 0 {
+				return nil, fmt.Errorf("invalid control line %q", line)
+			}
+			last = line[:i]
+			fields[last] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("empty control file")
+	}
+	return fields, nil
+}
+
+// Changes is the content of a .changes file, which is verified with a detached signature
+type Changes struct {
+	Control       map[string]string
+	FileChecksums []string
+}
+
+// ParseChanges parses an unsigned .changes file
+func ParseChanges(b []byte) (*Changes, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("-----BEGIN PGP SIGNED MESSAGE-----")) {
+		return nil, errors.New("clearsigned .changes files are not supported; submit the unsigned file with a detached signature")
+	}
+	fields, err := ParseControl(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range []string{"Source", "Version", "Architecture", "Files"} {
+		if fields[f] == "" {
+			return nil, fmt.Errorf(".changes file is missing the %s field", f)
+		}
+	}
+
+	c := &Changes{Control: selectFields(fields)}
+	// prefer SHA-256 checksums, which are searchable like any other artifact hash
+	alg, list := "sha256", fields["Checksums-Sha256"]
+	if list == "" {
+		alg, list = "md5", fields["Files"]
+	}
+	for _, line := range strings.Split(list, "\n") {
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		c.FileChecksums = append(c.FileChecksums, alg+":"+strings.ToLower(f[0]))
+	}
+	sort.Strings(c.FileChecksums)
+	return c, nil
+}
@@ -0,0 +1,95 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+)
+
+func TestDebianPackage(t *testing.T) {
+	inputArchive, err := os.Open("../../../tests/test.deb")
+	if err != nil {
+		t.Fatalf("could not open archive %v", err)
+	}
+	defer inputArchive.Close()
+
+	p := Package{}
+	if err := p.Unmarshal(inputArchive); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	expected := map[string]string{
+		"Package":      "rekor-hello",
+		"Version":      "1.0.0-1",
+		"Architecture": "all",
+	}
+	if !reflect.DeepEqual(p.Control, expected) {
+		t.Errorf("unexpected control fields: %v", p.Control)
+	}
+	if len(p.FileChecksums) != 1 {
+		t.Errorf("unexpected file checksums: %v", p.FileChecksums)
+	}
+
+	pubKey, err := os.Open("../../../tests/test_deb.pub")
+	if err != nil {
+		t.Fatalf("could not open public key %v", err)
+	}
+	defer pubKey.Close()
+
+	pub, err := pgp.NewPublicKey(pubKey)
+	if err != nil {
+		t.Fatalf("failed to parse public key: %v", err)
+	}
+	sig, err := pgp.NewSignature(bytes.NewReader(p.Signature))
+	if err != nil {
+		t.Fatalf("failed to parse signature: %v", err)
+	}
+	if err := sig.Verify(bytes.NewReader(p.SignedData), pub); err != nil {
+		t.Fatalf("signature verification failed: %v", err)
+	}
+}
+
+func TestParseChanges(t *testing.T) {
+	b, err := ioutil.ReadFile("../../../tests/test.changes")
+	if err != nil {
+		t.Fatalf("could not read .changes file %v", err)
+	}
+
+	c, err := ParseChanges(b)
+	if err != nil {
+		t.Fatalf("unexpected error parsing .changes file: %v", err)
+	}
+	if c.Control["Source"] != "rekor-hello" || c.Control["Version"] != "1.0.0-1" {
+		t.Errorf("unexpected control fields: %v", c.Control)
+	}
+	expected := []string{"sha256:f23b7bb1db58ac9970fc0534ef4d25e0c0befb1d214f0f80bdd113ebe6f2df21"}
+	if !reflect.DeepEqual(c.FileChecksums, expected) {
+		t.Errorf("unexpected file checksums: %v", c.FileChecksums)
+	}
+
+	if _, err := ParseChanges(append([]byte("-----BEGIN PGP SIGNED MESSAGE-----\nHash: SHA256\n\n"), b...)); err == nil {
+		t.Error("expected clearsigned .changes file to be rejected")
+	}
+	if _, err := ParseChanges([]byte("Source: rekor-hello\n")); err == nil {
+		t.Error("expected incomplete .changes file to be rejected")
+	}
+}
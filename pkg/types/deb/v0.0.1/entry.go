@@ -0,0 +1,426 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/deb"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := deb.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	DebModel                models.DebV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	control                 map[string]string
+	fileChecksums           []string
+	// sigObj is only set for .changes files, whose signature is detached
+	sigObj pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.hasExternalEntities() {
+		if err := v.fetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	keyObj := v.keyObj
+	if keyObj == nil && v.DebModel.PublicKey != nil {
+		// entries read back from the log carry the public key, but have not had their external entities fetched
+		var err error
+		if keyObj, err = pki.ParsePublicKey(pki.PGP, v.DebModel.PublicKey.Content); err != nil {
+			log.Logger.Error(err)
+		}
+	}
+	if keyObj != nil {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
+	}
+
+	if v.DebModel.Package.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.DebModel.Package.Hash.Algorithm, *v.DebModel.Package.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	result = append(result, v.fileChecksums...)
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	d, ok := pe.(*models.Deb)
+	if !ok {
+		return errors.New("cannot unmarshal non Debian v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(d.Spec, &v.DebModel); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.DebModel.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+func (v V001Entry) hasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.DebModel.Package != nil && v.DebModel.Package.URL.String() != "" {
+		return true
+	}
+	if v.DebModel.PublicKey != nil && v.DebModel.PublicKey.URL.String() != "" {
+		return true
+	}
+	if v.DebModel.Signature != nil && v.DebModel.Signature.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func readAll(ctx context.Context, url string, content []byte) ([]byte, error) {
+	rc, err := util.FileOrURLReadCloser(ctx, url, content)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	artifactFactory, err := pki.NewArtifactFactory(pki.PGP)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	var pkgBytes, sigBytes []byte
+	g.Go(func() error {
+		var err error
+		pkgBytes, err = readAll(ctx, v.DebModel.Package.URL.String(), v.DebModel.Package.Content)
+		return err
+	})
+	if v.DebModel.Signature != nil {
+		g.Go(func() error {
+			var err error
+			sigBytes, err = readAll(ctx, v.DebModel.Signature.URL.String(), v.DebModel.Signature.Content)
+			return err
+		})
+	}
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.DebModel.PublicKey.URL.String(),
+			v.DebModel.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		v.keyObj, err = artifactFactory.NewPublicKey(keyReadCloser)
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	h := sha256.Sum256(pkgBytes)
+	computedSHA := hex.EncodeToString(h[:])
+	if v.DebModel.Package.Hash != nil && v.DebModel.Package.Hash.Value != nil {
+		if oldSHA := swag.StringValue(v.DebModel.Package.Hash.Value); computedSHA != oldSHA {
+			return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
+		}
+	}
+
+	// .deb packages embed their signature, .changes files are signed externally
+	var signed []byte
+	if deb.IsDeb(pkgBytes) {
+		if sigBytes != nil {
+			return types.ValidationError(errors.New("a detached signature cannot be supplied for a .deb package"))
+		}
+		p := deb.Package{}
+		if err := p.Unmarshal(bytes.NewReader(pkgBytes)); err != nil {
+			return types.ValidationError(err)
+		}
+		if p.Signature == nil {
+			return types.ValidationError(errors.New("Debian package does not contain a _gpgorigin signature"))
+		}
+		v.control, v.fileChecksums = p.Control, p.FileChecksums
+		signed, sigBytes = p.SignedData, p.Signature
+	} else {
+		if sigBytes == nil {
+			return types.ValidationError(errors.New("a detached signature must be supplied for a .changes file"))
+		}
+		c, err := deb.ParseChanges(pkgBytes)
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		v.control, v.fileChecksums = c.Control, c.FileChecksums
+		signed = pkgBytes
+	}
+
+	sigObj, err := artifactFactory.NewSignature(bytes.NewReader(sigBytes))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	if err := sigObj.Verify(bytes.NewReader(signed), v.keyObj); err != nil {
+		return types.ValidationError(err)
+	}
+	if v.DebModel.Signature != nil {
+		v.sigObj = sigObj
+	}
+
+	// if we get here, all goroutines succeeded without error
+	if v.DebModel.Package.Hash == nil {
+		v.DebModel.Package.Hash = &models.DebV001SchemaPackageHash{}
+		v.DebModel.Package.Hash.Algorithm = swag.String(models.DebV001SchemaPackageHashAlgorithmSha256)
+		v.DebModel.Package.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.DebV001Schema{}
+
+	var err error
+	// need to canonicalize key content
+	canonicalEntry.PublicKey = &models.DebV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Package = &models.DebV001SchemaPackage{}
+	canonicalEntry.Package.Hash = &models.DebV001SchemaPackageHash{}
+	canonicalEntry.Package.Hash.Algorithm = v.DebModel.Package.Hash.Algorithm
+	canonicalEntry.Package.Hash.Value = v.DebModel.Package.Hash.Value
+	// data content is not set deliberately
+
+	canonicalEntry.Package.Control = v.control
+	canonicalEntry.Package.FileChecksums = v.fileChecksums
+
+	if v.sigObj != nil {
+		canonicalEntry.Signature = &models.DebV001SchemaSignature{}
+		canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.DebModel.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	d := models.Deb{}
+	d.APIVersion = swag.String(APIVERSION)
+	d.Spec = &canonicalEntry
+
+	return json.Marshal(&d)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	key := v.DebModel.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	pkg := v.DebModel.Package
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+
+	hash := pkg.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if len(pkg.Content) == 0 && pkg.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for package")
+	}
+
+	sig := v.DebModel.Signature
+	if sig != nil && len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	return nil
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func readProperty(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Deb{}
+	re := V001Entry{}
+
+	// we will need artifact, public-key and, for .changes files, a signature
+	re.DebModel = models.DebV001Schema{}
+	re.DebModel.Package = &models.DebV001SchemaPackage{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to Debian package or .changes file (file or URL) must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			re.DebModel.Package.URL = strfmt.URI(props.ArtifactPath.String())
+			if props.ArtifactHash != "" {
+				re.DebModel.Package.Hash = &models.DebV001SchemaPackageHash{
+					Algorithm: swag.String(models.DebV001SchemaPackageHashAlgorithmSha256),
+					Value:     swag.String(props.ArtifactHash),
+				}
+			}
+		} else {
+			if artifactBytes, err = readProperty(props.ArtifactPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.DebModel.Package.Content = strfmt.Base64(artifactBytes)
+		}
+	} else {
+		re.DebModel.Package.Content = strfmt.Base64(artifactBytes)
+	}
+
+	re.DebModel.PublicKey = &models.DebV001SchemaPublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify the signature")
+		}
+		if props.PublicKeyPath.IsAbs() {
+			re.DebModel.PublicKey.URL = strfmt.URI(props.PublicKeyPath.String())
+		} else {
+			if publicKeyBytes, err = readProperty(props.PublicKeyPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.DebModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+		}
+	} else {
+		re.DebModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+	}
+
+	switch {
+	case props.SignatureBytes != nil:
+		re.DebModel.Signature = &models.DebV001SchemaSignature{Content: strfmt.Base64(props.SignatureBytes)}
+	case props.SignaturePath != nil && props.SignaturePath.IsAbs():
+		re.DebModel.Signature = &models.DebV001SchemaSignature{URL: strfmt.URI(props.SignaturePath.String())}
+	case props.SignaturePath != nil:
+		sigBytes, err := readProperty(props.SignaturePath.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+		re.DebModel.Signature = &models.DebV001SchemaSignature{Content: strfmt.Base64(sigBytes)}
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if re.hasExternalEntities() {
+		if err := re.fetchExternalEntities(ctx); err != nil {
+			return nil, fmt.Errorf("error retrieving external entities: %v", err)
+		}
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.DebModel
+
+	return &returnVal, nil
+}
@@ -38,6 +38,17 @@ type EntryImpl interface {
 	CreateFromArtifactProperties(context.Context, ArtifactProperties) (models.ProposedEntry, error)
 }
 
+// EnvelopeEntry is implemented by types whose entries are signed DSSE envelopes, so that the
+// envelope can be stored and retrieved by its hash
+type EnvelopeEntry interface {
+	Envelope() []byte // the envelope as submitted; its SHA256 digest is the hash recorded in the log
+}
+
+// PKIFormatEntry is implemented by types whose entries may be signed in one of several PKI formats
+type PKIFormatEntry interface {
+	PKIFormat() string // the PKI format of the signature and public key, once the entry has been unmarshalled
+}
+
 // EntryFactory describes a factory function that can generate structs for a specific versioned type
 type EntryFactory func() EntryImpl
 
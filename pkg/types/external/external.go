@@ -0,0 +1,241 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// handlers maps kinds to the handler that serves them
+var handlers sync.Map
+
+// Register makes the type served by the handler available to the server
+func Register(h *Handler) error {
+	kind := h.Kind()
+	if _, found := types.TypeMap.Load(kind); found {
+		return fmt.Errorf("type %q is already implemented", kind)
+	}
+	if err := models.RegisterExternalKind(kind, func() models.ProposedEntry {
+		return &ProposedEntry{kind: kind}
+	}); err != nil {
+		return err
+	}
+	handlers.Store(kind, h)
+	types.TypeMap.Store(kind, func() types.TypeImpl {
+		return &externalType{handler: h}
+	})
+	return nil
+}
+
+// ProposedEntry is the model for entries of externally handled kinds; the spec is passed through
+// to the type handler as is
+type ProposedEntry struct {
+	kind       string
+	APIVersion *string
+	Spec       json.RawMessage
+}
+
+type proposedEntryJSON struct {
+	Kind       string          `json:"kind"`
+	APIVersion *string         `json:"apiVersion"`
+	Spec       json.RawMessage `json:"spec"`
+}
+
+// Kind implements the models.ProposedEntry interface
+func (m *ProposedEntry) Kind() string {
+	return m.kind
+}
+
+// SetKind implements the models.ProposedEntry interface
+func (m *ProposedEntry) SetKind(val string) {
+	m.kind = val
+}
+
+// UnmarshalJSON unmarshals the entry, keeping the spec as raw JSON
+func (m *ProposedEntry) UnmarshalJSON(raw []byte) error {
+	var data proposedEntryJSON
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return err
+	}
+	m.kind = data.Kind
+	m.APIVersion = data.APIVersion
+	m.Spec = data.Spec
+	return nil
+}
+
+// MarshalJSON marshals the entry with the kind property set
+func (m ProposedEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(proposedEntryJSON{
+		Kind:       m.kind,
+		APIVersion: m.APIVersion,
+		Spec:       m.Spec,
+	})
+}
+
+// Validate checks the properties common to all entries; the spec is validated by the type handler
+func (m *ProposedEntry) Validate(formats strfmt.Registry) error {
+	if m.APIVersion == nil || *m.APIVersion == "" {
+		return errors.New("apiVersion in body is required")
+	}
+	if len(m.Spec) == 0 || string(m.Spec) == "null" {
+		return errors.New("spec in body is required")
+	}
+	return nil
+}
+
+// ContextValidate implements the models.ProposedEntry interface
+func (m *ProposedEntry) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// externalType implements types.TypeImpl by delegating to a type handler
+type externalType struct {
+	handler *Handler
+}
+
+func (t *externalType) CreateProposedEntry(ctx context.Context, version string, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	return nil, fmt.Errorf("entries of type %q must be created outside of rekor", t.handler.Kind())
+}
+
+func (t *externalType) DefaultVersion() string {
+	return t.handler.description.Versions[0]
+}
+
+func (t *externalType) SupportedVersions() []string {
+	return t.handler.description.Versions
+}
+
+func (t *externalType) UnmarshalEntry(pe models.ProposedEntry) (types.EntryImpl, error) {
+	in, ok := pe.(*ProposedEntry)
+	if !ok || in.Kind() != t.handler.Kind() {
+		return nil, fmt.Errorf("cannot unmarshal non %s types", t.handler.Kind())
+	}
+	if err := in.Validate(strfmt.Default); err != nil {
+		return nil, err
+	}
+	supported := false
+	for _, v := range t.SupportedVersions() {
+		if v == *in.APIVersion {
+			supported = true
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("%s implementation for version '%v' not found", t.handler.Kind(), *in.APIVersion)
+	}
+	e := &entry{handler: t.handler, apiVersion: *in.APIVersion}
+	return e, e.Unmarshal(pe)
+}
+
+// entry implements types.EntryImpl by delegating to a type handler
+type entry struct {
+	handler    *Handler
+	apiVersion string
+	spec       json.RawMessage
+	// indexKeys are computed along with the canonical form of the entry
+	indexKeys []string
+}
+
+func (e *entry) APIVersion() string {
+	return e.apiVersion
+}
+
+func (e *entry) IndexKeys() []string {
+	if e.indexKeys != nil {
+		return e.indexKeys
+	}
+	keys, err := e.handler.indexKeys(context.Background(), e.apiVersion, e.spec)
+	if err != nil {
+		log.Logger.Error(err)
+		return nil
+	}
+	return lowercase(keys)
+}
+
+func (e *entry) Unmarshal(pe models.ProposedEntry) error {
+	in, ok := pe.(*ProposedEntry)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal non %s types", e.handler.Kind())
+	}
+	e.spec = in.Spec
+	return e.handler.validate(context.Background(), e.apiVersion, e.spec)
+}
+
+func (e *entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	spec, err := e.handler.canonicalize(ctx, e.apiVersion, e.spec)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := e.handler.indexKeys(ctx, e.apiVersion, spec)
+	if err != nil {
+		return nil, err
+	}
+	e.indexKeys = lowercase(keys)
+
+	canonical := ProposedEntry{
+		kind:       e.handler.Kind(),
+		APIVersion: swag.String(e.apiVersion),
+		Spec:       spec,
+	}
+	return json.Marshal(canonical)
+}
+
+func (e *entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (e *entry) CreateFromArtifactProperties(context.Context, types.ArtifactProperties) (models.ProposedEntry, error) {
+	return nil, fmt.Errorf("entries of type %q must be created outside of rekor", e.handler.Kind())
+}
+
+// index lookups lowercase their query, so keys are stored the same way
+func lowercase(keys []string) []string {
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, strings.ToLower(k))
+	}
+	return result
+}
+
+// SchemaHandler serves the JSON schema of externally handled types at /api/v1/types/<kind>/schema
+func SchemaHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := strings.TrimPrefix(r.URL.Path, "/api/v1/types/")
+		if r.Method != http.MethodGet || kind == r.URL.Path || !strings.HasSuffix(kind, "/schema") {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		h, ok := handlers.Load(strings.TrimSuffix(kind, "/schema"))
+		if !ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/schema+json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(h.(*Handler).description.Schema)
+	})
+}
@@ -0,0 +1,227 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// toyHandler implements a type whose entries carry a single message
+type toyHandler struct {
+	kind string
+}
+
+type toySpec struct {
+	Message string `json:"message"`
+}
+
+func (h toyHandler) Describe() (*Description, error) {
+	return &Description{
+		Kind:     h.kind,
+		Versions: []string{"0.0.1"},
+		Schema:   json.RawMessage(`{"type":"object","required":["message"]}`),
+	}, nil
+}
+
+func (h toyHandler) parse(spec json.RawMessage) (*toySpec, error) {
+	s := &toySpec{}
+	if err := json.Unmarshal(spec, s); err != nil {
+		return nil, err
+	}
+	if s.Message == "" {
+		return nil, errors.New("message is required")
+	}
+	return s, nil
+}
+
+func (h toyHandler) Validate(_ string, spec json.RawMessage) error {
+	_, err := h.parse(spec)
+	return err
+}
+
+func (h toyHandler) Canonicalize(_ string, spec json.RawMessage) (json.RawMessage, error) {
+	s, err := h.parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+func (h toyHandler) IndexKeys(_ string, spec json.RawMessage) ([]string, error) {
+	s, err := h.parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	d := sha256.Sum256([]byte(s.Message))
+	return []string{"sha256:" + strings.ToUpper(hex.EncodeToString(d[:]))}, nil
+}
+
+func startToyHandler(t *testing.T, kind string) *Handler {
+	t.Helper()
+	server, client := net.Pipe()
+	go func() {
+		_ = Serve(toyHandler{kind: kind}, server, server)
+	}()
+	h, err := NewHandler(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestExternalType(t *testing.T) {
+	h := startToyHandler(t, "toy")
+	if h.Kind() != "toy" {
+		t.Fatalf("unexpected kind %q", h.Kind())
+	}
+	if err := Register(h); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(startToyHandler(t, "toy")); err == nil {
+		t.Error("expected registering the same kind twice to fail")
+	}
+	if err := Register(startToyHandler(t, "rekord")); err == nil {
+		t.Error("expected registering a built in kind to fail")
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		wantUnmarshal bool
+	}{
+		{name: "valid entry", body: `{"kind":"toy","apiVersion":"0.0.1","spec":{"message":"hello","ignored":true}}`, wantUnmarshal: true},
+		{name: "handler rejects spec", body: `{"kind":"toy","apiVersion":"0.0.1","spec":{"message":""}}`},
+		{name: "unsupported version", body: `{"kind":"toy","apiVersion":"0.0.2","spec":{"message":"hello"}}`},
+		{name: "missing spec", body: `{"kind":"toy","apiVersion":"0.0.1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pe, err := models.UnmarshalProposedEntry(strings.NewReader(tt.body), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected error unmarshalling proposed entry: %v", err)
+			}
+			ei, err := types.NewEntry(pe)
+			if (err == nil) != tt.wantUnmarshal {
+				t.Fatalf("NewEntry() error = %v, wantUnmarshal %v", err, tt.wantUnmarshal)
+			}
+			if err != nil {
+				return
+			}
+
+			b, err := ei.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b, []byte(`{"kind":"toy","apiVersion":"0.0.1","spec":{"message":"hello"}}`)) {
+				t.Errorf("unexpected canonical entry %s", b)
+			}
+			d := sha256.Sum256([]byte("hello"))
+			want := "sha256:" + hex.EncodeToString(d[:])
+			if keys := ei.IndexKeys(); len(keys) != 1 || keys[0] != want {
+				t.Errorf("unexpected index keys %v", keys)
+			}
+
+			// the canonical entry must be accepted again, e.g. when read back from the log
+			pe, err = models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Errorf("unexpected error unmarshalling canonical entry: %v", err)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler(t *testing.T) {
+	h := startToyHandler(t, "schematoy")
+	if err := Register(h); err != nil {
+		t.Fatal(err)
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	tests := []struct {
+		path     string
+		wantCode int
+	}{
+		{path: "/api/v1/types/schematoy/schema", wantCode: http.StatusOK},
+		{path: "/api/v1/types/unknown/schema", wantCode: http.StatusTeapot},
+		{path: "/api/v1/log", wantCode: http.StatusTeapot},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		SchemaHandler(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.wantCode {
+			t.Errorf("%s: got status %d, want %d", tt.path, rec.Code, tt.wantCode)
+		}
+		if tt.wantCode == http.StatusOK && rec.Body.String() != `{"type":"object","required":["message"]}` {
+			t.Errorf("%s: unexpected schema %s", tt.path, rec.Body.String())
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	// the test binary doubles as a type handler process for TestStart
+	if kind := os.Getenv("REKOR_TEST_TYPE_HANDLER"); kind != "" {
+		if err := Serve(toyHandler{kind: kind}, os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestStart(t *testing.T) {
+	os.Setenv("REKOR_TEST_TYPE_HANDLER", "proctoy")
+	defer os.Unsetenv("REKOR_TEST_TYPE_HANDLER")
+
+	h, err := Start(context.Background(), os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Kind() != "proctoy" {
+		t.Errorf("unexpected kind %q", h.Kind())
+	}
+	if err := h.validate(context.Background(), "0.0.1", json.RawMessage(`{"message":""}`)); err == nil {
+		t.Error("expected handler to reject empty message")
+	}
+	if err := h.Close(); err != nil {
+		t.Errorf("unexpected error stopping type handler: %v", err)
+	}
+}
+
+func TestStartMissingHandler(t *testing.T) {
+	if _, err := Start(context.Background(), "/nonexistent/rekor-type-handler"); err == nil {
+		t.Error("expected error starting missing type handler")
+	}
+}
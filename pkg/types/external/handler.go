@@ -0,0 +1,135 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// Handler is the server side connection to a type handler
+type Handler struct {
+	client      *rpc.Client
+	cmd         *exec.Cmd
+	description Description
+}
+
+// Start runs the type handler at the given path and asks it which type it implements
+func Start(ctx context.Context, path string, args ...string) (*Handler, error) {
+	// #nosec G204 the handler command is supplied by the operator
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting type handler %s: %w", path, err)
+	}
+	h, err := NewHandler(ctx, &conn{Reader: stdout, Writer: stdin})
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("type handler %s: %w", path, err)
+	}
+	h.cmd = cmd
+	return h, nil
+}
+
+// NewHandler talks to a type handler over an established connection
+func NewHandler(ctx context.Context, c io.ReadWriteCloser) (*Handler, error) {
+	h := &Handler{client: jsonrpc.NewClient(c)}
+	if err := h.call(ctx, "Describe", Empty{}, &h.description); err != nil {
+		_ = h.client.Close()
+		return nil, err
+	}
+	if h.description.Kind == "" {
+		_ = h.client.Close()
+		return nil, errors.New("type handler did not declare a kind")
+	}
+	if len(h.description.Versions) == 0 {
+		_ = h.client.Close()
+		return nil, fmt.Errorf("type handler for %s did not declare any versions", h.description.Kind)
+	}
+	return h, nil
+}
+
+// Kind returns the kind of entry served by the handler
+func (h *Handler) Kind() string {
+	return h.description.Kind
+}
+
+// Close shuts down the connection to the handler, and the handler process if it was started by Start
+func (h *Handler) Close() error {
+	err := h.client.Close()
+	if h.cmd != nil {
+		if werr := h.cmd.Wait(); err == nil {
+			err = werr
+		}
+	}
+	return err
+}
+
+func (h *Handler) validate(ctx context.Context, apiVersion string, spec []byte) error {
+	return h.call(ctx, "Validate", EntryRequest{APIVersion: apiVersion, Spec: spec}, &Empty{})
+}
+
+func (h *Handler) canonicalize(ctx context.Context, apiVersion string, spec []byte) ([]byte, error) {
+	resp := CanonicalizeResponse{}
+	if err := h.call(ctx, "Canonicalize", EntryRequest{APIVersion: apiVersion, Spec: spec}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Spec, nil
+}
+
+func (h *Handler) indexKeys(ctx context.Context, apiVersion string, spec []byte) ([]string, error) {
+	resp := IndexKeysResponse{}
+	if err := h.call(ctx, "IndexKeys", EntryRequest{APIVersion: apiVersion, Spec: spec}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// call invokes a method on the handler; errors returned by the handler itself are reported as
+// validation errors, while failing to reach the handler is an internal error
+func (h *Handler) call(ctx context.Context, method string, args, reply interface{}) error {
+	c := h.client.Go(ServiceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.Done:
+	}
+	var serverErr rpc.ServerError
+	if errors.As(c.Error, &serverErr) {
+		return types.ValidationError(serverErr)
+	}
+	if c.Error != nil {
+		return fmt.Errorf("calling type handler for %q: %w", h.description.Kind, c.Error)
+	}
+	return nil
+}
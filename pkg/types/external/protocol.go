@@ -0,0 +1,134 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external lets a rekor server support entry types that are implemented outside of the
+// server binary. Each type is served by a "type handler" process, which the server starts at
+// startup and talks to with JSON-RPC over the handler's stdin and stdout.
+package external
+
+import (
+	"encoding/json"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+)
+
+// ServiceName is the name of the JSON-RPC service a type handler must implement; its methods are
+// called as e.g. TypeHandler.Canonicalize
+const ServiceName = "TypeHandler"
+
+// Description is returned by a type handler to announce the kind it implements
+type Description struct {
+	// Kind is the value of the kind property of entries of this type
+	Kind string `json:"kind"`
+	// Versions lists the supported API versions; the first is the default
+	Versions []string `json:"versions"`
+	// Schema is the JSON schema describing the entry spec, served at /api/v1/types/<kind>/schema
+	Schema json.RawMessage `json:"schema"`
+}
+
+// Empty is used for calls which take no arguments
+type Empty struct{}
+
+// EntryRequest carries a single entry to the type handler
+type EntryRequest struct {
+	APIVersion string          `json:"apiVersion"`
+	Spec       json.RawMessage `json:"spec"`
+}
+
+// CanonicalizeResponse holds the canonical form of the spec of an entry
+type CanonicalizeResponse struct {
+	Spec json.RawMessage `json:"spec"`
+}
+
+// IndexKeysResponse holds the keys an entry should be indexed under
+type IndexKeysResponse struct {
+	Keys []string `json:"keys"`
+}
+
+// TypeHandler is implemented by the external program serving a type. Errors returned from
+// Validate, Canonicalize and IndexKeys are reported to the client as validation failures.
+type TypeHandler interface {
+	Describe() (*Description, error)
+	Validate(apiVersion string, spec json.RawMessage) error
+	Canonicalize(apiVersion string, spec json.RawMessage) (json.RawMessage, error)
+	IndexKeys(apiVersion string, spec json.RawMessage) ([]string, error)
+}
+
+// Serve answers calls from a rekor server on the given connection until it is closed; a handler
+// program would typically call Serve(impl, os.Stdin, os.Stdout) from main
+func Serve(impl TypeHandler, r io.Reader, w io.Writer) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(ServiceName, &service{impl: impl}); err != nil {
+		return err
+	}
+	server.ServeCodec(jsonrpc.NewServerCodec(&conn{Reader: r, Writer: w}))
+	return nil
+}
+
+// service adapts a TypeHandler to the method signatures required by net/rpc
+type service struct {
+	impl TypeHandler
+}
+
+func (s *service) Describe(_ Empty, resp *Description) error {
+	d, err := s.impl.Describe()
+	if err != nil {
+		return err
+	}
+	*resp = *d
+	return nil
+}
+
+func (s *service) Validate(req EntryRequest, _ *Empty) error {
+	return s.impl.Validate(req.APIVersion, req.Spec)
+}
+
+func (s *service) Canonicalize(req EntryRequest, resp *CanonicalizeResponse) error {
+	spec, err := s.impl.Canonicalize(req.APIVersion, req.Spec)
+	if err != nil {
+		return err
+	}
+	resp.Spec = spec
+	return nil
+}
+
+func (s *service) IndexKeys(req EntryRequest, resp *IndexKeysResponse) error {
+	keys, err := s.impl.IndexKeys(req.APIVersion, req.Spec)
+	if err != nil {
+		return err
+	}
+	resp.Keys = keys
+	return nil
+}
+
+// conn joins the two halves of a handler's standard streams into a single connection
+type conn struct {
+	io.Reader
+	io.Writer
+}
+
+func (c *conn) Close() error {
+	var err error
+	if rc, ok := c.Reader.(io.Closer); ok {
+		err = rc.Close()
+	}
+	if wc, ok := c.Writer.(io.Closer); ok {
+		if werr := wc.Close(); err == nil {
+			err = werr
+		}
+	}
+	return err
+}
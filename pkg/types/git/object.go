@@ -0,0 +1,187 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	PGPSignature  = "pgp"
+	SSHSignature  = "ssh"
+	X509Signature = "x509"
+)
+
+// signatureMarkers are the armor headers git recognizes at the start of a signature
+var signatureMarkers = []struct {
+	marker string
+	format string
+}{
+	{"-----BEGIN PGP SIGNATURE-----", PGPSignature},
+	{"-----BEGIN PGP MESSAGE-----", PGPSignature},
+	{"-----BEGIN SSH SIGNATURE-----", SSHSignature},
+	{"-----BEGIN SIGNED MESSAGE-----", X509Signature},
+}
+
+// signatureHeaders are the commit headers holding signatures; gpgsig-sha256 carries the signature
+// made in a SHA-256 repository and is removed from the payload like gpgsig
+var signatureHeaders = []string{"gpgsig", "gpgsig-sha256"}
+
+// Object is a signed git commit or tag
+type Object struct {
+	Type            string // commit or tag
+	ID              string // the SHA-1 object name
+	Target          string // the object name referenced by a tag
+	Payload         []byte // the object with its signature removed, which is what was signed
+	Signature       []byte
+	SignatureFormat string
+}
+
+// ParseObject parses a commit or tag object as printed by git cat-file and splits the signature
+// from the signed payload the same way git verify-commit and verify-tag do
+func ParseObject(content []byte) (*Object, error) {
+	o := &Object{}
+	switch {
+	case bytes.HasPrefix(content, []byte("tree ")):
+		o.Type = "commit"
+	case bytes.HasPrefix(content, []byte("object ")):
+		o.Type = "tag"
+		end := bytes.IndexByte(content, '\n')
+		if end < 0 {
+			return nil, errors.New("truncated tag object")
+		}
+		o.Target = string(content[len("object "):end])
+		if !isObjectName(o.Target) {
+			return nil, fmt.Errorf("invalid tagged object name %q", o.Target)
+		}
+	default:
+		return nil, errors.New("content is not a git commit or tag object")
+	}
+
+	var err error
+	if o.Type == "commit" {
+		o.Payload, o.Signature, err = splitCommit(content)
+	} else {
+		o.Payload, o.Signature, err = splitTag(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.SignatureFormat = signatureFormat(o.Signature); o.SignatureFormat == "" {
+		return nil, fmt.Errorf("unsupported %s signature format", o.Type)
+	}
+
+	h := sha1.New() // #nosec G401
+	h.Write([]byte(o.Type + " " + strconv.Itoa(len(content)) + "\x00"))
+	h.Write(content)
+	o.ID = hex.EncodeToString(h.Sum(nil))
+	return o, nil
+}
+
+func isObjectName(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func signatureFormat(sig []byte) string {
+	for _, m := range signatureMarkers {
+		if bytes.HasPrefix(sig, []byte(m.marker)) {
+			return m.format
+		}
+	}
+	return ""
+}
+
+// splitCommit removes the signature headers from a commit; the signature is the value of the
+// gpgsig header, with the leading space of each continuation line removed
+func splitCommit(content []byte) ([]byte, []byte, error) {
+	var payload, sig []byte
+	// keep is set while reading the continuation lines of the gpgsig header
+	inHeaders, inSignature, keep, found := true, false, false, false
+	for rest := content; len(rest) > 0; {
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line = rest[:i+1]
+		}
+		rest = rest[len(line):]
+
+		if !inHeaders {
+			payload = append(payload, line...)
+			continue
+		}
+		if inSignature && line[0] == ' ' {
+			if keep {
+				sig = append(sig, line[1:]...)
+			}
+			continue
+		}
+		inSignature, keep = false, false
+
+		if line[0] == '\n' {
+			// the message follows the first empty line
+			inHeaders = false
+			payload = append(payload, line...)
+			continue
+		}
+		for _, h := range signatureHeaders {
+			if bytes.HasPrefix(line, []byte(h+" ")) {
+				inSignature = true
+				if h == "gpgsig" {
+					if found {
+						return nil, nil, errors.New("commit contains more than one gpgsig header")
+					}
+					found, keep = true, true
+					sig = append(sig, line[len(h)+1:]...)
+				}
+			}
+		}
+		if !inSignature {
+			payload = append(payload, line...)
+		}
+	}
+	if !found {
+		return nil, nil, errors.New("commit is not signed")
+	}
+	return payload, sig, nil
+}
+
+// splitTag splits the signature appended to a tag message; like git, the signature starts at the
+// last line beginning with a signature armor header
+func splitTag(content []byte) ([]byte, []byte, error) {
+	match := -1
+	for pos := 0; pos < len(content); {
+		if signatureFormat(content[pos:]) != "" {
+			match = pos
+		}
+		i := bytes.IndexByte(content[pos:], '\n')
+		if i < 0 {
+			break
+		}
+		pos += i + 1
+	}
+	if match < 0 {
+		return nil, nil, errors.New("tag is not signed")
+	}
+	return content[:match], content[match:], nil
+}
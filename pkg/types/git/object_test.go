@@ -0,0 +1,105 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestParseObject(t *testing.T) {
+	tests := []struct {
+		file   string
+		typ    string
+		id     string
+		target string
+		format string
+	}{
+		{file: "test_git_commit_ssh", typ: "commit", id: "00defd59b9c1e050c4d85b9e0a4f42f9a9e49112", format: SSHSignature},
+		{file: "test_git_commit_pgp", typ: "commit", id: "8f3f5cbec11fee79a421eed07990afee48f1d227", format: PGPSignature},
+		{file: "test_git_commit_x509", typ: "commit", id: "de501caaaeb3b83278c375723e3496fe649b19c1", format: X509Signature},
+		{file: "test_git_tag_pgp", typ: "tag", id: "e6775422f55ded29027f605fc799c09f79ddbb8c", target: "00defd59b9c1e050c4d85b9e0a4f42f9a9e49112", format: PGPSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			content, err := ioutil.ReadFile("../../../tests/" + tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := ParseObject(content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if o.Type != tt.typ || o.ID != tt.id || o.Target != tt.target || o.SignatureFormat != tt.format {
+				t.Errorf("unexpected object %s %s %s %s", o.Type, o.ID, o.Target, o.SignatureFormat)
+			}
+			if bytes.Contains(o.Payload, []byte("-----BEGIN")) {
+				t.Errorf("payload still contains the signature:\n%s", o.Payload)
+			}
+			if !bytes.HasSuffix(bytes.TrimSpace(o.Signature), []byte("-----")) {
+				t.Errorf("unexpected signature:\n%s", o.Signature)
+			}
+		})
+	}
+}
+
+func TestSplitCommit(t *testing.T) {
+	commit := "tree 7d4a466af82cd6857c85c0296d5c23fc68cba887\n" +
+		"author A <a@example.com> 1634200000 +0000\n" +
+		"committer A <a@example.com> 1634200000 +0000\n" +
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n \n abc\n -----END PGP SIGNATURE-----\n" +
+		"gpgsig-sha256 -----BEGIN PGP SIGNATURE-----\n \n def\n -----END PGP SIGNATURE-----\n" +
+		"\n" +
+		"message\n gpgsig in the message is kept\n"
+
+	payload, sig, err := splitCommit([]byte(commit))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedPayload := "tree 7d4a466af82cd6857c85c0296d5c23fc68cba887\n" +
+		"author A <a@example.com> 1634200000 +0000\n" +
+		"committer A <a@example.com> 1634200000 +0000\n" +
+		"\n" +
+		"message\n gpgsig in the message is kept\n"
+	if string(payload) != expectedPayload {
+		t.Errorf("unexpected payload:\n%s", payload)
+	}
+	if expectedSig := "-----BEGIN PGP SIGNATURE-----\n\nabc\n-----END PGP SIGNATURE-----\n"; string(sig) != expectedSig {
+		t.Errorf("unexpected signature:\n%s", sig)
+	}
+}
+
+func TestParseObjectErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "not an object", content: "hello world\n"},
+		{name: "unsigned commit", content: "tree 7d4a466af82cd6857c85c0296d5c23fc68cba887\n\nmessage\n"},
+		{name: "unsigned tag", content: "object 00defd59b9c1e050c4d85b9e0a4f42f9a9e49112\ntype commit\ntag v1\n\nmessage\n"},
+		{name: "invalid tag target", content: "object nothex\ntype commit\ntag v1\n\nmessage\n-----BEGIN PGP SIGNATURE-----\n"},
+		{name: "duplicate gpgsig", content: "tree 7d4a466af82cd6857c85c0296d5c23fc68cba887\ngpgsig -----BEGIN PGP SIGNATURE-----\ngpgsig -----BEGIN PGP SIGNATURE-----\n\nmessage\n"},
+		{name: "unknown signature", content: "tree 7d4a466af82cd6857c85c0296d5c23fc68cba887\ngpgsig something\n\nmessage\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseObject([]byte(tt.content)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/pki/pkcs7"
+	"github.com/sigstore/rekor/pkg/pki/ssh"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/git"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+// sshNamespace is the sshsig namespace git signs commits and tags in
+const sshNamespace = "git"
+
+func init() {
+	if err := git.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	GitObj models.GitV001Schema
+	keyObj pki.PublicKey
+	object *git.Object
+	// signerEmails are the subjectAltName email addresses of the certificate of an x509 signature
+	signerEmails []string
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.keyObj != nil {
+		key, err := v.keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+		for _, email := range v.signerEmails {
+			result = append(result, strings.ToLower(email))
+		}
+	}
+
+	switch {
+	case v.object != nil:
+		result = append(result, v.object.ID)
+		if v.object.Target != "" {
+			result = append(result, v.object.Target)
+		}
+	case v.GitObj.Object != nil && v.GitObj.Object.ID != "":
+		result = append(result, v.GitObj.Object.ID)
+		if v.GitObj.Object.Target != "" {
+			result = append(result, v.GitObj.Object.Target)
+		}
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Git)
+	if !ok {
+		return errors.New("cannot unmarshal non git v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.GitObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.GitObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	if v.GitObj.Object == nil {
+		return errors.New("missing git object")
+	}
+	// This also gets called in the CLI, where we won't have this data
+	if len(v.GitObj.Object.Content) == 0 {
+		return nil
+	}
+
+	o, err := git.ParseObject(v.GitObj.Object.Content)
+	if err != nil {
+		return err
+	}
+	if err := v.verify(o); err != nil {
+		return fmt.Errorf("verifying %s signature on %s %s: %w", o.SignatureFormat, o.Type, o.ID, err)
+	}
+	v.object = o
+	return nil
+}
+
+// verify checks the signature over the object payload; PGP and SSH signatures are verified with
+// the supplied key, while x509 signatures are verified with the certificate they embed
+func (v *V001Entry) verify(o *git.Object) error {
+	var sigObj pki.Signature
+	switch o.SignatureFormat {
+	case git.PGPSignature:
+		if len(v.GitObj.PublicKey) == 0 {
+			return errors.New("a public key must be provided")
+		}
+		key, err := pgp.NewPublicKey(bytes.NewReader(v.GitObj.PublicKey))
+		if err != nil {
+			return err
+		}
+		if sigObj, err = pgp.NewSignature(bytes.NewReader(o.Signature)); err != nil {
+			return err
+		}
+		v.keyObj = key
+	case git.SSHSignature:
+		if len(v.GitObj.PublicKey) == 0 {
+			return errors.New("a public key must be provided")
+		}
+		key, err := ssh.NewPublicKey(bytes.NewReader(v.GitObj.PublicKey))
+		if err != nil {
+			return err
+		}
+		canonicalKey, err := key.CanonicalValue()
+		if err != nil {
+			return err
+		}
+		if err := ssh.VerifyNamespace(bytes.NewReader(o.Payload), o.Signature, canonicalKey, sshNamespace); err != nil {
+			return err
+		}
+		v.keyObj = key
+		return nil
+	case git.X509Signature:
+		if len(v.GitObj.PublicKey) != 0 {
+			return errors.New("x509 signatures embed the signing certificate; a public key must not be provided")
+		}
+		block, _ := pem.Decode(o.Signature)
+		if block == nil {
+			return errors.New("failed to decode PEM signature")
+		}
+		key, err := pkcs7.NewPublicKey(bytes.NewReader(block.Bytes))
+		if err != nil {
+			return err
+		}
+		if sigObj, err = pkcs7.NewSignature(bytes.NewReader(block.Bytes)); err != nil {
+			return err
+		}
+		v.keyObj = key
+		// gitsign and S/MIME signer certificates carry the address as a subjectAltName
+		certPEM, err := key.CanonicalValue()
+		if err != nil {
+			return err
+		}
+		if certBlock, _ := pem.Decode(certPEM); certBlock != nil {
+			if cert, err := x509.ParseCertificate(certBlock.Bytes); err == nil {
+				v.signerEmails = cert.EmailAddresses
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported signature format %s", o.SignatureFormat)
+	}
+	return sigObj.Verify(bytes.NewReader(o.Payload), v.keyObj)
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.object == nil {
+		return nil, errors.New("cannot canonicalize entry without a verified git object")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("cannot canonicalize empty key")
+	}
+
+	canonicalEntry := models.GitV001Schema{
+		Object: &models.GitV001SchemaObject{
+			Type:   v.object.Type,
+			ID:     v.object.ID,
+			Target: v.object.Target,
+		},
+		Signature: &models.GitV001SchemaSignature{
+			Format:  v.object.SignatureFormat,
+			Content: v.object.Signature,
+		},
+		ExtraData: v.GitObj.ExtraData,
+	}
+	// x509 signatures carry their certificate, so only the supplied key is recorded
+	if v.object.SignatureFormat != git.X509Signature {
+		var err error
+		if canonicalEntry.PublicKey, err = v.keyObj.CanonicalValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	gitObj := models.Git{}
+	gitObj.APIVersion = swag.String(APIVERSION)
+	gitObj.Spec = &canonicalEntry
+
+	return json.Marshal(&gitObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Git{}
+
+	var err error
+	objectBytes := props.ArtifactBytes
+	if objectBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to git object must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("git objects cannot be fetched over HTTP(S)")
+		}
+		objectBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil && props.PublicKeyPath != nil {
+		publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+	}
+
+	re := V001Entry{
+		GitObj: models.GitV001Schema{
+			Object: &models.GitV001SchemaObject{
+				Content: strfmt.Base64(objectBytes),
+			},
+			PublicKey: strfmt.Base64(publicKeyBytes),
+		},
+	}
+
+	returnVal.Spec = re.GitObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
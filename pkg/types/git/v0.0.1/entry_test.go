@@ -0,0 +1,195 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	b, err := ioutil.ReadFile("../../../../tests/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	sshCommit := readFixture(t, "test_git_commit_ssh")
+	sshKey := readFixture(t, "test_git_ssh.pub")
+	pgpCommit := readFixture(t, "test_git_commit_pgp")
+	pgpTag := readFixture(t, "test_git_tag_pgp")
+	pgpKey := readFixture(t, "test_git_pgp.pub")
+	x509Commit := readFixture(t, "test_git_commit_x509")
+
+	tamperedCommit := bytes.Replace(pgpCommit, []byte("Second commit"), []byte("Second c0mmit"), 1)
+
+	tests := []struct {
+		name    string
+		obj     models.GitV001Schema
+		wantErr bool
+		wantKey string
+	}{
+		{
+			name:    "empty",
+			obj:     models.GitV001Schema{},
+			wantErr: true,
+		},
+		{
+			name: "object without content",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{},
+				PublicKey: sshKey,
+			},
+		},
+		{
+			name: "ssh signed commit",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: sshCommit},
+				PublicKey: sshKey,
+			},
+			wantKey: "00defd59b9c1e050c4d85b9e0a4f42f9a9e49112",
+		},
+		{
+			name: "pgp signed commit",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: pgpCommit},
+				PublicKey: pgpKey,
+			},
+			wantKey: "8f3f5cbec11fee79a421eed07990afee48f1d227",
+		},
+		{
+			name: "pgp signed tag",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: pgpTag},
+				PublicKey: pgpKey,
+			},
+			// the tagged commit is indexed along with the tag
+			wantKey: "00defd59b9c1e050c4d85b9e0a4f42f9a9e49112",
+		},
+		{
+			name: "x509 signed commit",
+			obj: models.GitV001Schema{
+				Object: &models.GitV001SchemaObject{Content: x509Commit},
+			},
+			wantKey: "rekor-git-test@example.com",
+		},
+		{
+			name: "x509 signed commit with a public key",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: x509Commit},
+				PublicKey: pgpKey,
+			},
+			wantErr: true,
+		},
+		{
+			name: "pgp signed commit without a public key",
+			obj: models.GitV001Schema{
+				Object: &models.GitV001SchemaObject{Content: pgpCommit},
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong key type",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: sshCommit},
+				PublicKey: pgpKey,
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered commit",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: tamperedCommit},
+				PublicKey: pgpKey,
+			},
+			wantErr: true,
+		},
+		{
+			name: "not a git object",
+			obj: models.GitV001Schema{
+				Object:    &models.GitV001SchemaObject{Content: pgpKey},
+				PublicKey: pgpKey,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			it := &models.Git{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       &tt.obj,
+			}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr || len(tt.obj.Object.Content) == 0 {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+			found := false
+			for _, k := range v.IndexKeys() {
+				found = found || k == tt.wantKey
+			}
+			if !found {
+				t.Errorf("V001Entry.IndexKeys() = %v, missing %v", v.IndexKeys(), tt.wantKey)
+			}
+
+			// the canonicalized entry must be accepted and keep the object name searchable
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			ei, err := types.NewEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			found = false
+			for _, k := range ei.IndexKeys() {
+				found = found || k == v.object.ID
+			}
+			if !found {
+				t.Errorf("canonicalized entry index keys %v are missing %v", ei.IndexKeys(), v.object.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/gomod"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := gomod.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	GomodObj models.GomodV001Schema
+	keyObj   pki.PublicKey
+	sigObj   pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+// PKIFormat returns the format of the signature and public key of the entry
+func (v V001Entry) PKIFormat() string {
+	if v.GomodObj.Signature == nil {
+		return ""
+	}
+	return swag.StringValue(v.GomodObj.Signature.Format)
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.keyObj != nil {
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	if m := v.GomodObj.Module; m != nil {
+		path := swag.StringValue(m.Path)
+		result = append(result, path, path+"@"+swag.StringValue(m.Version), swag.StringValue(m.Hash))
+		if m.GoModHash != "" {
+			result = append(result, m.GoModHash)
+		}
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Gomod)
+	if !ok {
+		return errors.New("cannot unmarshal non Go module v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.GomodObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.GomodObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	artifactFactory, err := pki.NewArtifactFactory(pki.Format(swag.StringValue(v.GomodObj.Signature.Format)))
+	if err != nil {
+		return err
+	}
+	v.keyObj, err = artifactFactory.NewPublicKey(bytes.NewReader(*v.GomodObj.Signature.PublicKey.Content))
+	if err != nil {
+		return err
+	}
+	v.sigObj, err = artifactFactory.NewSignature(bytes.NewReader(*v.GomodObj.Signature.Content))
+	if err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	m := v.GomodObj.Module
+	if err := validateModule(m); err != nil {
+		return err
+	}
+
+	if err := v.sigObj.Verify(bytes.NewReader(sumLines(m)), v.keyObj); err != nil {
+		return fmt.Errorf("verifying go.sum signature: %w", err)
+	}
+
+	// This also gets called in the CLI, where we won't have this data
+	if len(m.Zip) == 0 {
+		return nil
+	}
+	h, err := hashZip(m.Zip, swag.StringValue(m.Path), swag.StringValue(m.Version))
+	if err != nil {
+		return err
+	}
+	if h != swag.StringValue(m.Hash) {
+		return fmt.Errorf("module zip hash %s does not match %s", h, swag.StringValue(m.Hash))
+	}
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.keyObj == nil || v.sigObj == nil {
+		return nil, errors.New("key and signature objects not initialized before canonicalization")
+	}
+
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	keyContent := strfmt.Base64(key)
+	sig, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	sigContent := strfmt.Base64(sig)
+
+	m := v.GomodObj.Module
+	canonicalEntry := models.GomodV001Schema{
+		// the module zip is not set deliberately
+		Module: &models.GomodV001SchemaModule{
+			Path:      m.Path,
+			Version:   m.Version,
+			Hash:      m.Hash,
+			GoModHash: m.GoModHash,
+		},
+		Signature: &models.GomodV001SchemaSignature{
+			Format:  v.GomodObj.Signature.Format,
+			Content: &sigContent,
+			PublicKey: &models.GomodV001SchemaSignaturePublicKey{
+				Content: &keyContent,
+			},
+		},
+		ExtraData: v.GomodObj.ExtraData,
+	}
+
+	gomodObj := models.Gomod{}
+	gomodObj.APIVersion = swag.String(APIVERSION)
+	gomodObj.Spec = &canonicalEntry
+
+	return json.Marshal(&gomodObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Gomod{}
+
+	var err error
+	zipBytes := props.ArtifactBytes
+	if zipBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to module zip must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("module zips cannot be fetched over HTTP(S)")
+		}
+		zipBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	m, err := moduleFromZip(zipBytes)
+	if err != nil {
+		return nil, err
+	}
+	m.Zip = strfmt.Base64(zipBytes)
+
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a signature over the module go.sum lines must be provided")
+		}
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify signature")
+		}
+		publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+	}
+	sb, kb := strfmt.Base64(sigBytes), strfmt.Base64(publicKeyBytes)
+
+	format := props.PKIFormat
+	if format == "" {
+		format = models.GomodV001SchemaSignatureFormatPgp
+	}
+
+	re := V001Entry{
+		GomodObj: models.GomodV001Schema{
+			Module: m,
+			Signature: &models.GomodV001SchemaSignature{
+				Format:    swag.String(format),
+				Content:   &sb,
+				PublicKey: &models.GomodV001SchemaSignaturePublicKey{Content: &kb},
+			},
+		},
+	}
+
+	returnVal.Spec = re.GomodObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
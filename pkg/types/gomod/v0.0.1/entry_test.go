@@ -0,0 +1,146 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func p(b []byte) *strfmt.Base64 {
+	b64 := strfmt.Base64(b)
+	return &b64
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	sign := func(m *models.GomodV001SchemaModule) []byte {
+		h := sha256.Sum256(sumLines(m))
+		sig, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	zipBytes := moduleZip(t, testModule)
+	mod, err := moduleFromZip(zipBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withZip := *mod
+	withZip.Zip = zipBytes
+	otherZip := *mod
+	otherZip.Zip = moduleZip(t, map[string]string{"example.com/hello@v1.0.0/go.mod": "module example.com/hello\n"})
+	wrongVersion := *mod
+	wrongVersion.Version = swag.String("v1.0.1")
+	withoutGoMod := *mod
+	withoutGoMod.GoModHash = ""
+
+	tests := []struct {
+		name    string
+		module  *models.GomodV001SchemaModule
+		sig     []byte
+		wantErr bool
+	}{
+		{name: "valid", module: mod, sig: sign(mod)},
+		{name: "with zip", module: &withZip, sig: sign(mod)},
+		{name: "without go.mod hash", module: &withoutGoMod, sig: sign(&withoutGoMod)},
+		{name: "zip hash mismatch", module: &otherZip, sig: sign(mod), wantErr: true},
+		{name: "signature over another version", module: &wrongVersion, sig: sign(mod), wantErr: true},
+		{name: "signature without go.mod line", module: mod, sig: sign(&withoutGoMod), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := &models.Gomod{
+				APIVersion: swag.String(APIVERSION),
+				Spec: &models.GomodV001Schema{
+					Module: tt.module,
+					Signature: &models.GomodV001SchemaSignature{
+						Format:    swag.String(models.GomodV001SchemaSignatureFormatX509),
+						Content:   p(tt.sig),
+						PublicKey: &models.GomodV001SchemaSignaturePublicKey{Content: p(pub)},
+					},
+				},
+			}
+			v := &V001Entry{}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			ei, err := types.NewEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			for _, want := range []string{"example.com/hello", "example.com/hello@v1.0.0", swag.StringValue(mod.Hash)} {
+				found := false
+				for _, k := range ei.IndexKeys() {
+					found = found || k == want
+				}
+				if !found {
+					t.Errorf("IndexKeys() = %v, missing %v", ei.IndexKeys(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/mod/sumdb/dirhash"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func validateModule(m *models.GomodV001SchemaModule) error {
+	if m == nil {
+		return errors.New("missing module")
+	}
+	path, version := swag.StringValue(m.Path), swag.StringValue(m.Version)
+	if err := module.CheckPath(path); err != nil {
+		return err
+	}
+	if semver.Canonical(version) != version {
+		return fmt.Errorf("%s is not a canonical module version", version)
+	}
+	if err := module.Check(path, version); err != nil {
+		return err
+	}
+	if err := validateHash(swag.StringValue(m.Hash)); err != nil {
+		return fmt.Errorf("invalid module hash: %w", err)
+	}
+	if m.GoModHash != "" {
+		if err := validateHash(m.GoModHash); err != nil {
+			return fmt.Errorf("invalid go.mod hash: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateHash checks h is an h1: dirhash, the only hash the checksum database uses
+func validateHash(h string) error {
+	if !strings.HasPrefix(h, "h1:") {
+		return errors.New("only h1: hashes are supported")
+	}
+	if b, err := base64.StdEncoding.DecodeString(h[len("h1:"):]); err != nil || len(b) != 32 {
+		return errors.New("h1: hash must be a base64 encoded SHA-256 digest")
+	}
+	return nil
+}
+
+// sumLines returns the go.sum lines for the module, which is what the author signs
+func sumLines(m *models.GomodV001SchemaModule) []byte {
+	path, version := swag.StringValue(m.Path), swag.StringValue(m.Version)
+	lines := fmt.Sprintf("%s %s %s\n", path, version, swag.StringValue(m.Hash))
+	if m.GoModHash != "" {
+		lines += fmt.Sprintf("%s %s/go.mod %s\n", path, version, m.GoModHash)
+	}
+	return []byte(lines)
+}
+
+// hashZip computes the h1: dirhash of a module zip, whose files must all be below the
+// <path>@<version>/ directory
+func hashZip(b []byte, path, version string) (string, error) {
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return "", fmt.Errorf("invalid module zip: %w", err)
+	}
+	prefix := path + "@" + version + "/"
+	files := make([]string, 0, len(z.File))
+	zfiles := make(map[string]*zip.File, len(z.File))
+	for _, f := range z.File {
+		if !strings.HasPrefix(f.Name, prefix) {
+			return "", fmt.Errorf("module zip contains %s outside of %s", f.Name, prefix)
+		}
+		if _, ok := zfiles[f.Name]; ok {
+			return "", fmt.Errorf("module zip contains %s more than once", f.Name)
+		}
+		files = append(files, f.Name)
+		zfiles[f.Name] = f
+	}
+	return dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		return zfiles[name].Open()
+	})
+}
+
+// moduleFromZip takes the module path and version from the file names in a module zip and
+// computes its hashes; the go.mod hash is only computed if the zip contains a go.mod file
+func moduleFromZip(b []byte) (*models.GomodV001SchemaModule, error) {
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid module zip: %w", err)
+	}
+	if len(z.File) == 0 {
+		return nil, errors.New("module zip is empty")
+	}
+	name := z.File[0].Name
+	// module paths cannot contain @, so the first one starts the version
+	at := strings.Index(name, "@")
+	end := -1
+	if at >= 0 {
+		end = strings.Index(name[at:], "/")
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("module zip file %s is not below a <path>@<version>/ directory", name)
+	}
+	end += at
+	m := &models.GomodV001SchemaModule{
+		Path:    swag.String(name[:at]),
+		Version: swag.String(name[at+1 : end]),
+	}
+
+	h, err := hashZip(b, *m.Path, *m.Version)
+	if err != nil {
+		return nil, err
+	}
+	m.Hash = swag.String(h)
+
+	for _, f := range z.File {
+		if f.Name != name[:end+1]+"go.mod" {
+			continue
+		}
+		m.GoModHash, err = dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+			return f.Open()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
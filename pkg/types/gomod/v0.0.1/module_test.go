@@ -0,0 +1,131 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gomod
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"golang.org/x/mod/sumdb/dirhash"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// moduleZip builds a zip of the given files
+func moduleZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+var testModule = map[string]string{
+	"example.com/hello@v1.0.0/go.mod":   "module example.com/hello\n",
+	"example.com/hello@v1.0.0/hello.go": "package hello\n",
+}
+
+func TestModuleFromZip(t *testing.T) {
+	b := moduleZip(t, testModule)
+	path := filepath.Join(t.TempDir(), "v1.0.0.zip")
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	want, err := dirhash.HashZip(path, dirhash.Hash1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantGoMod, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader([]byte(testModule["example.com/hello@v1.0.0/go.mod"]))), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := moduleFromZip(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if swag.StringValue(m.Path) != "example.com/hello" || swag.StringValue(m.Version) != "v1.0.0" {
+		t.Errorf("unexpected module %s %s", swag.StringValue(m.Path), swag.StringValue(m.Version))
+	}
+	if swag.StringValue(m.Hash) != want {
+		t.Errorf("hash = %s, want %s", swag.StringValue(m.Hash), want)
+	}
+	if m.GoModHash != wantGoMod {
+		t.Errorf("go.mod hash = %s, want %s", m.GoModHash, wantGoMod)
+	}
+	if err := validateModule(m); err != nil {
+		t.Errorf("unexpected error validating module: %v", err)
+	}
+
+	for name, files := range map[string]map[string]string{
+		"files outside of the module": {"example.com/hello@v1.0.0/go.mod": "", "other/hello.go": ""},
+		"no version directory":        {"example.com/hello/go.mod": ""},
+	} {
+		if _, err := moduleFromZip(moduleZip(t, files)); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+}
+
+func TestValidateModule(t *testing.T) {
+	hash := "h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+	tests := []struct {
+		name      string
+		path      string
+		version   string
+		hash      string
+		goModHash string
+		wantErr   bool
+	}{
+		{name: "valid", path: "example.com/hello", version: "v1.0.0", hash: hash, goModHash: hash},
+		{name: "major version suffix", path: "example.com/hello/v2", version: "v2.1.0", hash: hash},
+		{name: "major version mismatch", path: "example.com/hello", version: "v2.1.0", hash: hash, wantErr: true},
+		{name: "non-canonical version", path: "example.com/hello", version: "v1.0", hash: hash, wantErr: true},
+		{name: "invalid path", path: "hello world", version: "v1.0.0", hash: hash, wantErr: true},
+		{name: "unsupported hash", path: "example.com/hello", version: "v1.0.0", hash: "h2:" + hash[3:], wantErr: true},
+		{name: "short hash", path: "example.com/hello", version: "v1.0.0", hash: "h1:AAAA", wantErr: true},
+		{name: "invalid go.mod hash", path: "example.com/hello", version: "v1.0.0", hash: hash, goModHash: "h1:", wantErr: true},
+	}
+	for _, tt := range tests {
+		m := &models.GomodV001SchemaModule{
+			Path:      swag.String(tt.path),
+			Version:   swag.String(tt.version),
+			Hash:      swag.String(tt.hash),
+			GoModHash: tt.goModHash,
+		}
+		if err := validateModule(m); (err != nil) != tt.wantErr {
+			t.Errorf("validateModule() %s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -47,6 +47,17 @@ func TestProvenance(t *testing.T) {
 		t.Fatal("Empty checksum")
 	}
 
+	if provenance.ChartMetadata["name"] != "test" || provenance.ChartMetadata["version"] != "0.1.0" {
+		t.Errorf("unexpected chart metadata %v", provenance.ChartMetadata)
+	}
+	fileChecksum, err := provenance.GetChartFileHash()
+	if err != nil {
+		t.Fatalf("Error retrieving chart file hash: %v", err)
+	}
+	if fileChecksum != checksum {
+		t.Errorf("chart file hash %s does not match %s", fileChecksum, checksum)
+	}
+
 	publickeyFile, err := os.Open("../../../tests/test_helm_armor.pub")
 	if err != nil {
 		t.Fatalf("could not open public key %v", err)
@@ -81,6 +81,18 @@ func (p *Provenance) parseMessageBlock(data []byte) error {
 		return errors.New("message block must have at least two parts")
 	}
 
+	// the first part is the Chart.yaml of the chart; only string values are kept
+	metadata := map[string]interface{}{}
+	if err := yaml.Unmarshal(parts[0], &metadata); err != nil {
+		return errors.Wrap(err, "Error occurred parsing chart metadata")
+	}
+	p.ChartMetadata = map[string]string{}
+	for k, v := range metadata {
+		if s, ok := v.(string); ok {
+			p.ChartMetadata[k] = s
+		}
+	}
+
 	sc := &SumCollection{}
 
 	err := yaml.Unmarshal(parts[1], sc)
@@ -118,3 +130,27 @@ func (p *Provenance) GetChartHash() (string, error) {
 	return "", errors.New("No checksums found")
 
 }
+
+// GetChartFileHash returns the hash recorded for the chart archive named after the chart
+// metadata, <name>-<version>.tgz, which is also the digest of the chart layer when the chart
+// is stored in an OCI registry
+func (p *Provenance) GetChartFileHash() (string, error) {
+	name, version := p.ChartMetadata["name"], p.ChartMetadata["version"]
+	if name == "" || version == "" {
+		return "", errors.New("Chart metadata must contain a name and version")
+	}
+	if p.SumCollection == nil || p.SumCollection.Files == nil {
+		return "", errors.New("Unable to locate chart hash")
+	}
+
+	filename := name + "-" + version + ".tgz"
+	value, ok := p.SumCollection.Files[filename]
+	if !ok {
+		return "", errors.Errorf("No checksum found for %s", filename)
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || parts[0] != "sha256" {
+		return "", errors.New("Invalid hash found in Provenance file")
+	}
+	return parts[1], nil
+}
@@ -77,22 +77,37 @@ func (v V001Entry) IndexKeys() []string {
 		}
 	}
 
-	key, err := v.keyObj.CanonicalValue()
-	if err != nil {
-		log.Logger.Error(err)
-	} else {
-		keyHash := sha256.Sum256(key)
-		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	keyObj := v.keyObj
+	if keyObj == nil && v.HelmObj.PublicKey != nil {
+		// entries read back from the log carry the public key, but have not had their external entities fetched
+		var err error
+		if keyObj, err = pki.ParsePublicKey(pki.PGP, v.HelmObj.PublicKey.Content); err != nil {
+			log.Logger.Error(err)
+		}
 	}
+	if keyObj != nil {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
 
-	result = append(result, v.keyObj.EmailAddresses()...)
-
-	chartHash, err := v.provenanceObj.GetChartHash()
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
+	}
 
-	if err != nil {
-		log.Logger.Error(err)
-	} else {
-		result = append(result, chartHash)
+	switch {
+	case v.provenanceObj != nil:
+		chartHash, err := v.provenanceObj.GetChartHash()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			result = append(result, chartHash)
+		}
+	case v.HelmObj.Chart != nil && v.HelmObj.Chart.Hash != nil:
+		// only the hash of the chart is kept in the log, not the provenance file
+		result = append(result, swag.StringValue(v.HelmObj.Chart.Hash.Value))
 	}
 
 	//TODO: Store signature as index
@@ -0,0 +1,390 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/helm"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.2"
+)
+
+func init() {
+	if err := helm.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V002Entry struct {
+	HelmObj                 models.HelmV002Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+	provenanceObj           *helm.Provenance
+}
+
+func (v V002Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V002Entry{}
+}
+
+func (v V002Entry) IndexKeys() []string {
+	var result []string
+
+	// the chart name, version and hash only come from the verified provenance file
+	if err := v.fetchExternalEntities(context.Background()); err != nil {
+		log.Logger.Error(err)
+		return result
+	}
+
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		log.Logger.Error(err)
+	} else {
+		keyHash := sha256.Sum256(key)
+		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	}
+
+	result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+
+	chart := v.HelmObj.Chart
+	if chart.Name != "" {
+		result = append(result, chart.Name, chart.Name+"@"+chart.Version)
+	}
+	if chart.Hash != nil {
+		result = append(result, swag.StringValue(chart.Hash.Algorithm)+":"+swag.StringValue(chart.Hash.Value))
+	}
+	if chart.Oci != nil {
+		result = append(result, swag.StringValue(chart.Oci.Repository))
+	}
+
+	return result
+}
+
+func (v *V002Entry) Unmarshal(pe models.ProposedEntry) error {
+	h, ok := pe.(*models.Helm)
+	if !ok {
+		return errors.New("cannot unmarshal non Helm v0.0.2 type")
+	}
+
+	if err := types.DecodeEntry(h.Spec, &v.HelmObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.HelmObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// cross field validation
+	return v.validate()
+}
+
+func (v V002Entry) hasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.HelmObj.PublicKey != nil && v.HelmObj.PublicKey.URL.String() != "" {
+		return true
+	}
+	if v.HelmObj.Chart != nil && v.HelmObj.Chart.URL.String() != "" {
+		return true
+	}
+	if v.HelmObj.Chart != nil && v.HelmObj.Chart.Provenance != nil && v.HelmObj.Chart.Provenance.URL.String() != "" {
+		return true
+	}
+
+	return false
+}
+
+func readAll(ctx context.Context, url string, content []byte) ([]byte, error) {
+	rc, err := util.FileOrURLReadCloser(ctx, url, content)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+func (v *V002Entry) fetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	artifactFactory, err := pki.NewArtifactFactory(pki.PGP)
+	if err != nil {
+		return err
+	}
+
+	chart := v.HelmObj.Chart
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	var provenanceBytes, keyBytes, chartBytes []byte
+	g.Go(func() error {
+		var err error
+		provenanceBytes, err = readAll(ctx, chart.Provenance.URL.String(), chart.Provenance.Content)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		keyBytes, err = readAll(ctx, v.HelmObj.PublicKey.URL.String(), v.HelmObj.PublicKey.Content)
+		return err
+	})
+	hasArchive := len(chart.Content) > 0 || chart.URL.String() != ""
+	if hasArchive {
+		g.Go(func() error {
+			var err error
+			chartBytes, err = readAll(ctx, chart.URL.String(), chart.Content)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	provenance := helm.Provenance{}
+	if err := provenance.Unmarshal(bytes.NewReader(provenanceBytes)); err != nil {
+		return types.ValidationError(err)
+	}
+	keyObj, err := artifactFactory.NewPublicKey(bytes.NewReader(keyBytes))
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	sigObj, err := artifactFactory.NewSignature(provenance.Block.ArmoredSignature.Body)
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	if err := sigObj.Verify(bytes.NewReader(provenance.Block.Bytes), keyObj); err != nil {
+		return types.ValidationError(err)
+	}
+
+	// the signed provenance file names the chart archive and records its hash
+	chartHash, err := provenance.GetChartFileHash()
+	if err != nil {
+		return types.ValidationError(err)
+	}
+	if hasArchive {
+		h := sha256.Sum256(chartBytes)
+		if computedSHA := hex.EncodeToString(h[:]); computedSHA != chartHash {
+			return types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, chartHash))
+		}
+	}
+	if chart.Oci != nil {
+		if digest := swag.StringValue(chart.Oci.Digest); digest != "sha256:"+chartHash {
+			return types.ValidationError(fmt.Errorf("OCI chart digest %s does not match the provenance file", digest))
+		}
+	}
+
+	// if we get here, all fetches and checks succeeded
+	v.keyObj, v.sigObj, v.provenanceObj = keyObj, sigObj, &provenance
+	chart.Name, chart.Version = provenance.ChartMetadata["name"], provenance.ChartMetadata["version"]
+	chart.Hash = &models.HelmV002SchemaChartHash{
+		Algorithm: swag.String(models.HelmV002SchemaChartHashAlgorithmSha256),
+		Value:     swag.String(chartHash),
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V002Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.HelmV002Schema{}
+	canonicalEntry.ExtraData = v.HelmObj.ExtraData
+
+	keyContent, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.PublicKey = &models.HelmV002SchemaPublicKey{
+		Content: keyContent,
+	}
+
+	sigContent, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	chart := v.HelmObj.Chart
+	// the chart archive and provenance file are not set deliberately
+	canonicalEntry.Chart = &models.HelmV002SchemaChart{
+		Name:    chart.Name,
+		Version: chart.Version,
+		Hash:    chart.Hash,
+		Oci:     chart.Oci,
+		Provenance: &models.HelmV002SchemaChartProvenance{
+			Signature: &models.HelmV002SchemaChartProvenanceSignature{
+				Content: sigContent,
+			},
+		},
+	}
+
+	// wrap in valid object with kind and apiVersion set
+	helmObj := models.Helm{}
+	helmObj.APIVersion = swag.String(APIVERSION)
+	helmObj.Spec = &canonicalEntry
+
+	return json.Marshal(&helmObj)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V002Entry) validate() error {
+	key := v.HelmObj.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	chart := v.HelmObj.Chart
+	if chart == nil {
+		return errors.New("missing chart")
+	}
+
+	sources := 0
+	if len(chart.Content) > 0 {
+		sources++
+	}
+	if chart.URL.String() != "" {
+		sources++
+	}
+	if chart.Oci != nil {
+		sources++
+		if !strings.HasPrefix(swag.StringValue(chart.Oci.Digest), "sha256:") {
+			return errors.New("OCI chart digest must be a sha256 digest")
+		}
+	}
+	if sources > 1 {
+		return errors.New("only one of 'content', 'url' or 'oci' may be specified for chart")
+	}
+
+	provenance := chart.Provenance
+	if provenance == nil {
+		return errors.New("missing provenance")
+	}
+	if provenance.Signature == nil || provenance.Signature.Content == nil {
+		if len(provenance.Content) == 0 && provenance.URL.String() == "" {
+			return errors.New("one of 'content' or 'url' must be specified for provenance")
+		}
+	}
+
+	return nil
+}
+
+func (v V002Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V002Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Helm{}
+	re := V002Entry{}
+
+	// we will need provenance file and public-key
+	re.HelmObj = models.HelmV002Schema{}
+	re.HelmObj.Chart = &models.HelmV002SchemaChart{}
+	re.HelmObj.Chart.Provenance = &models.HelmV002SchemaChartProvenance{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to provenance file (file or URL) must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			re.HelmObj.Chart.Provenance.URL = strfmt.URI(props.ArtifactPath.String())
+		} else {
+			artifactBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.HelmObj.Chart.Provenance.Content = strfmt.Base64(artifactBytes)
+		}
+	} else {
+		re.HelmObj.Chart.Provenance.Content = strfmt.Base64(artifactBytes)
+	}
+
+	re.HelmObj.PublicKey = &models.HelmV002SchemaPublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify the provenance file")
+		}
+		if props.PublicKeyPath.IsAbs() {
+			re.HelmObj.PublicKey.URL = strfmt.URI(props.PublicKeyPath.String())
+		} else {
+			publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.HelmObj.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+		}
+	} else {
+		re.HelmObj.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if re.hasExternalEntities() {
+		if err := re.fetchExternalEntities(ctx); err != nil {
+			return nil, fmt.Errorf("error retrieving external entities: %v", err)
+		}
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.HelmObj
+
+	return &returnVal, nil
+}
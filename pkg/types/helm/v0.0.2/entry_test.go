@@ -0,0 +1,357 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+const chartDigest = "sha256:6dec7ea21e655d5796c1e214cfb75b73428b2abfa2e66c8f7bc64ff4a7b3b29f"
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V002Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V002Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_helm_armor.pub")
+	provenanceBytes, _ := ioutil.ReadFile("../../../../tests/test-0.1.0.tgz.prov")
+	chartBytes, _ := ioutil.ReadFile("../../../../tests/test-0.1.0.tgz")
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var file *[]byte
+			var err error
+
+			switch r.URL.Path {
+			case "/key":
+				file = &keyBytes
+			case "/provenance":
+				file = &provenanceBytes
+			case "/chart":
+				file = &chartBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V002Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key without provenance file",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "provenance content with valid public key",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "provenance content with invalid public key",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(provenanceBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "provenance with matching chart archive",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Content: strfmt.Base64(chartBytes),
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "provenance with chart archive from URL",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						URL: strfmt.URI(testServer.URL + "/chart"),
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							URL: strfmt.URI(testServer.URL + "/provenance"),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "provenance with mismatched chart archive",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Content: strfmt.Base64(keyBytes),
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "provenance with matching OCI digest",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Oci: &models.HelmV002SchemaChartOci{
+							Repository: swag.String("ghcr.io/example/charts/test"),
+							Digest:     swag.String(chartDigest),
+						},
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "provenance with mismatched OCI digest",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Oci: &models.HelmV002SchemaChartOci{
+							Repository: swag.String("ghcr.io/example/charts/test"),
+							Digest:     swag.String("sha256:0000000000000000000000000000000000000000000000000000000000000000"),
+						},
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			hasExtEntities:            false,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "OCI reference with non-sha256 digest",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Oci: &models.HelmV002SchemaChartOci{
+							Repository: swag.String("ghcr.io/example/charts/test"),
+							Digest:     swag.String("sha512:00"),
+						},
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "both chart archive and OCI reference",
+			entry: V002Entry{
+				HelmObj: models.HelmV002Schema{
+					PublicKey: &models.HelmV002SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Chart: &models.HelmV002SchemaChart{
+						Content: strfmt.Base64(chartBytes),
+						Oci: &models.HelmV002SchemaChartOci{
+							Repository: swag.String("ghcr.io/example/charts/test"),
+							Digest:     swag.String(chartDigest),
+						},
+						Provenance: &models.HelmV002SchemaChartProvenance{
+							Content: strfmt.Base64(provenanceBytes),
+						},
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V002Entry{}
+		r := models.Helm{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.HelmObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.hasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		b, err := v.Canonicalize(context.TODO())
+		if (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		} else if err != nil {
+			if _, ok := err.(types.ValidationError); !ok {
+				t.Errorf("canonicalize returned an unexpected error that isn't of type types.ValidationError: %v", err)
+			}
+		}
+		if b != nil {
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Errorf("unexpected err from Unmarshalling canonicalized entry for '%v': %v", tc.caseDesc, err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Errorf("unexpected err from type-specific unmarshalling for '%v': %v", tc.caseDesc, err)
+			}
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_helm_armor.pub")
+	provenanceBytes, _ := ioutil.ReadFile("../../../../tests/test-0.1.0.tgz.prov")
+
+	v := V002Entry{
+		HelmObj: models.HelmV002Schema{
+			PublicKey: &models.HelmV002SchemaPublicKey{
+				Content: strfmt.Base64(keyBytes),
+			},
+			Chart: &models.HelmV002SchemaChart{
+				Oci: &models.HelmV002SchemaChartOci{
+					Repository: swag.String("ghcr.io/example/charts/test"),
+					Digest:     swag.String(chartDigest),
+				},
+				Provenance: &models.HelmV002SchemaChartProvenance{
+					Content: strfmt.Base64(provenanceBytes),
+				},
+			},
+		},
+	}
+
+	keys := v.IndexKeys()
+	for _, want := range []string{"test", "test@0.1.0", chartDigest, "ghcr.io/example/charts/test"} {
+		found := false
+		for _, k := range keys {
+			if k == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected index key %q in %v", want, keys)
+		}
+	}
+}
@@ -27,10 +27,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 
 	"github.com/in-toto/in-toto-golang/in_toto"
 	"github.com/in-toto/in-toto-golang/pkg/ssl"
-	"github.com/spf13/viper"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -58,8 +58,9 @@ func init() {
 
 type V001Entry struct {
 	IntotoObj models.IntotoV001Schema
-	keyObj    pki.PublicKey
+	keys      []*x509.PublicKey
 	env       ssl.Envelope
+	statement *in_toto.Statement
 }
 
 func (v V001Entry) APIVersion() string {
@@ -73,20 +74,34 @@ func NewEntry() types.EntryImpl {
 func (v V001Entry) IndexKeys() []string {
 	var result []string
 
-	h := sha256.Sum256([]byte(v.env.Payload))
-	payloadKey := "sha256:" + hex.EncodeToString(h[:])
-	result = append(result, payloadKey)
+	// the envelope is not part of the canonicalized entry stored in the log
+	if v.env.Payload != "" {
+		h := sha256.Sum256([]byte(v.env.Payload))
+		payloadKey := "sha256:" + hex.EncodeToString(h[:])
+		result = append(result, payloadKey)
+	}
+	for _, k := range v.keys {
+		result = append(result, pki.IdentityIndexKeys(k)...)
+	}
+	if v.env.Payload == "" {
+		return result
+	}
 
 	switch v.env.PayloadType {
 	case in_toto.PayloadType:
-		statement, err := parseStatement(v.env.Payload)
-		if err != nil {
-			log.Logger.Info("invalid id in_toto Statement")
-			return result
+		statement := v.statement
+		if statement == nil {
+			var err error
+			if statement, err = parseStatement(v.env.Payload); err != nil {
+				log.Logger.Info("invalid id in_toto Statement")
+				return result
+			}
 		}
+		// subject digests are indexed the same way as artifact hashes, so searching by an
+		// artifact's hash also finds the attestations about it
 		for _, s := range statement.Subject {
 			for alg, ds := range s.Digest {
-				result = append(result, alg+":"+ds)
+				result = append(result, strings.ToLower(alg+":"+ds))
 			}
 		}
 	default:
@@ -123,13 +138,9 @@ func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
 		return err
 	}
 
-	// Only support x509 signatures for intoto attestations
-	af, err := pki.NewArtifactFactory(pki.X509)
-	if err != nil {
-		return err
-	}
-
-	v.keyObj, err = af.NewPublicKey(bytes.NewReader(*v.IntotoObj.PublicKey))
+	// Only support x509 signatures for intoto attestations; the content may hold several keys or
+	// certificates when the envelope carries more than one signature
+	v.keys, err = parsePublicKeys(*v.IntotoObj.PublicKey)
 	if err != nil {
 		return err
 	}
@@ -138,12 +149,16 @@ func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
 }
 
 func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
-	if v.keyObj == nil {
+	if len(v.keys) == 0 {
 		return nil, errors.New("cannot canonicalze empty key")
 	}
-	pk, err := v.keyObj.CanonicalValue()
-	if err != nil {
-		return nil, err
+	var pk []byte
+	for _, k := range v.keys {
+		c, err := k.CanonicalValue()
+		if err != nil {
+			return nil, err
+		}
+		pk = append(pk, c...)
 	}
 	pkb := strfmt.Base64(pk)
 
@@ -168,22 +183,7 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 
 // validate performs cross-field validation for fields in object
 func (v *V001Entry) validate() error {
-	// TODO handle multiple
-	pk := v.keyObj.(*x509.PublicKey)
-
 	// This also gets called in the CLI, where we won't have this data
-	if v.IntotoObj.Content.Envelope == "" {
-		return nil
-	}
-	vfr, err := signature.LoadVerifier(pk.CryptoPubKey(), crypto.SHA256)
-	if err != nil {
-		return err
-	}
-	sslVerifier, err := ssl.NewEnvelopeSigner(&verifier{v: vfr})
-	if err != nil {
-		return err
-	}
-
 	if v.IntotoObj.Content.Envelope == "" {
 		return nil
 	}
@@ -192,20 +192,46 @@ func (v *V001Entry) validate() error {
 		return err
 	}
 
-	if err := sslVerifier.Verify(&v.env); err != nil {
+	if err := verifyEnvelope(&v.env, v.keys); err != nil {
 		return err
 	}
+
+	if v.env.PayloadType == in_toto.PayloadType {
+		statement, err := parseStatement(v.env.Payload)
+		if err != nil {
+			log.Logger.Infof("envelope payload is not a valid in-toto statement: %v", err)
+			return nil
+		}
+		v.statement = statement
+	}
 	return nil
 }
 
+// PredicateType returns the predicate type of the in-toto statement in the envelope, if any
+func (v V001Entry) PredicateType() string {
+	if v.statement == nil {
+		return ""
+	}
+	return v.statement.PredicateType
+}
+
 func (v *V001Entry) Attestation() (string, []byte) {
-	if len(v.env.Payload) > viper.GetInt("max_attestation_size") {
-		log.Logger.Infof("Skipping attestation storage, size %d is greater than max %d", len(v.env.Payload), viper.GetInt("max_attestation_size"))
+	if max := types.MaxAttestationSize(intoto.KIND); len(v.env.Payload) > max {
+		log.Logger.Infof("Skipping attestation storage, size %d is greater than max %d", len(v.env.Payload), max)
 		return "", nil
 	}
 	return v.env.PayloadType, []byte(v.env.Payload)
 }
 
+// Envelope returns the DSSE envelope of the entry, unless it is too large to store
+func (v *V001Entry) Envelope() []byte {
+	if max := types.MaxAttestationSize(intoto.KIND); len(v.IntotoObj.Content.Envelope) > max {
+		log.Logger.Infof("Skipping envelope storage, size %d is greater than max %d", len(v.IntotoObj.Content.Envelope), max)
+		return nil
+	}
+	return []byte(v.IntotoObj.Content.Envelope)
+}
+
 type verifier struct {
 	s signature.Signer
 	v signature.Verifier
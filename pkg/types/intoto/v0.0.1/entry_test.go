@@ -35,6 +35,7 @@ import (
 	"github.com/in-toto/in-toto-golang/pkg/ssl"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/spf13/viper"
 	"go.uber.org/goleak"
 )
 
@@ -243,3 +244,102 @@ func TestV001Entry_IndexKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestV001Entry_IndexKeysCanonicalized(t *testing.T) {
+	// entries read back from the log have no envelope, only its hash
+	v := V001Entry{}
+	if got := v.IndexKeys(); len(got) != 0 {
+		t.Errorf("V001Entry.IndexKeys() = %v, want none", got)
+	}
+}
+
+func TestV001Entry_MultipleSignatures(t *testing.T) {
+	newKey := func() (*ecdsa.PrivateKey, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Bytes: der, Type: "PUBLIC KEY"})
+	}
+	key1, pub1 := newKey()
+	key2, pub2 := newKey()
+
+	var signers []ssl.SignVerifier
+	for _, k := range []*ecdsa.PrivateKey{key1, key2} {
+		s, err := signature.LoadECDSASigner(k, crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, &verifier{s: s})
+	}
+	es, err := ssl.NewEnvelopeSigner(signers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statement, err := json.Marshal(in_toto.Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Type:          in_toto.StatementInTotoV01,
+			PredicateType: "https://slsa.dev/provenance/v0.1",
+			Subject: []in_toto.Subject{
+				{Name: "artifact", Digest: map[string]string{"sha256": "ABCDEF"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := es.SignPayload(in_toto.PayloadType, statement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		keys    []byte
+		wantErr bool
+	}{
+		{name: "all signers supplied", keys: append(append([]byte{}, pub1...), pub2...)},
+		{name: "one signer missing", keys: pub1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			err := v.Unmarshal(&models.Intoto{
+				Spec: &models.IntotoV001Schema{
+					PublicKey: p(tt.keys),
+					Content:   &models.IntotoV001SchemaContent{Envelope: string(envBytes)},
+				},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := v.PredicateType(); got != "https://slsa.dev/provenance/v0.1" {
+				t.Errorf("PredicateType() = %q", got)
+			}
+			keys := v.IndexKeys()
+			if keys[len(keys)-1] != "sha256:abcdef" {
+				t.Errorf("expected lowercased subject digest in index keys, got %v", keys)
+			}
+
+			viper.Set("max_attestation_size", len(envBytes))
+			if got := v.Envelope(); string(got) != string(envBytes) {
+				t.Errorf("Envelope() = %s, want %s", got, envBytes)
+			}
+			viper.Set("max_attestation_size", len(envBytes)-1)
+			if got := v.Envelope(); got != nil {
+				t.Errorf("expected oversized envelope to be skipped, got %s", got)
+			}
+		})
+	}
+}
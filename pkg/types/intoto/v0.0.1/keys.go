@@ -0,0 +1,117 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intoto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	pkix509 "github.com/sigstore/rekor/pkg/pki/x509"
+)
+
+// parsePublicKeys splits a PEM blob into the keys that may sign an envelope. Each public key or
+// certificate starts a new key, except that a certificate which issued the preceding one is
+// treated as an intermediate of that certificate's chain.
+func parsePublicKeys(b []byte) ([]*pkix509.PublicKey, error) {
+	var groups [][]byte
+	var prev *x509.Certificate
+	for block, rest := pem.Decode(b); block != nil; block, rest = pem.Decode(rest) {
+		encoded := pem.EncodeToMemory(block)
+		var c *x509.Certificate
+		if block.Type == "CERTIFICATE" {
+			var err error
+			if c, err = x509.ParseCertificate(block.Bytes); err != nil {
+				return nil, err
+			}
+			if prev != nil && prev.CheckSignatureFrom(c) == nil {
+				groups[len(groups)-1] = append(groups[len(groups)-1], encoded...)
+				prev = c
+				continue
+			}
+		}
+		groups = append(groups, encoded)
+		prev = c
+	}
+	if len(groups) == 0 {
+		return nil, errors.New("invalid public key: failure decoding PEM")
+	}
+
+	keys := make([]*pkix509.PublicKey, 0, len(groups))
+	for _, g := range groups {
+		k, err := pkix509.NewPublicKey(bytes.NewReader(g))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// verifyEnvelope checks that every signature on env verifies against at least one of keys
+func verifyEnvelope(env *ssl.Envelope, keys []*pkix509.PublicKey) error {
+	if len(env.Signatures) == 0 {
+		return ssl.ErrNoSignature
+	}
+	body, err := decodeDSSE(env.Payload)
+	if err != nil {
+		return err
+	}
+	pae := ssl.PAE(env.PayloadType, string(body))
+
+	verifiers := make([]signature.Verifier, 0, len(keys))
+	for _, k := range keys {
+		v, err := signature.LoadVerifier(k.CryptoPubKey(), crypto.SHA256)
+		if err != nil {
+			return err
+		}
+		verifiers = append(verifiers, v)
+	}
+
+	for i, s := range env.Signatures {
+		sig, err := decodeDSSE(s.Sig)
+		if err != nil {
+			return err
+		}
+		verified := false
+		for _, v := range verifiers {
+			if v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(pae)) == nil {
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			return fmt.Errorf("envelope signature %d does not verify against any supplied public key", i)
+		}
+	}
+	return nil
+}
+
+// decodeDSSE accepts both standard and URL-safe base64, as allowed by the DSSE specification
+func decodeDSSE(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return base64.URLEncoding.DecodeString(s)
+	}
+	return b, nil
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -61,6 +62,9 @@ type V001Entry struct {
 	jarObj                  *jarutils.JarSignature
 	keyObj                  pki.PublicKey
 	sigObj                  pki.Signature
+	// signers beyond the first signature block in the archive, in archive order
+	additionalKeyObjs []pki.PublicKey
+	additionalSigObjs []pki.Signature
 }
 
 func (v V001Entry) APIVersion() string {
@@ -81,12 +85,28 @@ func (v V001Entry) IndexKeys() []string {
 		}
 	}
 
-	key, err := v.keyObj.CanonicalValue()
-	if err != nil {
-		log.Logger.Error(err)
-	} else {
+	// the signer's key is only known once the archive has been fetched, which entries read back from the log are not
+	if v.keyObj != nil {
+		key, err := v.keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	for _, keyObj := range v.additionalKeyObjs {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+			continue
+		}
 		keyHash := sha256.Sum256(key)
 		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
 	}
 
 	if v.JARModel.Archive.Hash != nil {
@@ -166,37 +186,57 @@ func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
 
 	// this ensures that the JAR is signed and the signature verifies, as
 	// well as checks that the hashes in the signed manifest are all valid
-	jarObj, err := jarutils.Verify(zipReader, false)
+	jarObjs, err := jarutils.Verify(zipReader, false)
 	if err != nil {
 		return types.ValidationError(err)
 	}
-	switch len(jarObj) {
-	case 0:
+	if len(jarObjs) == 0 {
 		return types.ValidationError(errors.New("no signatures detected in JAR archive"))
-	case 1:
-	default:
-		return types.ValidationError(errors.New("multiple signatures detected in JAR; unable to process"))
 	}
-	v.jarObj = jarObj[0]
 
-	af, err := pki.NewArtifactFactory(pki.PKCS7)
-	if err != nil {
-		return err
-	}
-	// we need to find and extract the PKCS7 bundle from the JAR file manually
-	sigPKCS7, err := extractPKCS7SignatureFromJAR(zipReader)
+	// we need to find and extract the PKCS7 bundles from the JAR file manually; the
+	// verifier does not report signatures in a stable order, so the order of the
+	// signature blocks within the archive is used instead
+	sigBlocks, err := extractPKCS7SignaturesFromJAR(zipReader)
 	if err != nil {
 		return types.ValidationError(err)
 	}
+	if len(sigBlocks) != len(jarObjs) {
+		return types.ValidationError(fmt.Errorf("found %d signature blocks in JAR but %d verified signatures", len(sigBlocks), len(jarObjs)))
+	}
 
-	v.keyObj, err = af.NewPublicKey(bytes.NewReader(sigPKCS7))
+	af, err := pki.NewArtifactFactory(pki.PKCS7)
 	if err != nil {
-		return types.ValidationError(err)
+		return err
 	}
+	v.additionalKeyObjs = nil
+	v.additionalSigObjs = nil
+	for i, sigPKCS7 := range sigBlocks {
+		jarObj := findJarSignature(jarObjs, sigPKCS7)
+		if jarObj == nil {
+			return types.ValidationError(errors.New("signature block in JAR does not match any verified signature"))
+		}
+		if err := checkTimestamp(jarObj); err != nil {
+			return types.ValidationError(err)
+		}
 
-	v.sigObj, err = af.NewSignature(bytes.NewReader(sigPKCS7))
-	if err != nil {
-		return types.ValidationError(err)
+		keyObj, err := af.NewPublicKey(bytes.NewReader(sigPKCS7))
+		if err != nil {
+			return types.ValidationError(err)
+		}
+		sigObj, err := af.NewSignature(bytes.NewReader(sigPKCS7))
+		if err != nil {
+			return types.ValidationError(err)
+		}
+
+		if i == 0 {
+			v.jarObj = jarObj
+			v.keyObj = keyObj
+			v.sigObj = sigObj
+			continue
+		}
+		v.additionalKeyObjs = append(v.additionalKeyObjs, keyObj)
+		v.additionalSigObjs = append(v.additionalSigObjs, sigObj)
 	}
 
 	// if we get here, all goroutines succeeded without error
@@ -242,6 +282,14 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 	}
 	canonicalEntry.Signature.Content = sigContent
 
+	for _, sigObj := range v.additionalSigObjs {
+		sigContent, err := sigObj.CanonicalValue()
+		if err != nil {
+			return nil, err
+		}
+		canonicalEntry.AdditionalSignatures = append(canonicalEntry.AdditionalSignatures, string(sigContent))
+	}
+
 	canonicalEntry.Archive = &models.JarV001SchemaArchive{}
 	canonicalEntry.Archive.Hash = &models.JarV001SchemaArchiveHash{}
 	canonicalEntry.Archive.Hash.Algorithm = v.JARModel.Archive.Hash.Algorithm
@@ -285,8 +333,10 @@ func (v V001Entry) validate() error {
 	return nil
 }
 
-// extractPKCS7SignatureFromJAR extracts the first signature file from the JAR and returns it
-func extractPKCS7SignatureFromJAR(inz *zip.Reader) ([]byte, error) {
+// extractPKCS7SignaturesFromJAR extracts every signature file from the JAR and returns them in
+// the order they appear in the archive
+func extractPKCS7SignaturesFromJAR(inz *zip.Reader) ([][]byte, error) {
+	var sigs [][]byte
 	for _, f := range inz.File {
 		dir, name := path.Split(strings.ToUpper(f.Name))
 		if dir != "META-INF/" || name == "" {
@@ -309,10 +359,57 @@ func extractPKCS7SignatureFromJAR(inz *zip.Reader) ([]byte, error) {
 			if err = fileReader.Close(); err != nil {
 				return nil, err
 			}
-			return contents, nil
+			sigs = append(sigs, contents)
+		}
+	}
+	if len(sigs) == 0 {
+		return nil, errors.New("unable to locate signature in JAR file")
+	}
+	return sigs, nil
+}
+
+// findJarSignature returns the verified signature that was parsed from the given signature file
+func findJarSignature(jarObjs []*jarutils.JarSignature, sigPKCS7 []byte) *jarutils.JarSignature {
+	for _, jarObj := range jarObjs {
+		if bytes.Equal(jarObj.Raw, sigPKCS7) {
+			return jarObj
+		}
+	}
+	return nil
+}
+
+// checkTimestamp validates the RFC 3161 timestamp token embedded in a signature, if there is one.
+// The integrity of the token and its message imprint have already been checked when the JAR was
+// verified; this ensures the token was issued by a timestamping authority while the signing
+// certificate was valid.
+func checkTimestamp(jarObj *jarutils.JarSignature) error {
+	cs := jarObj.CounterSignature
+	if cs == nil {
+		return nil
+	}
+	if cs.Certificate == nil {
+		return errors.New("timestamp token does not include the certificate of the timestamping authority")
+	}
+	timestamping := false
+	for _, usage := range cs.Certificate.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageTimeStamping {
+			timestamping = true
 		}
 	}
-	return nil, errors.New("unable to locate signature in JAR file")
+	if !timestamping {
+		return errors.New("timestamp token was not issued by a certificate valid for timestamping")
+	}
+	signingTime := cs.SigningTime
+	if signingTime.Before(cs.Certificate.NotBefore) || signingTime.After(cs.Certificate.NotAfter) {
+		return fmt.Errorf("timestamp %v is outside the validity period of the timestamping certificate", signingTime)
+	}
+	if jarObj.Certificate == nil {
+		return errors.New("signature does not include a signing certificate")
+	}
+	if signingTime.Before(jarObj.Certificate.NotBefore) || signingTime.After(jarObj.Certificate.NotAfter) {
+		return fmt.Errorf("timestamp %v is outside the validity period of the signing certificate", signingTime)
+	}
+	return nil
 }
 
 func (v V001Entry) Attestation() (string, []byte) {
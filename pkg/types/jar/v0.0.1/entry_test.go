@@ -19,17 +19,22 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/sassoftware/relic/lib/pkcs9"
+	jarutils "github.com/sassoftware/relic/lib/signjar"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/types"
 	"go.uber.org/goleak"
@@ -56,6 +61,7 @@ func TestCrossFieldValidation(t *testing.T) {
 	}
 
 	jarBytes, _ := ioutil.ReadFile("../../../../tests/test.jar")
+	multiSignerBytes, _ := ioutil.ReadFile("../../../../tests/test_multisigner.jar")
 
 	h := sha256.Sum256(jarBytes)
 	dataSHA := hex.EncodeToString(h[:])
@@ -182,6 +188,19 @@ func TestCrossFieldValidation(t *testing.T) {
 			expectUnmarshalSuccess:    true,
 			expectCanonicalizeSuccess: false,
 		},
+		{
+			caseDesc: "archive with multiple signers",
+			entry: V001Entry{
+				JARModel: models.JarV001Schema{
+					Archive: &models.JarV001SchemaArchive{
+						Content: strfmt.Base64(multiSignerBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
 		{
 			caseDesc: "valid obj with extradata",
 			entry: V001Entry{
@@ -238,3 +257,92 @@ func TestCrossFieldValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestMultipleSigners(t *testing.T) {
+	jarBytes, err := ioutil.ReadFile("../../../../tests/test_multisigner.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := &V001Entry{
+		JARModel: models.JarV001Schema{
+			Archive: &models.JarV001SchemaArchive{
+				Content: strfmt.Base64(jarBytes),
+			},
+		},
+	}
+	b, err := v.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error canonicalizing multi-signer JAR: %v", err)
+	}
+
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical, ok := pe.(*models.Jar)
+	if !ok {
+		t.Fatalf("unexpected canonical entry type %T", pe)
+	}
+	spec := &models.JarV001Schema{}
+	if err := types.DecodeEntry(canonical.Spec, spec); err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.AdditionalSignatures) != 1 {
+		t.Fatalf("expected 1 additional signature, got %d", len(spec.AdditionalSignatures))
+	}
+
+	keys := v.IndexKeys()
+	for _, want := range []string{
+		"subject:cn=rekor test jar signer,o=sigstore",
+		"subject:cn=rekor test jar release signer,o=sigstore",
+	} {
+		found := false
+		for _, k := range keys {
+			if strings.ToLower(k) == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected index key %q in %v", want, keys)
+		}
+	}
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	signer := &x509.Certificate{NotBefore: notBefore, NotAfter: notAfter}
+	tsa := &x509.Certificate{NotBefore: notBefore, NotAfter: notAfter, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping}}
+	codeSigning := &x509.Certificate{NotBefore: notBefore, NotAfter: notAfter, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}}
+
+	jarSig := func(tsaCert *x509.Certificate, signingTime time.Time) *jarutils.JarSignature {
+		s := &jarutils.JarSignature{}
+		s.Certificate = signer
+		if tsaCert != nil || !signingTime.IsZero() {
+			s.CounterSignature = &pkcs9.CounterSignature{SigningTime: signingTime}
+			s.CounterSignature.Certificate = tsaCert
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		sig     *jarutils.JarSignature
+		wantErr bool
+	}{
+		{name: "no timestamp", sig: jarSig(nil, time.Time{})},
+		{name: "valid timestamp", sig: jarSig(tsa, notBefore.Add(time.Hour))},
+		{name: "missing timestamping certificate", sig: jarSig(nil, notBefore.Add(time.Hour)), wantErr: true},
+		{name: "certificate not valid for timestamping", sig: jarSig(codeSigning, notBefore.Add(time.Hour)), wantErr: true},
+		{name: "timestamp before signing certificate validity", sig: jarSig(tsa, notBefore.Add(-time.Hour)), wantErr: true},
+		{name: "timestamp after signing certificate validity", sig: jarSig(tsa, notAfter.Add(time.Hour)), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkTimestamp(tt.sig); (err != nil) != tt.wantErr {
+				t.Errorf("checkTimestamp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
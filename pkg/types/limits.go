@@ -0,0 +1,70 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// attestationSizeLimits parses the max_attestation_size_by_type setting, a list of kind=bytes
+func attestationSizeLimits() (map[string]int, error) {
+	limits := map[string]int{}
+	for _, l := range viper.GetStringSlice("max_attestation_size_by_type") {
+		i := strings.IndexByte(l, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("attestation size limit %q is not of the form kind=bytes", l)
+		}
+		size, err := strconv.Atoi(l[i+1:])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("attestation size limit %q is not of the form kind=bytes", l)
+		}
+		limits[l[:i]] = size
+	}
+	return limits, nil
+}
+
+// MaxAttestationSize returns the size in bytes of the largest attestation stored for entries of kind:
+// its limit in max_attestation_size_by_type, or else max_attestation_size
+func MaxAttestationSize(kind string) int {
+	limits, err := attestationSizeLimits()
+	if err != nil {
+		return viper.GetInt("max_attestation_size")
+	}
+	if size, ok := limits[kind]; ok {
+		return size
+	}
+	return viper.GetInt("max_attestation_size")
+}
+
+// LargestAttestationSize returns the size in bytes of the largest attestation stored for any kind of entry,
+// or an error if the limits are misconfigured
+func LargestAttestationSize() (int, error) {
+	limits, err := attestationSizeLimits()
+	if err != nil {
+		return 0, err
+	}
+	largest := viper.GetInt("max_attestation_size")
+	for _, size := range limits {
+		if size > largest {
+			largest = size
+		}
+	}
+	return largest, nil
+}
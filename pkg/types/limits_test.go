@@ -0,0 +1,47 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestAttestationSizeLimits(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("max_attestation_size", 100)
+	viper.Set("max_attestation_size_by_type", []string{"intoto=1000", "cose=10"})
+
+	for kind, want := range map[string]int{"intoto": 1000, "cose": 10, "rekord": 100} {
+		if got := MaxAttestationSize(kind); got != want {
+			t.Errorf("MaxAttestationSize(%s) = %d, want %d", kind, got, want)
+		}
+	}
+	if got, err := LargestAttestationSize(); err != nil || got != 1000 {
+		t.Errorf("LargestAttestationSize() = %d, %v", got, err)
+	}
+
+	for _, invalid := range []string{"intoto", "intoto=big", "intoto=-1"} {
+		viper.Set("max_attestation_size_by_type", []string{invalid})
+		if _, err := LargestAttestationSize(); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+		if got := MaxAttestationSize("intoto"); got != 100 {
+			t.Errorf("MaxAttestationSize() = %d with invalid limits, want the default", got)
+		}
+	}
+}
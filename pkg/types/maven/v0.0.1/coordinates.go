@@ -0,0 +1,87 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maven
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// identifierPattern matches the characters Maven permits in group and artifact identifiers
+var identifierPattern = regexp.MustCompile(`^[A-Za-z0-9_\-.]+$`)
+
+func validateCoordinates(c *models.MavenV001SchemaCoordinates) error {
+	if c == nil {
+		return errors.New("missing coordinates")
+	}
+	if !identifierPattern.MatchString(swag.StringValue(c.GroupID)) {
+		return fmt.Errorf("invalid groupId %q", swag.StringValue(c.GroupID))
+	}
+	if !identifierPattern.MatchString(swag.StringValue(c.ArtifactID)) {
+		return fmt.Errorf("invalid artifactId %q", swag.StringValue(c.ArtifactID))
+	}
+	if v := swag.StringValue(c.Version); v == "" || strings.ContainsAny(v, ":/\\ \t\r\n") {
+		return fmt.Errorf("invalid version %q", v)
+	}
+	return nil
+}
+
+// repositoryRoots are the path segments the Maven repository layout starts after, as used by
+// Maven Central (https://repo1.maven.org/maven2/) and local repositories (~/.m2/repository/)
+var repositoryRoots = map[string]bool{"maven2": true, "repository": true}
+
+// coordinatesFromPath derives the coordinates of an artifact stored in the Maven repository layout,
+// i.e. <root>/<groupId as path>/<artifactId>/<version>/<artifactId>-<version>[-<classifier>].<extension>
+func coordinatesFromPath(p string) (*models.MavenV001SchemaCoordinates, error) {
+	segments := strings.Split(path.Clean(p), "/")
+	n := len(segments)
+	if n < 4 {
+		return nil, fmt.Errorf("%s is not in a Maven repository layout", p)
+	}
+	file, version, artifactID := segments[n-1], segments[n-2], segments[n-3]
+	if !strings.HasPrefix(file, artifactID+"-"+version) {
+		return nil, fmt.Errorf("%s is not in a Maven repository layout", p)
+	}
+
+	// the first root is used, since group identifiers may contain the same names
+	root := -1
+	for i := 0; i < n-3; i++ {
+		if repositoryRoots[segments[i]] {
+			root = i
+			break
+		}
+	}
+	if root < 0 || root+1 == n-3 {
+		return nil, fmt.Errorf("cannot determine the groupId of %s", p)
+	}
+
+	c := &models.MavenV001SchemaCoordinates{
+		GroupID:    swag.String(strings.Join(segments[root+1:n-3], ".")),
+		ArtifactID: swag.String(artifactID),
+		Version:    swag.String(version),
+	}
+	if err := validateCoordinates(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
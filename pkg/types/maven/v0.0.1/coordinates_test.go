@@ -0,0 +1,51 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maven
+
+import (
+	"testing"
+
+	"github.com/go-openapi/swag"
+)
+
+func TestCoordinatesFromPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "/maven2/org/apache/maven/maven-core/3.9.0/maven-core-3.9.0.jar", want: "org.apache.maven:maven-core:3.9.0"},
+		{path: "/home/user/.m2/repository/dev/sigstore/sigstore-java/1.0.0/sigstore-java-1.0.0-sources.jar", want: "dev.sigstore:sigstore-java:1.0.0"},
+		{path: "/maven2/org/example/repository/tool/1.0/tool-1.0.pom", want: "org.example.repository:tool:1.0"},
+		{path: "/maven2/maven-core/3.9.0/maven-core-3.9.0.jar", wantErr: true},
+		{path: "/maven2/org/apache/maven/maven-core/3.9.0/other-3.9.0.jar", wantErr: true},
+		{path: "/tmp/org/apache/maven/maven-core/3.9.0/maven-core-3.9.0.jar", wantErr: true},
+		{path: "maven-core-3.9.0.jar", wantErr: true},
+	}
+	for _, tt := range tests {
+		c, err := coordinatesFromPath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("coordinatesFromPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got := swag.StringValue(c.GroupID) + ":" + swag.StringValue(c.ArtifactID) + ":" + swag.StringValue(c.Version); got != tt.want {
+			t.Errorf("coordinatesFromPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,492 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maven
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/maven"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := maven.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	MavenModel              models.MavenV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.hasExternalEntities() {
+		if err := v.fetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	keyObj := v.keyObj
+	if keyObj == nil && v.MavenModel.PublicKey != nil {
+		// entries read back from the log carry the public key, but have not had their external entities fetched
+		var err error
+		if keyObj, err = pki.ParsePublicKey(pki.PGP, v.MavenModel.PublicKey.Content); err != nil {
+			log.Logger.Error(err)
+		}
+	}
+	if keyObj != nil {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
+
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
+	}
+
+	if c := v.MavenModel.Coordinates; c != nil {
+		ga := swag.StringValue(c.GroupID) + ":" + swag.StringValue(c.ArtifactID)
+		result = append(result, ga, ga+":"+swag.StringValue(c.Version))
+	}
+
+	if v.MavenModel.Artifact.Hash != nil {
+		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.MavenModel.Artifact.Hash.Algorithm, *v.MavenModel.Artifact.Hash.Value))
+		result = append(result, hashKey)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	m, ok := pe.(*models.Maven)
+	if !ok {
+		return errors.New("cannot unmarshal non Maven v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(m.Spec, &v.MavenModel); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.MavenModel.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+func (v V001Entry) hasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.MavenModel.Artifact != nil && v.MavenModel.Artifact.URL.String() != "" {
+		return true
+	}
+	if v.MavenModel.PublicKey != nil && v.MavenModel.PublicKey.URL.String() != "" {
+		return true
+	}
+	if v.MavenModel.Signature != nil && v.MavenModel.Signature.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.validate(); err != nil {
+		return types.ValidationError(err)
+	}
+
+	artifactFactory, err := pki.NewArtifactFactory(pki.PGP)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	hashR, hashW := io.Pipe()
+	sigR, sigW := io.Pipe()
+	defer hashR.Close()
+	defer sigR.Close()
+
+	closePipesOnError := func(err error) error {
+		pipeReaders := []*io.PipeReader{hashR, sigR}
+		pipeWriters := []*io.PipeWriter{hashW, sigW}
+		for idx := range pipeReaders {
+			if e := pipeReaders[idx].CloseWithError(err); e != nil {
+				log.Logger.Error(fmt.Errorf("error closing pipe: %w", e))
+			}
+			if e := pipeWriters[idx].CloseWithError(err); e != nil {
+				log.Logger.Error(fmt.Errorf("error closing pipe: %w", e))
+			}
+		}
+		return err
+	}
+
+	oldSHA := ""
+	if v.MavenModel.Artifact.Hash != nil && v.MavenModel.Artifact.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.MavenModel.Artifact.Hash.Value)
+	}
+
+	g.Go(func() error {
+		defer hashW.Close()
+		defer sigW.Close()
+
+		dataReadCloser, err := util.FileOrURLReadCloser(ctx, v.MavenModel.Artifact.URL.String(), v.MavenModel.Artifact.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer dataReadCloser.Close()
+
+		/* #nosec G110 */
+		if _, err := io.Copy(io.MultiWriter(hashW, sigW), dataReadCloser); err != nil {
+			return closePipesOnError(err)
+		}
+		return nil
+	})
+
+	hashResult := make(chan string)
+
+	g.Go(func() error {
+		defer close(hashResult)
+		hasher := sha256.New()
+
+		if _, err := io.Copy(hasher, hashR); err != nil {
+			return closePipesOnError(err)
+		}
+
+		computedSHA := hex.EncodeToString(hasher.Sum(nil))
+		if oldSHA != "" && computedSHA != oldSHA {
+			return closePipesOnError(types.ValidationError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case hashResult <- computedSHA:
+			return nil
+		}
+	})
+
+	sigResult := make(chan pki.Signature)
+
+	g.Go(func() error {
+		defer close(sigResult)
+
+		sigReadCloser, err := util.FileOrURLReadCloser(ctx, v.MavenModel.Signature.URL.String(),
+			v.MavenModel.Signature.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer sigReadCloser.Close()
+
+		signature, err := artifactFactory.NewSignature(sigReadCloser)
+		if err != nil {
+			return closePipesOnError(types.ValidationError(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sigResult <- signature:
+			return nil
+		}
+	})
+
+	keyResult := make(chan pki.PublicKey)
+
+	g.Go(func() error {
+		defer close(keyResult)
+
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.MavenModel.PublicKey.URL.String(),
+			v.MavenModel.PublicKey.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer keyReadCloser.Close()
+
+		key, err := artifactFactory.NewPublicKey(keyReadCloser)
+		if err != nil {
+			return closePipesOnError(types.ValidationError(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case keyResult <- key:
+			return nil
+		}
+	})
+
+	g.Go(func() error {
+		v.keyObj, v.sigObj = <-keyResult, <-sigResult
+
+		if v.keyObj == nil || v.sigObj == nil {
+			return closePipesOnError(errors.New("failed to read signature or public key"))
+		}
+
+		var err error
+		if err = v.sigObj.Verify(sigR, v.keyObj); err != nil {
+			return closePipesOnError(types.ValidationError(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			return nil
+		}
+	})
+
+	computedSHA := <-hashResult
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// if we get here, all goroutines succeeded without error
+	if oldSHA == "" {
+		v.MavenModel.Artifact.Hash = &models.MavenV001SchemaArtifactHash{}
+		v.MavenModel.Artifact.Hash.Algorithm = swag.String(models.MavenV001SchemaArtifactHashAlgorithmSha256)
+		v.MavenModel.Artifact.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.fetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.keyObj == nil || v.sigObj == nil {
+		return nil, errors.New("key and signature objects not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.MavenV001Schema{}
+
+	var err error
+	// need to canonicalize key and signature content
+	canonicalEntry.PublicKey = &models.MavenV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.Signature = &models.MavenV001SchemaSignature{}
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Coordinates = v.MavenModel.Coordinates
+
+	canonicalEntry.Artifact = &models.MavenV001SchemaArtifact{}
+	canonicalEntry.Artifact.Hash = &models.MavenV001SchemaArtifactHash{}
+	canonicalEntry.Artifact.Hash.Algorithm = v.MavenModel.Artifact.Hash.Algorithm
+	canonicalEntry.Artifact.Hash.Value = v.MavenModel.Artifact.Hash.Value
+	// data content is not set deliberately
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.MavenModel.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	m := models.Maven{}
+	m.APIVersion = swag.String(APIVERSION)
+	m.Spec = &canonicalEntry
+
+	return json.Marshal(&m)
+}
+
+// validate performs cross-field validation for fields in object
+func (v V001Entry) validate() error {
+	if err := validateCoordinates(v.MavenModel.Coordinates); err != nil {
+		return err
+	}
+
+	sig := v.MavenModel.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := v.MavenModel.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	artifact := v.MavenModel.Artifact
+	if artifact == nil {
+		return errors.New("missing artifact")
+	}
+
+	hash := artifact.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	} else if len(artifact.Content) == 0 && artifact.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for artifact")
+	}
+
+	return nil
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func readProperty(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Maven{}
+	re := V001Entry{}
+
+	// we will need the artifact, its coordinates, signature and public key
+	re.MavenModel = models.MavenV001Schema{}
+	re.MavenModel.Artifact = &models.MavenV001SchemaArtifact{}
+
+	if props.ArtifactPath == nil {
+		return nil, errors.New("path to artifact (file or URL) in a Maven repository must be specified")
+	}
+	var err error
+	re.MavenModel.Coordinates, err = coordinatesFromPath(props.ArtifactPath.Path)
+	if err != nil {
+		return nil, err
+	}
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath.IsAbs() {
+			re.MavenModel.Artifact.URL = strfmt.URI(props.ArtifactPath.String())
+			if props.ArtifactHash != "" {
+				re.MavenModel.Artifact.Hash = &models.MavenV001SchemaArtifactHash{
+					Algorithm: swag.String(models.MavenV001SchemaArtifactHashAlgorithmSha256),
+					Value:     swag.String(props.ArtifactHash),
+				}
+			}
+		} else {
+			if artifactBytes, err = readProperty(props.ArtifactPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.MavenModel.Artifact.Content = strfmt.Base64(artifactBytes)
+		}
+	} else {
+		re.MavenModel.Artifact.Content = strfmt.Base64(artifactBytes)
+	}
+
+	re.MavenModel.Signature = &models.MavenV001SchemaSignature{}
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a detached signature must be provided")
+		}
+		if props.SignaturePath.IsAbs() {
+			re.MavenModel.Signature.URL = strfmt.URI(props.SignaturePath.String())
+		} else {
+			if sigBytes, err = readProperty(props.SignaturePath.Path); err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.MavenModel.Signature.Content = strfmt.Base64(sigBytes)
+		}
+	} else {
+		re.MavenModel.Signature.Content = strfmt.Base64(sigBytes)
+	}
+
+	re.MavenModel.PublicKey = &models.MavenV001SchemaPublicKey{}
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify the signature")
+		}
+		if props.PublicKeyPath.IsAbs() {
+			re.MavenModel.PublicKey.URL = strfmt.URI(props.PublicKeyPath.String())
+		} else {
+			if publicKeyBytes, err = readProperty(props.PublicKeyPath.Path); err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.MavenModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+		}
+	} else {
+		re.MavenModel.PublicKey.Content = strfmt.Base64(publicKeyBytes)
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	if re.hasExternalEntities() {
+		if err := re.fetchExternalEntities(ctx); err != nil {
+			return nil, fmt.Errorf("error retrieving external entities: %v", err)
+		}
+	}
+
+	returnVal.APIVersion = swag.String(re.APIVersion())
+	returnVal.Spec = re.MavenModel
+
+	return &returnVal, nil
+}
@@ -0,0 +1,287 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maven
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func coordinates() *models.MavenV001SchemaCoordinates {
+	return &models.MavenV001SchemaCoordinates{
+		GroupID:    swag.String("dev.sigstore"),
+		ArtifactID: swag.String("test"),
+		Version:    swag.String("1.0.0"),
+	}
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_maven.pub")
+	otherKeyBytes, _ := ioutil.ReadFile("../../../../tests/test_deb.pub")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test.jar")
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test.jar.asc")
+
+	h := sha256.Sum256(dataBytes)
+	dataSHA := hex.EncodeToString(h[:])
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var file []byte
+			switch r.URL.Path {
+			case "/key":
+				file = keyBytes
+			case "/data":
+				file = dataBytes
+			case "/signature":
+				file = sigBytes
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(file)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "invalid coordinates",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: &models.MavenV001SchemaCoordinates{
+						GroupID:    swag.String("dev sigstore"),
+						ArtifactID: swag.String("test"),
+						Version:    swag.String("1.0.0"),
+					},
+					Artifact:  &models.MavenV001SchemaArtifact{Content: strfmt.Base64(dataBytes)},
+					Signature: &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+					PublicKey: &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "missing signature",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact:    &models.MavenV001SchemaArtifact{Content: strfmt.Base64(dataBytes)},
+					PublicKey:   &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "artifact url without hash",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact:    &models.MavenV001SchemaArtifact{URL: strfmt.URI(testServer.URL + "/data")},
+					Signature:   &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+					PublicKey:   &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "everything from urls",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact: &models.MavenV001SchemaArtifact{
+						URL: strfmt.URI(testServer.URL + "/data"),
+						Hash: &models.MavenV001SchemaArtifactHash{
+							Algorithm: swag.String(models.MavenV001SchemaArtifactHashAlgorithmSha256),
+							Value:     swag.String(dataSHA),
+						},
+					},
+					Signature: &models.MavenV001SchemaSignature{URL: strfmt.URI(testServer.URL + "/signature")},
+					PublicKey: &models.MavenV001SchemaPublicKey{URL: strfmt.URI(testServer.URL + "/key")},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "inline content",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact:    &models.MavenV001SchemaArtifact{Content: strfmt.Base64(dataBytes)},
+					Signature:   &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+					PublicKey:   &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "hash mismatch",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact: &models.MavenV001SchemaArtifact{
+						Content: strfmt.Base64(dataBytes),
+						Hash: &models.MavenV001SchemaArtifactHash{
+							Algorithm: swag.String(models.MavenV001SchemaArtifactHashAlgorithmSha256),
+							Value:     swag.String(dataSHA[:60] + "0000"),
+						},
+					},
+					Signature: &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+					PublicKey: &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "wrong public key",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact:    &models.MavenV001SchemaArtifact{Content: strfmt.Base64(dataBytes)},
+					Signature:   &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+					PublicKey:   &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(otherKeyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature over other data",
+			entry: V001Entry{
+				MavenModel: models.MavenV001Schema{
+					Coordinates: coordinates(),
+					Artifact:    &models.MavenV001SchemaArtifact{Content: strfmt.Base64(keyBytes)},
+					Signature:   &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+					PublicKey:   &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Maven{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.MavenModel,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.hasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		b, err := v.Canonicalize(context.TODO())
+		if (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		} else if err != nil {
+			if _, ok := err.(types.ValidationError); !ok {
+				t.Errorf("canonicalize returned an unexpected error that isn't of type types.ValidationError: %v", err)
+			}
+		}
+		if b != nil {
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Errorf("unexpected err from Unmarshalling canonicalized entry for '%v': %v", tc.caseDesc, err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Errorf("unexpected err from type-specific unmarshalling for '%v': %v", tc.caseDesc, err)
+			}
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_maven.pub")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test.jar")
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test.jar.asc")
+
+	v := V001Entry{
+		MavenModel: models.MavenV001Schema{
+			Coordinates: coordinates(),
+			Artifact:    &models.MavenV001SchemaArtifact{Content: strfmt.Base64(dataBytes)},
+			Signature:   &models.MavenV001SchemaSignature{Content: strfmt.Base64(sigBytes)},
+			PublicKey:   &models.MavenV001SchemaPublicKey{Content: strfmt.Base64(keyBytes)},
+		},
+	}
+	if err := v.fetchExternalEntities(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h := sha256.Sum256(dataBytes)
+	keys := v.IndexKeys()
+	for _, want := range []string{"dev.sigstore:test", "dev.sigstore:test:1.0.0", "sha256:" + hex.EncodeToString(h[:]), "maven@example.com"} {
+		found := false
+		for _, k := range keys {
+			found = found || k == want
+		}
+		if !found {
+			t.Errorf("IndexKeys() = %v, missing %v", keys, want)
+		}
+	}
+}
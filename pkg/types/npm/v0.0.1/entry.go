@@ -0,0 +1,253 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/npm"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := npm.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	NpmObj     models.NpmV001Schema
+	provenance *provenance
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if pkg := v.NpmObj.Package; pkg != nil {
+		result = append(result, fmt.Sprintf("%s@%s", swag.StringValue(pkg.Name), swag.StringValue(pkg.Version)))
+		if h, err := parseIntegrity(swag.StringValue(pkg.Integrity)); err == nil {
+			result = append(result, h)
+		}
+	}
+
+	if v.provenance != nil {
+		result = append(result, pki.IdentityIndexKeys(v.provenance.certificate)...)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Npm)
+	if !ok {
+		return errors.New("cannot unmarshal non npm v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.NpmObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.NpmObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object; signatures are only verified
+// when the entry is canonicalized, since registry signatures need the keys configured on the server
+func (v V001Entry) validate() error {
+	pkg := v.NpmObj.Package
+	if pkg == nil {
+		return errors.New("missing package")
+	}
+	if swag.StringValue(pkg.Name) == "" || swag.StringValue(pkg.Version) == "" {
+		return errors.New("package name and version must be specified")
+	}
+	if _, err := parseIntegrity(swag.StringValue(pkg.Integrity)); err != nil {
+		return err
+	}
+	if v.NpmObj.RegistrySignature == nil && v.NpmObj.Provenance == nil {
+		return errors.New("a registry signature or provenance attestation must be specified")
+	}
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	pkg := v.NpmObj.Package
+	name, version, integrity := swag.StringValue(pkg.Name), swag.StringValue(pkg.Version), swag.StringValue(pkg.Integrity)
+
+	canonicalEntry := models.NpmV001Schema{
+		Package:   pkg,
+		ExtraData: v.NpmObj.ExtraData,
+	}
+
+	if rs := v.NpmObj.RegistrySignature; rs != nil {
+		keys, err := trustedRegistryKeys()
+		if err != nil {
+			return nil, types.ValidationError(err)
+		}
+		if err := verifyRegistrySignature(keys, *rs.Keyid, *rs.Sig, registrySignedMessage(name, version, integrity)); err != nil {
+			return nil, types.ValidationError(fmt.Errorf("verifying npm registry signature: %w", err))
+		}
+		canonicalEntry.RegistrySignature = rs
+	}
+
+	if p := v.NpmObj.Provenance; p != nil {
+		if len(p.Content) == 0 {
+			return nil, types.ValidationError(errors.New("provenance bundle content must be specified"))
+		}
+		integrityHash, err := parseIntegrity(integrity)
+		if err != nil {
+			return nil, types.ValidationError(err)
+		}
+		prov, err := verifyProvenance(p.Content, name, version, integrityHash)
+		if err != nil {
+			return nil, types.ValidationError(fmt.Errorf("verifying provenance attestation: %w", err))
+		}
+		cert, err := prov.certificate.CanonicalValue()
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.Sum256(p.Content)
+		canonicalEntry.Provenance = &models.NpmV001SchemaProvenance{
+			Hash: &models.NpmV001SchemaProvenanceHash{
+				Algorithm: swag.String(models.NpmV001SchemaProvenanceHashAlgorithmSha256),
+				Value:     swag.String(hex.EncodeToString(h[:])),
+			},
+			Certificate: cert,
+		}
+		v.provenance = prov
+	}
+
+	npmObj := models.Npm{}
+	npmObj.APIVersion = swag.String(APIVERSION)
+	npmObj.Spec = &canonicalEntry
+
+	return json.Marshal(&npmObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+// packageVersion is the part of a registry package version document recorded in an entry, as
+// returned by https://registry.npmjs.org/<name>/<version>
+type packageVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dist    struct {
+		Integrity  string `json:"integrity"`
+		Signatures []struct {
+			KeyID string `json:"keyid"`
+			Sig   string `json:"sig"`
+		} `json:"signatures"`
+	} `json:"dist"`
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Npm{}
+
+	var err error
+	docBytes := props.ArtifactBytes
+	if docBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to the package version document must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("package version documents cannot be fetched over HTTP(S)")
+		}
+		docBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	doc := packageVersion{}
+	if err := json.Unmarshal(docBytes, &doc); err != nil {
+		return nil, fmt.Errorf("parsing package version document: %w", err)
+	}
+
+	re := V001Entry{
+		NpmObj: models.NpmV001Schema{
+			Package: &models.NpmV001SchemaPackage{
+				Name:      swag.String(doc.Name),
+				Version:   swag.String(doc.Version),
+				Integrity: swag.String(doc.Dist.Integrity),
+			},
+		},
+	}
+	if len(doc.Dist.Signatures) > 0 {
+		sig, err := base64.StdEncoding.DecodeString(doc.Dist.Signatures[0].Sig)
+		if err != nil {
+			return nil, fmt.Errorf("decoding registry signature: %w", err)
+		}
+		sb := strfmt.Base64(sig)
+		re.NpmObj.RegistrySignature = &models.NpmV001SchemaRegistrySignature{
+			Keyid: swag.String(doc.Dist.Signatures[0].KeyID),
+			Sig:   &sb,
+		}
+	}
+
+	// the provenance bundle is passed as the signature
+	bundleBytes := props.SignatureBytes
+	if bundleBytes == nil && props.SignaturePath != nil {
+		bundleBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading provenance bundle: %w", err)
+		}
+	}
+	if bundleBytes != nil {
+		re.NpmObj.Provenance = &models.NpmV001SchemaProvenance{
+			Content: strfmt.Base64(bundleBytes),
+		}
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	returnVal.Spec = re.NpmObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
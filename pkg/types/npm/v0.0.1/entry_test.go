@@ -0,0 +1,320 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"github.com/spf13/viper"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func p(b []byte) *strfmt.Base64 {
+	b64 := strfmt.Base64(b)
+	return &b64
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, b []byte) []byte {
+	t.Helper()
+	h := sha256.Sum256(b)
+	sig, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+const (
+	pkgName    = "@sigstore/rekor"
+	pkgVersion = "1.0.0"
+)
+
+var tarball = sha512.Sum512([]byte("package.tgz"))
+
+func integrity() string {
+	return "sha512-" + base64.StdEncoding.EncodeToString(tarball[:])
+}
+
+// writeRegistryKeys writes the registry keys file for key and configures the server to use it
+func writeRegistryKeys(t *testing.T, keyID string, key *ecdsa.PrivateKey) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := fmt.Sprintf(`{"keys":[{"expires":null,"keyid":%q,"keytype":"ecdsa-sha2-nistp256","scheme":"ecdsa-sha2-nistp256","key":%q}]}`,
+		keyID, base64.StdEncoding.EncodeToString(der))
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := ioutil.WriteFile(path, []byte(keys), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("npm_registry_keys", path)
+	trustedKeysOnce = sync.Once{}
+	t.Cleanup(func() {
+		viper.Set("npm_registry_keys", "")
+		trustedKeysOnce = sync.Once{}
+	})
+}
+
+// provenanceBundle returns a bundle attesting to subject, signed by a self-signed certificate
+func provenanceBundle(t *testing.T, subject string, digest []byte) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "sigstore"},
+		EmailAddresses: []string{"ci@example.com"},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","subject":[{"name":%q,"digest":{"sha512":%q}}],"predicate":{}}`,
+		subject, hex.EncodeToString(digest))
+	env := ssl.Envelope{
+		PayloadType: "application/vnd.in-toto+json",
+		Payload:     base64.StdEncoding.EncodeToString([]byte(statement)),
+		Signatures: []ssl.Signature{{
+			Sig: base64.StdEncoding.EncodeToString(sign(t, key, ssl.PAE("application/vnd.in-toto+json", statement))),
+		}},
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"mediaType": "application/vnd.dev.sigstore.bundle+json;version=0.1",
+		"verificationMaterial": map[string]interface{}{
+			"x509CertificateChain": map[string]interface{}{
+				"certificates": []map[string][]byte{{"rawBytes": der}},
+			},
+		},
+		"dsseEnvelope": env,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestV001Entry_Canonicalize(t *testing.T) {
+	registryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte(registrySignedMessage(pkgName, pkgVersion, integrity()))
+	registrySig := func(keyID string, sig []byte) *models.NpmV001SchemaRegistrySignature {
+		return &models.NpmV001SchemaRegistrySignature{Keyid: swag.String(keyID), Sig: p(sig)}
+	}
+	prov := func(b []byte) *models.NpmV001SchemaProvenance {
+		return &models.NpmV001SchemaProvenance{Content: b}
+	}
+	purl := "pkg:npm/%40sigstore/rekor@1.0.0"
+	otherDigest := sha512.Sum512([]byte("other.tgz"))
+
+	tests := []struct {
+		name         string
+		integrity    string
+		registrySig  *models.NpmV001SchemaRegistrySignature
+		provenance   *models.NpmV001SchemaProvenance
+		noKeys       bool
+		wantErr      bool
+		wantCanonErr bool
+		wantKeys     []string
+	}{
+		{
+			name:        "registry signature",
+			registrySig: registrySig("SHA256:test", sign(t, registryKey, message)),
+			wantKeys:    []string{pkgName + "@" + pkgVersion, "sha512:" + hex.EncodeToString(tarball[:])},
+		},
+		{
+			name:       "provenance",
+			provenance: prov(provenanceBundle(t, purl, tarball[:])),
+			wantKeys:   []string{pkgName + "@" + pkgVersion, "ci@example.com"},
+		},
+		{
+			name:        "registry signature and provenance",
+			registrySig: registrySig("SHA256:test", sign(t, registryKey, message)),
+			provenance:  prov(provenanceBundle(t, purl, tarball[:])),
+			wantKeys:    []string{pkgName + "@" + pkgVersion, "ci@example.com"},
+		},
+		{
+			name:    "no signature or provenance",
+			wantErr: true,
+		},
+		{
+			name:        "invalid integrity",
+			integrity:   "sha512-abc",
+			registrySig: registrySig("SHA256:test", sign(t, registryKey, message)),
+			wantErr:     true,
+		},
+		{
+			name:         "unknown registry key",
+			registrySig:  registrySig("SHA256:other", sign(t, registryKey, message)),
+			wantCanonErr: true,
+		},
+		{
+			name:         "wrong registry key",
+			registrySig:  registrySig("SHA256:test", sign(t, otherKey, message)),
+			wantCanonErr: true,
+		},
+		{
+			name:         "registry keys not configured",
+			registrySig:  registrySig("SHA256:test", sign(t, registryKey, message)),
+			noKeys:       true,
+			wantCanonErr: true,
+		},
+		{
+			name:         "provenance for another package",
+			provenance:   prov(provenanceBundle(t, "pkg:npm/other@1.0.0", tarball[:])),
+			wantCanonErr: true,
+		},
+		{
+			name:         "provenance with another digest",
+			provenance:   prov(provenanceBundle(t, purl, otherDigest[:])),
+			wantCanonErr: true,
+		},
+		{
+			name:         "provenance without content",
+			provenance:   prov(nil),
+			wantCanonErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.noKeys {
+				writeRegistryKeys(t, "SHA256:test", registryKey)
+			}
+			i := tt.integrity
+			if i == "" {
+				i = integrity()
+			}
+			it := &models.Npm{
+				APIVersion: swag.String(APIVERSION),
+				Spec: &models.NpmV001Schema{
+					Package: &models.NpmV001SchemaPackage{
+						Name:      swag.String(pkgName),
+						Version:   swag.String(pkgVersion),
+						Integrity: swag.String(i),
+					},
+					RegistrySignature: tt.registrySig,
+					Provenance:        tt.provenance,
+				},
+			}
+			v := &V001Entry{}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if (err != nil) != tt.wantCanonErr {
+				t.Fatalf("V001Entry.Canonicalize() error = %v, wantCanonErr %v", err, tt.wantCanonErr)
+			}
+			if tt.wantCanonErr {
+				return
+			}
+			for _, want := range tt.wantKeys {
+				found := false
+				for _, k := range v.IndexKeys() {
+					found = found || k == want
+				}
+				if !found {
+					t.Errorf("IndexKeys() = %v, missing %v", v.IndexKeys(), want)
+				}
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseIntegrity(t *testing.T) {
+	sha256Sum := sha256.Sum256([]byte("package.tgz"))
+	sha256SRI := "sha256-" + base64.StdEncoding.EncodeToString(sha256Sum[:])
+	tests := []struct {
+		integrity string
+		want      string
+		wantErr   bool
+	}{
+		{integrity: integrity(), want: "sha512:" + hex.EncodeToString(tarball[:])},
+		{integrity: sha256SRI, want: "sha256:" + hex.EncodeToString(sha256Sum[:])},
+		{integrity: sha256SRI + " " + integrity(), want: "sha512:" + hex.EncodeToString(tarball[:])},
+		{integrity: "sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk= " + sha256SRI, want: "sha256:" + hex.EncodeToString(sha256Sum[:])},
+		{integrity: "sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk=", wantErr: true},
+		{integrity: "sha512-" + base64.StdEncoding.EncodeToString(sha256Sum[:]), wantErr: true},
+		{integrity: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseIntegrity(tt.integrity)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseIntegrity(%q) error = %v, wantErr %v", tt.integrity, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseIntegrity(%q) = %v, want %v", tt.integrity, got, tt.want)
+		}
+	}
+}
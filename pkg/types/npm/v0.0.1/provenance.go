@@ -0,0 +1,148 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/sigstore/rekor/pkg/pki/x509"
+)
+
+// bundle holds the parts of a Sigstore bundle needed to verify an npm provenance attestation
+type bundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope *ssl.Envelope `json:"dsseEnvelope"`
+}
+
+// provenance is a verified provenance attestation
+type provenance struct {
+	certificate *x509.PublicKey
+	statement   *in_toto.Statement
+}
+
+// verifyProvenance checks the bundle's envelope is signed by the certificate in the bundle and
+// that the attestation is about the package version with the given integrity hash
+func verifyProvenance(b []byte, name, version, integrityHash string) (*provenance, error) {
+	sb := bundle{}
+	if err := json.Unmarshal(b, &sb); err != nil {
+		return nil, fmt.Errorf("invalid Sigstore bundle: %w", err)
+	}
+	if !strings.HasPrefix(sb.MediaType, "application/vnd.dev.sigstore.bundle") {
+		return nil, fmt.Errorf("unsupported bundle media type %q", sb.MediaType)
+	}
+	if sb.DSSEEnvelope == nil {
+		return nil, errors.New("bundle does not contain a DSSE envelope")
+	}
+	if sb.DSSEEnvelope.PayloadType != in_toto.PayloadType {
+		return nil, fmt.Errorf("unsupported envelope payload type %q", sb.DSSEEnvelope.PayloadType)
+	}
+
+	var certDER []byte
+	switch vm := sb.VerificationMaterial; {
+	case vm.Certificate != nil:
+		certDER = vm.Certificate.RawBytes
+	case vm.X509CertificateChain != nil && len(vm.X509CertificateChain.Certificates) > 0:
+		certDER = vm.X509CertificateChain.Certificates[0].RawBytes
+	default:
+		return nil, errors.New("bundle does not contain a signing certificate")
+	}
+	// the certificate is subject to the trust roots configured for x509 keys
+	cert, err := x509.NewPublicKey(bytes.NewReader(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := base64.StdEncoding.DecodeString(sb.DSSEEnvelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding envelope payload: %w", err)
+	}
+	if err := verifyEnvelope(sb.DSSEEnvelope, body, cert); err != nil {
+		return nil, err
+	}
+
+	statement := &in_toto.Statement{}
+	if err := json.Unmarshal(body, statement); err != nil {
+		return nil, fmt.Errorf("invalid in-toto statement: %w", err)
+	}
+	if err := checkSubject(statement, name, version, integrityHash); err != nil {
+		return nil, err
+	}
+	return &provenance{certificate: cert, statement: statement}, nil
+}
+
+func verifyEnvelope(env *ssl.Envelope, body []byte, cert *x509.PublicKey) error {
+	if len(env.Signatures) == 0 {
+		return ssl.ErrNoSignature
+	}
+	verifier, err := signature.LoadVerifier(cert.CryptoPubKey(), crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	pae := ssl.PAE(env.PayloadType, string(body))
+	for i, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return fmt.Errorf("decoding envelope signature %d: %w", i, err)
+		}
+		if err := verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(pae)); err != nil {
+			return fmt.Errorf("envelope signature %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// packageURL returns the purl npm uses as the attestation subject name
+func packageURL(name, version string) string {
+	return "pkg:npm/" + strings.Replace(name, "@", "%40", 1) + "@" + version
+}
+
+// checkSubject requires a subject naming the package version with a digest matching integrityHash,
+// which is formatted as algorithm:hex
+func checkSubject(s *in_toto.Statement, name, version, integrityHash string) error {
+	purl := packageURL(name, version)
+	alg := strings.SplitN(integrityHash, ":", 2)[0]
+	for _, subject := range s.Subject {
+		if subject.Name != purl {
+			continue
+		}
+		if d, ok := subject.Digest[alg]; ok && alg+":"+strings.ToLower(d) == integrityHash {
+			return nil
+		}
+		return fmt.Errorf("attestation subject %s does not match the package integrity", purl)
+	}
+	return fmt.Errorf("attestation has no subject for %s", purl)
+}
@@ -0,0 +1,155 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npm
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/spf13/viper"
+)
+
+// registryKeys is the format npm publishes its signing keys in, see
+// https://registry.npmjs.org/-/npm/v1/keys
+type registryKeys struct {
+	Keys []struct {
+		Expires *string `json:"expires"`
+		KeyID   string  `json:"keyid"`
+		KeyType string  `json:"keytype"`
+		Scheme  string  `json:"scheme"`
+		Key     string  `json:"key"`
+	} `json:"keys"`
+}
+
+var (
+	trustedKeysOnce sync.Once
+	trustedKeys     map[string]crypto.PublicKey
+	errTrustedKeys  error
+)
+
+// LoadRegistryKeys reads the registry keys configured with npm_registry_keys. The file is only read
+// once; the server loads it on startup so that it does not start with a missing or invalid file.
+func LoadRegistryKeys() error {
+	_, err := trustedRegistryKeys()
+	return err
+}
+
+func trustedRegistryKeys() (map[string]crypto.PublicKey, error) {
+	trustedKeysOnce.Do(func() {
+		trustedKeys, errTrustedKeys = loadRegistryKeys(viper.GetString("npm_registry_keys"))
+	})
+	return trustedKeys, errTrustedKeys
+}
+
+// loadRegistryKeys reads the registry keys the server trusts. Keys are not rejected once they
+// expire, since npm keeps signatures made before the expiry time valid.
+func loadRegistryKeys(path string) (map[string]crypto.PublicKey, error) {
+	if path == "" {
+		return nil, errors.New("npm registry signatures are not accepted: no registry keys are configured")
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading npm registry keys: %w", err)
+	}
+	rk := registryKeys{}
+	if err := json.Unmarshal(b, &rk); err != nil {
+		return nil, fmt.Errorf("parsing npm registry keys: %w", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range rk.Keys {
+		if k.Scheme != "ecdsa-sha2-nistp256" {
+			continue
+		}
+		der, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("decoding npm registry key %s: %w", k.KeyID, err)
+		}
+		pub, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing npm registry key %s: %w", k.KeyID, err)
+		}
+		if _, ok := pub.(*ecdsa.PublicKey); !ok {
+			return nil, fmt.Errorf("npm registry key %s is not an ECDSA key", k.KeyID)
+		}
+		keys[k.KeyID] = pub
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no supported npm registry keys are configured")
+	}
+	return keys, nil
+}
+
+// registrySignedMessage is what the registry signs for a package version
+func registrySignedMessage(name, version, integrity string) string {
+	return fmt.Sprintf("%s@%s:%s", name, version, integrity)
+}
+
+func verifyRegistrySignature(keys map[string]crypto.PublicKey, keyID string, sig []byte, message string) error {
+	key, ok := keys[keyID]
+	if !ok {
+		return fmt.Errorf("unknown npm registry key %s", keyID)
+	}
+	verifier, err := signature.LoadVerifier(key, crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	return verifier.VerifySignature(bytes.NewReader(sig), strings.NewReader(message))
+}
+
+// sriAlgorithms are the subresource integrity hash functions accepted for package tarballs
+var sriAlgorithms = map[string]int{"sha256": 32, "sha384": 48, "sha512": 64}
+
+// parseIntegrity returns the strongest hash of a subresource integrity string, formatted as
+// algorithm:hex for indexing
+func parseIntegrity(integrity string) (string, error) {
+	best, bestLen := "", 0
+	for _, h := range strings.Fields(integrity) {
+		i := strings.Index(h, "-")
+		if i < 0 {
+			return "", fmt.Errorf("invalid integrity %q", h)
+		}
+		alg, size := h[:i], sriAlgorithms[h[:i]]
+		if size == 0 {
+			continue
+		}
+		// options may follow the digest, see https://www.w3.org/TR/SRI/#the-integrity-attribute
+		digest := strings.SplitN(h[i+1:], "?", 2)[0]
+		b, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil || len(b) != size {
+			return "", fmt.Errorf("invalid %s integrity value", alg)
+		}
+		if size > bestLen {
+			best, bestLen = alg+":"+hex.EncodeToString(b), size
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("integrity %q has no supported hash", integrity)
+	}
+	return best, nil
+}
@@ -0,0 +1,260 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/oci"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := oci.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	OciObj  models.OciV001Schema
+	keyObj  *x509.PublicKey
+	payload *simpleSigning
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.keyObj != nil {
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	if v.OciObj.ManifestDigest != nil {
+		result = append(result, strings.ToLower(*v.OciObj.ManifestDigest))
+	}
+
+	switch {
+	case v.payload != nil:
+		result = append(result, strings.ToLower(v.payload.Critical.Identity.DockerReference))
+		h := sha256.Sum256(v.OciObj.Payload.Content)
+		result = append(result, "sha256:"+hex.EncodeToString(h[:]))
+	case v.OciObj.DockerReference != "":
+		result = append(result, strings.ToLower(v.OciObj.DockerReference))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Oci)
+	if !ok {
+		return errors.New("cannot unmarshal non OCI v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.OciObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.OciObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	// the verifier may be either a PEM encoded public key or certificate
+	var err error
+	v.keyObj, err = x509.NewPublicKey(bytes.NewReader(*v.OciObj.Signature.PublicKey.Content))
+	if err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	if err := validateDigest(*v.OciObj.ManifestDigest); err != nil {
+		return err
+	}
+	// This also gets called in the CLI, where we won't have this data
+	if len(v.OciObj.Payload.Content) == 0 {
+		return nil
+	}
+
+	p, err := parsePayload(v.OciObj.Payload.Content)
+	if err != nil {
+		return err
+	}
+	if d := p.Critical.Image.DockerManifestDigest; d != *v.OciObj.ManifestDigest {
+		return fmt.Errorf("payload was signed for manifest %s, not %s", d, *v.OciObj.ManifestDigest)
+	}
+
+	sigObj, err := x509.NewSignature(bytes.NewReader(*v.OciObj.Signature.Content))
+	if err != nil {
+		return err
+	}
+	if err := sigObj.Verify(bytes.NewReader(v.OciObj.Payload.Content), v.keyObj); err != nil {
+		return err
+	}
+	v.payload = p
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.keyObj == nil {
+		return nil, errors.New("cannot canonicalize empty key")
+	}
+	if v.payload == nil {
+		return nil, errors.New("cannot canonicalize entry without a verified payload")
+	}
+
+	pk, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	pkb := strfmt.Base64(pk)
+
+	payloadHash := sha256.Sum256(v.OciObj.Payload.Content)
+
+	canonicalEntry := models.OciV001Schema{
+		ManifestDigest: v.OciObj.ManifestDigest,
+		Payload: &models.OciV001SchemaPayload{
+			Hash: &models.OciV001SchemaPayloadHash{
+				Algorithm: swag.String(models.OciV001SchemaPayloadHashAlgorithmSha256),
+				Value:     swag.String(hex.EncodeToString(payloadHash[:])),
+			},
+		},
+		Signature: &models.OciV001SchemaSignature{
+			Content: v.OciObj.Signature.Content,
+			PublicKey: &models.OciV001SchemaSignaturePublicKey{
+				Content: &pkb,
+			},
+		},
+		DockerReference: v.payload.Critical.Identity.DockerReference,
+		ExtraData:       v.OciObj.ExtraData,
+	}
+
+	ociObj := models.Oci{}
+	ociObj.APIVersion = swag.String(APIVERSION)
+	ociObj.Spec = &canonicalEntry
+
+	return json.Marshal(&ociObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Oci{}
+
+	var err error
+	payloadBytes := props.ArtifactBytes
+	if payloadBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to simple signing payload must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("simple signing payloads cannot be fetched over HTTP(S)")
+		}
+		payloadBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, err
+		}
+	}
+	p, err := parsePayload(payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	digest := p.Critical.Image.DockerManifestDigest
+	if props.ArtifactHash != "" && props.ArtifactHash != digest {
+		return nil, fmt.Errorf("payload was signed for manifest %s, not %s", digest, props.ArtifactHash)
+	}
+
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("a signature must be provided")
+		}
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+	// cosign writes signatures base64 encoded
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sigBytes))); err == nil {
+		sigBytes = decoded
+	}
+
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify signature")
+		}
+		publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+	}
+	sb, kb := strfmt.Base64(sigBytes), strfmt.Base64(publicKeyBytes)
+
+	re := V001Entry{
+		OciObj: models.OciV001Schema{
+			ManifestDigest: swag.String(digest),
+			Payload: &models.OciV001SchemaPayload{
+				Content: strfmt.Base64(payloadBytes),
+			},
+			Signature: &models.OciV001SchemaSignature{
+				Content: &sb,
+				PublicKey: &models.OciV001SchemaSignaturePublicKey{
+					Content: &kb,
+				},
+			},
+		},
+	}
+
+	returnVal.Spec = re.OciObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
@@ -0,0 +1,163 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func p(b []byte) *strfmt.Base64 {
+	b64 := strfmt.Base64(b)
+	return &b64
+}
+
+const (
+	digest      = "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
+	otherDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func payload(typ, ref, digest string) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":%q},"optional":null}`, ref, digest, typ))
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	sign := func(b []byte) []byte {
+		h := sha256.Sum256(b)
+		sig, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	valid := payload("cosign container image signature", "ghcr.io/sigstore/rekor", digest)
+	atomic := payload("atomic container signature", "ghcr.io/sigstore/rekor", digest)
+	wrongType := payload("something else", "ghcr.io/sigstore/rekor", digest)
+	noReference := payload("cosign container image signature", "", digest)
+	mixedCase := payload("cosign container image signature", "ghcr.io/Sigstore/Rekor:V1", digest)
+
+	tests := []struct {
+		name     string
+		digest   string
+		payload  []byte
+		sig      []byte
+		wantErr  bool
+		noVerify bool
+		// the docker reference as it is indexed
+		wantRef string
+	}{
+		{name: "valid", digest: digest, payload: valid, sig: sign(valid)},
+		{name: "atomic signature", digest: digest, payload: atomic, sig: sign(atomic)},
+		{name: "mixed-case reference", digest: digest, payload: mixedCase, sig: sign(mixedCase), wantRef: "ghcr.io/sigstore/rekor:v1"},
+		{name: "without payload", digest: digest, sig: sign(valid), noVerify: true},
+		{name: "digest mismatch", digest: otherDigest, payload: valid, sig: sign(valid), wantErr: true},
+		{name: "invalid digest", digest: "sha512:abc", payload: valid, sig: sign(valid), wantErr: true},
+		{name: "invalid signature", digest: digest, payload: valid, sig: sign(atomic), wantErr: true},
+		{name: "unsupported payload type", digest: digest, payload: wrongType, sig: sign(wrongType), wantErr: true},
+		{name: "missing docker reference", digest: digest, payload: noReference, sig: sign(noReference), wantErr: true},
+		{name: "not json", digest: digest, payload: []byte("hello"), sig: sign([]byte("hello")), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := models.OciV001Schema{
+				ManifestDigest: swag.String(tt.digest),
+				Payload:        &models.OciV001SchemaPayload{Content: tt.payload},
+				Signature: &models.OciV001SchemaSignature{
+					Content: p(tt.sig),
+					PublicKey: &models.OciV001SchemaSignaturePublicKey{
+						Content: p(pub),
+					},
+				},
+			}
+			v := &V001Entry{}
+			it := &models.Oci{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       &obj,
+			}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr || tt.noVerify {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			ei, err := types.NewEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			wantRef := tt.wantRef
+			if wantRef == "" {
+				wantRef = "ghcr.io/sigstore/rekor"
+			}
+			for _, want := range []string{digest, wantRef} {
+				found := false
+				for _, k := range ei.IndexKeys() {
+					found = found || k == want
+				}
+				if !found {
+					t.Errorf("IndexKeys() = %v, missing %v", ei.IndexKeys(), want)
+				}
+			}
+		})
+	}
+}
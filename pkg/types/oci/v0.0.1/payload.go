@@ -0,0 +1,76 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// signatureTypes are the critical.type values of simple signing payloads; cosign uses the former
+var signatureTypes = []string{"cosign container image signature", "atomic container signature"}
+
+// simpleSigning is the payload format signed by cosign, see
+// https://github.com/containers/image/blob/main/docs/containers-signature.5.md
+type simpleSigning struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+func parsePayload(b []byte) (*simpleSigning, error) {
+	p := &simpleSigning{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("invalid simple signing payload: %w", err)
+	}
+
+	validType := false
+	for _, t := range signatureTypes {
+		validType = validType || p.Critical.Type == t
+	}
+	if !validType {
+		return nil, fmt.Errorf("unsupported simple signing payload type %q", p.Critical.Type)
+	}
+	if p.Critical.Identity.DockerReference == "" {
+		return nil, errors.New("simple signing payload is missing the docker-reference identity")
+	}
+	if err := validateDigest(p.Critical.Image.DockerManifestDigest); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// validateDigest checks d is a lowercase sha256 digest as used by registries
+func validateDigest(d string) error {
+	v := strings.TrimPrefix(d, "sha256:")
+	if v == d || len(v) != 64 || strings.ToLower(v) != v {
+		return fmt.Errorf("invalid manifest digest %q", d)
+	}
+	if _, err := hex.DecodeString(v); err != nil {
+		return fmt.Errorf("invalid manifest digest %q", d)
+	}
+	return nil
+}
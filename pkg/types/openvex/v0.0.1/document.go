@@ -0,0 +1,190 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openvex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// contextPrefix is the namespace under which every published OpenVEX @context lives
+const contextPrefix = "https://openvex.dev/ns"
+
+// payloadType is the DSSE payload type of a bare OpenVEX document
+const payloadType = "application/vnd.openvex+json"
+
+// statuses are the impact statuses a VEX statement may assert
+var statuses = map[string]bool{
+	"not_affected":        true,
+	"affected":            true,
+	"fixed":               true,
+	"under_investigation": true,
+}
+
+// document is the subset of an OpenVEX document that is validated and indexed, see
+// https://github.com/openvex/spec/blob/main/OPENVEX-SPEC.md
+type document struct {
+	Context    string      `json:"@context"`
+	ID         string      `json:"@id"`
+	Author     string      `json:"author"`
+	Timestamp  *time.Time  `json:"timestamp"`
+	Statements []statement `json:"statements"`
+}
+
+type statement struct {
+	Vulnerability   vulnerability `json:"vulnerability"`
+	Products        []product     `json:"products"`
+	Subcomponents   []product     `json:"subcomponents"`
+	Status          string        `json:"status"`
+	Justification   string        `json:"justification"`
+	ImpactStatement string        `json:"impact_statement"`
+}
+
+type vulnerability struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// UnmarshalJSON also accepts the bare vulnerability name used by version 0.0.1 of the specification
+func (v *vulnerability) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		v.Name = name
+		return nil
+	}
+	type plain vulnerability
+	return json.Unmarshal(b, (*plain)(v))
+}
+
+type product struct {
+	ID            string            `json:"@id"`
+	Identifiers   map[string]string `json:"identifiers"`
+	Hashes        map[string]string `json:"hashes"`
+	Subcomponents []product         `json:"subcomponents"`
+}
+
+// UnmarshalJSON also accepts the bare product identifier used by version 0.0.1 of the specification
+func (p *product) UnmarshalJSON(b []byte) error {
+	var id string
+	if err := json.Unmarshal(b, &id); err == nil {
+		p.ID = id
+		return nil
+	}
+	type plain product
+	return json.Unmarshal(b, (*plain)(p))
+}
+
+// parseDocument decodes an OpenVEX document and checks the fields required by the specification
+func parseDocument(b []byte) (*document, error) {
+	d := document{}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("invalid OpenVEX document: %w", err)
+	}
+	if !strings.HasPrefix(d.Context, contextPrefix) {
+		return nil, fmt.Errorf("unsupported OpenVEX @context %q", d.Context)
+	}
+	if d.ID == "" {
+		return nil, errors.New("OpenVEX document is missing its @id")
+	}
+	if d.Author == "" {
+		return nil, errors.New("OpenVEX document is missing its author")
+	}
+	if d.Timestamp == nil {
+		return nil, errors.New("OpenVEX document is missing its timestamp")
+	}
+	if len(d.Statements) == 0 {
+		return nil, errors.New("OpenVEX document contains no statements")
+	}
+	for i, s := range d.Statements {
+		if s.Vulnerability.Name == "" {
+			return nil, fmt.Errorf("statement %d does not name a vulnerability", i)
+		}
+		if len(s.Products) == 0 {
+			return nil, fmt.Errorf("statement %d does not name any products", i)
+		}
+		if !statuses[s.Status] {
+			return nil, fmt.Errorf("statement %d has invalid status %q", i, s.Status)
+		}
+		if s.Status == "not_affected" && s.Justification == "" && s.ImpactStatement == "" {
+			return nil, fmt.Errorf("statement %d is not_affected without a justification or impact statement", i)
+		}
+	}
+	return &d, nil
+}
+
+// vulnerabilities returns the names and aliases of every vulnerability in the document
+func (d document) vulnerabilities() []string {
+	var result []string
+	seen := map[string]bool{}
+	add := func(s string) {
+		if s = strings.ToLower(s); s != "" && !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	for _, s := range d.Statements {
+		add(s.Vulnerability.Name)
+		for _, a := range s.Vulnerability.Aliases {
+			add(a)
+		}
+	}
+	return result
+}
+
+// sortedKeys returns the keys of m in a stable order, so the canonical entry is deterministic
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// products returns the identifiers of every product and subcomponent in the document; hashes
+// are formatted as algorithm:value with the algorithm spelled the way rekor indexes hashes
+func (d document) products() []string {
+	var result []string
+	seen := map[string]bool{}
+	add := func(s string) {
+		if s = strings.ToLower(s); s != "" && !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	var walk func([]product)
+	walk = func(ps []product) {
+		for _, p := range ps {
+			add(p.ID)
+			for _, k := range sortedKeys(p.Identifiers) {
+				add(p.Identifiers[k])
+			}
+			for _, alg := range sortedKeys(p.Hashes) {
+				add(strings.Replace(alg, "sha-", "sha", 1) + ":" + p.Hashes[alg])
+			}
+			walk(p.Subcomponents)
+		}
+	}
+	for _, s := range d.Statements {
+		walk(s.Products)
+		walk(s.Subcomponents)
+	}
+	return result
+}
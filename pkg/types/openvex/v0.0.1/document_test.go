@@ -0,0 +1,130 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openvex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			doc:  `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":{"name":"CVE-2023-1255"},"products":[{"@id":"pkg:apk/wolfi/git"}],"status":"fixed"}]}`,
+		},
+		{
+			name: "specification v0.0.1 string forms",
+			doc:  `{"@context":"https://openvex.dev/ns","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":"CVE-2023-1255","products":["pkg:apk/wolfi/git"],"status":"affected"}]}`,
+		},
+		{
+			name:    "not json",
+			doc:     `not json`,
+			wantErr: true,
+		},
+		{
+			name:    "foreign context",
+			doc:     `{"@context":"https://cyclonedx.org","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":"CVE-2023-1255","products":["p"],"status":"fixed"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing id",
+			doc:     `{"@context":"https://openvex.dev/ns/v0.2.0","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":"CVE-2023-1255","products":["p"],"status":"fixed"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing timestamp",
+			doc:     `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"id","author":"a","statements":[{"vulnerability":"CVE-2023-1255","products":["p"],"status":"fixed"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no statements",
+			doc:     `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "statement without products",
+			doc:     `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":"CVE-2023-1255","status":"fixed"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid status",
+			doc:     `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":"CVE-2023-1255","products":["p"],"status":"unknown"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not_affected without justification",
+			doc:     `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"id","author":"a","timestamp":"2023-01-08T18:02:03Z","statements":[{"vulnerability":"CVE-2023-1255","products":["p"],"status":"not_affected"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseDocument([]byte(tt.doc)); (err != nil) != tt.wantErr {
+				t.Errorf("parseDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDocumentIndexValues(t *testing.T) {
+	d, err := parseDocument([]byte(`{
+		"@context": "https://openvex.dev/ns/v0.2.0",
+		"@id": "id",
+		"author": "a",
+		"timestamp": "2023-01-08T18:02:03Z",
+		"statements": [
+			{
+				"vulnerability": {"name": "CVE-2023-1255", "aliases": ["GHSA-h2f5-mq5p-pf5q"]},
+				"products": [
+					{
+						"@id": "pkg:oci/app",
+						"identifiers": {"purl": "pkg:oci/app", "cpe23": "cpe:2.3:a:example:app:1.0:*:*:*:*:*:*:*"},
+						"hashes": {"sha-256": "AB01", "sha-512": "cd02"},
+						"subcomponents": [{"@id": "pkg:golang/example.com/lib@v1.0.0"}]
+					}
+				],
+				"status": "under_investigation"
+			},
+			{
+				"vulnerability": "cve-2023-1255",
+				"products": ["pkg:oci/app"],
+				"status": "fixed"
+			}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := d.vulnerabilities(), []string{"cve-2023-1255", "ghsa-h2f5-mq5p-pf5q"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("vulnerabilities() = %v, want %v", got, want)
+	}
+	want := []string{
+		"pkg:oci/app",
+		"cpe:2.3:a:example:app:1.0:*:*:*:*:*:*:*",
+		"sha256:ab01",
+		"sha512:cd02",
+		"pkg:golang/example.com/lib@v1.0.0",
+	}
+	if got := d.products(); !reflect.DeepEqual(got, want) {
+		t.Errorf("products() = %v, want %v", got, want)
+	}
+}
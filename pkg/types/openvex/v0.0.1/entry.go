@@ -0,0 +1,275 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openvex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/openvex"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := openvex.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	OpenvexObj models.OpenvexV001Schema
+	keyObj     pki.PublicKey
+	// sigObj is the detached PGP signature; DSSE signatures stay in the envelope
+	sigObj pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if h := v.OpenvexObj.Document.Hash; h != nil && h.Algorithm != nil && h.Value != nil {
+		result = append(result, *h.Algorithm+":"+*h.Value)
+	}
+	if v.OpenvexObj.ID != "" {
+		result = append(result, strings.ToLower(v.OpenvexObj.ID))
+	}
+	// vulnerability IDs let scanners find every statement made about a CVE
+	result = append(result, v.OpenvexObj.Vulnerabilities...)
+	result = append(result, v.OpenvexObj.Products...)
+	if v.keyObj != nil {
+		result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Openvex)
+	if !ok {
+		return errors.New("cannot unmarshal non OpenVEX v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.OpenvexObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.OpenvexObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	content := v.OpenvexObj.Document.Content
+	// This also gets called in the CLI, where we won't have this data
+	if len(content) == 0 {
+		return nil
+	}
+
+	sig := v.OpenvexObj.Signature
+	keyBytes := *sig.PublicKey.Content
+
+	var docBytes []byte
+	switch swag.StringValue(sig.Format) {
+	case models.OpenvexV001SchemaSignatureFormatPgp:
+		if len(sig.Content) == 0 {
+			return errors.New("a detached signature must be provided for the pgp format")
+		}
+		artifactFactory, err := pki.NewArtifactFactory(pki.PGP)
+		if err != nil {
+			return err
+		}
+		keyObj, err := artifactFactory.NewPublicKey(bytes.NewReader(keyBytes))
+		if err != nil {
+			return err
+		}
+		sigObj, err := artifactFactory.NewSignature(bytes.NewReader(sig.Content))
+		if err != nil {
+			return err
+		}
+		if err := sigObj.Verify(bytes.NewReader(content), keyObj); err != nil {
+			return fmt.Errorf("verifying document signature: %w", err)
+		}
+		v.keyObj, v.sigObj = keyObj, sigObj
+		docBytes = content
+	case models.OpenvexV001SchemaSignatureFormatDsse:
+		if len(sig.Content) > 0 {
+			return errors.New("DSSE signatures are carried in the envelope, not in the signature content")
+		}
+		keyObj, err := x509.NewPublicKey(bytes.NewReader(keyBytes))
+		if err != nil {
+			return err
+		}
+		if docBytes, err = openEnvelope(content, keyObj); err != nil {
+			return err
+		}
+		v.keyObj = keyObj
+	default:
+		return fmt.Errorf("unsupported signature format %q", swag.StringValue(sig.Format))
+	}
+
+	d, err := parseDocument(docBytes)
+	if err != nil {
+		return err
+	}
+	v.OpenvexObj.ID = d.ID
+	v.OpenvexObj.Vulnerabilities = d.vulnerabilities()
+	v.OpenvexObj.Products = d.products()
+
+	h := sha256.Sum256(content)
+	v.OpenvexObj.Document.Hash = &models.OpenvexV001SchemaDocumentHash{
+		Algorithm: swag.String(models.OpenvexV001SchemaDocumentHashAlgorithmSha256),
+		Value:     swag.String(hex.EncodeToString(h[:])),
+	}
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.keyObj == nil {
+		return nil, errors.New("OpenVEX document must be verified before canonicalizing")
+	}
+
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	keyContent := strfmt.Base64(key)
+
+	canonicalEntry := models.OpenvexV001Schema{
+		// the document content is not set deliberately
+		Document: &models.OpenvexV001SchemaDocument{
+			Hash: v.OpenvexObj.Document.Hash,
+		},
+		Signature: &models.OpenvexV001SchemaSignature{
+			Format: v.OpenvexObj.Signature.Format,
+			PublicKey: &models.OpenvexV001SchemaSignaturePublicKey{
+				Content: &keyContent,
+			},
+		},
+		ID:              v.OpenvexObj.ID,
+		Vulnerabilities: v.OpenvexObj.Vulnerabilities,
+		Products:        v.OpenvexObj.Products,
+		ExtraData:       v.OpenvexObj.ExtraData,
+	}
+	if v.sigObj != nil {
+		sig, err := v.sigObj.CanonicalValue()
+		if err != nil {
+			return nil, err
+		}
+		canonicalEntry.Signature.Content = strfmt.Base64(sig)
+	}
+
+	vexObj := models.Openvex{}
+	vexObj.APIVersion = swag.String(APIVERSION)
+	vexObj.Spec = &canonicalEntry
+
+	return json.Marshal(&vexObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Openvex{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to OpenVEX document or DSSE envelope must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("OpenVEX documents cannot be fetched over HTTP(S)")
+		}
+		artifactBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading artifact file: %w", err)
+		}
+	}
+
+	// a detached signature means a PGP signed document, otherwise the artifact is a DSSE envelope
+	format := models.OpenvexV001SchemaSignatureFormatDsse
+	sigBytes := props.SignatureBytes
+	if sigBytes == nil && props.SignaturePath != nil {
+		sigBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading signature file: %w", err)
+		}
+	}
+	if sigBytes != nil {
+		format = models.OpenvexV001SchemaSignatureFormatPgp
+	}
+
+	publicKeyBytes := props.PublicKeyBytes
+	if publicKeyBytes == nil {
+		if props.PublicKeyPath == nil {
+			return nil, errors.New("public key must be provided to verify signature")
+		}
+		publicKeyBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key file: %w", err)
+		}
+	}
+	kb := strfmt.Base64(publicKeyBytes)
+
+	re := V001Entry{
+		OpenvexObj: models.OpenvexV001Schema{
+			Document: &models.OpenvexV001SchemaDocument{
+				Content: strfmt.Base64(artifactBytes),
+			},
+			Signature: &models.OpenvexV001SchemaSignature{
+				Format:    swag.String(format),
+				Content:   strfmt.Base64(sigBytes),
+				PublicKey: &models.OpenvexV001SchemaSignaturePublicKey{Content: &kb},
+			},
+		},
+	}
+
+	returnVal.Spec = re.OpenvexObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
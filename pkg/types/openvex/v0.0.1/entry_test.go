@@ -0,0 +1,178 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openvex
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	docBytes, _ := ioutil.ReadFile("../../../../tests/test.openvex.json")
+	pgpSig, _ := ioutil.ReadFile("../../../../tests/test.openvex.json.asc")
+	pgpKey, _ := ioutil.ReadFile("../../../../tests/test_openvex.pub")
+
+	newKey := func() (*ecdsa.PrivateKey, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+	key, pub := newKey()
+	_, otherPub := newKey()
+	envelope := func(payloadType string, payload []byte) []byte {
+		h := sha256.Sum256(ssl.PAE(payloadType, string(payload)))
+		sig, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(ssl.Envelope{
+			PayloadType: payloadType,
+			Payload:     base64.StdEncoding.EncodeToString(payload),
+			Signatures:  []ssl.Signature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	predicate := map[string]interface{}{}
+	if err := json.Unmarshal(docBytes, &predicate); err != nil {
+		t.Fatal(err)
+	}
+	statement, err := json.Marshal(in_toto.Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Type:          in_toto.StatementInTotoV01,
+			PredicateType: "https://openvex.dev/ns/v0.2.0",
+		},
+		Predicate: predicate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		content []byte
+		sig     []byte
+		key     []byte
+		wantErr bool
+	}{
+		{name: "pgp signed document", format: "pgp", content: docBytes, sig: pgpSig, key: pgpKey},
+		{name: "pgp tampered document", format: "pgp", content: append(append([]byte{}, docBytes...), '\n'), sig: pgpSig, key: pgpKey, wantErr: true},
+		{name: "pgp without signature", format: "pgp", content: docBytes, key: pgpKey, wantErr: true},
+		{name: "dsse bare document", format: "dsse", content: envelope(payloadType, docBytes), key: pub},
+		{name: "dsse in-toto statement", format: "dsse", content: envelope(in_toto.PayloadType, statement), key: pub},
+		{name: "dsse signed by another key", format: "dsse", content: envelope(payloadType, docBytes), key: otherPub, wantErr: true},
+		{name: "dsse unsupported payload type", format: "dsse", content: envelope("text/plain", docBytes), key: pub, wantErr: true},
+		{name: "dsse with detached signature", format: "dsse", content: envelope(payloadType, docBytes), sig: pgpSig, key: pub, wantErr: true},
+		{name: "dsse invalid document", format: "dsse", content: envelope(payloadType, []byte(`{"@context":"https://openvex.dev/ns/v0.2.0"}`)), key: pub, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb := strfmt.Base64(tt.key)
+			it := &models.Openvex{
+				APIVersion: swag.String(APIVERSION),
+				Spec: &models.OpenvexV001Schema{
+					Document: &models.OpenvexV001SchemaDocument{
+						Content: strfmt.Base64(tt.content),
+					},
+					Signature: &models.OpenvexV001SchemaSignature{
+						Format:    swag.String(tt.format),
+						Content:   strfmt.Base64(tt.sig),
+						PublicKey: &models.OpenvexV001SchemaSignaturePublicKey{Content: &kb},
+					},
+				},
+			}
+			v := &V001Entry{}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			ei, err := types.NewEntry(pe)
+			if err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+			for _, want := range []string{
+				"https://openvex.dev/docs/example/vex-9fb3463de1b57",
+				"cve-2023-1255",
+				"ghsa-h2f5-mq5p-pf5q",
+				"cve-2022-39253",
+				"pkg:apk/wolfi/git@2.39.0-r1?arch=x86_64",
+				"sha256:8b4b8e0b6d298711eb2b6ac5eb4d87e975d3c5ff8c953fc0531109c7692bf1bd",
+			} {
+				found := false
+				for _, k := range ei.IndexKeys() {
+					found = found || k == want
+				}
+				if !found {
+					t.Errorf("IndexKeys() = %v, missing %v", ei.IndexKeys(), want)
+				}
+			}
+		})
+	}
+}
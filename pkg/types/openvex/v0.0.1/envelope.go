@@ -0,0 +1,86 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openvex
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/sigstore/rekor/pkg/pki/x509"
+)
+
+// openEnvelope verifies every signature on the DSSE envelope against key and returns the OpenVEX
+// document it carries, either bare or as the predicate of an in-toto statement
+func openEnvelope(b []byte, key *x509.PublicKey) ([]byte, error) {
+	env := ssl.Envelope{}
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, fmt.Errorf("invalid DSSE envelope: %w", err)
+	}
+	if len(env.Signatures) == 0 {
+		return nil, ssl.ErrNoSignature
+	}
+	body, err := decodeDSSE(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding envelope payload: %w", err)
+	}
+
+	verifier, err := signature.LoadVerifier(key.CryptoPubKey(), crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	pae := ssl.PAE(env.PayloadType, string(body))
+	for i, s := range env.Signatures {
+		sig, err := decodeDSSE(s.Sig)
+		if err != nil {
+			return nil, fmt.Errorf("decoding envelope signature %d: %w", i, err)
+		}
+		if err := verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(pae)); err != nil {
+			return nil, fmt.Errorf("envelope signature %d: %w", i, err)
+		}
+	}
+
+	switch env.PayloadType {
+	case payloadType:
+		return body, nil
+	case in_toto.PayloadType:
+		statement := in_toto.Statement{}
+		if err := json.Unmarshal(body, &statement); err != nil {
+			return nil, fmt.Errorf("invalid in-toto statement: %w", err)
+		}
+		if !strings.HasPrefix(statement.PredicateType, contextPrefix) {
+			return nil, fmt.Errorf("in-toto statement has unsupported predicate type %q", statement.PredicateType)
+		}
+		return json.Marshal(statement.Predicate)
+	}
+	return nil, fmt.Errorf("unsupported envelope payload type %q", env.PayloadType)
+}
+
+// decodeDSSE accepts both standard and URL-safe base64, as allowed by the DSSE specification
+func decodeDSSE(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return base64.URLEncoding.DecodeString(s)
+	}
+	return b, nil
+}
@@ -0,0 +1,100 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/sigstore/rekor/pkg/pki/x509"
+)
+
+// attestation is a PEP 740 attestation object, see
+// https://peps.python.org/pep-0740/#attestation-objects
+type attestation struct {
+	Version              int `json:"version"`
+	VerificationMaterial struct {
+		Certificate []byte `json:"certificate"`
+	} `json:"verification_material"`
+	Envelope struct {
+		Statement []byte `json:"statement"`
+		Signature []byte `json:"signature"`
+	} `json:"envelope"`
+}
+
+// verifiedAttestation is an attestation whose signature and subject have been checked
+type verifiedAttestation struct {
+	certificate *x509.PublicKey
+	statement   *in_toto.Statement
+}
+
+// verifyAttestation checks the attestation's statement is signed by its certificate and that it
+// names the distribution with the given sha256 digest as its subject
+func verifyAttestation(b []byte, filename, sha256Digest string) (*verifiedAttestation, error) {
+	a := attestation{}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, fmt.Errorf("invalid attestation: %w", err)
+	}
+	if a.Version != 1 {
+		return nil, fmt.Errorf("unsupported attestation version %d", a.Version)
+	}
+	if len(a.VerificationMaterial.Certificate) == 0 {
+		return nil, errors.New("attestation does not contain a signing certificate")
+	}
+	if len(a.Envelope.Statement) == 0 || len(a.Envelope.Signature) == 0 {
+		return nil, errors.New("attestation envelope must contain a statement and signature")
+	}
+
+	// the certificate is subject to the trust roots configured for x509 keys
+	cert, err := x509.NewPublicKey(bytes.NewReader(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.VerificationMaterial.Certificate})))
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := signature.LoadVerifier(cert.CryptoPubKey(), crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	pae := ssl.PAE(in_toto.PayloadType, string(a.Envelope.Statement))
+	if err := verifier.VerifySignature(bytes.NewReader(a.Envelope.Signature), bytes.NewReader(pae)); err != nil {
+		return nil, fmt.Errorf("verifying attestation signature: %w", err)
+	}
+
+	statement := &in_toto.Statement{}
+	if err := json.Unmarshal(a.Envelope.Statement, statement); err != nil {
+		return nil, fmt.Errorf("invalid in-toto statement: %w", err)
+	}
+	// PEP 740 requires exactly one subject, the distribution itself
+	if len(statement.Subject) != 1 {
+		return nil, fmt.Errorf("attestation must have exactly one subject, found %d", len(statement.Subject))
+	}
+	subject := statement.Subject[0]
+	if subject.Name != filename {
+		return nil, fmt.Errorf("attestation subject %q does not match distribution %q", subject.Name, filename)
+	}
+	if !strings.EqualFold(subject.Digest["sha256"], sha256Digest) {
+		return nil, fmt.Errorf("attestation digest does not match distribution %q", filename)
+	}
+	return &verifiedAttestation{certificate: cert, statement: statement}, nil
+}
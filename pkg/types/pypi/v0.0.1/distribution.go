@@ -0,0 +1,64 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sdistSuffixes are the source distribution archive formats PyPI accepts
+var sdistSuffixes = []string{".tar.gz", ".zip"}
+
+// parseFilename returns the project name and version of a wheel or source distribution, see
+// https://packaging.python.org/en/latest/specifications/binary-distribution-format/ and
+// https://packaging.python.org/en/latest/specifications/source-distribution-format/
+func parseFilename(filename string) (name, version string, err error) {
+	if strings.ContainsAny(filename, "/\\") {
+		return "", "", fmt.Errorf("distribution filename %q must not contain a path", filename)
+	}
+	if base := strings.TrimSuffix(filename, ".whl"); base != filename {
+		// {name}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}
+		parts := strings.Split(base, "-")
+		if len(parts) != 5 && len(parts) != 6 {
+			return "", "", fmt.Errorf("invalid wheel filename %q", filename)
+		}
+		name, version = parts[0], parts[1]
+	} else {
+		for _, suffix := range sdistSuffixes {
+			if base := strings.TrimSuffix(filename, suffix); base != filename {
+				// the name may contain dashes in older sdists, the version may not
+				if i := strings.LastIndex(base, "-"); i >= 0 {
+					name, version = base[:i], base[i+1:]
+				}
+				break
+			}
+		}
+	}
+	if name == "" || version == "" {
+		return "", "", fmt.Errorf("invalid distribution filename %q", filename)
+	}
+	return normalizeName(name), version, nil
+}
+
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeName normalizes a project name as described in
+// https://packaging.python.org/en/latest/specifications/name-normalization/
+func normalizeName(name string) string {
+	return strings.ToLower(nameSeparators.ReplaceAllString(name, "-"))
+}
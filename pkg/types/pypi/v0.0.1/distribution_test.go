@@ -0,0 +1,46 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import "testing"
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		name     string
+		version  string
+		wantErr  bool
+	}{
+		{filename: "requests-2.31.0-py3-none-any.whl", name: "requests", version: "2.31.0"},
+		{filename: "Sample_Project-1.2.0-1-cp311-cp311-manylinux_2_17_x86_64.whl", name: "sample-project", version: "1.2.0"},
+		{filename: "sample_project-1.2.0.tar.gz", name: "sample-project", version: "1.2.0"},
+		{filename: "zope.interface-6.0.zip", name: "zope-interface", version: "6.0"},
+		{filename: "old-style-name-0.1.tar.gz", name: "old-style-name", version: "0.1"},
+		{filename: "requests-2.31.0-none-any.whl", wantErr: true},
+		{filename: "requests.tar.gz", wantErr: true},
+		{filename: "requests-2.31.0.egg", wantErr: true},
+		{filename: "dist/requests-2.31.0.tar.gz", wantErr: true},
+	}
+	for _, tt := range tests {
+		name, version, err := parseFilename(tt.filename)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseFilename(%q) error = %v, wantErr %v", tt.filename, err, tt.wantErr)
+		}
+		if name != tt.name || version != tt.version {
+			t.Errorf("parseFilename(%q) = %v, %v, want %v, %v", tt.filename, name, version, tt.name, tt.version)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/pypi"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := pypi.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	PypiObj     models.PypiV001Schema
+	attestation *verifiedAttestation
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if d := v.PypiObj.Distribution; d != nil {
+		if name, version, err := parseFilename(swag.StringValue(d.Filename)); err == nil {
+			result = append(result, name, name+"=="+version)
+		}
+		if d.Hash != nil {
+			result = append(result, swag.StringValue(d.Hash.Algorithm)+":"+strings.ToLower(swag.StringValue(d.Hash.Value)))
+		}
+	}
+
+	if v.attestation != nil {
+		result = append(result, pki.IdentityIndexKeys(v.attestation.certificate)...)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Pypi)
+	if !ok {
+		return errors.New("cannot unmarshal non PyPI v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.PypiObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.PypiObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	d := v.PypiObj.Distribution
+	if d == nil || d.Hash == nil {
+		return errors.New("missing distribution")
+	}
+	filename := swag.StringValue(d.Filename)
+	if _, _, err := parseFilename(filename); err != nil {
+		return err
+	}
+	digest := swag.StringValue(d.Hash.Value)
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != sha256.Size {
+		return errors.New("invalid value for distribution hash")
+	}
+	if v.PypiObj.Attestation == nil {
+		return errors.New("missing attestation")
+	}
+
+	// This also gets called in the CLI, where we won't have this data
+	if len(v.PypiObj.Attestation.Content) == 0 {
+		return nil
+	}
+
+	a, err := verifyAttestation(v.PypiObj.Attestation.Content, filename, digest)
+	if err != nil {
+		return err
+	}
+	v.attestation = a
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.attestation == nil {
+		return nil, errors.New("cannot canonicalize entry without a verified attestation")
+	}
+
+	cert, err := v.attestation.certificate.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(v.PypiObj.Attestation.Content)
+
+	canonicalEntry := models.PypiV001Schema{
+		Distribution: &models.PypiV001SchemaDistribution{
+			Filename: v.PypiObj.Distribution.Filename,
+			Hash: &models.PypiV001SchemaDistributionHash{
+				Algorithm: v.PypiObj.Distribution.Hash.Algorithm,
+				Value:     swag.String(strings.ToLower(swag.StringValue(v.PypiObj.Distribution.Hash.Value))),
+			},
+		},
+		Attestation: &models.PypiV001SchemaAttestation{
+			Hash: &models.PypiV001SchemaAttestationHash{
+				Algorithm: swag.String(models.PypiV001SchemaAttestationHashAlgorithmSha256),
+				Value:     swag.String(hex.EncodeToString(h[:])),
+			},
+			Certificate: cert,
+		},
+		ExtraData: v.PypiObj.ExtraData,
+	}
+
+	pypiObj := models.Pypi{}
+	pypiObj.APIVersion = swag.String(APIVERSION)
+	pypiObj.Spec = &canonicalEntry
+
+	return json.Marshal(&pypiObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Pypi{}
+
+	if props.ArtifactPath == nil {
+		return nil, errors.New("path to the distribution must be specified")
+	}
+	if props.ArtifactPath.IsAbs() {
+		return nil, errors.New("distributions cannot be fetched over HTTP(S)")
+	}
+	f, err := os.Open(filepath.Clean(props.ArtifactPath.Path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, fmt.Errorf("error hashing distribution: %w", err)
+	}
+
+	attestationBytes := props.SignatureBytes
+	if attestationBytes == nil {
+		if props.SignaturePath == nil {
+			return nil, errors.New("path to the attestation must be specified")
+		}
+		attestationBytes, err = ioutil.ReadFile(filepath.Clean(props.SignaturePath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading attestation: %w", err)
+		}
+	}
+
+	re := V001Entry{
+		PypiObj: models.PypiV001Schema{
+			Distribution: &models.PypiV001SchemaDistribution{
+				Filename: swag.String(filepath.Base(props.ArtifactPath.Path)),
+				Hash: &models.PypiV001SchemaDistributionHash{
+					Algorithm: swag.String(models.PypiV001SchemaDistributionHashAlgorithmSha256),
+					Value:     swag.String(hex.EncodeToString(hasher.Sum(nil))),
+				},
+			},
+			Attestation: &models.PypiV001SchemaAttestation{
+				Content: strfmt.Base64(attestationBytes),
+			},
+		},
+	}
+
+	if err := re.validate(); err != nil {
+		return nil, err
+	}
+
+	returnVal.Spec = re.PypiObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
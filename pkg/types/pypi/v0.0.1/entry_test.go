@@ -0,0 +1,169 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pypi
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"github.com/in-toto/in-toto-golang/pkg/ssl"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const wheel = "Sample_Project-1.2.0-py3-none-any.whl"
+
+var wheelHash = sha256.Sum256([]byte("wheel"))
+
+// newAttestation returns a PEP 740 attestation over subject, signed by a self-signed certificate
+func newAttestation(t *testing.T, subject string, digest []byte, version int) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "sigstore"},
+		EmailAddresses: []string{"ci@example.com"},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://docs.pypi.org/attestations/publish/v1","subject":[{"name":%q,"digest":{"sha256":%q}}],"predicate":null}`,
+		subject, hex.EncodeToString(digest))
+	h := sha256.Sum256(ssl.PAE("application/vnd.in-toto+json", statement))
+	sig, err := key.Sign(rand.Reader, h[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := attestation{Version: version}
+	a.VerificationMaterial.Certificate = der
+	a.Envelope.Statement = []byte(statement)
+	a.Envelope.Signature = sig
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	valid := newAttestation(t, wheel, wheelHash[:], 1)
+	tampered := bytes.Replace(valid, []byte(`"signature":"`), []byte(`"signature":"AAAA`), 1)
+	otherHash := sha256.Sum256([]byte("other"))
+
+	tests := []struct {
+		name        string
+		filename    string
+		digest      string
+		attestation []byte
+		wantErr     bool
+		noVerify    bool
+	}{
+		{name: "valid", filename: wheel, attestation: valid},
+		{name: "without attestation content", filename: wheel, noVerify: true},
+		{name: "different filename", filename: "other-1.2.0-py3-none-any.whl", attestation: valid, wantErr: true},
+		{name: "different digest", filename: wheel, digest: hex.EncodeToString(otherHash[:]), attestation: valid, wantErr: true},
+		{name: "invalid digest", filename: wheel, digest: "abc", attestation: valid, wantErr: true},
+		{name: "invalid filename", filename: "sample.whl", attestation: valid, wantErr: true},
+		{name: "invalid signature", filename: wheel, attestation: tampered, wantErr: true},
+		{name: "unsupported version", filename: wheel, attestation: newAttestation(t, wheel, wheelHash[:], 2), wantErr: true},
+		{name: "not json", filename: wheel, attestation: []byte("hello"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest := tt.digest
+			if digest == "" {
+				digest = hex.EncodeToString(wheelHash[:])
+			}
+			it := &models.Pypi{
+				APIVersion: swag.String(APIVERSION),
+				Spec: &models.PypiV001Schema{
+					Distribution: &models.PypiV001SchemaDistribution{
+						Filename: swag.String(tt.filename),
+						Hash: &models.PypiV001SchemaDistributionHash{
+							Algorithm: swag.String(models.PypiV001SchemaDistributionHashAlgorithmSha256),
+							Value:     swag.String(digest),
+						},
+					},
+					Attestation: &models.PypiV001SchemaAttestation{Content: tt.attestation},
+				},
+			}
+			v := &V001Entry{}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr || tt.noVerify {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+			want := []string{"sample-project", "sample-project==1.2.0", "sha256:" + hex.EncodeToString(wheelHash[:]), "ci@example.com"}
+			for _, w := range want {
+				found := false
+				for _, k := range v.IndexKeys() {
+					found = found || k == w
+				}
+				if !found {
+					t.Errorf("IndexKeys() = %v, missing %v", v.IndexKeys(), w)
+				}
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatalf("unexpected err from Unmarshalling canonicalized entry: %v", err)
+			}
+			if _, err := types.NewEntry(pe); err != nil {
+				t.Fatalf("unexpected err from type-specific unmarshalling: %v", err)
+			}
+		})
+	}
+}
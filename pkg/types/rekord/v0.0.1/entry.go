@@ -61,6 +61,14 @@ func (v V001Entry) APIVersion() string {
 	return APIVERSION
 }
 
+// PKIFormat returns the format of the signature and public key of the entry
+func (v V001Entry) PKIFormat() string {
+	if v.RekordObj.Signature == nil {
+		return ""
+	}
+	return v.RekordObj.Signature.Format
+}
+
 func NewEntry() types.EntryImpl {
 	return &V001Entry{}
 }
@@ -75,15 +83,25 @@ func (v V001Entry) IndexKeys() []string {
 		}
 	}
 
-	key, err := v.keyObj.CanonicalValue()
-	if err != nil {
-		log.Logger.Error(err)
-	} else {
-		keyHash := sha256.Sum256(key)
-		result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+	keyObj := v.keyObj
+	if keyObj == nil && v.RekordObj.Signature != nil && v.RekordObj.Signature.PublicKey != nil {
+		// entries read back from the log carry the public key, but have not had their external entities fetched
+		var err error
+		if keyObj, err = pki.ParsePublicKey(pki.Format(v.RekordObj.Signature.Format), v.RekordObj.Signature.PublicKey.Content); err != nil {
+			log.Logger.Error(err)
+		}
 	}
+	if keyObj != nil {
+		key, err := keyObj.CanonicalValue()
+		if err != nil {
+			log.Logger.Error(err)
+		} else {
+			keyHash := sha256.Sum256(key)
+			result = append(result, strings.ToLower(hex.EncodeToString(keyHash[:])))
+		}
 
-	result = append(result, v.keyObj.EmailAddresses()...)
+		result = append(result, pki.IdentityIndexKeys(keyObj)...)
+	}
 
 	if v.RekordObj.Data.Hash != nil {
 		hashKey := strings.ToLower(fmt.Sprintf("%s:%s", *v.RekordObj.Data.Hash.Algorithm, *v.RekordObj.Data.Hash.Value))
@@ -319,6 +337,11 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	// formats accepting several signature schemes report the one that verified, unless it is the
+	// default one; leaving it out keeps the canonical form (and UUID) of existing entries unchanged
+	if a, ok := v.sigObj.(interface{ NonDefaultAlgorithm() string }); ok {
+		canonicalEntry.Signature.Algorithm = a.NonDefaultAlgorithm()
+	}
 
 	// key URL (if known) is not set deliberately
 	canonicalEntry.Signature.PublicKey = &models.RekordV001SchemaSignaturePublicKey{}
@@ -353,6 +376,9 @@ func (v V001Entry) validate() error {
 	if sig == nil {
 		return errors.New("missing signature")
 	}
+	if !pki.IsSupportedFormat(pki.Format(sig.Format)) {
+		return fmt.Errorf("unsupported PKI format %q", sig.Format)
+	}
 	if len(sig.Content) == 0 && sig.URL.String() == "" {
 		return errors.New("one of 'content' or 'url' must be specified for signature")
 	}
@@ -419,16 +445,7 @@ func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types
 	}
 
 	re.RekordObj.Signature = &models.RekordV001SchemaSignature{}
-	switch props.PKIFormat {
-	case "pgp":
-		re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatPgp
-	case "minisign":
-		re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatMinisign
-	case "x509":
-		re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatX509
-	case "ssh":
-		re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatSSH
-	}
+	re.RekordObj.Signature.Format = props.PKIFormat
 	sigBytes := props.SignatureBytes
 	if sigBytes == nil {
 		if props.SignaturePath == nil {
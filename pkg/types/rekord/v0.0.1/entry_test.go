@@ -19,8 +19,10 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -33,6 +35,8 @@ import (
 	"go.uber.org/goleak"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/x509"
 	"github.com/sigstore/rekor/pkg/types"
 )
 
@@ -569,3 +573,173 @@ func TestCrossFieldValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestIndexKeysCanonicalized(t *testing.T) {
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test_file.sig")
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_public_key.key")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test_file.txt")
+
+	v := &V001Entry{
+		RekordObj: models.RekordV001Schema{
+			Signature: &models.RekordV001SchemaSignature{
+				Format:  "pgp",
+				Content: strfmt.Base64(sigBytes),
+				PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(keyBytes),
+				},
+			},
+			Data: &models.RekordV001SchemaData{
+				Content: strfmt.Base64(dataBytes),
+			},
+		},
+	}
+	b, err := v.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := v.IndexKeys()
+
+	// an entry read back from the log only has the data hash and the key content
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical, err := types.NewEntry(pe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := canonical.IndexKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("IndexKeys() of canonicalized entry = %v, want %v", got, want)
+	}
+}
+
+const (
+	x509ECDSAPub = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEMx5pFtqg9j0QmiLpb5998ZKUmtgJ
+S2CDOA+0sFC+Yacs6au7GLDeH8l/5fOZAnXxtIoIisbwZ85W6CKeA5yNBA==
+-----END PUBLIC KEY-----
+`
+	x509ECDSASig = "MEQCIBAhFIDEwpKr3JwXNJs3IwAyGjZ60VujPF3bDqgaZOi9AiADAcVnZVRuWznD92G2lbL5PGR3Uc72YP4YsS69IAlY9Q=="
+	x509RSAPub   = `-----BEGIN PUBLIC KEY-----
+MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA8AVgiDT9WmcqZQfkSr90
+ijhHdig9xjq18nqtpeA6zcclhEZ70k3ExUfNDJTXBe3h5ewLZGgzcqMpFQFV0zsC
+uI6OA41G9ca1KFbY+t9TTfF/N1MOBpk/7eJpOAU1Akt5PC3NEp3hR/nLHR0XMUpY
+tY+38qRGB7yOUqKbpl2sJIQ8+7hh8TGcSQq99vuPEenq5xwY2r+6d55lOgdsNHEf
+KdIaVpbwyqbiJd4EpJfjDti9Jd5nL+vKwJ+tx3G+tGgKHFCqNC+CCmnkAfy0iOCt
+TXHIdkD57aevYNwuXYhDPUVtt7KDTQUmSB0b9Y/03tNHbXXjTEsH5sDl82BtQ/mX
+LwIDAQAB
+-----END PUBLIC KEY-----
+`
+	x509RSASig    = "fdJ+vQbVoGVldZ3pTesvXAmdBWDQ5qcbMYkl5BEOPhQ1C1oCuJzmaS47fHV+rWGlwBTZLAoI3HACM+pDPO1inSrgdrEHm0ZoHok+qkAravEWIe+la6wC1rBB/2N1hWnuRq9mroqwSf0ykqmxfwa2w7LzH/RXN2x70YOYVtpFyHL0Qy+do3ynb429Mpfyi8mU31hLCxeoaJ/9Cfn00+HYtkQRVyoorkrF21Ve8Ug3umM7/bRzHxMVJUOixTP+ctwHDX8+Zv4lldtaATk6yPW2/xx2MuMSOsR0zGihGJS0hBplF2THXSMeGRJGk22YD9dACV5sT4bZc0/Qt90VDEUAXw=="
+	x509RSAPSSSig = "2AwzDAuHFkMqPCE5Bhd0TR6J3LDaJQmxlHRSr4dFoaK7H6LTXIHMdE8bzDFPk+LbIPDto8lTvCdpRVevklbYcp+KbtHZ1kb71/edGfRbmc8PaB8kIMX8BgLNnkYSN7llSRkHj5frDEiOdt6f34Kmur+LODLl1omLjdL/z4xWwsT/jhg+gB8UtGgHiodM9KpTk4/0DLtCD4vcTAJy8x9uy74hV8HBxVTA3A8Hvo4U1s7vAgOo7GCoByMSKhVn+Tg+SadlQDwe7D2QDf1DI2r4vYe1vH0NQ2X2ZHLqdGW9AFzmJLWyGsaq0MzTeFbus4jH8dDsaE7g6iCVQ7aqUwxNHA=="
+)
+
+func TestCanonicalizeX509(t *testing.T) {
+	data := []byte("hello rekor\n")
+	tests := []struct {
+		name string
+		pub  string
+		sig  string
+		// want is the sha256 of the canonicalized entry; for the default schemes it must match
+		// entries logged before the signature scheme was recorded
+		want      string
+		algorithm string
+	}{
+		{name: "ecdsa", pub: x509ECDSAPub, sig: x509ECDSASig, want: "c3542d835aa71e29fce696f243bddc315106a5eb1b28ebe1230836e1281e1886"},
+		{name: "rsa pkcs1v15", pub: x509RSAPub, sig: x509RSASig, want: "afa3a558d28c570146e10df99920cd4f4f45da6dd2c9e19ecdbd4ed7fa7424ea"},
+		{name: "rsa pss", pub: x509RSAPub, sig: x509RSAPSSSig, want: "3c6f386d6d537bd771f7dff0aa03b677831a231b446bc6a313139abed9430ec6", algorithm: "rsa-pss-sha256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := base64.StdEncoding.DecodeString(tt.sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := &V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format:  "x509",
+						Content: strfmt.Base64(sig),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(tt.pub),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Content: strfmt.Base64(data),
+					},
+				},
+			}
+			b, err := v.Canonicalize(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sha256.Sum256(b); hex.EncodeToString(got[:]) != tt.want {
+				t.Errorf("Canonicalize() = %s, want sha256 %s", b, tt.want)
+			}
+
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := pe.(*models.Rekord).Spec.(map[string]interface{})["signature"].(map[string]interface{})["algorithm"]; (got != nil || tt.algorithm != "") && got != tt.algorithm {
+				t.Errorf("signature.algorithm = %v, want %q", got, tt.algorithm)
+			}
+		})
+	}
+}
+
+func TestRegisteredPKIFormat(t *testing.T) {
+	// a format registered at runtime, verifying like x509
+	if err := pki.RegisterFormat("x509-custom", pki.FormatFactory{
+		NewPublicKey: func(r io.Reader) (pki.PublicKey, error) {
+			return x509.NewPublicKey(r)
+		},
+		NewSignature: func(r io.Reader) (pki.Signature, error) {
+			return x509.NewSignature(r)
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(x509ECDSASig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pe, err := NewEntry().CreateFromArtifactProperties(context.TODO(), types.ArtifactProperties{
+		ArtifactBytes:  []byte("hello rekor\n"),
+		SignatureBytes: sig,
+		PublicKeyBytes: []byte(x509ECDSAPub),
+		PKIFormat:      "x509-custom",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := types.NewEntry(pe)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling entry with registered format: %v", err)
+	}
+	b, err := entry.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error canonicalizing entry with registered format: %v", err)
+	}
+	canonical, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := types.NewEntry(canonical); err != nil {
+		t.Errorf("unexpected error unmarshalling canonicalized entry: %v", err)
+	}
+
+	pe, err = NewEntry().CreateFromArtifactProperties(context.TODO(), types.ArtifactProperties{
+		ArtifactBytes:  []byte("hello rekor\n"),
+		SignatureBytes: sig,
+		PublicKeyBytes: []byte(x509ECDSAPub),
+		PKIFormat:      "unregistered",
+	})
+	if err == nil {
+		_, err = types.NewEntry(pe)
+	}
+	if err == nil {
+		t.Error("expected error for an unregistered format")
+	}
+}
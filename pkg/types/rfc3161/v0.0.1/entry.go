@@ -18,6 +18,7 @@ package rfc3161
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
 	"encoding/hex"
@@ -35,6 +36,8 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -89,6 +92,23 @@ func (v V001Entry) IndexKeys() []string {
 
 		payloadKey := "sha256:" + hx
 		result = append(result, payloadKey)
+
+		var tsr pkcs9.TimeStampResp
+		if _, err := asn1.Unmarshal(tb, &tsr); err != nil {
+			log.Logger.Warn(err)
+			return result
+		}
+		info, err := parseTSTInfo(&tsr.TimeStampToken)
+		if err != nil {
+			log.Logger.Warn(err)
+			return result
+		}
+		imprintKey, err := messageImprintKey(info)
+		if err != nil {
+			log.Logger.Warn(err)
+			return result
+		}
+		result = append(result, imprintKey)
 	}
 
 	return result
@@ -126,6 +146,7 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 		Tsr: &models.Rfc3161V001SchemaTsr{
 			Content: v.tsrContent,
 		},
+		TsaCertificate: v.Rfc3161Obj.TsaCertificate,
 	}
 
 	// ExtraData is copied through unfiltered
@@ -169,9 +190,23 @@ func (v V001Entry) validate() error {
 	if !tsr.TimeStampToken.ContentType.Equal(asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}) {
 		return fmt.Errorf("tsr wrong content type: %v", tsr.TimeStampToken.ContentType)
 	}
-	_, err = tsr.TimeStampToken.Content.Verify(nil, false)
+
+	var supplied []*x509.Certificate
+	if v.Rfc3161Obj.TsaCertificate != nil {
+		supplied, err = cryptoutils.UnmarshalCertificatesFromPEM(*v.Rfc3161Obj.TsaCertificate.Content)
+		if err != nil {
+			return fmt.Errorf("invalid tsa certificate: %w", err)
+		}
+		if len(supplied) == 0 {
+			return errors.New("no certificates found in 'tsaCertificate'")
+		}
+	}
+	roots, err := loadTrustedRoots(viper.GetString("rfc3161_tsa_roots"))
 	if err != nil {
-		return fmt.Errorf("tsr verification error: %v", err)
+		return err
+	}
+	if _, err := verifyToken(&tsr.TimeStampToken, supplied, roots); err != nil {
+		return err
 	}
 
 	return nil
@@ -208,6 +243,24 @@ func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.A
 		},
 	}
 
+	// the certificate of the timestamping authority is optional
+	certBytes := props.PublicKeyBytes
+	if certBytes == nil && props.PublicKeyPath != nil {
+		if props.PublicKeyPath.IsAbs() {
+			return nil, errors.New("timestamping authority certificates cannot be fetched over HTTP(S)")
+		}
+		certBytes, err = ioutil.ReadFile(filepath.Clean(props.PublicKeyPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading timestamping authority certificate: %w", err)
+		}
+	}
+	if certBytes != nil {
+		cb := strfmt.Base64(certBytes)
+		re.Rfc3161Obj.TsaCertificate = &models.Rfc3161V001SchemaTsaCertificate{
+			Content: &cb,
+		}
+	}
+
 	returnVal.Spec = re.Rfc3161Obj
 	returnVal.APIVersion = swag.String(re.APIVersion())
 
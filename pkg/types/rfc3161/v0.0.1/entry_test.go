@@ -18,14 +18,23 @@ package rfc3161
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/asn1"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sassoftware/relic/lib/pkcs9"
 
@@ -36,6 +45,8 @@ import (
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/spf13/viper"
 )
 
 func TestMain(m *testing.M) {
@@ -59,6 +70,8 @@ func TestCrossFieldValidation(t *testing.T) {
 	}
 
 	tsrBytes, _ := ioutil.ReadFile("../../../../tests/test.tsr")
+	chainBytes, _ := ioutil.ReadFile("../../../../tests/test_tsa_chain.pem")
+	rootOnly := chainBytes[bytes.Index(chainBytes, []byte("-----END CERTIFICATE-----"))+len("-----END CERTIFICATE-----\n"):]
 
 	testServer := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -152,6 +165,68 @@ func TestCrossFieldValidation(t *testing.T) {
 			expectCanonicalizeSuccess:    false,
 			expectValidationErrorMessage: "tsr verification error",
 		},
+		{
+			caseDesc: "valid obj with supplied tsa certificate",
+			entry: V001Entry{
+				Rfc3161Obj: models.Rfc3161V001Schema{
+					Tsr: &models.Rfc3161V001SchemaTsr{
+						Content: p(tsrBytes),
+					},
+					TsaCertificate: &models.Rfc3161V001SchemaTsaCertificate{
+						Content: p(chainBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "valid obj without embedded certificates and supplied tsa certificate",
+			entry: V001Entry{
+				Rfc3161Obj: models.Rfc3161V001Schema{
+					Tsr: &models.Rfc3161V001SchemaTsr{
+						Content: p(tBadContent(t, tsrBytes)),
+					},
+					TsaCertificate: &models.Rfc3161V001SchemaTsaCertificate{
+						Content: p(chainBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "invalid obj - tsr not signed by supplied tsa certificate",
+			entry: V001Entry{
+				Rfc3161Obj: models.Rfc3161V001Schema{
+					Tsr: &models.Rfc3161V001SchemaTsr{
+						Content: p(tsrBytes),
+					},
+					TsaCertificate: &models.Rfc3161V001SchemaTsaCertificate{
+						Content: p(rootOnly),
+					},
+				},
+			},
+			expectUnmarshalSuccess:       false,
+			expectCanonicalizeSuccess:    false,
+			expectValidationErrorMessage: "tsr was not signed by the supplied timestamping authority certificate",
+		},
+		{
+			caseDesc: "invalid obj - malformed tsa certificate",
+			entry: V001Entry{
+				Rfc3161Obj: models.Rfc3161V001Schema{
+					Tsr: &models.Rfc3161V001SchemaTsr{
+						Content: p(tsrBytes),
+					},
+					TsaCertificate: &models.Rfc3161V001SchemaTsaCertificate{
+						Content: p([]byte("not a certificate")),
+					},
+				},
+			},
+			expectUnmarshalSuccess:       false,
+			expectCanonicalizeSuccess:    false,
+			expectValidationErrorMessage: "invalid tsa certificate",
+		},
 		{
 			caseDesc: "valid obj with extra data",
 			entry: V001Entry{
@@ -202,6 +277,97 @@ func TestCrossFieldValidation(t *testing.T) {
 	}
 }
 
+func TestIndexKeys(t *testing.T) {
+	tsrBytes, err := ioutil.ReadFile("../../../../tests/test.tsr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := V001Entry{
+		Rfc3161Obj: models.Rfc3161V001Schema{
+			Tsr: &models.Rfc3161V001SchemaTsr{
+				Content: p(tsrBytes),
+			},
+		},
+	}
+	keys := v.IndexKeys()
+	want := "sha256:1fbc02de3d979c27aff8fc39671d4e07541a475bb49312049a17e05aeed57021"
+	found := false
+	for _, k := range keys {
+		if k == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected message imprint %s in index keys %v", want, keys)
+	}
+}
+
+func TestTrustedRoots(t *testing.T) {
+	tsrBytes, err := ioutil.ReadFile("../../../../tests/test.tsr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainBytes, err := ioutil.ReadFile("../../../../tests/test_tsa_chain.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := cryptoutils.UnmarshalCertificatesFromPEM(chainBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Untrusted Root"},
+		NotBefore:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	otherDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &otherKey.PublicKey, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := x509.ParseCertificate(otherDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		root    *x509.Certificate
+		wantErr bool
+	}{
+		{name: "trusted root", root: chain[len(chain)-1]},
+		{name: "untrusted root", root: other, wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pemBytes, err := cryptoutils.MarshalCertificateToPEM(tt.root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(dir, fmt.Sprintf("roots%d.pem", i))
+			if err := ioutil.WriteFile(path, pemBytes, 0600); err != nil {
+				t.Fatal(err)
+			}
+			viper.Set("rfc3161_tsa_roots", path)
+			defer viper.Set("rfc3161_tsa_roots", "")
+
+			v := &V001Entry{}
+			err = v.Unmarshal(NewEntryFromBytes(tsrBytes))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func tTooBig() []byte {
 	lotsOfBytes := make([]byte, 10*1024+1)
 	for i := 0; i < len(lotsOfBytes); i++ {
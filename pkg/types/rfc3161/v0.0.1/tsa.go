@@ -0,0 +1,153 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rfc3161
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/sassoftware/relic/lib/pkcs7"
+	"github.com/sassoftware/relic/lib/pkcs9"
+	"github.com/sassoftware/relic/lib/x509tools"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// parseTSTInfo extracts the TSTInfo structure signed by the timestamping authority
+func parseTSTInfo(tst *pkcs7.ContentInfoSignedData) (*pkcs9.TSTInfo, error) {
+	infoBytes, err := tst.Content.ContentInfo.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("reading TSTInfo: %w", err)
+	}
+	if len(infoBytes) == 0 {
+		return nil, errors.New("tsr does not contain a TSTInfo")
+	}
+	// most TSAs wrap the TSTInfo in an OCTET STRING
+	if infoBytes[0] == asn1.TagOctetString {
+		if _, err := asn1.Unmarshal(infoBytes, &infoBytes); err != nil {
+			return nil, fmt.Errorf("reading TSTInfo: %w", err)
+		}
+	}
+	info := &pkcs9.TSTInfo{}
+	if _, err := asn1.Unmarshal(infoBytes, info); err != nil {
+		return nil, fmt.Errorf("parsing TSTInfo: %w", err)
+	}
+	return info, nil
+}
+
+// messageImprintKey renders the hash covered by the timestamp as an index key, e.g. sha256:<hex>
+func messageImprintKey(info *pkcs9.TSTInfo) (string, error) {
+	hash, err := x509tools.PkixDigestToHashE(info.MessageImprint.HashAlgorithm)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(fmt.Sprintf("%s:%s", x509tools.HashShortName(hash), hex.EncodeToString(info.MessageImprint.HashedMessage))), nil
+}
+
+// verifyToken checks the signature over a timestamp token. If the certificate chain of the
+// timestamping authority is supplied, the token must be signed by the first certificate in it;
+// if the server is configured with trusted roots, the signing certificate must chain up to one
+// of them at the time the timestamp was issued.
+func verifyToken(tst *pkcs7.ContentInfoSignedData, supplied []*x509.Certificate, roots *x509.CertPool) (*pkcs9.TSTInfo, error) {
+	info, err := parseTSTInfo(tst)
+	if err != nil {
+		return nil, err
+	}
+	if len(tst.Content.SignerInfos) != 1 {
+		return nil, errors.New("tsr must have exactly one signer")
+	}
+	content, err := tst.Content.ContentInfo.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	embedded, err := tst.Content.Certificates.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("parsing tsr certificates: %w", err)
+	}
+	certs := append(append([]*x509.Certificate{}, supplied...), embedded...)
+
+	signer, err := tst.Content.SignerInfos[0].Verify(content, false, certs)
+	if err != nil {
+		return nil, fmt.Errorf("tsr verification error: %w", err)
+	}
+	if len(supplied) > 0 && !signer.Equal(supplied[0]) {
+		return nil, errors.New("tsr was not signed by the supplied timestamping authority certificate")
+	}
+	if len(supplied) == 0 && roots == nil {
+		return info, nil
+	}
+
+	if !hasTimestampingUsage(signer) {
+		return nil, errors.New("tsr signing certificate is not valid for timestamping")
+	}
+	if roots != nil {
+		genTime, err := info.SigningTime()
+		if err != nil {
+			return nil, fmt.Errorf("parsing tsr time: %w", err)
+		}
+		intermediates := x509.NewCertPool()
+		for _, c := range certs {
+			intermediates.AddCert(c)
+		}
+		if _, err := signer.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   genTime,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+		}); err != nil {
+			return nil, fmt.Errorf("tsr signing certificate is not trusted: %w", err)
+		}
+	}
+	return info, nil
+}
+
+func hasTimestampingUsage(c *x509.Certificate) bool {
+	for _, usage := range c.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageTimeStamping {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTrustedRoots reads the timestamping authority roots the server trusts; a nil pool is
+// returned if none are configured
+func loadTrustedRoots(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading trusted timestamping authority roots: %w", err)
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing trusted timestamping authority roots: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no trusted timestamping authority roots found")
+	}
+	roots := x509.NewCertPool()
+	for _, c := range certs {
+		roots.AddCert(c)
+	}
+	return roots, nil
+}
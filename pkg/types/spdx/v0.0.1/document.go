@@ -0,0 +1,219 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	documentID = "SPDXRef-DOCUMENT"
+	// maxLineLength bounds a single tag-value line, including <text> continuations
+	maxLineLength = 1024 * 1024
+)
+
+// spdxDocument holds the parts of an SPDX document that rekor records
+type spdxDocument struct {
+	namespace string
+	// packageChecksums are the checksums of described packages, formatted as algorithm:value
+	packageChecksums []string
+}
+
+type spdxPackage struct {
+	id        string
+	checksums []string
+}
+
+// parseDocument parses an SPDX document in either JSON or tag-value format
+func parseDocument(b []byte) (*spdxDocument, error) {
+	if t := bytes.TrimSpace(b); len(t) > 0 && t[0] == '{' {
+		return parseJSONDocument(t)
+	}
+	return parseTagValueDocument(b)
+}
+
+func formatChecksum(algorithm, value string) string {
+	return strings.ToLower(strings.TrimSpace(algorithm)) + ":" + strings.ToLower(strings.TrimSpace(value))
+}
+
+// newDocument validates the document-level fields and collects the checksums of the described
+// packages; described may contain duplicates and identifiers of elements which are not packages
+func newDocument(version, namespace string, described []string, packages []spdxPackage) (*spdxDocument, error) {
+	if !strings.HasPrefix(version, "SPDX-") {
+		return nil, fmt.Errorf("invalid SPDX version %q", version)
+	}
+	if namespace == "" {
+		return nil, errors.New("SPDX document does not specify a DocumentNamespace")
+	}
+
+	byID := make(map[string]spdxPackage, len(packages))
+	for _, p := range packages {
+		byID[p.id] = p
+	}
+	seen := map[string]bool{}
+	doc := &spdxDocument{namespace: namespace}
+	for _, id := range described {
+		for _, c := range byID[id].checksums {
+			if !seen[c] {
+				seen[c] = true
+				doc.packageChecksums = append(doc.packageChecksums, c)
+			}
+		}
+	}
+	sort.Strings(doc.packageChecksums)
+	return doc, nil
+}
+
+type jsonDocument struct {
+	SPDXVersion       string   `json:"spdxVersion"`
+	DocumentNamespace string   `json:"documentNamespace"`
+	DocumentDescribes []string `json:"documentDescribes"`
+	Packages          []struct {
+		SPDXID    string `json:"SPDXID"`
+		Checksums []struct {
+			Algorithm     string `json:"algorithm"`
+			ChecksumValue string `json:"checksumValue"`
+		} `json:"checksums"`
+	} `json:"packages"`
+	Relationships []struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+}
+
+func parseJSONDocument(b []byte) (*spdxDocument, error) {
+	var d jsonDocument
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("parsing SPDX JSON document: %w", err)
+	}
+
+	described := append([]string{}, d.DocumentDescribes...)
+	for _, r := range d.Relationships {
+		if id, ok := describedBy(r.SPDXElementID, r.RelationshipType, r.RelatedSPDXElement); ok {
+			described = append(described, id)
+		}
+	}
+	var packages []spdxPackage
+	for _, p := range d.Packages {
+		sp := spdxPackage{id: p.SPDXID}
+		for _, c := range p.Checksums {
+			sp.checksums = append(sp.checksums, formatChecksum(c.Algorithm, c.ChecksumValue))
+		}
+		packages = append(packages, sp)
+	}
+	return newDocument(d.SPDXVersion, d.DocumentNamespace, described, packages)
+}
+
+// describedBy returns the element a relationship states the document describes, if any
+func describedBy(element, relationship, related string) (string, bool) {
+	switch {
+	case element == documentID && strings.EqualFold(relationship, "DESCRIBES"):
+		return related, true
+	case related == documentID && strings.EqualFold(relationship, "DESCRIBED_BY"):
+		return element, true
+	}
+	return "", false
+}
+
+func parseTagValueDocument(b []byte) (*spdxDocument, error) {
+	var version, namespace string
+	var described []string
+	var packages []spdxPackage
+	// current indexes the package whose fields are being read, if any
+	current := -1
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 4096), maxLineLength)
+	inText := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		// multi-line values are enclosed in <text>...</text> and carry no fields we need
+		if inText {
+			inText = !strings.Contains(line, "</text>")
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tag, value, ok := splitTagValue(line)
+		if !ok {
+			return nil, fmt.Errorf("invalid SPDX tag-value line %q", line)
+		}
+		if strings.HasPrefix(value, "<text>") && !strings.Contains(value, "</text>") {
+			inText = true
+			continue
+		}
+
+		switch tag {
+		case "SPDXVersion":
+			version = value
+		case "DocumentNamespace":
+			namespace = value
+		case "DocumentDescribes":
+			for _, id := range strings.Split(value, ",") {
+				described = append(described, strings.TrimSpace(id))
+			}
+		case "Relationship":
+			if f := strings.Fields(value); len(f) == 3 {
+				if id, ok := describedBy(f[0], f[1], f[2]); ok {
+					described = append(described, id)
+				}
+			}
+		case "PackageName":
+			packages = append(packages, spdxPackage{})
+			current = len(packages) - 1
+		case "FileName", "SnippetSPDXID", "LicenseID":
+			// these start elements that are not packages
+			current = -1
+		case "SPDXID":
+			if current >= 0 && packages[current].id == "" {
+				packages[current].id = value
+			}
+		case "PackageChecksum":
+			if current < 0 {
+				continue
+			}
+			algorithm, checksum, ok := splitTagValue(value)
+			if !ok {
+				return nil, fmt.Errorf("invalid SPDX package checksum %q", value)
+			}
+			packages[current].checksums = append(packages[current].checksums, formatChecksum(algorithm, checksum))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading SPDX tag-value document: %w", err)
+	}
+	if inText {
+		return nil, errors.New("unterminated <text> value in SPDX tag-value document")
+	}
+	return newDocument(version, namespace, described, packages)
+}
+
+func splitTagValue(s string) (string, string, bool) {
+	i := strings.Index(s, ":")
+	if i <= 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:]), true
+}
@@ -0,0 +1,168 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/types/spdx"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	if err := spdx.VersionMap.SetEntryFactory(APIVERSION, NewEntry); err != nil {
+		log.Logger.Panic(err)
+	}
+}
+
+type V001Entry struct {
+	SpdxObj models.SpdxV001Schema
+	doc     *spdxDocument
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if h := v.SpdxObj.Document.Hash; h != nil && h.Algorithm != nil && h.Value != nil {
+		result = append(result, *h.Algorithm+":"+*h.Value)
+	}
+	if v.doc != nil {
+		result = append(result, v.doc.packageChecksums...)
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	it, ok := pe.(*models.Spdx)
+	if !ok {
+		return errors.New("cannot unmarshal non SPDX v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(it.Spec, &v.SpdxObj); err != nil {
+		return err
+	}
+
+	// field validation
+	if err := v.SpdxObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+
+	return v.validate()
+}
+
+// validate performs cross-field validation for fields in object
+func (v *V001Entry) validate() error {
+	content := v.SpdxObj.Document.Content
+	// This also gets called in the CLI, where we won't have this data
+	if len(content) == 0 {
+		return nil
+	}
+
+	doc, err := parseDocument(content)
+	if err != nil {
+		return err
+	}
+	v.doc = doc
+
+	h := sha256.Sum256(content)
+	v.SpdxObj.Document.Hash = &models.SpdxV001SchemaDocumentHash{
+		Algorithm: swag.String(models.SpdxV001SchemaDocumentHashAlgorithmSha256),
+		Value:     swag.String(hex.EncodeToString(h[:])),
+	}
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if v.doc == nil {
+		return nil, errors.New("SPDX document must be parsed before canonicalizing")
+	}
+
+	canonicalEntry := models.SpdxV001Schema{
+		Document: &models.SpdxV001SchemaDocument{
+			Hash: v.SpdxObj.Document.Hash,
+		},
+		Namespace:        v.doc.namespace,
+		PackageChecksums: v.doc.packageChecksums,
+		ExtraData:        v.SpdxObj.ExtraData,
+	}
+
+	spdxObj := models.Spdx{}
+	spdxObj.APIVersion = swag.String(APIVERSION)
+	spdxObj.Spec = &canonicalEntry
+
+	return json.Marshal(&spdxObj)
+}
+
+func (v V001Entry) Attestation() (string, []byte) {
+	return "", nil
+}
+
+func (v V001Entry) CreateFromArtifactProperties(_ context.Context, props types.ArtifactProperties) (models.ProposedEntry, error) {
+	returnVal := models.Spdx{}
+
+	var err error
+	artifactBytes := props.ArtifactBytes
+	if artifactBytes == nil {
+		if props.ArtifactPath == nil {
+			return nil, errors.New("path to artifact file must be specified")
+		}
+		if props.ArtifactPath.IsAbs() {
+			return nil, errors.New("SPDX documents cannot be fetched over HTTP(S)")
+		}
+		artifactBytes, err = ioutil.ReadFile(filepath.Clean(props.ArtifactPath.Path))
+		if err != nil {
+			return nil, fmt.Errorf("error reading artifact file: %w", err)
+		}
+	}
+
+	re := V001Entry{
+		SpdxObj: models.SpdxV001Schema{
+			Document: &models.SpdxV001SchemaDocument{
+				Content: strfmt.Base64(artifactBytes),
+			},
+		},
+	}
+
+	returnVal.Spec = re.SpdxObj
+	returnVal.APIVersion = swag.String(re.APIVersion())
+
+	return &returnVal, nil
+}
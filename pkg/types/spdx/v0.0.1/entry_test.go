@@ -0,0 +1,224 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"go.uber.org/goleak"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const tagValueSPDXDocument = `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: example
+DocumentNamespace: https://example.com/spdx/example-1.0
+DocumentComment: <text>A comment
+that spans: several lines
+</text>
+Creator: Tool: example
+
+PackageName: example
+SPDXID: SPDXRef-Package-example
+PackageDownloadLocation: https://example.com/example-1.0.tar.gz
+PackageChecksum: SHA256: AB3D0F5A3C2B1E5B0E2A1F99A2C3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8B9C0D1
+PackageChecksum: SHA1: 85ed0817af83a24ad8da68c2b5094de69833983c
+
+PackageName: dependency
+SPDXID: SPDXRef-Package-dependency
+PackageChecksum: SHA256: 1111111111111111111111111111111111111111111111111111111111111111
+
+FileName: ./README
+SPDXID: SPDXRef-File-readme
+FileChecksum: SHA1: 2222222222222222222222222222222222222222
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-example
+Relationship: SPDXRef-Package-example DEPENDS_ON SPDXRef-Package-dependency
+`
+
+const jsonSPDXDocument = `{
+  "spdxVersion": "SPDX-2.2",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "example",
+  "documentNamespace": "https://example.com/spdx/example-1.0",
+  "documentDescribes": ["SPDXRef-Package-example"],
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-example",
+      "name": "example",
+      "checksums": [
+        {"algorithm": "SHA256", "checksumValue": "ab3d0f5a3c2b1e5b0e2a1f99a2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1"},
+        {"algorithm": "SHA1", "checksumValue": "85ed0817af83a24ad8da68c2b5094de69833983c"}
+      ]
+    },
+    {
+      "SPDXID": "SPDXRef-Package-dependency",
+      "name": "dependency",
+      "checksums": [
+        {"algorithm": "SHA256", "checksumValue": "1111111111111111111111111111111111111111111111111111111111111111"}
+      ]
+    }
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-Package-dependency", "relationshipType": "DESCRIBED_BY", "relatedSpdxElement": "SPDXRef-DOCUMENT"}
+  ]
+}`
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       string
+		checksums []string
+		wantErr   bool
+	}{
+		{
+			name: "tag-value",
+			doc:  tagValueSPDXDocument,
+			checksums: []string{
+				"sha1:85ed0817af83a24ad8da68c2b5094de69833983c",
+				"sha256:ab3d0f5a3c2b1e5b0e2a1f99a2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1",
+			},
+		},
+		{
+			name: "json",
+			doc:  jsonSPDXDocument,
+			checksums: []string{
+				"sha1:85ed0817af83a24ad8da68c2b5094de69833983c",
+				"sha256:1111111111111111111111111111111111111111111111111111111111111111",
+				"sha256:ab3d0f5a3c2b1e5b0e2a1f99a2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1",
+			},
+		},
+		{
+			name:    "missing namespace",
+			doc:     "SPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0\n",
+			wantErr: true,
+		},
+		{
+			name:    "not spdx",
+			doc:     "this is not an SPDX document",
+			wantErr: true,
+		},
+		{
+			name:    "wrong version",
+			doc:     `{"spdxVersion": "2.2", "documentNamespace": "https://example.com/spdx"}`,
+			wantErr: true,
+		},
+		{
+			name:    "unterminated text",
+			doc:     "SPDXVersion: SPDX-2.2\nDocumentNamespace: https://example.com/spdx\nDocumentComment: <text>open\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseDocument([]byte(tt.doc))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if doc.namespace != "https://example.com/spdx/example-1.0" {
+				t.Errorf("parseDocument() namespace = %v", doc.namespace)
+			}
+			if !reflect.DeepEqual(doc.packageChecksums, tt.checksums) {
+				t.Errorf("parseDocument() checksums = %v, want %v", doc.packageChecksums, tt.checksums)
+			}
+		})
+	}
+}
+
+func TestV001Entry_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     models.SpdxV001Schema
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			obj:     models.SpdxV001Schema{},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			obj: models.SpdxV001Schema{
+				Document: &models.SpdxV001SchemaDocument{
+					Content: []byte(tagValueSPDXDocument),
+				},
+			},
+		},
+		{
+			name: "invalid document",
+			obj: models.SpdxV001Schema{
+				Document: &models.SpdxV001SchemaDocument{
+					Content: []byte("{}"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &V001Entry{}
+			it := &models.Spdx{
+				APIVersion: swag.String(APIVERSION),
+				Spec:       &tt.obj,
+			}
+			if err := v.Unmarshal(it); (err != nil) != tt.wantErr {
+				t.Fatalf("V001Entry.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			b, err := v.Canonicalize(context.Background())
+			if err != nil {
+				t.Fatalf("V001Entry.Canonicalize() error = %v", err)
+			}
+			pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonical := &V001Entry{}
+			if err := canonical.Unmarshal(pe); err != nil {
+				t.Fatalf("unmarshalling canonical entry: %v", err)
+			}
+			if got, want := canonical.SpdxObj.Namespace, "https://example.com/spdx/example-1.0"; got != want {
+				t.Errorf("canonical namespace = %v, want %v", got, want)
+			}
+			if got := len(v.IndexKeys()); got != 3 {
+				t.Errorf("V001Entry.IndexKeys() = %v", v.IndexKeys())
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package tuf
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -29,6 +30,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/theupdateframework/go-tuf/data"
 	"golang.org/x/sync/errgroup"
 
@@ -80,7 +82,8 @@ func NewEntry() types.EntryImpl {
 func (v V001Entry) IndexKeys() []string {
 	var result []string
 
-	if v.hasExternalEntities() {
+	// entries read back from the log carry the metadata and root inline, but have not been parsed yet
+	if v.hasExternalEntities() || v.sigObj == nil || v.keyObj == nil {
 		if err := v.fetchExternalEntities(context.Background()); err != nil {
 			log.Logger.Error(err)
 			return result
@@ -114,7 +117,8 @@ func (v V001Entry) IndexKeys() []string {
 		result = append(result, strings.ToLower(hex.EncodeToString(rootHash[:])))
 	}
 
-	// TODO: Index individual key IDs
+	// Index individual key IDs
+	result = append(result, pki.IdentityIndexKeys(v.keyObj)...)
 	return result
 }
 
@@ -240,7 +244,11 @@ func (v *V001Entry) fetchExternalEntities(ctx context.Context) error {
 		}
 		defer keyReadCloser.Close()
 
-		key, err := artifactFactory.NewPublicKey(keyReadCloser)
+		policy, err := ptuf.ParseExpiryPolicy(viper.GetString("tuf_expiry_policy"))
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		key, err := ptuf.NewPublicKey(keyReadCloser, ptuf.WithExpiryPolicy(policy))
 		if err != nil {
 			return closePipesOnError(types.ValidationError(err))
 		}
@@ -395,18 +403,14 @@ func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types
 			if err != nil {
 				return nil, fmt.Errorf("error reading root file: %w", err)
 			}
-			s := &data.Signed{}
-			if err := json.Unmarshal(rootBytes, s); err != nil {
+			if re.TufObj.Root.Content, err = rootContent(rootBytes); err != nil {
 				return nil, err
 			}
-			re.TufObj.Root.Content = s
 		}
 	} else {
-		s := &data.Signed{}
-		if err := json.Unmarshal(rootBytes, s); err != nil {
+		if re.TufObj.Root.Content, err = rootContent(rootBytes); err != nil {
 			return nil, err
 		}
-		re.TufObj.Root.Content = s
 	}
 
 	if err := re.Validate(); err != nil {
@@ -424,3 +428,19 @@ func (v V001Entry) CreateFromArtifactProperties(ctx context.Context, props types
 
 	return &returnVal, nil
 }
+
+// rootContent decodes a single root.json, or a JSON array of roots forming a rotation chain
+func rootContent(b []byte) (interface{}, error) {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '[' {
+		var chain []*data.Signed
+		if err := json.Unmarshal(trimmed, &chain); err != nil {
+			return nil, err
+		}
+		return chain, nil
+	}
+	s := &data.Signed{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/go-openapi/swag"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/types"
+	"github.com/spf13/viper"
 	"github.com/theupdateframework/go-tuf/data"
 
 	"go.uber.org/goleak"
@@ -49,6 +50,10 @@ func TestNewEntryReturnType(t *testing.T) {
 }
 
 func TestCrossFieldValidation(t *testing.T) {
+	// the testdata root expired in 2021
+	viper.Set("tuf_expiry_policy", "ignore")
+	t.Cleanup(func() { viper.Set("tuf_expiry_policy", "") })
+
 	type TestCase struct {
 		caseDesc                  string
 		entry                     V001Entry
@@ -18,31 +18,246 @@ package util
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 )
 
+// FetchPolicy restricts the URLs that FileOrURLReadCloser fetches artifacts from. The zero value fetches
+// from any URL without limits.
+type FetchPolicy struct {
+	// Disabled rejects every URL, so that artifacts must be submitted inline
+	Disabled bool
+	// AllowedSchemes are the URL schemes that may be fetched; any scheme supported by net/http if empty
+	AllowedSchemes []string
+	// AllowedHosts are the hosts that may be fetched from, either exact names or "*.domain" for any
+	// subdomain; any host if empty
+	AllowedHosts []string
+	// MaxSize is the largest response body read, in bytes; unlimited if not positive
+	MaxSize int64
+	// Timeout bounds each fetch, including reading the body; unlimited if not positive
+	Timeout time.Duration
+	// BlockLinkLocal refuses to connect to link-local addresses and cloud metadata endpoints
+	BlockLinkLocal bool
+	// BlockPrivate refuses to connect to loopback, private and unspecified addresses
+	BlockPrivate bool
+}
+
+var (
+	fetchPolicyMu sync.RWMutex
+	fetchPolicy   FetchPolicy
+	// fetchClient enforces fetchPolicy, and is shared by all fetches so that connections are reused
+	fetchClient = FetchPolicy{}.client()
+)
+
+// SetFetchPolicy sets the policy for all later calls to FileOrURLReadCloser
+func SetFetchPolicy(p FetchPolicy) {
+	c := p.client()
+	fetchPolicyMu.Lock()
+	defer fetchPolicyMu.Unlock()
+	fetchClient.CloseIdleConnections()
+	fetchPolicy, fetchClient = p, c
+}
+
+func currentFetchPolicy() (FetchPolicy, *http.Client) {
+	fetchPolicyMu.RLock()
+	defer fetchPolicyMu.RUnlock()
+	return fetchPolicy, fetchClient
+}
+
+// cloud metadata endpoints that are not in the link-local ranges
+var metadataAddresses = []net.IP{
+	net.ParseIP("fd00:ec2::254"),   // AWS over IPv6
+	net.ParseIP("100.100.100.200"), // Alibaba Cloud
+}
+
+// checkURL returns an error if the policy does not allow fetching u
+func (p FetchPolicy) checkURL(u *url.URL) error {
+	if p.Disabled {
+		return errors.New("fetching artifacts from URLs is disabled on this server; submit the content instead")
+	}
+	if len(p.AllowedSchemes) > 0 && !containsFold(p.AllowedSchemes, u.Scheme) {
+		return fmt.Errorf("fetching artifacts over %q is not allowed", u.Scheme)
+	}
+	if len(p.AllowedHosts) > 0 && !hostAllowed(p.AllowedHosts, u.Hostname()) {
+		return fmt.Errorf("fetching artifacts from %q is not allowed", u.Hostname())
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, l := range list {
+		if strings.EqualFold(l, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostAllowed(allowed []string, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if strings.HasPrefix(a, "*.") {
+			if strings.HasSuffix(host, a[1:]) {
+				return true
+			}
+		} else if host == a {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIP returns an error if the policy does not allow connecting to ip
+func (p FetchPolicy) checkIP(ip net.IP) error {
+	if p.BlockLinkLocal {
+		if ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+			return fmt.Errorf("connecting to link-local address %v is not allowed", ip)
+		}
+		for _, m := range metadataAddresses {
+			if m.Equal(ip) {
+				return fmt.Errorf("connecting to metadata address %v is not allowed", ip)
+			}
+		}
+	}
+	if p.BlockPrivate && (ip.IsLoopback() || isPrivate(ip) || ip.IsUnspecified()) {
+		return fmt.Errorf("connecting to private address %v is not allowed", ip)
+	}
+	return nil
+}
+
+var privateNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, n, _ := net.ParseCIDR(cidr)
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// isPrivate is net.IP.IsPrivate, which needs Go 1.17, plus the shared address space used for carrier-grade NAT
+func isPrivate(ip net.IP) bool {
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// client returns an HTTP client that enforces the policy on every connection and redirect
+func (p FetchPolicy) client() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		// checked once the address is resolved, so that names resolving to blocked addresses are refused too
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("unexpected address %q", address)
+			}
+			return p.checkIP(ip)
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	if p.BlockLinkLocal || p.BlockPrivate {
+		// through a proxy, only the address of the proxy would be checked rather than that of the artifact
+		transport.Proxy = nil
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return p.checkURL(req.URL)
+		},
+	}
+}
+
+// ErrArtifactTooLarge is returned when reading an artifact larger than the MaxSize of the FetchPolicy
+var ErrArtifactTooLarge = errors.New("artifact is larger than the maximum size allowed by this server")
+
+// maxSizeReader fails once more than n bytes have been read, rather than silently truncating the artifact
+type maxSizeReader struct {
+	r io.Reader
+	n int64
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	m.n -= int64(n)
+	if m.n < 0 {
+		return n, ErrArtifactTooLarge
+	}
+	return n, err
+}
+
+// fetchedBody releases the timeout of the fetch once the body is closed
+type fetchedBody struct {
+	io.Reader
+	body   io.Closer
+	cancel context.CancelFunc
+}
+
+func (f *fetchedBody) Close() error {
+	defer f.cancel()
+	return f.body.Close()
+}
+
 // FileOrURLReadCloser Note: caller is responsible for closing ReadCloser returned from method!
 func FileOrURLReadCloser(ctx context.Context, url string, content []byte) (io.ReadCloser, error) {
 	var dataReader io.ReadCloser
 	if url != "" {
-		//TODO: set timeout here, SSL settings?
-		client := &http.Client{}
+		p, client := currentFetchPolicy()
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return nil, err
 		}
+		if err := p.checkURL(req.URL); err != nil {
+			return nil, err
+		}
+
+		cancel := context.CancelFunc(func() {})
+		if p.Timeout > 0 {
+			var tctx context.Context
+			tctx, cancel = context.WithTimeout(ctx, p.Timeout)
+			req = req.WithContext(tctx)
+		}
 		resp, err := client.Do(req)
 		if err != nil {
+			cancel()
 			return nil, err
 		}
 		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			resp.Body.Close()
+			cancel()
 			return nil, fmt.Errorf("error received while fetching artifact: %v", resp.Status)
 		}
+		if p.MaxSize > 0 && resp.ContentLength > p.MaxSize {
+			resp.Body.Close()
+			cancel()
+			return nil, fmt.Errorf("%w: %d bytes, at most %d", ErrArtifactTooLarge, resp.ContentLength, p.MaxSize)
+		}
 
-		dataReader = resp.Body
+		body := &fetchedBody{Reader: resp.Body, body: resp.Body, cancel: cancel}
+		if p.MaxSize > 0 {
+			body.Reader = &maxSizeReader{r: resp.Body, n: p.MaxSize}
+		}
+		dataReader = body
 	} else {
 		dataReader = ioutil.NopCloser(bytes.NewReader(content))
 	}
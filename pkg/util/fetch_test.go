@@ -0,0 +1,154 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fetch(t *testing.T, p FetchPolicy, u string) (string, error) {
+	t.Helper()
+	SetFetchPolicy(p)
+	defer SetFetchPolicy(FetchPolicy{})
+	rc, err := FileOrURLReadCloser(context.Background(), u, nil)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	return string(b), err
+}
+
+func TestFileOrURLReadCloserPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "http://other.example.com/artifact", http.StatusFound)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte("artifact"))
+		case "/chunked":
+			// no Content-Length, so the size is only known while reading
+			_, _ = w.Write([]byte("art"))
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("ifact"))
+		default:
+			_, _ = w.Write([]byte("artifact"))
+		}
+	}))
+	defer srv.Close()
+	host, _, _ := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+
+	tests := []struct {
+		name    string
+		policy  FetchPolicy
+		path    string
+		wantErr bool
+	}{
+		{name: "no policy", path: "/"},
+		{name: "disabled", policy: FetchPolicy{Disabled: true}, path: "/", wantErr: true},
+		{name: "allowed scheme", policy: FetchPolicy{AllowedSchemes: []string{"HTTP"}}, path: "/"},
+		{name: "scheme not allowed", policy: FetchPolicy{AllowedSchemes: []string{"https"}}, path: "/", wantErr: true},
+		{name: "allowed host", policy: FetchPolicy{AllowedHosts: []string{host}}, path: "/"},
+		{name: "host not allowed", policy: FetchPolicy{AllowedHosts: []string{"*.example.com"}}, path: "/", wantErr: true},
+		{name: "redirect to host not allowed", policy: FetchPolicy{AllowedHosts: []string{host}}, path: "/redirect", wantErr: true},
+		{name: "private address", policy: FetchPolicy{BlockPrivate: true}, path: "/", wantErr: true},
+		{name: "link-local only", policy: FetchPolicy{BlockLinkLocal: true}, path: "/"},
+		{name: "within max size", policy: FetchPolicy{MaxSize: 8}, path: "/"},
+		{name: "too large", policy: FetchPolicy{MaxSize: 7}, path: "/", wantErr: true},
+		{name: "too large while reading", policy: FetchPolicy{MaxSize: 7}, path: "/chunked", wantErr: true},
+		{name: "timeout", policy: FetchPolicy{Timeout: 50 * time.Millisecond}, path: "/slow", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fetch(t, tt.policy, srv.URL+tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FileOrURLReadCloser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != "artifact" {
+				t.Errorf("got %q", got)
+			}
+		})
+	}
+}
+
+func TestFetchPolicyClient(t *testing.T) {
+	SetFetchPolicy(FetchPolicy{BlockLinkLocal: true})
+	defer SetFetchPolicy(FetchPolicy{})
+	_, c1 := currentFetchPolicy()
+	_, c2 := currentFetchPolicy()
+	if c1 != c2 {
+		t.Error("a new client was created for each fetch")
+	}
+	if c1.Transport.(*http.Transport).Proxy != nil {
+		t.Error("proxy is used although addresses are checked")
+	}
+
+	SetFetchPolicy(FetchPolicy{})
+	if _, c := currentFetchPolicy(); c.Transport.(*http.Transport).Proxy == nil {
+		t.Error("proxy is not used without address checks")
+	}
+}
+
+func TestFetchPolicyCheckIP(t *testing.T) {
+	p := FetchPolicy{BlockLinkLocal: true, BlockPrivate: true}
+	for ip, blocked := range map[string]bool{
+		"169.254.169.254": true,
+		"fe80::1":         true,
+		"fd00:ec2::254":   true,
+		"100.100.100.200": true,
+		"127.0.0.1":       true,
+		"::1":             true,
+		"10.1.2.3":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"0.0.0.0":         true,
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+	} {
+		if err := p.checkIP(net.ParseIP(ip)); (err != nil) != blocked {
+			t.Errorf("checkIP(%s) = %v, want blocked %v", ip, err, blocked)
+		}
+	}
+	if err := (FetchPolicy{BlockLinkLocal: true}).checkIP(net.ParseIP("10.1.2.3")); err != nil {
+		t.Errorf("private address blocked without BlockPrivate: %v", err)
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	allowed := []string{"artifacts.example.com", "*.example.org"}
+	for host, want := range map[string]bool{
+		"artifacts.example.com":  true,
+		"ARTIFACTS.example.com.": true,
+		"other.example.com":      false,
+		"a.b.example.org":        true,
+		"example.org":            false,
+		"evilexample.org":        false,
+	} {
+		u := &url.URL{Scheme: "https", Host: host}
+		if got := hostAllowed(allowed, u.Hostname()); got != want {
+			t.Errorf("hostAllowed(%s) = %v, want %v", host, got, want)
+		}
+	}
+}
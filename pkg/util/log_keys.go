@@ -0,0 +1,119 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// headers of the PEM blocks in a bundle of log keys
+const (
+	logKeyLogID       = "Log-ID"
+	logKeyTreeID      = "Tree-ID"
+	logKeyActiveFrom  = "Active-From"
+	logKeyActiveUntil = "Active-Until"
+)
+
+// LogKey is a key that a log has signed entries and tree heads with, and when
+type LogKey struct {
+	PublicKey crypto.PublicKey
+	// LogID is the hex-encoded SHA256 digest of the DER-encoded key, as found in the logID of the entries it signed
+	LogID string
+	// TreeID is the log tree the key signed for; 0 if unknown
+	TreeID int64
+	// ActiveFrom and ActiveUntil bound the time the key was used; zero if unbounded
+	ActiveFrom, ActiveUntil time.Time
+}
+
+// ActiveAt reports whether the key was in use at t
+func (k LogKey) ActiveAt(t time.Time) bool {
+	return (k.ActiveFrom.IsZero() || !t.Before(k.ActiveFrom)) && (k.ActiveUntil.IsZero() || t.Before(k.ActiveUntil))
+}
+
+// MarshalPEM encodes the key as a PEM block whose headers hold its log ID, tree ID and validity window
+func (k LogKey) MarshalPEM() ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(k.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{logKeyLogID: logID(der)}
+	if k.TreeID != 0 {
+		headers[logKeyTreeID] = strconv.FormatInt(k.TreeID, 10)
+	}
+	if !k.ActiveFrom.IsZero() {
+		headers[logKeyActiveFrom] = k.ActiveFrom.UTC().Format(time.RFC3339)
+	}
+	if !k.ActiveUntil.IsZero() {
+		headers[logKeyActiveUntil] = k.ActiveUntil.UTC().Format(time.RFC3339)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Headers: headers, Bytes: der}), nil
+}
+
+func logID(der []byte) string {
+	h := sha256.Sum256(der)
+	return hex.EncodeToString(h[:])
+}
+
+// ParseLogKeys parses a bundle of PEM encoded log keys, as returned by /api/v1/log/publicKey?history=true.
+// Headers missing from a block are left zero, except for the log ID, which is computed from the key.
+func ParseLogKeys(data []byte) ([]LogKey, error) {
+	var keys []LogKey
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q in log keys", block.Type)
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing log key: %w", err)
+		}
+		k := LogKey{PublicKey: pub, LogID: logID(block.Bytes)}
+		if id, ok := block.Headers[logKeyLogID]; ok && !strings.EqualFold(id, k.LogID) {
+			return nil, fmt.Errorf("log key with log ID %s has a %s header of %s", k.LogID, logKeyLogID, id)
+		}
+		if v, ok := block.Headers[logKeyTreeID]; ok {
+			if k.TreeID, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("log key %s: invalid %s: %w", k.LogID, logKeyTreeID, err)
+			}
+		}
+		if v, ok := block.Headers[logKeyActiveFrom]; ok {
+			if k.ActiveFrom, err = time.Parse(time.RFC3339, v); err != nil {
+				return nil, fmt.Errorf("log key %s: invalid %s: %w", k.LogID, logKeyActiveFrom, err)
+			}
+		}
+		if v, ok := block.Headers[logKeyActiveUntil]; ok {
+			if k.ActiveUntil, err = time.Parse(time.RFC3339, v); err != nil {
+				return nil, fmt.Errorf("log key %s: invalid %s: %w", k.LogID, logKeyActiveUntil, err)
+			}
+		}
+		if !k.ActiveFrom.IsZero() && !k.ActiveUntil.IsZero() && !k.ActiveFrom.Before(k.ActiveUntil) {
+			return nil, fmt.Errorf("log key %s is active until %v, before it is active from %v", k.LogID, k.ActiveUntil, k.ActiveFrom)
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no log keys found")
+	}
+	return keys, nil
+}
@@ -0,0 +1,114 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLogKey(t *testing.T) (*ecdsa.PublicKey, string) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(der)
+	return &priv.PublicKey, hex.EncodeToString(h[:])
+}
+
+func TestLogKeysRoundTrip(t *testing.T) {
+	pub1, id1 := newLogKey(t)
+	pub2, id2 := newLogKey(t)
+	rotated := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	keys := []LogKey{
+		{PublicKey: pub2, TreeID: 2, ActiveFrom: rotated},
+		{PublicKey: pub1, TreeID: 1, ActiveUntil: rotated},
+	}
+	var bundle []byte
+	for _, k := range keys {
+		b, err := k.MarshalPEM()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle = append(bundle, b...)
+	}
+
+	got, err := ParseLogKeys(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d keys, want 2", len(got))
+	}
+	if got[0].LogID != id2 || got[0].TreeID != 2 || !got[0].ActiveFrom.Equal(rotated) || !got[0].ActiveUntil.IsZero() {
+		t.Errorf("current key = %+v", got[0])
+	}
+	if got[1].LogID != id1 || got[1].TreeID != 1 || !got[1].ActiveFrom.IsZero() || !got[1].ActiveUntil.Equal(rotated) {
+		t.Errorf("previous key = %+v", got[1])
+	}
+	if !got[1].PublicKey.(*ecdsa.PublicKey).Equal(pub1) {
+		t.Error("previous key does not round trip")
+	}
+
+	before, after := rotated.Add(-time.Second), rotated
+	if !got[1].ActiveAt(before) || got[1].ActiveAt(after) || got[0].ActiveAt(before) || !got[0].ActiveAt(after) {
+		t.Error("ActiveAt does not split the keys at the rotation")
+	}
+}
+
+func TestParseLogKeysErrors(t *testing.T) {
+	pub, _ := newLogKey(t)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := func(headers map[string]string) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Headers: headers, Bytes: der}))
+	}
+
+	tests := map[string]string{
+		"empty":          "",
+		"certificate":    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		"wrong log ID":   block(map[string]string{logKeyLogID: strings.Repeat("0", 64)}),
+		"bad tree ID":    block(map[string]string{logKeyTreeID: "tree"}),
+		"bad time":       block(map[string]string{logKeyActiveUntil: "yesterday"}),
+		"inverted range": block(map[string]string{logKeyActiveFrom: "2021-06-01T00:00:00Z", logKeyActiveUntil: "2021-01-01T00:00:00Z"}),
+	}
+	for name, data := range tests {
+		if _, err := ParseLogKeys([]byte(data)); err == nil {
+			t.Errorf("%s: ParseLogKeys() succeeded, want error", name)
+		}
+	}
+
+	// a key without headers is a key of unknown tree that is always active
+	keys, err := ParseLogKeys([]byte(block(nil)))
+	if err != nil || len(keys) != 1 || !keys[0].ActiveAt(time.Now()) {
+		t.Errorf("ParseLogKeys() without headers = %+v, %v", keys, err)
+	}
+}
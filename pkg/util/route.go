@@ -0,0 +1,30 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package util
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Route returns the method and path of r as the OpenAPI router looks them up: the method is upper-cased
+// and the escaped path is cleaned. Middlewares that run before routing use it so that they treat a request
+// like the endpoint it is dispatched to, however its method and path are spelled.
+func Route(r *http.Request) (string, string) {
+	return strings.ToUpper(r.Method), path.Clean(r.URL.EscapedPath())
+}
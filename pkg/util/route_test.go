@@ -0,0 +1,40 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package util
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute(t *testing.T) {
+	for _, test := range []struct {
+		method, target string
+	}{
+		{"POST", "/api/v1/log/entries"},
+		{"post", "/api/v1/log/entries"},
+		{"POST", "/api/v1//log/entries"},
+		{"POST", "/api/v1/log/./entries"},
+		{"POST", "/api/v1/log/entries/"},
+		{"POST", "/api/v1/log/x/../entries?apiKey=key"},
+	} {
+		method, path := Route(httptest.NewRequest(test.method, test.target, nil))
+		if method != "POST" || path != "/api/v1/log/entries" {
+			t.Errorf("Route(%s %s) = %s %s", test.method, test.target, method, path)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	// algEd25519 is a witness signing the checkpoint text directly, as in golang.org/x/mod/sumdb/note
+	algEd25519 byte = 0x01
+	// algCosignatureV1 is a witness following https://c2sp.org/tlog-cosignature
+	algCosignatureV1 byte = 0x04
+)
+
+// Witness is a verifier for the cosignatures of a checkpoint witness
+type Witness struct {
+	Name    string
+	KeyHash uint32
+	alg     byte
+	key     ed25519.PublicKey
+}
+
+// ParseWitness parses a witness verifier key in the signed note format:
+// <name>+<hex encoded key hash>+<base64 encoded algorithm byte and public key>
+func ParseWitness(vkey string) (*Witness, error) {
+	parts := strings.SplitN(vkey, "+", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, errors.New("malformed witness key")
+	}
+	name := parts[0]
+	hashBytes, err := hex.DecodeString(parts[1])
+	if err != nil || len(hashBytes) != 4 {
+		return nil, errors.New("malformed witness key hash")
+	}
+	keyBytes, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil || len(keyBytes) != 1+ed25519.PublicKeySize {
+		return nil, errors.New("malformed witness public key")
+	}
+	if keyBytes[0] != algEd25519 && keyBytes[0] != algCosignatureV1 {
+		return nil, fmt.Errorf("unsupported witness key algorithm %d", keyBytes[0])
+	}
+	w := &Witness{
+		Name:    name,
+		KeyHash: binary.BigEndian.Uint32(hashBytes),
+		alg:     keyBytes[0],
+		key:     ed25519.PublicKey(keyBytes[1:]),
+	}
+	if w.KeyHash != witnessKeyHash(name, keyBytes) {
+		return nil, errors.New("witness key hash does not match public key")
+	}
+	return w, nil
+}
+
+func witnessKeyHash(name string, key []byte) uint32 {
+	h := sha256.New()
+	h.Write([]byte(name))
+	h.Write([]byte("\n"))
+	h.Write(key)
+	sum := h.Sum(nil)
+	return binary.BigEndian.Uint32(sum)
+}
+
+// Verify checks that sig is a valid cosignature by the witness over the checkpoint text
+func (w Witness) Verify(checkpoint string, sig note.Signature) bool {
+	if sig.Name != w.Name || sig.Hash != w.KeyHash {
+		return false
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(sig.Base64)
+	if err != nil {
+		return false
+	}
+	switch w.alg {
+	case algEd25519:
+		return ed25519.Verify(w.key, []byte(checkpoint), sigBytes)
+	case algCosignatureV1:
+		// the signature is prefixed with the big-endian timestamp at which the witness cosigned
+		if len(sigBytes) != 8+ed25519.SignatureSize {
+			return false
+		}
+		timestamp := binary.BigEndian.Uint64(sigBytes[:8])
+		msg := fmt.Sprintf("cosignature/v1\ntime %d\n%s", timestamp, checkpoint)
+		return ed25519.Verify(w.key, []byte(msg), sigBytes[8:])
+	default:
+		return false
+	}
+}
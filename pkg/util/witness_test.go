@@ -0,0 +1,129 @@
+//
+// Copyright 2021 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"golang.org/x/mod/sumdb/note"
+)
+
+const testCheckpoint = "rekor.example.com - 1\n10\nYmFuYW5hcw==\n"
+
+// cosignV1 returns a vkey and a signed note with a https://c2sp.org/tlog-cosignature signature
+func cosignV1(t *testing.T, name string, checkpoint string, timestamp uint64) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := append([]byte{algCosignatureV1}, pub...)
+	hash := witnessKeyHash(name, key)
+	vkey := fmt.Sprintf("%s+%08x+%s", name, hash, base64.StdEncoding.EncodeToString(key))
+
+	msg := fmt.Sprintf("cosignature/v1\ntime %d\n%s", timestamp, checkpoint)
+	sig := make([]byte, 12)
+	binary.BigEndian.PutUint32(sig, hash)
+	binary.BigEndian.PutUint64(sig[4:], timestamp)
+	sig = append(sig, ed25519.Sign(priv, []byte(msg))...)
+	return vkey, fmt.Sprintf("%s\n— %s %s\n", checkpoint, name, base64.StdEncoding.EncodeToString(sig))
+}
+
+func TestWitnessEd25519(t *testing.T) {
+	skey, vkey, err := note.GenerateKey(rand.Reader, "witness.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := note.Sign(&note.Note{Text: testCheckpoint}, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := ParseWitness(vkey)
+	if err != nil {
+		t.Fatalf("ParseWitness(%v) = %v", vkey, err)
+	}
+	sn := SignedNote{}
+	if err := sn.UnmarshalText(msg); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Verify(sn.Note, sn.Signatures[0]) {
+		t.Error("valid cosignature failed to verify")
+	}
+	if w.Verify("rekor.example.com - 1\n11\nYmFuYW5hcw==\n", sn.Signatures[0]) {
+		t.Error("cosignature verified against a different checkpoint")
+	}
+}
+
+func TestWitnessCosignatureV1(t *testing.T) {
+	vkey, msg := cosignV1(t, "witness.example.com", testCheckpoint, 1631053125)
+
+	w, err := ParseWitness(vkey)
+	if err != nil {
+		t.Fatalf("ParseWitness(%v) = %v", vkey, err)
+	}
+	sn := SignedNote{}
+	if err := sn.UnmarshalText([]byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+	if sn.Signatures[0].Hash != w.KeyHash {
+		t.Fatalf("key hash %08x, want %08x", sn.Signatures[0].Hash, w.KeyHash)
+	}
+	if !w.Verify(sn.Note, sn.Signatures[0]) {
+		t.Error("valid cosignature failed to verify")
+	}
+
+	other, _ := cosignV1(t, "witness.example.com", testCheckpoint, 1631053125)
+	ow, err := ParseWitness(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ow.Verify(sn.Note, sn.Signatures[0]) {
+		t.Error("cosignature verified with the wrong key")
+	}
+}
+
+func TestParseWitnessInvalid(t *testing.T) {
+	_, vkey, err := note.GenerateKey(rand.Reader, "witness.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		desc string
+		vkey string
+	}{
+		{desc: "empty", vkey: ""},
+		{desc: "missing key", vkey: "witness.example.com+12345678"},
+		{desc: "bad hash", vkey: "witness.example.com+zzzzzzzz+AQ=="},
+		{desc: "wrong name", vkey: "other" + vkey[len("witness.example.com"):]},
+		{desc: "short key", vkey: "witness.example.com+12345678+AQ=="},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := ParseWitness(test.vkey); err == nil {
+				t.Errorf("ParseWitness(%q) succeeded, want error", test.vkey)
+			}
+		})
+	}
+}